@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+// followInterval is how often --follow checks the input for new results.
+const followInterval = 500 * time.Millisecond
+
+var (
+	aggregateProject string
+	aggregateVersion string
+	aggregatePhase   string
+	aggregateOutput  string
+	aggregateFollow  bool
+	aggregateTeam    string
+)
+
+func init() {
+	rootCmd.AddCommand(aggregateCmd)
+
+	aggregateCmd.Flags().StringVar(&aggregateProject, "project", "", "Project name for the report")
+	aggregateCmd.Flags().StringVar(&aggregateVersion, "version", "", "Version or target the report covers")
+	aggregateCmd.Flags().StringVar(&aggregatePhase, "phase", "", "Workflow phase for the report")
+	aggregateCmd.Flags().StringVarP(&aggregateOutput, "output", "o", "", "Write the report to file instead of stdout, rewriting it after each result with --follow")
+	aggregateCmd.Flags().BoolVarP(&aggregateFollow, "follow", "f", false, "Keep reading as results are appended to the file, until interrupted")
+	aggregateCmd.Flags().StringVar(&aggregateTeam, "team", "", "Team file whose workflow ports the results' inputs and outputs are checked against")
+}
+
+var aggregateCmd = &cobra.Command{
+	Use:   "aggregate [results.ndjson]",
+	Short: "Build a TeamReport from newline-delimited AgentResult JSON",
+	Long: `Read AgentResults, one JSON object per line, and aggregate them into a
+TeamReport with one team per step. A later result for the same step
+replaces the earlier one, so retried steps report their latest result.
+
+If no file is provided, reads from stdin. The report is written as JSON
+to stdout or --output once the input ends. With --follow, the command
+keeps reading as a coordinator appends results, like tail -f, and updates
+the report after each one: --output is rewritten in place, or stdout gets
+one compact report per line. Interrupt it to stop.
+
+With --team, each result is checked against its step's ports: declared
+outputs must be present and match the port's type and schema, and
+reported inputs must match what upstream steps produced. Violations are
+added to the step's team as a contract task, NO-GO for missing or
+mistyped values and WARN for inputs that differ.
+
+Examples:
+  # Aggregate finished results and render them
+  mas aggregate --project=my-app --version=v1.2.0 results.ndjson | mas render
+
+  # Keep report.json current while a coordinator runs
+  mas aggregate --follow --project=my-app --version=v1.2.0 -o report.json results.ndjson
+
+  # Check results against the team's workflow contract
+  mas aggregate --team team.json --project=my-app results.ndjson`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAggregate,
+}
+
+func runAggregate(cmd *cobra.Command, args []string) error {
+	var in io.Reader = os.Stdin
+	if len(args) > 0 {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return fmt.Errorf("opening results: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	agg := multiagentspec.NewStreamAggregator(aggregateProject, aggregateVersion, aggregatePhase)
+	if aggregateTeam != "" {
+		loader := multiagentspec.NewLoader(multiagentspec.WithLogger(logger))
+		team, err := loader.LoadTeam(aggregateTeam)
+		if err != nil {
+			return fmt.Errorf("loading team: %w", err)
+		}
+		if team.Workflow != nil {
+			agg.CheckContracts(team.Workflow)
+		}
+	}
+	if !aggregateFollow {
+		if err := agg.ReadNDJSON(in, nil); err != nil {
+			return fmt.Errorf("reading results: %w", err)
+		}
+		return writeAggregate(agg.Report(), false)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	err := agg.ReadNDJSON(&followReader{ctx: ctx, r: in}, func(report *multiagentspec.TeamReport) error {
+		return writeAggregate(report, true)
+	})
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("reading results: %w", err)
+	}
+	return nil
+}
+
+// writeAggregate writes report to --output, replacing the file atomically,
+// or to stdout, compactly when following so each update is one line.
+func writeAggregate(report *multiagentspec.TeamReport, follow bool) error {
+	var data []byte
+	var err error
+	if follow && aggregateOutput == "" {
+		data, err = json.Marshal(report)
+	} else {
+		data, err = report.ToJSON()
+	}
+	if err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+	data = append(data, '\n')
+	if aggregateOutput == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(aggregateOutput), ".mas-report-*")
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), aggregateOutput); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
+
+// followReader reads from r, waiting for more data at EOF instead of
+// returning it, until ctx is done.
+type followReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		if n > 0 || (err != nil && err != io.EOF) {
+			return n, err
+		}
+		select {
+		case <-f.ctx.Done():
+			return 0, f.ctx.Err()
+		case <-time.After(followInterval):
+		}
+	}
+}
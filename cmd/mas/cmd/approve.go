@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"text/tabwriter"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/audit"
+	"github.com/plexusone/multi-agent-spec/sdk/go/reportstore"
+	"github.com/spf13/cobra"
+)
+
+var (
+	approveStore   string
+	approveAs      string
+	approveComment string
+	approveAudit   string
+	approveKeyEnv  string
+)
+
+func init() {
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(rejectCmd)
+
+	for _, c := range []*cobra.Command{approveCmd, rejectCmd} {
+		c.Flags().StringVar(&approveStore, "store", "", "Report store the run keeps its approvals in (required)")
+		c.Flags().StringVar(&approveAs, "as", "", "Who is deciding (default: $MAS_APPROVER, then the current user)")
+		c.Flags().StringVarP(&approveComment, "comment", "m", "", "Note to record with the decision")
+		c.Flags().StringVar(&approveAudit, "audit-log", "", "Append the decision to this audit log (JSON Lines)")
+		c.Flags().StringVar(&approveKeyEnv, "audit-hmac-key-env", "", "Environment variable holding the HMAC-SHA256 key that signs --audit-log entries")
+		_ = c.MarkFlagRequired("store")
+	}
+}
+
+var approveCmd = &cobra.Command{
+	Use:   "approve --store <store> <run> [gate]",
+	Short: "Approve a paused run's approval gate",
+	Long: `Approve an approval gate that mas run --store is waiting on, so the
+gated step runs when the run is resumed. A gate is a task with
+human_in_loop, named step.task. Without a gate, the run's approvals are
+listed.
+
+The decision is recorded with who made it and when. It fails if the gate
+was already decided, has expired, or the run restricts approvers with
+--approvers and you are not one of them. With --audit-log, the decision is
+also appended to the run's audit log as an approval_granted or
+approval_rejected entry.
+
+Examples:
+  # See what a run is waiting on
+  mas approve --store .mas release-42
+
+  # Approve, then resume the run
+  mas approve --store .mas release-42 deploy.sign-off -m "release notes checked"
+  mas run team.json --store .mas --run release-42`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDecide(cmd, args, multiagentspec.ApprovalApproved)
+	},
+}
+
+var rejectCmd = &cobra.Command{
+	Use:   "reject --store <store> <run> <gate>",
+	Short: "Reject a paused run's approval gate",
+	Long: `Reject an approval gate that mas run --store is waiting on: when the run
+is resumed, the gated step is NO-GO and the steps after it are skipped.
+See mas approve.
+
+Examples:
+  mas reject --store .mas release-42 deploy.sign-off -m "wait for the security fix"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDecide(cmd, args, multiagentspec.ApprovalRejected)
+	},
+}
+
+func runDecide(cmd *cobra.Command, args []string, decision multiagentspec.ApprovalDecision) error {
+	store, err := reportstore.OpenURL(cmd.Context(), approveStore)
+	if err != nil {
+		return err
+	}
+	run := args[0]
+	if len(args) == 1 {
+		return listApprovals(cmd, store, run)
+	}
+	a, err := store.GetApproval(cmd.Context(), run, args[1])
+	if err != nil {
+		return err
+	}
+	by, err := approver()
+	if err != nil {
+		return err
+	}
+	// Open the audit log first so a missing key fails before the decision
+	var log *audit.Log
+	if approveAudit != "" {
+		if log, err = openAuditLog(approveAudit, approveKeyEnv); err != nil {
+			return err
+		}
+		defer log.Close()
+	}
+	if err := a.Decide(by, decision, approveComment, time.Now()); err != nil {
+		return err
+	}
+	if err := store.PutApproval(cmd.Context(), a); err != nil {
+		return err
+	}
+	if log != nil {
+		entry, err := audit.ApprovalEntry(a)
+		if err != nil {
+			return err
+		}
+		if _, err := log.Append(entry); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%s %s in run %s\n", a.Decision, a.Gate, run)
+	return nil
+}
+
+// listApprovals prints the run's approvals as a table.
+func listApprovals(cmd *cobra.Command, store *reportstore.Store, run string) error {
+	approvals, err := store.Approvals(cmd.Context(), run)
+	if err != nil {
+		return err
+	}
+	if len(approvals) == 0 {
+		return fmt.Errorf("run %s has no approvals", run)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "GATE\tDECISION\tBY\tREQUEST")
+	now := time.Now()
+	for _, a := range approvals {
+		decision := string(a.Decision)
+		if a.Expired(now) {
+			decision = "expired"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Gate, decision, a.DecidedBy, a.Request)
+	}
+	return w.Flush()
+}
+
+// approver returns who is deciding: --as, $MAS_APPROVER, or the current
+// user.
+func approver() (string, error) {
+	if approveAs != "" {
+		return approveAs, nil
+	}
+	if name := os.Getenv("MAS_APPROVER"); name != "" {
+		return name, nil
+	}
+	u, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("finding the current user: %w (set --as)", err)
+	}
+	return u.Username, nil
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/plexusone/multi-agent-spec/sdk/go/audit"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditHMACKeyEnv string
+	auditPublicKey  string
+	auditFormat     string
+	auditOutput     string
+	auditRun        string
+	auditTeam       string
+	auditStep       string
+	auditAgent      string
+	auditTypes      []string
+	auditSince      string
+	auditUntil      string
+)
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditVerifyCmd)
+	auditCmd.AddCommand(auditExportCmd)
+
+	auditCmd.PersistentFlags().StringVar(&auditHMACKeyEnv, "hmac-key-env", "", "Environment variable holding the HMAC-SHA256 signing key")
+	auditCmd.PersistentFlags().StringVar(&auditPublicKey, "public-key", "", "File holding the hex Ed25519 public key the log is signed with")
+
+	auditExportCmd.Flags().StringVar(&auditFormat, "format", audit.FormatJSONL, "Export format: jsonl or csv")
+	auditExportCmd.Flags().StringVarP(&auditOutput, "output", "o", "", "Write export to file instead of stdout")
+	auditExportCmd.Flags().StringVar(&auditRun, "run", "", "Only entries for this run")
+	auditExportCmd.Flags().StringVar(&auditTeam, "team", "", "Only entries for this team")
+	auditExportCmd.Flags().StringVar(&auditStep, "step", "", "Only entries for this step")
+	auditExportCmd.Flags().StringVar(&auditAgent, "agent", "", "Only entries for this agent")
+	auditExportCmd.Flags().StringSliceVar(&auditTypes, "type", nil, "Only entries of this event type (repeatable)")
+	auditExportCmd.Flags().StringVar(&auditSince, "since", "", "Only entries at or after this RFC 3339 time")
+	auditExportCmd.Flags().StringVar(&auditUntil, "until", "", "Only entries before this RFC 3339 time")
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Verify and export audit logs",
+	Long: `Verify and export append-only audit logs of run events.
+
+Audit logs are JSON Lines files of hash-chained entries. With --hmac-key-env
+or --public-key, entry signatures are checked as well; otherwise only the
+hash chain is.`,
+}
+
+var auditVerifyCmd = &cobra.Command{
+	Use:   "verify <audit.jsonl>",
+	Short: "Check an audit log for tampering",
+	Long: `Check that an audit log's entries are complete, in order, unmodified,
+and, given a key, validly signed. Exits non-zero on the first broken entry.
+
+Examples:
+  # Check the hash chain only
+  mas audit verify runs/audit.jsonl
+
+  # Check HMAC signatures with the key in $MAS_AUDIT_KEY
+  mas audit verify --hmac-key-env MAS_AUDIT_KEY runs/audit.jsonl
+
+  # Check Ed25519 signatures
+  mas audit verify --public-key audit.pub runs/audit.jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuditVerify,
+}
+
+var auditExportCmd = &cobra.Command{
+	Use:   "export <audit.jsonl>",
+	Short: "Export audit log entries for review",
+	Long: `Verify an audit log and export its entries, optionally filtered, as JSON
+Lines or CSV.
+
+Examples:
+  # Export one run's approvals as CSV
+  mas audit export --run r-42 --type approval_granted --type approval_rejected --format csv runs/audit.jsonl
+
+  # Export a month of entries to a file
+  mas audit export --since 2026-03-01T00:00:00Z --until 2026-04-01T00:00:00Z -o march.jsonl runs/audit.jsonl`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAuditExport,
+}
+
+func runAuditVerify(cmd *cobra.Command, args []string) error {
+	entries, signer, err := loadAuditLog(args[0])
+	if err != nil {
+		return err
+	}
+	checked := "hash chain"
+	if signer != nil {
+		checked = "hash chain and signatures"
+	}
+	fmt.Fprintf(os.Stdout, "%s: %d entries, %s valid\n", args[0], len(entries), checked)
+	return nil
+}
+
+func runAuditExport(cmd *cobra.Command, args []string) error {
+	filter := audit.Filter{Run: auditRun, Team: auditTeam, Step: auditStep, Agent: auditAgent}
+	for _, t := range auditTypes {
+		if !isAuditEventType(t) {
+			return fmt.Errorf("unknown event type %q", t)
+		}
+		filter.Types = append(filter.Types, audit.EventType(t))
+	}
+	var err error
+	if filter.Since, err = parseAuditTime("--since", auditSince); err != nil {
+		return err
+	}
+	if filter.Until, err = parseAuditTime("--until", auditUntil); err != nil {
+		return err
+	}
+
+	entries, _, err := loadAuditLog(args[0])
+	if err != nil {
+		return err
+	}
+
+	w := os.Stdout
+	if auditOutput != "" {
+		f, err := os.Create(auditOutput)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	return audit.Export(w, audit.Query(entries, filter), auditFormat)
+}
+
+// loadAuditLog reads and verifies the log at path with the signer named by
+// the flags, if any.
+func loadAuditLog(path string) ([]audit.Entry, audit.Signer, error) {
+	signer, err := auditSigner()
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := audit.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := audit.Verify(entries, signer); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	logger.Debug("verified audit log", "path", path, "entries", len(entries), "signed", signer != nil)
+	return entries, signer, nil
+}
+
+func auditSigner() (audit.Signer, error) {
+	switch {
+	case auditHMACKeyEnv != "" && auditPublicKey != "":
+		return nil, fmt.Errorf("--hmac-key-env and --public-key are mutually exclusive")
+	case auditHMACKeyEnv != "":
+		key := os.Getenv(auditHMACKeyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("%s is not set", auditHMACKeyEnv)
+		}
+		return audit.NewHMACSigner([]byte(key)), nil
+	case auditPublicKey != "":
+		data, err := os.ReadFile(auditPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("reading public key: %w", err)
+		}
+		key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%s: not a hex Ed25519 public key", auditPublicKey)
+		}
+		return audit.NewEd25519Verifier(key), nil
+	}
+	return nil, nil
+}
+
+func isAuditEventType(s string) bool {
+	for _, t := range audit.EventTypes() {
+		if string(t) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func parseAuditTime(flag, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", flag, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var bundleOutput string
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+
+	bundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Zip file to write (default: the report's name with .zip)")
+}
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle <report>",
+	Short: "Pack a report and its artifact files into a zip archive",
+	Long: `Write a zip archive holding a TeamReport (JSON or YAML) as report.json
+and the files its teams' artifacts point at, so the evidence behind the
+results travels with them, e.g., as one CI artifact.
+
+Artifact paths are read relative to the report's directory unless
+absolute. In the archive, the files are stored under artifacts/<team>/
+and the report's paths point at them, so it can be unpacked and rendered
+anywhere. Artifacts with only a URL are kept as links. mas bundle fails if
+an artifact file is missing.
+
+Examples:
+  # Write report.zip next to the report
+  mas bundle report.json
+
+  # Bundle for upload, then render the unpacked copy
+  mas bundle report.json -o evidence.zip
+  unzip evidence.zip -d evidence && mas render --format=narrative evidence/report.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBundle,
+}
+
+func runBundle(cmd *cobra.Command, args []string) error {
+	report, err := multiagentspec.LoadTeamReportFromFile(args[0])
+	if err != nil {
+		return fmt.Errorf("loading report: %w", err)
+	}
+	output := bundleOutput
+	if output == "" {
+		output = strings.TrimSuffix(args[0], filepath.Ext(args[0])) + ".zip"
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := multiagentspec.WriteBundle(f, report, filepath.Dir(args[0])); err != nil {
+		f.Close()
+		os.Remove(output)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	artifacts := 0
+	for _, t := range report.Teams {
+		artifacts += len(t.Artifacts)
+	}
+	fmt.Fprintf(os.Stderr, "wrote %s (%d artifacts)\n", output, artifacts)
+	return nil
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/deploy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deployTeam    string
+	deployAgents  string
+	deployTargets []string
+	deployOutput  string
+	deployEnv     string
+	deployDryRun  bool
+	deployJSON    bool
+)
+
+func init() {
+	rootCmd.AddCommand(deployCmd)
+	deployCmd.AddCommand(deployGenerateCmd)
+	deployCmd.AddCommand(deployDiffCmd)
+
+	for _, cmd := range []*cobra.Command{deployGenerateCmd, deployDiffCmd} {
+		cmd.Flags().StringVar(&deployTeam, "team", "", "Team definition JSON (default: team.json next to the deployment)")
+		cmd.Flags().StringVar(&deployAgents, "agents", "", "Directory of agent markdown files (default: agents/ next to the deployment)")
+		cmd.Flags().StringSliceVar(&deployTargets, "target", nil, "Target name to generate (repeatable; default: all targets)")
+		cmd.Flags().StringVarP(&deployOutput, "output", "o", "", "Root directory for generated files (default: the deployment's directory)")
+		cmd.Flags().StringVar(&deployEnv, "env", "", "Environment whose target overrides to apply (e.g., prod)")
+	}
+	deployGenerateCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "Print the files that would be created or updated without writing them")
+	deployGenerateCmd.Flags().BoolVar(&deployJSON, "json", false, "Print the generation plan as JSON")
+}
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Work with deployment definitions",
+}
+
+var deployGenerateCmd = &cobra.Command{
+	Use:   "generate <deployment.json>",
+	Short: "Generate platform artifacts for deployment targets",
+	Long: `Generate platform-specific artifacts (agent files, manifests, settings)
+for the targets in a deployment definition.
+
+Paths in the deployment (output, agentDir, ...) are resolved relative to the
+output root, which defaults to the directory containing the deployment file.
+With --env, the named environment's overrides are merged onto the base
+targets before generating.
+
+When skills/, tools/, or rubrics/ directories sit next to the deployment
+file, every skill the agents reference must be defined in skills/, every
+tool must be canonical or defined in tools/, and every rubric must be
+defined in rubrics/.
+
+With --dry-run, nothing is written; the plan lists each file as create,
+update, or unchanged. --json prints the plan as JSON for review automation.
+
+Examples:
+  # Generate all targets next to the deployment file
+  mas deploy generate deployment.json
+
+  # Generate a single target into another directory
+  mas deploy generate --target local-claude -o build deployment.json
+
+  # Review what would change, as JSON
+  mas deploy generate --dry-run --json deployment.json
+
+  # Apply the prod environment's overrides
+  mas deploy generate --env prod deployment.json
+
+  # Use explicit team and agent locations
+  mas deploy generate --team specs/team.json --agents specs/agents deployment.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeployGenerate,
+}
+
+var deployDiffCmd = &cobra.Command{
+	Use:   "diff <deployment.json>",
+	Short: "Report drift between deployment targets and generated artifacts",
+	Long: `Regenerate the artifacts for the targets in a deployment definition in
+memory and compare them with the files under the output root, reporting
+files that would be added (+), changed (~), or removed (-). A file counts
+as removed when it sits in a directory the target generates into but the
+generator no longer produces it.
+
+Exits with an error when any target has drifted, so it can gate CI.
+
+Examples:
+  # Check every target for hand edits
+  mas deploy diff deployment.json
+
+  # Check the prod environment's Kubernetes target
+  mas deploy diff --env prod --target k8s deployment.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDeployDiff,
+}
+
+func runDeployGenerate(cmd *cobra.Command, args []string) error {
+	root, generated, err := generateTargets(args[0])
+	if err != nil {
+		return err
+	}
+	if deployDryRun || deployJSON {
+		plan, err := planTargets(root, generated)
+		if err != nil {
+			return err
+		}
+		if err := printPlan(plan); err != nil {
+			return err
+		}
+		if deployDryRun {
+			return nil
+		}
+	}
+	for _, g := range generated {
+		if err := deploy.Write(root, g.Files); err != nil {
+			return fmt.Errorf("target %s: %w", g.Target.Name, err)
+		}
+		if deployJSON {
+			continue
+		}
+		for _, f := range g.Files {
+			fmt.Fprintf(os.Stdout, "%s: %s\n", g.Target.Name, filepath.Join(root, filepath.FromSlash(f.Path)))
+		}
+	}
+	return nil
+}
+
+// generatePlan is the --dry-run/--json output of deploy generate.
+type generatePlan struct {
+	Root    string       `json:"root"`
+	DryRun  bool         `json:"dryRun"`
+	Targets []targetPlan `json:"targets"`
+}
+
+type targetPlan struct {
+	Name     string                  `json:"name"`
+	Platform multiagentspec.Platform `json:"platform"`
+	Files    []deploy.PlannedFile    `json:"files"`
+}
+
+func planTargets(root string, generated []generatedTarget) (*generatePlan, error) {
+	plan := &generatePlan{Root: root, DryRun: deployDryRun, Targets: []targetPlan{}}
+	for _, g := range generated {
+		files, err := deploy.Plan(root, g.Files)
+		if err != nil {
+			return nil, fmt.Errorf("target %s: %w", g.Target.Name, err)
+		}
+		plan.Targets = append(plan.Targets, targetPlan{Name: g.Target.Name, Platform: g.Target.Platform, Files: files})
+	}
+	return plan, nil
+}
+
+func printPlan(plan *generatePlan) error {
+	if deployJSON {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling plan: %w", err)
+		}
+		fmt.Fprintln(os.Stdout, string(data))
+		return nil
+	}
+
+	counts := make(map[deploy.Action]int)
+	for _, t := range plan.Targets {
+		fmt.Fprintf(os.Stdout, "%s (%s):\n", t.Name, t.Platform)
+		for _, f := range t.Files {
+			counts[f.Action]++
+			fmt.Fprintf(os.Stdout, "  %-9s %s\n", f.Action, filepath.Join(plan.Root, filepath.FromSlash(f.Path)))
+		}
+	}
+	fmt.Fprintf(os.Stdout, "%d to create, %d to update, %d unchanged\n",
+		counts[deploy.ActionCreate], counts[deploy.ActionUpdate], counts[deploy.ActionUnchanged])
+	return nil
+}
+
+func runDeployDiff(cmd *cobra.Command, args []string) error {
+	root, generated, err := generateTargets(args[0])
+	if err != nil {
+		return err
+	}
+	drifted := 0
+	for _, g := range generated {
+		changes, err := deploy.Diff(root, g.Files)
+		if err != nil {
+			return fmt.Errorf("target %s: %w", g.Target.Name, err)
+		}
+		if len(changes) == 0 {
+			fmt.Fprintf(os.Stdout, "%s: up to date\n", g.Target.Name)
+			continue
+		}
+		drifted++
+		fmt.Fprintf(os.Stdout, "%s:\n", g.Target.Name)
+		for _, c := range changes {
+			fmt.Fprintf(os.Stdout, "  %s %s\n", changeMarks[c.Kind], filepath.Join(root, filepath.FromSlash(c.Path)))
+		}
+	}
+	if drifted > 0 {
+		return fmt.Errorf("%d of %d targets differ from generated artifacts", drifted, len(generated))
+	}
+	return nil
+}
+
+var changeMarks = map[deploy.ChangeKind]string{
+	deploy.ChangeAdded:    "+",
+	deploy.ChangeModified: "~",
+	deploy.ChangeRemoved:  "-",
+}
+
+// generatedTarget is a target together with the files generated for it.
+type generatedTarget struct {
+	Target *multiagentspec.Target
+	Files  []deploy.File
+}
+
+// generateTargets loads and validates the deployment, applies --env, and
+// generates the selected targets. It returns the output root and the files
+// per target; targets without a generator are skipped unless named with
+// --target.
+func generateTargets(deploymentPath string) (string, []generatedTarget, error) {
+	project, err := loadProject(deploymentPath)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := project.Deployment.Validate(); err != nil {
+		return "", nil, fmt.Errorf("invalid deployment: %w", err)
+	}
+	if deployEnv != "" {
+		if project.Deployment, err = project.Deployment.ForEnvironment(deployEnv); err != nil {
+			return "", nil, err
+		}
+		if err := project.Deployment.Validate(); err != nil {
+			return "", nil, fmt.Errorf("invalid deployment for environment %s: %w", deployEnv, err)
+		}
+	}
+
+	targets, err := selectTargets(project.Deployment, deployTargets)
+	if err != nil {
+		return "", nil, err
+	}
+
+	root := deployOutput
+	if root == "" {
+		root = filepath.Dir(deploymentPath)
+	}
+
+	var generated []generatedTarget
+	for i := range targets {
+		target := &targets[i]
+		if _, ok := deploy.Lookup(target.Platform); !ok {
+			if len(deployTargets) > 0 {
+				return "", nil, fmt.Errorf("target %s: no generator for platform %q", target.Name, target.Platform)
+			}
+			logger.Warn("skipping target: no generator for platform", "target", target.Name, "platform", target.Platform)
+			continue
+		}
+
+		files, err := deploy.Generate(project, target)
+		if err != nil {
+			return "", nil, err
+		}
+		logger.Debug("generated target", "target", target.Name, "platform", target.Platform, "files", len(files))
+		generated = append(generated, generatedTarget{Target: target, Files: files})
+	}
+	return root, generated, nil
+}
+
+// loadProject loads the deployment and, when present, its team and agents.
+func loadProject(deploymentPath string) (*deploy.Project, error) {
+	loader := multiagentspec.NewLoader(multiagentspec.WithLogger(logger))
+	dep, err := loader.LoadDeployment(deploymentPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading deployment: %w", err)
+	}
+	project := &deploy.Project{Deployment: dep}
+	dir := filepath.Dir(deploymentPath)
+
+	teamPath := deployTeam
+	if teamPath == "" && fileExists(filepath.Join(dir, "team.json")) {
+		teamPath = filepath.Join(dir, "team.json")
+	}
+	if teamPath != "" {
+		project.Team, err = loader.LoadTeam(teamPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading team: %w", err)
+		}
+	}
+
+	agentsDir := deployAgents
+	if agentsDir == "" && fileExists(filepath.Join(dir, "agents")) {
+		agentsDir = filepath.Join(dir, "agents")
+	}
+	if agentsDir != "" {
+		project.Agents, err = loader.LoadAgentsFromDir(agentsDir)
+		if err != nil {
+			return nil, fmt.Errorf("loading agents: %w", err)
+		}
+	}
+	if err := loadLibraries(loader, dir, project); err != nil {
+		return nil, err
+	}
+	if project.Team != nil && project.Agents != nil {
+		if _, err := loader.ResolveTeamAgents(project.Team, project.Agents); err != nil {
+			return nil, err
+		}
+	}
+
+	return project, nil
+}
+
+// selectTargets returns the named targets, or all targets if names is empty.
+func selectTargets(dep *multiagentspec.Deployment, names []string) ([]multiagentspec.Target, error) {
+	if len(names) == 0 {
+		return dep.Targets, nil
+	}
+	targets := make([]multiagentspec.Target, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, t := range dep.Targets {
+			if t.Name == name {
+				targets = append(targets, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("deployment has no target named %q", name)
+		}
+	}
+	return targets, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,341 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/deploy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	describeDir        string
+	describeAgents     string
+	describeDeployment string
+)
+
+func init() {
+	rootCmd.AddCommand(describeCmd)
+	describeCmd.AddCommand(describeAgentCmd)
+	describeCmd.AddCommand(describeTeamCmd)
+
+	describeCmd.PersistentFlags().StringVar(&describeDir, "dir", ".", "Spec directory (default for describe team: the team file's directory)")
+	describeCmd.PersistentFlags().StringVar(&describeAgents, "agents", "", "Directory of agent markdown files (default: agents/ in the spec directory)")
+	describeCmd.PersistentFlags().StringVar(&describeDeployment, "deployment", "", "Deployment definition JSON whose variables apply (default: deployment.json in the spec directory)")
+}
+
+var describeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Print resolved agent and team definitions",
+	Long: `Print an agent or team as deployment sees it: names qualified by
+namespace, agent entries resolved to the revision they pin, dependencies
+resolved, and the problems mas lint would report.`,
+}
+
+var describeAgentCmd = &cobra.Command{
+	Use:   "agent <name>",
+	Short: "Print a resolved agent definition",
+	Long: `Print the agent an entry such as prd/lead or lead@^1.2 resolves to among
+the agents of the spec directory: its definition, its dependencies
+resolved from its namespace, the variables its instructions use, and
+whether it passes lint.
+
+Example:
+  mas describe agent prd/lead --dir specs`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribeAgent,
+}
+
+var describeTeamCmd = &cobra.Command{
+	Use:   "team <team.json>",
+	Short: "Print a resolved team definition",
+	Long: `Print a team with its members resolved against the agents next to it:
+the workflow and its category, the effective lead, each member's
+resolved revision, the dependencies the team needs beyond its members,
+the workflow steps, and whether the team passes lint.
+
+Example:
+  mas describe team specs/team.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDescribeTeam,
+}
+
+func runDescribeAgent(cmd *cobra.Command, args []string) error {
+	loader := multiagentspec.NewLoader(multiagentspec.WithLogger(logger))
+	project, registry, err := loadDescribeSpecs(loader, describeDir, "")
+	if err != nil {
+		return err
+	}
+	a, err := registry.Resolve(args[0])
+	if err != nil {
+		return err
+	}
+	writeAgentDescription(os.Stdout, a, registry, project)
+	return nil
+}
+
+func runDescribeTeam(cmd *cobra.Command, args []string) error {
+	dir := describeDir
+	if !cmd.Flags().Changed("dir") {
+		dir = filepath.Dir(args[0])
+	}
+	loader := multiagentspec.NewLoader(multiagentspec.WithLogger(logger))
+	project, registry, err := loadDescribeSpecs(loader, dir, args[0])
+	if err != nil {
+		return err
+	}
+	writeTeamDescription(os.Stdout, project.Team, registry, project)
+	return nil
+}
+
+// loadDescribeSpecs loads the specs of dir, as mas lint does, and indexes
+// their agents.
+func loadDescribeSpecs(loader *multiagentspec.Loader, dir, teamPath string) (*deploy.Project, *multiagentspec.AgentRegistry, error) {
+	project, err := loadSpecs(loader, dir, teamPath, describeAgents)
+	if err != nil {
+		return nil, nil, err
+	}
+	deploymentPath := describeDeployment
+	if deploymentPath == "" && fileExists(filepath.Join(dir, "deployment.json")) {
+		deploymentPath = filepath.Join(dir, "deployment.json")
+	}
+	if deploymentPath != "" {
+		if project.Deployment, err = loader.LoadDeployment(deploymentPath); err != nil {
+			return nil, nil, fmt.Errorf("loading deployment: %w", err)
+		}
+	}
+	registry, err := multiagentspec.NewAgentRegistry(project.Agents)
+	if err != nil {
+		return nil, nil, err
+	}
+	return project, registry, nil
+}
+
+func writeAgentDescription(w io.Writer, a *multiagentspec.Agent, registry *multiagentspec.AgentRegistry, project *deploy.Project) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	field := func(name, value string) {
+		fmt.Fprintf(tw, "%s:\t%s\n", name, dash(value))
+	}
+	field("Agent", agentRevision(a))
+	field("Description", a.Description)
+	field("Namespace", a.Namespace)
+	field("Model", string(a.Model))
+	if a.Role != "" {
+		field("Role", a.Role)
+	}
+	if a.Goal != "" {
+		field("Goal", a.Goal)
+	}
+	field("Tools", strings.Join(a.Tools, ", "))
+	if len(a.AllowedTools) > 0 {
+		field("Allowed tools", strings.Join(a.AllowedTools, ", "))
+	}
+	field("Skills", strings.Join(a.Skills, ", "))
+	if len(a.Requires) > 0 {
+		field("Requires", strings.Join(a.Requires, ", "))
+	}
+	var deps []string
+	for _, entry := range a.Dependencies {
+		d, err := registry.ResolveFrom(a, entry)
+		if err != nil {
+			deps = append(deps, entry+" (unresolved)")
+			continue
+		}
+		deps = append(deps, agentRevision(d))
+	}
+	field("Dependencies", strings.Join(deps, ", "))
+	field("Delegation", delegationSummary(a))
+	if a.Deprecated {
+		deprecated := "yes"
+		if a.ReplacedBy != "" {
+			deprecated += ", replaced by " + a.ReplacedBy
+		}
+		field("Deprecated", deprecated)
+	}
+	vars, err := a.InstructionVariables()
+	instructions := fmt.Sprintf("%d lines", strings.Count(strings.TrimSpace(a.Instructions), "\n")+1)
+	switch {
+	case strings.TrimSpace(a.Instructions) == "":
+		instructions = ""
+	case err == nil && len(vars) > 0:
+		instructions += "; variables: " + strings.Join(vars, ", ")
+	}
+	field("Instructions", instructions)
+	_ = tw.Flush()
+
+	if len(a.Tasks) > 0 {
+		fmt.Fprintln(w, "\nTasks:")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, t := range a.Tasks {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", t.ID, dash(string(t.Type)), dash(taskAction(t)))
+		}
+		_ = tw.Flush()
+	}
+
+	writeStatus(w, lintAgentList([]*multiagentspec.Agent{a}, project.Variables(), lintOptions(registry, project)...))
+}
+
+func writeTeamDescription(w io.Writer, t *multiagentspec.Team, registry *multiagentspec.AgentRegistry, project *deploy.Project) {
+	var problems []string
+	if err := t.Validate(); err != nil {
+		problems = append(problems, fmt.Sprintf("team %s: %v", t.Name, err))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	field := func(name, value string) {
+		fmt.Fprintf(tw, "%s:\t%s\n", name, dash(value))
+	}
+	team := t.Name
+	if t.Version != "" {
+		team += "@" + t.Version
+	}
+	field("Team", team)
+	field("Description", t.Description)
+	workflow := ""
+	if t.Workflow != nil && t.Workflow.Type != "" {
+		workflow = fmt.Sprintf("%s (%s)", t.Workflow.Type, t.WorkflowCategory())
+	}
+	field("Workflow", workflow)
+	field("Lead", t.EffectiveLead())
+	if t.Orchestrator != "" && t.Orchestrator != t.EffectiveLead() {
+		field("Orchestrator", t.Orchestrator)
+	}
+	if c := t.Collaboration; c != nil {
+		if len(c.Specialists) > 0 {
+			field("Specialists", strings.Join(c.Specialists, ", "))
+		}
+		if c.TaskQueue || t.SelfClaim {
+			field("Task queue", fmt.Sprintf("task_queue %t, self_claim %t", c.TaskQueue, t.SelfClaim))
+		}
+		if r := c.Consensus; r != nil {
+			field("Consensus", fmt.Sprintf("%.0f%% agreement, %d rounds, tie breaker %s", 100*r.RequiredAgreement, r.MaxRounds, dash(r.TieBreaker)))
+		}
+	}
+	vars := project.Variables()
+	if len(vars) > 0 {
+		names := make([]string, 0, len(vars))
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			names[i] = name + "=" + vars[name]
+		}
+		field("Variables", strings.Join(names, ", "))
+	}
+	_ = tw.Flush()
+
+	fmt.Fprintln(w, "\nMembers:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	var members []*multiagentspec.Agent
+	for _, entry := range t.Agents {
+		a, err := registry.Resolve(entry)
+		if err != nil {
+			fmt.Fprintf(tw, "  %s\t(unresolved)\n", entry)
+			problems = append(problems, fmt.Sprintf("team %s: %v", t.Name, err))
+			continue
+		}
+		members = append(members, a)
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", entry, agentRevision(a), dash(string(a.Model)))
+	}
+	_ = tw.Flush()
+
+	if closure, err := registry.Closure(members); err == nil && len(closure) > len(members) {
+		fmt.Fprintln(w, "\nDependencies:")
+		for _, a := range closure[len(members):] {
+			fmt.Fprintf(w, "  %s\n", agentRevision(a))
+		}
+	} else if err != nil {
+		problems = append(problems, fmt.Sprintf("team %s: %v", t.Name, err))
+	}
+
+	if t.Workflow != nil && len(t.Workflow.Steps) > 0 {
+		fmt.Fprintln(w, "\nSteps:")
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, s := range t.Workflow.Steps {
+			if len(s.DependsOn) > 0 {
+				fmt.Fprintf(tw, "  %s\t%s\tafter %s\n", s.Name, s.Agent, strings.Join(s.DependsOn, ", "))
+				continue
+			}
+			fmt.Fprintf(tw, "  %s\t%s\n", s.Name, s.Agent)
+		}
+		_ = tw.Flush()
+	}
+
+	if len(members) == len(t.Agents) {
+		if err := registry.CheckTeamDependencies(t); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if refs, err := t.DeprecatedReferences(project.Agents); err == nil {
+		for _, ref := range refs {
+			problems = append(problems, ref.String())
+		}
+	}
+	problems = append(problems, lintAgentList(members, vars, lintOptions(registry, project)...)...)
+	writeStatus(w, problems)
+}
+
+// writeStatus prints "Status: valid", or the problems found.
+func writeStatus(w io.Writer, problems []string) {
+	if len(problems) == 0 {
+		fmt.Fprintln(w, "\nStatus: valid")
+		return
+	}
+	fmt.Fprintf(w, "\nStatus: %d problems\n", len(problems))
+	for _, p := range problems {
+		fmt.Fprintf(w, "  %s\n", p)
+	}
+}
+
+// agentRevision returns the agent's qualified name and, if set, version,
+// e.g., "prd/lead@1.2.0".
+func agentRevision(a *multiagentspec.Agent) string {
+	if a.Version == "" {
+		return a.QualifiedName()
+	}
+	return a.QualifiedName() + "@" + a.Version
+}
+
+// delegationSummary describes whom the agent may delegate to and receive
+// work from.
+func delegationSummary(a *multiagentspec.Agent) string {
+	d := a.Delegation
+	if d == nil {
+		return ""
+	}
+	to := "none"
+	if d.AllowDelegation {
+		to = "any"
+		if len(d.CanDelegateTo) > 0 {
+			to = strings.Join(d.CanDelegateTo, ", ")
+		}
+	}
+	from := "any"
+	if len(d.CanReceiveFrom) > 0 {
+		from = strings.Join(d.CanReceiveFrom, ", ")
+	}
+	return fmt.Sprintf("to %s; from %s", to, from)
+}
+
+// taskAction returns what a task runs or checks.
+func taskAction(t multiagentspec.Task) string {
+	switch t.Type {
+	case multiagentspec.TaskTypeCommand:
+		return t.Command
+	case multiagentspec.TaskTypePattern:
+		if t.Files != "" {
+			return fmt.Sprintf("%s in %s", t.Pattern, t.Files)
+		}
+		return t.Pattern
+	case multiagentspec.TaskTypeFile:
+		return t.File
+	}
+	return t.Description
+}
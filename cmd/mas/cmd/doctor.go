@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorTeam   string
+	doctorAgents string
+)
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+
+	doctorCmd.Flags().StringVar(&doctorTeam, "team", "", "Team definition JSON (default: team.json in the spec directory)")
+	doctorCmd.Flags().StringVar(&doctorAgents, "agents", "", "Directory of agent markdown files (default: agents/ in the spec directory)")
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor [spec-dir]",
+	Short: "Check that this machine can run a team's agents",
+	Long: `Check that the binaries the team's agents require (their requires
+field) are on PATH. With a team, its members and everything they depend
+on are checked, and the members' dependencies must be on the team or in
+the shared namespace; without one, every agent is checked.
+
+The team and agents are read from team.json and agents/ in spec-dir
+(default: the current directory) unless --team or --agents is given.
+
+Examples:
+  # Check the specs in the current directory
+  mas doctor
+
+  # Check the team in specs/
+  mas doctor specs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDoctor,
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	loader := multiagentspec.NewLoader(multiagentspec.WithLogger(logger))
+	project, err := loadSpecs(loader, dir, doctorTeam, doctorAgents)
+	if err != nil {
+		return err
+	}
+
+	agents := project.Agents
+	if project.Team != nil {
+		registry, err := multiagentspec.NewAgentRegistry(project.Agents)
+		if err != nil {
+			return err
+		}
+		if err := registry.CheckTeamDependencies(project.Team); err != nil {
+			return err
+		}
+		if agents, err = registry.TeamClosure(project.Team); err != nil {
+			return err
+		}
+	}
+
+	missing := 0
+	for _, bin := range multiagentspec.RequiredBinaries(agents) {
+		path, err := exec.LookPath(bin)
+		if err != nil {
+			missing++
+			fmt.Fprintf(os.Stdout, "missing  %s\n", bin)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "ok       %s (%s)\n", bin, path)
+	}
+	if missing > 0 {
+		return fmt.Errorf("%d required binaries not found on PATH", missing)
+	}
+	return nil
+}
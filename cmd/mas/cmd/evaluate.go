@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/evaluator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	evalProvider      string
+	evalModel         string
+	evalRubric        string
+	evalRegion        string
+	evalPromptVersion string
+	evalCacheDir      string
+	evalPrompt        string
+)
+
+func init() {
+	rootCmd.AddCommand(evaluateCmd)
+
+	evaluateCmd.Flags().StringVar(&evalProvider, "provider", evaluator.ProviderAnthropic, "LLM provider: anthropic, openai, or bedrock")
+	evaluateCmd.Flags().StringVar(&evalModel, "model", "", "Model tier (haiku, sonnet, opus) or provider model ID (default: sonnet)")
+	evaluateCmd.Flags().StringVar(&evalRubric, "rubric", "", "Rubric definition (.json, .yaml) or file with the evaluation criteria (required)")
+	evaluateCmd.Flags().StringVar(&evalRegion, "region", "", "AWS region for bedrock (default: AWS_REGION)")
+	evaluateCmd.Flags().StringVar(&evalPromptVersion, "prompt-version", "", "Prompt version to record in the evaluation")
+	evaluateCmd.Flags().StringVar(&evalPrompt, "prompt", "", "Prompt template definition to use as the system prompt, recorded as name@version")
+	evaluateCmd.Flags().StringVar(&evalCacheDir, "cache-dir", "", "Directory caching evaluations of unchanged content, rubric, prompt, and model")
+	_ = evaluateCmd.MarkFlagRequired("rubric")
+}
+
+var evaluateCmd = &cobra.Command{
+	Use:   "evaluate [file]",
+	Short: "Grade a document against a rubric with an LLM",
+	Long: `Grade a document against a rubric with an LLM and print the result as
+LLMEvaluation JSON (score, confidence, reasoning, strengths, concerns,
+and suggestions). The rubric is a rubric definition (.json, .yaml, .yml),
+rendered with its categories, criteria, and score anchors, or any other
+file used as the criteria text.
+
+Credentials come from ANTHROPIC_API_KEY, OPENAI_API_KEY, or the standard
+AWS environment variables for bedrock. If no file is provided, reads from
+stdin. With --cache-dir, an evaluation of the same content against the
+same rubric, prompt version, and model is read from the cache instead of
+calling the model again. With --prompt, a prompt template definition
+(.md, .json, .yaml) replaces the built-in system prompt and its
+name@version is recorded as the evaluation's promptVersion.
+
+Examples:
+  # Grade a PRD with Claude Sonnet
+  mas evaluate --rubric rubrics/prd-quality.yaml prd.md
+
+  # Use OpenAI's smaller tier
+  mas evaluate --provider openai --model haiku --rubric rubrics/prd.md prd.md
+
+  # Use Bedrock in a specific region
+  mas evaluate --provider bedrock --region us-west-2 --rubric rubrics/prd.md prd.md
+
+  # Skip the model call when prd.md has not changed
+  mas evaluate --cache-dir .mas/cache --rubric rubrics/prd-quality.yaml prd.md`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runEvaluate,
+}
+
+func runEvaluate(cmd *cobra.Command, args []string) error {
+	rubric, err := readRubric(evalRubric)
+	if err != nil {
+		return err
+	}
+	var content []byte
+	if len(args) > 0 {
+		content, err = os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("reading file: %w", err)
+		}
+	} else {
+		content, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("reading stdin: %w", err)
+		}
+	}
+	if len(content) == 0 {
+		return fmt.Errorf("empty input")
+	}
+
+	cfg := evaluator.Config{
+		Provider:      evalProvider,
+		Model:         evalModel,
+		Region:        evalRegion,
+		PromptVersion: evalPromptVersion,
+	}
+	if evalPrompt != "" {
+		if cfg.Prompt, err = multiagentspec.LoadPromptFromFile(evalPrompt); err != nil {
+			return fmt.Errorf("loading prompt: %w", err)
+		}
+	}
+	ev, err := evaluator.New(cfg)
+	if err != nil {
+		return err
+	}
+	var cached *evaluator.Cached
+	if evalCacheDir != "" {
+		cached = evaluator.NewCached(ev, evaluator.NewFileCache(evalCacheDir))
+		ev = cached
+	}
+	logger.Debug("evaluating", "provider", evalProvider, "model", evalModel, "rubric", evalRubric)
+	eval, err := ev.Evaluate(cmd.Context(), rubric, string(content))
+	if err != nil {
+		return err
+	}
+	if cached != nil {
+		stats := cached.Stats()
+		logger.Debug("evaluation cache", "hits", stats.Hits, "misses", stats.Misses, "dir", evalCacheDir)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(eval)
+}
+
+// readRubric returns the evaluation criteria in path: a rubric definition
+// (.json, .yaml, .yml) rendered as a prompt, or any other file as is.
+func readRubric(path string) (string, error) {
+	switch filepath.Ext(path) {
+	case ".json", ".yaml", ".yml":
+		rubric, err := multiagentspec.LoadRubricFromFile(path)
+		if err != nil {
+			return "", fmt.Errorf("loading rubric: %w", err)
+		}
+		return rubric.Prompt(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading rubric: %w", err)
+	}
+	return string(data), nil
+}
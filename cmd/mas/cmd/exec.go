@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execTasks   []string
+	execDir     string
+	execTimeout time.Duration
+	execOutput  string
+)
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+
+	execCmd.Flags().StringSliceVar(&execTasks, "task", nil, "ID of a task to run; repeatable (default: every task)")
+	execCmd.Flags().StringVar(&execDir, "dir", ".", "Directory commands run in and pattern and file paths are relative to")
+	execCmd.Flags().DurationVar(&execTimeout, "timeout", 0, "Time limit for each task, e.g., 2m (default: none)")
+	execCmd.Flags().StringVarP(&execOutput, "output", "o", "", "Agent result path, or - for stdout (default: <agent>-result.json in the current directory)")
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec <agent.md>",
+	Short: "Run an agent's tasks locally",
+	Long: `Run an agent's command, pattern, and file tasks on this machine, print
+each task result, and write them as an agent result JSON, for developing
+and debugging task definitions.
+
+Command tasks pass if they exit with status 0. Pattern tasks pass if
+their pattern matches no line of the files their glob selects. File tasks
+pass if the file exists. A failed task is NO-GO, or WARN if it sets
+required: false. Manual tasks are skipped. mas exec fails if the agent
+result is NO-GO.
+
+Examples:
+  # Run the QA agent's run-tests task
+  mas exec agents/qa.md --task run-tests
+
+  # Run every task against another checkout and print the agent result
+  mas exec agents/qa.md --dir ../app -o - | jq .status`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExec,
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	agent, err := multiagentspec.LoadAgentFromFile(args[0])
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	runner := &multiagentspec.TaskRunner{Dir: execDir, Timeout: execTimeout}
+	result, err := runner.RunAgent(ctx, agent, execTasks...)
+	if err != nil {
+		return err
+	}
+
+	out := execOutput
+	resultsW := io.Writer(os.Stdout)
+	if out == "-" {
+		resultsW = os.Stderr
+	}
+	if err := writeTaskResults(resultsW, result); err != nil {
+		return err
+	}
+	if out == "-" {
+		if err := writeJSON(os.Stdout, result); err != nil {
+			return err
+		}
+	} else {
+		if out == "" {
+			out = agent.Name + "-result.json"
+		}
+		if err := writeJSONFile(out, result); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, out)
+	}
+	if result.Status == multiagentspec.StatusNoGo {
+		return fmt.Errorf("agent %s: %s", result.AgentID, result.Status)
+	}
+	return nil
+}
+
+// writeTaskResults prints a row per task result.
+func writeTaskResults(w io.Writer, result *multiagentspec.AgentResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TASK\tSTATUS\tDURATION\tDETAIL")
+	for _, t := range result.Tasks {
+		duration := "-"
+		if t.Status != multiagentspec.StatusSkip {
+			duration = (time.Duration(t.DurationMs) * time.Millisecond).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s %s\t%s\t%s\n", t.ID, t.Status.Icon(), t.Status, duration, dash(t.Detail))
+	}
+	return tw.Flush()
+}
+
+// writeJSONFile writes v as indented JSON to path, creating its directory.
+func writeJSONFile(path string, v any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
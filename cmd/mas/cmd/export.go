@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/agentsmd"
+	"github.com/plexusone/multi-agent-spec/sdk/go/deploy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportTeam    string
+	exportAgents  string
+	exportVariant string
+	exportOutput  string
+)
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportAgentsMDCmd)
+
+	exportAgentsMDCmd.Flags().StringVar(&exportTeam, "team", "", "Team definition JSON (default: team.json in the spec directory)")
+	exportAgentsMDCmd.Flags().StringVar(&exportAgents, "agents", "", "Directory of agent markdown files (default: agents/ in the spec directory)")
+	exportAgentsMDCmd.Flags().StringVar(&exportVariant, "variant", "agents", "File to write: "+variantNames())
+	exportAgentsMDCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Output path, or - for stdout (default: the variant's file in the current directory)")
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Convert spec definitions into other agent configuration formats",
+}
+
+var exportAgentsMDCmd = &cobra.Command{
+	Use:   "agents-md [spec-dir]",
+	Short: "Write a team's agents as AGENTS.md or a tool variant",
+	Long: `Write the team's agents to an AGENTS.md file with an "## Agents" section
+holding each agent's description, settings, and instructions. With
+--variant, the same content is written to the file another coding agent
+reads (CLAUDE.md, GEMINI.md, or .github/copilot-instructions.md).
+
+The team and agents are read from team.json and agents/ in spec-dir
+(default: the current directory) unless --team or --agents is given.
+
+Examples:
+  # Write AGENTS.md from the specs in the current directory
+  mas export agents-md
+
+  # Write CLAUDE.md from specs/
+  mas export agents-md --variant claude specs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExportAgentsMD,
+}
+
+func runExportAgentsMD(cmd *cobra.Command, args []string) error {
+	variant, ok := agentsmd.LookupVariant(exportVariant)
+	if !ok {
+		return fmt.Errorf("unknown variant %q (want %s)", exportVariant, variantNames())
+	}
+
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	loader := multiagentspec.NewLoader(multiagentspec.WithLogger(logger))
+	project := &deploy.Project{}
+	teamPath := exportTeam
+	if teamPath == "" && fileExists(filepath.Join(dir, "team.json")) {
+		teamPath = filepath.Join(dir, "team.json")
+	}
+	var err error
+	if teamPath != "" {
+		if project.Team, err = loader.LoadTeam(teamPath); err != nil {
+			return fmt.Errorf("loading team: %w", err)
+		}
+	}
+	agentsDir := exportAgents
+	if agentsDir == "" {
+		agentsDir = filepath.Join(dir, "agents")
+	}
+	if project.Agents, err = loader.LoadAgentsFromDir(agentsDir); err != nil {
+		return fmt.Errorf("loading agents: %w", err)
+	}
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return err
+	}
+
+	data := agentsmd.Export(project.Team, agents)
+	out := exportOutput
+	switch out {
+	case "-":
+		_, err := os.Stdout.Write(data)
+		return err
+	case "":
+		out = filepath.FromSlash(variant.Path)
+	}
+	if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, data, 0o600); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, out)
+	return nil
+}
+
+func variantNames() string {
+	var names []string
+	for _, v := range agentsmd.Variants() {
+		names = append(names, v.Name)
+	}
+	return strings.Join(names, ", ")
+}
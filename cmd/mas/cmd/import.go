@@ -0,0 +1,272 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/agentsmd"
+	"github.com/plexusone/multi-agent-spec/sdk/go/importer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importOutput string
+	importForce  bool
+	importName   string
+
+	importCrewAITasks   string
+	importCrewAIProcess string
+	importCrewAIManager string
+)
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importAgentsMDCmd)
+	importCmd.AddCommand(importClaudeCodeCmd)
+	importCmd.AddCommand(importCrewAICmd)
+	importCmd.AddCommand(importAutoGenCmd)
+
+	importCmd.PersistentFlags().StringVarP(&importOutput, "output", "o", ".", "Directory to write team.json and agents/ into")
+	importCmd.PersistentFlags().BoolVar(&importForce, "force", false, "Overwrite existing files")
+	importCmd.PersistentFlags().StringVar(&importName, "name", "", "Team name (default: derived from the source)")
+
+	importCrewAICmd.Flags().StringVar(&importCrewAITasks, "tasks", "", "Tasks YAML (default: tasks.yaml next to the agents file)")
+	importCrewAICmd.Flags().StringVar(&importCrewAIProcess, "process", importer.CrewAIProcessSequential, "Crew process: sequential or hierarchical")
+	importCrewAICmd.Flags().StringVar(&importCrewAIManager, "manager", "", "Agent that leads a hierarchical crew")
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Convert existing agent configurations into spec definitions",
+}
+
+var importAgentsMDCmd = &cobra.Command{
+	Use:   "agents-md [file-or-dir]",
+	Short: "Bootstrap a team from AGENTS.md or a tool variant",
+	Long: `Convert an AGENTS.md file into a team definition and agent markdown files.
+
+Given a directory (default: the current directory), the first of AGENTS.md,
+CLAUDE.md, GEMINI.md, or .github/copilot-instructions.md is read. A file
+written by "mas export agents-md" yields one agent per "### <agent>"
+subsection of its "## Agents" section; any other file becomes a single
+agent whose instructions are the file's content.
+
+Examples:
+  # Bootstrap specs/ from the repository's AGENTS.md
+  mas import agents-md -o specs
+
+  # Import a Claude Code memory file
+  mas import agents-md CLAUDE.md`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImportAgentsMD,
+}
+
+func runImportAgentsMD(cmd *cobra.Command, args []string) error {
+	path := "."
+	if len(args) > 0 {
+		path = args[0]
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		if path, err = agentsmd.FindFile(path); err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+	team, agents, err := agentsmd.Import(data)
+	if err != nil {
+		return fmt.Errorf("importing %s: %w", path, err)
+	}
+	return writeImportResult(&importer.Result{Team: team, Agents: agents})
+}
+
+var importClaudeCodeCmd = &cobra.Command{
+	Use:   "claude-code [agents-dir]",
+	Short: "Convert Claude Code subagents into spec agents and a draft team",
+	Long: `Convert the Claude Code subagent files in agents-dir (default:
+.claude/agents) into agent markdown files and a draft team.json.
+
+Subdirectories become namespaces. MCP tools (mcp__<server>__<tool>) become
+MCP server declarations, read from the .mcp.json next to the .claude
+directory. Settings without a spec equivalent, such as color, are reported
+as warnings.
+
+Examples:
+  # Migrate the current project's subagents into specs/
+  mas import claude-code -o specs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImportClaudeCode,
+}
+
+func runImportClaudeCode(cmd *cobra.Command, args []string) error {
+	dir := filepath.FromSlash(".claude/agents")
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	res, err := importer.ImportClaudeCode(dir)
+	if err != nil {
+		return err
+	}
+	return writeImportResult(res)
+}
+
+var importCrewAICmd = &cobra.Command{
+	Use:   "crewai [agents.yaml]",
+	Short: "Convert CrewAI agents.yaml and tasks.yaml into spec definitions",
+	Long: `Convert a CrewAI agents.yaml (default: config/agents.yaml) and its
+tasks.yaml into agent markdown files, a team.json with the crew's workflow,
+and a deployment.json with a crewai target.
+
+Agents keep their role, goal, backstory, and delegation setting. Tasks are
+added to their agents and become workflow steps in file order. The crew's
+process and manager are set in Python code, so pass them as flags.
+
+Examples:
+  # Import a sequential crew from a CrewAI project
+  mas import crewai src/research_crew/config/agents.yaml -o specs
+
+  # Import a hierarchical crew led by the manager agent
+  mas import crewai --process hierarchical --manager manager -o specs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImportCrewAI,
+}
+
+func runImportCrewAI(cmd *cobra.Command, args []string) error {
+	agentsPath := filepath.Join("config", "agents.yaml")
+	if len(args) > 0 {
+		agentsPath = args[0]
+	}
+	agentsYAML, err := os.ReadFile(agentsPath)
+	if err != nil {
+		return fmt.Errorf("reading agents: %w", err)
+	}
+
+	tasksPath := importCrewAITasks
+	if tasksPath == "" {
+		tasksPath = filepath.Join(filepath.Dir(agentsPath), "tasks.yaml")
+	}
+	var tasksYAML []byte
+	if importCrewAITasks != "" || fileExists(tasksPath) {
+		if tasksYAML, err = os.ReadFile(tasksPath); err != nil {
+			return fmt.Errorf("reading tasks: %w", err)
+		}
+	}
+
+	// CrewAI projects keep their config in src/<package>/config.
+	name := filepath.Base(filepath.Dir(agentsPath))
+	if name == "config" {
+		abs, err := filepath.Abs(agentsPath)
+		if err == nil {
+			name = filepath.Base(filepath.Dir(filepath.Dir(abs)))
+		}
+	}
+	res, err := importer.ImportCrewAI(agentsYAML, tasksYAML, importer.CrewAIOptions{
+		Name:    strings.ReplaceAll(strings.ToLower(name), "_", "-"),
+		Process: importCrewAIProcess,
+		Manager: importCrewAIManager,
+	})
+	if err != nil {
+		return err
+	}
+	return writeImportResult(res)
+}
+
+var importAutoGenCmd = &cobra.Command{
+	Use:   "autogen <config>",
+	Short: "Convert an AutoGen group-chat config into spec definitions",
+	Long: `Convert an AutoGen group-chat config (YAML or JSON) into agent markdown
+files, a team.json, and a deployment.json with an autogen target.
+
+The config lists the group chat's agents with their ConversableAgent
+settings and, optionally, the group_chat and manager settings. System
+messages become instructions and llm_config models map to model tiers. The
+first human_input_mode, max_consecutive_auto_reply, code_execution_config,
+and model set on any agent go to the autogen target.
+
+Examples:
+  mas import autogen groupchat.yaml -o specs`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImportAutoGen,
+}
+
+func runImportAutoGen(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	res, err := importer.ImportAutoGen(data)
+	if err != nil {
+		return fmt.Errorf("importing %s: %w", args[0], err)
+	}
+	return writeImportResult(res)
+}
+
+// writeImportResult prints the result's warnings to stderr and writes
+// team.json, deployment.json when the result has one, and one markdown file
+// per agent under agents/ in the --output directory.
+func writeImportResult(res *importer.Result) error {
+	for _, w := range res.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %s\n", w)
+	}
+	if importName != "" {
+		res.Team.Name = importName
+		if res.Deployment != nil {
+			res.Deployment.Team = importName
+		}
+	}
+
+	type output struct {
+		path string
+		data []byte
+	}
+	var outputs []output
+	addJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling %s: %w", name, err)
+		}
+		outputs = append(outputs, output{filepath.Join(importOutput, name), append(data, '\n')})
+		return nil
+	}
+	if err := addJSON("team.json", res.Team); err != nil {
+		return err
+	}
+	if res.Deployment != nil {
+		if err := addJSON("deployment.json", res.Deployment); err != nil {
+			return err
+		}
+	}
+	for _, a := range res.Agents {
+		data, err := multiagentspec.MarshalAgentMarkdown(a)
+		if err != nil {
+			return fmt.Errorf("agent %s: %w", a.QualifiedName(), err)
+		}
+		path := filepath.Join(importOutput, "agents", filepath.FromSlash(a.Namespace), a.Name+".md")
+		outputs = append(outputs, output{path, data})
+	}
+
+	if !importForce {
+		for _, o := range outputs {
+			if fileExists(o.path) {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", o.path)
+			}
+		}
+	}
+	for _, o := range outputs {
+		if err := os.MkdirAll(filepath.Dir(o.path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(o.path, o.data, 0o600); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stdout, o.path)
+	}
+	return nil
+}
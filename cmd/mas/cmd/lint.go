@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/deploy"
+	"github.com/plexusone/multi-agent-spec/sdk/go/evaluator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintTeam        string
+	lintAgents      string
+	lintDeployment  string
+	lintEvaluations string
+)
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVar(&lintTeam, "team", "", "Team definition JSON (default: team.json in the spec directory)")
+	lintCmd.Flags().StringVar(&lintAgents, "agents", "", "Directory of agent markdown files (default: agents/ in the spec directory)")
+	lintCmd.Flags().StringVar(&lintDeployment, "deployment", "", "Deployment definition JSON whose variables apply (default: deployment.json in the spec directory)")
+	lintCmd.Flags().StringVar(&lintEvaluations, "evaluations", "", "Directory of LLM evaluation JSON files to check against prompts/ (default: evaluations/ in the spec directory)")
+}
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [spec-dir]",
+	Short: "Check a team's agents for problems that would break deployment",
+	Long: `Check the team's agents for problems that loading does not catch:
+
+  - instructions referencing {{ .vars.name }} placeholders that neither the
+    team nor the deployment defines
+  - the team, its collaboration config, or a member's delegation config
+    referencing a deprecated agent
+  - a member depending on an agent that is neither on the team nor in the
+    shared namespace
+  - an agent depending on or delegating to an agent that does not exist
+  - an agent referencing a skill that skills/ does not define
+  - an agent listing a tool that is neither canonical nor defined in tools/
+  - an agent without instructions, or a command task without
+    expected_output
+  - an agent or task naming a rubric that rubrics/ does not define
+  - a prompt defined twice in prompts/, or an evaluation recording a prompt
+    version that prompts/ does not define
+
+Agent files that fail to load are reported as problems too, and the
+agents that did load are still checked.
+
+The team, agents, and deployment are read from team.json, agents/, and
+deployment.json in spec-dir (default: the current directory) unless
+--team, --agents, or --deployment is given; evaluations are the JSON
+files in evaluations/ or --evaluations. Each problem is printed as
+"<team or agent>: <problem>", and the command fails if there are any.
+
+Examples:
+  # Lint the specs in the current directory
+  mas lint
+
+  # Lint with a per-project deployment's variables
+  mas lint --deployment deploy/atlas.json specs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLint,
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	loader := multiagentspec.NewLoader(
+		multiagentspec.WithLogger(logger),
+		multiagentspec.WithAgentParseOptions(multiagentspec.ContinueOnError()),
+	)
+	var problems []string
+	project, err := loadSpecs(loader, dir, lintTeam, lintAgents)
+	var loadErrs multiagentspec.LoadErrors
+	if errors.As(err, &loadErrs) {
+		for _, fileErr := range loadErrs {
+			problems = append(problems, fileErr.Error())
+		}
+	} else if err != nil {
+		return err
+	}
+	deploymentPath := lintDeployment
+	if deploymentPath == "" && fileExists(filepath.Join(dir, "deployment.json")) {
+		deploymentPath = filepath.Join(dir, "deployment.json")
+	}
+	if deploymentPath != "" {
+		if project.Deployment, err = loader.LoadDeployment(deploymentPath); err != nil {
+			return fmt.Errorf("loading deployment: %w", err)
+		}
+	}
+	registry, err := multiagentspec.NewAgentRegistry(project.Agents)
+	if err != nil {
+		return err
+	}
+	agents := project.Agents
+	if project.Team != nil {
+		if agents, err = loader.ResolveTeamAgents(project.Team, project.Agents); err != nil {
+			return err
+		}
+		refs, err := project.Team.DeprecatedReferences(project.Agents)
+		if err != nil {
+			return err
+		}
+		for _, ref := range refs {
+			problems = append(problems, ref.String())
+		}
+		if err := registry.CheckTeamDependencies(project.Team); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	// Only duplicate definitions; LintAgent reports unknown references.
+	if err := multiagentspec.CheckSkillReferences(nil, project.Skills); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if err := multiagentspec.CheckToolReferences(nil, project.Tools); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if project.Rubrics != nil {
+		if err := multiagentspec.CheckRubricReferences(project.Agents, project.Rubrics); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	if fileExists(filepath.Join(dir, "prompts")) {
+		evalDir := lintEvaluations
+		if evalDir == "" && fileExists(filepath.Join(dir, "evaluations")) {
+			evalDir = filepath.Join(dir, "evaluations")
+		}
+		problem, err := lintPrompts(loader, filepath.Join(dir, "prompts"), evalDir)
+		if err != nil {
+			return err
+		}
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	problems = append(problems, lintAgentList(agents, project.Variables(), lintOptions(registry, project)...)...)
+	for _, p := range problems {
+		fmt.Fprintln(os.Stdout, p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problems found", len(problems))
+	}
+	return nil
+}
+
+// loadSpecs loads the team, agents, and libraries of a spec directory:
+// teamPath, or team.json in dir when present, agentsDir, or agents/ in dir,
+// and skills/, tools/, and rubrics/ in dir when present. If loader
+// continues on errors, agent files that fail to load are returned as a
+// LoadErrors along with the project.
+func loadSpecs(loader *multiagentspec.Loader, dir, teamPath, agentsDir string) (*deploy.Project, error) {
+	project := &deploy.Project{}
+	var err error
+	if teamPath == "" && fileExists(filepath.Join(dir, "team.json")) {
+		teamPath = filepath.Join(dir, "team.json")
+	}
+	if teamPath != "" {
+		if project.Team, err = loader.LoadTeam(teamPath); err != nil {
+			return nil, fmt.Errorf("loading team: %w", err)
+		}
+	}
+	if agentsDir == "" {
+		agentsDir = filepath.Join(dir, "agents")
+	}
+	project.Agents, err = loader.LoadAgentsFromDir(agentsDir)
+	var loadErrs multiagentspec.LoadErrors
+	if err != nil && !errors.As(err, &loadErrs) {
+		return nil, fmt.Errorf("loading agents: %w", err)
+	}
+	if err := loadLibraries(loader, dir, project); err != nil {
+		return nil, err
+	}
+	return project, err
+}
+
+// loadLibraries loads the skill, custom tool, and rubric definitions in the
+// skills/, tools/, and rubrics/ directories of dir, when present, into
+// project.
+func loadLibraries(loader *multiagentspec.Loader, dir string, project *deploy.Project) error {
+	var err error
+	if fileExists(filepath.Join(dir, "skills")) {
+		if project.Skills, err = loader.LoadSkillsFromDir(filepath.Join(dir, "skills")); err != nil {
+			return fmt.Errorf("loading skills: %w", err)
+		}
+	}
+	if fileExists(filepath.Join(dir, "tools")) {
+		if project.Tools, err = loader.LoadToolsFromDir(filepath.Join(dir, "tools")); err != nil {
+			return fmt.Errorf("loading tools: %w", err)
+		}
+	}
+	if fileExists(filepath.Join(dir, "rubrics")) {
+		if project.Rubrics, err = loader.LoadRubricsFromDir(filepath.Join(dir, "rubrics")); err != nil {
+			return fmt.Errorf("loading rubrics: %w", err)
+		}
+	}
+	return nil
+}
+
+// lintPrompts loads the prompt library in promptsDir and checks the
+// evaluations in evalDir, if set, against it. Evaluations recording the
+// built-in evaluator prompt need no library entry.
+func lintPrompts(loader *multiagentspec.Loader, promptsDir, evalDir string) (string, error) {
+	prompts, err := loader.LoadPromptsFromDir(promptsDir)
+	if err != nil {
+		return "", fmt.Errorf("loading prompts: %w", err)
+	}
+	lib, err := multiagentspec.NewPromptLibrary(prompts)
+	if err != nil {
+		return err.Error(), nil
+	}
+	if evalDir == "" {
+		return "", nil
+	}
+	evals, err := loadEvaluations(evalDir)
+	if err != nil {
+		return "", fmt.Errorf("loading evaluations: %w", err)
+	}
+	for name, eval := range evals {
+		if eval.PromptVersion == evaluator.DefaultPromptVersion {
+			delete(evals, name)
+		}
+	}
+	if err := multiagentspec.CheckPromptReferences(evals, lib); err != nil {
+		return err.Error(), nil
+	}
+	return "", nil
+}
+
+// loadEvaluations reads every .json file under dir as an LLMEvaluation,
+// keyed by path.
+func loadEvaluations(dir string) (map[string]*multiagentspec.LLMEvaluation, error) {
+	evals := make(map[string]*multiagentspec.LLMEvaluation)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".json" {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var eval multiagentspec.LLMEvaluation
+		if err := json.Unmarshal(data, &eval); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		evals[path] = &eval
+		return nil
+	})
+	return evals, err
+}
+
+// lintOptions checks agents against registry and the project's skills
+// and custom tools.
+func lintOptions(registry *multiagentspec.AgentRegistry, project *deploy.Project) []multiagentspec.LintOption {
+	opts := []multiagentspec.LintOption{
+		multiagentspec.WithLintRegistry(registry),
+		multiagentspec.WithLintTools(project.Tools),
+	}
+	if project.Skills != nil {
+		opts = append(opts, multiagentspec.WithLintSkills(project.Skills))
+	}
+	return opts
+}
+
+// lintAgentList returns one "<agent>: <problem>" line per problem found.
+func lintAgentList(agents []*multiagentspec.Agent, vars map[string]string, opts ...multiagentspec.LintOption) []string {
+	var problems []string
+	for _, a := range agents {
+		for _, f := range multiagentspec.LintAgent(a, opts...) {
+			problems = append(problems, f.String())
+		}
+		missing, err := a.UnresolvedVariables(vars)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", a.QualifiedName(), err))
+			continue
+		}
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: unresolved variables: %s", a.QualifiedName(), strings.Join(missing, ", ")))
+		}
+	}
+	return problems
+}
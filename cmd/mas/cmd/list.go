@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/migrate"
+	"github.com/spf13/cobra"
+)
+
+var listFormat string
+
+func init() {
+	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table or json")
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list [dir]",
+	Short: "List the agents, teams, and deployment targets in a directory",
+	Long: `Scan dir (default: the current directory) and print tables of the
+agents, teams, and deployment targets defined under it.
+
+Agents are the markdown files in every agents/ directory, namespaced by
+subdirectory as deployment does. Teams and deployments are the JSON files
+recognized as such by their $schema or shape. Files that fail to load are
+skipped with a warning. Hidden directories are not scanned.
+
+Examples:
+  # List the specs in the current directory
+  mas list
+
+  # Qualified names of every agent, for scripting
+  mas list specs --format json | jq -r '.agents[].name'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runList,
+}
+
+// listing is the mas list output.
+type listing struct {
+	Agents  []listedAgent  `json:"agents"`
+	Teams   []listedTeam   `json:"teams"`
+	Targets []listedTarget `json:"targets"`
+}
+
+type listedAgent struct {
+	Name      string               `json:"name"`
+	Namespace string               `json:"namespace,omitempty"`
+	Model     multiagentspec.Model `json:"model,omitempty"`
+	Tasks     int                  `json:"tasks"`
+	Dir       string               `json:"dir"` // the agents/ directory
+}
+
+type listedTeam struct {
+	Name     string                      `json:"name"`
+	Version  string                      `json:"version,omitempty"`
+	Workflow multiagentspec.WorkflowType `json:"workflow,omitempty"`
+	Agents   int                         `json:"agents"`
+	Path     string                      `json:"path"`
+}
+
+type listedTarget struct {
+	Name     string                        `json:"name"`
+	Team     string                        `json:"team"`
+	Platform multiagentspec.Platform       `json:"platform"`
+	Mode     multiagentspec.DeploymentMode `json:"mode,omitempty"`
+	Output   string                        `json:"output,omitempty"`
+	Path     string                        `json:"path"`
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	switch listFormat {
+	case "table", "json":
+	default:
+		return fmt.Errorf("unknown format %q (want table or json)", listFormat)
+	}
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	l, err := scanSpecs(dir)
+	if err != nil {
+		return err
+	}
+	if listFormat == "json" {
+		return writeJSON(os.Stdout, l)
+	}
+	return writeListing(os.Stdout, l)
+}
+
+// scanSpecs walks dir for agents, teams, and deployments.
+func scanSpecs(dir string) (*listing, error) {
+	loader := multiagentspec.NewLoader(
+		multiagentspec.WithLogger(logger),
+		multiagentspec.WithAgentParseOptions(multiagentspec.ContinueOnError()),
+	)
+	l := &listing{Agents: []listedAgent{}, Teams: []listedTeam{}, Targets: []listedTarget{}}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			if d.Name() != "agents" {
+				return nil
+			}
+			agents, err := loader.LoadAgentsFromDir(path)
+			var loadErrs multiagentspec.LoadErrors
+			if errors.As(err, &loadErrs) {
+				for _, fileErr := range loadErrs {
+					logger.Warn("skipping agent", "path", fileErr.Path, "error", fileErr.Err)
+				}
+			} else if err != nil {
+				return err
+			}
+			for _, a := range agents {
+				l.Agents = append(l.Agents, listedAgent{
+					Name:      a.QualifiedName(),
+					Namespace: a.Namespace,
+					Model:     a.Model,
+					Tasks:     len(a.Tasks),
+					Dir:       path,
+				})
+			}
+			return filepath.SkipDir
+		}
+		if filepath.Ext(path) == ".json" {
+			if err := l.addJSON(loader, path); err != nil {
+				logger.Warn("skipping file", "path", path, "error", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", dir, err)
+	}
+	sort.SliceStable(l.Agents, func(i, j int) bool { return l.Agents[i].Name < l.Agents[j].Name })
+	return l, nil
+}
+
+// addJSON adds the team or deployment at path; other documents are
+// ignored.
+func (l *listing) addJSON(loader *multiagentspec.Loader, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var doc map[string]interface{}
+	if json.Unmarshal(data, &doc) != nil {
+		return nil
+	}
+	switch migrate.DetectKind(doc) {
+	case multiagentspec.SchemaTeam:
+		t, err := loader.LoadTeam(path)
+		if err != nil {
+			return err
+		}
+		team := listedTeam{Name: t.Name, Version: t.Version, Agents: len(t.Agents), Path: path}
+		if t.Workflow != nil {
+			team.Workflow = t.Workflow.Type
+		}
+		l.Teams = append(l.Teams, team)
+	case multiagentspec.SchemaDeployment:
+		dep, err := loader.LoadDeployment(path)
+		if err != nil {
+			return err
+		}
+		for _, t := range dep.Targets {
+			l.Targets = append(l.Targets, listedTarget{
+				Name:     t.Name,
+				Team:     dep.Team,
+				Platform: t.Platform,
+				Mode:     t.Mode,
+				Output:   t.Output,
+				Path:     path,
+			})
+		}
+	}
+	return nil
+}
+
+// writeListing prints a table per kind, skipping empty ones.
+func writeListing(w io.Writer, l *listing) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	tables := 0
+	header := func(columns string) {
+		if tables > 0 {
+			fmt.Fprintln(tw)
+		}
+		tables++
+		fmt.Fprintln(tw, columns)
+	}
+	if len(l.Agents) > 0 {
+		header("AGENT\tMODEL\tTASKS\tNAMESPACE")
+		for _, a := range l.Agents {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%s\n", a.Name, dash(string(a.Model)), a.Tasks, dash(a.Namespace))
+		}
+	}
+	if len(l.Teams) > 0 {
+		header("TEAM\tVERSION\tWORKFLOW\tAGENTS")
+		for _, t := range l.Teams {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", t.Name, dash(t.Version), dash(string(t.Workflow)), t.Agents)
+		}
+	}
+	if len(l.Targets) > 0 {
+		header("TARGET\tTEAM\tPLATFORM\tOUTPUT")
+		for _, t := range l.Targets {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", t.Name, t.Team, t.Platform, dash(t.Output))
+		}
+	}
+	return tw.Flush()
+}
+
+// dash returns s, or "-" if s is empty.
+func dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/migrate"
+	"github.com/spf13/cobra"
+)
+
+// mcpProtocolVersion is the Model Context Protocol revision served.
+const mcpProtocolVersion = "2025-06-18"
+
+// maxMCPMessage bounds a single JSON-RPC message; reports can be large.
+const maxMCPMessage = 64 << 20
+
+func init() {
+	rootCmd.AddCommand(mcpCmd)
+}
+
+var mcpCmd = &cobra.Command{
+	Use:   "mcp",
+	Short: "Serve mas tools to agents over the Model Context Protocol",
+	Long: `Run an MCP server on stdin/stdout so coordinator agents can call mas
+directly instead of shelling out. Messages are newline-delimited JSON-RPC.
+
+Tools:
+  render_report      Render a TeamReport as box or narrative text
+  validate_spec      Validate an agent, team, deployment, report, or message
+  aggregate_results  Combine AgentResults into a TeamReport
+  diff_reports       Compare team and task statuses of two TeamReports
+
+Examples:
+  # Register with Claude Code
+  claude mcp add mas -- mas mcp
+
+  # Gemini CLI settings.json
+  {"mcpServers": {"mas": {"command": "mas", "args": ["mcp"]}}}`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return serveMCP(cmd.InOrStdin(), cmd.OutOrStdout())
+	},
+}
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// JSON-RPC error codes.
+const (
+	rpcParseError     = -32700
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// serveMCP answers JSON-RPC requests from r on w until r is exhausted.
+// Notifications (requests without an ID) get no response.
+func serveMCP(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMCPMessage)
+	enc := json.NewEncoder(w)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var req rpcRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			resp := rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: rpcParseError, Message: err.Error()}}
+			if err := enc.Encode(resp); err != nil {
+				return err
+			}
+			continue
+		}
+		result, rpcErr := handleMCP(&req)
+		if len(req.ID) == 0 {
+			continue
+		}
+		if err := enc.Encode(rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr}); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func handleMCP(req *rpcRequest) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]string{"name": "mas", "version": version},
+		}, nil
+	case "ping":
+		return struct{}{}, nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": mcpTools}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: rpcInvalidParams, Message: err.Error()}
+		}
+		for _, t := range mcpTools {
+			if t.Name == params.Name {
+				return callMCPTool(t, params.Arguments), nil
+			}
+		}
+		return nil, &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("unknown tool %q", params.Name)}
+	default:
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+// mcpContent is a text content item of a tool result.
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+// callMCPTool runs the tool and reports its failures in the result, as MCP
+// expects, rather than as JSON-RPC errors.
+func callMCPTool(t mcpTool, args json.RawMessage) mcpToolResult {
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	text, err := t.run(args)
+	if err != nil {
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	return mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}
+}
+
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+	run         func(args json.RawMessage) (string, error)
+}
+
+var mcpTools = []mcpTool{
+	{
+		Name:        "render_report",
+		Description: "Render a TeamReport as box (terminal) or narrative (Markdown) text.",
+		InputSchema: objectSchema([]string{"report"}, map[string]interface{}{
+			"report": map[string]interface{}{"type": "object", "description": "TeamReport JSON"},
+			"format": map[string]interface{}{"type": "string", "enum": []string{"box", "narrative"}, "default": "box"},
+		}),
+		run: mcpRenderReport,
+	},
+	{
+		Name:        "validate_spec",
+		Description: "Validate a multi-agent-spec document against its schema and consistency rules. Agents may be given as markdown with YAML frontmatter.",
+		InputSchema: objectSchema([]string{"document"}, map[string]interface{}{
+			"document": map[string]interface{}{"description": "Document JSON, or agent markdown as a string"},
+			"kind":     map[string]interface{}{"type": "string", "enum": schemaKindNames(), "description": "Document kind (detected if omitted)"},
+		}),
+		run: mcpValidateSpec,
+	},
+	{
+		Name:        "aggregate_results",
+		Description: "Combine AgentResults into a TeamReport with the overall status computed.",
+		InputSchema: objectSchema([]string{"results"}, map[string]interface{}{
+			"results": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "object"}, "description": "AgentResult JSON documents"},
+			"project": map[string]interface{}{"type": "string"},
+			"version": map[string]interface{}{"type": "string"},
+			"phase":   map[string]interface{}{"type": "string"},
+		}),
+		run: mcpAggregateResults,
+	},
+	{
+		Name:        "diff_reports",
+		Description: "Compare the team and task statuses of two TeamReports, such as two runs of the same team.",
+		InputSchema: objectSchema([]string{"base", "head"}, map[string]interface{}{
+			"base": map[string]interface{}{"type": "object", "description": "Earlier TeamReport JSON"},
+			"head": map[string]interface{}{"type": "object", "description": "Later TeamReport JSON"},
+		}),
+		run: mcpDiffReports,
+	},
+}
+
+func objectSchema(required []string, properties map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+}
+
+func schemaKindNames() []string {
+	kinds := multiagentspec.SchemaKinds()
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = string(k)
+	}
+	return names
+}
+
+func mcpRenderReport(args json.RawMessage) (string, error) {
+	var in struct {
+		Report json.RawMessage `json:"report"`
+		Format string          `json:"format"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	report, err := multiagentspec.ParseTeamReport(in.Report)
+	if err != nil {
+		return "", fmt.Errorf("parsing report: %w", err)
+	}
+
+	var buf bytes.Buffer
+	switch in.Format {
+	case "", "box":
+		err = multiagentspec.NewRenderer(&buf).Render(report)
+	case "narrative":
+		err = multiagentspec.NewNarrativeRenderer(&buf).Render(report)
+	default:
+		return "", fmt.Errorf("unknown format %q (want box or narrative)", in.Format)
+	}
+	if err != nil {
+		return "", fmt.Errorf("rendering report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func mcpValidateSpec(args json.RawMessage) (string, error) {
+	var in struct {
+		Document json.RawMessage           `json:"document"`
+		Kind     multiagentspec.SchemaKind `json:"kind"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	data := in.Document
+	var markdown string
+	if json.Unmarshal(data, &markdown) == nil {
+		agent, err := multiagentspec.ParseAgentMarkdown([]byte(markdown))
+		if err != nil {
+			return "", fmt.Errorf("parsing agent markdown: %w", err)
+		}
+		if in.Kind == "" {
+			in.Kind = multiagentspec.SchemaAgent
+		}
+		if in.Kind != multiagentspec.SchemaAgent {
+			return "", fmt.Errorf("markdown documents must be agents, not %s", in.Kind)
+		}
+		if data, err = json.Marshal(agent); err != nil {
+			return "", err
+		}
+	}
+	if in.Kind == "" {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return "", fmt.Errorf("parsing document: %w", err)
+		}
+		if in.Kind = migrate.DetectKind(doc); in.Kind == "" {
+			return "", fmt.Errorf("cannot detect document kind; set kind")
+		}
+	}
+
+	if err := multiagentspec.ValidateJSON(in.Kind, data); err != nil {
+		return "", err
+	}
+	switch in.Kind {
+	case multiagentspec.SchemaTeam:
+		var team multiagentspec.Team
+		if err := json.Unmarshal(data, &team); err != nil {
+			return "", err
+		}
+		if err := team.Validate(); err != nil {
+			return "", fmt.Errorf("invalid team: %w", err)
+		}
+	case multiagentspec.SchemaDeployment:
+		var dep multiagentspec.Deployment
+		if err := json.Unmarshal(data, &dep); err != nil {
+			return "", err
+		}
+		if err := dep.Validate(); err != nil {
+			return "", fmt.Errorf("invalid deployment: %w", err)
+		}
+	case multiagentspec.SchemaAgent:
+		var agent multiagentspec.Agent
+		if err := json.Unmarshal(data, &agent); err != nil {
+			return "", err
+		}
+		for _, s := range agent.MCPServers {
+			if err := s.Validate(); err != nil {
+				return "", fmt.Errorf("invalid agent: %w", err)
+			}
+		}
+	}
+	return fmt.Sprintf("valid %s document (spec %s)", in.Kind, multiagentspec.SpecVersion), nil
+}
+
+func mcpAggregateResults(args json.RawMessage) (string, error) {
+	var in struct {
+		Results []json.RawMessage `json:"results"`
+		Project string            `json:"project"`
+		Version string            `json:"version"`
+		Phase   string            `json:"phase"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	results := make([]multiagentspec.AgentResult, 0, len(in.Results))
+	for i, raw := range in.Results {
+		r, err := multiagentspec.ParseAgentResult(raw)
+		if err != nil {
+			return "", fmt.Errorf("results[%d]: %w", i, err)
+		}
+		if r.AgentID == "" {
+			return "", fmt.Errorf("results[%d]: agent_id is required", i)
+		}
+		results = append(results, *r)
+	}
+
+	data, err := multiagentspec.AggregateResults(results, in.Project, in.Version, in.Phase).ToJSON()
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func mcpDiffReports(args json.RawMessage) (string, error) {
+	var in struct {
+		Base json.RawMessage `json:"base"`
+		Head json.RawMessage `json:"head"`
+	}
+	if err := json.Unmarshal(args, &in); err != nil {
+		return "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	base, err := multiagentspec.ParseTeamReport(in.Base)
+	if err != nil {
+		return "", fmt.Errorf("parsing base report: %w", err)
+	}
+	head, err := multiagentspec.ParseTeamReport(in.Head)
+	if err != nil {
+		return "", fmt.Errorf("parsing head report: %w", err)
+	}
+
+	data, err := json.MarshalIndent(multiagentspec.DiffReports(base, head), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
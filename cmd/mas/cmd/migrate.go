@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/migrate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateKind    string
+	migrateWrite   bool
+	migrateOutput  string
+	migrateLogJSON bool
+)
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVar(&migrateKind, "kind", "", "Document kind (agent, team, deployment, team-report, agent-result); detected if empty")
+	migrateCmd.Flags().BoolVarP(&migrateWrite, "write", "w", false, "Rewrite the input file in place")
+	migrateCmd.Flags().StringVarP(&migrateOutput, "output", "o", "", "Write migrated document to file instead of stdout")
+	migrateCmd.Flags().BoolVar(&migrateLogJSON, "log-json", false, "Print the change log to stderr as JSON")
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [file.json]",
+	Short: "Upgrade a spec document to the current spec version",
+	Long: `Upgrade a team, deployment, report, or agent result JSON document written
+against an older spec version to spec version ` + multiagentspec.SpecVersion + `.
+
+The migrated document is written to stdout (or --output / --write) and the
+list of applied transforms is printed to stderr.
+
+If no file is provided, reads from stdin.
+
+Examples:
+  # Preview migration of a team definition
+  mas migrate team.json
+
+  # Migrate a deployment in place
+  mas migrate --write deployment.json
+
+  # Migrate a report from stdin with a JSON change log
+  cat report.json | mas migrate --log-json > report.v2.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runMigrate,
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	if migrateWrite && len(args) == 0 {
+		return fmt.Errorf("--write requires a file argument")
+	}
+
+	var data []byte
+	var err error
+	if len(args) > 0 {
+		data, err = os.ReadFile(args[0])
+		if err != nil {
+			return fmt.Errorf("reading file: %w", err)
+		}
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("reading stdin: %w", err)
+		}
+	}
+
+	var res *migrate.Result
+	if migrateKind != "" {
+		res, err = migrate.MigrateKind(data, multiagentspec.SchemaKind(migrateKind))
+	} else {
+		res, err = migrate.Migrate(data)
+	}
+	if err != nil {
+		return fmt.Errorf("migrating: %w", err)
+	}
+
+	if err := printMigrationLog(res); err != nil {
+		return err
+	}
+
+	out := append(res.Document, '\n')
+	switch {
+	case migrateWrite:
+		if !res.Changed() {
+			return nil
+		}
+		return os.WriteFile(args[0], out, 0o600)
+	case migrateOutput != "":
+		return os.WriteFile(migrateOutput, out, 0o600)
+	default:
+		_, err = os.Stdout.Write(out)
+		return err
+	}
+}
+
+func printMigrationLog(res *migrate.Result) error {
+	if migrateLogJSON {
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling change log: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, string(data))
+		return nil
+	}
+
+	if !res.Changed() {
+		fmt.Fprintf(os.Stderr, "%s is already at spec version %s\n", res.Kind, res.ToVersion)
+		return nil
+	}
+	from := res.FromVersion
+	if from == "" {
+		from = "unversioned"
+	}
+	fmt.Fprintf(os.Stderr, "migrated %s from %s to %s (%d changes):\n", res.Kind, from, res.ToVersion, len(res.Changes))
+	for _, c := range res.Changes {
+		fmt.Fprintf(os.Stderr, "  - [%s] %s: %s\n", c.Transform, c.Path, c.Description)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/publish"
+	"github.com/spf13/cobra"
+)
+
+var (
+	publishTo       string
+	publishURL      string
+	publishFormat   string
+	publishAttempts int
+	publishDryRun   bool
+	publishTeams    []string
+)
+
+func init() {
+	rootCmd.AddCommand(publishCmd)
+
+	publishCmd.Flags().StringVar(&publishTo, "to", "", "Where to post: webhook, slack, or teams (required)")
+	publishCmd.Flags().StringVar(&publishURL, "url", "", "Webhook URL (default: $MAS_PUBLISH_URL)")
+	publishCmd.Flags().StringVar(&publishFormat, "format", "summary", "Summary format: summary, box, narrative, gh-summary, or json (webhook only)")
+	publishCmd.Flags().IntVar(&publishAttempts, "attempts", 3, "Tries before giving up on network errors, 429s, and 5xx responses")
+	publishCmd.Flags().BoolVar(&publishDryRun, "dry-run", false, "Print the message instead of posting it")
+	publishCmd.Flags().StringSliceVar(&publishTeams, "team", nil, "Publish only these teams, by ID or name (comma-separated or repeated)")
+	_ = publishCmd.MarkFlagRequired("to")
+}
+
+var publishCmd = &cobra.Command{
+	Use:   "publish [report]",
+	Short: "Post a report summary to a webhook, Slack, or Microsoft Teams",
+	Long: `Post a summary of a TeamReport (JSON or YAML; default: stdin) to a
+webhook. --to selects the message: a Slack Block Kit message, a Microsoft
+Teams Adaptive Card, or, for other services, JSON with the summary as
+"text" and the report's project, version, phase, and status.
+
+--format selects the summary: the overall and team statuses (summary), or
+the report rendered as by mas render (box, narrative, or gh-summary). For
+--to webhook, json posts the report itself.
+
+Network errors, 429s, and 5xx responses are retried with exponential
+backoff, honoring Retry-After. Webhook URLs are secrets, so --url defaults
+to $MAS_PUBLISH_URL.
+
+Examples:
+  # Notify a Slack channel
+  MAS_PUBLISH_URL=$SLACK_WEBHOOK_URL mas publish --to slack report.json
+
+  # Post the box rendering to Teams
+  mas publish --to teams --format box --url "$TEAMS_WEBHOOK_URL" report.json
+
+  # See the message without posting it
+  mas publish --to slack --dry-run report.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPublish,
+}
+
+func runPublish(cmd *cobra.Command, args []string) error {
+	report, err := readReport(args)
+	if err != nil {
+		return err
+	}
+	if len(publishTeams) > 0 {
+		if report, err = report.FilterTeams(publishTeams); err != nil {
+			return err
+		}
+	}
+	cfg := publish.Config{
+		Target:   publish.Target(publishTo),
+		URL:      publishURL,
+		Format:   publish.Format(publishFormat),
+		Attempts: publishAttempts,
+		Backoff:  time.Second,
+	}
+	if cfg.URL == "" {
+		cfg.URL = os.Getenv("MAS_PUBLISH_URL")
+	}
+	msg, err := publish.Build(report, cfg)
+	if err != nil {
+		return err
+	}
+	if publishDryRun {
+		var out bytes.Buffer
+		if err := json.Indent(&out, msg.Body, "", "  "); err != nil {
+			return fmt.Errorf("formatting message: %w", err)
+		}
+		fmt.Fprintln(os.Stdout, out.String())
+		return nil
+	}
+	if cfg.URL == "" {
+		return fmt.Errorf("no webhook URL: set --url or MAS_PUBLISH_URL")
+	}
+	if err := publish.Send(cmd.Context(), msg, cfg); err != nil {
+		return fmt.Errorf("publishing to %s: %w", publishTo, err)
+	}
+	fmt.Fprintf(os.Stderr, "published to %s\n", publishTo)
+	return nil
+}
+
+// readReport reads a TeamReport, JSON or YAML, from the file in args or
+// from stdin.
+func readReport(args []string) (*multiagentspec.TeamReport, error) {
+	if len(args) > 0 {
+		report, err := multiagentspec.LoadTeamReportFromFile(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("loading report: %w", err)
+		}
+		return report, nil
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty input")
+	}
+	if isYAML("", data) {
+		return multiagentspec.ParseTeamReportYAML(data)
+	}
+	return multiagentspec.ParseTeamReport(data)
+}
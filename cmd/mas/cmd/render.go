@@ -1,43 +1,99 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
 	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/spf13/cobra"
 )
 
-const defaultSchemaURL = "https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/report/team-report.schema.json"
-
 var (
 	format       string
 	boxOut       string
 	narrativeOut string
+	ghSummaryOut string
+	metricsOut   string
+	emailOut     string
+	emailFrom    string
+	emailTo      []string
 	validate     bool
+	strict       bool
+	renderTeams  []string
 	schemaURL    string
+	indexOut     string
+	renderTitle  string
+	renderLocale string
+	renderTheme  string
+	renderWidth  int
+	compact      bool
+	noDurations  bool
+	renderDAG    bool
+	repoURL      string
+	repoCommit   string
+	renderServe  string
+	renderWatch  bool
 )
 
 func init() {
 	rootCmd.AddCommand(renderCmd)
 
-	renderCmd.Flags().StringVar(&format, "format", "box", "Output format for stdout: box or narrative")
+	renderCmd.Flags().StringVar(&format, "format", "box", "Output format for stdout: box, narrative, gh-summary, prometheus, or email (HTML)")
 	renderCmd.Flags().StringVar(&boxOut, "box-out", "", "Write box format to file")
 	renderCmd.Flags().StringVar(&narrativeOut, "narrative-out", "", "Write narrative format to file")
-	renderCmd.Flags().BoolVar(&validate, "validate", false, "Validate JSON against schema before rendering")
-	renderCmd.Flags().StringVar(&schemaURL, "schema", "", "JSON Schema URL or file path for validation")
+	renderCmd.Flags().StringVar(&ghSummaryOut, "gh-summary-out", "", "Append GitHub step summary markdown to file (e.g., $GITHUB_STEP_SUMMARY)")
+	renderCmd.Flags().StringVar(&metricsOut, "metrics-out", "", "Write Prometheus metrics to file (e.g., for the node_exporter textfile collector)")
+	renderCmd.Flags().StringVar(&emailOut, "email-out", "", "Write a MIME email message (HTML with a box text alternative) to file, e.g., for sendmail -t")
+	renderCmd.Flags().StringVar(&emailFrom, "email-from", "", "From address for --email-out")
+	renderCmd.Flags().StringSliceVar(&emailTo, "email-to", nil, "To addresses for --email-out (comma-separated or repeated)")
+	renderCmd.Flags().BoolVar(&validate, "validate", false, "Validate JSON against the embedded team report schema before rendering")
+	renderCmd.Flags().BoolVar(&strict, "strict", false, "Fail if the report is structurally inconsistent (duplicate team IDs, unknown dependencies, invalid content blocks); print status overrides as warnings")
+	renderCmd.Flags().StringSliceVar(&renderTeams, "team", nil, "Render only these teams, by ID or name (comma-separated or repeated)")
+	renderCmd.Flags().StringVar(&indexOut, "index", "", "Render each report to its own file next to this index file, which links them (HTML for .html, else markdown)")
+	renderCmd.Flags().StringVar(&renderTitle, "title", "", "Title of the combined report or index when rendering several reports")
+	renderCmd.Flags().StringVar(&renderTheme, "theme", "double", "Box format characters: double, single, rounded, ascii, or markdown (ascii in a code fence)")
+	renderCmd.Flags().IntVar(&renderWidth, "width", 0, "Box format width in columns (default: the terminal's width when writing to one, else 80)")
+	renderCmd.Flags().BoolVar(&compact, "compact", false, "Print a short box to stdout: GO teams on one line, no SKIP tasks or empty content blocks (--box-out still writes the full box)")
+	renderCmd.Flags().BoolVar(&noDurations, "no-durations", false, "Leave task durations out of box task lines and narrative task tables")
+	renderCmd.Flags().BoolVar(&renderDAG, "dag", false, "Draw the teams' dependency DAG in the box format, as levels from left to right joined by arrows")
+	renderCmd.Flags().StringVar(&repoURL, "repo-url", "", "Repository web URL (e.g., https://github.com/org/repo) to link issue and file task locations to: links in narrative and email output, file:line in box output")
+	renderCmd.Flags().StringVar(&repoCommit, "commit", "", "Commit, branch, or tag --repo-url links point at (default: HEAD)")
+	renderCmd.Flags().StringVar(&renderLocale, "locale", "", "Translate narrative status words and headings: a built-in locale (de, es, fr) or a JSON or YAML catalog file")
+	renderCmd.Flags().StringVar(&schemaURL, "schema", "", "JSON Schema URL or file path to validate against instead of the embedded schema")
+	renderCmd.Flags().StringVar(&renderServe, "serve", "", "Serve the report as HTML on this address (e.g., :8080) instead of writing it, rendering it again on each request")
+	renderCmd.Flags().BoolVar(&renderWatch, "watch", false, "With --serve, reload the browser when the report files change")
 }
 
 var renderCmd = &cobra.Command{
-	Use:   "render [file.json]",
-	Short: "Render TeamReport JSON to box or narrative format",
-	Long: `Render a TeamReport JSON file to box format (terminal) or narrative
-format (Pandoc-friendly Markdown).
+	Use:   "render [file.json...]",
+	Short: "Render TeamReport JSON or YAML to box, narrative, GitHub summary, Prometheus, or email format",
+	Long: `Render a TeamReport JSON file to box format (terminal), narrative
+format (Pandoc-friendly Markdown), gh-summary format (GitHub-flavored
+Markdown for GitHub Actions job summaries), prometheus format (Prometheus
+text exposition format for report, team, and task metrics), or email format
+(email-safe HTML with inline styles). --email-out writes a complete MIME
+message with the box format as its plain-text alternative.
+
+The report may be JSON or YAML, detected by the .json, .yaml, or .yml
+extension, or by content for stdin and other files. If no file is
+provided, reads from stdin.
+
+Given several files, such as the reports of the components in a release
+train, render combines them into one report with a section per input: each
+input's teams, prefixed with its project, under a components table with
+each input's status. With --index, it instead renders each file to its own
+file in the given format, next to an index page (HTML or markdown) linking
+them.
 
-If no file is provided, reads from stdin.
+With --serve, render serves the email format's HTML over HTTP instead,
+rendering the files again on each request; --watch also makes open pages
+reload when the files change, for iterating on a coordinator's output.
 
 Examples:
   # Box format to stdout (default)
@@ -49,52 +105,121 @@ Examples:
   # Both formats to separate files
   mas render --box-out=report.txt --narrative-out=report.md report.json
 
-  # Validate before rendering
+  # Add the report to the GitHub Actions run page
+  mas render --gh-summary-out="$GITHUB_STEP_SUMMARY" report.json
+
+  # Export metrics for the node_exporter textfile collector
+  mas render --metrics-out=/var/lib/node_exporter/mas.prom report.json
+
+  # Push metrics to a Prometheus Pushgateway
+  mas render --format=prometheus report.json | curl --data-binary @- http://pushgateway:9091/metrics/job/mas
+
+  # Email the report with sendmail
+  mas render --email-out=report.eml --email-from=ci@example.com --email-to=team@example.com report.json
+  sendmail -t < report.eml
+
+  # Validate before rendering (offline, embedded schema)
   mas render --validate report.json
 
+  # Validate against a specific schema
+  mas render --validate --schema=./team-report.schema.json report.json
+
+  # Reject inconsistent reports before rendering
+  mas render --strict report.json
+
+  # Render only the security and QA teams
+  mas render --team security,qa report.json
+
+  # Render a report written as YAML
+  mas render report.yaml
+
+  # Short box for the CI log, with the full box kept as an artifact
+  mas render --compact --box-out=report.txt report.json
+
+  # Box format 120 columns wide, e.g., for a wide log viewer
+  mas render --width=120 report.json > report.txt
+
+  # Box format with the order teams ran in drawn at the top
+  mas render --dag report.json
+
+  # Box format for a GitHub comment
+  mas render --theme=markdown report.json | gh pr comment 123 --body-file -
+
+  # Narrative report in German for PDF conversion
+  mas render --format=narrative --locale=de report.json | pandoc -o report.pdf --pdf-engine=xelatex
+
+  # Link issue locations to the commit under review
+  mas render --format=narrative --repo-url=https://github.com/org/repo --commit="$GITHUB_SHA" report.json
+
+  # Combine the reports of a release train
+  mas render --title="RELEASE 2026.10" api.json web.json worker.json
+
+  # Render each report to HTML with an index page linking them
+  mas render --format=email --index=site/index.html api.json web.json worker.json
+
+  # Preview the HTML rendering, reloading the browser when report.json changes
+  mas render --watch --serve :8080 report.json
+
   # Read from stdin
   cat report.json | mas render --format=narrative`,
-	Args: cobra.MaximumNArgs(1),
+	Args: cobra.ArbitraryArgs,
 	RunE: runRender,
 }
 
 func runRender(cmd *cobra.Command, args []string) error {
-	// Read input
-	var data []byte
-	var err error
+	switch format {
+	case "box", "narrative", "gh-summary", "prometheus", "email":
+	default:
+		return fmt.Errorf("unknown format %q (want box, narrative, gh-summary, prometheus, or email)", format)
+	}
 
-	if len(args) > 0 {
-		data, err = os.ReadFile(args[0])
-		if err != nil {
-			return fmt.Errorf("reading file: %w", err)
-		}
-	} else {
-		data, err = io.ReadAll(os.Stdin)
-		if err != nil {
-			return fmt.Errorf("reading stdin: %w", err)
-		}
+	theme, err := multiagentspec.ThemeByName(renderTheme)
+	if err != nil {
+		return err
+	}
+	boxOpts := []multiagentspec.RendererOption{multiagentspec.WithTheme(theme), multiagentspec.WithAutoWidth()}
+	if renderWidth > 0 {
+		boxOpts = append(boxOpts, multiagentspec.WithWidth(renderWidth))
+	}
+	if noDurations {
+		boxOpts = append(boxOpts, multiagentspec.WithDurations(false))
+	}
+	if renderDAG {
+		boxOpts = append(boxOpts, multiagentspec.WithDAG())
+	}
+	if l := locationResolver(); l != nil {
+		boxOpts = append(boxOpts, multiagentspec.WithLocations(l))
+	}
+	narrativeOpts, err := narrativeOptions()
+	if err != nil {
+		return err
 	}
 
-	if len(data) == 0 {
-		return fmt.Errorf("empty input")
+	if indexOut != "" {
+		return runRenderIndex(args, boxOpts, narrativeOpts)
 	}
 
-	// Validate if requested
-	if validate {
-		if err := validateJSON(data); err != nil {
-			return fmt.Errorf("validation failed: %w", err)
+	if renderServe != "" {
+		if indexOut != "" {
+			return fmt.Errorf("--serve and --index cannot be used together")
 		}
+		return serveRender(cmd.Context(), args)
+	}
+	if renderWatch {
+		return fmt.Errorf("--watch needs --serve")
 	}
 
-	// Parse report
-	report, err := multiagentspec.ParseTeamReport(data)
+	report, err := loadRenderInput(args)
 	if err != nil {
-		return fmt.Errorf("parsing report: %w", err)
+		return err
 	}
 
 	// Determine what to render
-	renderBox := boxOut != "" || (format == "box" && narrativeOut == "")
+	renderBox := boxOut != "" || (format == "box" && narrativeOut == "" && ghSummaryOut == "" && metricsOut == "" && emailOut == "")
 	renderNarrative := narrativeOut != "" || format == "narrative"
+	renderGHSummary := ghSummaryOut != "" || format == "gh-summary"
+	renderMetrics := metricsOut != "" || format == "prometheus"
+	renderEmail := format == "email"
 
 	// Render box format
 	if renderBox {
@@ -108,7 +233,19 @@ func runRender(cmd *cobra.Command, args []string) error {
 			w = f
 		}
 
-		renderer := multiagentspec.NewRenderer(w)
+		opts := boxOpts
+		if compact && boxOut == "" {
+			opts = append(opts, multiagentspec.WithCompact())
+		}
+		renderer := multiagentspec.NewRenderer(w, opts...)
+		if err := renderer.Render(report); err != nil {
+			return fmt.Errorf("rendering box format: %w", err)
+		}
+	}
+
+	// Print the compact box too when the full one goes to a file
+	if compact && boxOut != "" && format == "box" {
+		renderer := multiagentspec.NewRenderer(os.Stdout, append(boxOpts, multiagentspec.WithCompact())...)
 		if err := renderer.Render(report); err != nil {
 			return fmt.Errorf("rendering box format: %w", err)
 		}
@@ -126,25 +263,310 @@ func runRender(cmd *cobra.Command, args []string) error {
 			w = f
 		}
 
-		renderer := multiagentspec.NewNarrativeRenderer(w)
+		renderer := multiagentspec.NewNarrativeRenderer(w, narrativeOpts...)
 		if err := renderer.Render(report); err != nil {
 			return fmt.Errorf("rendering narrative format: %w", err)
 		}
 	}
 
+	// Render GitHub summary format, appending since job summary files
+	// collect output from every step
+	if renderGHSummary {
+		var w io.Writer = os.Stdout
+		if ghSummaryOut != "" {
+			f, err := os.OpenFile(ghSummaryOut, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+			if err != nil {
+				return fmt.Errorf("opening GitHub summary output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := multiagentspec.WriteGitHubSummary(w, report); err != nil {
+			return fmt.Errorf("rendering GitHub summary format: %w", err)
+		}
+	}
+
+	// Render Prometheus metrics
+	if renderMetrics {
+		var w io.Writer = os.Stdout
+		if metricsOut != "" {
+			f, err := os.Create(metricsOut)
+			if err != nil {
+				return fmt.Errorf("creating metrics output file: %w", err)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := multiagentspec.WritePrometheusMetrics(w, report); err != nil {
+			return fmt.Errorf("rendering prometheus format: %w", err)
+		}
+	}
+
+	// Render HTML email
+	if renderEmail {
+		if err := multiagentspec.NewHTMLEmailRenderer(os.Stdout, multiagentspec.WithEmailLocations(locationResolver())).Render(report); err != nil {
+			return fmt.Errorf("rendering email format: %w", err)
+		}
+	}
+
+	// Write MIME email message
+	if emailOut != "" {
+		msg, err := multiagentspec.EmailMessage(report, multiagentspec.EmailOptions{From: emailFrom, To: emailTo, Locations: locationResolver()})
+		if err != nil {
+			return fmt.Errorf("rendering email message: %w", err)
+		}
+		if err := os.WriteFile(emailOut, msg, 0o644); err != nil {
+			return fmt.Errorf("writing email message: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// loadRenderInput loads the reports at paths, or stdin if there are none,
+// combining several into one, and keeps only the --team teams.
+func loadRenderInput(paths []string) (*multiagentspec.TeamReport, error) {
+	var report *multiagentspec.TeamReport
+	var err error
+	if len(paths) <= 1 {
+		path := ""
+		if len(paths) > 0 {
+			path = paths[0]
+		}
+		if report, err = loadRenderReport(path); err != nil {
+			return nil, err
+		}
+	} else {
+		reports := make([]*multiagentspec.TeamReport, 0, len(paths))
+		for _, path := range paths {
+			r, err := loadRenderReport(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			reports = append(reports, r)
+		}
+		report = multiagentspec.CombineReports(reports...)
+		report.Title = renderTitle
+	}
+
+	// Filter teams if requested, after validation so it sees the whole report
+	if len(renderTeams) > 0 {
+		if report, err = report.FilterTeams(renderTeams); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// loadRenderReport reads, validates, and parses the report at path, or
+// stdin if path is empty.
+func loadRenderReport(path string) (*multiagentspec.TeamReport, error) {
+	var data []byte
+	var err error
+	if path != "" {
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading file: %w", err)
+		}
+	} else {
+		data, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading stdin: %w", err)
+		}
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty input")
+	}
+
+	// Convert YAML input so validation and parsing see JSON
+	if isYAML(path, data) {
+		if data, err = multiagentspec.YAMLToJSON(data); err != nil {
+			return nil, fmt.Errorf("parsing report: %w", err)
+		}
+	}
+
+	// Validate if requested
+	if validate {
+		if err := validateJSON(data); err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+	}
+
+	// Parse report
+	report, err := multiagentspec.ParseTeamReport(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing report: %w", err)
+	}
+
+	// Check structure if requested
+	if strict {
+		if err := report.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid report:\n%w", err)
+		}
+		for _, o := range report.StatusOverrides() {
+			if path != "" {
+				fmt.Fprintf(os.Stderr, "warning: %s: %s\n", path, o)
+			} else {
+				fmt.Fprintf(os.Stderr, "warning: %s\n", o)
+			}
+		}
+	}
+	return report, nil
+}
+
+// indexExtensions are the file extensions of each format's rendering.
+var indexExtensions = map[string]string{
+	"box":        ".txt",
+	"narrative":  ".md",
+	"gh-summary": ".md",
+	"prometheus": ".prom",
+	"email":      ".html",
+}
+
+// locationResolver returns the resolver for --repo-url and --commit, or
+// nil without --repo-url.
+func locationResolver() *multiagentspec.LocationResolver {
+	if repoURL == "" {
+		return nil
+	}
+	return &multiagentspec.LocationResolver{BaseURL: repoURL, Commit: repoCommit}
+}
+
+// narrativeOptions returns the narrative renderer options for --locale,
+// --no-durations, and --repo-url.
+func narrativeOptions() ([]multiagentspec.NarrativeOption, error) {
+	var opts []multiagentspec.NarrativeOption
+	if noDurations {
+		opts = append(opts, multiagentspec.WithNarrativeDurations(false))
+	}
+	if l := locationResolver(); l != nil {
+		opts = append(opts, multiagentspec.WithNarrativeLocations(l))
+	}
+	if renderLocale == "" {
+		return opts, nil
+	}
+	var catalog *multiagentspec.Catalog
+	var err error
+	switch strings.ToLower(filepath.Ext(renderLocale)) {
+	case ".json", ".yaml", ".yml":
+		catalog, err = multiagentspec.LoadCatalog(renderLocale)
+	default:
+		catalog, err = multiagentspec.CatalogFor(renderLocale)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, multiagentspec.WithCatalog(catalog)), nil
+}
+
+// runRenderIndex renders each report in args to its own file in the
+// --index file's directory, then writes the index linking them.
+func runRenderIndex(args []string, boxOpts []multiagentspec.RendererOption, narrativeOpts []multiagentspec.NarrativeOption) error {
+	if len(args) == 0 {
+		return fmt.Errorf("--index needs report files")
+	}
+	if boxOut != "" || narrativeOut != "" || ghSummaryOut != "" || metricsOut != "" || emailOut != "" {
+		return fmt.Errorf("--index renders each report to its own file; use --format instead of the -out flags")
+	}
+	if len(renderTeams) > 0 {
+		return fmt.Errorf("--team cannot be used with --index")
+	}
+
+	dir := filepath.Dir(indexOut)
+	used := map[string]bool{filepath.Base(indexOut): true}
+	entries := make([]multiagentspec.IndexEntry, 0, len(args))
+	for _, path := range args {
+		report, err := loadRenderReport(path)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		// Name the rendering after the input, numbering repeated names
+		stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		name := stem + indexExtensions[format]
+		for n := 2; used[name]; n++ {
+			name = fmt.Sprintf("%s-%d%s", stem, n, indexExtensions[format])
+		}
+		used[name] = true
+
+		var buf bytes.Buffer
+		if err := renderFormat(&buf, report, format, boxOpts, narrativeOpts); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		entries = append(entries, multiagentspec.IndexEntry{Report: report, Link: name})
+	}
+
+	title := renderTitle
+	if title == "" {
+		title = "Report Index"
+	}
+	var buf bytes.Buffer
+	switch strings.ToLower(filepath.Ext(indexOut)) {
+	case ".html", ".htm":
+		if err := multiagentspec.WriteIndexHTML(&buf, title, entries); err != nil {
+			return fmt.Errorf("rendering index: %w", err)
+		}
+	default:
+		if err := multiagentspec.WriteIndexMarkdown(&buf, title, entries); err != nil {
+			return fmt.Errorf("rendering index: %w", err)
+		}
+	}
+	if err := os.WriteFile(indexOut, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing index: %w", err)
+	}
+	return nil
+}
+
+// renderFormat renders report to w in format, passing boxOpts and
+// narrativeOpts to the box and narrative renderers.
+func renderFormat(w io.Writer, report *multiagentspec.TeamReport, format string, boxOpts []multiagentspec.RendererOption, narrativeOpts []multiagentspec.NarrativeOption) error {
+	var err error
+	switch format {
+	case "box":
+		err = multiagentspec.NewRenderer(w, boxOpts...).Render(report)
+	case "narrative":
+		err = multiagentspec.NewNarrativeRenderer(w, narrativeOpts...).Render(report)
+	case "gh-summary":
+		err = multiagentspec.WriteGitHubSummary(w, report)
+	case "prometheus":
+		err = multiagentspec.WritePrometheusMetrics(w, report)
+	case "email":
+		err = multiagentspec.NewHTMLEmailRenderer(w, multiagentspec.WithEmailLocations(locationResolver())).Render(report)
+	}
+	if err != nil {
+		return fmt.Errorf("rendering %s format: %w", format, err)
+	}
+	return nil
+}
+
+// isYAML reports whether input is YAML: by extension for .yaml, .yml, and
+// .json files, else when it does not start with a JSON object or array.
+func isYAML(path string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
+	}
+	trimmed := bytes.TrimSpace(data)
+	return len(trimmed) > 0 && trimmed[0] != '{' && trimmed[0] != '['
+}
+
 func validateJSON(data []byte) error {
-	// Determine schema URL
-	url := defaultSchemaURL
-	if schemaURL != "" {
-		url = schemaURL
+	// Use the schema embedded for the binary's spec version unless one is given
+	if schemaURL == "" {
+		return multiagentspec.ValidateTeamReportJSON(data)
 	}
 
 	// Compile schema
 	compiler := jsonschema.NewCompiler()
-	schema, err := compiler.Compile(url)
+	schema, err := compiler.Compile(schemaURL)
 	if err != nil {
 		return fmt.Errorf("compiling schema: %w", err)
 	}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// liveReloadScript makes a served page reload when the server sends an
+// event, after the report files change.
+const liveReloadScript = `<script>new EventSource("/events").onmessage = function () { location.reload(); };</script>`
+
+// previewServer serves the HTML rendering of report files.
+type previewServer struct {
+	paths []string
+
+	mu      sync.Mutex
+	clients map[chan struct{}]bool // pages waiting for a reload event
+}
+
+// serveRender serves the reports at paths on --serve until interrupted.
+func serveRender(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return fmt.Errorf("--serve needs report files, not stdin")
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	p := &previewServer{paths: paths, clients: make(map[chan struct{}]bool)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", p.handlePage)
+	mux.HandleFunc("/events", p.handleEvents)
+	ln, err := net.Listen("tcp", renderServe)
+	if err != nil {
+		return err
+	}
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	fmt.Fprintf(os.Stderr, "Serving %s at http://%s/\n", strings.Join(paths, ", "), net.JoinHostPort(host, port))
+
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if renderWatch {
+		go p.watch(ctx)
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// handlePage renders the reports, or the error that prevents it, so a page
+// left open recovers once the files are fixed.
+func (p *previewServer) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	var buf bytes.Buffer
+	status := http.StatusOK
+	report, err := loadRenderInput(p.paths)
+	if err == nil {
+		err = multiagentspec.NewHTMLEmailRenderer(&buf, multiagentspec.WithEmailLocations(locationResolver())).Render(report)
+	}
+	if err != nil {
+		status = http.StatusInternalServerError
+		buf.Reset()
+		fmt.Fprintf(&buf, "<!DOCTYPE html>\n<html><body><h1>Cannot render report</h1><pre>%s</pre></body></html>\n", html.EscapeString(err.Error()))
+	}
+	page := buf.Bytes()
+	if renderWatch {
+		page = injectBeforeBodyEnd(page, liveReloadScript)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(page)
+}
+
+// injectBeforeBodyEnd inserts s before the page's last </body>, or appends
+// it if there is none.
+func injectBeforeBodyEnd(page []byte, s string) []byte {
+	i := bytes.LastIndex(bytes.ToLower(page), []byte("</body>"))
+	if i < 0 {
+		return append(page, s...)
+	}
+	out := make([]byte, 0, len(page)+len(s))
+	out = append(out, page[:i]...)
+	out = append(out, s...)
+	return append(out, page[i:]...)
+}
+
+// handleEvents streams a server-sent event each time the files change.
+func (p *previewServer) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	ch := make(chan struct{}, 1)
+	p.mu.Lock()
+	p.clients[ch] = true
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		delete(p.clients, ch)
+		p.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			if _, err := fmt.Fprint(w, "data: reload\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// watch notifies the pages whenever a file's size or modification time
+// changes, checking every followInterval until ctx is done.
+func (p *previewServer) watch(ctx context.Context) {
+	last := p.stamp()
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if now := p.stamp(); now != last {
+			last = now
+			p.mu.Lock()
+			for ch := range p.clients {
+				select {
+				case ch <- struct{}{}:
+				default: // a reload is already pending
+				}
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// stamp summarizes the files' sizes and modification times.
+func (p *previewServer) stamp() string {
+	var b strings.Builder
+	for _, path := range p.paths {
+		if fi, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, "%d:%d;", fi.Size(), fi.ModTime().UnixNano())
+		} else {
+			b.WriteString("missing;")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/reportstore"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportStore  string
+	queryWhere   string
+	queryGroupBy string
+	queryFormat  string
+	queryProject string
+	queryVersion string
+	queryLimit   int
+)
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(reportQueryCmd)
+	reportCmd.AddCommand(reportSaveCmd)
+
+	reportSaveCmd.Flags().StringVar(&reportStore, "store", "", "Report store: a directory, s3://bucket/prefix, or gs://bucket/prefix (required)")
+	_ = reportSaveCmd.MarkFlagRequired("store")
+
+	reportQueryCmd.Flags().StringVar(&reportStore, "store", "", "Query the index of this report store (a directory, s3://bucket/prefix, or gs://bucket/prefix) instead of scanning a directory")
+	reportQueryCmd.Flags().StringVar(&queryProject, "project", "", "Only reports for this project")
+	reportQueryCmd.Flags().StringVar(&queryVersion, "version", "", "Only reports for this version")
+	reportQueryCmd.Flags().IntVar(&queryLimit, "limit", 0, "Only the newest N matching reports (default: all)")
+
+	reportQueryCmd.Flags().StringVar(&queryWhere, "where", "", `Tag query, e.g., "customer=acme AND environment=prod" (default: all reports)`)
+	reportQueryCmd.Flags().StringVar(&queryGroupBy, "group-by", "", "Count matching reports by status for each value of this tag instead of listing them")
+	reportQueryCmd.Flags().StringVar(&queryFormat, "format", "table", "Output format: table or json")
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Work with collections of team reports",
+}
+
+var reportQueryCmd = &cobra.Command{
+	Use:   "query [dir]",
+	Short: "List or count reports whose tags match a query",
+	Long: `Scan a directory of TeamReport files (.json, .yaml, and .yml,
+recursively; default: the current directory) and list the reports whose
+tags match --where, oldest first, with their overall status and team
+statuses.
+
+With --store, the store's index is queried instead, and reports are
+identified by ID. The store may be a directory or a bucket, as for
+mas report save.
+
+Tag queries are terms joined with AND and OR, AND binding tighter:
+key=value, key!=value, key (the tag is set), and !key (the tag is unset).
+
+With --group-by, the matching reports are instead counted by overall
+status for each value of the tag.
+
+Examples:
+  # Reports for one customer's production deployments
+  mas report query --where "customer=acme AND environment=prod" reports/
+
+  # Status counts per customer
+  mas report query --group-by customer reports/
+
+  # The last five production runs saved to a store
+  mas report query --store s3://ci-artifacts/mas --where environment=prod --limit 5
+
+  # Machine-readable output
+  mas report query --where environment=staging --format json reports/`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReportQuery,
+}
+
+var reportSaveCmd = &cobra.Command{
+	Use:   "save --store <store> <report>...",
+	Short: "Save reports to a report store",
+	Long: `Save TeamReport files (JSON or YAML) to a report store, a set of
+reports indexed by project, version, tags, and date. Reports are stored
+by content, so saving one twice stores it once. Each report's ID is
+printed.
+
+The store is a directory, or a bucket so that CI runs can share one:
+  s3://bucket/prefix   Amazon S3 or a compatible service. Credentials and
+                       region come from AWS_ACCESS_KEY_ID,
+                       AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, and
+                       AWS_REGION; AWS_ENDPOINT_URL_S3 selects another
+                       service, such as MinIO.
+  gs://bucket/prefix   Google Cloud Storage. The access token comes from
+                       GOOGLE_OAUTH_ACCESS_TOKEN (gcloud auth
+                       print-access-token) or the metadata server on
+                       Google Cloud.
+
+Examples:
+  # Keep every CI run's report
+  mas report save --store /var/lib/mas/reports report.json
+
+  # Keep them in a bucket shared across pipelines
+  mas report save --store s3://ci-artifacts/mas report.json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runReportSave,
+}
+
+func runReportSave(cmd *cobra.Command, args []string) error {
+	store, err := reportstore.OpenURL(cmd.Context(), reportStore)
+	if err != nil {
+		return err
+	}
+	for _, path := range args {
+		report, err := multiagentspec.LoadTeamReportFromFile(path)
+		if err != nil {
+			return fmt.Errorf("loading %s: %w", path, err)
+		}
+		e, err := store.Save(cmd.Context(), report)
+		if err != nil {
+			return fmt.Errorf("saving %s: %w", path, err)
+		}
+		fmt.Fprintf(os.Stdout, "%s %s\n", e.ID, path)
+	}
+	return nil
+}
+
+// queryRow is one report in mas report query output.
+type queryRow struct {
+	ID          string                        `json:"id,omitempty"`
+	Path        string                        `json:"path,omitempty"`
+	Project     string                        `json:"project"`
+	Version     string                        `json:"version"`
+	Tags        map[string]string             `json:"tags,omitempty"`
+	Status      multiagentspec.Status         `json:"status"`
+	Teams       map[multiagentspec.Status]int `json:"teams"`
+	GeneratedAt time.Time                     `json:"generated_at"`
+}
+
+func runReportQuery(cmd *cobra.Command, args []string) error {
+	switch queryFormat {
+	case "table", "json":
+	default:
+		return fmt.Errorf("unknown format %q (want table or json)", queryFormat)
+	}
+	dir := "."
+	if len(args) > 0 {
+		if reportStore != "" {
+			return fmt.Errorf("give either --store or a directory, not both")
+		}
+		dir = args[0]
+	}
+	query, err := multiagentspec.ParseTagQuery(queryWhere)
+	if err != nil {
+		return err
+	}
+	var rows []queryRow
+	if reportStore != "" {
+		rows, err = queryStore(cmd.Context(), query)
+	} else {
+		rows, err = queryDir(dir, query)
+	}
+	if err != nil {
+		return err
+	}
+
+	if queryGroupBy != "" {
+		// GroupByTag needs only each report's tags and status
+		matched := make([]*multiagentspec.TeamReport, len(rows))
+		for i, row := range rows {
+			matched[i] = &multiagentspec.TeamReport{Tags: row.Tags, Status: row.Status}
+		}
+		return writeTagGroups(os.Stdout, multiagentspec.GroupByTag(matched, queryGroupBy))
+	}
+	if queryFormat == "json" {
+		if rows == nil {
+			rows = []queryRow{}
+		}
+		return writeJSON(os.Stdout, rows)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPORT\tPROJECT\tVERSION\tGENERATED\tSTATUS\tTEAMS")
+	totals := map[multiagentspec.Status]int{}
+	for _, row := range rows {
+		generated := ""
+		if !row.GeneratedAt.IsZero() {
+			generated = row.GeneratedAt.UTC().Format(time.RFC3339)
+		}
+		name := row.Path
+		if row.ID != "" {
+			name = row.ID[:12]
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s %s\t%s\n",
+			name, row.Project, row.Version, generated, row.Status.Icon(), row.Status, statusCounts(row.Teams))
+		totals[row.Status]++
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	noun := "reports"
+	if len(rows) == 1 {
+		noun = "report"
+	}
+	fmt.Fprintf(os.Stdout, "\n%d %s", len(rows), noun)
+	if s := statusCounts(totals); s != "" {
+		fmt.Fprintf(os.Stdout, ": %s", s)
+	}
+	fmt.Fprintln(os.Stdout)
+	return nil
+}
+
+// queryDir loads the reports under dir and returns those matching query,
+// oldest first.
+func queryDir(dir string, query *multiagentspec.TagQuery) ([]queryRow, error) {
+	reports, err := multiagentspec.LoadTeamReportsFromDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading reports: %w", err)
+	}
+	var rows []queryRow
+	for path, r := range reports {
+		if !query.Matches(r.Tags) || (queryProject != "" && r.Project != queryProject) || (queryVersion != "" && r.Version != queryVersion) {
+			continue
+		}
+		status := r.Status
+		if status == "" {
+			status = r.ComputeOverallStatus()
+		}
+		rows = append(rows, queryRow{
+			Path:        path,
+			Project:     r.Project,
+			Version:     r.Version,
+			Tags:        r.Tags,
+			Status:      status,
+			Teams:       r.TeamStatusCounts(),
+			GeneratedAt: r.GeneratedAt,
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if !rows[i].GeneratedAt.Equal(rows[j].GeneratedAt) {
+			return rows[i].GeneratedAt.Before(rows[j].GeneratedAt)
+		}
+		return rows[i].Path < rows[j].Path
+	})
+	if queryLimit > 0 && len(rows) > queryLimit {
+		rows = rows[len(rows)-queryLimit:]
+	}
+	return rows, nil
+}
+
+// queryStore returns the entries of the report store matching query,
+// oldest first.
+func queryStore(ctx context.Context, query *multiagentspec.TagQuery) ([]queryRow, error) {
+	store, err := reportstore.OpenURL(ctx, reportStore)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := store.Query(reportstore.Query{
+		Project: queryProject,
+		Version: queryVersion,
+		Tags:    query,
+		Limit:   queryLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]queryRow, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, queryRow{
+			ID:          e.ID,
+			Project:     e.Project,
+			Version:     e.Version,
+			Tags:        e.Tags,
+			Status:      e.Status,
+			Teams:       e.Teams,
+			GeneratedAt: e.GeneratedAt,
+		})
+	}
+	return rows, nil
+}
+
+// writeTagGroups writes one row per tag value with its status counts.
+func writeTagGroups(w io.Writer, groups []multiagentspec.TagGroup) error {
+	if queryFormat == "json" {
+		if groups == nil {
+			groups = []multiagentspec.TagGroup{}
+		}
+		return writeJSON(w, groups)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{strings.ToUpper(queryGroupBy), "REPORTS"}
+	for _, s := range multiagentspec.Statuses() {
+		header = append(header, string(s))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, g := range groups {
+		value := g.Value
+		if value == "" {
+			value = "(none)"
+		}
+		cells := []string{value, fmt.Sprint(g.Reports)}
+		for _, s := range multiagentspec.Statuses() {
+			cells = append(cells, fmt.Sprint(g.Statuses[s]))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// statusCounts formats counts as "2 GO, 1 WARN" in status order.
+func statusCounts(counts map[multiagentspec.Status]int) string {
+	var parts []string
+	for _, s := range multiagentspec.Statuses() {
+		if counts[s] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[s], s))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
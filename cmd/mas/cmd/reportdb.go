@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/reportdb"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reportDB      string
+	trendProject  string
+	trendTeam     string
+	trendTags     map[string]string
+	trendInterval string
+	trendSince    string
+	trendUntil    string
+	trendFormat   string
+)
+
+func init() {
+	reportCmd.AddCommand(reportIngestCmd)
+	reportCmd.AddCommand(reportTrendCmd)
+
+	for _, c := range []*cobra.Command{reportIngestCmd, reportTrendCmd} {
+		c.Flags().StringVar(&reportDB, "db", "", "SQLite report database (required)")
+		_ = c.MarkFlagRequired("db")
+	}
+
+	reportTrendCmd.Flags().StringVar(&trendProject, "project", "", "Only reports for this project")
+	reportTrendCmd.Flags().StringVar(&trendTeam, "team", "", "Only this team ID")
+	reportTrendCmd.Flags().StringToStringVar(&trendTags, "tag", nil, "Only reports with this tag, as key=value (repeatable)")
+	reportTrendCmd.Flags().StringVar(&trendInterval, "interval", "week", "Group runs by day, week, month, or run")
+	reportTrendCmd.Flags().StringVar(&trendSince, "since", "", "Only reports generated at or after this RFC 3339 time")
+	reportTrendCmd.Flags().StringVar(&trendUntil, "until", "", "Only reports generated before this RFC 3339 time")
+	reportTrendCmd.Flags().StringVar(&trendFormat, "format", "table", "Output format: table or json")
+}
+
+var reportIngestCmd = &cobra.Command{
+	Use:   "ingest --db <file> <report>...",
+	Short: "Add reports to a SQLite report database",
+	Long: `Add TeamReport files (JSON or YAML) to a SQLite database, normalized
+into reports, teams, tasks, and tags tables for SQL queries and
+mas report trend. The database is created if needed. Reports are keyed by
+content, as in a report store, so ingesting one twice adds it once. Each
+report's ID is printed.
+
+Example:
+  mas report ingest --db reports.db runs/*.json
+  sqlite3 reports.db "SELECT team_id, status, COUNT(*) FROM teams GROUP BY 1, 2"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runReportIngest,
+}
+
+var reportTrendCmd = &cobra.Command{
+	Use:   "trend --db <file>",
+	Short: "Show each team's pass rate over time",
+	Long: `Query a SQLite report database for each team's runs per period: how
+many were not skipped and the share of those that passed (GO or WARN).
+
+Examples:
+  # Weekly pass rate of every team in production
+  mas report trend --db reports.db --tag environment=prod
+
+  # Daily pass rate of one team since March
+  mas report trend --db reports.db --team security --interval day --since 2026-03-01T00:00:00Z`,
+	Args: cobra.NoArgs,
+	RunE: runReportTrend,
+}
+
+func runReportIngest(cmd *cobra.Command, args []string) error {
+	db, err := reportdb.Open(reportDB)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	for _, path := range args {
+		report, err := multiagentspec.LoadTeamReportFromFile(path)
+		if err != nil {
+			return fmt.Errorf("loading %s: %w", path, err)
+		}
+		id, err := db.Ingest(cmd.Context(), report)
+		if err != nil {
+			return fmt.Errorf("ingesting %s: %w", path, err)
+		}
+		fmt.Fprintf(os.Stdout, "%s %s\n", id, path)
+	}
+	return nil
+}
+
+// trendRow is one point in mas report trend output.
+type trendRow struct {
+	reportdb.TrendPoint
+	Runs     int     `json:"runs"`
+	PassRate float64 `json:"pass_rate"`
+}
+
+func runReportTrend(cmd *cobra.Command, args []string) error {
+	switch trendFormat {
+	case "table", "json":
+	default:
+		return fmt.Errorf("unknown format %q (want table or json)", trendFormat)
+	}
+	q := reportdb.TrendQuery{
+		Project:  trendProject,
+		Team:     trendTeam,
+		Tags:     trendTags,
+		Interval: reportdb.Interval(trendInterval),
+	}
+	if trendInterval == "run" {
+		q.Interval = reportdb.Run
+	}
+	var err error
+	if q.Since, err = parseAuditTime("--since", trendSince); err != nil {
+		return err
+	}
+	if q.Until, err = parseAuditTime("--until", trendUntil); err != nil {
+		return err
+	}
+	if _, err := os.Stat(reportDB); err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	db, err := reportdb.Open(reportDB)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	points, err := db.Trend(cmd.Context(), q)
+	if err != nil {
+		return err
+	}
+
+	if trendFormat == "json" {
+		rows := make([]trendRow, 0, len(points))
+		for _, p := range points {
+			rows = append(rows, trendRow{TrendPoint: p, Runs: p.Runs(), PassRate: p.PassRate()})
+		}
+		return writeJSON(os.Stdout, rows)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PERIOD\tTEAM\tRUNS\tPASS RATE\tSTATUSES")
+	for _, p := range points {
+		rate := "-"
+		if p.Runs() > 0 {
+			rate = fmt.Sprintf("%.0f%%", 100*p.PassRate())
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", p.Period, p.Team, p.Runs(), rate, statusCounts(p.Statuses))
+	}
+	return tw.Flush()
+}
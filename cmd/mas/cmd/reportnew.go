@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newReportProject string
+	newReportVersion string
+	newReportPhase   string
+	newReportTitle   string
+	newReportTags    []string
+	newReportOutput  string
+	newReportEntries []reportEntry
+)
+
+func init() {
+	reportCmd.AddCommand(reportNewCmd)
+
+	reportNewCmd.Flags().StringVar(&newReportProject, "project", "", "Project name for the report")
+	reportNewCmd.Flags().StringVar(&newReportVersion, "version", "", "Version or target the report covers")
+	reportNewCmd.Flags().StringVar(&newReportPhase, "phase", "", "Workflow phase for the report")
+	reportNewCmd.Flags().StringVar(&newReportTitle, "title", "", "Report title")
+	reportNewCmd.Flags().StringArrayVar(&newReportTags, "tag", nil, "Tag as key=value; repeatable")
+	reportNewCmd.Flags().Var(&reportEntryFlag{entries: &newReportEntries, team: true}, "team", "Start a team as id or id=name; repeatable")
+	reportNewCmd.Flags().Var(&reportEntryFlag{entries: &newReportEntries}, "task", "Add a task to the last --team as id=STATUS or id=STATUS:detail; repeatable")
+	reportNewCmd.Flags().StringVarP(&newReportOutput, "output", "o", "", "Write the report to file instead of stdout")
+}
+
+var reportNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Write a TeamReport from the command line or prompts",
+	Long: `Build a TeamReport of manually recorded results, in the format agents
+produce, and write it as JSON to stdout or --output.
+
+Teams and tasks come from repeated flags: each --team starts a team, and
+each --task adds a result to the team before it. A task is id=STATUS or
+id=STATUS:detail, where STATUS is GO, NO-GO, WARN, or SKIP, in any case.
+
+Without --team, the teams and tasks are read from prompts on stderr, with
+the project and version asked for unless given as flags. An empty answer
+ends the current list.
+
+Examples:
+  # Record a manual security review
+  mas report new --project my-app --version v1.2.0 \
+    --team security --task scan=WARN:"2 findings" --task pentest=GO \
+    --team "qa=Manual QA" --task smoke=GO:"checked on staging" -o manual.json
+
+  # Answer prompts, then render the result
+  mas report new | mas render`,
+	Args: cobra.NoArgs,
+	RunE: runReportNew,
+}
+
+// reportEntry is a --team or --task flag, kept in command-line order so
+// each task belongs to the team before it.
+type reportEntry struct {
+	team  bool
+	value string
+}
+
+// reportEntryFlag appends the values of --team or --task to entries.
+type reportEntryFlag struct {
+	entries *[]reportEntry
+	team    bool
+}
+
+func (f *reportEntryFlag) String() string { return "" }
+
+func (f *reportEntryFlag) Type() string { return "string" }
+
+func (f *reportEntryFlag) Set(value string) error {
+	if f.team {
+		if id, _, _ := strings.Cut(value, "="); strings.TrimSpace(id) == "" {
+			return fmt.Errorf("team has no id")
+		}
+	} else if _, err := parseTaskSpec(value); err != nil {
+		return err
+	}
+	*f.entries = append(*f.entries, reportEntry{team: f.team, value: value})
+	return nil
+}
+
+func runReportNew(cmd *cobra.Command, args []string) error {
+	entries := newReportEntries
+	project, version := newReportProject, newReportVersion
+	if len(entries) == 0 {
+		var err error
+		if project, version, entries, err = promptReport(os.Stdin, os.Stderr, project, version); err != nil {
+			return err
+		}
+	}
+
+	b := multiagentspec.NewReportBuilder(project, version).
+		Phase(newReportPhase).
+		Title(newReportTitle).
+		GeneratedBy("mas report new")
+	for _, tag := range newReportTags {
+		key, value, ok := strings.Cut(tag, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("tag %q: want key=value", tag)
+		}
+		b.Tag(key, value)
+	}
+	teams := 0
+	for _, e := range entries {
+		if e.team {
+			id, name, ok := strings.Cut(e.value, "=")
+			if !ok {
+				name = id
+			}
+			b.AddTeam(strings.TrimSpace(id), strings.TrimSpace(name))
+			teams++
+			continue
+		}
+		if teams == 0 {
+			return fmt.Errorf("task %q comes before any --team", e.value)
+		}
+		task, _ := parseTaskSpec(e.value)
+		b.AddTask(task)
+	}
+	if teams == 0 {
+		return fmt.Errorf("report has no teams")
+	}
+	report, err := b.Build()
+	if err != nil {
+		return err
+	}
+	if newReportOutput == "" {
+		return writeJSON(os.Stdout, report)
+	}
+	return writeJSONFile(newReportOutput, report)
+}
+
+// parseTaskSpec parses id=STATUS or id=STATUS:detail.
+func parseTaskSpec(spec string) (multiagentspec.TaskResult, error) {
+	id, rest, ok := strings.Cut(spec, "=")
+	id = strings.TrimSpace(id)
+	if !ok || id == "" {
+		return multiagentspec.TaskResult{}, fmt.Errorf("task %q: want id=STATUS or id=STATUS:detail", spec)
+	}
+	name, detail, _ := strings.Cut(rest, ":")
+	status, err := multiagentspec.ParseStatus(strings.TrimSpace(name))
+	if err != nil {
+		return multiagentspec.TaskResult{}, fmt.Errorf("task %q: %w", spec, err)
+	}
+	return multiagentspec.TaskResult{ID: id, Status: status, Detail: strings.TrimSpace(detail)}, nil
+}
+
+// promptReport asks on w for the project and version, unless given, and
+// for teams and their tasks, reading the answers from r until an empty
+// team ID or EOF.
+func promptReport(r io.Reader, w io.Writer, project, version string) (string, string, []reportEntry, error) {
+	sc := bufio.NewScanner(r)
+	ask := func(prompt string) (string, bool) {
+		fmt.Fprint(w, prompt)
+		if !sc.Scan() {
+			fmt.Fprintln(w)
+			return "", false
+		}
+		return strings.TrimSpace(sc.Text()), true
+	}
+	if project == "" {
+		project, _ = ask("Project: ")
+	}
+	if version == "" {
+		version, _ = ask("Version: ")
+	}
+	var entries []reportEntry
+	for {
+		team, ok := ask("Team ID (empty to finish): ")
+		if !ok || team == "" {
+			break
+		}
+		entries = append(entries, reportEntry{team: true, value: team})
+		for {
+			task, ok := ask("  Task as id=STATUS[:detail] (empty to finish team): ")
+			if !ok || task == "" {
+				break
+			}
+			if _, err := parseTaskSpec(task); err != nil {
+				fmt.Fprintf(w, "  %v\n", err)
+				continue
+			}
+			entries = append(entries, reportEntry{value: task})
+		}
+	}
+	return project, version, entries, sc.Err()
+}
@@ -3,13 +3,24 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
 	"github.com/spf13/cobra"
 )
 
 const version = "0.1.0"
 
+var (
+	logLevel  string
+	logFormat string
+
+	// logger is the CLI's stderr logger, configured from --log-level and
+	// --log-format before each command runs.
+	logger = slog.Default()
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "mas",
 	Short: "Multi-Agent Spec CLI",
@@ -18,6 +29,14 @@ var rootCmd = &cobra.Command{
 It provides tools for rendering, validating, and working with
 multi-agent team reports and specifications.`,
 	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		l, err := multiagentspec.NewLogger(os.Stderr, &multiagentspec.LoggingConfig{Level: logLevel, Format: logFormat})
+		if err != nil {
+			return err
+		}
+		logger = l
+		return nil
+	},
 }
 
 // Execute runs the root command.
@@ -27,6 +46,9 @@ func Execute() error {
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
+
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Log level: debug, info, warn, or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "text", "Log format: text or json")
 }
 
 var versionCmd = &cobra.Command{
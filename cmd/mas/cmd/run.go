@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/audit"
+	"github.com/plexusone/multi-agent-spec/sdk/go/reportstore"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runAgents       string
+	runDir          string
+	runTimeout      time.Duration
+	runParallel     int
+	runAgentCommand string
+	runPhase        string
+	runOutput       string
+	runStore        string
+	runID           string
+	runApprovers    []string
+	runApprovalTTL  time.Duration
+	runAuditLog     string
+	runAuditKeyEnv  string
+	runDeployment   string
+	runTarget       string
+	runEnv          string
+)
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().StringVar(&runAgents, "agents", "", "Directory of agent markdown files (default: agents/ next to the team file)")
+	runCmd.Flags().StringVar(&runDir, "dir", ".", "Directory commands run in and pattern and file paths are relative to")
+	runCmd.Flags().DurationVar(&runTimeout, "timeout", 0, "Time limit for each task, e.g., 2m (default: none)")
+	runCmd.Flags().IntVar(&runParallel, "parallel", 0, "Most steps to run at once (default: as many as the workflow allows)")
+	runCmd.Flags().StringVar(&runAgentCommand, "agent-command", "", "Shell command that runs agents without command, pattern, or file tasks (default: skip them)")
+	runCmd.Flags().StringVar(&runPhase, "phase", "", "Workflow phase for the report")
+	runCmd.Flags().StringVarP(&runOutput, "output", "o", "", "Write the report to file instead of stdout")
+	runCmd.Flags().StringVar(&runStore, "store", "", "Report store (a directory, s3://bucket/prefix, or gs://bucket/prefix) that keeps the run's approvals and results, enforcing human_in_loop tasks as approval gates")
+	runCmd.Flags().StringVar(&runID, "run", "", "Run to start or resume with --store (default: a new run named by the current time)")
+	runCmd.Flags().StringSliceVar(&runApprovers, "approvers", nil, "Who may decide the run's approval gates (default: anyone)")
+	runCmd.Flags().DurationVar(&runApprovalTTL, "approval-ttl", 0, "How long a requested approval stays open before the step fails, e.g., 24h (default: no limit)")
+	runCmd.Flags().StringVar(&runAuditLog, "audit-log", "", "Append the run's step events to this audit log (JSON Lines)")
+	runCmd.Flags().StringVar(&runAuditKeyEnv, "audit-hmac-key-env", "", "Environment variable holding the HMAC-SHA256 key that signs --audit-log entries")
+	runCmd.Flags().StringVar(&runDeployment, "deployment", "", "Deployment whose target runtime settings (timeouts, retries, rate limits, tracing, metrics) the run applies")
+	runCmd.Flags().StringVar(&runTarget, "target", "", "Deployment target whose runtime settings to apply (default: the only target)")
+	runCmd.Flags().StringVar(&runEnv, "env", "", "Environment whose target overrides to apply (e.g., prod)")
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run <team.json>",
+	Short: "Run a team's workflow locally and write its TeamReport",
+	Long: `Run a team's workflow on this machine with the reference executor and
+write the resulting TeamReport as JSON. Each step starts once the steps it
+depends on have finished, concurrently where the workflow allows; a
+chain's steps run in order, as do a team's agents when it has no steps.
+Progress is printed to stderr as steps start and finish.
+
+Agents with command, pattern, or file tasks run them as mas exec does.
+Other agents, driven by an LLM, run with --agent-command: the command gets
+a JSON object with the step, agent, model, resolved instructions, inputs,
+and tasks on stdin, and MAS_STEP and MAS_AGENT in its environment, and
+must print an AgentResult JSON. Without it, their steps are skipped.
+Steps of an agent with a rate_limit wait for it, sharing it across the
+agent's steps. Once an agent exceeds its budget, the step gets a budget
+task, and the agent's remaining steps are skipped or, with on_exceed
+downgrade, run on the cheaper model.
+
+A step is skipped if its when condition is false or an upstream step is
+NO-GO or skipped. Each result is checked against its step's ports, as
+mas aggregate --team does. mas run fails if the report is NO-GO.
+
+With --audit-log, the step starts, retries, and finishes, and changes to
+the run's status, are appended to a tamper-evident audit log that mas
+audit verify checks. When TRACEPARENT
+is set, each step runs in a child span of it, passed to commands in
+TRACEPARENT and set on the step's result.
+
+With --deployment, the run applies the runtime settings of a deployment
+target (--target, required when there are several, with --env overrides):
+step timeouts, retries, and rate limits, and, with observability.tracing
+enabled, a span for the run, each step, and each attempt at a step,
+exported to its OTLP endpoint or, with exporter console, to stderr. With
+observability.metrics enabled, step durations, statuses, retries, token
+usage, and the steps queued and running are served for Prometheus on its
+endpoint (default :9464/metrics) while the run lasts.
+
+With --store, tasks with human_in_loop become approval gates: a step runs
+only once mas approve has approved each of its gates, and is NO-GO if one
+is rejected or expires. The first time a run reaches a gate, it records a
+pending approval and pauses: the step and those after it wait, and mas run
+exits with an error naming the gates. Running it again with the same --run
+resumes it, reusing the results of the steps that finished.
+
+Examples:
+  # Run the team and render the report
+  mas run team.json --agents ./agents | mas render
+
+  # Run LLM agents with a wrapper script, two steps at a time
+  mas run team.json --agent-command ./run-agent.sh --parallel 2 -o report.json
+
+  # Pause at approval gates, then resume once approved
+  mas run team.json --store .mas --run release-42 --approvers alice,bob
+  mas approve --store .mas release-42 deploy.sign-off
+  mas run team.json --store .mas --run release-42
+
+  # Apply the prod target's runtime settings and tracing
+  mas run team.json --deployment deployment.json --target prod-k8s --env prod`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRun,
+}
+
+func runRun(cmd *cobra.Command, args []string) error {
+	loader := multiagentspec.NewLoader(multiagentspec.WithLogger(logger))
+	team, err := loader.LoadTeam(args[0])
+	if err != nil {
+		return fmt.Errorf("loading team: %w", err)
+	}
+	agentsDir := runAgents
+	if agentsDir == "" {
+		agentsDir = filepath.Join(filepath.Dir(args[0]), "agents")
+	}
+	agents, err := loader.LoadAgentsFromDir(agentsDir)
+	if err != nil {
+		return fmt.Errorf("loading agents: %w", err)
+	}
+
+	results := make(map[string]*multiagentspec.AgentResult)
+	opts := []multiagentspec.ExecutorOption{
+		multiagentspec.WithTaskRunner(&multiagentspec.TaskRunner{Dir: runDir, Timeout: runTimeout}),
+		multiagentspec.WithMaxParallel(runParallel),
+		multiagentspec.WithRateLimiters(multiagentspec.NewRateLimiters()),
+		multiagentspec.WithBudget(multiagentspec.NewBudgetTracker(agents)),
+		multiagentspec.WithStepLogger(logger),
+		multiagentspec.WithStepObserver(func(ev multiagentspec.StepEvent) {
+			if ev.Result != nil {
+				results[ev.Step] = ev.Result
+			}
+			writeStepEvent(os.Stderr, ev)
+		}),
+	}
+	var store *reportstore.Store
+	if runStore != "" {
+		if store, err = reportstore.OpenURL(cmd.Context(), runStore); err != nil {
+			return err
+		}
+		if runID == "" {
+			runID = time.Now().UTC().Format("20060102-150405")
+		}
+		prior, err := store.RunResults(cmd.Context(), runID)
+		if err != nil && !errors.Is(err, reportstore.ErrNotFound) {
+			return err
+		}
+		if len(prior) > 0 {
+			fmt.Fprintf(os.Stderr, "resuming run %s\n", runID)
+		}
+		policy := multiagentspec.ApprovalPolicy{Approvers: runApprovers, TTL: runApprovalTTL}
+		opts = append(opts, multiagentspec.WithApprovals(store, runID, policy), multiagentspec.WithPriorResults(prior))
+	} else if runID != "" && runAuditLog == "" {
+		return fmt.Errorf("--run requires --store or --audit-log")
+	}
+	var metricsCfg *multiagentspec.MetricsConfig
+	metrics := multiagentspec.NewExecutorMetrics()
+	if runDeployment != "" {
+		rt, err := loadTargetRuntime(loader, runDeployment, runTarget, runEnv)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, multiagentspec.WithRuntime(rt))
+		if rt != nil && rt.Observability != nil && rt.Observability.Metrics != nil && rt.Observability.Metrics.Enabled {
+			metricsCfg = rt.Observability.Metrics
+			opts = append(opts, multiagentspec.WithMetrics(metrics))
+		}
+	} else if runTarget != "" || runEnv != "" {
+		return fmt.Errorf("--target and --env require --deployment")
+	}
+	if os.Getenv(multiagentspec.TraceparentEnv) != "" {
+		opts = append(opts, multiagentspec.WithTraceContext(""))
+	}
+	if runAuditLog != "" {
+		log, err := openAuditLog(runAuditLog, runAuditKeyEnv)
+		if err != nil {
+			return err
+		}
+		defer log.Close()
+		if runID == "" {
+			runID = time.Now().UTC().Format("20060102-150405")
+		}
+		opts = append(opts, multiagentspec.WithAuditLog(log.StepAuditor(runID, team.Name)))
+	}
+	if runAgentCommand != "" {
+		opts = append(opts, multiagentspec.WithLLMRunner(&commandAgentRunner{command: runAgentCommand, dir: runDir, vars: team.Variables}))
+	}
+	executor, err := multiagentspec.NewExecutor(team, agents, opts...)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	if url, err := metricsCfg.Serve(ctx, metrics); err != nil {
+		return fmt.Errorf("serving metrics: %w", err)
+	} else if url != "" {
+		fmt.Fprintf(os.Stderr, "serving metrics at %s\n", url)
+	}
+	report, runErr := executor.Run(ctx)
+	report.Phase = runPhase
+	report.GeneratedBy = "mas run"
+	if store != nil {
+		if err := saveRunResults(cmd.Context(), store, report, results); err != nil {
+			return err
+		}
+		var pending *multiagentspec.ApprovalPendingError
+		if errors.As(runErr, &pending) {
+			fmt.Fprintf(os.Stderr, "⏸  run %s paused: decide each gate with mas approve --store %s %s <gate> (or mas reject), then rerun with --run %s\n", runID, runStore, runID, runID)
+		}
+	}
+	if runOutput == "" {
+		err = writeJSON(os.Stdout, report)
+	} else {
+		err = writeJSONFile(runOutput, report)
+	}
+	switch {
+	case err != nil:
+		return fmt.Errorf("writing report: %w", err)
+	case runErr != nil:
+		return runErr
+	case report.Status == multiagentspec.StatusNoGo:
+		return fmt.Errorf("team %s: %s", team.Name, report.Status)
+	}
+	return nil
+}
+
+// loadTargetRuntime returns the runtime settings of the deployment's
+// target named name, or of its only target when name is empty, with the
+// overrides of env, if set.
+func loadTargetRuntime(loader *multiagentspec.Loader, path, name, env string) (*multiagentspec.RuntimeConfig, error) {
+	dep, err := loader.LoadDeployment(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading deployment: %w", err)
+	}
+	if env != "" {
+		if dep, err = dep.ForEnvironment(env); err != nil {
+			return nil, err
+		}
+	}
+	if name == "" {
+		if len(dep.Targets) != 1 {
+			return nil, fmt.Errorf("deployment has %d targets; choose one with --target", len(dep.Targets))
+		}
+		return dep.Targets[0].Runtime, nil
+	}
+	targets, err := selectTargets(dep, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	return targets[0].Runtime, nil
+}
+
+// saveRunResults saves the results of the run's steps in the report's
+// order, which is the workflow's.
+func saveRunResults(ctx context.Context, store *reportstore.Store, report *multiagentspec.TeamReport, results map[string]*multiagentspec.AgentResult) error {
+	var ordered []multiagentspec.AgentResult
+	for _, t := range report.Teams {
+		if r := results[t.ID]; r != nil {
+			ordered = append(ordered, *r)
+		}
+	}
+	if err := store.SaveRunResults(ctx, runID, ordered); err != nil {
+		return fmt.Errorf("saving run %s: %w", runID, err)
+	}
+	return nil
+}
+
+// writeStepEvent prints a line as a step starts, retries, or finishes.
+func writeStepEvent(w io.Writer, ev multiagentspec.StepEvent) {
+	if ev.State == multiagentspec.StepRunning {
+		fmt.Fprintf(w, "▶  %s: running %s\n", ev.Step, ev.Agent)
+		return
+	}
+	if ev.State == multiagentspec.StepRetrying {
+		fmt.Fprintf(w, "↻  %s: attempt %d failed, retrying (%s)\n", ev.Step, ev.Attempt, ev.Result.Error)
+		return
+	}
+	if ev.State == multiagentspec.StepWaiting {
+		fmt.Fprintf(w, "⏸  %s: %s\n", ev.Step, ev.Result.Tasks[0].Detail)
+		return
+	}
+	r := ev.Result
+	line := fmt.Sprintf("%s %s: %s", r.Status.Icon(), ev.Step, r.Status)
+	if ev.State == multiagentspec.StepDone && r.Duration != "" {
+		line += " in " + r.Duration
+	}
+	for _, t := range r.Tasks {
+		if t.Status == r.Status && t.Detail != "" {
+			line += " (" + t.Detail + ")"
+			break
+		}
+	}
+	fmt.Fprintln(w, line)
+}
+
+// commandAgentRunner runs a step's agent with a shell command that reads
+// the step as JSON on stdin and prints its AgentResult.
+type commandAgentRunner struct {
+	command string
+	dir     string
+	vars    map[string]string
+}
+
+// agentStepRequest is what --agent-command reads on stdin.
+type agentStepRequest struct {
+	Step         string                 `json:"step"`
+	Agent        string                 `json:"agent"`
+	Model        string                 `json:"model,omitempty"`
+	Instructions string                 `json:"instructions"`
+	Inputs       map[string]interface{} `json:"inputs,omitempty"`
+	Tasks        []multiagentspec.Task  `json:"tasks,omitempty"`
+}
+
+func (r *commandAgentRunner) RunStep(ctx context.Context, run multiagentspec.StepRun) (*multiagentspec.AgentResult, error) {
+	instructions, err := run.Agent.ResolveInstructions(r.vars)
+	if err != nil {
+		return nil, err
+	}
+	req, err := json.Marshal(agentStepRequest{
+		Step:         run.Step.Name,
+		Agent:        run.Agent.QualifiedName(),
+		Model:        string(run.Agent.Model),
+		Instructions: instructions,
+		Inputs:       run.Inputs,
+		Tasks:        run.Agent.Tasks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", r.command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", r.command)
+	}
+	c.Dir = r.dir
+	c.Env = append(os.Environ(), "MAS_STEP="+run.Step.Name, "MAS_AGENT="+run.Agent.QualifiedName())
+	if run.Traceparent != "" {
+		c.Env = append(c.Env, multiagentspec.TraceparentEnv+"="+run.Traceparent)
+	}
+	c.Stdin = bytes.NewReader(req)
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if msg := strings.TrimSpace(stderr.String()); errors.As(err, &exitErr) && msg != "" {
+			return nil, fmt.Errorf("agent command: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("agent command: %w", err)
+	}
+	result, err := multiagentspec.ParseAgentResult(out)
+	if err != nil {
+		return nil, fmt.Errorf("agent command: parsing AgentResult: %w", err)
+	}
+	return result, nil
+}
+
+// openAuditLog opens the audit log at path, signing entries with the
+// HMAC key in the keyEnv environment variable when keyEnv is set.
+func openAuditLog(path, keyEnv string) (*audit.Log, error) {
+	var signer audit.Signer
+	if keyEnv != "" {
+		key := os.Getenv(keyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("%s is not set", keyEnv)
+		}
+		signer = audit.NewHMACSigner([]byte(key))
+	}
+	return audit.Open(path, signer)
+}
@@ -7,6 +7,8 @@
 // Commands:
 //
 //	render    Render TeamReport JSON to box or narrative format
+//	migrate   Upgrade a spec document to the current spec version
+//	deploy    Generate platform artifacts from a deployment definition
 //	version   Print version information
 package main
 
@@ -14,6 +14,8 @@
 //	data, _ := json.MarshalIndent(agent, "", "  ")
 package multiagentspec
 
+import "fmt"
+
 // Model represents the model capability tier.
 type Model string
 
@@ -23,6 +25,11 @@ const (
 	ModelOpus   Model = "opus"
 )
 
+// Models returns all model tiers in schema order.
+func Models() []Model {
+	return []Model{ModelHaiku, ModelSonnet, ModelOpus}
+}
+
 // Tool represents canonical tool names available to agents.
 type Tool string
 
@@ -38,6 +45,14 @@ const (
 	ToolTask      Tool = "Task"
 )
 
+// Tools returns all canonical tool names in schema order.
+func Tools() []Tool {
+	return []Tool{
+		ToolWebSearch, ToolWebFetch, ToolRead, ToolWrite,
+		ToolGlob, ToolGrep, ToolBash, ToolEdit, ToolTask,
+	}
+}
+
 // TaskType represents how a task is executed.
 type TaskType string
 
@@ -48,51 +63,131 @@ const (
 	TaskTypeManual  TaskType = "manual"
 )
 
+// TaskTypes returns all task execution types in schema order.
+func TaskTypes() []TaskType {
+	return []TaskType{TaskTypeCommand, TaskTypePattern, TaskTypeFile, TaskTypeManual}
+}
+
 // Task represents a task that an agent can perform.
 type Task struct {
 	// ID is the unique task identifier within this agent.
-	ID string `json:"id"`
+	ID string `json:"id" yaml:"id"`
 
 	// Description describes what this task validates or accomplishes.
-	Description string `json:"description,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 
 	// Type is how the task is executed (command, pattern, file, manual).
-	Type TaskType `json:"type,omitempty"`
+	Type TaskType `json:"type,omitempty" yaml:"type,omitempty"`
 
 	// Command is the shell command to execute (for type: command).
-	Command string `json:"command,omitempty"`
+	Command string `json:"command,omitempty" yaml:"command,omitempty"`
 
 	// Pattern is the regex pattern to search for (for type: pattern).
-	Pattern string `json:"pattern,omitempty"`
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
 
 	// File is the file path to check (for type: file).
-	File string `json:"file,omitempty"`
+	File string `json:"file,omitempty" yaml:"file,omitempty"`
 
 	// Files is a glob pattern for files to check (for type: pattern).
-	Files string `json:"files,omitempty"`
+	Files string `json:"files,omitempty" yaml:"files,omitempty"`
 
 	// Required indicates if task failure causes agent to report NO-GO.
-	Required *bool `json:"required,omitempty"`
+	Required *bool `json:"required,omitempty" yaml:"required,omitempty"`
 
 	// ExpectedOutput describes what constitutes success.
-	ExpectedOutput string `json:"expected_output,omitempty"`
+	ExpectedOutput string `json:"expected_output,omitempty" yaml:"expected_output,omitempty"`
 
 	// HumanInLoop describes when to prompt for human intervention.
-	HumanInLoop string `json:"human_in_loop,omitempty"`
+	HumanInLoop string `json:"human_in_loop,omitempty" yaml:"human_in_loop,omitempty"`
+
+	// Rubric names the rubric this evaluation task is graded against,
+	// overriding the agent's.
+	Rubric string `json:"rubric,omitempty" yaml:"rubric,omitempty" jsonschema_description:"Name of the rubric this evaluation task is graded against, overriding the agent's"`
 }
 
 // DelegationConfig defines delegation permissions for an agent.
 type DelegationConfig struct {
 	// AllowDelegation enables this agent to delegate work to others.
-	AllowDelegation bool `json:"allow_delegation,omitempty" yaml:"allow_delegation,omitempty"`
+	AllowDelegation bool `json:"allow_delegation,omitempty" yaml:"allow_delegation,omitempty" jsonschema:"default=false" jsonschema_description:"Whether this agent can delegate work to others"`
 
 	// CanDelegateTo lists agent names this agent can delegate to.
 	// Empty means no restrictions (can delegate to any agent).
-	CanDelegateTo []string `json:"can_delegate_to,omitempty" yaml:"can_delegate_to,omitempty"`
+	CanDelegateTo []string `json:"can_delegate_to,omitempty" yaml:"can_delegate_to,omitempty" jsonschema_description:"Agent names this agent can delegate to (empty means no restrictions)"`
 
 	// CanReceiveFrom lists agent names this agent can receive delegations from.
 	// Empty means no restrictions (can receive from any agent).
-	CanReceiveFrom []string `json:"can_receive_from,omitempty" yaml:"can_receive_from,omitempty"`
+	CanReceiveFrom []string `json:"can_receive_from,omitempty" yaml:"can_receive_from,omitempty" jsonschema_description:"Agent names this agent can receive delegations from (empty means no restrictions)"`
+}
+
+// MCPTransport is how an agent connects to an MCP server.
+type MCPTransport string
+
+const (
+	MCPTransportStdio MCPTransport = "stdio"
+	MCPTransportHTTP  MCPTransport = "http"
+	MCPTransportSSE   MCPTransport = "sse"
+)
+
+// MCPTransports returns all MCP transports in schema order.
+func MCPTransports() []MCPTransport {
+	return []MCPTransport{MCPTransportStdio, MCPTransportHTTP, MCPTransportSSE}
+}
+
+// MCPServer declares a Model Context Protocol server an agent depends on.
+type MCPServer struct {
+	// Name identifies the server; platforms prefix its tools with it.
+	Name string `json:"name" yaml:"name"`
+
+	// Transport is how the server is reached (default: stdio).
+	Transport MCPTransport `json:"transport,omitempty" yaml:"transport,omitempty"`
+
+	// Command is the executable that starts the server (for stdio).
+	Command string `json:"command,omitempty" yaml:"command,omitempty" jsonschema_description:"Executable that starts the server (stdio transport)"`
+
+	// Args are the command's arguments (for stdio).
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+
+	// URL is the server endpoint (for http and sse).
+	URL string `json:"url,omitempty" yaml:"url,omitempty" jsonschema_description:"Server endpoint (http and sse transports)"`
+
+	// AllowedTools limits the agent to these server tools.
+	// Empty means all tools the server offers.
+	AllowedTools []string `json:"allowed_tools,omitempty" yaml:"allowed_tools,omitempty" jsonschema_description:"Server tools the agent may use (empty means all)"`
+}
+
+// EffectiveTransport returns the transport, defaulting to stdio.
+func (s MCPServer) EffectiveTransport() MCPTransport {
+	if s.Transport == "" {
+		return MCPTransportStdio
+	}
+	return s.Transport
+}
+
+// Validate checks that the server has a name and the command or URL its
+// transport needs.
+func (s MCPServer) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("mcp server name is required")
+	}
+	switch s.EffectiveTransport() {
+	case MCPTransportStdio:
+		if s.Command == "" {
+			return fmt.Errorf("mcp server %s: stdio transport requires command", s.Name)
+		}
+		if s.URL != "" {
+			return fmt.Errorf("mcp server %s: url does not apply to stdio transport", s.Name)
+		}
+	case MCPTransportHTTP, MCPTransportSSE:
+		if s.URL == "" {
+			return fmt.Errorf("mcp server %s: %s transport requires url", s.Name, s.Transport)
+		}
+		if s.Command != "" || len(s.Args) > 0 {
+			return fmt.Errorf("mcp server %s: command does not apply to %s transport", s.Name, s.Transport)
+		}
+	default:
+		return fmt.Errorf("mcp server %s: unknown transport %q", s.Name, s.Transport)
+	}
+	return nil
 }
 
 // Agent represents an agent definition.
@@ -107,6 +202,17 @@ type Agent struct {
 	// Description is a brief summary of what the agent does.
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 
+	// Version is the semantic version of the agent definition (e.g.,
+	// 2.1.0), which teams can pin with name@constraint.
+	Version string `json:"version,omitempty" yaml:"version,omitempty" jsonschema_description:"Semantic version of the agent definition, e.g., 2.1.0"`
+
+	// Deprecated marks the agent for removal; teams and delegation configs
+	// referencing it are reported by the loader and mas lint.
+	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty" jsonschema_description:"Marks the agent for removal"`
+
+	// ReplacedBy names the agent that supersedes this deprecated one.
+	ReplacedBy string `json:"replaced_by,omitempty" yaml:"replaced_by,omitempty" jsonschema_description:"Agent that supersedes this deprecated one"`
+
 	// Icon is the icon identifier for visual representation.
 	// Formats: 'brandkit:name' (from brandkit repo), 'lucide:name' (Lucide icon),
 	// or plain name for inference.
@@ -136,19 +242,45 @@ type Agent struct {
 	// Tasks are the tasks this agent can perform.
 	Tasks []Task `json:"tasks,omitempty" yaml:"tasks,omitempty"`
 
+	// MCPServers are the Model Context Protocol servers the agent uses.
+	MCPServers []MCPServer `json:"mcp_servers,omitempty" yaml:"mcp_servers,omitempty" jsonschema_description:"Model Context Protocol servers the agent uses"`
+
 	// Role-based fields for self-directed workflows
 
 	// Role is the agent's role title (e.g., "Security Analyst").
-	Role string `json:"role,omitempty" yaml:"role,omitempty"`
+	Role string `json:"role,omitempty" yaml:"role,omitempty" jsonschema_description:"Agent's role title for self-directed workflows (e.g., 'Security Analyst')"`
 
 	// Goal describes what the agent aims to achieve.
-	Goal string `json:"goal,omitempty" yaml:"goal,omitempty"`
+	Goal string `json:"goal,omitempty" yaml:"goal,omitempty" jsonschema_description:"What the agent aims to achieve in this role"`
 
 	// Backstory provides context and background for the agent's role.
-	Backstory string `json:"backstory,omitempty" yaml:"backstory,omitempty"`
+	Backstory string `json:"backstory,omitempty" yaml:"backstory,omitempty" jsonschema_description:"Context and background for the agent's role"`
 
 	// Delegation defines delegation permissions for self-directed workflows.
 	Delegation *DelegationConfig `json:"delegation,omitempty" yaml:"delegation,omitempty"`
+
+	// Budget caps the agent's LLM usage per run.
+	Budget *Budget `json:"budget,omitempty" yaml:"budget,omitempty"`
+
+	// RateLimit caps how fast the agent calls its LLM provider, shared
+	// across all its concurrent instances.
+	RateLimit *RateLimit `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+
+	// Guardrails restricts the agent's input, output, and fetched URLs.
+	Guardrails *Guardrails `json:"guardrails,omitempty" yaml:"guardrails,omitempty"`
+
+	// Memory configures what the agent remembers between calls.
+	Memory *Memory `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	// KnowledgeSources are the corpora the agent grounds its answers on.
+	KnowledgeSources []KnowledgeSource `json:"knowledge_sources,omitempty" yaml:"knowledge_sources,omitempty" jsonschema_description:"Corpora the agent grounds its answers on"`
+
+	// Examples are few-shot input and output pairs that generators add to
+	// the agent's prompt.
+	Examples []Example `json:"examples,omitempty" yaml:"examples,omitempty" jsonschema_description:"Few-shot examples added to the agent's prompt"`
+
+	// Rubric names the rubric the agent's evaluations are graded against.
+	Rubric string `json:"rubric,omitempty" yaml:"rubric,omitempty" jsonschema_description:"Name of the rubric the agent's evaluations are graded against"`
 }
 
 // NewAgent creates a new Agent with the given name and description.
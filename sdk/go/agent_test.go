@@ -2,6 +2,7 @@ package multiagentspec
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -163,3 +164,48 @@ func TestAgentJSONOmitEmpty(t *testing.T) {
 		t.Error("model should be omitted when empty")
 	}
 }
+
+func TestMCPServerValidate(t *testing.T) {
+	tests := []struct {
+		server  MCPServer
+		wantErr string
+	}{
+		{MCPServer{Name: "db", Command: "db-mcp", Args: []string{"--ro"}}, ""},
+		{MCPServer{Name: "github", Transport: MCPTransportHTTP, URL: "https://example.com/mcp"}, ""},
+		{MCPServer{Name: "events", Transport: MCPTransportSSE, URL: "https://example.com/sse"}, ""},
+		{MCPServer{Command: "db-mcp"}, "name is required"},
+		{MCPServer{Name: "db"}, "stdio transport requires command"},
+		{MCPServer{Name: "db", Command: "db-mcp", URL: "https://example.com"}, "url does not apply"},
+		{MCPServer{Name: "github", Transport: MCPTransportHTTP}, "http transport requires url"},
+		{MCPServer{Name: "github", Transport: MCPTransportSSE, URL: "https://example.com", Command: "x"}, "command does not apply"},
+		{MCPServer{Name: "ws", Transport: "websocket", URL: "wss://example.com"}, "unknown transport"},
+	}
+	for _, tt := range tests {
+		err := tt.server.Validate()
+		if tt.wantErr == "" && err != nil {
+			t.Errorf("Validate(%+v) = %v, want nil", tt.server, err)
+		}
+		if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("Validate(%+v) = %v, want %q", tt.server, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAgentMCPServersMarkdown(t *testing.T) {
+	data := []byte("---\nname: triage\nmcp_servers:\n  - name: github\n    transport: http\n    url: https://example.com/mcp\n    allowed_tools: [create_issue]\n---\n\nTriage issues.\n")
+	agent, err := ParseAgentMarkdown(data)
+	if err != nil {
+		t.Fatalf("ParseAgentMarkdown: %v", err)
+	}
+	if len(agent.MCPServers) != 1 || agent.MCPServers[0].URL != "https://example.com/mcp" || agent.MCPServers[0].AllowedTools[0] != "create_issue" {
+		t.Fatalf("MCPServers = %+v", agent.MCPServers)
+	}
+
+	out, err := json.Marshal(agent)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if err := ValidateAgentJSON(out); err != nil {
+		t.Errorf("schema rejects mcp_servers: %v\n%s", err, out)
+	}
+}
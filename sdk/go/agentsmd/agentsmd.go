@@ -0,0 +1,365 @@
+// Package agentsmd converts between multi-agent-spec definitions and
+// AGENTS.md, the markdown instructions file read by coding agents, and its
+// per-tool variants (CLAUDE.md, GEMINI.md, ...).
+//
+// Export writes a team as an AGENTS.md with an "## Agents" section holding
+// one "### <agent>" subsection per agent. Import reads that layout back, and
+// turns a plain AGENTS.md without an Agents section into a single-agent team
+// whose instructions are the file's content, so repositories that already
+// carry one can bootstrap a spec team.
+//
+// Example:
+//
+//	path, err := agentsmd.FindFile(".")
+//	if err != nil {
+//	    return err
+//	}
+//	data, _ := os.ReadFile(path)
+//	team, agents, err := agentsmd.Import(data)
+package agentsmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/internal/slug"
+)
+
+// DefaultTeamVersion is the version given to imported teams.
+const DefaultTeamVersion = "0.1.0"
+
+// headingShift is how many levels agent instruction headings are demoted
+// so they nest under the agent's "###" heading.
+const headingShift = 3
+
+// Variant is a file under which a coding agent reads AGENTS.md-style
+// instructions.
+type Variant struct {
+	// Name is the short name used to select the variant (e.g., claude).
+	Name string
+
+	// Path is the slash-separated path relative to the repository root.
+	Path string
+}
+
+// Variants returns the known instruction files in lookup order.
+func Variants() []Variant {
+	return []Variant{
+		{Name: "agents", Path: "AGENTS.md"},
+		{Name: "claude", Path: "CLAUDE.md"},
+		{Name: "gemini", Path: "GEMINI.md"},
+		{Name: "copilot", Path: ".github/copilot-instructions.md"},
+	}
+}
+
+// LookupVariant returns the variant with the given name.
+func LookupVariant(name string) (Variant, bool) {
+	for _, v := range Variants() {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variant{}, false
+}
+
+// FindFile returns the path of the first variant present in dir.
+func FindFile(dir string) (string, error) {
+	for _, v := range Variants() {
+		path := filepath.Join(dir, filepath.FromSlash(v.Path))
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no AGENTS.md or tool variant in %s", dir)
+}
+
+// Export renders agents as an AGENTS.md document titled with the team name.
+// Agents are written in the order given; team may be nil.
+func Export(team *multiagentspec.Team, agents []*multiagentspec.Agent) []byte {
+	var buf bytes.Buffer
+	title := "Agents"
+	if team != nil && team.Name != "" {
+		title = team.Name
+	}
+	fmt.Fprintf(&buf, "# %s\n", title)
+	if team != nil && team.Description != "" {
+		fmt.Fprintf(&buf, "\n%s\n", strings.TrimSpace(team.Description))
+	}
+
+	buf.WriteString("\n## Agents\n")
+	for _, a := range agents {
+		fmt.Fprintf(&buf, "\n### %s\n", a.QualifiedName())
+		if a.Description != "" {
+			fmt.Fprintf(&buf, "\n%s\n", strings.TrimSpace(a.Description))
+		}
+
+		var fields []string
+		for _, f := range agentFields {
+			if v := f.get(a); v != "" {
+				fields = append(fields, fmt.Sprintf("- %s: %s", f.key, v))
+			}
+		}
+		if len(fields) > 0 {
+			fmt.Fprintf(&buf, "\n%s\n", strings.Join(fields, "\n"))
+		}
+
+		if instructions := strings.TrimSpace(a.Instructions); instructions != "" {
+			fmt.Fprintf(&buf, "\n%s\n", shiftHeadings(instructions, headingShift))
+		}
+	}
+	return buf.Bytes()
+}
+
+// agentField is an agent setting written as a "- Key: value" list item.
+type agentField struct {
+	key string
+	get func(*multiagentspec.Agent) string
+	set func(*multiagentspec.Agent, string)
+}
+
+var agentFields = []agentField{
+	{
+		key: "Model",
+		get: func(a *multiagentspec.Agent) string { return string(a.Model) },
+		set: func(a *multiagentspec.Agent, v string) { a.Model = multiagentspec.Model(v) },
+	},
+	{
+		key: "Tools",
+		get: func(a *multiagentspec.Agent) string { return strings.Join(a.Tools, ", ") },
+		set: func(a *multiagentspec.Agent, v string) { a.Tools = splitList(v) },
+	},
+	{
+		key: "Allowed tools",
+		get: func(a *multiagentspec.Agent) string { return strings.Join(a.AllowedTools, ", ") },
+		set: func(a *multiagentspec.Agent, v string) { a.AllowedTools = splitList(v) },
+	},
+	{
+		key: "Role",
+		get: func(a *multiagentspec.Agent) string { return a.Role },
+		set: func(a *multiagentspec.Agent, v string) { a.Role = v },
+	},
+	{
+		key: "Goal",
+		get: func(a *multiagentspec.Agent) string { return a.Goal },
+		set: func(a *multiagentspec.Agent, v string) { a.Goal = v },
+	},
+}
+
+// Import reads an AGENTS.md document into a team and its agents. The team
+// is named after the document's title. Without an "## Agents" section the
+// whole document becomes the instructions of a single agent.
+func Import(data []byte) (*multiagentspec.Team, []*multiagentspec.Agent, error) {
+	doc := parseSections(string(data))
+	name := slug.Make(doc.title)
+	if name == "" {
+		name = "agents"
+	}
+	team := multiagentspec.NewTeam(name, DefaultTeamVersion)
+
+	var agents []*multiagentspec.Agent
+	if doc.agents == nil {
+		body := strings.TrimSpace(doc.body)
+		if body == "" {
+			return nil, nil, fmt.Errorf("document has no instructions")
+		}
+		agents = []*multiagentspec.Agent{{Name: name, Instructions: body}}
+	} else {
+		team.Description = strings.TrimSpace(doc.preamble)
+		for _, s := range doc.agents {
+			a, err := parseAgent(s)
+			if err != nil {
+				return nil, nil, err
+			}
+			agents = append(agents, a)
+		}
+		if len(agents) == 0 {
+			return nil, nil, fmt.Errorf("agents section has no agents")
+		}
+	}
+
+	for _, a := range agents {
+		team.Agents = append(team.Agents, a.QualifiedName())
+	}
+	return team, agents, nil
+}
+
+// document is an AGENTS.md split into the parts Import reads.
+type document struct {
+	title    string
+	body     string         // everything after the title
+	preamble string         // text between the title and the first "##" heading
+	agents   []agentSection // nil when there is no "## Agents" section
+}
+
+type agentSection struct {
+	heading string
+	lines   []string
+}
+
+// parseSections splits the document on headings outside code fences.
+func parseSections(text string) document {
+	var doc document
+	var body, preamble []string
+	current := -1 // index of the agent section being read
+	inAgents, seenSection, seenContent := false, false, false
+	for _, l := range fenceAware(strings.Split(text, "\n")) {
+		level, heading := l.heading()
+		if level == 1 && !seenContent {
+			doc.title = heading
+			seenContent = true
+			continue
+		}
+		if strings.TrimSpace(l.text) != "" {
+			seenContent = true
+		}
+
+		switch {
+		case level == 1 || level == 2:
+			seenSection = true
+			inAgents = level == 2 && strings.EqualFold(heading, "agents")
+			if inAgents && doc.agents == nil {
+				doc.agents = []agentSection{}
+			}
+			current = -1
+		case level == 3 && inAgents:
+			doc.agents = append(doc.agents, agentSection{heading: heading})
+			current = len(doc.agents) - 1
+		case current >= 0:
+			doc.agents[current].lines = append(doc.agents[current].lines, l.text)
+		}
+
+		body = append(body, l.text)
+		if !seenSection {
+			preamble = append(preamble, l.text)
+		}
+	}
+	doc.body = strings.Join(body, "\n")
+	doc.preamble = strings.Join(preamble, "\n")
+	return doc
+}
+
+// parseAgent reads an agent subsection: an optional description paragraph,
+// optional "- Key: value" settings, then the instructions.
+func parseAgent(s agentSection) (*multiagentspec.Agent, error) {
+	namespace, name := multiagentspec.ParseQualifiedName(strings.TrimSpace(s.heading))
+	if name == "" {
+		return nil, fmt.Errorf("agent heading %q has no name", s.heading)
+	}
+	a := &multiagentspec.Agent{Name: name, Namespace: namespace}
+
+	lines := s.lines
+	skipBlank := func() {
+		for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+			lines = lines[1:]
+		}
+	}
+
+	skipBlank()
+	var description []string
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) != "" && !strings.HasPrefix(lines[0], "- ") && !strings.HasPrefix(lines[0], "#") {
+		description = append(description, strings.TrimSpace(lines[0]))
+		lines = lines[1:]
+	}
+	a.Description = strings.Join(description, " ")
+
+	skipBlank()
+	for len(lines) > 0 {
+		key, value, ok := strings.Cut(strings.TrimPrefix(lines[0], "- "), ":")
+		if !ok || !strings.HasPrefix(lines[0], "- ") {
+			break
+		}
+		f := lookupField(strings.TrimSpace(key))
+		if f == nil {
+			break
+		}
+		f.set(a, strings.TrimSpace(value))
+		lines = lines[1:]
+	}
+
+	a.Instructions = shiftHeadings(strings.TrimSpace(strings.Join(lines, "\n")), -headingShift)
+	return a, nil
+}
+
+func lookupField(key string) *agentField {
+	for i := range agentFields {
+		if strings.EqualFold(agentFields[i].key, key) {
+			return &agentFields[i]
+		}
+	}
+	return nil
+}
+
+// line is a document line, marked when it sits inside a code fence.
+type line struct {
+	text    string
+	inFence bool
+}
+
+// heading returns the ATX heading level and text, or 0 for other lines.
+func (l line) heading() (int, string) {
+	if l.inFence {
+		return 0, ""
+	}
+	level := 0
+	for level < len(l.text) && l.text[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (level < len(l.text) && l.text[level] != ' ') {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(strings.TrimRight(strings.TrimSpace(l.text[level:]), "#"))
+}
+
+func fenceAware(texts []string) []line {
+	lines := make([]line, len(texts))
+	fence := ""
+	for i, t := range texts {
+		trimmed := strings.TrimSpace(t)
+		lines[i] = line{text: t, inFence: fence != ""}
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+			lines[i].inFence = true
+		case fence != "" && strings.HasPrefix(trimmed, fence):
+			fence = ""
+		}
+	}
+	return lines
+}
+
+// shiftHeadings moves headings outside code fences by delta levels,
+// clamped to 1..6.
+func shiftHeadings(text string, delta int) string {
+	lines := fenceAware(strings.Split(text, "\n"))
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		level, heading := l.heading()
+		if level == 0 {
+			out[i] = l.text
+			continue
+		}
+		level += delta
+		if level < 1 {
+			level = 1
+		}
+		if level > 6 {
+			level = 6
+		}
+		out[i] = strings.Repeat("#", level) + " " + heading
+	}
+	return strings.Join(out, "\n")
+}
+
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,97 @@
+package agentsmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	team := multiagentspec.NewTeam("release-team", "1.0.0")
+	team.Description = "Validates releases before tagging."
+	qa := multiagentspec.NewAgent("qa", "Runs tests").WithNamespace("shared").WithTools("Read", "Bash").
+		WithInstructions("Run the suite.\n\n## Reporting\n\nSummarize failures:\n\n```sh\n# not a heading\ngo test ./...\n```")
+	qa.Role = "QA Engineer"
+	pm := multiagentspec.NewAgent("pm", "Checks the release plan").WithInstructions("Review the plan.")
+
+	data := Export(team, []*multiagentspec.Agent{pm, qa})
+	for _, want := range []string{
+		"# release-team\n\nValidates releases before tagging.\n\n## Agents\n\n### pm\n\nChecks the release plan\n\n- Model: sonnet\n\nReview the plan.\n",
+		"### shared/qa\n\nRuns tests\n\n- Model: sonnet\n- Tools: Read, Bash\n- Role: QA Engineer\n",
+		"\n##### Reporting\n",
+		"```sh\n# not a heading\n",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("AGENTS.md missing %q:\n%s", want, data)
+		}
+	}
+
+	gotTeam, agents, err := Import(data)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if gotTeam.Name != "release-team" || gotTeam.Description != team.Description || strings.Join(gotTeam.Agents, ",") != "pm,shared/qa" {
+		t.Errorf("team = %+v", gotTeam)
+	}
+	if len(agents) != 2 {
+		t.Fatalf("got %d agents, want 2", len(agents))
+	}
+	got := agents[1]
+	if got.Name != "qa" || got.Namespace != "shared" || got.Description != "Runs tests" || got.Model != multiagentspec.ModelSonnet ||
+		strings.Join(got.Tools, ",") != "Read,Bash" || got.Role != "QA Engineer" {
+		t.Errorf("qa = %+v", got)
+	}
+	if got.Instructions != qa.Instructions {
+		t.Errorf("qa instructions =\n%s\nwant\n%s", got.Instructions, qa.Instructions)
+	}
+	if agents[0].Instructions != "Review the plan." {
+		t.Errorf("pm instructions = %q", agents[0].Instructions)
+	}
+}
+
+func TestImportPlainAgentsMD(t *testing.T) {
+	data := []byte("# Acme API\n\nUse Go 1.22.\n\n## Testing\n\nRun `make test` before committing.\n")
+	team, agents, err := Import(data)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if team.Name != "acme-api" || len(team.Agents) != 1 || team.Agents[0] != "acme-api" {
+		t.Errorf("team = %+v", team)
+	}
+	if len(agents) != 1 || agents[0].Instructions != "Use Go 1.22.\n\n## Testing\n\nRun `make test` before committing." {
+		t.Errorf("agents = %+v", agents)
+	}
+
+	if _, _, err := Import([]byte("# Empty\n")); err == nil {
+		t.Error("Import of a document without instructions should fail")
+	}
+	if _, _, err := Import([]byte("# T\n\n## Agents\n\nNo agents yet.\n")); err == nil {
+		t.Error("Import of an empty agents section should fail")
+	}
+}
+
+func TestFindFile(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := FindFile(dir); err == nil {
+		t.Error("FindFile in an empty directory should fail")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"CLAUDE.md", ".github/copilot-instructions.md"} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte("# x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, err := FindFile(dir); err != nil || got != filepath.Join(dir, "CLAUDE.md") {
+		t.Errorf("FindFile = %q, %v; want CLAUDE.md", got, err)
+	}
+
+	if v, ok := LookupVariant("gemini"); !ok || v.Path != "GEMINI.md" {
+		t.Errorf("LookupVariant(gemini) = %+v, %v", v, ok)
+	}
+}
@@ -0,0 +1,166 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrApprovalNotFound is returned by an ApprovalStore for a gate with no
+// approval record.
+var ErrApprovalNotFound = errors.New("approval not found")
+
+// ApprovalDecision is the state of an approval.
+type ApprovalDecision string
+
+const (
+	// ApprovalPending is an approval waiting for a decision.
+	ApprovalPending ApprovalDecision = "pending"
+
+	// ApprovalApproved is an approval granted: the gated step may run.
+	ApprovalApproved ApprovalDecision = "approved"
+
+	// ApprovalRejected is an approval refused: the gated step is NO-GO.
+	ApprovalRejected ApprovalDecision = "rejected"
+)
+
+// Approval records a human decision on an approval gate: a task with
+// HumanInLoop set, in a workflow step of a run. Until it is approved, the
+// executor does not run the step.
+type Approval struct {
+	// Run identifies the workflow run.
+	Run string `json:"run"`
+
+	// Gate is the gated task as step.task (e.g., "deploy.sign-off").
+	Gate string `json:"gate"`
+
+	// Request is what the approver is asked, from the task's HumanInLoop.
+	Request string `json:"request"`
+
+	// Approvers are who may decide; empty means anyone.
+	Approvers []string `json:"approvers,omitempty"`
+
+	// Decision is pending until someone approves or rejects.
+	Decision ApprovalDecision `json:"decision"`
+
+	// DecidedBy is who approved or rejected.
+	DecidedBy string `json:"decided_by,omitempty"`
+
+	// Comment is the decider's note.
+	Comment string `json:"comment,omitempty"`
+
+	// RequestedAt is when the executor first reached the gate.
+	RequestedAt time.Time `json:"requested_at"`
+
+	// DecidedAt is when the decision was made.
+	DecidedAt *time.Time `json:"decided_at,omitempty"`
+
+	// ExpiresAt is when a pending approval lapses, failing the step.
+	// Nil means never.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ApprovalGate returns the gate name of a task in a workflow step.
+func ApprovalGate(step, task string) string {
+	return step + "." + task
+}
+
+// Expired reports whether the approval is still pending after ExpiresAt.
+func (a *Approval) Expired(now time.Time) bool {
+	return a.Decision == ApprovalPending && a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)
+}
+
+// Decide records by's decision at now. It fails if the approval was
+// already decided or has expired, or if Approvers is set and does not
+// include by.
+func (a *Approval) Decide(by string, decision ApprovalDecision, comment string, now time.Time) error {
+	switch {
+	case decision != ApprovalApproved && decision != ApprovalRejected:
+		return fmt.Errorf("gate %s: invalid decision %q", a.Gate, decision)
+	case by == "":
+		return fmt.Errorf("gate %s: approver is required", a.Gate)
+	case a.Decision != ApprovalPending:
+		return fmt.Errorf("gate %s was already %s by %s", a.Gate, a.Decision, a.DecidedBy)
+	case a.Expired(now):
+		return fmt.Errorf("gate %s expired at %s", a.Gate, a.ExpiresAt.Format(time.RFC3339))
+	case len(a.Approvers) > 0 && !containsString(a.Approvers, by):
+		return fmt.Errorf("gate %s: %s is not an approver (want one of %s)", a.Gate, by, strings.Join(a.Approvers, ", "))
+	}
+	a.Decision = decision
+	a.DecidedBy = by
+	a.Comment = comment
+	now = now.UTC()
+	a.DecidedAt = &now
+	return nil
+}
+
+// TaskResult returns the gated task's result: GO once approved, NO-GO if
+// rejected or expired, and SKIP while pending.
+func (a *Approval) TaskResult(task string, now time.Time) TaskResult {
+	switch {
+	case a.Decision == ApprovalApproved:
+		return TaskResult{ID: task, Status: StatusGo, Detail: a.decisionDetail()}
+	case a.Decision == ApprovalRejected:
+		return TaskResult{ID: task, Status: StatusNoGo, Detail: a.decisionDetail()}
+	case a.Expired(now):
+		return TaskResult{ID: task, Status: StatusNoGo, Detail: "approval expired at " + a.ExpiresAt.Format(time.RFC3339)}
+	}
+	return SkipTask(task, "waiting for approval of "+a.Gate)
+}
+
+// decisionDetail describes a decision, e.g., "approved by alice: ship it".
+func (a *Approval) decisionDetail() string {
+	detail := fmt.Sprintf("%s by %s", a.Decision, a.DecidedBy)
+	if a.Comment != "" {
+		detail += ": " + a.Comment
+	}
+	return detail
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ApprovalStore keeps the approvals of workflow runs.
+// reportstore.Store implements it.
+type ApprovalStore interface {
+	// GetApproval returns the approval for gate in run, or an error
+	// wrapping ErrApprovalNotFound.
+	GetApproval(ctx context.Context, run, gate string) (*Approval, error)
+
+	// PutApproval saves a, replacing any approval for its run and gate.
+	PutApproval(ctx context.Context, a *Approval) error
+}
+
+// ApprovalPolicy sets who may decide the approvals an executor requests
+// and how long they stay open.
+type ApprovalPolicy struct {
+	// Approvers are who may decide; empty means anyone.
+	Approvers []string
+
+	// TTL is how long a requested approval stays pending before it
+	// expires; zero means it never does.
+	TTL time.Duration
+}
+
+// ApprovalPendingError is returned by Executor.Run when the run paused at
+// approval gates. Running the workflow again with the same run, once the
+// gates are decided, resumes it.
+type ApprovalPendingError struct {
+	// Run identifies the workflow run.
+	Run string
+
+	// Gates are the gates waiting for a decision, in workflow order.
+	Gates []string
+}
+
+func (e *ApprovalPendingError) Error() string {
+	return fmt.Sprintf("run %s: waiting for approval of %s", e.Run, strings.Join(e.Gates, ", "))
+}
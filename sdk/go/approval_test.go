@@ -0,0 +1,175 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestApprovalDecide(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	a := &Approval{Gate: "deploy.sign-off", Approvers: []string{"alice", "bob"}, Decision: ApprovalPending, ExpiresAt: &expires}
+	if r := a.TaskResult("sign-off", now); r.Status != StatusSkip || r.Detail != "waiting for approval of deploy.sign-off" {
+		t.Errorf("pending TaskResult() = %+v", r)
+	}
+	if err := a.Decide("mallory", ApprovalApproved, "", now); err == nil || !strings.Contains(err.Error(), "mallory is not an approver") {
+		t.Errorf("Decide(non-approver) = %v", err)
+	}
+	if err := a.Decide("bob", "maybe", "", now); err == nil {
+		t.Error("Decide() accepted an invalid decision")
+	}
+	if err := a.Decide("bob", ApprovalRejected, "tests are red", now); err != nil {
+		t.Fatalf("Decide() = %v", err)
+	}
+	if a.DecidedBy != "bob" || a.DecidedAt == nil || !a.DecidedAt.Equal(now) {
+		t.Errorf("decided approval = %+v", a)
+	}
+	if r := a.TaskResult("sign-off", now); r.Status != StatusNoGo || r.Detail != "rejected by bob: tests are red" {
+		t.Errorf("rejected TaskResult() = %+v", r)
+	}
+	if err := a.Decide("alice", ApprovalApproved, "", now); err == nil || !strings.Contains(err.Error(), "already rejected by bob") {
+		t.Errorf("Decide(decided) = %v", err)
+	}
+
+	late := &Approval{Gate: "deploy.sign-off", Decision: ApprovalPending, ExpiresAt: &expires}
+	if !late.Expired(expires) || late.Expired(now) {
+		t.Error("Expired() is wrong around ExpiresAt")
+	}
+	if err := late.Decide("alice", ApprovalApproved, "", expires); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Errorf("Decide(expired) = %v", err)
+	}
+	if r := late.TaskResult("sign-off", expires); r.Status != StatusNoGo {
+		t.Errorf("expired TaskResult() = %+v, want NO-GO", r)
+	}
+}
+
+// memApprovals is an in-memory ApprovalStore.
+type memApprovals struct {
+	mu        sync.Mutex
+	approvals map[string]Approval
+}
+
+func (m *memApprovals) GetApproval(_ context.Context, run, gate string) (*Approval, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.approvals[run+"/"+gate]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrApprovalNotFound, gate)
+	}
+	return &a, nil
+}
+
+func (m *memApprovals) PutApproval(_ context.Context, a *Approval) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.approvals == nil {
+		m.approvals = make(map[string]Approval)
+	}
+	m.approvals[a.Run+"/"+a.Gate] = *a
+	return nil
+}
+
+func (m *memApprovals) decide(t *testing.T, run, gate string, decision ApprovalDecision) {
+	t.Helper()
+	a, err := m.GetApproval(context.Background(), run, gate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Decide("alice", decision, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	m.PutApproval(context.Background(), a)
+}
+
+func TestExecutorApprovals(t *testing.T) {
+	deployer := commandAgent("deployer", "deploy")
+	deployer.Tasks = append(deployer.Tasks, Task{ID: "sign-off", HumanInLoop: "Confirm the release notes"})
+	agents := []*Agent{commandAgent("builder", "build"), deployer, commandAgent("notifier", "notify")}
+	team := &Team{Name: "release", Agents: []string{"builder", "deployer", "notifier"}}
+	store := &memApprovals{}
+	policy := ApprovalPolicy{Approvers: []string{"alice"}, TTL: time.Hour}
+
+	run := func(prior []AgentResult) (*TeamReport, *fakeRunner, []StepState, error) {
+		t.Helper()
+		tasks := &fakeRunner{}
+		var states []StepState
+		e, err := NewExecutor(team, agents, WithTaskRunner(tasks), WithApprovals(store, "run-1", policy),
+			WithPriorResults(prior), WithStepObserver(func(ev StepEvent) { states = append(states, ev.State) }))
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := e.Run(context.Background())
+		return report, tasks, states, err
+	}
+
+	report, tasks, states, err := run(nil)
+	var pending *ApprovalPendingError
+	if !errors.As(err, &pending) || pending.Run != "run-1" || strings.Join(pending.Gates, ",") != "deployer.sign-off" {
+		t.Fatalf("Run() = %v, want gate deployer.sign-off pending", err)
+	}
+	if got := strings.Join(tasks.steps(), ","); got != "builder" {
+		t.Errorf("ran %s, want only builder", got)
+	}
+	if want := []StepState{StepRunning, StepDone, StepWaiting, StepSkipped}; !reflect.DeepEqual(states, want) {
+		t.Errorf("step states = %v, want %v", states, want)
+	}
+	if d := report.Teams[2].Tasks[0].Detail; d != "upstream step deployer is waiting for approval" {
+		t.Errorf("notifier detail = %q", d)
+	}
+	a, err := store.GetApproval(context.Background(), "run-1", "deployer.sign-off")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Request != "Confirm the release notes" || a.Decision != ApprovalPending || a.ExpiresAt == nil || a.Approvers[0] != "alice" {
+		t.Errorf("requested approval = %+v", a)
+	}
+
+	// Resume once approved: builder is not run again.
+	store.decide(t, "run-1", "deployer.sign-off", ApprovalApproved)
+	prior := []AgentResult{{AgentID: "builder", StepID: "builder", Status: StatusGo, Tasks: []TaskResult{PassTask("build", "")}}}
+	report, tasks, _, err = run(prior)
+	if err != nil {
+		t.Fatalf("resumed Run() = %v", err)
+	}
+	if got := strings.Join(tasks.steps(), ","); got != "deployer,notifier" {
+		t.Errorf("resumed run ran %s, want deployer,notifier", got)
+	}
+	if report.Status != StatusGo {
+		t.Errorf("resumed report status = %s, want GO", report.Status)
+	}
+	var signOff TaskResult
+	for _, task := range report.Teams[1].Tasks {
+		if task.ID == "sign-off" {
+			signOff = task
+		}
+	}
+	if signOff.Status != StatusGo || signOff.Detail != "approved by alice" {
+		t.Errorf("sign-off task = %+v", signOff)
+	}
+}
+
+func TestExecutorApprovalRejected(t *testing.T) {
+	gated := &Agent{Name: "gated", Tasks: []Task{{ID: "ok", HumanInLoop: "Approve?"}}}
+	team := &Team{Name: "t", Agents: []string{"gated"}}
+	store := &memApprovals{}
+	store.PutApproval(context.Background(), &Approval{Run: "r", Gate: "gated.ok", Decision: ApprovalPending})
+	store.decide(t, "r", "gated.ok", ApprovalRejected)
+	tasks := &fakeRunner{}
+	e, err := NewExecutor(team, []*Agent{gated}, WithTaskRunner(tasks), WithApprovals(store, "r", ApprovalPolicy{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := report.Teams[0]; s.Status != StatusNoGo || s.Tasks[0].Detail != "gate gated.ok rejected by alice" || len(tasks.runs) != 0 {
+		t.Errorf("gated = %s %q, %d runs", s.Status, s.Tasks[0].Detail, len(tasks.runs))
+	}
+}
@@ -0,0 +1,153 @@
+package multiagentspec
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Artifact references a raw output that supports a team's results, such
+// as a log, SBOM, or screenshot.
+type Artifact struct {
+	// Name is the artifact's display name (e.g., "Unit test log").
+	Name string `json:"name" jsonschema_description:"Display name of the artifact (e.g., Unit test log)"`
+
+	// Path is the artifact's file, relative to the report's directory
+	// unless absolute. Bundles include the file.
+	Path string `json:"path,omitempty" jsonschema_description:"File path of the artifact, relative to the report's directory unless absolute"`
+
+	// URL is where the artifact can be fetched, for artifacts kept
+	// elsewhere (e.g., CI artifact storage). It is used when Path is unset.
+	URL string `json:"url,omitempty" jsonschema_description:"URL of an artifact kept elsewhere, used when path is unset"`
+
+	// MediaType is the artifact's media type (e.g., text/plain,
+	// application/spdx+json, image/png).
+	MediaType string `json:"media_type,omitempty" jsonschema_description:"Media type of the artifact (e.g., text/plain, application/spdx+json)"`
+}
+
+// Link returns the artifact's Path, or its URL when it has no path.
+func (a Artifact) Link() string {
+	if a.Path != "" {
+		return a.Path
+	}
+	return a.URL
+}
+
+// artifactMD renders the artifact as a Markdown list item linking to it.
+func artifactMD(a Artifact) string {
+	link := a.Link()
+	if strings.ContainsAny(link, " ()<>") {
+		link = "<" + link + ">"
+	}
+	item := fmt.Sprintf("- [%s](%s)", a.Name, link)
+	if a.MediaType != "" {
+		item += " (" + a.MediaType + ")"
+	}
+	return item
+}
+
+// artifactsMD renders artifacts as a Markdown list.
+func artifactsMD(artifacts []Artifact) string {
+	items := make([]string, len(artifacts))
+	for i, a := range artifacts {
+		items[i] = artifactMD(a)
+	}
+	return strings.Join(items, "\n")
+}
+
+// BundleReport is the name of the report in a bundle.
+const BundleReport = "report.json"
+
+// WriteBundle writes a zip archive to w holding the report as
+// BundleReport and the files of its teams' artifacts that have a Path,
+// read relative to dir. The files are stored under
+// artifacts/<team ID>/, and the bundled report's paths point at them, so
+// the archive can be unpacked and rendered anywhere. Artifacts with only
+// a URL are left as they are. The report is not modified.
+func WriteBundle(w io.Writer, report *TeamReport, dir string) error {
+	bundled := *report
+	bundled.Teams = make([]TeamSection, len(report.Teams))
+	zw := zip.NewWriter(w)
+	names := make(map[string]bool)
+	for i, t := range report.Teams {
+		bundled.Teams[i] = t
+		if len(t.Artifacts) == 0 {
+			continue
+		}
+		bundled.Teams[i].Artifacts = make([]Artifact, len(t.Artifacts))
+		for j, a := range t.Artifacts {
+			if a.Path != "" {
+				name := bundleName(names, "artifacts/"+t.ID+"/"+filepath.Base(a.Path))
+				if err := addBundleFile(zw, name, bundlePath(dir, a.Path)); err != nil {
+					return fmt.Errorf("team %s: artifact %s: %w", t.ID, a.Name, err)
+				}
+				a.Path = name
+			}
+			bundled.Teams[i].Artifacts[j] = a
+		}
+	}
+	data, err := bundled.ToJSON()
+	if err != nil {
+		return err
+	}
+	f, err := zw.Create(BundleReport)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// bundleName returns name, or name with a number before its extension
+// if it is already taken, and marks it taken.
+func bundleName(taken map[string]bool, name string) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for n := 2; taken[name]; n++ {
+		name = fmt.Sprintf("%s-%d%s", base, n, ext)
+	}
+	taken[name] = true
+	return name
+}
+
+// bundlePath returns the file an artifact path refers to.
+func bundlePath(dir, p string) string {
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(dir, filepath.FromSlash(p))
+}
+
+// addBundleFile copies the file at src into zw as name.
+func addBundleFile(zw *zip.Writer, name, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if fi.IsDir() {
+		return fmt.Errorf("%s is a directory", src)
+	}
+	h, err := zip.FileInfoHeader(fi)
+	if err != nil {
+		return err
+	}
+	h.Name = name
+	h.Method = zip.Deflate
+	dst, err := zw.CreateHeader(h)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, f)
+	return err
+}
@@ -0,0 +1,99 @@
+package multiagentspec
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestArtifactLink(t *testing.T) {
+	tests := []struct {
+		artifact Artifact
+		want     string
+	}{
+		{Artifact{Name: "log", Path: "logs/unit.log", URL: "https://ci.example.com/unit.log"}, "logs/unit.log"},
+		{Artifact{Name: "log", URL: "https://ci.example.com/unit.log"}, "https://ci.example.com/unit.log"},
+		{Artifact{Name: "log"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.artifact.Link(); got != tt.want {
+			t.Errorf("%+v.Link() = %q, want %q", tt.artifact, got, tt.want)
+		}
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "logs", "unit.log"), "ok\n")
+	writeFile(t, filepath.Join(dir, "other", "unit.log"), "also ok\n")
+	sbom := filepath.Join(t.TempDir(), "sbom.json")
+	writeFile(t, sbom, "{}")
+	report := &TeamReport{Project: "app", Teams: []TeamSection{
+		{ID: "qa", Artifacts: []Artifact{
+			{Name: "Unit log", Path: "logs/unit.log"},
+			{Name: "Other log", Path: "other/unit.log"},
+			{Name: "Trace", URL: "https://ci.example.com/trace"},
+		}},
+		{ID: "security", Artifacts: []Artifact{{Name: "SBOM", Path: sbom, MediaType: "application/json"}}},
+		{ID: "docs"},
+	}}
+
+	var buf bytes.Buffer
+	if err := WriteBundle(&buf, report, dir); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	for name, want := range map[string]string{
+		"artifacts/qa/unit.log":        "ok\n",
+		"artifacts/qa/unit-2.log":      "also ok\n",
+		"artifacts/security/sbom.json": "{}",
+	} {
+		if files[name] != want {
+			t.Errorf("%s = %q, want %q", name, files[name], want)
+		}
+	}
+
+	bundled, err := ParseTeamReport([]byte(files[BundleReport]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, team := range bundled.Teams {
+		for _, a := range team.Artifacts {
+			paths = append(paths, a.Link())
+		}
+	}
+	want := []string{"artifacts/qa/unit.log", "artifacts/qa/unit-2.log", "https://ci.example.com/trace", "artifacts/security/sbom.json"}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("bundled links = %v, want %v", paths, want)
+	}
+	if report.Teams[0].Artifacts[0].Path != "logs/unit.log" {
+		t.Errorf("report modified: %+v", report.Teams[0].Artifacts)
+	}
+}
+
+func TestWriteBundleMissingFile(t *testing.T) {
+	report := &TeamReport{Teams: []TeamSection{{ID: "qa", Artifacts: []Artifact{{Name: "Unit log", Path: "unit.log"}}}}}
+	err := WriteBundle(io.Discard, report, t.TempDir())
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WriteBundle() error = %v, want not exist", err)
+	}
+}
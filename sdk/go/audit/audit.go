@@ -0,0 +1,453 @@
+// Package audit records significant run events (step starts and finishes,
+// messages, approvals, retries, status changes) in an append-only,
+// tamper-evident log for compliance reviews.
+//
+// The log is a JSON Lines file with one Entry per line. Each entry carries
+// a sequence number, a UTC timestamp, the hash of the previous entry, its
+// own SHA-256 hash, and a signature over that hash, so editing, removing,
+// or reordering entries breaks verification.
+//
+// Example:
+//
+//	log, err := audit.Open("runs/audit.jsonl", audit.NewHMACSigner(key))
+//	if err != nil {
+//	    return err
+//	}
+//	defer log.Close()
+//	_, err = log.Append(audit.Entry{Type: audit.EventStepStarted, Run: runID, Step: "review"})
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// EventType identifies the kind of audited event.
+type EventType string
+
+const (
+	// EventStepStarted records a workflow step starting.
+	EventStepStarted EventType = "step_started"
+
+	// EventStepFinished records a workflow step finishing.
+	EventStepFinished EventType = "step_finished"
+
+	// EventMessageSent records an inter-agent message.
+	EventMessageSent EventType = "message_sent"
+
+	// EventApprovalGranted records a human or lead approving a request.
+	EventApprovalGranted EventType = "approval_granted"
+
+	// EventApprovalRejected records a human or lead rejecting a request.
+	EventApprovalRejected EventType = "approval_rejected"
+
+	// EventRetry records a step being retried.
+	EventRetry EventType = "retry"
+
+	// EventStatusChanged records a step, team, or run changing status.
+	EventStatusChanged EventType = "status_changed"
+)
+
+// EventTypes returns all event types.
+func EventTypes() []EventType {
+	return []EventType{
+		EventStepStarted, EventStepFinished, EventMessageSent, EventApprovalGranted,
+		EventApprovalRejected, EventRetry, EventStatusChanged,
+	}
+}
+
+// Entry is one audit log record.
+type Entry struct {
+	// Seq is the entry's position in the log, starting at 1.
+	Seq int64 `json:"seq"`
+
+	// Time is when the event happened, in UTC.
+	Time time.Time `json:"time"`
+
+	// Type is the event type.
+	Type EventType `json:"type"`
+
+	// Run identifies the workflow run.
+	Run string `json:"run,omitempty"`
+
+	// Team is the team name.
+	Team string `json:"team,omitempty"`
+
+	// Step is the workflow step name.
+	Step string `json:"step,omitempty"`
+
+	// Agent is the agent involved.
+	Agent string `json:"agent,omitempty"`
+
+	// Actor is who caused the event, e.g., the approving user.
+	Actor string `json:"actor,omitempty"`
+
+	// Status is the status after the event, for step finishes and status
+	// changes.
+	Status multiagentspec.Status `json:"status,omitempty"`
+
+	// Detail is a human-readable description.
+	Detail string `json:"detail,omitempty"`
+
+	// Data holds event-specific JSON.
+	Data json.RawMessage `json:"data,omitempty"`
+
+	// PrevHash is the hash of the previous entry; empty for the first.
+	PrevHash string `json:"prev_hash,omitempty"`
+
+	// Hash is the hex SHA-256 of the entry without Hash and Signature.
+	Hash string `json:"hash"`
+
+	// Signature is the signer's signature over Hash, hex encoded.
+	Signature string `json:"signature,omitempty"`
+}
+
+// MessageEntry returns an EventMessageSent entry for msg, with the message
+// as its data.
+func MessageEntry(run string, msg *multiagentspec.Message) (Entry, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return Entry{}, fmt.Errorf("marshal message: %w", err)
+	}
+	return Entry{
+		Type:   EventMessageSent,
+		Run:    run,
+		Agent:  msg.From,
+		Detail: fmt.Sprintf("%s from %s to %s", msg.Type, msg.From, msg.To),
+		Data:   data,
+	}, nil
+}
+
+// ApprovalEntry returns the entry for a decided approval gate:
+// EventApprovalGranted or EventApprovalRejected, with the decider as Actor,
+// the decision time, and the approval as its data. It fails for an approval
+// that is still pending.
+func ApprovalEntry(a *multiagentspec.Approval) (Entry, error) {
+	e := Entry{Run: a.Run, Actor: a.DecidedBy}
+	switch a.Decision {
+	case multiagentspec.ApprovalApproved:
+		e.Type = EventApprovalGranted
+	case multiagentspec.ApprovalRejected:
+		e.Type = EventApprovalRejected
+	default:
+		return Entry{}, fmt.Errorf("approval %s is %s", a.Gate, a.Decision)
+	}
+	e.Step, _, _ = strings.Cut(a.Gate, ".")
+	e.Detail = string(a.Decision) + " " + a.Gate
+	if a.Comment != "" {
+		e.Detail += ": " + a.Comment
+	}
+	if a.DecidedAt != nil {
+		e.Time = *a.DecidedAt
+	}
+	data, err := json.Marshal(a)
+	if err != nil {
+		return Entry{}, fmt.Errorf("marshal approval: %w", err)
+	}
+	e.Data = data
+	return e, nil
+}
+
+// StepEntry returns the entry for a workflow step event of run in team:
+// EventStepStarted as the step starts, EventRetry before it is retried,
+// and EventStepFinished once it has finished, been skipped, or paused for
+// approval, with the result's status and the result as its data.
+func StepEntry(run, team string, ev multiagentspec.StepEvent) (Entry, error) {
+	e := Entry{Run: run, Team: team, Step: ev.Step, Agent: ev.Agent}
+	switch ev.State {
+	case multiagentspec.StepRunning:
+		e.Type = EventStepStarted
+		return e, nil
+	case multiagentspec.StepRetrying:
+		e.Type = EventRetry
+		e.Detail = fmt.Sprintf("attempt %d failed: %s", ev.Attempt, ev.Result.Error)
+		return e, nil
+	}
+	e.Type = EventStepFinished
+	e.Status = ev.Result.Status
+	e.Detail = string(ev.State)
+	if ev.State != multiagentspec.StepDone && len(ev.Result.Tasks) > 0 {
+		e.Detail += ": " + ev.Result.Tasks[0].Detail
+	}
+	data, err := json.Marshal(ev.Result)
+	if err != nil {
+		return Entry{}, fmt.Errorf("marshal step result: %w", err)
+	}
+	e.Data = data
+	return e, nil
+}
+
+// StepAuditor returns a multiagentspec.StepAuditor that appends the step
+// events of run in team to l, for multiagentspec.WithAuditLog. When a
+// finished step changes the run's overall status, it also appends an
+// EventStatusChanged entry.
+func (l *Log) StepAuditor(run, team string) multiagentspec.StepAuditor {
+	return &stepAuditor{log: l, run: run, team: team}
+}
+
+type stepAuditor struct {
+	log       *Log
+	run, team string
+
+	// report collects the finished steps to track the run's status.
+	report multiagentspec.TeamReport
+	status multiagentspec.Status
+}
+
+func (a *stepAuditor) AuditStep(ev multiagentspec.StepEvent) error {
+	e, err := StepEntry(a.run, a.team, ev)
+	if err != nil {
+		return err
+	}
+	if _, err := a.log.Append(e); err != nil {
+		return err
+	}
+	if e.Type != EventStepFinished {
+		return nil
+	}
+
+	a.report.Teams = append(a.report.Teams, ev.Result.ToTeamSection())
+	status := a.report.ComputeOverallStatus()
+	if status == a.status {
+		return nil
+	}
+	detail := "run status " + string(status)
+	if a.status != "" {
+		detail = fmt.Sprintf("run status %s -> %s", a.status, status)
+	}
+	a.status = status
+	_, err = a.log.Append(Entry{Type: EventStatusChanged, Run: a.run, Team: a.team, Step: ev.Step, Status: status, Detail: detail})
+	return err
+}
+
+// computeHash returns the hex SHA-256 of e's JSON encoding with Hash and
+// Signature cleared.
+func (e Entry) computeHash() (string, error) {
+	e.Hash = ""
+	e.Signature = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("marshal entry: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Log is an append-only audit log file. It is safe for concurrent use.
+type Log struct {
+	mu     sync.Mutex
+	f      *os.File
+	signer Signer
+	seq    int64
+	last   string
+	now    func() time.Time
+}
+
+// Open opens the audit log at path, creating it if needed. Existing entries
+// are verified with signer before new ones are appended. A nil signer
+// leaves entries unsigned; they are still hash chained.
+func Open(path string, signer Signer) (*Log, error) {
+	entries, err := ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := Verify(entries, signer); err != nil {
+		return nil, fmt.Errorf("verify %s: %w", path, err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	l := &Log{f: f, signer: signer, now: time.Now}
+	if n := len(entries); n > 0 {
+		l.seq = entries[n-1].Seq
+		l.last = entries[n-1].Hash
+	}
+	return l, nil
+}
+
+// Append sets e's sequence number, timestamp (when zero), chain hash, and
+// signature, writes it to the log, and returns the written entry.
+func (l *Log) Append(e Entry) (Entry, error) {
+	if e.Type == "" {
+		return Entry{}, fmt.Errorf("entry type is required")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.Seq = l.seq + 1
+	if e.Time.IsZero() {
+		e.Time = l.now()
+	}
+	e.Time = e.Time.UTC()
+	e.PrevHash = l.last
+	hash, err := e.computeHash()
+	if err != nil {
+		return Entry{}, err
+	}
+	e.Hash = hash
+	e.Signature = ""
+	if l.signer != nil {
+		sig, err := sign(l.signer, hash)
+		if err != nil {
+			return Entry{}, fmt.Errorf("sign entry %d: %w", e.Seq, err)
+		}
+		e.Signature = sig
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, fmt.Errorf("marshal entry: %w", err)
+	}
+	if _, err := l.f.Write(append(line, '\n')); err != nil {
+		return Entry{}, fmt.Errorf("write entry %d: %w", e.Seq, err)
+	}
+	if err := l.f.Sync(); err != nil {
+		return Entry{}, fmt.Errorf("sync entry %d: %w", e.Seq, err)
+	}
+	l.seq = e.Seq
+	l.last = e.Hash
+	return e, nil
+}
+
+// Query returns the log's entries matching f.
+func (l *Log) Query(f Filter) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries, err := ReadFile(l.f.Name())
+	if err != nil {
+		return nil, err
+	}
+	return Query(entries, f), nil
+}
+
+// Close closes the log file.
+func (l *Log) Close() error {
+	return l.f.Close()
+}
+
+// Read parses audit entries, one JSON object per line.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReadFile reads the audit entries in the file at path.
+func ReadFile(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	entries, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// Verify checks that entries form an unbroken chain: sequence numbers count
+// up from 1, each entry's PrevHash is the previous entry's Hash, and each
+// Hash matches the entry's content. With a non-nil signer, every entry
+// must also carry a valid signature.
+func Verify(entries []Entry, signer Signer) error {
+	prev := ""
+	for i, e := range entries {
+		if want := int64(i + 1); e.Seq != want {
+			return fmt.Errorf("entry %d: sequence number %d, want %d", want, e.Seq, want)
+		}
+		if e.PrevHash != prev {
+			return fmt.Errorf("entry %d: previous hash does not match entry %d", e.Seq, e.Seq-1)
+		}
+		hash, err := e.computeHash()
+		if err != nil {
+			return err
+		}
+		if e.Hash != hash {
+			return fmt.Errorf("entry %d: hash does not match content", e.Seq)
+		}
+		if signer != nil {
+			if e.Signature == "" {
+				return fmt.Errorf("entry %d: not signed", e.Seq)
+			}
+			if err := verify(signer, e.Hash, e.Signature); err != nil {
+				return fmt.Errorf("entry %d: %w", e.Seq, err)
+			}
+		}
+		prev = e.Hash
+	}
+	return nil
+}
+
+// Filter selects audit entries. Zero fields match everything.
+type Filter struct {
+	Run   string
+	Team  string
+	Step  string
+	Agent string
+	Types []EventType
+
+	// Since and Until bound the entry time, inclusive of Since and
+	// exclusive of Until.
+	Since time.Time
+	Until time.Time
+}
+
+// Match reports whether e satisfies f.
+func (f Filter) Match(e Entry) bool {
+	switch {
+	case f.Run != "" && e.Run != f.Run,
+		f.Team != "" && e.Team != f.Team,
+		f.Step != "" && e.Step != f.Step,
+		f.Agent != "" && e.Agent != f.Agent,
+		!f.Since.IsZero() && e.Time.Before(f.Since),
+		!f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if e.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Query returns the entries matching f, in log order.
+func Query(entries []Entry, f Filter) []Entry {
+	var out []Entry
+	for _, e := range entries {
+		if f.Match(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
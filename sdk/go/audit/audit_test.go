@@ -0,0 +1,291 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestLogAppendAndVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	signer := NewHMACSigner([]byte("secret"))
+
+	log, err := Open(path, signer)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	log.now = func() time.Time { return start }
+
+	first, err := log.Append(Entry{Type: EventStepStarted, Run: "r1", Step: "review", Agent: "reviewer"})
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if first.Seq != 1 || first.PrevHash != "" || !first.Time.Equal(start) || first.Hash == "" || first.Signature == "" {
+		t.Errorf("first entry = %+v", first)
+	}
+	msg := multiagentspec.NewMessage(multiagentspec.MsgShareFinding, "reviewer", "lead", "found <b>it</b>")
+	msgEntry, err := MessageEntry("r1", msg)
+	if err != nil {
+		t.Fatalf("MessageEntry: %v", err)
+	}
+	if _, err := log.Append(msgEntry); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	// Reopening continues the chain.
+	log, err = Open(path, signer)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	third, err := log.Append(Entry{Type: EventStepFinished, Run: "r2", Step: "review", Status: multiagentspec.StatusGo})
+	if err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if third.Seq != 3 {
+		t.Errorf("third Seq = %d, want 3", third.Seq)
+	}
+	got, err := log.Query(Filter{Run: "r1", Types: []EventType{EventMessageSent}})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(got) != 1 || got[0].Agent != "reviewer" {
+		t.Errorf("Query = %+v", got)
+	}
+	var decoded multiagentspec.Message
+	if err := json.Unmarshal(got[0].Data, &decoded); err != nil || decoded.Content != msg.Content {
+		t.Errorf("message data = %s (%v)", got[0].Data, err)
+	}
+	log.Close()
+
+	entries, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if err := Verify(entries, signer); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := Verify(entries, NewHMACSigner([]byte("other"))); err == nil {
+		t.Error("Verify accepted the wrong key")
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := Open(path, nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for _, step := range []string{"a", "b", "c"} {
+		if _, err := log.Append(Entry{Type: EventStepStarted, Step: step}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	log.Close()
+	entries, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if err := Verify(entries, nil); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tamper func([]Entry) []Entry
+		signer Signer
+		want   string
+	}{
+		{"edited", func(e []Entry) []Entry { e[1].Step = "x"; return e }, nil, "entry 2: hash does not match"},
+		{"removed", func(e []Entry) []Entry { return append(e[:1], e[2:]...) }, nil, "entry 2: sequence number 3"},
+		{"truncated head", func(e []Entry) []Entry { return e[1:] }, nil, "entry 1: sequence number 2"},
+		{"unsigned", func(e []Entry) []Entry { return e }, NewHMACSigner([]byte("k")), "entry 1: not signed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := tt.tamper(append([]Entry(nil), entries...))
+			err := Verify(tampered, tt.signer)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Verify = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// Open refuses to extend a tampered log.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), `"step":"b"`, `"step":"x"`, 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, nil); err == nil {
+		t.Error("Open accepted a tampered log")
+	}
+}
+
+func TestStepAuditor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := Open(path, nil)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer log.Close()
+	team := &multiagentspec.Team{Name: "qa", Agents: []string{"tester"}, Workflow: &multiagentspec.Workflow{
+		Type: multiagentspec.WorkflowChain,
+		Steps: []multiagentspec.Step{
+			{Name: "unit", Agent: "tester"},
+			{Name: "e2e", Agent: "tester", When: "unit.passed == true"},
+		},
+	}}
+	tester := &multiagentspec.Agent{Name: "tester", Tasks: []multiagentspec.Task{{ID: "go-test", Type: multiagentspec.TaskTypeCommand, Command: "go test"}}}
+	runner := multiagentspec.AgentRunnerFunc(func(ctx context.Context, run multiagentspec.StepRun) (*multiagentspec.AgentResult, error) {
+		return &multiagentspec.AgentResult{Tasks: []multiagentspec.TaskResult{multiagentspec.PassTask("go-test", "")},
+			Outputs: map[string]interface{}{"passed": false}}, nil
+	})
+	e, err := multiagentspec.NewExecutor(team, []*multiagentspec.Agent{tester},
+		multiagentspec.WithTaskRunner(runner), multiagentspec.WithAuditLog(log.StepAuditor("r1", "qa")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := log.Query(Filter{Run: "r1", Team: "qa"})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	var events []string
+	for _, entry := range got {
+		events = append(events, fmt.Sprintf("%s %s %s %s", entry.Type, entry.Step, entry.Status, entry.Detail))
+	}
+	want := []string{
+		"step_started unit  ",
+		"step_finished unit GO done",
+		"status_changed unit GO run status GO",
+		"step_finished e2e SKIP skipped: when unit.passed == true is false",
+	}
+	if strings.Join(events, "\n") != strings.Join(want, "\n") {
+		t.Errorf("entries:\n%s\nwant:\n%s", strings.Join(events, "\n"), strings.Join(want, "\n"))
+	}
+	var result multiagentspec.AgentResult
+	if err := json.Unmarshal(got[1].Data, &result); err != nil || result.StepID != "unit" {
+		t.Errorf("finished entry data = %s (%v)", got[1].Data, err)
+	}
+
+	// A failing step worsens the run's status
+	team.Workflow = &multiagentspec.Workflow{Type: multiagentspec.WorkflowChain, Steps: []multiagentspec.Step{
+		{Name: "unit", Agent: "tester"}, {Name: "lint", Agent: "tester"},
+	}}
+	failing := multiagentspec.AgentRunnerFunc(func(ctx context.Context, run multiagentspec.StepRun) (*multiagentspec.AgentResult, error) {
+		if run.Step.Name == "lint" {
+			return &multiagentspec.AgentResult{Tasks: []multiagentspec.TaskResult{multiagentspec.FailTask("vet", errors.New("vet failed"), "")}}, nil
+		}
+		return &multiagentspec.AgentResult{Tasks: []multiagentspec.TaskResult{multiagentspec.PassTask("go-test", "")}}, nil
+	})
+	e, err = multiagentspec.NewExecutor(team, []*multiagentspec.Agent{tester},
+		multiagentspec.WithTaskRunner(failing), multiagentspec.WithAuditLog(log.StepAuditor("r2", "qa")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := log.Query(Filter{Run: "r2", Types: []EventType{EventStatusChanged}})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Detail != "run status GO" || changes[1].Detail != "run status GO -> NO-GO" || changes[1].Status != multiagentspec.StatusNoGo {
+		t.Errorf("status changes = %+v", changes)
+	}
+}
+
+func TestApprovalEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	signer := NewHMACSigner([]byte("secret"))
+	log, err := Open(path, signer)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	a := &multiagentspec.Approval{Run: "r1", Gate: "deploy.sign-off", Request: "Ship it?", Decision: multiagentspec.ApprovalPending, RequestedAt: now}
+	if _, err := ApprovalEntry(a); err == nil {
+		t.Error("ApprovalEntry(pending) succeeded")
+	}
+	for _, tt := range []struct {
+		decision multiagentspec.ApprovalDecision
+		comment  string
+		typ      EventType
+		detail   string
+	}{
+		{multiagentspec.ApprovalApproved, "notes checked", EventApprovalGranted, "approved deploy.sign-off: notes checked"},
+		{multiagentspec.ApprovalRejected, "", EventApprovalRejected, "rejected deploy.sign-off"},
+	} {
+		a := *a
+		if err := a.Decide("ann", tt.decision, tt.comment, now.Add(time.Minute)); err != nil {
+			t.Fatal(err)
+		}
+		e, err := ApprovalEntry(&a)
+		if err != nil {
+			t.Fatalf("ApprovalEntry: %v", err)
+		}
+		written, err := log.Append(e)
+		if err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+		if written.Type != tt.typ || written.Run != "r1" || written.Step != "deploy" || written.Actor != "ann" ||
+			written.Detail != tt.detail || !written.Time.Equal(now.Add(time.Minute)) {
+			t.Errorf("entry = %+v", written)
+		}
+	}
+	if err := log.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	if err := Verify(entries, signer); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	e := Entry{Type: EventRetry, Run: "r1", Team: "qa", Step: "unit", Agent: "tester", Time: at}
+	tests := []struct {
+		f    Filter
+		want bool
+	}{
+		{Filter{}, true},
+		{Filter{Run: "r1", Team: "qa", Step: "unit", Agent: "tester"}, true},
+		{Filter{Run: "r2"}, false},
+		{Filter{Types: []EventType{EventStepStarted, EventRetry}}, true},
+		{Filter{Types: []EventType{EventStepStarted}}, false},
+		{Filter{Since: at}, true},
+		{Filter{Since: at.Add(time.Second)}, false},
+		{Filter{Until: at}, false},
+		{Filter{Until: at.Add(time.Second)}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Match(e); got != tt.want {
+			t.Errorf("%+v.Match = %v, want %v", tt.f, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Export formats.
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// csvHeader lists the columns written by Export in CSV format.
+var csvHeader = []string{
+	"seq", "time", "type", "run", "team", "step", "agent", "actor", "status", "detail", "data", "prev_hash", "hash", "signature",
+}
+
+// Export writes entries to w as JSON Lines (FormatJSONL), the log's own
+// format, or as CSV with a header row (FormatCSV) for spreadsheets and
+// review tools.
+func Export(w io.Writer, entries []Entry, format string) error {
+	switch format {
+	case FormatJSONL:
+		enc := json.NewEncoder(w)
+		for _, e := range entries {
+			if err := enc.Encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := cw.Write([]string{
+				strconv.FormatInt(e.Seq, 10), e.Time.Format(time.RFC3339Nano), string(e.Type),
+				e.Run, e.Team, e.Step, e.Agent, e.Actor, string(e.Status), e.Detail, string(e.Data),
+				e.PrevHash, e.Hash, e.Signature,
+			}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unknown export format %q (want %s or %s)", format, FormatJSONL, FormatCSV)
+}
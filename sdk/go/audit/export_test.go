@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExport(t *testing.T) {
+	entries := []Entry{{
+		Seq:    1,
+		Time:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Type:   EventApprovalGranted,
+		Run:    "r1",
+		Actor:  "alice",
+		Detail: "approved plan, with edits",
+		Data:   json.RawMessage(`{"plan":"p1"}`),
+		Hash:   "ab",
+	}}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, entries, FormatCSV); err != nil {
+		t.Fatalf("Export csv: %v", err)
+	}
+	want := "seq,time,type,run,team,step,agent,actor,status,detail,data,prev_hash,hash,signature\n" +
+		`1,2026-03-01T12:00:00Z,approval_granted,r1,,,,alice,,"approved plan, with edits","{""plan"":""p1""}",,ab,` + "\n"
+	if buf.String() != want {
+		t.Errorf("csv =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := Export(&buf, entries, FormatJSONL); err != nil {
+		t.Fatalf("Export jsonl: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil || len(got) != 1 || got[0].Actor != "alice" {
+		t.Errorf("jsonl round trip = %+v, %v", got, err)
+	}
+
+	if err := Export(&buf, entries, "xml"); err == nil || !strings.Contains(err.Error(), "unknown export format") {
+		t.Errorf("Export(xml) = %v", err)
+	}
+}
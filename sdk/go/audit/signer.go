@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrBadSignature is returned when an entry's signature does not verify.
+var ErrBadSignature = errors.New("signature does not verify")
+
+// Signer signs and verifies entry hashes.
+type Signer interface {
+	// Sign returns a signature over digest.
+	Sign(digest []byte) ([]byte, error)
+
+	// Verify returns ErrBadSignature if sig is not a valid signature
+	// over digest.
+	Verify(digest, sig []byte) error
+}
+
+// HMACSigner signs with HMAC-SHA256 under a shared secret key. Verifying
+// requires the same key.
+type HMACSigner struct {
+	key []byte
+}
+
+// NewHMACSigner returns an HMAC-SHA256 signer using key.
+func NewHMACSigner(key []byte) *HMACSigner {
+	return &HMACSigner{key: key}
+}
+
+// Sign implements Signer.
+func (s *HMACSigner) Sign(digest []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(digest)
+	return mac.Sum(nil), nil
+}
+
+// Verify implements Signer.
+func (s *HMACSigner) Verify(digest, sig []byte) error {
+	want, _ := s.Sign(digest)
+	if !hmac.Equal(sig, want) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// Ed25519Signer signs with an Ed25519 private key, so reviewers can verify
+// the log with only the public key. A signer built from a public key alone
+// verifies but cannot sign.
+type Ed25519Signer struct {
+	private ed25519.PrivateKey
+	public  ed25519.PublicKey
+}
+
+// NewEd25519Signer returns a signer using key.
+func NewEd25519Signer(key ed25519.PrivateKey) *Ed25519Signer {
+	return &Ed25519Signer{private: key, public: key.Public().(ed25519.PublicKey)}
+}
+
+// NewEd25519Verifier returns a verify-only signer using key.
+func NewEd25519Verifier(key ed25519.PublicKey) *Ed25519Signer {
+	return &Ed25519Signer{public: key}
+}
+
+// Sign implements Signer.
+func (s *Ed25519Signer) Sign(digest []byte) ([]byte, error) {
+	if s.private == nil {
+		return nil, fmt.Errorf("no private key")
+	}
+	return ed25519.Sign(s.private, digest), nil
+}
+
+// Verify implements Signer.
+func (s *Ed25519Signer) Verify(digest, sig []byte) error {
+	if !ed25519.Verify(s.public, digest, sig) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+func sign(s Signer, hash string) (string, error) {
+	digest, err := hex.DecodeString(hash)
+	if err != nil {
+		return "", fmt.Errorf("decode hash: %w", err)
+	}
+	sig, err := s.Sign(digest)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sig), nil
+}
+
+func verify(s Signer, hash, signature string) error {
+	digest, err := hex.DecodeString(hash)
+	if err != nil {
+		return fmt.Errorf("decode hash: %w", err)
+	}
+	sig, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	return s.Verify(digest, sig)
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"testing"
+)
+
+func TestEd25519Signer(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := []byte("digest")
+	sig, err := NewEd25519Signer(private).Sign(digest)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	verifier := NewEd25519Verifier(public)
+	if err := verifier.Verify(digest, sig); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := verifier.Verify([]byte("other"), sig); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Verify(other) = %v, want ErrBadSignature", err)
+	}
+	if _, err := verifier.Sign(digest); err == nil {
+		t.Error("verify-only signer signed")
+	}
+}
+
+func TestHMACSigner(t *testing.T) {
+	s := NewHMACSigner([]byte("key"))
+	sig, _ := s.Sign([]byte("digest"))
+	if err := s.Verify([]byte("digest"), sig); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+	if err := NewHMACSigner([]byte("other")).Verify([]byte("digest"), sig); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("Verify with other key = %v, want ErrBadSignature", err)
+	}
+}
@@ -162,7 +162,7 @@ func StreamBoxReport(qw422016 *qt422016.Writer, report *TeamReport) {
 	qw422016.N().S(`
 `)
 //line box.qtpl:37
-	if len(report.FooterBlocks) > 0 {
+	if cost := costSummaryBlocks(report); len(cost) > 0 {
 //line box.qtpl:37
 		qw422016.N().S(`
 `)
@@ -172,7 +172,7 @@ func StreamBoxReport(qw422016 *qt422016.Writer, report *TeamReport) {
 		qw422016.N().S(`
 `)
 //line box.qtpl:39
-		streamboxRenderBlocks(qw422016, report.FooterBlocks)
+		streamboxRenderBlocks(qw422016, cost)
 //line box.qtpl:39
 		qw422016.N().S(`
 `)
@@ -182,172 +182,212 @@ func StreamBoxReport(qw422016 *qt422016.Writer, report *TeamReport) {
 	qw422016.N().S(`
 `)
 //line box.qtpl:41
-	streamboxSeparator(qw422016)
+	if issues := issuesBlocks(report); len(issues) > 0 {
 //line box.qtpl:41
-	qw422016.N().S(`
+		qw422016.N().S(`
 `)
 //line box.qtpl:42
-	streamboxCenterLine(qw422016, report.FinalMessage())
+		streamboxSeparator(qw422016)
 //line box.qtpl:42
-	qw422016.N().S(`
+		qw422016.N().S(`
 `)
 //line box.qtpl:43
-	streamboxFooter(qw422016)
+		streamboxRenderBlocks(qw422016, issues)
 //line box.qtpl:43
-	qw422016.N().S(`
+		qw422016.N().S(`
 `)
 //line box.qtpl:44
+	}
+//line box.qtpl:44
+	qw422016.N().S(`
+`)
+//line box.qtpl:45
+	if len(report.FooterBlocks) > 0 {
+//line box.qtpl:45
+		qw422016.N().S(`
+`)
+//line box.qtpl:46
+		streamboxSeparator(qw422016)
+//line box.qtpl:46
+		qw422016.N().S(`
+`)
+//line box.qtpl:47
+		streamboxRenderBlocks(qw422016, report.FooterBlocks)
+//line box.qtpl:47
+		qw422016.N().S(`
+`)
+//line box.qtpl:48
+	}
+//line box.qtpl:48
+	qw422016.N().S(`
+`)
+//line box.qtpl:49
+	streamboxSeparator(qw422016)
+//line box.qtpl:49
+	qw422016.N().S(`
+`)
+//line box.qtpl:50
+	streamboxCenterLine(qw422016, report.FinalMessage())
+//line box.qtpl:50
+	qw422016.N().S(`
+`)
+//line box.qtpl:51
+	streamboxFooter(qw422016)
+//line box.qtpl:51
+	qw422016.N().S(`
+`)
+//line box.qtpl:52
 }
 
-//line box.qtpl:44
+//line box.qtpl:52
 func WriteBoxReport(qq422016 qtio422016.Writer, report *TeamReport) {
-//line box.qtpl:44
+//line box.qtpl:52
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:44
+//line box.qtpl:52
 	StreamBoxReport(qw422016, report)
-//line box.qtpl:44
+//line box.qtpl:52
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:44
+//line box.qtpl:52
 }
 
-//line box.qtpl:44
+//line box.qtpl:52
 func BoxReport(report *TeamReport) string {
-//line box.qtpl:44
+//line box.qtpl:52
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:44
+//line box.qtpl:52
 	WriteBoxReport(qb422016, report)
-//line box.qtpl:44
+//line box.qtpl:52
 	qs422016 := string(qb422016.B)
-//line box.qtpl:44
+//line box.qtpl:52
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:44
+//line box.qtpl:52
 	return qs422016
-//line box.qtpl:44
+//line box.qtpl:52
 }
 
-//line box.qtpl:46
+//line box.qtpl:54
 func streamboxHeader(qw422016 *qt422016.Writer) {
-//line box.qtpl:46
+//line box.qtpl:54
 	qw422016.N().S(`
 ╔`)
-//line box.qtpl:47
-	qw422016.E().S(strings.Repeat("═", qtplBoxWidth))
-//line box.qtpl:47
+//line box.qtpl:55
+	qw422016.E().S(qtplBoxRule)
+//line box.qtpl:55
 	qw422016.N().S(`╗
 `)
-//line box.qtpl:48
+//line box.qtpl:56
 }
 
-//line box.qtpl:48
+//line box.qtpl:56
 func writeboxHeader(qq422016 qtio422016.Writer) {
-//line box.qtpl:48
+//line box.qtpl:56
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:48
+//line box.qtpl:56
 	streamboxHeader(qw422016)
-//line box.qtpl:48
+//line box.qtpl:56
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:48
+//line box.qtpl:56
 }
 
-//line box.qtpl:48
+//line box.qtpl:56
 func boxHeader() string {
-//line box.qtpl:48
+//line box.qtpl:56
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:48
+//line box.qtpl:56
 	writeboxHeader(qb422016)
-//line box.qtpl:48
+//line box.qtpl:56
 	qs422016 := string(qb422016.B)
-//line box.qtpl:48
+//line box.qtpl:56
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:48
+//line box.qtpl:56
 	return qs422016
-//line box.qtpl:48
+//line box.qtpl:56
 }
 
-//line box.qtpl:50
+//line box.qtpl:58
 func streamboxSeparator(qw422016 *qt422016.Writer) {
-//line box.qtpl:50
+//line box.qtpl:58
 	qw422016.N().S(`
 ╠`)
-//line box.qtpl:51
-	qw422016.E().S(strings.Repeat("═", qtplBoxWidth))
-//line box.qtpl:51
+//line box.qtpl:59
+	qw422016.E().S(qtplBoxRule)
+//line box.qtpl:59
 	qw422016.N().S(`╣
 `)
-//line box.qtpl:52
+//line box.qtpl:60
 }
 
-//line box.qtpl:52
+//line box.qtpl:60
 func writeboxSeparator(qq422016 qtio422016.Writer) {
-//line box.qtpl:52
+//line box.qtpl:60
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:52
+//line box.qtpl:60
 	streamboxSeparator(qw422016)
-//line box.qtpl:52
+//line box.qtpl:60
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:52
+//line box.qtpl:60
 }
 
-//line box.qtpl:52
+//line box.qtpl:60
 func boxSeparator() string {
-//line box.qtpl:52
+//line box.qtpl:60
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:52
+//line box.qtpl:60
 	writeboxSeparator(qb422016)
-//line box.qtpl:52
+//line box.qtpl:60
 	qs422016 := string(qb422016.B)
-//line box.qtpl:52
+//line box.qtpl:60
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:52
+//line box.qtpl:60
 	return qs422016
-//line box.qtpl:52
+//line box.qtpl:60
 }
 
-//line box.qtpl:54
+//line box.qtpl:62
 func streamboxFooter(qw422016 *qt422016.Writer) {
-//line box.qtpl:54
+//line box.qtpl:62
 	qw422016.N().S(`
 ╚`)
-//line box.qtpl:55
-	qw422016.E().S(strings.Repeat("═", qtplBoxWidth))
-//line box.qtpl:55
+//line box.qtpl:63
+	qw422016.E().S(qtplBoxRule)
+//line box.qtpl:63
 	qw422016.N().S(`╝
 `)
-//line box.qtpl:56
+//line box.qtpl:64
 }
 
-//line box.qtpl:56
+//line box.qtpl:64
 func writeboxFooter(qq422016 qtio422016.Writer) {
-//line box.qtpl:56
+//line box.qtpl:64
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:56
+//line box.qtpl:64
 	streamboxFooter(qw422016)
-//line box.qtpl:56
+//line box.qtpl:64
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:56
+//line box.qtpl:64
 }
 
-//line box.qtpl:56
+//line box.qtpl:64
 func boxFooter() string {
-//line box.qtpl:56
+//line box.qtpl:64
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:56
+//line box.qtpl:64
 	writeboxFooter(qb422016)
-//line box.qtpl:56
+//line box.qtpl:64
 	qs422016 := string(qb422016.B)
-//line box.qtpl:56
+//line box.qtpl:64
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:56
+//line box.qtpl:64
 	return qs422016
-//line box.qtpl:56
+//line box.qtpl:64
 }
 
-//line box.qtpl:58
+//line box.qtpl:66
 func streamboxCenterLine(qw422016 *qt422016.Writer, text string) {
-//line box.qtpl:58
+//line box.qtpl:66
 	qw422016.N().S(`
 `)
-//line box.qtpl:60
+//line box.qtpl:68
 	visualLen := boxVisualLength(text)
 	padding := qtplBoxWidth - visualLen
 	if padding < 0 {
@@ -356,363 +396,366 @@ func streamboxCenterLine(qw422016 *qt422016.Writer, text string) {
 	left := padding / 2
 	right := padding - left
 
-//line box.qtpl:67
+//line box.qtpl:75
 	qw422016.N().S(`
 ║`)
-//line box.qtpl:68
-	qw422016.E().S(strings.Repeat(" ", left))
-//line box.qtpl:68
+//line box.qtpl:76
+	qw422016.E().S(spaces[:left])
+//line box.qtpl:76
 	qw422016.E().S(text)
-//line box.qtpl:68
-	qw422016.E().S(strings.Repeat(" ", right))
-//line box.qtpl:68
+//line box.qtpl:76
+	qw422016.E().S(spaces[:right])
+//line box.qtpl:76
 	qw422016.N().S(`║
 `)
-//line box.qtpl:69
+//line box.qtpl:77
 }
 
-//line box.qtpl:69
+//line box.qtpl:77
 func writeboxCenterLine(qq422016 qtio422016.Writer, text string) {
-//line box.qtpl:69
+//line box.qtpl:77
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:69
+//line box.qtpl:77
 	streamboxCenterLine(qw422016, text)
-//line box.qtpl:69
+//line box.qtpl:77
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:69
+//line box.qtpl:77
 }
 
-//line box.qtpl:69
+//line box.qtpl:77
 func boxCenterLine(text string) string {
-//line box.qtpl:69
+//line box.qtpl:77
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:69
+//line box.qtpl:77
 	writeboxCenterLine(qb422016, text)
-//line box.qtpl:69
+//line box.qtpl:77
 	qs422016 := string(qb422016.B)
-//line box.qtpl:69
+//line box.qtpl:77
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:69
+//line box.qtpl:77
 	return qs422016
-//line box.qtpl:69
+//line box.qtpl:77
 }
 
-//line box.qtpl:71
+//line box.qtpl:79
 func streamboxPaddedLine(qw422016 *qt422016.Writer, text string) {
-//line box.qtpl:71
+//line box.qtpl:79
 	qw422016.N().S(`
 `)
-//line box.qtpl:73
+//line box.qtpl:81
 	visualLen := boxVisualLength(text)
 	padding := qtplBoxWidth - visualLen - 1
 	if padding < 0 {
 		padding = 0
 	}
 
-//line box.qtpl:78
+//line box.qtpl:86
 	qw422016.N().S(`
 ║ `)
-//line box.qtpl:79
+//line box.qtpl:87
 	qw422016.E().S(text)
-//line box.qtpl:79
-	qw422016.E().S(strings.Repeat(" ", padding))
-//line box.qtpl:79
+//line box.qtpl:87
+	qw422016.E().S(spaces[:padding])
+//line box.qtpl:87
 	qw422016.N().S(`║
 `)
-//line box.qtpl:80
+//line box.qtpl:88
 }
 
-//line box.qtpl:80
+//line box.qtpl:88
 func writeboxPaddedLine(qq422016 qtio422016.Writer, text string) {
-//line box.qtpl:80
+//line box.qtpl:88
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:80
+//line box.qtpl:88
 	streamboxPaddedLine(qw422016, text)
-//line box.qtpl:80
+//line box.qtpl:88
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:80
+//line box.qtpl:88
 }
 
-//line box.qtpl:80
+//line box.qtpl:88
 func boxPaddedLine(text string) string {
-//line box.qtpl:80
+//line box.qtpl:88
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:80
+//line box.qtpl:88
 	writeboxPaddedLine(qb422016, text)
-//line box.qtpl:80
+//line box.qtpl:88
 	qs422016 := string(qb422016.B)
-//line box.qtpl:80
+//line box.qtpl:88
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:80
+//line box.qtpl:88
 	return qs422016
-//line box.qtpl:80
+//line box.qtpl:88
 }
 
-//line box.qtpl:82
+//line box.qtpl:90
 func streamboxTeamHeader(qw422016 *qt422016.Writer, team TeamSection) {
-//line box.qtpl:82
+//line box.qtpl:90
 	qw422016.N().S(`
 `)
-//line box.qtpl:84
+//line box.qtpl:92
 	text := boxFormatTeamHeader(team)
 
-//line box.qtpl:85
+//line box.qtpl:93
 	qw422016.N().S(`
 `)
-//line box.qtpl:86
+//line box.qtpl:94
 	streamboxPaddedLine(qw422016, text)
-//line box.qtpl:86
+//line box.qtpl:94
 	qw422016.N().S(`
 `)
-//line box.qtpl:87
+//line box.qtpl:95
 }
 
-//line box.qtpl:87
+//line box.qtpl:95
 func writeboxTeamHeader(qq422016 qtio422016.Writer, team TeamSection) {
-//line box.qtpl:87
+//line box.qtpl:95
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:87
+//line box.qtpl:95
 	streamboxTeamHeader(qw422016, team)
-//line box.qtpl:87
+//line box.qtpl:95
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:87
+//line box.qtpl:95
 }
 
-//line box.qtpl:87
+//line box.qtpl:95
 func boxTeamHeader(team TeamSection) string {
-//line box.qtpl:87
+//line box.qtpl:95
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:87
+//line box.qtpl:95
 	writeboxTeamHeader(qb422016, team)
-//line box.qtpl:87
+//line box.qtpl:95
 	qs422016 := string(qb422016.B)
-//line box.qtpl:87
+//line box.qtpl:95
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:87
+//line box.qtpl:95
 	return qs422016
-//line box.qtpl:87
+//line box.qtpl:95
 }
 
-//line box.qtpl:89
+//line box.qtpl:97
 func streamboxTaskLine(qw422016 *qt422016.Writer, task TaskResult) {
-//line box.qtpl:89
+//line box.qtpl:97
 	qw422016.N().S(`
 `)
-//line box.qtpl:91
+//line box.qtpl:99
 	line := boxFormatTaskLine(task)
 
-//line box.qtpl:92
+//line box.qtpl:100
 	qw422016.N().S(`
 `)
-//line box.qtpl:93
+//line box.qtpl:101
 	streamboxPaddedLine(qw422016, line)
-//line box.qtpl:93
+//line box.qtpl:101
 	qw422016.N().S(`
 `)
-//line box.qtpl:94
+//line box.qtpl:102
 }
 
-//line box.qtpl:94
+//line box.qtpl:102
 func writeboxTaskLine(qq422016 qtio422016.Writer, task TaskResult) {
-//line box.qtpl:94
+//line box.qtpl:102
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:94
+//line box.qtpl:102
 	streamboxTaskLine(qw422016, task)
-//line box.qtpl:94
+//line box.qtpl:102
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:94
+//line box.qtpl:102
 }
 
-//line box.qtpl:94
+//line box.qtpl:102
 func boxTaskLine(task TaskResult) string {
-//line box.qtpl:94
+//line box.qtpl:102
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:94
+//line box.qtpl:102
 	writeboxTaskLine(qb422016, task)
-//line box.qtpl:94
+//line box.qtpl:102
 	qs422016 := string(qb422016.B)
-//line box.qtpl:94
+//line box.qtpl:102
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:94
+//line box.qtpl:102
 	return qs422016
-//line box.qtpl:94
+//line box.qtpl:102
 }
 
-//line box.qtpl:96
+//line box.qtpl:104
 func streamboxRenderTags(qw422016 *qt422016.Writer, tags map[string]string) {
-//line box.qtpl:96
+//line box.qtpl:104
 	qw422016.N().S(`
 `)
-//line box.qtpl:98
+//line box.qtpl:106
 	lines := boxFormatTags(tags)
 
-//line box.qtpl:99
+//line box.qtpl:107
 	qw422016.N().S(`
 `)
-//line box.qtpl:100
+//line box.qtpl:108
 	for _, line := range lines {
-//line box.qtpl:100
+//line box.qtpl:108
 		qw422016.N().S(`
 `)
-//line box.qtpl:101
+//line box.qtpl:109
 		streamboxPaddedLine(qw422016, line)
-//line box.qtpl:101
+//line box.qtpl:109
 		qw422016.N().S(`
 `)
-//line box.qtpl:102
+//line box.qtpl:110
 	}
-//line box.qtpl:102
+//line box.qtpl:110
 	qw422016.N().S(`
 `)
-//line box.qtpl:103
+//line box.qtpl:111
 }
 
-//line box.qtpl:103
+//line box.qtpl:111
 func writeboxRenderTags(qq422016 qtio422016.Writer, tags map[string]string) {
-//line box.qtpl:103
+//line box.qtpl:111
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:103
+//line box.qtpl:111
 	streamboxRenderTags(qw422016, tags)
-//line box.qtpl:103
+//line box.qtpl:111
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:103
+//line box.qtpl:111
 }
 
-//line box.qtpl:103
+//line box.qtpl:111
 func boxRenderTags(tags map[string]string) string {
-//line box.qtpl:103
+//line box.qtpl:111
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:103
+//line box.qtpl:111
 	writeboxRenderTags(qb422016, tags)
-//line box.qtpl:103
+//line box.qtpl:111
 	qs422016 := string(qb422016.B)
-//line box.qtpl:103
+//line box.qtpl:111
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:103
+//line box.qtpl:111
 	return qs422016
-//line box.qtpl:103
+//line box.qtpl:111
 }
 
-//line box.qtpl:105
+//line box.qtpl:113
 func streamboxRenderBlocks(qw422016 *qt422016.Writer, blocks []ContentBlock) {
-//line box.qtpl:105
+//line box.qtpl:113
 	qw422016.N().S(`
 `)
-//line box.qtpl:106
+//line box.qtpl:114
 	for _, block := range blocks {
-//line box.qtpl:106
+//line box.qtpl:114
 		qw422016.N().S(`
 `)
-//line box.qtpl:107
+//line box.qtpl:115
 		streamboxRenderBlock(qw422016, block)
-//line box.qtpl:107
+//line box.qtpl:115
 		qw422016.N().S(`
 `)
-//line box.qtpl:108
+//line box.qtpl:116
 	}
-//line box.qtpl:108
+//line box.qtpl:116
 	qw422016.N().S(`
 `)
-//line box.qtpl:109
+//line box.qtpl:117
 }
 
-//line box.qtpl:109
+//line box.qtpl:117
 func writeboxRenderBlocks(qq422016 qtio422016.Writer, blocks []ContentBlock) {
-//line box.qtpl:109
+//line box.qtpl:117
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:109
+//line box.qtpl:117
 	streamboxRenderBlocks(qw422016, blocks)
-//line box.qtpl:109
+//line box.qtpl:117
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:109
+//line box.qtpl:117
 }
 
-//line box.qtpl:109
+//line box.qtpl:117
 func boxRenderBlocks(blocks []ContentBlock) string {
-//line box.qtpl:109
+//line box.qtpl:117
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:109
+//line box.qtpl:117
 	writeboxRenderBlocks(qb422016, blocks)
-//line box.qtpl:109
+//line box.qtpl:117
 	qs422016 := string(qb422016.B)
-//line box.qtpl:109
+//line box.qtpl:117
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:109
+//line box.qtpl:117
 	return qs422016
-//line box.qtpl:109
+//line box.qtpl:117
 }
 
-//line box.qtpl:111
+//line box.qtpl:119
 func streamboxRenderBlock(qw422016 *qt422016.Writer, block ContentBlock) {
-//line box.qtpl:111
+//line box.qtpl:119
 	qw422016.N().S(`
 `)
-//line box.qtpl:112
+//line box.qtpl:120
 	if block.Title != "" {
-//line box.qtpl:112
+//line box.qtpl:120
 		qw422016.N().S(`
 `)
-//line box.qtpl:113
+//line box.qtpl:121
 		streamboxPaddedLine(qw422016, block.Title)
-//line box.qtpl:113
+//line box.qtpl:121
 		qw422016.N().S(`
 `)
-//line box.qtpl:114
+//line box.qtpl:122
 	}
-//line box.qtpl:114
+//line box.qtpl:122
 	qw422016.N().S(`
 `)
-//line box.qtpl:116
+//line box.qtpl:124
 	lines := boxFormatBlock(block)
 
-//line box.qtpl:117
+//line box.qtpl:125
 	qw422016.N().S(`
 `)
-//line box.qtpl:118
+//line box.qtpl:126
 	for _, line := range lines {
-//line box.qtpl:118
+//line box.qtpl:126
 		qw422016.N().S(`
 `)
-//line box.qtpl:119
+//line box.qtpl:127
 		streamboxPaddedLine(qw422016, line)
-//line box.qtpl:119
+//line box.qtpl:127
 		qw422016.N().S(`
 `)
-//line box.qtpl:120
+//line box.qtpl:128
 	}
-//line box.qtpl:120
+//line box.qtpl:128
 	qw422016.N().S(`
 `)
-//line box.qtpl:121
+//line box.qtpl:129
 }
 
-//line box.qtpl:121
+//line box.qtpl:129
 func writeboxRenderBlock(qq422016 qtio422016.Writer, block ContentBlock) {
-//line box.qtpl:121
+//line box.qtpl:129
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line box.qtpl:121
+//line box.qtpl:129
 	streamboxRenderBlock(qw422016, block)
-//line box.qtpl:121
+//line box.qtpl:129
 	qt422016.ReleaseWriter(qw422016)
-//line box.qtpl:121
+//line box.qtpl:129
 }
 
-//line box.qtpl:121
+//line box.qtpl:129
 func boxRenderBlock(block ContentBlock) string {
-//line box.qtpl:121
+//line box.qtpl:129
 	qb422016 := qt422016.AcquireByteBuffer()
-//line box.qtpl:121
+//line box.qtpl:129
 	writeboxRenderBlock(qb422016, block)
-//line box.qtpl:121
+//line box.qtpl:129
 	qs422016 := string(qb422016.B)
-//line box.qtpl:121
+//line box.qtpl:129
 	qt422016.ReleaseByteBuffer(qb422016)
-//line box.qtpl:121
+//line box.qtpl:129
 	return qs422016
-//line box.qtpl:121
+//line box.qtpl:129
 }
 
-//line box.qtpl:124
+//line box.qtpl:132
+// qtplBoxRule is the horizontal border.
+var qtplBoxRule = strings.Repeat("═", qtplBoxWidth)
+
 func boxVisualLength(s string) int {
 	length := 0
 	for _, r := range s {
@@ -730,9 +773,9 @@ func boxVisualLength(s string) int {
 func boxFormatTeamHeader(team TeamSection) string {
 	icon := team.Status.Icon()
 	if team.Verdict != "" {
-		return fmt.Sprintf("%s %s — %s — %s", icon, team.Name, team.Status, team.Verdict)
+		return fmt.Sprintf("%s %s — %s — %s", icon, team.Name, team.StatusLabel(), team.Verdict)
 	}
-	return fmt.Sprintf("%s %s — %s", icon, team.Name, team.Status)
+	return fmt.Sprintf("%s %s — %s", icon, team.Name, team.StatusLabel())
 }
 
 func boxFormatTaskLine(task TaskResult) string {
@@ -743,14 +786,41 @@ func boxFormatTaskLine(task TaskResult) string {
 	icon := task.Status.Icon()
 	statusText := string(task.Status)
 	if task.Severity != "" {
-		statusText = fmt.Sprintf("%s [%s]", statusText, task.Severity)
+		statusText += " [" + task.Severity + "]"
+	}
+	var buf [24]byte
+	var duration []byte
+	if task.DurationMs > 0 {
+		duration = appendDuration(buf[:0], task.DurationMs)
 	}
 	detail := task.Detail
-	maxDetail := qtplBoxWidth - 45
+	maxDetail := qtplBoxWidth - 47
+	if len(duration) > 0 {
+		maxDetail -= len(duration) + 2
+	}
 	if len(detail) > maxDetail {
 		detail = detail[:maxDetail-3] + "..."
 	}
-	return fmt.Sprintf("  %-24s %s %-15s %s", id, icon, statusText, detail)
+	var sb strings.Builder
+	sb.Grow(qtplBoxWidth + 32)
+	sb.WriteString("  ")
+	cols := 2 + writePadded(&sb, id, 24)
+	sb.WriteString(" ")
+	sb.WriteString(icon)
+	sb.WriteString(" ")
+	cols += 2 + boxVisualLength(icon) + writePadded(&sb, statusText, 15)
+	sb.WriteString(" ")
+	sb.WriteString(detail)
+	cols += 1 + boxVisualLength(detail)
+	if len(duration) > 0 {
+		padding := qtplBoxWidth - 2 - cols - len(duration)
+		if padding < 1 {
+			padding = 1
+		}
+		writeSpaces(&sb, padding)
+		sb.Write(duration)
+	}
+	return sb.String()
 }
 
 func boxFormatTags(tags map[string]string) []string {
@@ -811,22 +881,19 @@ func boxFormatBlock(block ContentBlock) []string {
 func boxWrapText(content string, maxWidth int) []string {
 	var lines []string
 	words := strings.Fields(content)
-	if len(words) == 0 {
-		return lines
-	}
-	currentLine := ""
-	for _, word := range words {
-		if currentLine == "" {
-			currentLine = word
-		} else if len(currentLine)+1+len(word) <= maxWidth {
-			currentLine += " " + word
-		} else {
-			lines = append(lines, currentLine)
-			currentLine = word
+	start, length := 0, 0
+	for i, word := range words {
+		if i > start && length+1+len(word) > maxWidth {
+			lines = append(lines, strings.Join(words[start:i], " "))
+			start, length = i, 0
+		}
+		if i > start {
+			length++
 		}
+		length += len(word)
 	}
-	if currentLine != "" {
-		lines = append(lines, currentLine)
+	if start < len(words) {
+		lines = append(lines, strings.Join(words[start:], " "))
 	}
 	return lines
 }
@@ -0,0 +1,271 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// BudgetAction is what an executor does when an agent exceeds its budget.
+type BudgetAction string
+
+const (
+	// BudgetActionAbort stops the agent and reports NO-GO.
+	BudgetActionAbort BudgetAction = "abort"
+
+	// BudgetActionDowngrade switches the agent to a cheaper model tier and
+	// reports WARN. With no cheaper tier left, the agent is aborted.
+	BudgetActionDowngrade BudgetAction = "downgrade"
+
+	// BudgetActionWarn lets the agent continue and reports WARN.
+	BudgetActionWarn BudgetAction = "warn"
+)
+
+// BudgetActions returns all budget actions in schema order.
+func BudgetActions() []BudgetAction {
+	return []BudgetAction{BudgetActionAbort, BudgetActionDowngrade, BudgetActionWarn}
+}
+
+// Budget caps an agent's LLM usage per run. Zero limits are unlimited.
+type Budget struct {
+	// MaxTokens is the maximum input plus output tokens per run.
+	MaxTokens int64 `json:"max_tokens,omitempty" yaml:"max_tokens,omitempty" jsonschema:"minimum=0" jsonschema_description:"Maximum input plus output tokens per run"`
+
+	// MaxCostUSD is the maximum LLM cost per run in US dollars.
+	MaxCostUSD float64 `json:"max_cost_usd,omitempty" yaml:"max_cost_usd,omitempty" jsonschema:"minimum=0" jsonschema_description:"Maximum LLM cost per run in US dollars"`
+
+	// OnExceed is what happens when a limit is exceeded (default: abort).
+	OnExceed BudgetAction `json:"on_exceed,omitempty" yaml:"on_exceed,omitempty"`
+
+	// DowngradeTo is the model tier to switch to with the downgrade
+	// action (default: the next cheaper tier).
+	DowngradeTo Model `json:"downgrade_to,omitempty" yaml:"downgrade_to,omitempty" jsonschema_description:"Model tier to switch to with the downgrade action (default: the next cheaper tier)"`
+}
+
+// EffectiveOnExceed returns the exceed action, defaulting to abort.
+func (b *Budget) EffectiveOnExceed() BudgetAction {
+	if b.OnExceed == "" {
+		return BudgetActionAbort
+	}
+	return b.OnExceed
+}
+
+// Validate checks that the budget sets a non-negative limit and a known
+// action, and that DowngradeTo is a model tier used only with downgrade.
+func (b *Budget) Validate() error {
+	if b.MaxTokens < 0 || b.MaxCostUSD < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	if b.MaxTokens == 0 && b.MaxCostUSD == 0 {
+		return fmt.Errorf("budget sets no limit")
+	}
+	switch b.EffectiveOnExceed() {
+	case BudgetActionAbort, BudgetActionWarn:
+		if b.DowngradeTo != "" {
+			return fmt.Errorf("budget downgrade_to requires on_exceed %s", BudgetActionDowngrade)
+		}
+	case BudgetActionDowngrade:
+		if b.DowngradeTo != "" && b.DowngradeTo.rank() < 0 {
+			return fmt.Errorf("budget downgrade_to: unknown model %q", b.DowngradeTo)
+		}
+	default:
+		return fmt.Errorf("budget: unknown on_exceed action %q", b.OnExceed)
+	}
+	return nil
+}
+
+// Exceeded returns a description of the limits u exceeds, or "" if none.
+func (b *Budget) Exceeded(u Usage) string {
+	var over []string
+	if b.MaxTokens > 0 && u.Tokens() > b.MaxTokens {
+		over = append(over, fmt.Sprintf("%d tokens exceeds limit of %d", u.Tokens(), b.MaxTokens))
+	}
+	if b.MaxCostUSD > 0 && u.CostUSD > b.MaxCostUSD {
+		over = append(over, fmt.Sprintf("$%.4f exceeds limit of $%.4f", u.CostUSD, b.MaxCostUSD))
+	}
+	return strings.Join(over, "; ")
+}
+
+// Cheaper returns the next cheaper model tier, or false for haiku and
+// unknown tiers.
+func (m Model) Cheaper() (Model, bool) {
+	if r := m.rank(); r > 0 {
+		return Models()[r-1], true
+	}
+	return "", false
+}
+
+// rank returns the tier's position in Models, cheapest first, or -1.
+func (m Model) rank() int {
+	for i, tier := range Models() {
+		if tier == m {
+			return i
+		}
+	}
+	return -1
+}
+
+// BudgetDecision is the outcome of checking an agent's usage against its
+// budget.
+type BudgetDecision struct {
+	// Agent is the agent's qualified name.
+	Agent string
+
+	// Usage is the agent's usage so far in the run.
+	Usage Usage
+
+	// Exceeded describes the exceeded limits; empty if within budget.
+	Exceeded string
+
+	// Action is what the executor must do; empty if within budget.
+	Action BudgetAction
+
+	// Model is the tier to continue with after a downgrade.
+	Model Model
+}
+
+// Continue reports whether the agent may keep running.
+func (d BudgetDecision) Continue() bool {
+	return d.Action != BudgetActionAbort
+}
+
+// TaskResult returns the budget breach as a report task: NO-GO for an
+// abort, WARN otherwise. It returns false if the budget was not exceeded.
+func (d BudgetDecision) TaskResult() (TaskResult, bool) {
+	if d.Exceeded == "" {
+		return TaskResult{}, false
+	}
+	// The task carries no usage of its own, so team totals summed over
+	// tasks are not counted twice.
+	task := TaskResult{ID: "budget"}
+	switch d.Action {
+	case BudgetActionAbort:
+		task.Status, task.Severity = StatusNoGo, "high"
+		task.Detail = "Budget exceeded, agent aborted: " + d.Exceeded
+	case BudgetActionDowngrade:
+		task.Status, task.Severity = StatusWarn, "medium"
+		task.Detail = fmt.Sprintf("Budget exceeded, downgraded to %s: %s", d.Model, d.Exceeded)
+	default:
+		task.Status, task.Severity = StatusWarn, "medium"
+		task.Detail = "Budget exceeded: " + d.Exceeded
+	}
+	return task, true
+}
+
+// BudgetTracker accumulates per-agent usage during a run and decides, after
+// each LLM call, whether an agent may continue. Executors call Record with
+// each call's usage and act on the decision. It is safe for concurrent use.
+type BudgetTracker struct {
+	mu     sync.Mutex
+	agents map[string]*Agent
+	usage  map[string]Usage
+	models map[string]Model
+}
+
+// NewBudgetTracker returns a tracker for the agents' budgets, keyed by
+// qualified name. Agents without a budget are tracked but never limited;
+// agents without a model are taken to run on sonnet, the schema default.
+func NewBudgetTracker(agents []*Agent) *BudgetTracker {
+	t := &BudgetTracker{
+		agents: make(map[string]*Agent, len(agents)),
+		usage:  make(map[string]Usage),
+		models: make(map[string]Model),
+	}
+	for _, a := range agents {
+		model := a.Model
+		if model == "" {
+			model = ModelSonnet
+		}
+		t.agents[a.QualifiedName()] = a
+		t.models[a.QualifiedName()] = model
+	}
+	return t
+}
+
+// Record adds u to the agent's usage and checks it against the budget.
+// After a downgrade, the decision's Model is the tier to use from then on,
+// and a later breach downgrades again.
+func (t *BudgetTracker) Record(agent string, u Usage) BudgetDecision {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	total := t.usage[agent].Add(u)
+	t.usage[agent] = total
+	d := BudgetDecision{Agent: agent, Usage: total, Model: t.models[agent]}
+
+	a := t.agents[agent]
+	if a == nil || a.Budget == nil {
+		return d
+	}
+	if d.Exceeded = a.Budget.Exceeded(total); d.Exceeded == "" {
+		return d
+	}
+
+	d.Action = a.Budget.EffectiveOnExceed()
+	if d.Action == BudgetActionDowngrade {
+		next, ok := d.Model.Cheaper()
+		if target := a.Budget.DowngradeTo; target != "" {
+			next, ok = target, target.rank() < d.Model.rank()
+		}
+		if ok {
+			d.Model = next
+			t.models[agent] = next
+		} else {
+			d.Action = BudgetActionAbort
+		}
+	}
+	return d
+}
+
+// Usage returns the agent's usage so far.
+func (t *BudgetTracker) Usage(agent string) Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.usage[agent]
+}
+
+// ApplyBudgets checks each team's total usage against the budget of the
+// agent it ran, matched by AgentID or Name, and appends a budget task to
+// teams over budget, raising the team and report status to match. It
+// returns the decisions for teams over budget. Since the run is over,
+// downgrades are reported with the tier the executor would have switched to.
+func ApplyBudgets(report *TeamReport, agents []*Agent) []BudgetDecision {
+	var breaches []BudgetDecision
+	for i := range report.Teams {
+		team := &report.Teams[i]
+		name := team.AgentID
+		if name == "" {
+			name = team.Name
+		}
+		a := findAgent(agents, name)
+		if a == nil || a.Budget == nil {
+			continue
+		}
+		d := NewBudgetTracker([]*Agent{a}).Record(a.QualifiedName(), team.TotalUsage())
+		task, ok := d.TaskResult()
+		if !ok {
+			continue
+		}
+		team.Tasks = append(team.Tasks, task)
+		team.Status = worseStatus(team.Status, task.Status)
+		breaches = append(breaches, d)
+	}
+	if len(breaches) > 0 {
+		report.Status = report.ComputeOverallStatus()
+	}
+	return breaches
+}
+
+func findAgent(agents []*Agent, name string) *Agent {
+	for _, a := range agents {
+		if a.QualifiedName() == name || a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// worseStatus returns the more severe of a and b.
+func worseStatus(a, b Status) Status {
+	return computeStatusFromTasks([]TaskResult{{Status: a}, {Status: b}})
+}
@@ -0,0 +1,125 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBudgetValidate(t *testing.T) {
+	valid := []Budget{
+		{MaxTokens: 1000},
+		{MaxCostUSD: 0.5, OnExceed: BudgetActionWarn},
+		{MaxTokens: 1000, OnExceed: BudgetActionDowngrade, DowngradeTo: ModelHaiku},
+	}
+	for _, b := range valid {
+		if err := b.Validate(); err != nil {
+			t.Errorf("%+v: Validate() = %v", b, err)
+		}
+	}
+
+	tests := []struct {
+		budget Budget
+		want   string
+	}{
+		{Budget{}, "sets no limit"},
+		{Budget{MaxTokens: -1}, "must not be negative"},
+		{Budget{MaxTokens: 1, OnExceed: "pause"}, "unknown on_exceed action"},
+		{Budget{MaxTokens: 1, DowngradeTo: ModelHaiku}, "requires on_exceed downgrade"},
+		{Budget{MaxTokens: 1, OnExceed: BudgetActionDowngrade, DowngradeTo: "tiny"}, "unknown model"},
+	}
+	for _, tt := range tests {
+		err := tt.budget.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: Validate() = %v, want %q", tt.budget, err, tt.want)
+		}
+	}
+}
+
+func TestModelCheaper(t *testing.T) {
+	if m, ok := ModelOpus.Cheaper(); !ok || m != ModelSonnet {
+		t.Errorf("opus.Cheaper() = %q, %v", m, ok)
+	}
+	if m, ok := ModelSonnet.Cheaper(); !ok || m != ModelHaiku {
+		t.Errorf("sonnet.Cheaper() = %q, %v", m, ok)
+	}
+	if _, ok := ModelHaiku.Cheaper(); ok {
+		t.Error("haiku has no cheaper tier")
+	}
+}
+
+func TestBudgetTracker(t *testing.T) {
+	agents := []*Agent{
+		{Name: "writer", Model: ModelOpus, Budget: &Budget{MaxTokens: 1000, OnExceed: BudgetActionDowngrade}},
+		{Name: "critic", Namespace: "qa", Budget: &Budget{MaxCostUSD: 0.10}},
+		{Name: "free"},
+	}
+	tr := NewBudgetTracker(agents)
+
+	if d := tr.Record("writer", Usage{TokensIn: 600, TokensOut: 300}); d.Action != "" || d.Model != ModelOpus {
+		t.Errorf("within budget: %+v", d)
+	}
+	d := tr.Record("writer", Usage{TokensIn: 200})
+	if d.Action != BudgetActionDowngrade || d.Model != ModelSonnet || !d.Continue() || d.Exceeded != "1100 tokens exceeds limit of 1000" {
+		t.Errorf("first breach: %+v", d)
+	}
+	if d := tr.Record("writer", Usage{TokensOut: 1}); d.Model != ModelHaiku {
+		t.Errorf("second breach: %+v", d)
+	}
+	if d := tr.Record("writer", Usage{TokensOut: 1}); d.Action != BudgetActionAbort || d.Continue() {
+		t.Errorf("no cheaper tier: %+v", d)
+	}
+	if got := tr.Usage("writer").Tokens(); got != 1102 {
+		t.Errorf("writer tokens = %d", got)
+	}
+
+	d = tr.Record("qa/critic", Usage{CostUSD: 0.25})
+	task, ok := d.TaskResult()
+	if !ok || task.ID != "budget" || task.Status != StatusNoGo || task.Detail != "Budget exceeded, agent aborted: $0.2500 exceeds limit of $0.1000" {
+		t.Errorf("critic task = %+v", task)
+	}
+	if d := tr.Record("free", Usage{TokensIn: 1 << 40}); d.Action != "" {
+		t.Errorf("unbudgeted agent limited: %+v", d)
+	}
+	if _, ok := (BudgetDecision{}).TaskResult(); ok {
+		t.Error("TaskResult reported a breach within budget")
+	}
+}
+
+func TestBudgetDowngradeTo(t *testing.T) {
+	tr := NewBudgetTracker([]*Agent{{Name: "a", Budget: &Budget{MaxTokens: 1, OnExceed: BudgetActionDowngrade, DowngradeTo: ModelHaiku}}})
+	if d := tr.Record("a", Usage{TokensIn: 2}); d.Model != ModelHaiku || d.Action != BudgetActionDowngrade {
+		t.Errorf("downgrade from default sonnet: %+v", d)
+	}
+	if d := tr.Record("a", Usage{TokensIn: 1}); d.Action != BudgetActionAbort {
+		t.Errorf("already at downgrade_to: %+v", d)
+	}
+}
+
+func TestApplyBudgets(t *testing.T) {
+	report := &TeamReport{
+		Status: StatusGo,
+		Teams: []TeamSection{
+			{ID: "draft", Name: "writer", Status: StatusGo, Tasks: []TaskResult{{ID: "write", Status: StatusGo, TokensIn: 5000}}},
+			{ID: "review", Name: "critic", Status: StatusGo, CostUSD: 0.01, Tasks: []TaskResult{{ID: "review", Status: StatusGo}}},
+		},
+	}
+	agents := []*Agent{
+		{Name: "writer", Budget: &Budget{MaxTokens: 1000, OnExceed: BudgetActionWarn}},
+		{Name: "critic", Budget: &Budget{MaxCostUSD: 1}},
+	}
+
+	breaches := ApplyBudgets(report, agents)
+	if len(breaches) != 1 || breaches[0].Agent != "writer" {
+		t.Fatalf("breaches = %+v", breaches)
+	}
+	writer := report.Teams[0]
+	if len(writer.Tasks) != 2 || writer.Tasks[1].ID != "budget" || writer.Status != StatusWarn || report.Status != StatusWarn {
+		t.Errorf("report after ApplyBudgets = %+v", report)
+	}
+	if got := writer.TotalUsage().TokensIn; got != 5000 {
+		t.Errorf("writer tokens after ApplyBudgets = %d, want 5000", got)
+	}
+	if len(report.Teams[1].Tasks) != 1 {
+		t.Errorf("critic within budget got tasks %+v", report.Teams[1].Tasks)
+	}
+}
@@ -3,33 +3,33 @@ package multiagentspec
 // CollaborationConfig defines how agents interact in self-directed workflows.
 type CollaborationConfig struct {
 	// Lead is the lead agent name (required for crew workflow).
-	Lead string `json:"lead,omitempty"`
+	Lead string `json:"lead,omitempty" jsonschema_description:"Lead agent name (required for 'crew' workflow)"`
 
 	// Specialists are non-delegating specialist agent names.
-	Specialists []string `json:"specialists,omitempty"`
+	Specialists []string `json:"specialists,omitempty" jsonschema_description:"Non-delegating specialist agent names"`
 
 	// TaskQueue enables shared task queue for self-claiming (swarm workflow).
-	TaskQueue bool `json:"task_queue,omitempty"`
+	TaskQueue bool `json:"task_queue,omitempty" jsonschema:"default=false" jsonschema_description:"Enable shared task queue for self-claiming (for 'swarm' workflow)"`
 
 	// Consensus defines consensus rules (council workflow).
 	Consensus *ConsensusRules `json:"consensus,omitempty"`
 
 	// Channels define communication pathways between agents.
-	Channels []Channel `json:"channels,omitempty"`
+	Channels []Channel `json:"channels,omitempty" jsonschema_description:"Communication channels between agents"`
 }
 
 // ConsensusRules defines how agents reach agreement in council workflows.
 type ConsensusRules struct {
 	// RequiredAgreement is the fraction of agents that must agree (0.0-1.0).
 	// Default is 0.5 (simple majority).
-	RequiredAgreement float64 `json:"required_agreement,omitempty"`
+	RequiredAgreement float64 `json:"required_agreement,omitempty" jsonschema:"minimum=0,maximum=1,default=0.5" jsonschema_description:"Fraction of agents that must agree (e.g., 0.66 for 2/3 majority)"`
 
 	// MaxRounds is the maximum debate rounds before forcing decision.
 	// Default is 3.
-	MaxRounds int `json:"max_rounds,omitempty"`
+	MaxRounds int `json:"max_rounds,omitempty" jsonschema:"minimum=1,default=3" jsonschema_description:"Maximum debate rounds before forcing decision"`
 
 	// TieBreaker is the agent name to break ties, or "lead" for lead agent.
-	TieBreaker string `json:"tie_breaker,omitempty"`
+	TieBreaker string `json:"tie_breaker,omitempty" jsonschema_description:"Agent name to break ties, or 'lead' for lead agent"`
 }
 
 // ChannelType represents the communication pattern of a channel.
@@ -46,16 +46,21 @@ const (
 	ChannelPubSub ChannelType = "pub-sub"
 )
 
+// ChannelTypes returns all channel types in schema order.
+func ChannelTypes() []ChannelType {
+	return []ChannelType{ChannelDirect, ChannelBroadcast, ChannelPubSub}
+}
+
 // Channel defines a communication pathway between agents.
 type Channel struct {
 	// Name is the channel identifier.
-	Name string `json:"name"`
+	Name string `json:"name" jsonschema_description:"Channel identifier"`
 
 	// Type is the channel type: direct, broadcast, or pub-sub.
 	Type ChannelType `json:"type"`
 
 	// Participants are agent names. Use "*" for all agents.
-	Participants []string `json:"participants,omitempty"`
+	Participants []string `json:"participants,omitempty" jsonschema_description:"Agent names or '*' for all agents"`
 }
 
 // HasChannel returns true if a channel with the given name exists.
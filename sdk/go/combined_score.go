@@ -0,0 +1,126 @@
+package multiagentspec
+
+import "fmt"
+
+// DefaultLLMMaxScore is the scale of LLM scores when MaxScore is unset, and
+// the scale of rule and combined scores.
+const DefaultLLMMaxScore = 10
+
+// ScoreThresholds map a 0-10 score to a Status: GO at or above Go, WARN at
+// or above Warn, NO-GO below.
+type ScoreThresholds struct {
+	Go   float64 `json:"go"`
+	Warn float64 `json:"warn"`
+}
+
+// DefaultScoreThresholds are the thresholds ComputeCombinedScore applies.
+var DefaultScoreThresholds = ScoreThresholds{Go: 7, Warn: 5}
+
+// Status returns the status for score.
+func (t ScoreThresholds) Status(score float64) Status {
+	switch {
+	case score >= t.Go:
+		return StatusGo
+	case score >= t.Warn:
+		return StatusWarn
+	default:
+		return StatusNoGo
+	}
+}
+
+// CombinedScore is a rule-based and an LLM score merged by weight.
+type CombinedScore struct {
+	// Score is the weighted score on a 0-10 scale.
+	Score float64 `json:"score"`
+
+	// RuleScore is the rule-based input score (0-10).
+	RuleScore float64 `json:"ruleScore"`
+
+	// LLMScore is the LLM score normalized to 0-10.
+	LLMScore float64 `json:"llmScore"`
+
+	// Weights are the weights applied, normalized to sum to 1.
+	Weights CombinedWeights `json:"weights"`
+
+	// Status is Score mapped through DefaultScoreThresholds.
+	Status Status `json:"status"`
+}
+
+// ComputeCombinedScore merges a rule-based score (0-10) with an LLM
+// evaluation. The LLM score is normalized from its MaxScore (default 10) to
+// 0-10 and the weights are normalized to sum to 1, so {Rule: 3, LLM: 1}
+// means 75% rule. llm may be nil when weights.LLM is zero.
+func ComputeCombinedScore(ruleScore float64, llm *LLMEvaluation, weights CombinedWeights) (*CombinedScore, error) {
+	if weights.Rule < 0 || weights.LLM < 0 {
+		return nil, fmt.Errorf("weights must not be negative")
+	}
+	total := weights.Rule + weights.LLM
+	if total == 0 {
+		return nil, fmt.Errorf("weights must not both be zero")
+	}
+	if ruleScore < 0 || ruleScore > DefaultLLMMaxScore {
+		return nil, fmt.Errorf("rule score %g is outside 0-%d", ruleScore, DefaultLLMMaxScore)
+	}
+	c := &CombinedScore{
+		RuleScore: ruleScore,
+		Weights:   CombinedWeights{Rule: weights.Rule / total, LLM: weights.LLM / total},
+	}
+	if llm != nil {
+		score, err := llm.NormalizedScore()
+		if err != nil {
+			return nil, err
+		}
+		c.LLMScore = score
+	} else if weights.LLM > 0 {
+		return nil, fmt.Errorf("llm evaluation is required when its weight is %g", weights.LLM)
+	}
+	c.Score = c.Weights.Rule*c.RuleScore + c.Weights.LLM*c.LLMScore
+	c.Status = DefaultScoreThresholds.Status(c.Score)
+	return c, nil
+}
+
+// NormalizedScore returns the score scaled from MaxScore (default 10) to
+// 0-10.
+func (e *LLMEvaluation) NormalizedScore() (float64, error) {
+	max := e.MaxScore
+	if max == 0 {
+		max = DefaultLLMMaxScore
+	}
+	if max < 0 || e.Score < 0 || e.Score > max {
+		return 0, fmt.Errorf("llm score %g is outside 0-%g", e.Score, max)
+	}
+	return e.Score / max * DefaultLLMMaxScore, nil
+}
+
+// ApplyTo sets the task's Status from the combined score, records the
+// scores and weights in its Metadata, and fills an empty Detail with a
+// summary.
+func (c *CombinedScore) ApplyTo(task *TaskResult) {
+	task.Status = c.Status
+	if task.Metadata == nil {
+		task.Metadata = make(map[string]interface{})
+	}
+	task.Metadata["evaluation_type"] = string(EvaluationTypeCombined)
+	task.Metadata["combined_score"] = c.Score
+	task.Metadata["rule_score"] = c.RuleScore
+	task.Metadata["llm_score"] = c.LLMScore
+	task.Metadata["rule_weight"] = c.Weights.Rule
+	task.Metadata["llm_weight"] = c.Weights.LLM
+	if task.Detail == "" {
+		task.Detail = c.String()
+	}
+}
+
+// TaskResult returns a task result with the given id carrying the combined
+// score, as ApplyTo sets it.
+func (c *CombinedScore) TaskResult(id string) TaskResult {
+	task := TaskResult{ID: id}
+	c.ApplyTo(&task)
+	return task
+}
+
+// String summarizes the score, e.g., "7.4/10 (rule 8.0 x 0.60, llm 6.5 x 0.40)".
+func (c *CombinedScore) String() string {
+	return fmt.Sprintf("%.1f/%d (rule %.1f x %.2f, llm %.1f x %.2f)",
+		c.Score, DefaultLLMMaxScore, c.RuleScore, c.Weights.Rule, c.LLMScore, c.Weights.LLM)
+}
@@ -0,0 +1,97 @@
+package multiagentspec
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestComputeCombinedScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       float64
+		llm        *LLMEvaluation
+		weights    CombinedWeights
+		wantScore  float64
+		wantStatus Status
+	}{
+		{"weighted", 8, &LLMEvaluation{Score: 6}, CombinedWeights{Rule: 0.6, LLM: 0.4}, 7.2, StatusGo},
+		{"unnormalized weights", 8, &LLMEvaluation{Score: 4}, CombinedWeights{Rule: 1, LLM: 1}, 6, StatusWarn},
+		{"llm max score", 10, &LLMEvaluation{Score: 50, MaxScore: 100}, CombinedWeights{Rule: 0.5, LLM: 0.5}, 7.5, StatusGo},
+		{"rule only", 4, nil, CombinedWeights{Rule: 1}, 4, StatusNoGo},
+		{"llm only", 0, &LLMEvaluation{Score: 5}, CombinedWeights{LLM: 0.3}, 5, StatusWarn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ComputeCombinedScore(tt.rule, tt.llm, tt.weights)
+			if err != nil {
+				t.Fatalf("ComputeCombinedScore: %v", err)
+			}
+			if math.Abs(c.Score-tt.wantScore) > 1e-9 {
+				t.Errorf("Score = %g, want %g", c.Score, tt.wantScore)
+			}
+			if c.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", c.Status, tt.wantStatus)
+			}
+			if sum := c.Weights.Rule + c.Weights.LLM; math.Abs(sum-1) > 1e-9 {
+				t.Errorf("normalized weights sum to %g", sum)
+			}
+		})
+	}
+}
+
+func TestComputeCombinedScoreErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    float64
+		llm     *LLMEvaluation
+		weights CombinedWeights
+		wantErr string
+	}{
+		{"negative weight", 5, &LLMEvaluation{Score: 5}, CombinedWeights{Rule: -1, LLM: 1}, "must not be negative"},
+		{"zero weights", 5, &LLMEvaluation{Score: 5}, CombinedWeights{}, "must not both be zero"},
+		{"rule out of range", 11, &LLMEvaluation{Score: 5}, CombinedWeights{Rule: 1, LLM: 1}, "rule score 11 is outside 0-10"},
+		{"llm out of range", 5, &LLMEvaluation{Score: 12}, CombinedWeights{Rule: 1, LLM: 1}, "llm score 12 is outside 0-10"},
+		{"missing llm", 5, nil, CombinedWeights{Rule: 1, LLM: 1}, "llm evaluation is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ComputeCombinedScore(tt.rule, tt.llm, tt.weights)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ComputeCombinedScore() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScoreThresholds(t *testing.T) {
+	th := ScoreThresholds{Go: 8, Warn: 6}
+	for score, want := range map[float64]Status{9: StatusGo, 8: StatusGo, 7.9: StatusWarn, 6: StatusWarn, 5.9: StatusNoGo} {
+		if got := th.Status(score); got != want {
+			t.Errorf("Status(%g) = %s, want %s", score, got, want)
+		}
+	}
+}
+
+func TestCombinedScoreTaskResult(t *testing.T) {
+	c, err := ComputeCombinedScore(8, &LLMEvaluation{Score: 6.5}, CombinedWeights{Rule: 0.6, LLM: 0.4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := c.TaskResult("prd-quality")
+	if task.ID != "prd-quality" || task.Status != StatusGo {
+		t.Errorf("task = %+v", task)
+	}
+	if task.Detail != "7.4/10 (rule 8.0 x 0.60, llm 6.5 x 0.40)" {
+		t.Errorf("Detail = %q", task.Detail)
+	}
+	if task.Metadata["combined_score"] != c.Score || task.Metadata["evaluation_type"] != "combined" {
+		t.Errorf("Metadata = %v", task.Metadata)
+	}
+
+	existing := TaskResult{ID: "x", Status: StatusNoGo, Detail: "kept", Metadata: map[string]interface{}{"source": "ci"}}
+	c.ApplyTo(&existing)
+	if existing.Status != StatusGo || existing.Detail != "kept" || existing.Metadata["source"] != "ci" || existing.Metadata["llm_weight"] != 0.4 {
+		t.Errorf("ApplyTo = %+v", existing)
+	}
+}
@@ -0,0 +1,349 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// condition is a parsed Step.When expression.
+type condition struct {
+	expr string
+	root condNode
+	refs []string // referenced steps, in order of appearance
+}
+
+// condNode evaluates part of a condition given the outputs of finished
+// steps, keyed by step name then output name.
+type condNode func(outputs map[string]map[string]interface{}) (interface{}, error)
+
+// parseCondition parses a Step.When expression: output references
+// ('step_name.output_name'), string, number, boolean, and null literals,
+// the comparisons ==, !=, <, <=, >, and >=, and &&, ||, !, and
+// parentheses.
+func parseCondition(expr string) (*condition, error) {
+	toks, err := tokenizeCondition(expr)
+	if err != nil {
+		return nil, fmt.Errorf("when %q: %w", expr, err)
+	}
+	p := &condParser{toks: toks}
+	root, err := p.or()
+	if err == nil && p.pos < len(p.toks) {
+		err = fmt.Errorf("unexpected %q", p.toks[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("when %q: %w", expr, err)
+	}
+	return &condition{expr: expr, root: root, refs: p.refs}, nil
+}
+
+// eval reports whether the condition holds. A reference to an output that
+// was not produced is null.
+func (c *condition) eval(outputs map[string]map[string]interface{}) (bool, error) {
+	v, err := c.root(outputs)
+	if err != nil {
+		return false, fmt.Errorf("when %q: %w", c.expr, err)
+	}
+	return truthy(v), nil
+}
+
+type condTokenKind int
+
+const (
+	condOp condTokenKind = iota
+	condValue
+	condRef
+)
+
+type condToken struct {
+	kind  condTokenKind
+	text  string
+	value interface{} // condValue: the literal
+}
+
+func tokenizeCondition(expr string) ([]condToken, error) {
+	var toks []condToken
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			var b strings.Builder
+			for j < len(expr) && expr[j] != c {
+				if expr[j] == '\\' && j+1 < len(expr) {
+					j++
+				}
+				b.WriteByte(expr[j])
+				j++
+			}
+			if j >= len(expr) {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			toks = append(toks, condToken{kind: condValue, text: expr[i : j+1], value: b.String()})
+			i = j + 1
+		case c >= '0' && c <= '9' || c == '-' && i+1 < len(expr) && expr[i+1] >= '0' && expr[i+1] <= '9':
+			j := i + 1
+			for j < len(expr) && (expr[j] >= '0' && expr[j] <= '9' || expr[j] == '.') {
+				j++
+			}
+			n, err := strconv.ParseFloat(expr[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", expr[i:j])
+			}
+			toks = append(toks, condToken{kind: condValue, text: expr[i:j], value: n})
+			i = j
+		case c == '_' || c|0x20 >= 'a' && c|0x20 <= 'z':
+			j := i + 1
+			for j < len(expr) && (expr[j] == '_' || expr[j] == '-' || expr[j] == '.' ||
+				expr[j]|0x20 >= 'a' && expr[j]|0x20 <= 'z' || expr[j] >= '0' && expr[j] <= '9') {
+				j++
+			}
+			word := expr[i:j]
+			i = j
+			switch word {
+			case "true", "false":
+				toks = append(toks, condToken{kind: condValue, text: word, value: word == "true"})
+			case "null":
+				toks = append(toks, condToken{kind: condValue, text: word})
+			default:
+				if step, output, ok := strings.Cut(word, "."); !ok || step == "" || output == "" {
+					return nil, fmt.Errorf("unknown name %q (reference outputs as step_name.output_name)", word)
+				}
+				toks = append(toks, condToken{kind: condRef, text: word})
+			}
+		default:
+			op := ""
+			for _, candidate := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")"} {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q", c)
+			}
+			toks = append(toks, condToken{kind: condOp, text: op})
+			i += len(op)
+		}
+	}
+	return toks, nil
+}
+
+type condParser struct {
+	toks []condToken
+	pos  int
+	refs []string
+}
+
+func (p *condParser) accept(ops ...string) (string, bool) {
+	if p.pos < len(p.toks) && p.toks[p.pos].kind == condOp {
+		for _, op := range ops {
+			if p.toks[p.pos].text == op {
+				p.pos++
+				return op, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (p *condParser) or() (condNode, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||"); !ok {
+			return left, nil
+		}
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(out map[string]map[string]interface{}) (interface{}, error) {
+			v, err := l(out)
+			if err != nil || truthy(v) {
+				return true, err
+			}
+			v, err = right(out)
+			return truthy(v), err
+		}
+	}
+}
+
+func (p *condParser) and() (condNode, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(out map[string]map[string]interface{}) (interface{}, error) {
+			v, err := l(out)
+			if err != nil || !truthy(v) {
+				return false, err
+			}
+			v, err = right(out)
+			return truthy(v), err
+		}
+	}
+}
+
+func (p *condParser) unary() (condNode, error) {
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(out map[string]map[string]interface{}) (interface{}, error) {
+			v, err := operand(out)
+			return !truthy(v), err
+		}, nil
+	}
+	return p.comparison()
+}
+
+func (p *condParser) comparison() (condNode, error) {
+	left, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+	return func(out map[string]map[string]interface{}) (interface{}, error) {
+		a, err := left(out)
+		if err != nil {
+			return nil, err
+		}
+		b, err := right(out)
+		if err != nil {
+			return nil, err
+		}
+		return compareValues(op, a, b)
+	}, nil
+}
+
+func (p *condParser) primary() (condNode, error) {
+	if p.pos >= len(p.toks) {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+	tok := p.toks[p.pos]
+	p.pos++
+	switch tok.kind {
+	case condValue:
+		return func(map[string]map[string]interface{}) (interface{}, error) { return tok.value, nil }, nil
+	case condRef:
+		step, output, _ := strings.Cut(tok.text, ".")
+		p.refs = append(p.refs, step)
+		return func(out map[string]map[string]interface{}) (interface{}, error) { return out[step][output], nil }, nil
+	}
+	if tok.text == "(" {
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.accept(")"); !ok {
+			return nil, fmt.Errorf("missing )")
+		}
+		return inner, nil
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+// compareValues applies a comparison operator. Numbers compare by value
+// whatever their Go type; other values are equal if deeply equal, and
+// only numbers and strings are ordered.
+func compareValues(op string, a, b interface{}) (bool, error) {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			a, b = x, y
+		}
+	}
+	switch op {
+	case "==":
+		return reflect.DeepEqual(a, b), nil
+	case "!=":
+		return !reflect.DeepEqual(a, b), nil
+	}
+	var cmp int
+	switch x := a.(type) {
+	case float64:
+		y, ok := b.(float64)
+		if !ok {
+			return false, fmt.Errorf("cannot compare %v %s %v", a, op, b)
+		}
+		switch {
+		case x < y:
+			cmp = -1
+		case x > y:
+			cmp = 1
+		}
+	case string:
+		y, ok := b.(string)
+		if !ok {
+			return false, fmt.Errorf("cannot compare %q %s %v", x, op, b)
+		}
+		cmp = strings.Compare(x, y)
+	default:
+		return false, fmt.Errorf("cannot compare %v %s %v", a, op, b)
+	}
+	switch op {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	}
+	return cmp >= 0, nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// truthy reports whether a value counts as true: false, null, 0, and ""
+// do not.
+func truthy(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return x
+	case string:
+		return x != ""
+	}
+	if n, ok := toFloat(v); ok {
+		return n != 0
+	}
+	return true
+}
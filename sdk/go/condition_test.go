@@ -0,0 +1,73 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConditionEval(t *testing.T) {
+	outputs := map[string]map[string]interface{}{
+		"review": {"approved": true, "score": 7.5, "verdict": "ship"},
+		"tests":  {"failures": 0, "passed": int64(42)},
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"review.approved", true},
+		{"review.approved == true", true},
+		{"!review.approved", false},
+		{"review.score >= 7", true},
+		{"review.score < 7 || tests.failures == 0", true},
+		{"review.verdict == 'ship' && tests.passed > 40", true},
+		{`review.verdict != "ship"`, false},
+		{"!(tests.failures > 0)", true},
+		{"review.missing", false},
+		{"review.missing == null", true},
+		{"other.output == null", true},
+		{"tests.failures", false},
+		{"review.score > -1", true},
+	}
+	for _, tt := range tests {
+		c, err := parseCondition(tt.expr)
+		if err != nil {
+			t.Fatalf("parseCondition(%q): %v", tt.expr, err)
+		}
+		got, err := c.eval(outputs)
+		if err != nil {
+			t.Fatalf("eval(%q): %v", tt.expr, err)
+		}
+		if got != tt.want {
+			t.Errorf("eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	c, err := parseCondition("review.approved && (tests.failures == 0 || other.ok)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"review", "tests", "other"}; !reflect.DeepEqual(c.refs, want) {
+		t.Errorf("refs = %v, want %v", c.refs, want)
+	}
+
+	c, _ = parseCondition("review.verdict > 3")
+	if _, err := c.eval(outputs); err == nil || err.Error() != `when "review.verdict > 3": cannot compare "ship" > 3` {
+		t.Errorf("eval error = %v", err)
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	tests := map[string]string{
+		"approved":            `when "approved": unknown name "approved" (reference outputs as step_name.output_name)`,
+		"review.ok ==":        `when "review.ok ==": unexpected end of expression`,
+		"(review.ok":          `when "(review.ok": missing )`,
+		"review.ok = true":    `when "review.ok = true": unexpected '='`,
+		"review.v == 'ship":   `when "review.v == 'ship": unterminated string`,
+		"review.ok review.ok": `when "review.ok review.ok": unexpected "review.ok"`,
+	}
+	for expr, want := range tests {
+		if _, err := parseCondition(expr); err == nil || err.Error() != want {
+			t.Errorf("parseCondition(%q) error = %v, want %s", expr, err, want)
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package multiagentspec
 
+import (
+	"fmt"
+	"strings"
+)
+
 // ContentBlockType discriminates content block variants.
 type ContentBlockType string
 
@@ -11,6 +16,14 @@ const (
 	ContentBlockMetric  ContentBlockType = "metric"
 )
 
+// ContentBlockTypes returns all content block types in schema order.
+func ContentBlockTypes() []ContentBlockType {
+	return []ContentBlockType{
+		ContentBlockKVPairs, ContentBlockList, ContentBlockTable,
+		ContentBlockText, ContentBlockMetric,
+	}
+}
+
 // ContentBlock represents rich content within a report section.
 // The Type field determines which other fields are relevant:
 //   - kv_pairs: uses Pairs
@@ -136,3 +149,65 @@ func NewMetricBlock(label, value string, status Status, target string) ContentBl
 		Target: target,
 	}
 }
+
+// IsEmpty reports whether the block has nothing to show beyond its title:
+// no pairs, items, or rows, blank text, or a metric without a value.
+func (b ContentBlock) IsEmpty() bool {
+	switch b.Type {
+	case ContentBlockKVPairs:
+		return len(b.Pairs) == 0
+	case ContentBlockList:
+		return len(b.Items) == 0
+	case ContentBlockTable:
+		return len(b.Rows) == 0
+	case ContentBlockText:
+		return strings.TrimSpace(b.Content) == ""
+	case ContentBlockMetric:
+		return b.Value == ""
+	}
+	return false
+}
+
+// Validate checks that the block has a known type and the fields that type
+// uses: pairs with keys, items with text, table rows as wide as the
+// headers, text content, or a metric label and value. Statuses must be
+// known.
+func (b ContentBlock) Validate() error {
+	switch b.Type {
+	case ContentBlockKVPairs:
+		for i, p := range b.Pairs {
+			if p.Key == "" {
+				return fmt.Errorf("kv_pairs block: pair %d: key is required", i)
+			}
+		}
+	case ContentBlockList:
+		for i, item := range b.Items {
+			if item.Text == "" {
+				return fmt.Errorf("list block: item %d: text is required", i)
+			}
+			if !item.Status.valid() {
+				return fmt.Errorf("list block: item %d: unknown status %q", i, item.Status)
+			}
+		}
+	case ContentBlockTable:
+		for i, row := range b.Rows {
+			if len(b.Headers) > 0 && len(row) != len(b.Headers) {
+				return fmt.Errorf("table block: row %d has %d cells, want %d", i, len(row), len(b.Headers))
+			}
+		}
+	case ContentBlockText:
+		if b.Content == "" {
+			return fmt.Errorf("text block: content is required")
+		}
+	case ContentBlockMetric:
+		if b.Label == "" || b.Value == "" {
+			return fmt.Errorf("metric block: label and value are required")
+		}
+	default:
+		return fmt.Errorf("unknown content block type %q", b.Type)
+	}
+	if !b.Status.valid() {
+		return fmt.Errorf("%s block: unknown status %q", b.Type, b.Status)
+	}
+	return nil
+}
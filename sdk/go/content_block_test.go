@@ -192,7 +192,7 @@ func TestRenderWithContentBlocks(t *testing.T) {
 }
 
 func TestRenderTable(t *testing.T) {
-	lines := renderTable(
+	lines := testBox().renderTable(
 		[]string{"Name", "Status"},
 		[][]string{
 			{"auth", "GO"},
@@ -222,7 +222,7 @@ func TestRenderTable(t *testing.T) {
 
 func TestWrapText(t *testing.T) {
 	content := "This is a long line that should be wrapped to fit within the box width properly"
-	lines := wrapText(content, 40)
+	lines := testBox().wrapText(content, 40)
 
 	for _, line := range lines {
 		// Each line should be a paddedLine with visual width of boxWidth+2
@@ -286,7 +286,7 @@ func TestBackwardCompatibility(t *testing.T) {
 
 func TestRenderMetric(t *testing.T) {
 	t.Run("without target", func(t *testing.T) {
-		line := renderMetric("Coverage", "85%", StatusGo, "")
+		line := testBox().renderMetric("Coverage", "85%", StatusGo, "")
 
 		if !strings.Contains(line, "Coverage") {
 			t.Error("expected label in output")
@@ -300,7 +300,7 @@ func TestRenderMetric(t *testing.T) {
 	})
 
 	t.Run("with target", func(t *testing.T) {
-		line := renderMetric("Coverage", "85%", StatusGo, "80%")
+		line := testBox().renderMetric("Coverage", "85%", StatusGo, "80%")
 
 		if !strings.Contains(line, "Coverage") {
 			t.Error("expected label in output")
@@ -319,7 +319,7 @@ func TestRenderKVPairs(t *testing.T) {
 		{Key: "Name", Value: "test"},
 		{Key: "Status", Value: "active", Icon: "✅"},
 	}
-	lines := renderKVPairs(pairs)
+	lines := testBox().renderKVPairs(pairs)
 
 	if len(lines) != 2 {
 		t.Errorf("expected 2 lines, got %d", len(lines))
@@ -339,7 +339,7 @@ func TestRenderList(t *testing.T) {
 		{Text: "Item with icon", Icon: "•"},
 		{Text: "Item with status", Status: StatusWarn},
 	}
-	lines := renderList(items)
+	lines := testBox().renderList(items)
 
 	if len(lines) != 3 {
 		t.Errorf("expected 3 lines, got %d", len(lines))
@@ -909,3 +909,65 @@ func TestQuickRendererMetricBlock(t *testing.T) {
 	// Coverage is GO so should have green icon nearby
 	// Performance is WARN so should have yellow icon nearby
 }
+
+func TestContentBlockValidate(t *testing.T) {
+	valid := []ContentBlock{
+		NewKVPairsBlock("META", KVPair{Key: "Name", Value: "test"}),
+		NewListBlock("FINDINGS", ListItem{Text: "Issue", Status: StatusWarn}),
+		NewTableBlock("TABLE", []string{"A", "B"}, [][]string{{"1", "2"}}),
+		NewTextBlock("NOTES", "All good"),
+		NewMetricBlock("Coverage", "82%", StatusGo, "80%"),
+	}
+	for _, b := range valid {
+		if err := b.Validate(); err != nil {
+			t.Errorf("%s block: %v", b.Type, err)
+		}
+	}
+
+	invalid := []struct {
+		block ContentBlock
+		want  string
+	}{
+		{ContentBlock{Type: "chart"}, `unknown content block type "chart"`},
+		{NewKVPairsBlock("", KVPair{Value: "x"}), "pair 0: key is required"},
+		{NewListBlock("", ListItem{Text: "x", Status: "OK"}), `item 0: unknown status "OK"`},
+		{NewTableBlock("", []string{"A", "B"}, [][]string{{"1"}}), "row 0 has 1 cells, want 2"},
+		{NewTextBlock("NOTES", ""), "content is required"},
+		{NewMetricBlock("Coverage", "", StatusGo, ""), "label and value are required"},
+		{NewMetricBlock("Coverage", "82%", "PASS", ""), `unknown status "PASS"`},
+	}
+	for _, tt := range invalid {
+		err := tt.block.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s block: Validate() = %v, want %q", tt.block.Type, err, tt.want)
+		}
+	}
+}
+
+func TestContentBlockIsEmpty(t *testing.T) {
+	tests := []struct {
+		block ContentBlock
+		empty bool
+	}{
+		{NewKVPairsBlock("t"), true},
+		{NewKVPairsBlock("t", KVPair{Key: "k", Value: "v"}), false},
+		{NewListBlock("t"), true},
+		{NewListBlock("t", ListItem{Text: "a"}), false},
+		{NewTableBlock("t", []string{"A"}, nil), true},
+		{NewTableBlock("t", []string{"A"}, [][]string{{"1"}}), false},
+		{NewTextBlock("t", " \n"), true},
+		{NewTextBlock("t", "text"), false},
+		{NewMetricBlock("Coverage", "", StatusGo, ""), true},
+		{NewMetricBlock("Coverage", "80%", StatusGo, ""), false},
+	}
+	for _, tt := range tests {
+		if got := tt.block.IsEmpty(); got != tt.empty {
+			t.Errorf("%s block %+v: IsEmpty = %v, want %v", tt.block.Type, tt.block, got, tt.empty)
+		}
+	}
+}
+
+// testBox returns the default box for testing its line helpers.
+func testBox() *box {
+	return &box{Theme: &ThemeDouble, width: boxWidth}
+}
@@ -0,0 +1,204 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ContractTaskID is the ID of the task that reports a result's contract
+// violations.
+const ContractTaskID = "contract"
+
+// ContractViolation is a difference between an agent result's inputs or
+// outputs and the ports its workflow step declares.
+type ContractViolation struct {
+	// Step is the step whose result violates its contract.
+	Step string
+
+	// Port is the input or output port concerned.
+	Port string
+
+	// Input is true for an input port and false for an output port.
+	Input bool
+
+	// Status is NO-GO for a missing or mistyped value and WARN for an
+	// input that differs from what its upstream step produced.
+	Status Status
+
+	// Problem describes the violation (e.g., "is missing").
+	Problem string
+}
+
+// String describes the violation, e.g., "output approved is missing".
+func (v ContractViolation) String() string {
+	kind := "output"
+	if v.Input {
+		kind = "input"
+	}
+	return fmt.Sprintf("%s %s %s", kind, v.Port, v.Problem)
+}
+
+// CheckContract compares the result of a workflow step, matched by
+// StepID, with the step's ports. Every declared output must be in
+// Outputs, and each value, input or output, must have its port's Type and
+// match its Schema. If the result reports Inputs, each input read from an
+// upstream output must equal the value in outputs, which holds the
+// outputs of the steps that ran by step name; a required input must be
+// present. Results of unknown steps, skipped results, and results with an
+// Error are not checked.
+func (w *Workflow) CheckContract(result AgentResult, outputs map[string]map[string]interface{}) []ContractViolation {
+	if result.Status == StatusSkip || result.Error != "" {
+		return nil
+	}
+	i := w.stepIndexes(result.StepID)
+	if len(i) == 0 {
+		return nil
+	}
+	s := w.Steps[i[0]]
+	var violations []ContractViolation
+	add := func(port string, input bool, status Status, format string, args ...interface{}) {
+		violations = append(violations, ContractViolation{
+			Step: s.Name, Port: port, Input: input, Status: status, Problem: fmt.Sprintf(format, args...),
+		})
+	}
+	for _, out := range s.Outputs {
+		value, ok := result.Outputs[out.Name]
+		if !ok {
+			add(out.Name, false, StatusNoGo, "is missing")
+			continue
+		}
+		if problem := checkPortValue(out, value); problem != "" {
+			add(out.Name, false, StatusNoGo, "%s", problem)
+		}
+	}
+	if result.Inputs == nil {
+		return violations
+	}
+	for _, in := range s.Inputs {
+		value, ok := result.Inputs[in.Name]
+		var upstream interface{}
+		produced := false
+		if in.From != "" {
+			from, output, _ := strings.Cut(in.From, ".")
+			upstream, produced = outputs[from][output]
+		}
+		switch {
+		case !ok && produced:
+			add(in.Name, true, StatusWarn, "was not received from %s", in.From)
+		case !ok && in.Required != nil && *in.Required && in.Default == nil:
+			add(in.Name, true, StatusNoGo, "is required but has no value")
+		case !ok:
+		case produced && !sameJSONValue(value, upstream):
+			add(in.Name, true, StatusWarn, "differs from %s", in.From)
+		default:
+			if problem := checkPortValue(in, value); problem != "" {
+				add(in.Name, true, StatusNoGo, "%s", problem)
+			}
+		}
+	}
+	return violations
+}
+
+// contractTask returns a task reporting violations, with the most severe
+// of their statuses.
+func contractTask(violations []ContractViolation) TaskResult {
+	task := TaskResult{ID: ContractTaskID, Status: StatusGo}
+	problems := make([]string, len(violations))
+	for i, v := range violations {
+		task.Status = worseStatus(task.Status, v.Status)
+		problems[i] = v.String()
+	}
+	task.Detail = strings.Join(problems, "; ")
+	return task
+}
+
+// checkPortValue returns why value does not fit port, or "" if it does.
+func checkPortValue(port Port, value interface{}) string {
+	v, err := jsonValue(value)
+	if err != nil {
+		return fmt.Sprintf("is not JSON: %v", err)
+	}
+	if port.Type != "" {
+		if got := jsonType(v); !portTypeAccepts(port.Type, got) {
+			return fmt.Sprintf("is %s, want %s", got, port.Type)
+		}
+	}
+	if len(port.Schema) == 0 {
+		return ""
+	}
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(port.Schema))
+	if err != nil {
+		return fmt.Sprintf("has an invalid schema: %v", err)
+	}
+	url := "urn:multi-agent-spec:port:" + port.Name
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(url, doc); err != nil {
+		return fmt.Sprintf("has an invalid schema: %v", err)
+	}
+	schema, err := c.Compile(url)
+	if err != nil {
+		return fmt.Sprintf("has an invalid schema: %v", err)
+	}
+	// Validate the value as jsonschema decodes it, with json.Number numbers.
+	data, _ := json.Marshal(v)
+	inst, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Sprintf("is not JSON: %v", err)
+	}
+	if err := schema.Validate(inst); err != nil {
+		return fmt.Sprintf("does not match its schema: %v", err)
+	}
+	return ""
+}
+
+// jsonValue returns value as encoding/json decodes it, so Go values from
+// in-process runners compare and type-check like values read from JSON.
+func jsonValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+// jsonType returns the JSON type of a decoded JSON value.
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return string(PortTypeString)
+	case float64:
+		return string(PortTypeNumber)
+	case bool:
+		return string(PortTypeBoolean)
+	case map[string]interface{}:
+		return string(PortTypeObject)
+	case []interface{}:
+		return string(PortTypeArray)
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// portTypeAccepts reports whether a port of type t accepts a value of the
+// JSON type got. File ports hold paths.
+func portTypeAccepts(t PortType, got string) bool {
+	if t == PortTypeFile {
+		return got == string(PortTypeString)
+	}
+	return string(t) == got
+}
+
+// sameJSONValue reports whether a and b encode to the same JSON value.
+func sameJSONValue(a, b interface{}) bool {
+	av, aerr := jsonValue(a)
+	bv, berr := jsonValue(b)
+	return aerr == nil && berr == nil && reflect.DeepEqual(av, bv)
+}
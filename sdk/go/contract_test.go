@@ -0,0 +1,114 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func contractWorkflow() *Workflow {
+	required := true
+	return &Workflow{Type: WorkflowGraph, Steps: []Step{
+		{Name: "review", Agent: "reviewer", Outputs: []Port{
+			{Name: "approved", Type: PortTypeBoolean},
+			{Name: "score", Type: PortTypeNumber, Schema: json.RawMessage(`{"minimum": 0, "maximum": 10}`)},
+			{Name: "notes", Type: PortTypeString},
+		}},
+		{Name: "release", Agent: "releaser", DependsOn: []string{"review"}, Inputs: []Port{
+			{Name: "approved", Type: PortTypeBoolean, From: "review.approved"},
+			{Name: "score", From: "review.score"},
+			{Name: "channel", Type: PortTypeString, Required: &required},
+		}},
+	}}
+}
+
+func TestCheckContract(t *testing.T) {
+	w := contractWorkflow()
+	upstream := map[string]map[string]interface{}{
+		"review": {"approved": true, "score": 7},
+	}
+
+	review := AgentResult{StepID: "review", Status: StatusGo, Outputs: map[string]interface{}{
+		"approved": "yes",
+		"score":    12,
+	}}
+	var got []string
+	for _, v := range w.CheckContract(review, nil) {
+		got = append(got, string(v.Status)+" "+v.String())
+	}
+	want := []string{
+		"NO-GO output approved is string, want boolean",
+		"NO-GO output score does not match its schema",
+		"NO-GO output notes is missing",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CheckContract() = %q, want %d violations", got, len(want))
+	}
+	for i := range want {
+		if !strings.HasPrefix(got[i], want[i]) {
+			t.Errorf("violation %d = %q, want prefix %q", i, got[i], want[i])
+		}
+	}
+
+	release := AgentResult{StepID: "release", Status: StatusGo, Inputs: map[string]interface{}{
+		"approved": true,
+		"score":    7.0, // equal once both are JSON
+	}}
+	violations := w.CheckContract(release, upstream)
+	if len(violations) != 1 || violations[0].String() != "input channel is required but has no value" || !violations[0].Input {
+		t.Errorf("CheckContract() = %v, want the missing required input", violations)
+	}
+
+	release.Inputs = map[string]interface{}{"approved": false, "channel": 1}
+	got = nil
+	for _, v := range w.CheckContract(release, upstream) {
+		got = append(got, string(v.Status)+" "+v.String())
+	}
+	want = []string{
+		"WARN input approved differs from review.approved",
+		"WARN input score was not received from review.score",
+		"NO-GO input channel is number, want string",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("CheckContract() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	for _, r := range []AgentResult{
+		{StepID: "review", Status: StatusSkip},
+		{StepID: "review", Status: StatusNoGo, Error: "timeout"},
+		{StepID: "unknown", Status: StatusGo},
+		{StepID: "release", Status: StatusGo}, // reports no inputs
+	} {
+		if v := w.CheckContract(r, upstream); len(v) != 0 {
+			t.Errorf("CheckContract(%s %s) = %v, want none", r.StepID, r.Status, v)
+		}
+	}
+}
+
+func TestStreamAggregatorCheckContracts(t *testing.T) {
+	agg := NewStreamAggregator("app", "v1.0.0", "REVIEW")
+	agg.CheckContracts(contractWorkflow())
+	tasks := []TaskResult{{ID: "run", Status: StatusGo}}
+	agg.Add(AgentResult{AgentID: "reviewer", StepID: "review", Tasks: tasks, Outputs: map[string]interface{}{
+		"approved": true, "score": 9, "notes": "ok",
+	}})
+	agg.Add(AgentResult{AgentID: "releaser", StepID: "release", Tasks: tasks, Inputs: map[string]interface{}{
+		"approved": false, "score": 9, "channel": "stable",
+	}})
+
+	report := agg.Report()
+	if len(report.Teams[0].Tasks) != 1 || report.Teams[0].Status != StatusGo {
+		t.Errorf("review team = %+v, want no contract task", report.Teams[0])
+	}
+	release := report.Teams[1]
+	if len(release.Tasks) != 2 || len(tasks) != 1 {
+		t.Fatalf("release tasks = %+v, want a contract task added to a copy", release.Tasks)
+	}
+	task := release.Tasks[1]
+	if task.ID != ContractTaskID || task.Status != StatusWarn || task.Detail != "input approved differs from review.approved" {
+		t.Errorf("contract task = %+v", task)
+	}
+	if release.Status != StatusWarn || report.Status != StatusWarn {
+		t.Errorf("statuses = %s, %s; want WARN", release.Status, report.Status)
+	}
+}
@@ -0,0 +1,141 @@
+package multiagentspec
+
+// Ancestors returns the IDs of the teams the team with id depends on,
+// directly or through other teams, in report order.
+func (r *TeamReport) Ancestors(id string) []string {
+	return r.teamIDs(reachable(r.dependencyGraph(), r.teamIndexes(id)))
+}
+
+// Descendants returns the IDs of the teams that depend on the team with
+// id, directly or through other teams, in report order.
+func (r *TeamReport) Descendants(id string) []string {
+	return r.teamIDs(reachable(reverseGraph(r.dependencyGraph()), r.teamIndexes(id)))
+}
+
+// Impact returns the IDs of the teams that cannot pass if the teams with
+// ids fail: those that depend on any of them, directly or through other
+// teams, in report order.
+func (r *TeamReport) Impact(ids ...string) []string {
+	return r.teamIDs(reachable(reverseGraph(r.dependencyGraph()), r.teamIndexes(ids...)))
+}
+
+// BlockedBy returns the IDs of the NO-GO teams the team with id depends
+// on, directly or through other teams, in report order, e.g., to show why
+// a team was skipped.
+func (r *TeamReport) BlockedBy(id string) []string {
+	var blocked []string
+	for _, ancestor := range r.Ancestors(id) {
+		for _, t := range r.Teams {
+			if t.ID == ancestor && t.Status == StatusNoGo {
+				blocked = append(blocked, ancestor)
+				break
+			}
+		}
+	}
+	return blocked
+}
+
+// teamIndexes returns the indexes of the teams with ids.
+func (r *TeamReport) teamIndexes(ids ...string) []int {
+	var indexes []int
+	for i, t := range r.Teams {
+		for _, id := range ids {
+			if t.ID == id {
+				indexes = append(indexes, i)
+				break
+			}
+		}
+	}
+	return indexes
+}
+
+// teamIDs returns the IDs of the teams marked in reached, each once.
+func (r *TeamReport) teamIDs(reached []bool) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for i, ok := range reached {
+		if id := r.Teams[i].ID; ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Ancestors returns the names of the steps the step runs after, directly
+// or through other steps, in workflow order. In a chain, a step without
+// depends_on runs after the step before it.
+func (w *Workflow) Ancestors(step string) []string {
+	return w.stepNames(reachable(w.dependencyGraph(), w.stepIndexes(step)))
+}
+
+// Descendants returns the names of the steps that run after the step,
+// directly or through other steps, in workflow order.
+func (w *Workflow) Descendants(step string) []string {
+	return w.stepNames(reachable(reverseGraph(w.dependencyGraph()), w.stepIndexes(step)))
+}
+
+// Impact returns the names of the steps that are skipped if the steps
+// fail: those that run after any of them, directly or through other steps,
+// in workflow order. Rerunning a failed step means rerunning these too.
+func (w *Workflow) Impact(steps ...string) []string {
+	return w.stepNames(reachable(reverseGraph(w.dependencyGraph()), w.stepIndexes(steps...)))
+}
+
+// stepIndexes returns the indexes of the steps with names.
+func (w *Workflow) stepIndexes(names ...string) []int {
+	var indexes []int
+	for i, s := range w.Steps {
+		for _, name := range names {
+			if s.Name == name {
+				indexes = append(indexes, i)
+				break
+			}
+		}
+	}
+	return indexes
+}
+
+// stepNames returns the names of the steps marked in reached.
+func (w *Workflow) stepNames(reached []bool) []string {
+	var names []string
+	for i, ok := range reached {
+		if ok {
+			names = append(names, w.Steps[i].Name)
+		}
+	}
+	return names
+}
+
+// reachable marks the nodes of a graph, given as the edges from each node
+// by index, reached from the nodes in from by one or more edges. The nodes
+// in from are left unmarked.
+func reachable(edges [][]int, from []int) []bool {
+	reached := make([]bool, len(edges))
+	queue := append([]int(nil), from...)
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for _, j := range edges[i] {
+			if !reached[j] {
+				reached[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+	for _, i := range from {
+		reached[i] = false
+	}
+	return reached
+}
+
+// reverseGraph returns the graph with every edge reversed.
+func reverseGraph(edges [][]int) [][]int {
+	reversed := make([][]int, len(edges))
+	for i, es := range edges {
+		for _, j := range es {
+			reversed[j] = append(reversed[j], i)
+		}
+	}
+	return reversed
+}
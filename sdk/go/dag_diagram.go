@@ -0,0 +1,136 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dagDiagram returns the WithDAG lines drawing the teams' DependsOn DAG,
+// or "" if it is not enabled or no team depends on another.
+//
+// The Levels of the report are drawn as columns from left to right, each
+// team with an arrow from the level before it. Teams whose dependencies
+// the columns leave ambiguous, such as those with several, are listed
+// after the diagram with theirs. When the columns do not fit the box,
+// each level gets its own line and every dependency is listed instead.
+func (b *box) dagDiagram(report *TeamReport) string {
+	if !b.dag || !hasDependencies(report) {
+		return ""
+	}
+	teams := make(map[string]TeamSection, len(report.Teams))
+	for _, t := range report.Teams {
+		teams[t.ID] = t
+	}
+	label := func(id string) string {
+		return teams[id].Status.Icon() + " " + id
+	}
+	deps := func(id string) []string {
+		var known []string
+		for _, dep := range teams[id].DependsOn {
+			if _, ok := teams[dep]; ok {
+				known = append(known, dep)
+			}
+		}
+		return known
+	}
+	arrow := b.Arrow
+	if arrow == "" {
+		arrow = ">"
+	}
+	connector := " " + b.TableRule + b.TableRule + arrow + " "
+
+	var levels [][]string
+	for _, level := range report.Levels() {
+		ids := make([]string, len(level))
+		for i, t := range level {
+			ids[i] = t.ID
+		}
+		levels = append(levels, ids)
+	}
+	lines := []string{b.paddedLine("Workflow:")}
+	grid, ok := b.dagGrid(levels, label, connector)
+	if ok {
+		lines = append(lines, grid...)
+	} else {
+		for i, level := range levels {
+			labels := make([]string, len(level))
+			for j, id := range level {
+				labels[j] = label(id)
+			}
+			lines = append(lines, b.wrapText(fmt.Sprintf("%d. %s", i+1, strings.Join(labels, ", ")), b.width-2)...)
+		}
+	}
+	for c := 1; c < len(levels); c++ {
+		for r, id := range levels[c] {
+			d := deps(id)
+			if ok && len(d) == 1 && dagNeighbor(levels[c-1], r, d[0]) {
+				continue
+			}
+			lines = append(lines, b.wrapText(strings.Join(d, ", ")+connector+id, b.width-2)...)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// dagGrid returns the lines drawing levels as columns joined by
+// connector, or false if they do not fit the box.
+func (b *box) dagGrid(levels [][]string, label func(string) string, connector string) ([]string, bool) {
+	gap := visualLength(connector)
+	widths := make([]int, len(levels))
+	total, rows := gap*(len(levels)-1), 0
+	for c, level := range levels {
+		for _, id := range level {
+			widths[c] = max(widths[c], visualLength(label(id)))
+		}
+		total += widths[c]
+		rows = max(rows, len(level))
+	}
+	if total > b.width-2 {
+		return nil, false
+	}
+
+	lines := make([]string, 0, rows)
+	for r := 0; r < rows; r++ {
+		var sb strings.Builder
+		for c, level := range levels {
+			if r >= len(level) {
+				writeSpaces(&sb, gap*min(c, 1)+widths[c])
+				continue
+			}
+			if c > 0 {
+				sb.WriteString(connector)
+			}
+			text := label(level[r])
+			sb.WriteString(text)
+			writeSpaces(&sb, widths[c]-visualLength(text))
+		}
+		lines = append(lines, b.paddedLine(strings.TrimRight(sb.String(), " ")))
+	}
+	return lines, true
+}
+
+// dagNeighbor reports whether the grid's arrow into row r shows dep
+// unambiguously: it is alone in the level before, or in the same row.
+func dagNeighbor(prev []string, r int, dep string) bool {
+	if len(prev) == 1 {
+		return prev[0] == dep
+	}
+	return r < len(prev) && prev[r] == dep
+}
+
+// hasDependencies reports whether any team depends on another team in the
+// report.
+func hasDependencies(report *TeamReport) bool {
+	ids := make(map[string]bool, len(report.Teams))
+	for _, t := range report.Teams {
+		ids[t.ID] = true
+	}
+	for _, t := range report.Teams {
+		for _, dep := range t.DependsOn {
+			if ids[dep] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func dagTestReport() *TeamReport {
+	return &TeamReport{Project: "app", Version: "v1.0.0", Phase: "RELEASE", Teams: []TeamSection{
+		{ID: "build", Name: "Build", Status: StatusGo},
+		{ID: "lint", Name: "Lint", Status: StatusGo, DependsOn: []string{"build"}},
+		{ID: "test", Name: "Test", Status: StatusNoGo, DependsOn: []string{"build"}},
+		{ID: "review", Name: "Review", Status: StatusGo, DependsOn: []string{"lint"}},
+		{ID: "release", Name: "Release", Status: StatusSkip, DependsOn: []string{"review", "test"}},
+	}}
+}
+
+// dagLines returns the trimmed inner text of the box lines from the
+// Workflow heading to the next separator.
+func dagLines(theme Theme, out string) []string {
+	var lines []string
+	for _, line := range strings.Split(out, "\n") {
+		if len(lines) > 0 && strings.HasPrefix(line, theme.SeparatorLeft) {
+			break
+		}
+		text := strings.TrimSpace(strings.Trim(line, theme.Vertical))
+		if text == "Workflow:" || len(lines) > 0 {
+			lines = append(lines, text)
+		}
+	}
+	return lines
+}
+
+func TestRendererDAG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderBox(&buf, dagTestReport(), WithDAG(), WithTheme(ThemeASCII)); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(dagLines(ThemeASCII, buf.String()), "\n")
+	want := strings.Join([]string{
+		"Workflow:",
+		"🟢 build --> 🟢 lint --> 🟢 review --> ⚪ release",
+		"--> 🔴 test",
+		"review, test --> release",
+	}, "\n")
+	if got != want {
+		t.Errorf("diagram =\n%s\nwant\n%s", got, want)
+	}
+	if !strings.Contains(buf.String(), "|          --> 🔴 test") {
+		t.Errorf("test is not under lint:\n%s", buf.String())
+	}
+	if widths := boxLineWidths(t, buf.String()); len(widths) != 1 {
+		t.Errorf("line widths = %v:\n%s", widths, buf.String())
+	}
+}
+
+func TestRendererDAGNarrow(t *testing.T) {
+	report := dagTestReport()
+	report.Teams[3].ID = "review-" + strings.Repeat("x", 30)
+	report.Teams[4].DependsOn[0] = report.Teams[3].ID
+	report.Teams[3].DependsOn = []string{"lint"}
+	var buf bytes.Buffer
+	if err := RenderBox(&buf, report, WithDAG(), WithWidth(60)); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(dagLines(ThemeDouble, buf.String()), "\n")
+	want := strings.Join([]string{
+		"Workflow:",
+		"1. 🟢 build",
+		"2. 🟢 lint, 🔴 test",
+		"3. 🟢 " + report.Teams[3].ID,
+		"4. ⚪ release",
+		"build ──▶ lint",
+		"build ──▶ test",
+		"lint ──▶ " + report.Teams[3].ID,
+		report.Teams[3].ID + ", test ──▶",
+		"release",
+	}, "\n")
+	if got != want {
+		t.Errorf("diagram =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRendererDAGOff(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *TeamReport
+		opts   []RendererOption
+	}{
+		{"without WithDAG", dagTestReport(), nil},
+		{"without dependencies", themeTestReport(), []RendererOption{WithDAG()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RenderBox(&buf, tt.report, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(buf.String(), "Workflow:") {
+				t.Errorf("diagram drawn:\n%s", buf.String())
+			}
+		})
+	}
+}
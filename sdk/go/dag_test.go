@@ -0,0 +1,81 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func graphTestReport() *TeamReport {
+	return &TeamReport{Teams: []TeamSection{
+		{ID: "build", Status: StatusGo},
+		{ID: "lint", Status: StatusGo, DependsOn: []string{"build"}},
+		{ID: "test", Status: StatusNoGo, DependsOn: []string{"build"}},
+		{ID: "review", Status: StatusNoGo, DependsOn: []string{"lint"}},
+		{ID: "release", Status: StatusSkip, DependsOn: []string{"review", "test"}},
+		{ID: "docs", Status: StatusGo},
+	}}
+}
+
+func TestTeamReportGraphQueries(t *testing.T) {
+	report := graphTestReport()
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"Ancestors(release)", report.Ancestors("release"), []string{"build", "lint", "test", "review"}},
+		{"Ancestors(build)", report.Ancestors("build"), nil},
+		{"Descendants(build)", report.Descendants("build"), []string{"lint", "test", "review", "release"}},
+		{"Descendants(lint)", report.Descendants("lint"), []string{"review", "release"}},
+		{"Descendants(unknown)", report.Descendants("unknown"), nil},
+		{"Impact(test, review)", report.Impact("test", "review"), []string{"release"}},
+		{"Impact(lint, docs)", report.Impact("lint", "docs"), []string{"review", "release"}},
+		{"BlockedBy(release)", report.BlockedBy("release"), []string{"test", "review"}},
+		{"BlockedBy(lint)", report.BlockedBy("lint"), nil},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestTeamReportGraphQueriesCycle(t *testing.T) {
+	report := &TeamReport{Teams: []TeamSection{
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"a"}},
+		{ID: "c", DependsOn: []string{"b"}},
+	}}
+	if got := report.Ancestors("a"); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Ancestors(a) = %v", got)
+	}
+	if got := report.Descendants("a"); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("Descendants(a) = %v", got)
+	}
+}
+
+func TestWorkflowGraphQueries(t *testing.T) {
+	w := &Workflow{Type: WorkflowChain, Steps: []Step{
+		{Name: "plan"},
+		{Name: "docs", DependsOn: []string{"plan"}},
+		{Name: "code", DependsOn: []string{"plan"}},
+		{Name: "check"},
+		{Name: "ship"},
+	}}
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"Ancestors(ship)", w.Ancestors("ship"), []string{"plan", "code", "check"}},
+		{"Descendants(docs)", w.Descendants("docs"), nil},
+		{"Descendants(code)", w.Descendants("code"), []string{"check", "ship"}},
+		{"Impact(plan)", w.Impact("plan"), []string{"docs", "code", "check", "ship"}},
+		{"Impact(docs, check)", w.Impact("docs", "check"), []string{"ship"}},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// ADK-Go defaults applied when ADKGoConfig leaves a field empty.
+const (
+	DefaultADKGoOutput       = "adk"
+	DefaultADKGoModel        = "gemini-2.5-flash"
+	DefaultADKGoServerPort   = 8080
+	DefaultADKGoSessionStore = "memory"
+)
+
+func init() {
+	Register(ADKGoGenerator{})
+}
+
+// ADKGoGenerator scaffolds a Go module serving the team with Google's Agent
+// Development Kit. Each agent gets its own package under agents/; main.go
+// wires them under the team orchestrator (or a generated coordinator) and
+// starts the ADK launcher on ADKGoConfig.ServerPort.
+//
+// Tools are resolved through a tools package exposing
+// Lookup(names ...string) []tool.Tool. A starter registry is generated unless
+// ADKGoConfig.ToolRegistry names the import path of an existing one.
+type ADKGoGenerator struct{}
+
+// Platform returns PlatformADKGo.
+func (ADKGoGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformADKGo
+}
+
+// adkAgent is the template data for one agent package.
+type adkAgent struct {
+	Name        string // ADK agent name
+	Package     string
+	Description string
+	Instruction string
+	Model       string
+	Tools       []string
+	SubAgents   []string // packages of sub-agents, root only
+}
+
+type adkData struct {
+	Module       string
+	Team         string
+	Description  string
+	Port         int
+	Model        string
+	ToolRegistry string
+	Agents       []adkAgent
+	Root         *adkAgent // orchestrator or single agent; nil means coordinator
+}
+
+// Generate returns go.mod, main.go, the agent packages, and the tool registry.
+func (ADKGoGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.ADKGoConfig{}
+	if target.ADKGo != nil {
+		cfg = *target.ADKGo
+	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultADKGoModel
+	}
+	if cfg.ServerPort == 0 {
+		cfg.ServerPort = DefaultADKGoServerPort
+	}
+	if cfg.SessionStore == "" {
+		cfg.SessionStore = DefaultADKGoSessionStore
+	}
+	if cfg.SessionStore != DefaultADKGoSessionStore {
+		return nil, fmt.Errorf("adk-go: unsupported session store %q (want %q)", cfg.SessionStore, DefaultADKGoSessionStore)
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("adk-go: no agents to deploy")
+	}
+	for _, a := range agents {
+		if a.Memory.EffectiveType() == multiagentspec.MemoryLongTerm {
+			return nil, fmt.Errorf("adk-go: agent %s: long-term memory needs a persistent session store, but only %q is supported", a.QualifiedName(), DefaultADKGoSessionStore)
+		}
+	}
+
+	team := teamName(project)
+	data := adkData{
+		Module:       team,
+		Team:         snakeCase(team),
+		Port:         cfg.ServerPort,
+		Model:        target.ResolveModel(multiagentspec.Model(cfg.Model)),
+		ToolRegistry: cfg.ToolRegistry,
+	}
+	if project.Team != nil {
+		data.Description = project.Team.Description
+	}
+
+	orchestrator := -1
+	for i, a := range agents {
+		ac := adkAgent{
+			Name:        snakeCase(a.QualifiedName()),
+			Package:     goPackageName(a.QualifiedName()),
+			Description: a.Description,
+			Instruction: a.PromptInstructions(multiagentspec.ExampleFormatMarkdown),
+			Model:       data.Model,
+			Tools:       a.Tools,
+		}
+		if a.Model != "" {
+			ac.Model = target.ResolveModel(a.Model)
+		}
+		if project.Team != nil && project.Team.Orchestrator != "" &&
+			(project.Team.Orchestrator == a.QualifiedName() || project.Team.Orchestrator == a.Name) {
+			orchestrator = i
+		}
+		data.Agents = append(data.Agents, ac)
+	}
+	if orchestrator < 0 && len(data.Agents) == 1 {
+		orchestrator = 0
+	}
+	if orchestrator >= 0 {
+		root := data.Agents[orchestrator]
+		data.Agents = append(data.Agents[:orchestrator:orchestrator], data.Agents[orchestrator+1:]...)
+		for _, a := range data.Agents {
+			root.SubAgents = append(root.SubAgents, a.Package)
+		}
+		data.Root = &root
+	}
+
+	out := target.Output
+	if out == "" {
+		out = DefaultADKGoOutput
+	}
+
+	files := []File{{Path: path.Join(out, "go.mod"), Content: []byte(fmt.Sprintf("module %s\n\ngo 1.24\n", data.Module))}}
+	add := func(name string, tmpl *template.Template, v interface{}) error {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, v); err != nil {
+			return fmt.Errorf("adk-go: render %s: %w", name, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("adk-go: format %s: %w", name, err)
+		}
+		files = append(files, File{Path: path.Join(out, name), Content: src})
+		return nil
+	}
+
+	if err := add("main.go", adkMainTemplate, data); err != nil {
+		return nil, err
+	}
+	all := data.Agents
+	if data.Root != nil {
+		all = append([]adkAgent{*data.Root}, all...)
+	}
+	for _, a := range all {
+		if err := add(path.Join("agents", a.Package, "agent.go"), adkAgentTemplate, a); err != nil {
+			return nil, err
+		}
+	}
+	if data.ToolRegistry == "" {
+		if err := add(path.Join("tools", "tools.go"), adkToolsTemplate, nil); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// goPackageName derives a Go package name from an agent name.
+func goPackageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9' && b.Len() > 0) {
+			b.WriteRune(r)
+		}
+	}
+	pkg := b.String()
+	if pkg == "" || adkReservedNames[pkg] {
+		pkg += "agent"
+	}
+	return pkg
+}
+
+// adkReservedNames are identifiers the generated code already imports or
+// declares, which agent packages must not shadow.
+var adkReservedNames = map[string]bool{
+	"agent": true, "context": true, "full": true, "gemini": true, "genai": true,
+	"launcher": true, "llmagent": true, "log": true, "main": true, "model": true,
+	"os": true, "root": true, "session": true, "tool": true, "tools": true,
+}
+
+var adkFuncs = template.FuncMap{
+	// goString renders s as a Go string literal, preferring a raw string.
+	"goString": func(s string) string {
+		if !strings.Contains(s, "`") && !strings.Contains(s, "\r") {
+			return "`" + s + "`"
+		}
+		return strconv.Quote(s)
+	},
+	"quote": strconv.Quote,
+}
+
+var adkMainTemplate = template.Must(template.New("main").Funcs(adkFuncs).Parse(`// Command {{.Module}} serves the {{.Module}} agents with Google ADK.
+//
+// Generated by mas deploy generate. Run "go mod tidy" before the first build.
+// Set GOOGLE_API_KEY to authenticate with the Gemini API.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"google.golang.org/adk/agent"
+{{- if not .Root}}
+	"google.golang.org/adk/agent/llmagent"
+{{- end}}
+	"google.golang.org/adk/cmd/launcher"
+	"google.golang.org/adk/cmd/launcher/full"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/model/gemini"
+	"google.golang.org/adk/session"
+	"google.golang.org/genai"
+
+{{- if .Root}}
+	{{quote (print .Module "/agents/" .Root.Package)}}
+{{- end}}
+{{- range .Agents}}
+	{{quote (print $.Module "/agents/" .Package)}}
+{{- end}}
+{{- if .ToolRegistry}}
+	tools {{quote .ToolRegistry}}
+{{- else}}
+	{{quote (print .Module "/tools")}}
+{{- end}}
+)
+
+// port is the default server port used when no launcher arguments are given.
+const port = "{{.Port}}"
+
+func main() {
+	ctx := context.Background()
+
+	newModel := func(name string) model.LLM {
+		m, err := gemini.NewModel(ctx, name, &genai.ClientConfig{APIKey: os.Getenv("GOOGLE_API_KEY")})
+		if err != nil {
+			log.Fatalf("create model %s: %v", name, err)
+		}
+		return m
+	}
+{{range .Agents}}
+	{{.Package}}Agent, err := {{.Package}}.New(newModel({{quote .Model}}), tools.Lookup({{range $i, $t := .Tools}}{{if $i}}, {{end}}{{quote $t}}{{end}}))
+	if err != nil {
+		log.Fatalf("create agent %s: %v", {{.Package}}.Name, err)
+	}
+{{end}}
+{{- if .Root}}
+	root, err := {{.Root.Package}}.New(newModel({{quote .Root.Model}}), tools.Lookup({{range $i, $t := .Root.Tools}}{{if $i}}, {{end}}{{quote $t}}{{end}}){{range .Root.SubAgents}}, {{.}}Agent{{end}})
+	if err != nil {
+		log.Fatalf("create agent %s: %v", {{.Root.Package}}.Name, err)
+	}
+{{- else}}
+	root, err := llmagent.New(llmagent.Config{
+		Name:        {{quote .Team}},
+		Description: {{quote .Description}},
+		Model:       newModel({{quote .Model}}),
+		Instruction: "Delegate each request to the sub-agent best suited to handle it.",
+		SubAgents:   []agent.Agent{ {{- range $i, $a := .Agents}}{{if $i}}, {{end}}{{$a.Package}}Agent{{end -}} },
+	})
+	if err != nil {
+		log.Fatalf("create coordinator: %v", err)
+	}
+{{- end}}
+
+	config := &launcher.Config{
+		AgentLoader:    agent.NewSingleLoader(root),
+		SessionService: session.InMemoryService(),
+	}
+
+	args := os.Args[1:]
+	if len(args) == 0 {
+		args = []string{"web", "-port", port, "api", "webui"}
+	}
+	l := full.NewLauncher()
+	if err := l.Execute(ctx, config, args); err != nil {
+		log.Fatalf("run failed: %v\n\n%s", err, l.CommandLineSyntax())
+	}
+}
+`))
+
+var adkAgentTemplate = template.Must(template.New("agent").Funcs(adkFuncs).Parse(`// Package {{.Package}} defines the {{.Name}} agent.
+//
+// Generated by mas deploy generate.
+package {{.Package}}
+
+import (
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/agent/llmagent"
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+)
+
+// Name is the ADK agent name.
+const Name = {{quote .Name}}
+
+// Description tells other agents when to delegate to this one.
+const Description = {{quote .Description}}
+
+// Instruction is the agent's system prompt.
+const Instruction = {{goString .Instruction}}
+
+// New creates the agent with the given model, tools, and sub-agents.
+func New(m model.LLM, tools []tool.Tool, subAgents ...agent.Agent) (agent.Agent, error) {
+	return llmagent.New(llmagent.Config{
+		Name:        Name,
+		Description: Description,
+		Model:       m,
+		Instruction: Instruction,
+		Tools:       tools,
+		SubAgents:   subAgents,
+	})
+}
+`))
+
+var adkToolsTemplate = template.Must(template.New("tools").Parse(`// Package tools resolves spec tool names to ADK tools.
+//
+// Generated by mas deploy generate. Register function tools here for spec
+// tools without an ADK built-in.
+package tools
+
+import (
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/geminitool"
+)
+
+// Registry maps spec tool names to ADK tools.
+var Registry = map[string]tool.Tool{
+	"WebSearch": geminitool.GoogleSearch{},
+}
+
+// Lookup returns the registered tools for names, skipping names without a
+// registration.
+func Lookup(names ...string) []tool.Tool {
+	var tools []tool.Tool
+	for _, name := range names {
+		if t, ok := Registry[name]; ok {
+			tools = append(tools, t)
+		}
+	}
+	return tools
+}
+`))
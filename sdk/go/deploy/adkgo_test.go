@@ -0,0 +1,135 @@
+package deploy
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestADKGoGenerator(t *testing.T) {
+	p := testProject()
+	p.Team.Orchestrator = "pm"
+	p.Agents[0].WithTools("WebSearch", "Read").Model = ""
+
+	target := &multiagentspec.Target{
+		Name:     "adk",
+		Platform: multiagentspec.PlatformADKGo,
+		ADKGo:    &multiagentspec.ADKGoConfig{Model: "gemini-2.0-flash", ServerPort: 9090},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+
+	for _, name := range []string{"adk/main.go", "adk/agents/pm/agent.go", "adk/agents/sharedqa/agent.go", "adk/tools/tools.go"} {
+		src, ok := got[name]
+		if !ok {
+			t.Errorf("missing %s", name)
+			continue
+		}
+		if _, err := parser.ParseFile(token.NewFileSet(), name, src, 0); err != nil {
+			t.Errorf("%s does not parse: %v", name, err)
+		}
+	}
+	if mod := got["adk/go.mod"]; !strings.HasPrefix(mod, "module release-team\n") {
+		t.Errorf("go.mod = %q", mod)
+	}
+
+	main := got["adk/main.go"]
+	for _, want := range []string{
+		`const port = "9090"`,
+		`sharedqa.New(newModel("gemini-2.0-flash"), tools.Lookup("WebSearch", "Read"))`,
+		`root, err := pm.New(newModel("gemini-2.5-pro"), tools.Lookup(), sharedqaAgent)`,
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.go missing %q:\n%s", want, main)
+		}
+	}
+	if strings.Contains(main, "llmagent") {
+		t.Error("main.go should not build a coordinator when the team has an orchestrator")
+	}
+	if !strings.Contains(got["adk/agents/pm/agent.go"], "const Instruction = `Review the plan.`") {
+		t.Error("pm instruction not embedded")
+	}
+}
+
+func TestADKGoGeneratorCoordinator(t *testing.T) {
+	p := testProject()
+	p.Agents[1].WithModel(multiagentspec.ModelHaiku)
+	p.Agents[0].Model = ""
+	target := &multiagentspec.Target{
+		Name:     "adk",
+		Platform: multiagentspec.PlatformADKGo,
+		Output:   "svc",
+		ADKGo:    &multiagentspec.ADKGoConfig{ToolRegistry: "example.com/shared/tools"},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	if _, ok := got["svc/tools/tools.go"]; ok {
+		t.Error("tool registry generated despite ToolRegistry")
+	}
+	main := got["svc/main.go"]
+	for _, want := range []string{
+		`tools "example.com/shared/tools"`,
+		`newModel("gemini-2.5-flash")`,
+		`SubAgents:   []agent.Agent{pmAgent, sharedqaAgent}`,
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.go missing %q:\n%s", want, main)
+		}
+	}
+}
+
+func TestADKGoGeneratorSessionStore(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "adk",
+		Platform: multiagentspec.PlatformADKGo,
+		ADKGo:    &multiagentspec.ADKGoConfig{SessionStore: "redis"},
+	}
+	if _, err := Generate(testProject(), target); err == nil {
+		t.Error("expected error for unsupported session store")
+	}
+}
+
+func TestADKGoGeneratorMemory(t *testing.T) {
+	p := testProject()
+	p.Agents[0].Memory = &multiagentspec.Memory{Type: multiagentspec.MemoryShortTerm}
+	target := &multiagentspec.Target{Name: "adk", Platform: multiagentspec.PlatformADKGo}
+	if _, err := Generate(p, target); err != nil {
+		t.Fatalf("Generate with short-term memory: %v", err)
+	}
+
+	p.Agents[0].Memory = &multiagentspec.Memory{Type: multiagentspec.MemoryLongTerm, Backend: multiagentspec.MemoryBackendSQLite, Path: "memory.db"}
+	if _, err := Generate(p, target); err == nil || !strings.Contains(err.Error(), "agent shared/qa: long-term memory needs a persistent session store") {
+		t.Errorf("Generate error = %v, want long-term memory rejected", err)
+	}
+
+	// Invalid memory settings are rejected for every platform
+	p.Agents[0].Memory = &multiagentspec.Memory{Type: multiagentspec.MemoryLongTerm}
+	claude := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+	if _, err := Generate(p, claude); err == nil || !strings.Contains(err.Error(), "agent shared/qa: memory: long-term memory requires a backend") {
+		t.Errorf("Generate error = %v, want invalid memory", err)
+	}
+}
+
+func TestGoPackageName(t *testing.T) {
+	tests := map[string]string{
+		"shared/qa":   "sharedqa",
+		"stats-agent": "statsagent",
+		"2fa":         "fa",
+		"model":       "modelagent",
+		"---":         "agent",
+	}
+	for in, want := range tests {
+		if got := goPackageName(in); got != want {
+			t.Errorf("goPackageName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
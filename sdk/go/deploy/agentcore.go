@@ -0,0 +1,721 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// AWS AgentCore defaults applied when AWSAgentCoreConfig leaves a field empty.
+const (
+	DefaultAgentCoreRegion        = "us-east-1"
+	DefaultAgentCoreLambdaRuntime = "python3.11"
+	DefaultAgentCoreIAC           = "cdk"
+)
+
+func init() {
+	Register(AgentCoreGenerator{})
+}
+
+// AgentCoreGenerator emits infrastructure-as-code defining one Bedrock agent
+// per spec agent. AWSAgentCoreConfig.IAC selects CDK (TypeScript) or
+// Terraform. Agents with command tasks get a Lambda action group exposing
+// each command task as a function.
+type AgentCoreGenerator struct{}
+
+// Platform returns PlatformAWSAgentCore.
+func (AgentCoreGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformAWSAgentCore
+}
+
+// SecretSources returns aws-sm: action group Lambdas are granted read access
+// to each secret and load it into their environment at cold start.
+func (AgentCoreGenerator) SecretSources() []multiagentspec.SecretSource {
+	return []multiagentspec.SecretSource{multiagentspec.SecretSourceAWSSecretsManager}
+}
+
+// agentCoreAgent is the template data for one Bedrock agent.
+type agentCoreAgent struct {
+	Name            string
+	ID              string // PascalCase identifier
+	Resource        string // snake_case identifier
+	Description     string
+	Instruction     string
+	FoundationModel string
+	Commands        []agentCoreCommand
+	Secrets         []agentCoreSecret
+}
+
+// agentCoreSecret is a Secrets Manager secret loaded by the action group.
+type agentCoreSecret struct {
+	Name     string // environment variable
+	ID       string // PascalCase identifier
+	Resource string // snake_case identifier
+	SecretID string // secret name or ARN
+	Property string
+}
+
+// IsARN reports whether SecretID is a full ARN rather than a name.
+func (s agentCoreSecret) IsARN() bool {
+	return strings.HasPrefix(s.SecretID, "arn:")
+}
+
+// agentCoreCommand is a command task exposed through the action group.
+type agentCoreCommand struct {
+	ID          string
+	Description string
+	Command     string
+}
+
+type agentCoreData struct {
+	Team            string
+	ID              string
+	Region          string
+	FoundationModel string
+	LambdaRuntime   string
+	Secrets         []agentCoreSecret
+	Agents          []agentCoreAgent
+}
+
+// Generate returns a CDK app or Terraform module under Target.Output.
+func (AgentCoreGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.AWSAgentCoreConfig{}
+	if target.AWSAgentCore != nil {
+		cfg = *target.AWSAgentCore
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultAgentCoreRegion
+	}
+	if cfg.LambdaRuntime == "" {
+		cfg.LambdaRuntime = DefaultAgentCoreLambdaRuntime
+	}
+	if cfg.FoundationModel == "" {
+		cfg.FoundationModel = target.ResolveModel(multiagentspec.ModelSonnet)
+	}
+	if cfg.IAC == "" {
+		cfg.IAC = DefaultAgentCoreIAC
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []agentCoreSecret
+	for _, name := range sortedSecretNames(target.Secrets) {
+		ref := target.Secrets[name]
+		secrets = append(secrets, agentCoreSecret{
+			Name:     name,
+			ID:       pascalCase(strings.ToLower(name)),
+			Resource: snakeCase(strings.ToLower(name)),
+			SecretID: ref.Path(),
+			Property: ref.Property(),
+		})
+	}
+
+	team := teamName(project)
+	data := agentCoreData{
+		Team:            team,
+		ID:              pascalCase(team),
+		Region:          cfg.Region,
+		FoundationModel: cfg.FoundationModel,
+		LambdaRuntime:   cfg.LambdaRuntime,
+		Secrets:         secrets,
+	}
+	for _, a := range agents {
+		ac := agentCoreAgent{
+			Name:            k8sName(a.QualifiedName()),
+			ID:              pascalCase(a.QualifiedName()),
+			Resource:        snakeCase(a.QualifiedName()),
+			Description:     a.Description,
+			Instruction:     a.PromptInstructions(multiagentspec.ExampleFormatMarkdown),
+			FoundationModel: cfg.FoundationModel,
+			Secrets:         secrets,
+		}
+		if a.Model != "" {
+			ac.FoundationModel = target.ResolveModel(a.Model)
+		}
+		for _, t := range a.Tasks {
+			if t.Type == multiagentspec.TaskTypeCommand && t.Command != "" {
+				ac.Commands = append(ac.Commands, agentCoreCommand{ID: t.ID, Description: t.Description, Command: t.Command})
+			}
+		}
+		data.Agents = append(data.Agents, ac)
+	}
+
+	switch cfg.IAC {
+	case "cdk":
+		out := target.Output
+		if out == "" {
+			out = "cdk"
+		}
+		return agentCoreCDK(out, data)
+	case "terraform":
+		out := target.Output
+		if out == "" {
+			out = "terraform"
+		}
+		return agentCoreTerraform(out, data)
+	default:
+		return nil, fmt.Errorf("aws-agentcore: unsupported iac %q (want cdk or terraform)", cfg.IAC)
+	}
+}
+
+func agentCoreCDK(out string, data agentCoreData) ([]File, error) {
+	var files []File
+	add := func(name string, tmpl *template.Template, v interface{}) error {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, v); err != nil {
+			return fmt.Errorf("aws-agentcore: render %s: %w", name, err)
+		}
+		files = append(files, File{Path: path.Join(out, name), Content: buf.Bytes()})
+		return nil
+	}
+
+	if err := add(path.Join("bin", data.Team+".ts"), cdkAppTemplate, data); err != nil {
+		return nil, err
+	}
+	if err := add(path.Join("lib", data.Team+"-stack.ts"), cdkStackTemplate, data); err != nil {
+		return nil, err
+	}
+	for _, a := range data.Agents {
+		if err := add(path.Join("lib", "agents", a.Name+".ts"), cdkAgentTemplate, struct {
+			agentCoreAgent
+			LambdaRuntime string
+		}{a, data.LambdaRuntime}); err != nil {
+			return nil, err
+		}
+		if len(a.Commands) > 0 {
+			if err := add(path.Join("lambda", a.Name, "index.py"), actionGroupHandlerTemplate, a); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := add("package.json", cdkPackageTemplate, data); err != nil {
+		return nil, err
+	}
+	if err := add("cdk.json", cdkJSONTemplate, data); err != nil {
+		return nil, err
+	}
+	files = append(files, File{Path: path.Join(out, "tsconfig.json"), Content: []byte(cdkTSConfig)})
+	return files, nil
+}
+
+func agentCoreTerraform(out string, data agentCoreData) ([]File, error) {
+	var buf bytes.Buffer
+	if err := terraformTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("aws-agentcore: render main.tf: %w", err)
+	}
+	files := []File{{Path: path.Join(out, "main.tf"), Content: buf.Bytes()}}
+	for _, a := range data.Agents {
+		if len(a.Commands) == 0 {
+			continue
+		}
+		var h bytes.Buffer
+		if err := actionGroupHandlerTemplate.Execute(&h, a); err != nil {
+			return nil, fmt.Errorf("aws-agentcore: render handler for %s: %w", a.Name, err)
+		}
+		files = append(files, File{Path: path.Join(out, "lambda", a.Name, "index.py"), Content: h.Bytes()})
+	}
+	return files, nil
+}
+
+var agentCoreFuncs = template.FuncMap{
+	// camel lower-cases the first letter of a PascalCase identifier.
+	"camel": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToLower(s[:1]) + s[1:]
+	},
+	// tsString quotes s as a single-quoted TypeScript string.
+	"tsString": func(s string) string {
+		r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+		return "'" + r.Replace(s) + "'"
+	},
+	// tsTemplate escapes s for a TypeScript template literal.
+	"tsTemplate": func(s string) string {
+		r := strings.NewReplacer(`\`, `\\`, "`", "\\`", "${", `\${`)
+		return r.Replace(s)
+	},
+	// hclString quotes s as an HCL string without interpolation.
+	"hclString": func(s string) string {
+		data, _ := json.Marshal(s)
+		r := strings.NewReplacer("${", "$${", "%{", "%%{")
+		return r.Replace(string(data))
+	},
+	// hclHeredoc escapes interpolation sequences for an HCL heredoc body.
+	"hclHeredoc": func(s string) string {
+		r := strings.NewReplacer("${", "$${", "%{", "%%{")
+		return r.Replace(s)
+	},
+	// pyCommands renders the command table as a Python dict literal.
+	"pyCommands": func(cmds []agentCoreCommand) string {
+		m := make(map[string]string, len(cmds))
+		for _, c := range cmds {
+			m[c.ID] = c.Command
+		}
+		data, _ := json.MarshalIndent(m, "", "    ")
+		return string(data)
+	},
+	// pySecrets renders the secret table as a Python dict literal mapping
+	// each environment variable to its secret ID and JSON property.
+	"pySecrets": func(secrets []agentCoreSecret) string {
+		m := make(map[string][2]string, len(secrets))
+		for _, s := range secrets {
+			m[s.Name] = [2]string{s.SecretID, s.Property}
+		}
+		data, _ := json.MarshalIndent(m, "", "    ")
+		return string(data)
+	},
+}
+
+var cdkAppTemplate = template.Must(template.New("app").Funcs(agentCoreFuncs).Parse(`#!/usr/bin/env node
+import 'source-map-support/register';
+import * as cdk from 'aws-cdk-lib';
+import { {{.ID}}Stack } from '../lib/{{.Team}}-stack';
+
+const app = new cdk.App();
+
+new {{.ID}}Stack(app, '{{.ID}}Stack', {
+  env: {
+    account: process.env.CDK_DEFAULT_ACCOUNT,
+    region: process.env.CDK_DEFAULT_REGION ?? {{tsString .Region}},
+  },
+});
+`))
+
+var cdkStackTemplate = template.Must(template.New("stack").Funcs(agentCoreFuncs).Parse(`import * as cdk from 'aws-cdk-lib';
+import { Construct } from 'constructs';
+{{range .Agents}}
+import { {{.ID}}Agent } from './agents/{{.Name}}';
+{{- end}}
+
+export interface {{.ID}}StackProps extends cdk.StackProps {
+  /** Overrides the foundation model of every agent. */
+  readonly foundationModel?: string;
+}
+
+export class {{.ID}}Stack extends cdk.Stack {
+{{- range .Agents}}
+  public readonly {{camel .ID}}: {{.ID}}Agent;
+{{- end}}
+
+  constructor(scope: Construct, id: string, props?: {{.ID}}StackProps) {
+    super(scope, id, props);
+{{- range .Agents}}
+
+    // {{.Name}} agent
+    this.{{camel .ID}} = new {{.ID}}Agent(this, '{{.ID}}', {
+      foundationModel: props?.foundationModel,
+    });
+{{- end}}
+  }
+}
+`))
+
+var cdkAgentTemplate = template.Must(template.New("agent").Funcs(agentCoreFuncs).Parse(`import * as cdk from 'aws-cdk-lib';
+import * as bedrock from 'aws-cdk-lib/aws-bedrock';
+import * as iam from 'aws-cdk-lib/aws-iam';
+{{- if .Commands}}
+import * as lambda from 'aws-cdk-lib/aws-lambda';
+{{- if .Secrets}}
+import * as secretsmanager from 'aws-cdk-lib/aws-secretsmanager';
+{{- end}}
+import * as path from 'path';
+{{- end}}
+import { Construct } from 'constructs';
+
+export interface {{.ID}}AgentProps {
+  readonly foundationModel?: string;
+}
+
+export class {{.ID}}Agent extends Construct {
+  public readonly agent: bedrock.CfnAgent;
+  public readonly agentAlias: bedrock.CfnAgentAlias;
+
+  constructor(scope: Construct, id: string, props?: {{.ID}}AgentProps) {
+    super(scope, id);
+
+    const foundationModel = props?.foundationModel ?? {{tsString .FoundationModel}};
+
+    // IAM role for the agent
+    const agentRole = new iam.Role(this, 'AgentRole', {
+      assumedBy: new iam.ServicePrincipal('bedrock.amazonaws.com'),
+      managedPolicies: [
+        iam.ManagedPolicy.fromAwsManagedPolicyName('AmazonBedrockFullAccess'),
+      ],
+    });
+{{- if .Commands}}
+
+    // Lambda backing the command task action group
+    const actionFunction = new lambda.Function(this, 'ActionGroupFunction', {
+      runtime: new lambda.Runtime({{tsString .LambdaRuntime}}, lambda.RuntimeFamily.PYTHON),
+      handler: 'index.handler',
+      code: lambda.Code.fromAsset(path.join(__dirname, '..', '..', 'lambda', {{tsString .Name}})),
+      timeout: cdk.Duration.minutes(5),
+    });
+    actionFunction.addPermission('BedrockInvoke', {
+      principal: new iam.ServicePrincipal('bedrock.amazonaws.com'),
+    });
+{{- range .Secrets}}
+{{- if .IsARN}}
+    secretsmanager.Secret.fromSecretCompleteArn(this, '{{.ID}}Secret', {{tsString .SecretID}}).grantRead(actionFunction);
+{{- else}}
+    secretsmanager.Secret.fromSecretNameV2(this, '{{.ID}}Secret', {{tsString .SecretID}}).grantRead(actionFunction);
+{{- end}}
+{{- end}}
+{{- end}}
+
+    // Agent instruction
+    const instruction = ` + "`{{tsTemplate .Instruction}}`" + `;
+
+    // Create the Bedrock Agent
+    this.agent = new bedrock.CfnAgent(this, 'Agent', {
+      agentName: {{tsString .Name}},
+      description: {{tsString .Description}},
+      foundationModel: foundationModel,
+      instruction: instruction,
+      agentResourceRoleArn: agentRole.roleArn,
+      idleSessionTtlInSeconds: 600,
+      autoPrepare: true,
+{{- if .Commands}}
+      actionGroups: [
+        {
+          actionGroupName: 'tasks',
+          description: 'Command tasks defined in the agent spec',
+          actionGroupExecutor: { lambda: actionFunction.functionArn },
+          functionSchema: {
+            functions: [
+{{- range .Commands}}
+              { name: {{tsString .ID}}, description: {{tsString .Description}} },
+{{- end}}
+            ],
+          },
+        },
+      ],
+{{- end}}
+    });
+
+    // Create agent alias for invocation
+    this.agentAlias = new bedrock.CfnAgentAlias(this, 'AgentAlias', {
+      agentId: this.agent.attrAgentId,
+      agentAliasName: 'live',
+    });
+
+    // Output the agent ID
+    new cdk.CfnOutput(this, '{{.ID}}AgentId', {
+      value: this.agent.attrAgentId,
+      description: {{tsString (print "Agent ID for " .Name)}},
+    });
+  }
+}
+`))
+
+var cdkPackageTemplate = template.Must(template.New("package").Parse(`{
+  "name": "{{.Team}}-cdk",
+  "version": "1.0.0",
+  "scripts": {
+    "build": "tsc",
+    "cdk": "cdk",
+    "deploy": "cdk deploy",
+    "destroy": "cdk destroy",
+    "synth": "cdk synth",
+    "watch": "tsc -w"
+  },
+  "dependencies": {
+    "aws-cdk-lib": "^2.170.0",
+    "constructs": "^10.0.0"
+  },
+  "devDependencies": {
+    "@types/node": "^20.0.0",
+    "aws-cdk": "^2.170.0",
+    "source-map-support": "^0.5.21",
+    "ts-node": "^10.9.0",
+    "typescript": "^5.0.0"
+  }
+}
+`))
+
+var cdkJSONTemplate = template.Must(template.New("cdk").Parse(`{
+  "app": "npx ts-node --prefer-ts-exts bin/{{.Team}}.ts",
+  "context": {},
+  "watch": {
+    "include": [
+      "**"
+    ]
+  }
+}
+`))
+
+const cdkTSConfig = `{
+  "compilerOptions": {
+    "target": "ES2022",
+    "module": "commonjs",
+    "lib": ["ES2022"],
+    "declaration": true,
+    "strict": true,
+    "noImplicitAny": true,
+    "strictNullChecks": true,
+    "esModuleInterop": true,
+    "skipLibCheck": true,
+    "outDir": "dist"
+  },
+  "exclude": ["node_modules", "cdk.out", "dist"]
+}
+`
+
+var actionGroupHandlerTemplate = template.Must(template.New("handler").Funcs(agentCoreFuncs).Parse(`"""Action group handler for the {{.Name}} agent.
+
+Generated by mas deploy generate. Each function runs the command task of
+the same ID from the agent spec.
+"""
+
+import json
+{{- if .Secrets}}
+import os
+{{- end}}
+import subprocess
+{{- if .Secrets}}
+
+import boto3
+{{- end}}
+
+COMMANDS = {{pyCommands .Commands}}
+{{- if .Secrets}}
+
+# Environment variable -> [secret ID, JSON property] in Secrets Manager.
+SECRETS = {{pySecrets .Secrets}}
+
+
+def _load_secrets():
+    client = boto3.client("secretsmanager")
+    for name, (secret_id, prop) in SECRETS.items():
+        value = client.get_secret_value(SecretId=secret_id)["SecretString"]
+        os.environ[name] = json.loads(value)[prop] if prop else value
+
+
+_load_secrets()
+{{- end}}
+
+
+def handler(event, context):
+    function = event.get("function", "")
+    command = COMMANDS.get(function)
+    if command is None:
+        body = json.dumps({"error": f"unknown function {function}"})
+    else:
+        proc = subprocess.run(
+            command, shell=True, capture_output=True, text=True, timeout=290
+        )
+        body = json.dumps(
+            {
+                "exit_code": proc.returncode,
+                "stdout": proc.stdout[-4000:],
+                "stderr": proc.stderr[-4000:],
+            }
+        )
+    return {
+        "messageVersion": "1.0",
+        "response": {
+            "actionGroup": event.get("actionGroup", ""),
+            "function": function,
+            "functionResponse": {"responseBody": {"TEXT": {"body": body}}},
+        },
+    }
+`))
+
+var terraformTemplate = template.Must(template.New("main.tf").Funcs(agentCoreFuncs).Parse(`# Bedrock agents for the {{.Team}} team.
+# Generated by mas deploy generate.
+
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.60"
+    }
+    archive = {
+      source = "hashicorp/archive"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+variable "region" {
+  type    = string
+  default = {{hclString .Region}}
+}
+
+variable "foundation_model" {
+  type        = string
+  default     = null
+  description = "Overrides the foundation model of every agent."
+}
+
+data "aws_iam_policy_document" "bedrock_assume" {
+  statement {
+    actions = ["sts:AssumeRole"]
+    principals {
+      type        = "Service"
+      identifiers = ["bedrock.amazonaws.com"]
+    }
+  }
+}
+
+data "aws_iam_policy_document" "lambda_assume" {
+  statement {
+    actions = ["sts:AssumeRole"]
+    principals {
+      type        = "Service"
+      identifiers = ["lambda.amazonaws.com"]
+    }
+  }
+}
+{{- range .Secrets}}
+
+data "aws_secretsmanager_secret" "{{.Resource}}" {
+{{- if .IsARN}}
+  arn = {{hclString .SecretID}}
+{{- else}}
+  name = {{hclString .SecretID}}
+{{- end}}
+}
+{{- end}}
+{{range .Agents}}
+# {{.Name}} agent
+
+resource "aws_iam_role" "{{.Resource}}" {
+  name_prefix        = "{{.Name}}-"
+  assume_role_policy = data.aws_iam_policy_document.bedrock_assume.json
+}
+
+resource "aws_iam_role_policy_attachment" "{{.Resource}}" {
+  role       = aws_iam_role.{{.Resource}}.name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonBedrockFullAccess"
+}
+
+resource "aws_bedrockagent_agent" "{{.Resource}}" {
+  agent_name                  = {{hclString .Name}}
+  description                 = {{hclString .Description}}
+  foundation_model            = coalesce(var.foundation_model, {{hclString .FoundationModel}})
+  agent_resource_role_arn     = aws_iam_role.{{.Resource}}.arn
+  idle_session_ttl_in_seconds = 600
+  prepare_agent               = true
+  instruction                 = <<-EOT
+{{hclHeredoc .Instruction}}
+  EOT
+}
+
+resource "aws_bedrockagent_agent_alias" "{{.Resource}}" {
+  agent_id         = aws_bedrockagent_agent.{{.Resource}}.agent_id
+  agent_alias_name = "live"
+}
+{{- if .Commands}}
+
+data "archive_file" "{{.Resource}}_actions" {
+  type        = "zip"
+  source_dir  = "${path.module}/lambda/{{.Name}}"
+  output_path = "${path.module}/build/{{.Name}}.zip"
+}
+
+resource "aws_iam_role" "{{.Resource}}_actions" {
+  name_prefix        = "{{.Name}}-actions-"
+  assume_role_policy = data.aws_iam_policy_document.lambda_assume.json
+}
+
+resource "aws_iam_role_policy_attachment" "{{.Resource}}_actions" {
+  role       = aws_iam_role.{{.Resource}}_actions.name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+}
+{{- if .Secrets}}
+
+resource "aws_iam_role_policy" "{{.Resource}}_secrets" {
+  role   = aws_iam_role.{{.Resource}}_actions.name
+  policy = jsonencode({
+    Version   = "2012-10-17"
+    Statement = [{
+      Effect   = "Allow"
+      Action   = ["secretsmanager:GetSecretValue"]
+      Resource = [
+{{- range .Secrets}}
+        data.aws_secretsmanager_secret.{{.Resource}}.arn,
+{{- end}}
+      ]
+    }]
+  })
+}
+{{- end}}
+
+resource "aws_lambda_function" "{{.Resource}}_actions" {
+  function_name    = "{{.Name}}-actions"
+  role             = aws_iam_role.{{.Resource}}_actions.arn
+  runtime          = {{hclString $.LambdaRuntime}}
+  handler          = "index.handler"
+  filename         = data.archive_file.{{.Resource}}_actions.output_path
+  source_code_hash = data.archive_file.{{.Resource}}_actions.output_base64sha256
+  timeout          = 300
+}
+
+resource "aws_lambda_permission" "{{.Resource}}_actions" {
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.{{.Resource}}_actions.function_name
+  principal     = "bedrock.amazonaws.com"
+  source_arn    = aws_bedrockagent_agent.{{.Resource}}.agent_arn
+}
+
+resource "aws_bedrockagent_agent_action_group" "{{.Resource}}_tasks" {
+  agent_id          = aws_bedrockagent_agent.{{.Resource}}.agent_id
+  agent_version     = "DRAFT"
+  action_group_name = "tasks"
+  description       = "Command tasks defined in the agent spec"
+
+  action_group_executor {
+    lambda = aws_lambda_function.{{.Resource}}_actions.arn
+  }
+
+  function_schema {
+    member_functions {
+{{- range .Commands}}
+      functions {
+        name        = {{hclString .ID}}
+        description = {{hclString .Description}}
+      }
+{{- end}}
+    }
+  }
+}
+{{- end}}
+
+output "{{.Resource}}_agent_id" {
+  value       = aws_bedrockagent_agent.{{.Resource}}.agent_id
+  description = "Agent ID for {{.Name}}"
+}
+{{end -}}
+`))
+
+// pascalCase converts a hyphenated or namespaced name to PascalCase.
+func pascalCase(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, isNameSeparator) {
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+	return b.String()
+}
+
+// snakeCase converts a hyphenated or namespaced name to snake_case.
+func snakeCase(name string) string {
+	return strings.ToLower(strings.Join(strings.FieldsFunc(name, isNameSeparator), "_"))
+}
+
+func isNameSeparator(r rune) bool {
+	return r == '-' || r == '_' || r == '/' || r == '.' || r == ' '
+}
@@ -0,0 +1,197 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func agentCoreProject() *Project {
+	p := testProject()
+	qa := p.Agents[0].WithModel(multiagentspec.ModelHaiku).WithInstructions("Run `go test` and report ${status}.")
+	qa.Tasks = []multiagentspec.Task{
+		{ID: "unit-tests", Description: "Run unit tests", Type: multiagentspec.TaskTypeCommand, Command: "go test ./..."},
+		{ID: "review", Type: multiagentspec.TaskTypeManual},
+	}
+	return p
+}
+
+func filesByPath(files []File) map[string]string {
+	m := make(map[string]string, len(files))
+	for _, f := range files {
+		m[f.Path] = string(f.Content)
+	}
+	return m
+}
+
+func TestAgentCoreGeneratorCDK(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "aws",
+		Platform: multiagentspec.PlatformAWSAgentCore,
+		AWSAgentCore: &multiagentspec.AWSAgentCoreConfig{
+			Region:          "eu-west-1",
+			FoundationModel: "anthropic.claude-3-5-sonnet-20241022-v2:0",
+			LambdaRuntime:   "python3.12",
+		},
+	}
+	files, err := Generate(agentCoreProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+
+	for _, p := range []string{
+		"cdk/bin/release-team.ts",
+		"cdk/lib/release-team-stack.ts",
+		"cdk/lib/agents/pm.ts",
+		"cdk/lib/agents/shared-qa.ts",
+		"cdk/lambda/shared-qa/index.py",
+		"cdk/package.json",
+		"cdk/cdk.json",
+		"cdk/tsconfig.json",
+	} {
+		if _, ok := got[p]; !ok {
+			t.Errorf("missing %s", p)
+		}
+	}
+	if _, ok := got["cdk/lambda/pm/index.py"]; ok {
+		t.Error("pm has no command tasks but got an action group handler")
+	}
+
+	if !strings.Contains(got["cdk/bin/release-team.ts"], "?? 'eu-west-1'") {
+		t.Error("app does not default to the configured region")
+	}
+	if !strings.Contains(got["cdk/lib/release-team-stack.ts"], "new SharedQaAgent(this, 'SharedQa'") {
+		t.Error("stack does not instantiate the shared/qa agent")
+	}
+
+	pm := got["cdk/lib/agents/pm.ts"]
+	if !strings.Contains(pm, "'anthropic.claude-3-5-sonnet-20241022-v2:0'") {
+		t.Error("pm does not use the configured foundation model")
+	}
+	if strings.Contains(pm, "actionGroups") {
+		t.Error("pm should not define an action group")
+	}
+
+	qa := got["cdk/lib/agents/shared-qa.ts"]
+	if !strings.Contains(qa, multiagentspec.MapModelToBedrock(multiagentspec.ModelHaiku)) {
+		t.Error("qa does not use its own model")
+	}
+	if !strings.Contains(qa, "Run \\`go test\\` and report \\${status}.") {
+		t.Errorf("instruction not escaped for a template literal:\n%s", qa)
+	}
+	if !strings.Contains(qa, "new lambda.Runtime('python3.12'") || !strings.Contains(qa, "{ name: 'unit-tests', description: 'Run unit tests' }") {
+		t.Errorf("qa action group incomplete:\n%s", qa)
+	}
+	if strings.Contains(qa, "'review'") {
+		t.Error("manual task exposed as an action group function")
+	}
+	if !strings.Contains(got["cdk/lambda/shared-qa/index.py"], `"unit-tests": "go test ./..."`) {
+		t.Error("handler missing command table")
+	}
+}
+
+func TestAgentCoreGeneratorTerraform(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:         "aws",
+		Platform:     multiagentspec.PlatformAWSAgentCore,
+		AWSAgentCore: &multiagentspec.AWSAgentCoreConfig{IAC: "terraform"},
+	}
+	files, err := Generate(agentCoreProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	if len(got) != 2 {
+		t.Errorf("got %d files, want main.tf and one handler", len(got))
+	}
+
+	main := got["terraform/main.tf"]
+	for _, want := range []string{
+		`default = "us-east-1"`,
+		`resource "aws_bedrockagent_agent" "pm"`,
+		`resource "aws_bedrockagent_agent" "shared_qa"`,
+		`resource "aws_bedrockagent_agent_action_group" "shared_qa_tasks"`,
+		`runtime          = "python3.11"`,
+		`name        = "unit-tests"`,
+		"report $${status}.",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf missing %q", want)
+		}
+	}
+	if strings.Contains(main, `"pm_tasks"`) {
+		t.Error("pm should not define an action group")
+	}
+	if _, ok := got["terraform/lambda/shared-qa/index.py"]; !ok {
+		t.Error("missing action group handler")
+	}
+}
+
+func TestAgentCoreGeneratorSecrets(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:         "aws",
+		Platform:     multiagentspec.PlatformAWSAgentCore,
+		AWSAgentCore: &multiagentspec.AWSAgentCoreConfig{IAC: "terraform"},
+		Secrets: map[string]multiagentspec.SecretRef{
+			"GITHUB_TOKEN": {Source: multiagentspec.SecretSourceAWSSecretsManager, Key: "ci/github#token"},
+			"NPM_TOKEN":    {Source: multiagentspec.SecretSourceAWSSecretsManager, Key: "arn:aws:secretsmanager:us-east-1:123456789012:secret:npm-AbCdEf"},
+		},
+	}
+	files, err := Generate(agentCoreProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	main := got["terraform/main.tf"]
+	for _, want := range []string{
+		`data "aws_secretsmanager_secret" "github_token"`,
+		`name = "ci/github"`,
+		`arn = "arn:aws:secretsmanager:us-east-1:123456789012:secret:npm-AbCdEf"`,
+		`resource "aws_iam_role_policy" "shared_qa_secrets"`,
+		"data.aws_secretsmanager_secret.npm_token.arn,",
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("main.tf missing %q", want)
+		}
+	}
+	handler := got["terraform/lambda/shared-qa/index.py"]
+	for _, want := range []string{"import boto3", `"ci/github",`, `"token"`, "_load_secrets()"} {
+		if !strings.Contains(handler, want) {
+			t.Errorf("index.py missing %q:\n%s", want, handler)
+		}
+	}
+
+	target.AWSAgentCore.IAC = "cdk"
+	files, err = Generate(agentCoreProject(), target)
+	if err != nil {
+		t.Fatalf("Generate cdk: %v", err)
+	}
+	agent := filesByPath(files)["cdk/lib/agents/shared-qa.ts"]
+	for _, want := range []string{
+		"import * as secretsmanager from 'aws-cdk-lib/aws-secretsmanager';",
+		"secretsmanager.Secret.fromSecretNameV2(this, 'GithubTokenSecret', 'ci/github').grantRead(actionFunction);",
+		"secretsmanager.Secret.fromSecretCompleteArn(this, 'NpmTokenSecret',",
+	} {
+		if !strings.Contains(agent, want) {
+			t.Errorf("shared-qa.ts missing %q", want)
+		}
+	}
+
+	target.Secrets = map[string]multiagentspec.SecretRef{"X": {Source: multiagentspec.SecretSourceVault, Key: "secret/x"}}
+	if _, err := Generate(agentCoreProject(), target); err == nil {
+		t.Error("expected error for vault secret")
+	}
+}
+
+func TestAgentCoreGeneratorUnsupportedIAC(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:         "aws",
+		Platform:     multiagentspec.PlatformAWSAgentCore,
+		AWSAgentCore: &multiagentspec.AWSAgentCoreConfig{IAC: "pulumi"},
+	}
+	if _, err := Generate(testProject(), target); err == nil {
+		t.Error("expected error for pulumi")
+	}
+}
@@ -0,0 +1,347 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Bedrock multi-agent defaults applied when AWSBedrockAgentsConfig leaves a
+// field empty.
+const (
+	DefaultBedrockAgentsOutput            = "bedrock"
+	DefaultBedrockAgentsCollaborationMode = "SUPERVISOR"
+)
+
+func init() {
+	Register(BedrockAgentsGenerator{})
+}
+
+// BedrockAgentsGenerator emits a Terraform module using Bedrock's native
+// multi-agent collaboration. Every agent with delegation targets becomes a
+// supervisor and its targets are associated as collaborators; knowledge
+// bases from the config are associated with the agents they list.
+type BedrockAgentsGenerator struct{}
+
+// Platform returns PlatformAWSBedrockAgents.
+func (BedrockAgentsGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformAWSBedrockAgents
+}
+
+type bedrockAgent struct {
+	Name            string
+	Resource        string
+	Description     string
+	Instruction     string
+	FoundationModel string
+	Collaborators   []bedrockCollaborator
+	KnowledgeBases  []bedrockKnowledgeBase
+}
+
+type bedrockCollaborator struct {
+	Name        string
+	Resource    string
+	Instruction string
+}
+
+type bedrockKnowledgeBase struct {
+	Resource    string
+	ID          string
+	Description string
+}
+
+// Generate returns main.tf under Target.Output.
+func (BedrockAgentsGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.AWSBedrockAgentsConfig{}
+	if target.AWSBedrockAgents != nil {
+		cfg = *target.AWSBedrockAgents
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultAgentCoreRegion
+	}
+	if cfg.FoundationModel == "" {
+		cfg.FoundationModel = target.ResolveModel(multiagentspec.ModelSonnet)
+	}
+	if cfg.CollaborationMode == "" {
+		cfg.CollaborationMode = DefaultBedrockAgentsCollaborationMode
+	}
+	switch cfg.CollaborationMode {
+	case "SUPERVISOR", "SUPERVISOR_ROUTER":
+	default:
+		return nil, fmt.Errorf("aws-bedrock-agents: invalid collaborationMode %q (want SUPERVISOR or SUPERVISOR_ROUTER)", cfg.CollaborationMode)
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("aws-bedrock-agents: no agents to deploy")
+	}
+
+	targets, err := delegationTargets(project, agents)
+	if err != nil {
+		return nil, fmt.Errorf("aws-bedrock-agents: %w", err)
+	}
+	if err := checkDelegationCycles(agents, targets); err != nil {
+		return nil, fmt.Errorf("aws-bedrock-agents: %w", err)
+	}
+
+	kbs := make(map[*multiagentspec.Agent][]multiagentspec.BedrockKnowledgeBase)
+	for _, kb := range cfg.KnowledgeBases {
+		if kb.ID == "" {
+			return nil, fmt.Errorf("aws-bedrock-agents: knowledge base without id")
+		}
+		if len(kb.Agents) == 0 {
+			for _, a := range agents {
+				kbs[a] = append(kbs[a], kb)
+			}
+			continue
+		}
+		for _, name := range kb.Agents {
+			a := findAgent(agents, name)
+			if a == nil {
+				return nil, fmt.Errorf("aws-bedrock-agents: knowledge base %s references %q, which is not on the team", kb.ID, name)
+			}
+			kbs[a] = append(kbs[a], kb)
+		}
+	}
+	for _, a := range agents {
+	sources:
+		for _, k := range a.VectorIndexes() {
+			for _, kb := range kbs[a] {
+				if kb.ID == k.VectorIndex {
+					continue sources
+				}
+			}
+			description := k.Description
+			if description == "" {
+				description = fmt.Sprintf("Use the %s knowledge base when relevant.", k.Name)
+			}
+			kbs[a] = append(kbs[a], multiagentspec.BedrockKnowledgeBase{ID: k.VectorIndex, Description: description})
+		}
+	}
+
+	relay := "DISABLED"
+	if cfg.RelayConversationHistory {
+		relay = "TO_COLLABORATOR"
+	}
+	data := struct {
+		Team              string
+		Region            string
+		CollaborationMode string
+		Relay             string
+		Agents            []bedrockAgent
+	}{
+		Team:              teamName(project),
+		Region:            cfg.Region,
+		CollaborationMode: cfg.CollaborationMode,
+		Relay:             relay,
+	}
+	for _, a := range agents {
+		ba := bedrockAgent{
+			Name:            k8sName(a.QualifiedName()),
+			Resource:        snakeCase(a.QualifiedName()),
+			Description:     a.Description,
+			Instruction:     a.PromptInstructions(multiagentspec.ExampleFormatMarkdown),
+			FoundationModel: cfg.FoundationModel,
+		}
+		if a.Model != "" {
+			ba.FoundationModel = target.ResolveModel(a.Model)
+		}
+		for _, c := range targets[a] {
+			instruction := c.Description
+			if instruction == "" {
+				instruction = fmt.Sprintf("Delegate to %s when its expertise is needed.", c.QualifiedName())
+			}
+			ba.Collaborators = append(ba.Collaborators, bedrockCollaborator{
+				Name:        k8sName(c.QualifiedName()),
+				Resource:    snakeCase(c.QualifiedName()),
+				Instruction: instruction,
+			})
+		}
+		for i, kb := range kbs[a] {
+			ba.KnowledgeBases = append(ba.KnowledgeBases, bedrockKnowledgeBase{
+				Resource:    fmt.Sprintf("%s_kb%d", ba.Resource, i),
+				ID:          kb.ID,
+				Description: kb.Description,
+			})
+		}
+		data.Agents = append(data.Agents, ba)
+	}
+
+	var buf bytes.Buffer
+	if err := bedrockAgentsTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("aws-bedrock-agents: render main.tf: %w", err)
+	}
+	out := target.Output
+	if out == "" {
+		out = DefaultBedrockAgentsOutput
+	}
+	return []File{{Path: path.Join(out, "main.tf"), Content: buf.Bytes()}}, nil
+}
+
+// checkDelegationCycles reports an error if delegation targets form a cycle.
+// Bedrock collaborators are referenced by alias, so the supervisor chain
+// must be acyclic.
+func checkDelegationCycles(agents []*multiagentspec.Agent, targets map[*multiagentspec.Agent][]*multiagentspec.Agent) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[*multiagentspec.Agent]int, len(agents))
+	var visit func(a *multiagentspec.Agent, chain []string) error
+	visit = func(a *multiagentspec.Agent, chain []string) error {
+		chain = append(chain, a.QualifiedName())
+		switch state[a] {
+		case visiting:
+			return fmt.Errorf("delegation cycle %s", strings.Join(chain, " -> "))
+		case done:
+			return nil
+		}
+		state[a] = visiting
+		for _, t := range targets[a] {
+			if err := visit(t, chain); err != nil {
+				return err
+			}
+		}
+		state[a] = done
+		return nil
+	}
+	for _, a := range agents {
+		if err := visit(a, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findAgent returns the agent matching name by qualified or plain name.
+func findAgent(agents []*multiagentspec.Agent, name string) *multiagentspec.Agent {
+	for _, a := range agents {
+		if a.QualifiedName() == name {
+			return a
+		}
+	}
+	for _, a := range agents {
+		if a.Name == name {
+			return a
+		}
+	}
+	return nil
+}
+
+var bedrockAgentsTemplate = template.Must(template.New("main.tf").Funcs(agentCoreFuncs).Parse(`# Bedrock multi-agent collaboration for the {{.Team}} team.
+# Generated by mas deploy generate.
+
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.82"
+    }
+  }
+}
+
+provider "aws" {
+  region = var.region
+}
+
+variable "region" {
+  type    = string
+  default = {{hclString .Region}}
+}
+
+data "aws_iam_policy_document" "bedrock_assume" {
+  statement {
+    actions = ["sts:AssumeRole"]
+    principals {
+      type        = "Service"
+      identifiers = ["bedrock.amazonaws.com"]
+    }
+  }
+}
+{{range .Agents}}
+# {{.Name}} agent
+
+resource "aws_iam_role" "{{.Resource}}" {
+  name_prefix        = "{{.Name}}-"
+  assume_role_policy = data.aws_iam_policy_document.bedrock_assume.json
+}
+
+resource "aws_iam_role_policy_attachment" "{{.Resource}}" {
+  role       = aws_iam_role.{{.Resource}}.name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonBedrockFullAccess"
+}
+
+resource "aws_bedrockagent_agent" "{{.Resource}}" {
+  agent_name                  = {{hclString .Name}}
+  description                 = {{hclString .Description}}
+  foundation_model            = {{hclString .FoundationModel}}
+  agent_resource_role_arn     = aws_iam_role.{{.Resource}}.arn
+  idle_session_ttl_in_seconds = 600
+{{- if .Collaborators}}
+  agent_collaboration         = {{hclString $.CollaborationMode}}
+  prepare_agent               = false
+{{- else}}
+  prepare_agent               = true
+{{- end}}
+  instruction                 = <<-EOT
+{{hclHeredoc .Instruction}}
+  EOT
+}
+{{- $agent := .}}
+{{- range .KnowledgeBases}}
+
+resource "aws_bedrockagent_agent_knowledge_base_association" "{{.Resource}}" {
+  agent_id             = aws_bedrockagent_agent.{{$agent.Resource}}.agent_id
+  knowledge_base_id    = {{hclString .ID}}
+  description          = {{hclString .Description}}
+  knowledge_base_state = "ENABLED"
+}
+{{- end}}
+{{- range .Collaborators}}
+
+resource "aws_bedrockagent_agent_collaborator" "{{$agent.Resource}}_{{.Resource}}" {
+  agent_id                   = aws_bedrockagent_agent.{{$agent.Resource}}.agent_id
+  collaborator_name          = {{hclString .Name}}
+  collaboration_instruction  = {{hclString .Instruction}}
+  relay_conversation_history = {{hclString $.Relay}}
+
+  agent_descriptor {
+    alias_arn = aws_bedrockagent_agent_alias.{{.Resource}}.agent_alias_arn
+  }
+}
+{{- end}}
+
+resource "aws_bedrockagent_agent_alias" "{{.Resource}}" {
+  agent_id         = aws_bedrockagent_agent.{{.Resource}}.agent_id
+  agent_alias_name = "live"
+{{- if or .Collaborators .KnowledgeBases}}
+
+  depends_on = [
+{{- range .KnowledgeBases}}
+    aws_bedrockagent_agent_knowledge_base_association.{{.Resource}},
+{{- end}}
+{{- range .Collaborators}}
+    aws_bedrockagent_agent_collaborator.{{$agent.Resource}}_{{.Resource}},
+{{- end}}
+  ]
+{{- end}}
+}
+
+output "{{.Resource}}_agent_id" {
+  value       = aws_bedrockagent_agent.{{.Resource}}.agent_id
+  description = "Agent ID for {{.Name}}"
+}
+
+output "{{.Resource}}_alias_id" {
+  value       = aws_bedrockagent_agent_alias.{{.Resource}}.agent_alias_id
+  description = "Live alias ID for {{.Name}}"
+}
+{{end -}}
+`))
@@ -0,0 +1,119 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestBedrockAgentsGenerator(t *testing.T) {
+	p := testProject()
+	p.Team.Orchestrator = "pm"
+	target := &multiagentspec.Target{
+		Name:     "bedrock",
+		Platform: multiagentspec.PlatformAWSBedrockAgents,
+		AWSBedrockAgents: &multiagentspec.AWSBedrockAgentsConfig{
+			CollaborationMode:        "SUPERVISOR_ROUTER",
+			RelayConversationHistory: true,
+			KnowledgeBases: []multiagentspec.BedrockKnowledgeBase{
+				{ID: "KB123", Description: "Release runbooks", Agents: []string{"qa"}},
+			},
+		},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "bedrock/main.tf" {
+		t.Fatalf("files = %v", files)
+	}
+	tf := string(files[0].Content)
+	for _, want := range []string{
+		`agent_collaboration         = "SUPERVISOR_ROUTER"`,
+		`resource "aws_bedrockagent_agent_collaborator" "pm_shared_qa" {`,
+		`collaboration_instruction  = "Runs tests"`,
+		`relay_conversation_history = "TO_COLLABORATOR"`,
+		"alias_arn = aws_bedrockagent_agent_alias.shared_qa.agent_alias_arn",
+		`resource "aws_bedrockagent_agent_knowledge_base_association" "shared_qa_kb0" {`,
+		`knowledge_base_id    = "KB123"`,
+		"    aws_bedrockagent_agent_collaborator.pm_shared_qa,\n",
+		`output "pm_alias_id" {`,
+	} {
+		if !strings.Contains(tf, want) {
+			t.Errorf("main.tf missing %q:\n%s", want, tf)
+		}
+	}
+	if strings.Contains(tf, `"shared_qa_pm"`) {
+		t.Error("qa has no delegation settings and should not be a supervisor")
+	}
+	if strings.Contains(tf, "pm_kb0") {
+		t.Error("knowledge base is scoped to qa")
+	}
+}
+
+func TestBedrockAgentsGeneratorKnowledgeSources(t *testing.T) {
+	p := testProject()
+	p.Agents[0].KnowledgeSources = []multiagentspec.KnowledgeSource{
+		{Name: "runbooks", VectorIndex: "KB123"},
+		{Name: "faq", Description: "Customer questions", VectorIndex: "KB456"},
+		{Name: "docs", URL: "https://example.com/docs"},
+	}
+	target := &multiagentspec.Target{
+		Name:     "bedrock",
+		Platform: multiagentspec.PlatformAWSBedrockAgents,
+		AWSBedrockAgents: &multiagentspec.AWSBedrockAgentsConfig{
+			KnowledgeBases: []multiagentspec.BedrockKnowledgeBase{{ID: "KB123", Description: "Release runbooks", Agents: []string{"qa"}}},
+		},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	tf := string(files[0].Content)
+	for _, want := range []string{
+		`knowledge_base_id    = "KB123"`,
+		`description          = "Release runbooks"`,
+		`resource "aws_bedrockagent_agent_knowledge_base_association" "shared_qa_kb1" {`,
+		`knowledge_base_id    = "KB456"`,
+		`description          = "Customer questions"`,
+	} {
+		if !strings.Contains(tf, want) {
+			t.Errorf("main.tf missing %q:\n%s", want, tf)
+		}
+	}
+	if n := strings.Count(tf, `knowledge_base_id    = "KB123"`); n != 1 {
+		t.Errorf("KB123 associated %d times, want once", n)
+	}
+	if strings.Contains(tf, "shared_qa_kb2") {
+		t.Error("url knowledge source became a knowledge base")
+	}
+}
+
+func TestBedrockAgentsGeneratorErrors(t *testing.T) {
+	cycle := testProject()
+	cycle.Agents[0].WithDelegation(&multiagentspec.DelegationConfig{AllowDelegation: true})
+	cycle.Agents[1].WithDelegation(&multiagentspec.DelegationConfig{AllowDelegation: true})
+
+	tests := []struct {
+		name    string
+		project *Project
+		cfg     *multiagentspec.AWSBedrockAgentsConfig
+		want    string
+	}{
+		{"mode", testProject(), &multiagentspec.AWSBedrockAgentsConfig{CollaborationMode: "SWARM"}, "invalid collaborationMode"},
+		{"cycle", cycle, nil, "delegation cycle"},
+		{"kb agent", testProject(), &multiagentspec.AWSBedrockAgentsConfig{
+			KnowledgeBases: []multiagentspec.BedrockKnowledgeBase{{ID: "KB1", Description: "d", Agents: []string{"unused"}}},
+		}, "not on the team"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &multiagentspec.Target{Name: "b", Platform: multiagentspec.PlatformAWSBedrockAgents, AWSBedrockAgents: tt.cfg}
+			_, err := Generate(tt.project, target)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
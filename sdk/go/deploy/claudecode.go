@@ -0,0 +1,236 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Claude Code defaults applied when ClaudeCodeConfig leaves a field empty.
+const (
+	DefaultClaudeCodeAgentDir = ".claude/agents"
+	DefaultClaudeCodeFormat   = "markdown"
+)
+
+// ClaudeCodeTeamsEnv is the environment variable that enables Claude Code
+// agent teams.
+const ClaudeCodeTeamsEnv = "CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS"
+
+func init() {
+	Register(ClaudeCodeGenerator{})
+}
+
+// ClaudeCodeGenerator writes one markdown subagent file per agent into
+// ClaudeCodeConfig.AgentDir, falling back to Target.Output. When agent teams
+// are enabled it also writes a settings.json next to the agent directory
+// with the teams env flag and teammate display mode. Agents' MCP servers are
+// written to a project .mcp.json above the .claude directory. Both files sit
+// in directories shared with the project, so they are marked Shared.
+type ClaudeCodeGenerator struct{}
+
+// Platform returns PlatformClaudeCode.
+func (ClaudeCodeGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformClaudeCode
+}
+
+// claudeFrontmatter is the YAML frontmatter of a Claude Code subagent file.
+type claudeFrontmatter struct {
+	Name         string   `yaml:"name"`
+	Description  string   `yaml:"description,omitempty"`
+	Model        string   `yaml:"model,omitempty"`
+	Tools        []string `yaml:"tools,omitempty,flow"`
+	AllowedTools []string `yaml:"allowedTools,omitempty,flow"`
+}
+
+// claudeSettings is the subset of .claude/settings.json written for teams.
+type claudeSettings struct {
+	Env          map[string]string  `json:"env,omitempty"`
+	TeammateMode string             `json:"teammateMode,omitempty"`
+	Permissions  *claudePermissions `json:"permissions,omitempty"`
+}
+
+type claudePermissions struct {
+	Allow []string `json:"allow,omitempty"`
+}
+
+// claudeMCPConfig is the project .mcp.json file.
+type claudeMCPConfig struct {
+	MCPServers map[string]claudeMCPServer `json:"mcpServers"`
+}
+
+type claudeMCPServer struct {
+	Type    string   `json:"type"`
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	URL     string   `json:"url,omitempty"`
+}
+
+// Generate returns the agent markdown files, .mcp.json when agents declare
+// MCP servers, and, for team mode or guardrail domains, settings.json.
+func (ClaudeCodeGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.ClaudeCodeConfig{}
+	if target.ClaudeCode != nil {
+		cfg = *target.ClaudeCode
+	}
+	if cfg.AgentDir == "" {
+		cfg.AgentDir = target.Output
+	}
+	if cfg.AgentDir == "" {
+		cfg.AgentDir = DefaultClaudeCodeAgentDir
+	}
+	if cfg.Format == "" {
+		cfg.Format = DefaultClaudeCodeFormat
+	}
+	if cfg.Format != DefaultClaudeCodeFormat {
+		return nil, fmt.Errorf("claude-code: unsupported format %q (want %q)", cfg.Format, DefaultClaudeCodeFormat)
+	}
+	switch cfg.TeamMode {
+	case "", "subagent", "team":
+	default:
+		return nil, fmt.Errorf("claude-code: invalid team_mode %q (want subagent or team)", cfg.TeamMode)
+	}
+	switch cfg.TeammateMode {
+	case "", "auto", "in-process", "tmux":
+	default:
+		return nil, fmt.Errorf("claude-code: invalid teammate_mode %q (want auto, in-process, or tmux)", cfg.TeammateMode)
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	agentDir := path.Clean(strings.ReplaceAll(cfg.AgentDir, "\\", "/"))
+	files := make([]File, 0, len(agents)+2)
+	for _, a := range agents {
+		content, err := claudeAgentMarkdown(a, target)
+		if err != nil {
+			return nil, fmt.Errorf("claude-code: agent %s: %w", a.QualifiedName(), err)
+		}
+		files = append(files, File{
+			Path:    path.Join(agentDir, a.Namespace, a.Name+".md"),
+			Content: content,
+		})
+	}
+
+	servers, err := teamMCPServers(agents)
+	if err != nil {
+		return nil, fmt.Errorf("claude-code: %w", err)
+	}
+	if len(servers) > 0 {
+		config := claudeMCPConfig{MCPServers: make(map[string]claudeMCPServer, len(servers))}
+		for _, s := range servers {
+			config.MCPServers[s.Name] = claudeMCPServer{
+				Type:    string(s.Transport),
+				Command: s.Command,
+				Args:    s.Args,
+				URL:     s.URL,
+			}
+		}
+		data, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("claude-code: marshal mcp config: %w", err)
+		}
+		files = append(files, File{
+			Path:    path.Join(path.Dir(path.Dir(agentDir)), ".mcp.json"),
+			Content: append(data, '\n'),
+			Shared:  true,
+		})
+	}
+
+	webFetch := claudeWebFetchRules(agents)
+	if cfg.TeamMode == "team" || cfg.EnableTeams || len(webFetch) > 0 {
+		settings := claudeSettings{}
+		if len(webFetch) > 0 {
+			settings.Permissions = &claudePermissions{Allow: webFetch}
+		}
+		if cfg.EnableTeams {
+			settings.Env = map[string]string{ClaudeCodeTeamsEnv: "1"}
+		}
+		if cfg.TeamMode == "team" {
+			settings.TeammateMode = cfg.TeammateMode
+			if settings.TeammateMode == "" {
+				settings.TeammateMode = "auto"
+			}
+		}
+		data, err := json.MarshalIndent(settings, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("claude-code: marshal settings: %w", err)
+		}
+		files = append(files, File{
+			Path:    path.Join(path.Dir(agentDir), "settings.json"),
+			Content: append(data, '\n'),
+			Shared:  true,
+		})
+	}
+
+	return files, nil
+}
+
+// claudeWebFetchRules returns WebFetch(domain:<d>) permission rules for
+// the guardrail allowed domains of all agents, sorted. Claude Code
+// permissions are project-wide, so fetches from the listed domains run
+// without a prompt for every agent and other domains still prompt.
+func claudeWebFetchRules(agents []*multiagentspec.Agent) []string {
+	var rules []string
+	for _, a := range agents {
+		if a.Guardrails == nil {
+			continue
+		}
+		for _, d := range a.Guardrails.AllowedDomains {
+			rules = appendUnique(rules, "WebFetch(domain:"+strings.ToLower(d)+")")
+		}
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// claudeAgentMarkdown renders an agent as a Claude Code subagent file.
+// The agent's model is resolved for target. An agent with a tool list also
+// gets its MCP servers' tools, as mcp__<server>__<tool> or, when the server
+// allows all tools, mcp__<server>.
+func claudeAgentMarkdown(a *multiagentspec.Agent, target *multiagentspec.Target) ([]byte, error) {
+	fm := claudeFrontmatter{
+		Name:         a.Name,
+		Description:  a.Description,
+		Tools:        a.Tools,
+		AllowedTools: a.AllowedTools,
+	}
+	if len(a.Tools) > 0 {
+		fm.Tools = append([]string(nil), a.Tools...)
+		for _, s := range a.MCPServers {
+			if len(s.AllowedTools) == 0 {
+				fm.Tools = appendUnique(fm.Tools, "mcp__"+s.Name)
+			}
+			for _, t := range s.AllowedTools {
+				fm.Tools = appendUnique(fm.Tools, "mcp__"+s.Name+"__"+t)
+			}
+		}
+	}
+	if a.Model != "" {
+		fm.Model = target.ResolveModel(a.Model)
+	}
+
+	header, err := yaml.Marshal(fm)
+	if err != nil {
+		return nil, fmt.Errorf("marshal frontmatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n")
+	if prompt := a.PromptInstructions(multiagentspec.ExampleFormatXML); prompt != "" {
+		buf.WriteString("\n")
+		buf.WriteString(prompt)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,207 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestClaudeCodeGenerator(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Model = multiagentspec.ModelOpus
+	p.Agents[1].AllowedTools = []string{"Read"}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+	if files[0].Path != ".claude/agents/pm.md" || files[1].Path != ".claude/agents/shared/qa.md" {
+		t.Errorf("paths = %q, %q", files[0].Path, files[1].Path)
+	}
+
+	want := "---\nname: pm\ndescription: Checks the release plan\nmodel: opus\nallowedTools: [Read]\n---\n\nReview the plan.\n"
+	if got := string(files[0].Content); got != want {
+		t.Errorf("pm.md =\n%s\nwant\n%s", got, want)
+	}
+	if !strings.Contains(string(files[1].Content), "tools: [Read, Bash]\n") {
+		t.Errorf("qa.md missing tools:\n%s", files[1].Content)
+	}
+
+	// Generated files load back as spec agents
+	agent, err := multiagentspec.ParseAgentMarkdown(files[0].Content)
+	if err != nil || agent.Name != "pm" || agent.Model != multiagentspec.ModelOpus || agent.Instructions != "Review the plan." {
+		t.Errorf("ParseAgentMarkdown = %+v, %v", agent, err)
+	}
+
+	// Target model overrides replace the mapped tier
+	target.Models = map[multiagentspec.Model]string{multiagentspec.ModelOpus: "claude-opus-4-20250514"}
+	files, err = Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate with models: %v", err)
+	}
+	if !strings.Contains(string(files[0].Content), "model: claude-opus-4-20250514\n") {
+		t.Errorf("pm.md ignores model override:\n%s", files[0].Content)
+	}
+}
+
+func TestClaudeCodeGeneratorTeams(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "teams",
+		Platform: multiagentspec.PlatformClaudeCode,
+		ClaudeCode: &multiagentspec.ClaudeCodeConfig{
+			AgentDir:     "out/.claude/agents",
+			TeamMode:     "team",
+			TeammateMode: "tmux",
+			EnableTeams:  true,
+		},
+	}
+	files, err := Generate(testProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	settings := files[len(files)-1]
+	if settings.Path != "out/.claude/settings.json" {
+		t.Errorf("settings path = %q", settings.Path)
+	}
+	for _, want := range []string{`"CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS": "1"`, `"teammateMode": "tmux"`} {
+		if !strings.Contains(string(settings.Content), want) {
+			t.Errorf("settings.json missing %s:\n%s", want, settings.Content)
+		}
+	}
+}
+
+func TestClaudeCodeGeneratorGuardrails(t *testing.T) {
+	p := testProject()
+	p.Agents[0].Guardrails = &multiagentspec.Guardrails{AllowedDomains: []string{"pkg.go.dev", "GitHub.com"}}
+	p.Agents[1].Guardrails = &multiagentspec.Guardrails{AllowedDomains: []string{"github.com"}}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	want := `{
+  "permissions": {
+    "allow": [
+      "WebFetch(domain:github.com)",
+      "WebFetch(domain:pkg.go.dev)"
+    ]
+  }
+}
+`
+	if got := filesByPath(files)[".claude/settings.json"]; got != want {
+		t.Errorf("settings.json =\n%s\nwant\n%s", got, want)
+	}
+
+	p.Agents[1].Guardrails.OutputDenyPatterns = []string{"("}
+	if _, err := Generate(p, target); err == nil || !strings.Contains(err.Error(), "agent pm: guardrails: output_deny_patterns") {
+		t.Errorf("Generate error = %v, want invalid pattern", err)
+	}
+}
+
+func TestClaudeCodeGeneratorExamples(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Examples = []multiagentspec.Example{{Input: "Ship v2 Friday?", Output: "NO-GO: QA sign-off is missing.", Commentary: "Blockers come first."}}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	want := "\nReview the plan.\n\n<examples>\n<example>\n<input>\nShip v2 Friday?\n</input>\n<output>\nNO-GO: QA sign-off is missing.\n</output>\n<commentary>\nBlockers come first.\n</commentary>\n</example>\n</examples>\n"
+	if pm := filesByPath(files)[".claude/agents/pm.md"]; !strings.HasSuffix(pm, want) {
+		t.Errorf("pm.md =\n%s\nwant suffix\n%s", pm, want)
+	}
+
+	p.Agents[1].Examples = append(p.Agents[1].Examples, multiagentspec.Example{Input: "Ship now?"})
+	if _, err := Generate(p, target); err == nil || !strings.Contains(err.Error(), "agent pm: example 2: output is required") {
+		t.Errorf("Generate error = %v, want invalid example", err)
+	}
+}
+
+func TestClaudeCodeGeneratorInvalidConfig(t *testing.T) {
+	configs := []*multiagentspec.ClaudeCodeConfig{
+		{Format: "json"},
+		{TeamMode: "swarm"},
+		{TeammateMode: "window"},
+	}
+	for _, cfg := range configs {
+		target := &multiagentspec.Target{Name: "bad", Platform: multiagentspec.PlatformClaudeCode, ClaudeCode: cfg}
+		if _, err := Generate(testProject(), target); err == nil {
+			t.Errorf("expected error for config %+v", cfg)
+		}
+	}
+}
+
+func TestClaudeCodeGeneratorMCPServers(t *testing.T) {
+	p := testProject()
+	p.Agents[0].MCPServers = []multiagentspec.MCPServer{
+		{Name: "github", Transport: multiagentspec.MCPTransportHTTP, URL: "https://api.githubcopilot.com/mcp/", AllowedTools: []string{"create_issue", "list_issues"}},
+		{Name: "db", Command: "npx", Args: []string{"-y", "db-mcp"}},
+	}
+	p.Agents[1].MCPServers = []multiagentspec.MCPServer{{Name: "github", Transport: multiagentspec.MCPTransportHTTP, URL: "https://api.githubcopilot.com/mcp/"}}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	want := `{
+  "mcpServers": {
+    "db": {
+      "type": "stdio",
+      "command": "npx",
+      "args": [
+        "-y",
+        "db-mcp"
+      ]
+    },
+    "github": {
+      "type": "http",
+      "url": "https://api.githubcopilot.com/mcp/"
+    }
+  }
+}
+`
+	if got[".mcp.json"] != want {
+		t.Errorf(".mcp.json =\n%s\nwant\n%s", got[".mcp.json"], want)
+	}
+	if qa := got[".claude/agents/shared/qa.md"]; !strings.Contains(qa, "tools: [Read, Bash, mcp__github__create_issue, mcp__github__list_issues, mcp__db]\n") {
+		t.Errorf("qa.md missing MCP tools:\n%s", qa)
+	}
+	if pm := got[".claude/agents/pm.md"]; strings.Contains(pm, "tools:") {
+		t.Errorf("pm.md has no tool list and should not gain one:\n%s", pm)
+	}
+
+	// .mcp.json lands in the project root, so diffing a written tree does
+	// not report the project's own files as removed.
+	root := t.TempDir()
+	for _, name := range []string{"deployment.json", "team.json", "agents/pm.md", "agents/qa.md"} {
+		if err := os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), []byte("spec\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Write(root, files); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if changes, err := Diff(root, files); err != nil || len(changes) != 0 {
+		t.Errorf("Diff = %v, %v; want no changes", changes, err)
+	}
+
+	// Conflicting declarations of a shared server are rejected
+	p.Agents[1].MCPServers[0].URL = "https://example.com/mcp"
+	if _, err := Generate(p, target); err == nil || !strings.Contains(err.Error(), "agents pm and shared/qa declare it differently") {
+		t.Errorf("Generate error = %v, want conflicting declarations", err)
+	}
+}
@@ -0,0 +1,337 @@
+// Package deploy turns a deployment definition into platform-specific
+// artifacts.
+//
+// Each supported platform has a Generator registered under its
+// multiagentspec.Platform. A generator receives the team, its agents, and the
+// deployment target and returns the files to write, so callers can preview,
+// diff, or write them.
+//
+// Example:
+//
+//	project := &deploy.Project{Team: team, Agents: agents, Deployment: dep}
+//	files, err := deploy.Generate(project, &dep.Targets[0])
+//	if err != nil {
+//	    return err
+//	}
+//	err = deploy.Write(".", files)
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Project holds the definitions a generator works from.
+type Project struct {
+	// Team is the team being deployed. Optional; when nil all Agents are deployed.
+	Team *multiagentspec.Team
+
+	// Agents are the agent definitions available to the team.
+	Agents []*multiagentspec.Agent
+
+	// Deployment is the deployment definition containing the target.
+	Deployment *multiagentspec.Deployment
+
+	// Skills are the skill definitions agents reference. Optional; when nil
+	// agent skills are not checked against definitions.
+	Skills []*multiagentspec.Skill
+
+	// Tools are the custom tool definitions agents reference. Optional; when
+	// nil agent tools are not checked against definitions.
+	Tools []*multiagentspec.ToolSpec
+
+	// Rubrics are the evaluation rubrics agents and tasks reference.
+	// Optional; when nil rubric references are not checked.
+	Rubrics []*multiagentspec.Rubric
+}
+
+// TeamAgents returns the agents referenced by the team, in team order.
+// Team entries match an agent's qualified name or plain name, and entries
+// pinned with name@constraint resolve to the highest compatible version
+// (see multiagentspec.Team.ResolveAgents). If the project has no team, all
+// agents are returned.
+func (p *Project) TeamAgents() ([]*multiagentspec.Agent, error) {
+	if p.Team == nil {
+		return p.Agents, nil
+	}
+	return p.Team.ResolveAgents(p.Agents)
+}
+
+// Variables returns the values for instruction placeholders: the team's
+// variables overridden by the deployment's.
+func (p *Project) Variables() map[string]string {
+	var team, dep map[string]string
+	if p.Team != nil {
+		team = p.Team.Variables
+	}
+	if p.Deployment != nil {
+		dep = p.Deployment.Variables
+	}
+	return multiagentspec.MergeVariables(team, dep)
+}
+
+// resolveInstructions returns a copy of the project whose agents have
+// their instruction placeholders filled in from the project's variables.
+func (p *Project) resolveInstructions() (*Project, error) {
+	vars := p.Variables()
+	resolved := *p
+	resolved.Agents = make([]*multiagentspec.Agent, len(p.Agents))
+	for i, a := range p.Agents {
+		instructions, err := a.ResolveInstructions(vars)
+		if err != nil {
+			return nil, fmt.Errorf("agent %s: %w", a.QualifiedName(), err)
+		}
+		agent := *a
+		agent.Instructions = instructions
+		resolved.Agents[i] = &agent
+	}
+	return &resolved, nil
+}
+
+// File is a generated artifact.
+type File struct {
+	// Path is the slash-separated path relative to the output root.
+	Path string `json:"path"`
+
+	// Content is the file content.
+	Content []byte `json:"-"`
+
+	// Mode is the file permission. Zero means 0o644.
+	Mode os.FileMode `json:"mode,omitempty"`
+
+	// Shared marks a file in a directory the generator does not own, such
+	// as a project's .mcp.json, so Diff does not report the directory's
+	// other files as removed.
+	Shared bool `json:"shared,omitempty"`
+}
+
+// Generator produces artifacts for one platform.
+type Generator interface {
+	// Platform returns the platform this generator handles.
+	Platform() multiagentspec.Platform
+
+	// Generate returns the files for the target.
+	Generate(project *Project, target *multiagentspec.Target) ([]File, error)
+}
+
+// SecretWirer is implemented by generators that wire secrets from sources
+// other than env. Generators that do not implement it support only env
+// secrets, which their runtimes read from the process environment.
+type SecretWirer interface {
+	// SecretSources returns the secret sources the generator can wire.
+	SecretSources() []multiagentspec.SecretSource
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[multiagentspec.Platform]Generator)
+)
+
+// Register makes a generator available for its platform, replacing any
+// generator previously registered for the same platform.
+func Register(g Generator) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[g.Platform()] = g
+}
+
+// Lookup returns the generator registered for platform.
+func Lookup(platform multiagentspec.Platform) (Generator, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	g, ok := registry[platform]
+	return g, ok
+}
+
+// SupportedPlatforms returns the platforms with a registered generator, sorted.
+func SupportedPlatforms() []multiagentspec.Platform {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	platforms := make([]multiagentspec.Platform, 0, len(registry))
+	for p := range registry {
+		platforms = append(platforms, p)
+	}
+	sort.Slice(platforms, func(i, j int) bool { return platforms[i] < platforms[j] })
+	return platforms
+}
+
+// Generate runs the generator registered for the target's platform.
+func Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	g, ok := Lookup(target.Platform)
+	if !ok {
+		return nil, fmt.Errorf("target %s: no generator for platform %q", target.Name, target.Platform)
+	}
+	if err := checkSecrets(g, target); err != nil {
+		return nil, fmt.Errorf("target %s: %w", target.Name, err)
+	}
+	if err := checkAgents(project); err != nil {
+		return nil, fmt.Errorf("target %s: %w", target.Name, err)
+	}
+	project, err := project.resolveInstructions()
+	if err != nil {
+		return nil, fmt.Errorf("target %s: %w", target.Name, err)
+	}
+	files, err := g.Generate(project, target)
+	if err != nil {
+		return nil, fmt.Errorf("target %s: %w", target.Name, err)
+	}
+	return files, nil
+}
+
+// checkSecrets validates the target's secret references and rejects sources
+// the generator cannot wire.
+func checkSecrets(g Generator, target *multiagentspec.Target) error {
+	supported := []multiagentspec.SecretSource{multiagentspec.SecretSourceEnv}
+	if w, ok := g.(SecretWirer); ok {
+		supported = w.SecretSources()
+	}
+	for _, name := range sortedSecretNames(target.Secrets) {
+		ref := target.Secrets[name]
+		if err := ref.Validate(); err != nil {
+			return fmt.Errorf("secret %s: %w", name, err)
+		}
+		ok := false
+		for _, s := range supported {
+			ok = ok || s == ref.Source
+		}
+		if !ok {
+			return fmt.Errorf("secret %s: %s does not support %s secrets", name, g.Platform(), ref.Source)
+		}
+	}
+	return nil
+}
+
+// checkAgents validates the guardrails, memory settings, knowledge
+// sources, examples, and instruction variables of the project's agents,
+// and that the team's members have their dependencies, so generators can
+// rely on them. Local knowledge source paths are checked when the agents
+// are loaded.
+func checkAgents(project *Project) error {
+	if project.Team != nil {
+		registry, err := multiagentspec.NewAgentRegistry(project.Agents)
+		if err != nil {
+			return err
+		}
+		if err := registry.CheckTeamDependencies(project.Team); err != nil {
+			return err
+		}
+	}
+	if project.Skills != nil {
+		if err := multiagentspec.CheckSkillReferences(project.Agents, project.Skills); err != nil {
+			return err
+		}
+	}
+	if project.Tools != nil {
+		if err := multiagentspec.CheckToolReferences(project.Agents, project.Tools); err != nil {
+			return err
+		}
+	}
+	if project.Rubrics != nil {
+		if err := multiagentspec.CheckRubricReferences(project.Agents, project.Rubrics); err != nil {
+			return err
+		}
+	}
+	vars := project.Variables()
+	for _, a := range project.Agents {
+		if err := a.Guardrails.Validate(); err != nil {
+			return fmt.Errorf("agent %s: guardrails: %w", a.QualifiedName(), err)
+		}
+		if err := a.Memory.Validate(); err != nil {
+			return fmt.Errorf("agent %s: memory: %w", a.QualifiedName(), err)
+		}
+		if err := a.ValidateKnowledgeSources(""); err != nil {
+			return fmt.Errorf("agent %s: %w", a.QualifiedName(), err)
+		}
+		if err := a.ValidateExamples(); err != nil {
+			return fmt.Errorf("agent %s: %w", a.QualifiedName(), err)
+		}
+		missing, err := a.UnresolvedVariables(vars)
+		if err != nil {
+			return fmt.Errorf("agent %s: %w", a.QualifiedName(), err)
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("agent %s: instructions reference undefined variables: %s", a.QualifiedName(), strings.Join(missing, ", "))
+		}
+	}
+	return nil
+}
+
+// sortedSecretNames returns the environment variable names of secrets, sorted.
+func sortedSecretNames(secrets map[string]multiagentspec.SecretRef) []string {
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// teamMCPServers returns the MCP servers declared by agents, sorted by name.
+// Agents sharing a server must declare it identically apart from its
+// allowed tools, which are merged; an agent allowing all tools widens the
+// merged server to all tools.
+func teamMCPServers(agents []*multiagentspec.Agent) ([]multiagentspec.MCPServer, error) {
+	byName := make(map[string]*multiagentspec.MCPServer)
+	declaredBy := make(map[string]string)
+	for _, a := range agents {
+		for _, s := range a.MCPServers {
+			if err := s.Validate(); err != nil {
+				return nil, fmt.Errorf("agent %s: %w", a.QualifiedName(), err)
+			}
+			merged, ok := byName[s.Name]
+			if !ok {
+				merged := s
+				merged.Transport = s.EffectiveTransport()
+				merged.AllowedTools = append([]string(nil), s.AllowedTools...)
+				byName[s.Name] = &merged
+				declaredBy[s.Name] = a.QualifiedName()
+				continue
+			}
+			if merged.Transport != s.EffectiveTransport() || merged.Command != s.Command ||
+				merged.URL != s.URL || strings.Join(merged.Args, "\x00") != strings.Join(s.Args, "\x00") {
+				return nil, fmt.Errorf("mcp server %s: agents %s and %s declare it differently", s.Name, declaredBy[s.Name], a.QualifiedName())
+			}
+			if len(merged.AllowedTools) == 0 || len(s.AllowedTools) == 0 {
+				merged.AllowedTools = nil
+			} else {
+				merged.AllowedTools = appendUnique(merged.AllowedTools, s.AllowedTools...)
+			}
+		}
+	}
+
+	servers := make([]multiagentspec.MCPServer, 0, len(byName))
+	for _, s := range byName {
+		servers = append(servers, *s)
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].Name < servers[j].Name })
+	return servers, nil
+}
+
+// Write writes files under root, creating directories as needed.
+// Paths that would escape root are rejected.
+func Write(root string, files []File) error {
+	for _, f := range files {
+		rel := filepath.FromSlash(f.Path)
+		if !filepath.IsLocal(rel) {
+			return fmt.Errorf("refusing to write %q outside output directory", f.Path)
+		}
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("create directory for %s: %w", path, err)
+		}
+		mode := f.Mode
+		if mode == 0 {
+			mode = 0o644
+		}
+		if err := os.WriteFile(path, f.Content, mode); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func testProject() *Project {
+	team := multiagentspec.NewTeam("release-team", "1.0.0").WithAgents("pm", "shared/qa")
+	return &Project{
+		Team: team,
+		Agents: []*multiagentspec.Agent{
+			multiagentspec.NewAgent("qa", "Runs tests").WithNamespace("shared").WithTools("Read", "Bash"),
+			multiagentspec.NewAgent("pm", "Checks the release plan").WithInstructions("Review the plan."),
+			multiagentspec.NewAgent("unused", "Not on the team"),
+		},
+		Deployment: multiagentspec.NewDeployment("release-team"),
+	}
+}
+
+func TestTeamAgents(t *testing.T) {
+	agents, err := testProject().TeamAgents()
+	if err != nil {
+		t.Fatalf("TeamAgents: %v", err)
+	}
+	if len(agents) != 2 || agents[0].Name != "pm" || agents[1].QualifiedName() != "shared/qa" {
+		t.Errorf("TeamAgents returned %d agents in wrong order", len(agents))
+	}
+
+	p := testProject()
+	p.Team.Agents = append(p.Team.Agents, "missing")
+	if _, err := p.TeamAgents(); err == nil {
+		t.Error("expected error for unknown team agent")
+	}
+
+	p = testProject()
+	p.Agents[1].Version = "1.4.0"
+	p.Team.Agents[0] = "pm@^2"
+	if _, err := p.TeamAgents(); err == nil || !strings.Contains(err.Error(), "agent pm: no version satisfies ^2") {
+		t.Errorf("TeamAgents error = %v, want incompatible pm", err)
+	}
+	p.Team.Agents[0] = "pm@~1.4"
+	if agents, err := p.TeamAgents(); err != nil || agents[0] != p.Agents[1] {
+		t.Errorf("TeamAgents(pm@~1.4) = %v, %v", agents, err)
+	}
+
+	p.Team = nil
+	if agents, _ := p.TeamAgents(); len(agents) != 3 {
+		t.Errorf("without team, TeamAgents = %d agents, want 3", len(agents))
+	}
+}
+
+func TestGenerateUnknownPlatform(t *testing.T) {
+	target := &multiagentspec.Target{Name: "x", Platform: "bogus"}
+	if _, err := Generate(testProject(), target); err == nil {
+		t.Error("expected error for platform without generator")
+	}
+}
+
+func TestGenerateSecretSources(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "local",
+		Platform: multiagentspec.PlatformClaudeCode,
+		Secrets:  map[string]multiagentspec.SecretRef{"GITHUB_TOKEN": {Source: multiagentspec.SecretSourceEnv, Key: "GITHUB_TOKEN"}},
+	}
+	if _, err := Generate(testProject(), target); err != nil {
+		t.Errorf("env secret: %v", err)
+	}
+
+	target.Secrets["DB_PASSWORD"] = multiagentspec.SecretRef{Source: multiagentspec.SecretSourceVault, Key: "secret/data/db#password"}
+	_, err := Generate(testProject(), target)
+	if err == nil || !strings.Contains(err.Error(), "does not support vault secrets") {
+		t.Errorf("vault secret on claude-code: err = %v", err)
+	}
+
+	target.Secrets = map[string]multiagentspec.SecretRef{"X": {Source: "ssm", Key: "x"}}
+	if _, err := Generate(testProject(), target); err == nil {
+		t.Error("expected error for unknown secret source")
+	}
+}
+
+func TestGenerateVariables(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Instructions = "Review the {{ .vars.project_name }} plan for {{ .vars.release }}."
+	p.Team.Variables = map[string]string{"project_name": "atlas", "release": "v1"}
+	p.Deployment.Variables = map[string]string{"release": "v2"}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if pm := filesByPath(files)[".claude/agents/pm.md"]; !strings.Contains(pm, "Review the atlas plan for v2.") {
+		t.Errorf("pm.md =\n%s\nwant resolved instructions", pm)
+	}
+	if !strings.Contains(p.Agents[1].Instructions, "{{ .vars.project_name }}") {
+		t.Error("Generate modified the project's agent instructions")
+	}
+
+	p.Team.Variables = nil
+	_, err = Generate(p, target)
+	if err == nil || !strings.Contains(err.Error(), "agent pm: instructions reference undefined variables: project_name") {
+		t.Errorf("Generate error = %v, want undefined variable", err)
+	}
+}
+
+func TestGenerateTeamDependencies(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Dependencies = []string{"shared/qa"}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+	if _, err := Generate(p, target); err != nil {
+		t.Errorf("Generate: %v", err)
+	}
+
+	p.Agents[1].Dependencies = []string{"unused"}
+	_, err := Generate(p, target)
+	if err == nil || !strings.Contains(err.Error(), "agent pm depends on unused, which is neither on the team nor in the shared namespace") {
+		t.Errorf("Generate error = %v, want unsatisfied dependency", err)
+	}
+}
+
+func TestGenerateSkillReferences(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Skills = []string{"planning"}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+	if _, err := Generate(p, target); err != nil {
+		t.Errorf("Generate without skill definitions: %v", err)
+	}
+
+	p.Skills = []*multiagentspec.Skill{{Name: "review"}}
+	_, err := Generate(p, target)
+	if err == nil || !strings.Contains(err.Error(), `agent pm: unknown skill "planning"`) {
+		t.Errorf("Generate error = %v, want unknown skill", err)
+	}
+
+	p.Skills = append(p.Skills, &multiagentspec.Skill{Name: "planning"})
+	if _, err := Generate(p, target); err != nil {
+		t.Errorf("Generate: %v", err)
+	}
+}
+
+func TestGenerateToolReferences(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Tools = []string{"Bash(git log:*)", "jira_search"}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+	if _, err := Generate(p, target); err != nil {
+		t.Errorf("Generate without tool definitions: %v", err)
+	}
+
+	p.Tools = []*multiagentspec.ToolSpec{}
+	_, err := Generate(p, target)
+	if err == nil || !strings.Contains(err.Error(), `agent pm: unknown tool "jira_search"`) {
+		t.Errorf("Generate error = %v, want unknown tool", err)
+	}
+
+	p.Tools = append(p.Tools, &multiagentspec.ToolSpec{Name: "jira_search"})
+	if _, err := Generate(p, target); err != nil {
+		t.Errorf("Generate: %v", err)
+	}
+}
+
+func TestGenerateRubricReferences(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Tasks = []multiagentspec.Task{{ID: "plan-review", Rubric: "plan-quality"}}
+	target := &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode}
+	p.Rubrics = []*multiagentspec.Rubric{}
+	_, err := Generate(p, target)
+	if err == nil || !strings.Contains(err.Error(), `agent pm: task plan-review: unknown rubric "plan-quality"`) {
+		t.Errorf("Generate error = %v, want unknown rubric", err)
+	}
+
+	p.Rubrics = append(p.Rubrics, &multiagentspec.Rubric{Name: "plan-quality", Categories: []multiagentspec.RubricCategory{{ID: "risk"}}})
+	if _, err := Generate(p, target); err != nil {
+		t.Errorf("Generate: %v", err)
+	}
+}
+
+func TestSupportedPlatforms(t *testing.T) {
+	found := false
+	for _, p := range SupportedPlatforms() {
+		if p == multiagentspec.PlatformClaudeCode {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("claude-code generator is not registered")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	root := t.TempDir()
+	files := []File{{Path: "a/b/c.txt", Content: []byte("hello")}}
+	if err := Write(root, files); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "a", "b", "c.txt"))
+	if err != nil || string(data) != "hello" {
+		t.Errorf("written file = %q, %v", data, err)
+	}
+
+	if err := Write(root, []File{{Path: "../escape.txt"}}); err == nil {
+		t.Error("expected error for path outside root")
+	}
+}
+
+func TestTeamMCPServers(t *testing.T) {
+	server := func(tools ...string) multiagentspec.MCPServer {
+		return multiagentspec.MCPServer{Name: "github", Transport: multiagentspec.MCPTransportSSE, URL: "https://example.com/sse", AllowedTools: tools}
+	}
+	agent := func(name string, servers ...multiagentspec.MCPServer) *multiagentspec.Agent {
+		a := multiagentspec.NewAgent(name, "")
+		a.MCPServers = servers
+		return a
+	}
+
+	servers, err := teamMCPServers([]*multiagentspec.Agent{
+		agent("a", server("create_issue"), multiagentspec.MCPServer{Name: "db", Command: "db-mcp"}),
+		agent("b", server("list_issues", "create_issue")),
+	})
+	if err != nil {
+		t.Fatalf("teamMCPServers: %v", err)
+	}
+	if len(servers) != 2 || servers[0].Name != "db" || servers[0].Transport != multiagentspec.MCPTransportStdio {
+		t.Fatalf("servers = %+v", servers)
+	}
+	if got := strings.Join(servers[1].AllowedTools, ","); got != "create_issue,list_issues" {
+		t.Errorf("merged allowed tools = %s", got)
+	}
+
+	servers, err = teamMCPServers([]*multiagentspec.Agent{agent("a", server("create_issue")), agent("b", server())})
+	if err != nil || servers[0].AllowedTools != nil {
+		t.Errorf("an agent allowing all tools should widen the server: %+v, %v", servers, err)
+	}
+
+	if _, err := teamMCPServers([]*multiagentspec.Agent{agent("a", multiagentspec.MCPServer{Name: "db"})}); err == nil || !strings.Contains(err.Error(), "agent a: mcp server db: stdio transport requires command") {
+		t.Errorf("teamMCPServers error = %v, want invalid server", err)
+	}
+}
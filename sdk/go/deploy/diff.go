@@ -0,0 +1,137 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// ChangeKind classifies how an existing file differs from generated output.
+type ChangeKind string
+
+const (
+	// ChangeAdded is a generated file that does not exist yet.
+	ChangeAdded ChangeKind = "added"
+
+	// ChangeModified is an existing file whose content differs from the
+	// generated content.
+	ChangeModified ChangeKind = "modified"
+
+	// ChangeRemoved is an existing file in a directory the generator owns
+	// that it no longer produces.
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// Change is a difference between generated files and the files on disk.
+type Change struct {
+	// Path is the slash-separated path relative to the output root.
+	Path string `json:"path"`
+
+	// Kind is how the file differs.
+	Kind ChangeKind `json:"kind"`
+}
+
+// Diff compares files with what exists under root and returns the changes,
+// sorted by path. Files on disk count as removed only when they sit directly
+// in a directory the generator owns: one below root that holds generated
+// files not marked Shared. Unrelated files elsewhere in the tree, in root
+// itself, or next to shared files such as a project's .mcp.json are not
+// reported.
+func Diff(root string, files []File) ([]Change, error) {
+	generated := make(map[string]bool, len(files))
+	dirs := make(map[string]bool)
+	var changes []Change
+	for _, f := range files {
+		p := path.Clean(f.Path)
+		generated[p] = true
+		if dir := path.Dir(p); !f.Shared && dir != "." {
+			dirs[dir] = true
+		}
+
+		existing, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			changes = append(changes, Change{Path: p, Kind: ChangeAdded})
+		case err != nil:
+			return nil, fmt.Errorf("read %s: %w", p, err)
+		case !bytes.Equal(existing, f.Content):
+			changes = append(changes, Change{Path: p, Kind: ChangeModified})
+		}
+	}
+
+	for dir := range dirs {
+		entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(dir)))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read directory %s: %w", dir, err)
+		}
+		for _, e := range entries {
+			p := path.Join(dir, e.Name())
+			if e.Type().IsRegular() && !generated[p] {
+				changes = append(changes, Change{Path: p, Kind: ChangeRemoved})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// Action is what writing a generated file would do.
+type Action string
+
+const (
+	// ActionCreate writes a file that does not exist yet.
+	ActionCreate Action = "create"
+
+	// ActionUpdate overwrites a file with different content.
+	ActionUpdate Action = "update"
+
+	// ActionUnchanged leaves a file whose content already matches.
+	ActionUnchanged Action = "unchanged"
+)
+
+// PlannedFile is one file in a generation plan.
+type PlannedFile struct {
+	// Path is the slash-separated path relative to the output root.
+	Path string `json:"path"`
+
+	// Action is what writing the file would do.
+	Action Action `json:"action"`
+
+	// Size is the generated content length in bytes.
+	Size int `json:"size"`
+}
+
+// Plan reports what Write(root, files) would do to each file, in file order,
+// without writing anything.
+func Plan(root string, files []File) ([]PlannedFile, error) {
+	changes, err := Diff(root, files)
+	if err != nil {
+		return nil, err
+	}
+	kinds := make(map[string]ChangeKind, len(changes))
+	for _, c := range changes {
+		kinds[c.Path] = c.Kind
+	}
+	plan := make([]PlannedFile, 0, len(files))
+	for _, f := range files {
+		p := path.Clean(f.Path)
+		action := ActionUnchanged
+		switch kinds[p] {
+		case ChangeAdded:
+			action = ActionCreate
+		case ChangeModified:
+			action = ActionUpdate
+		}
+		plan = append(plan, PlannedFile{Path: p, Action: action, Size: len(f.Content)})
+	}
+	return plan, nil
+}
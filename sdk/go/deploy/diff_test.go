@@ -0,0 +1,109 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	root := t.TempDir()
+	files := []File{
+		{Path: "agents/pm.md", Content: []byte("pm\n")},
+		{Path: "agents/qa.md", Content: []byte("qa\n")},
+		{Path: "agents/new.md", Content: []byte("new\n")},
+	}
+	if err := Write(root, files[:2]); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if changes, err := Diff(root, files[:2]); err != nil || len(changes) != 0 {
+		t.Fatalf("Diff after Write = %v, %v; want no changes", changes, err)
+	}
+
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("agents/qa.md", "qa, edited by hand\n")
+	write("agents/old.md", "old\n")
+	write("README.md", "not generated\n")
+
+	changes, err := Diff(root, files)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	want := []Change{
+		{Path: "agents/new.md", Kind: ChangeAdded},
+		{Path: "agents/old.md", Kind: ChangeRemoved},
+		{Path: "agents/qa.md", Kind: ChangeModified},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Diff = %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %v, want %v", i, changes[i], want[i])
+		}
+	}
+}
+
+func TestDiffOwnedDirs(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"deployment.json", "team.json", "agents/pm.json", ".claude/settings.local.json"} {
+		if err := os.MkdirAll(filepath.Join(root, filepath.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, name), []byte("{}\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files := []File{
+		{Path: ".mcp.json", Content: []byte("{}\n"), Shared: true},
+		{Path: "README.md", Content: []byte("readme\n")},
+		{Path: ".claude/settings.json", Content: []byte("{}\n"), Shared: true},
+		{Path: ".claude/agents/pm.md", Content: []byte("pm\n")},
+	}
+	if err := Write(root, files); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// Neither the output root nor directories holding only shared files
+	// are owned, so their other files are not removed.
+	if changes, err := Diff(root, files); err != nil || len(changes) != 0 {
+		t.Errorf("Diff = %v, %v; want no changes", changes, err)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	root := t.TempDir()
+	files := []File{
+		{Path: "out/a.txt", Content: []byte("a\n")},
+		{Path: "out/b.txt", Content: []byte("b\n")},
+		{Path: "out/c.txt", Content: []byte("c\n")},
+	}
+	if err := Write(root, []File{files[0], {Path: "out/b.txt", Content: []byte("old\n")}}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	plan, err := Plan(root, files)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	want := []PlannedFile{
+		{Path: "out/a.txt", Action: ActionUnchanged, Size: 2},
+		{Path: "out/b.txt", Action: ActionUpdate, Size: 2},
+		{Path: "out/c.txt", Action: ActionCreate, Size: 2},
+	}
+	if len(plan) != len(want) {
+		t.Fatalf("Plan = %v, want %v", plan, want)
+	}
+	for i := range want {
+		if plan[i] != want[i] {
+			t.Errorf("plan[%d] = %v, want %v", i, plan[i], want[i])
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "out", "c.txt")); err == nil {
+		t.Error("Plan wrote a file")
+	}
+}
@@ -0,0 +1,211 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// DefaultGeminiCLIConfigDir is the config directory used when neither
+// GeminiCLIConfig.ConfigDir nor Target.Output is set.
+const DefaultGeminiCLIConfigDir = ".gemini"
+
+func init() {
+	Register(GeminiCLIGenerator{})
+}
+
+// GeminiCLIGenerator writes a Gemini CLI config directory: settings.json
+// with the default model, auto-approved tools, and agents' MCP servers, and
+// one markdown agent
+// file per agent under agents/ carrying its system prompt and tool
+// allowlist. Canonical model names are resolved with Target.ResolveModel and
+// tool names are mapped with Target.MapTool.
+type GeminiCLIGenerator struct{}
+
+// Platform returns PlatformGeminiCLI.
+func (GeminiCLIGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformGeminiCLI
+}
+
+// geminiFrontmatter is the YAML frontmatter of a Gemini CLI agent file.
+type geminiFrontmatter struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Model       string   `yaml:"model,omitempty"`
+	Tools       []string `yaml:"tools,omitempty,flow"`
+}
+
+// geminiSettings is the subset of .gemini/settings.json written by the
+// generator.
+type geminiSettings struct {
+	Model        *geminiModelSettings       `json:"model,omitempty"`
+	Tools        *geminiToolSettings        `json:"tools,omitempty"`
+	MCPServers   map[string]geminiMCPServer `json:"mcpServers,omitempty"`
+	Experimental geminiExperimental         `json:"experimental"`
+}
+
+// geminiMCPServer is a settings.json MCP server entry. Gemini CLI reads
+// url as an SSE endpoint and httpUrl as a streamable HTTP endpoint.
+type geminiMCPServer struct {
+	Command      string   `json:"command,omitempty"`
+	Args         []string `json:"args,omitempty"`
+	URL          string   `json:"url,omitempty"`
+	HTTPURL      string   `json:"httpUrl,omitempty"`
+	IncludeTools []string `json:"includeTools,omitempty"`
+}
+
+type geminiModelSettings struct {
+	Name string `json:"name"`
+}
+
+type geminiToolSettings struct {
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+type geminiExperimental struct {
+	EnableAgents bool `json:"enableAgents"`
+}
+
+// Generate returns settings.json and the agent files.
+func (GeminiCLIGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.GeminiCLIConfig{}
+	if target.GeminiCLI != nil {
+		cfg = *target.GeminiCLI
+	}
+	if cfg.ConfigDir == "" {
+		cfg.ConfigDir = target.Output
+	}
+	if cfg.ConfigDir == "" {
+		cfg.ConfigDir = DefaultGeminiCLIConfigDir
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	dir := path.Clean(strings.ReplaceAll(cfg.ConfigDir, "\\", "/"))
+	settings := geminiSettings{Experimental: geminiExperimental{EnableAgents: true}}
+	if cfg.Model != "" {
+		settings.Model = &geminiModelSettings{Name: target.ResolveModel(multiagentspec.Model(cfg.Model))}
+	}
+
+	files := make([]File, 0, len(agents)+1)
+	var allowed []string
+	for _, a := range agents {
+		content, err := geminiAgentMarkdown(a, target)
+		if err != nil {
+			return nil, fmt.Errorf("gemini-cli: agent %s: %w", a.QualifiedName(), err)
+		}
+		files = append(files, File{
+			Path:    path.Join(dir, "agents", a.Namespace, a.Name+".md"),
+			Content: content,
+		})
+		allowedTools, err := geminiTools(a.AllowedTools, target)
+		if err != nil {
+			return nil, fmt.Errorf("gemini-cli: agent %s: %w", a.QualifiedName(), err)
+		}
+		allowed = appendUnique(allowed, allowedTools...)
+	}
+	if len(allowed) > 0 {
+		settings.Tools = &geminiToolSettings{Allowed: allowed}
+	}
+
+	servers, err := teamMCPServers(agents)
+	if err != nil {
+		return nil, fmt.Errorf("gemini-cli: %w", err)
+	}
+	if len(servers) > 0 {
+		settings.MCPServers = make(map[string]geminiMCPServer, len(servers))
+	}
+	for _, srv := range servers {
+		entry := geminiMCPServer{Command: srv.Command, Args: srv.Args, IncludeTools: srv.AllowedTools}
+		if srv.Transport == multiagentspec.MCPTransportHTTP {
+			entry.HTTPURL = srv.URL
+		} else {
+			entry.URL = srv.URL
+		}
+		settings.MCPServers[srv.Name] = entry
+	}
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("gemini-cli: marshal settings: %w", err)
+	}
+	files = append(files, File{
+		Path:    path.Join(dir, "settings.json"),
+		Content: append(data, '\n'),
+		Shared:  true,
+	})
+	return files, nil
+}
+
+// geminiAgentMarkdown renders an agent as a Gemini CLI agent file, with its
+// model resolved for target.
+func geminiAgentMarkdown(a *multiagentspec.Agent, target *multiagentspec.Target) ([]byte, error) {
+	tools, err := geminiTools(a.Tools, target)
+	if err != nil {
+		return nil, err
+	}
+	fm := geminiFrontmatter{
+		Name:        a.Name,
+		Description: a.Description,
+		Tools:       tools,
+	}
+	if a.Model != "" {
+		fm.Model = target.ResolveModel(a.Model)
+	}
+
+	header, err := yaml.Marshal(fm)
+	if err != nil {
+		return nil, fmt.Errorf("marshal frontmatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n")
+	if prompt := a.PromptInstructions(multiagentspec.ExampleFormatMarkdown); prompt != "" {
+		buf.WriteString("\n")
+		buf.WriteString(prompt)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// geminiTools maps canonical tool names to Gemini CLI names, dropping
+// duplicates that map to the same built-in.
+func geminiTools(tools []string, target *multiagentspec.Target) ([]string, error) {
+	var mapped []string
+	for _, t := range tools {
+		name, err := target.MapTool(multiagentspec.Tool(t))
+		if err != nil {
+			return nil, err
+		}
+		mapped = appendUnique(mapped, name)
+	}
+	return mapped, nil
+}
+
+// appendUnique appends the values not already present in list.
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, have := range list {
+			if have == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,96 @@
+package deploy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestGeminiCLIGenerator(t *testing.T) {
+	p := testProject()
+	p.Agents[0].WithModel(multiagentspec.ModelHaiku)
+	p.Agents[0].AllowedTools = []string{"Read"}
+
+	target := &multiagentspec.Target{
+		Name:      "gemini",
+		Platform:  multiagentspec.PlatformGeminiCLI,
+		GeminiCLI: &multiagentspec.GeminiCLIConfig{Model: "sonnet"},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	if len(got) != 3 {
+		t.Fatalf("got %d files, want 3", len(got))
+	}
+
+	qa := got[".gemini/agents/shared/qa.md"]
+	for _, want := range []string{"name: qa\n", "model: gemini-2.5-flash\n", "tools: [read_file, run_shell_command]\n"} {
+		if !strings.Contains(qa, want) {
+			t.Errorf("qa.md missing %q:\n%s", want, qa)
+		}
+	}
+	if pm := got[".gemini/agents/pm.md"]; !strings.HasSuffix(pm, "---\n\nReview the plan.\n") {
+		t.Errorf("pm.md body = %q", pm)
+	}
+
+	var settings struct {
+		Model struct {
+			Name string `json:"name"`
+		} `json:"model"`
+		Tools struct {
+			Allowed []string `json:"allowed"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal([]byte(got[".gemini/settings.json"]), &settings); err != nil {
+		t.Fatalf("settings.json: %v", err)
+	}
+	if settings.Model.Name != "gemini-2.5-pro" {
+		t.Errorf("model = %q", settings.Model.Name)
+	}
+	if strings.Join(settings.Tools.Allowed, ",") != "read_file" {
+		t.Errorf("allowed tools = %v", settings.Tools.Allowed)
+	}
+}
+
+func TestGeminiCLIGeneratorMCPServers(t *testing.T) {
+	p := testProject()
+	p.Agents[0].MCPServers = []multiagentspec.MCPServer{{Name: "github", Transport: multiagentspec.MCPTransportHTTP, URL: "https://api.githubcopilot.com/mcp/", AllowedTools: []string{"create_issue"}}}
+	p.Agents[1].MCPServers = []multiagentspec.MCPServer{{Name: "db", Command: "npx", Args: []string{"-y", "db-mcp"}}}
+
+	files, err := Generate(p, &multiagentspec.Target{Name: "gemini", Platform: multiagentspec.PlatformGeminiCLI})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	var settings struct {
+		MCPServers map[string]geminiMCPServer `json:"mcpServers"`
+	}
+	if err := json.Unmarshal([]byte(filesByPath(files)[".gemini/settings.json"]), &settings); err != nil {
+		t.Fatalf("settings.json: %v", err)
+	}
+	github, db := settings.MCPServers["github"], settings.MCPServers["db"]
+	if github.HTTPURL != "https://api.githubcopilot.com/mcp/" || github.URL != "" || strings.Join(github.IncludeTools, ",") != "create_issue" {
+		t.Errorf("github server = %+v", github)
+	}
+	if db.Command != "npx" || strings.Join(db.Args, " ") != "-y db-mcp" || db.IncludeTools != nil {
+		t.Errorf("db server = %+v", db)
+	}
+}
+
+func TestGeminiCLIGeneratorConfigDir(t *testing.T) {
+	target := &multiagentspec.Target{Name: "gemini", Platform: multiagentspec.PlatformGeminiCLI, Output: "out/.gemini"}
+	files, err := Generate(testProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	settings := filesByPath(files)["out/.gemini/settings.json"]
+	if settings == "" {
+		t.Fatal("settings.json not written under Target.Output")
+	}
+	if strings.Contains(settings, `"model"`) {
+		t.Errorf("settings without a configured model should omit it:\n%s", settings)
+	}
+}
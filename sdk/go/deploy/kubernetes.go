@@ -0,0 +1,647 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Kubernetes defaults applied when KubernetesConfig leaves a field empty.
+const (
+	DefaultKubernetesNamespace = "default"
+	DefaultKubernetesOutput    = "k8s"
+	DefaultAgentPort           = 8080
+)
+
+// External Secrets Operator stores that remote secret references are read
+// through. The cluster must define a ClusterSecretStore with each name used.
+const (
+	DefaultAWSSecretStore   = "aws-secrets-manager"
+	DefaultVaultSecretStore = "vault"
+)
+
+func init() {
+	for _, p := range []multiagentspec.Platform{
+		multiagentspec.PlatformKubernetes,
+		multiagentspec.PlatformAWSEKS,
+		multiagentspec.PlatformAzureAKS,
+		multiagentspec.PlatformGCPGKE,
+	} {
+		Register(KubernetesGenerator{For: p})
+	}
+}
+
+// KubernetesGenerator emits a ConfigMap, Deployment, and Service per agent.
+// With HelmChart set it emits a Helm chart instead, with the agents listed
+// in values.yaml. The same generator serves kubernetes, aws-eks, azure-aks,
+// and gcp-gke targets.
+type KubernetesGenerator struct {
+	For multiagentspec.Platform
+}
+
+// Platform returns the platform this generator is registered for.
+func (g KubernetesGenerator) Platform() multiagentspec.Platform {
+	return g.For
+}
+
+// SecretSources returns every source: env and file secrets are read from a
+// Secret the operator creates, and aws-sm and vault secrets are synced into
+// Secrets by External Secrets Operator.
+func (KubernetesGenerator) SecretSources() []multiagentspec.SecretSource {
+	return multiagentspec.SecretSources()
+}
+
+// k8sAgent is the per-agent data shared by plain manifests and Helm values.
+// Spec is the agent rendered as markdown with frontmatter.
+type k8sAgent struct {
+	Name  string `yaml:"name"`
+	Model string `yaml:"model,omitempty"`
+	Spec  string `yaml:"spec"`
+}
+
+// Generate returns plain manifests or a Helm chart under Target.Output.
+func (g KubernetesGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.KubernetesConfig{}
+	if target.Kubernetes != nil {
+		cfg = *target.Kubernetes
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = DefaultKubernetesNamespace
+	}
+	out := target.Output
+	if out == "" {
+		out = DefaultKubernetesOutput
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+
+	team := teamName(project)
+	secretEnv := k8sSecretEnv(team, target.Secrets)
+	k8sAgents := make([]k8sAgent, 0, len(agents))
+	for _, a := range agents {
+		spec, err := claudeAgentMarkdown(a, target)
+		if err != nil {
+			return nil, fmt.Errorf("%s: agent %s: %w", g.For, a.QualifiedName(), err)
+		}
+		k8sAgents = append(k8sAgents, k8sAgent{
+			Name:  k8sName(a.QualifiedName()),
+			Model: target.ResolveModel(a.Model),
+			Spec:  string(spec),
+		})
+	}
+
+	if cfg.HelmChart {
+		return helmChart(path.Join(out, team), team, teamVersion(project), cfg, k8sAgents, target.Secrets)
+	}
+
+	files := []File{}
+	ns, err := marshalYAML(k8sObject{APIVersion: "v1", Kind: "Namespace", Metadata: k8sMeta{Name: cfg.Namespace}})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, File{Path: path.Join(out, "namespace.yaml"), Content: ns})
+
+	if len(target.Secrets) > 0 {
+		content, err := k8sSecretManifests(team, cfg.Namespace, target.Secrets)
+		if err != nil {
+			return nil, fmt.Errorf("%s: secrets: %w", g.For, err)
+		}
+		files = append(files, File{Path: path.Join(out, "secrets.yaml"), Content: content})
+	}
+
+	for _, a := range k8sAgents {
+		content, err := agentManifests(team, teamVersion(project), cfg, a, secretEnv)
+		if err != nil {
+			return nil, fmt.Errorf("%s: agent %s: %w", g.For, a.Name, err)
+		}
+		files = append(files, File{Path: path.Join(out, a.Name+".yaml"), Content: content})
+	}
+	return files, nil
+}
+
+// Manifest types cover only the fields the generator emits.
+
+type k8sMeta struct {
+	Name      string            `yaml:"name,omitempty"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+type k8sObject struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   k8sMeta           `yaml:"metadata"`
+	Data       map[string]string `yaml:"data,omitempty"`
+	Spec       interface{}       `yaml:"spec,omitempty"`
+}
+
+type k8sDeploymentSpec struct {
+	Replicas int            `yaml:"replicas"`
+	Selector k8sSelector    `yaml:"selector"`
+	Template k8sPodTemplate `yaml:"template"`
+}
+
+type k8sSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type k8sPodTemplate struct {
+	Metadata k8sMeta    `yaml:"metadata"`
+	Spec     k8sPodSpec `yaml:"spec"`
+}
+
+type k8sPodSpec struct {
+	Containers []k8sContainer `yaml:"containers"`
+	Volumes    []k8sVolume    `yaml:"volumes"`
+}
+
+type k8sContainer struct {
+	Name         string           `yaml:"name"`
+	Image        string           `yaml:"image"`
+	Ports        []k8sPort        `yaml:"ports"`
+	Env          []k8sEnv         `yaml:"env"`
+	Resources    *k8sResources    `yaml:"resources,omitempty"`
+	VolumeMounts []k8sVolumeMount `yaml:"volumeMounts"`
+}
+
+type k8sPort struct {
+	Name          string `yaml:"name,omitempty"`
+	ContainerPort int    `yaml:"containerPort,omitempty"`
+	Port          int    `yaml:"port,omitempty"`
+	TargetPort    string `yaml:"targetPort,omitempty"`
+}
+
+type k8sEnv struct {
+	Name      string        `yaml:"name"`
+	Value     string        `yaml:"value,omitempty"`
+	ValueFrom *k8sEnvSource `yaml:"valueFrom,omitempty"`
+}
+
+type k8sEnvSource struct {
+	SecretKeyRef k8sSecretKeyRef `yaml:"secretKeyRef"`
+}
+
+type k8sSecretKeyRef struct {
+	Name string `yaml:"name"`
+	Key  string `yaml:"key"`
+}
+
+type k8sResources struct {
+	Limits map[string]string `yaml:"limits"`
+}
+
+type k8sVolume struct {
+	Name      string          `yaml:"name"`
+	ConfigMap k8sConfigMapRef `yaml:"configMap"`
+}
+
+type k8sConfigMapRef struct {
+	Name string `yaml:"name"`
+}
+
+type k8sVolumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+type k8sServiceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []k8sPort         `yaml:"ports"`
+}
+
+// agentManifests renders the ConfigMap, Deployment, and Service for one agent.
+func agentManifests(team, version string, cfg multiagentspec.KubernetesConfig, a k8sAgent, secretEnv []k8sEnv) ([]byte, error) {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       a.Name,
+		"app.kubernetes.io/part-of":    team,
+		"app.kubernetes.io/managed-by": "mas",
+	}
+	selector := map[string]string{"app.kubernetes.io/name": a.Name}
+	meta := k8sMeta{Name: a.Name, Namespace: cfg.Namespace, Labels: labels}
+
+	configMap := k8sObject{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Metadata:   meta,
+		Data:       map[string]string{"agent.md": a.Spec},
+	}
+
+	container := k8sContainer{
+		Name:  a.Name,
+		Image: k8sImage(cfg.ImageRegistry, a.Name, version),
+		Ports: []k8sPort{{Name: "http", ContainerPort: DefaultAgentPort}},
+		Env: append([]k8sEnv{
+			{Name: "AGENT_NAME", Value: a.Name},
+			{Name: "AGENT_MODEL", Value: a.Model},
+			{Name: "AGENT_SPEC", Value: "/etc/agent/agent.md"},
+		}, secretEnv...),
+		Resources:    k8sResourceLimits(cfg.ResourceLimits),
+		VolumeMounts: []k8sVolumeMount{{Name: "agent-spec", MountPath: "/etc/agent", ReadOnly: true}},
+	}
+	deployment := k8sObject{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata:   meta,
+		Spec: k8sDeploymentSpec{
+			Replicas: 1,
+			Selector: k8sSelector{MatchLabels: selector},
+			Template: k8sPodTemplate{
+				Metadata: k8sMeta{Labels: labels},
+				Spec: k8sPodSpec{
+					Containers: []k8sContainer{container},
+					Volumes:    []k8sVolume{{Name: "agent-spec", ConfigMap: k8sConfigMapRef{Name: a.Name}}},
+				},
+			},
+		},
+	}
+
+	service := k8sObject{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   meta,
+		Spec: k8sServiceSpec{
+			Selector: selector,
+			Ports:    []k8sPort{{Name: "http", Port: DefaultAgentPort, TargetPort: "http"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	for i, obj := range []k8sObject{configMap, deployment, service} {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+		data, err := marshalYAML(obj)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// helmValues is the generated values.yaml of the team chart.
+type helmValues struct {
+	Namespace string        `yaml:"namespace"`
+	Image     helmImage     `yaml:"image"`
+	Service   helmService   `yaml:"service"`
+	Resources *k8sResources `yaml:"resources,omitempty"`
+	Secrets   []helmSecret  `yaml:"secrets,omitempty"`
+	Agents    []k8sAgent    `yaml:"agents"`
+}
+
+// helmSecret is an environment variable read from a key of the same name
+// in a Secret.
+type helmSecret struct {
+	Name       string `yaml:"name"`
+	SecretName string `yaml:"secretName"`
+}
+
+type helmImage struct {
+	Registry   string `yaml:"registry"`
+	Tag        string `yaml:"tag"`
+	PullPolicy string `yaml:"pullPolicy"`
+}
+
+type helmService struct {
+	Port int `yaml:"port"`
+}
+
+// helmChart renders a chart whose templates range over .Values.agents.
+func helmChart(dir, team, version string, cfg multiagentspec.KubernetesConfig, agents []k8sAgent, secrets map[string]multiagentspec.SecretRef) ([]File, error) {
+	chart := fmt.Sprintf(`apiVersion: v2
+name: %s
+description: Multi-agent team %s
+type: application
+version: %s
+appVersion: %s
+`, team, team, helmChartVersion(version), strconv.Quote(version))
+
+	vals := helmValues{
+		Namespace: cfg.Namespace,
+		Image:     helmImage{Registry: cfg.ImageRegistry, Tag: version, PullPolicy: "IfNotPresent"},
+		Service:   helmService{Port: DefaultAgentPort},
+		Resources: k8sResourceLimits(cfg.ResourceLimits),
+		Agents:    agents,
+	}
+	for _, e := range k8sSecretEnv(team, secrets) {
+		vals.Secrets = append(vals.Secrets, helmSecret{Name: e.Name, SecretName: e.ValueFrom.SecretKeyRef.Name})
+	}
+	values, err := marshalYAML(vals)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []File{
+		{Path: path.Join(dir, "Chart.yaml"), Content: []byte(chart)},
+		{Path: path.Join(dir, "values.yaml"), Content: values},
+		{Path: path.Join(dir, "templates", "_helpers.tpl"), Content: []byte(helmHelpers)},
+		{Path: path.Join(dir, "templates", "agents.yaml"), Content: []byte(helmAgentsTemplate)},
+	}
+	if len(secrets) > 0 {
+		content, err := k8sSecretManifests(team, "{{ .Values.namespace }}", secrets)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(dir, "templates", "secrets.yaml"), Content: content})
+	}
+	return files, nil
+}
+
+const helmHelpers = `{{- define "team.labels" -}}
+app.kubernetes.io/part-of: {{ .Chart.Name }}
+app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- end }}
+
+{{- define "team.image" -}}
+{{- if .root.Values.image.registry -}}
+{{ .root.Values.image.registry }}/{{ .agent.name }}:{{ .root.Values.image.tag }}
+{{- else -}}
+{{ .agent.name }}:{{ .root.Values.image.tag }}
+{{- end -}}
+{{- end }}
+`
+
+const helmAgentsTemplate = `{{- range $agent := .Values.agents }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ $agent.name }}
+  namespace: {{ $.Values.namespace }}
+  labels:
+    app.kubernetes.io/name: {{ $agent.name }}
+    {{- include "team.labels" $ | nindent 4 }}
+data:
+  agent.md: |
+    {{- $agent.spec | nindent 4 }}
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ $agent.name }}
+  namespace: {{ $.Values.namespace }}
+  labels:
+    app.kubernetes.io/name: {{ $agent.name }}
+    {{- include "team.labels" $ | nindent 4 }}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: {{ $agent.name }}
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: {{ $agent.name }}
+        {{- include "team.labels" $ | nindent 8 }}
+    spec:
+      containers:
+        - name: {{ $agent.name }}
+          image: {{ include "team.image" (dict "root" $ "agent" $agent) }}
+          imagePullPolicy: {{ $.Values.image.pullPolicy }}
+          ports:
+            - name: http
+              containerPort: {{ $.Values.service.port }}
+          env:
+            - name: AGENT_NAME
+              value: {{ $agent.name | quote }}
+            - name: AGENT_MODEL
+              value: {{ $agent.model | default "" | quote }}
+            - name: AGENT_SPEC
+              value: /etc/agent/agent.md
+            {{- range $.Values.secrets }}
+            - name: {{ .name }}
+              valueFrom:
+                secretKeyRef:
+                  name: {{ .secretName }}
+                  key: {{ .name }}
+            {{- end }}
+          {{- with $.Values.resources }}
+          resources:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          volumeMounts:
+            - name: agent-spec
+              mountPath: /etc/agent
+              readOnly: true
+      volumes:
+        - name: agent-spec
+          configMap:
+            name: {{ $agent.name }}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: {{ $agent.name }}
+  namespace: {{ $.Values.namespace }}
+  labels:
+    app.kubernetes.io/name: {{ $agent.name }}
+    {{- include "team.labels" $ | nindent 4 }}
+spec:
+  selector:
+    app.kubernetes.io/name: {{ $agent.name }}
+  ports:
+    - name: http
+      port: {{ $.Values.service.port }}
+      targetPort: http
+{{- end }}
+`
+
+// k8sSecretName returns the Secret holding a team's secrets from source.
+// env and file secrets share one Secret created by the operator; each remote
+// source gets its own Secret managed by an ExternalSecret.
+func k8sSecretName(team string, source multiagentspec.SecretSource) string {
+	switch source {
+	case multiagentspec.SecretSourceAWSSecretsManager, multiagentspec.SecretSourceVault:
+		return k8sName(team) + "-" + string(source)
+	default:
+		return k8sName(team) + "-secrets"
+	}
+}
+
+// k8sSecretEnv returns container environment variables reading each secret
+// from its Secret, sorted by name.
+func k8sSecretEnv(team string, secrets map[string]multiagentspec.SecretRef) []k8sEnv {
+	env := make([]k8sEnv, 0, len(secrets))
+	for _, name := range sortedSecretNames(secrets) {
+		env = append(env, k8sEnv{
+			Name: name,
+			ValueFrom: &k8sEnvSource{SecretKeyRef: k8sSecretKeyRef{
+				Name: k8sSecretName(team, secrets[name].Source),
+				Key:  name,
+			}},
+		})
+	}
+	return env
+}
+
+type k8sExternalSecretSpec struct {
+	RefreshInterval string                 `yaml:"refreshInterval"`
+	SecretStoreRef  k8sSecretStoreRef      `yaml:"secretStoreRef"`
+	Target          k8sConfigMapRef        `yaml:"target"`
+	Data            []k8sExternalSecretKey `yaml:"data"`
+}
+
+type k8sSecretStoreRef struct {
+	Kind string `yaml:"kind"`
+	Name string `yaml:"name"`
+}
+
+type k8sExternalSecretKey struct {
+	SecretKey string            `yaml:"secretKey"`
+	RemoteRef k8sRemoteRefValue `yaml:"remoteRef"`
+}
+
+type k8sRemoteRefValue struct {
+	Key      string `yaml:"key"`
+	Property string `yaml:"property,omitempty"`
+}
+
+// k8sSecretManifests renders an ExternalSecret per remote source, preceded
+// by the kubectl command that creates the Secret for env and file secrets.
+// Secret values never appear in the output.
+func k8sSecretManifests(team, namespace string, secrets map[string]multiagentspec.SecretRef) ([]byte, error) {
+	stores := map[multiagentspec.SecretSource]string{
+		multiagentspec.SecretSourceAWSSecretsManager: DefaultAWSSecretStore,
+		multiagentspec.SecretSourceVault:             DefaultVaultSecretStore,
+	}
+	remote := make(map[multiagentspec.SecretSource][]k8sExternalSecretKey)
+	var local []string
+	for _, e := range k8sSecretEnv(team, secrets) {
+		ref := secrets[e.Name]
+		switch ref.Source {
+		case multiagentspec.SecretSourceEnv:
+			local = append(local, fmt.Sprintf("--from-literal=%s=\"$%s\"", e.Name, ref.Key))
+		case multiagentspec.SecretSourceFile:
+			local = append(local, fmt.Sprintf("--from-file=%s=%s", e.Name, ref.Key))
+		default:
+			remote[ref.Source] = append(remote[ref.Source], k8sExternalSecretKey{
+				SecretKey: e.Name,
+				RemoteRef: k8sRemoteRefValue{Key: ref.Path(), Property: ref.Property()},
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	if len(local) > 0 {
+		ns := namespace
+		if strings.Contains(ns, "{{") {
+			ns = "<namespace>"
+		}
+		fmt.Fprintf(&buf, "# Create the %s Secret before deploying:\n#\n#   kubectl create secret generic %s -n %s \\\n#     %s\n",
+			k8sSecretName(team, multiagentspec.SecretSourceEnv), k8sSecretName(team, multiagentspec.SecretSourceEnv), ns,
+			strings.Join(local, " \\\n#     "))
+	}
+	for _, source := range []multiagentspec.SecretSource{multiagentspec.SecretSourceAWSSecretsManager, multiagentspec.SecretSourceVault} {
+		if len(remote[source]) == 0 {
+			continue
+		}
+		name := k8sSecretName(team, source)
+		data, err := marshalYAML(k8sObject{
+			APIVersion: "external-secrets.io/v1beta1",
+			Kind:       "ExternalSecret",
+			Metadata:   k8sMeta{Name: name, Namespace: namespace},
+			Spec: k8sExternalSecretSpec{
+				RefreshInterval: "1h",
+				SecretStoreRef:  k8sSecretStoreRef{Kind: "ClusterSecretStore", Name: stores[source]},
+				Target:          k8sConfigMapRef{Name: name},
+				Data:            remote[source],
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+func k8sResourceLimits(r *multiagentspec.ResourceLimits) *k8sResources {
+	if r == nil {
+		return nil
+	}
+	limits := map[string]string{}
+	if r.CPU != "" {
+		limits["cpu"] = r.CPU
+	}
+	if r.Memory != "" {
+		limits["memory"] = r.Memory
+	}
+	if r.GPU > 0 {
+		limits["nvidia.com/gpu"] = strconv.Itoa(r.GPU)
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+	return &k8sResources{Limits: limits}
+}
+
+func k8sImage(registry, name, tag string) string {
+	image := name + ":" + tag
+	if registry != "" {
+		image = strings.TrimSuffix(registry, "/") + "/" + image
+	}
+	return image
+}
+
+// k8sName converts a qualified agent name to a DNS-1123 resource name.
+func k8sName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "/", "-")
+}
+
+// helmChartVersion returns version if it is a plain semver, else 0.1.0.
+func helmChartVersion(version string) string {
+	v := strings.TrimPrefix(version, "v")
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return "0.1.0"
+	}
+	for _, p := range parts {
+		if _, err := strconv.Atoi(p); err != nil {
+			return "0.1.0"
+		}
+	}
+	return v
+}
+
+// teamName returns the team name, falling back to the deployment's team reference.
+func teamName(project *Project) string {
+	if project.Team != nil && project.Team.Name != "" {
+		return project.Team.Name
+	}
+	if project.Deployment != nil && project.Deployment.Team != "" {
+		return project.Deployment.Team
+	}
+	return "team"
+}
+
+// teamVersion returns the team version used for image tags, or "latest".
+func teamVersion(project *Project) string {
+	if project.Team != nil && project.Team.Version != "" {
+		return project.Team.Version
+	}
+	return "latest"
+}
+
+func marshalYAML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, fmt.Errorf("marshal yaml: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("marshal yaml: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,212 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestKubernetesGeneratorManifests(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "eks",
+		Platform: multiagentspec.PlatformAWSEKS,
+		Output:   "deploy/k8s",
+		Kubernetes: &multiagentspec.KubernetesConfig{
+			Namespace:      "agents",
+			ImageRegistry:  "ghcr.io/acme/",
+			ResourceLimits: &multiagentspec.ResourceLimits{CPU: "500m", Memory: "512Mi", GPU: 1},
+		},
+	}
+	files, err := Generate(testProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	if got := strings.Join(paths, ","); got != "deploy/k8s/namespace.yaml,deploy/k8s/pm.yaml,deploy/k8s/shared-qa.yaml" {
+		t.Errorf("paths = %s", got)
+	}
+
+	var kinds []string
+	dec := yaml.NewDecoder(bytes.NewReader(files[1].Content))
+	for {
+		var doc struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if err := dec.Decode(&doc); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("decode manifest: %v", err)
+		}
+		if doc.Metadata.Namespace != "agents" {
+			t.Errorf("%s namespace = %q", doc.Kind, doc.Metadata.Namespace)
+		}
+		kinds = append(kinds, doc.Kind)
+	}
+	if got := strings.Join(kinds, ","); got != "ConfigMap,Deployment,Service" {
+		t.Errorf("kinds = %s", got)
+	}
+
+	for _, want := range []string{"image: ghcr.io/acme/pm:1.0.0", "cpu: 500m", "memory: 512Mi", "nvidia.com/gpu: \"1\""} {
+		if !strings.Contains(string(files[1].Content), want) {
+			t.Errorf("pm.yaml missing %q:\n%s", want, files[1].Content)
+		}
+	}
+}
+
+func TestKubernetesGeneratorHelm(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:       "helm",
+		Platform:   multiagentspec.PlatformKubernetes,
+		Output:     "helm",
+		Kubernetes: &multiagentspec.KubernetesConfig{Namespace: "agents", HelmChart: true},
+	}
+	files, err := Generate(testProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	byPath := make(map[string]string)
+	for _, f := range files {
+		byPath[f.Path] = string(f.Content)
+	}
+	for _, p := range []string{"helm/release-team/Chart.yaml", "helm/release-team/values.yaml", "helm/release-team/templates/agents.yaml"} {
+		if _, ok := byPath[p]; !ok {
+			t.Errorf("missing %s", p)
+		}
+	}
+
+	var values struct {
+		Namespace string `yaml:"namespace"`
+		Agents    []struct {
+			Name string `yaml:"name"`
+			Spec string `yaml:"spec"`
+		} `yaml:"agents"`
+	}
+	if err := yaml.Unmarshal([]byte(byPath["helm/release-team/values.yaml"]), &values); err != nil {
+		t.Fatalf("parse values.yaml: %v", err)
+	}
+	if values.Namespace != "agents" || len(values.Agents) != 2 || values.Agents[1].Name != "shared-qa" {
+		t.Errorf("values = %+v", values)
+	}
+	if !strings.Contains(byPath["helm/release-team/Chart.yaml"], "version: 1.0.0") {
+		t.Errorf("Chart.yaml:\n%s", byPath["helm/release-team/Chart.yaml"])
+	}
+}
+
+func TestKubernetesGeneratorSecrets(t *testing.T) {
+	secrets := map[string]multiagentspec.SecretRef{
+		"ANTHROPIC_API_KEY": {Source: multiagentspec.SecretSourceEnv, Key: "ANTHROPIC_API_KEY"},
+		"TLS_CERT":          {Source: multiagentspec.SecretSourceFile, Key: "certs/tls.pem"},
+		"OPENAI_API_KEY":    {Source: multiagentspec.SecretSourceAWSSecretsManager, Key: "prod/openai#api_key"},
+		"DB_PASSWORD":       {Source: multiagentspec.SecretSourceVault, Key: "secret/data/db#password"},
+	}
+	target := &multiagentspec.Target{
+		Name:       "k8s",
+		Platform:   multiagentspec.PlatformKubernetes,
+		Kubernetes: &multiagentspec.KubernetesConfig{Namespace: "agents"},
+		Secrets:    secrets,
+	}
+	files, err := Generate(testProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+
+	manifest := got["k8s/secrets.yaml"]
+	for _, want := range []string{
+		"kubectl create secret generic release-team-secrets -n agents",
+		`--from-literal=ANTHROPIC_API_KEY="$ANTHROPIC_API_KEY"`,
+		"--from-file=TLS_CERT=certs/tls.pem",
+		"kind: ExternalSecret",
+		"name: release-team-aws-sm",
+		"name: aws-secrets-manager",
+		"key: prod/openai\n",
+		"property: api_key",
+		"name: release-team-vault",
+		"key: secret/data/db\n",
+	} {
+		if !strings.Contains(manifest, want) {
+			t.Errorf("secrets.yaml missing %q:\n%s", want, manifest)
+		}
+	}
+
+	var deployment struct {
+		Kind string `yaml:"kind"`
+		Spec struct {
+			Template struct {
+				Spec struct {
+					Containers []struct {
+						Env []struct {
+							Name      string `yaml:"name"`
+							ValueFrom *struct {
+								SecretKeyRef struct {
+									Name string `yaml:"name"`
+									Key  string `yaml:"key"`
+								} `yaml:"secretKeyRef"`
+							} `yaml:"valueFrom"`
+						} `yaml:"env"`
+					} `yaml:"containers"`
+				} `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+	}
+	dec := yaml.NewDecoder(strings.NewReader(got["k8s/pm.yaml"]))
+	for deployment.Kind != "Deployment" {
+		if err := dec.Decode(&deployment); err != nil {
+			t.Fatalf("decode manifest: %v", err)
+		}
+	}
+	refs := map[string]string{}
+	for _, e := range deployment.Spec.Template.Spec.Containers[0].Env {
+		if e.ValueFrom != nil {
+			refs[e.Name] = e.ValueFrom.SecretKeyRef.Name + "/" + e.ValueFrom.SecretKeyRef.Key
+		}
+	}
+	for name, want := range map[string]string{
+		"ANTHROPIC_API_KEY": "release-team-secrets/ANTHROPIC_API_KEY",
+		"TLS_CERT":          "release-team-secrets/TLS_CERT",
+		"OPENAI_API_KEY":    "release-team-aws-sm/OPENAI_API_KEY",
+		"DB_PASSWORD":       "release-team-vault/DB_PASSWORD",
+	} {
+		if refs[name] != want {
+			t.Errorf("env %s = %q, want %q", name, refs[name], want)
+		}
+	}
+
+	target.Kubernetes.HelmChart = true
+	files, err = Generate(testProject(), target)
+	if err != nil {
+		t.Fatalf("Generate helm: %v", err)
+	}
+	got = filesByPath(files)
+	if !strings.Contains(got["k8s/release-team/values.yaml"], "secretName: release-team-vault") {
+		t.Errorf("values.yaml missing secrets:\n%s", got["k8s/release-team/values.yaml"])
+	}
+	if !strings.Contains(got["k8s/release-team/templates/secrets.yaml"], "{{ .Values.namespace }}") {
+		t.Error("helm secrets.yaml should use the chart namespace")
+	}
+}
+
+func TestKubernetesPlatformsRegistered(t *testing.T) {
+	for _, p := range []multiagentspec.Platform{
+		multiagentspec.PlatformKubernetes, multiagentspec.PlatformAWSEKS,
+		multiagentspec.PlatformAzureAKS, multiagentspec.PlatformGCPGKE,
+	} {
+		if _, ok := Lookup(p); !ok {
+			t.Errorf("no generator registered for %s", p)
+		}
+	}
+}
@@ -0,0 +1,533 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// LangGraph defaults applied when LangGraphConfig leaves a field empty.
+const (
+	DefaultLangGraphOutput       = "langgraph"
+	DefaultLangGraphCheckpointer = "memory"
+	DefaultLangGraphStateSchema  = "typeddict"
+)
+
+// LangGraphCheckpointEnv is the environment variable that overrides
+// LangGraphConfig.CheckpointerURI in the generated graph.
+const LangGraphCheckpointEnv = "LANGGRAPH_CHECKPOINT_URI"
+
+func init() {
+	Register(LangGraphGenerator{})
+}
+
+// LangGraphGenerator emits a LangGraph Python graph from the team's
+// deterministic workflow: one node per step, edges from DependsOn (steps
+// with several dependencies wait for all of them), and conditional edges for
+// steps with a When condition. Alongside graph.py it writes langgraph.json
+// for the LangGraph CLI and requirements.txt.
+type LangGraphGenerator struct{}
+
+// Platform returns PlatformLangGraph.
+func (LangGraphGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformLangGraph
+}
+
+type langGraphAgent struct {
+	Key          string
+	Model        string
+	Instructions string
+}
+
+type langGraphStep struct {
+	Name     string
+	Func     string
+	Agent    string
+	Inputs   map[string]string // port name -> "step.output"
+	Outputs  []string
+	Deps     []string
+	When     string // Python expression, empty when unconditional
+	Join     string // join node name for conditional steps with several deps
+	Terminal bool
+}
+
+type langGraphData struct {
+	Team            string
+	Pydantic        bool
+	Checkpointer    string
+	CheckpointerURI string
+	Agents          []langGraphAgent
+	Steps           []langGraphStep
+}
+
+// Generate returns graph.py, langgraph.json, and requirements.txt.
+func (LangGraphGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.LangGraphConfig{}
+	if target.LangGraph != nil {
+		cfg = *target.LangGraph
+	}
+	if cfg.Checkpointer == "" {
+		cfg.Checkpointer = DefaultLangGraphCheckpointer
+	}
+	if cfg.StateSchema == "" {
+		cfg.StateSchema = DefaultLangGraphStateSchema
+	}
+	switch cfg.Checkpointer {
+	case "memory", "sqlite", "postgres", "none":
+	default:
+		return nil, fmt.Errorf("langgraph: invalid checkpointer %q (want memory, sqlite, postgres, or none)", cfg.Checkpointer)
+	}
+	if cfg.Checkpointer == "sqlite" && cfg.CheckpointerURI == "" {
+		cfg.CheckpointerURI = "checkpoints.db"
+	}
+	switch cfg.StateSchema {
+	case "typeddict", "pydantic":
+	default:
+		return nil, fmt.Errorf("langgraph: invalid stateSchema %q (want typeddict or pydantic)", cfg.StateSchema)
+	}
+
+	team := project.Team
+	if team == nil || team.Workflow == nil || len(team.Workflow.Steps) == 0 {
+		return nil, fmt.Errorf("langgraph: team has no workflow steps")
+	}
+	if wt := team.Workflow.Type; wt != "" && !wt.IsDeterministic() {
+		return nil, fmt.Errorf("langgraph: workflow type %q is not deterministic", wt)
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*multiagentspec.Agent, len(agents)*2)
+	for _, a := range agents {
+		byName[a.QualifiedName()] = a
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = a
+		}
+	}
+
+	data := langGraphData{
+		Team:            team.Name,
+		Pydantic:        cfg.StateSchema == "pydantic",
+		Checkpointer:    cfg.Checkpointer,
+		CheckpointerURI: cfg.CheckpointerURI,
+	}
+
+	steps := team.Workflow.Steps
+	known := make(map[string]bool, len(steps))
+	for _, s := range steps {
+		known[s.Name] = true
+	}
+	dependents := make(map[string]bool)
+	usedAgents := make(map[string]bool)
+	for _, s := range steps {
+		a, ok := byName[s.Agent]
+		if !ok {
+			return nil, fmt.Errorf("langgraph: step %s references unknown agent %q", s.Name, s.Agent)
+		}
+		key := a.QualifiedName()
+		if !usedAgents[key] {
+			usedAgents[key] = true
+			la := langGraphAgent{Key: key, Model: cfg.Model, Instructions: a.PromptInstructions(multiagentspec.ExampleFormatMarkdown)}
+			if a.Model != "" {
+				la.Model = target.ResolveModel(a.Model)
+			}
+			if la.Model == "" {
+				la.Model = target.ResolveModel(multiagentspec.ModelSonnet)
+			}
+			data.Agents = append(data.Agents, la)
+		}
+
+		ls := langGraphStep{
+			Name:   s.Name,
+			Func:   "step_" + snakeCase(s.Name),
+			Agent:  key,
+			Inputs: make(map[string]string),
+			Deps:   s.DependsOn,
+		}
+		for _, d := range s.DependsOn {
+			if !known[d] {
+				return nil, fmt.Errorf("langgraph: step %s depends on unknown step %q", s.Name, d)
+			}
+			dependents[d] = true
+		}
+		for _, in := range s.Inputs {
+			if in.From != "" {
+				ls.Inputs[in.Name] = in.From
+			}
+		}
+		for _, out := range s.Outputs {
+			ls.Outputs = append(ls.Outputs, out.Name)
+		}
+		if s.When != "" {
+			ls.When, err = pythonCondition(s.When, known)
+			if err != nil {
+				return nil, fmt.Errorf("langgraph: step %s: %w", s.Name, err)
+			}
+			if len(s.DependsOn) > 1 {
+				ls.Join = s.Name + "__join"
+			}
+		}
+		data.Steps = append(data.Steps, ls)
+	}
+	for i := range data.Steps {
+		data.Steps[i].Terminal = !dependents[data.Steps[i].Name]
+	}
+
+	out := target.Output
+	if out == "" {
+		out = DefaultLangGraphOutput
+	}
+
+	var graph bytes.Buffer
+	if err := langGraphTemplate.Execute(&graph, data); err != nil {
+		return nil, fmt.Errorf("langgraph: render graph.py: %w", err)
+	}
+
+	cli, err := json.MarshalIndent(map[string]interface{}{
+		"dependencies": []string{"."},
+		"graphs":       map[string]string{team.Name: "./graph.py:graph"},
+		"env":          ".env",
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("langgraph: marshal langgraph.json: %w", err)
+	}
+
+	reqs := []string{"langgraph>=0.2", "langchain>=0.3", "langchain-anthropic>=0.3"}
+	switch cfg.Checkpointer {
+	case "sqlite":
+		reqs = append(reqs, "langgraph-checkpoint-sqlite>=2.0")
+	case "postgres":
+		reqs = append(reqs, "langgraph-checkpoint-postgres>=2.0", "psycopg[binary]>=3.1")
+	}
+	if data.Pydantic {
+		reqs = append(reqs, "pydantic>=2")
+	}
+
+	return []File{
+		{Path: path.Join(out, "graph.py"), Content: graph.Bytes()},
+		{Path: path.Join(out, "langgraph.json"), Content: append(cli, '\n')},
+		{Path: path.Join(out, "requirements.txt"), Content: []byte(strings.Join(reqs, "\n") + "\n")},
+	}, nil
+}
+
+// pythonCondition translates a Step.When expression into Python. Output
+// references ('step.output') become _ref(state, "step", "output") lookups.
+func pythonCondition(expr string, steps map[string]bool) (string, error) {
+	var b strings.Builder
+	keyword := func(word string) {
+		trimmed := strings.TrimRight(b.String(), " ")
+		b.Reset()
+		b.WriteString(trimmed)
+		if trimmed != "" && !strings.HasSuffix(trimmed, "(") {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+		b.WriteByte(' ')
+	}
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(expr) && expr[j] != c {
+				if expr[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(expr) {
+				return "", fmt.Errorf("when %q: unterminated string", expr)
+			}
+			b.WriteString(expr[i : j+1])
+			i = j + 1
+		case strings.HasPrefix(expr[i:], "&&"):
+			keyword("and")
+			i += 2
+		case strings.HasPrefix(expr[i:], "||"):
+			keyword("or")
+			i += 2
+		case c == '!' && !strings.HasPrefix(expr[i:], "!="):
+			keyword("not")
+			i++
+		case c == ' ' && strings.HasSuffix(b.String(), " "):
+			i++
+		case c == '_' || (c|0x20 >= 'a' && c|0x20 <= 'z'):
+			j := i + 1
+			for j < len(expr) && (expr[j] == '_' || expr[j] == '-' || expr[j] == '.' ||
+				(expr[j]|0x20 >= 'a' && expr[j]|0x20 <= 'z') || (expr[j] >= '0' && expr[j] <= '9')) {
+				j++
+			}
+			word := expr[i:j]
+			i = j
+			switch word {
+			case "true":
+				b.WriteString("True")
+				continue
+			case "false":
+				b.WriteString("False")
+				continue
+			case "null":
+				b.WriteString("None")
+				continue
+			}
+			step, output, ok := strings.Cut(word, ".")
+			if !ok || output == "" {
+				return "", fmt.Errorf("when %q: unknown name %q (reference outputs as step_name.output_name)", expr, word)
+			}
+			if !steps[step] {
+				return "", fmt.Errorf("when %q: unknown step %q", expr, step)
+			}
+			fmt.Fprintf(&b, "_ref(state, %s, %s)", pyString(step), pyString(output))
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// pyString renders s as a Python string literal.
+func pyString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+var langGraphFuncs = template.FuncMap{
+	"py": pyString,
+	// pyText renders s as a triple-quoted Python string when that is lossless.
+	"pyText": func(s string) string {
+		if s != "" && !strings.Contains(s, `"""`) && !strings.Contains(s, `\`) &&
+			!strings.HasSuffix(s, `"`) {
+			return `"""` + s + `"""`
+		}
+		return pyString(s)
+	},
+	"pyList": func(items []string) string {
+		quoted := make([]string, len(items))
+		for i, s := range items {
+			quoted[i] = pyString(s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	},
+	"pyDict": func(m map[string]string) string {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		items := make([]string, len(keys))
+		for i, k := range keys {
+			items[i] = pyString(k) + ": " + pyString(m[k])
+		}
+		return "{" + strings.Join(items, ", ") + "}"
+	},
+}
+
+var langGraphTemplate = template.Must(template.New("graph").Funcs(langGraphFuncs).Parse(`"""LangGraph graph for the {{.Team}} team.
+
+Generated by mas deploy generate from the team workflow. Each workflow step is
+a node that runs its agent; step outputs are collected in state["outputs"]
+keyed by step and output name.
+"""
+
+from __future__ import annotations
+
+import json
+{{- if or (eq .Checkpointer "sqlite") (eq .Checkpointer "postgres")}}
+import os
+{{- end}}
+{{- if eq .Checkpointer "sqlite"}}
+import sqlite3
+{{- end}}
+from typing import Annotated, Any
+{{- if not .Pydantic}}, TypedDict{{end}}
+
+from langchain.chat_models import init_chat_model
+from langchain_core.messages import HumanMessage, SystemMessage
+from langgraph.graph import END, START, StateGraph
+{{- if eq .Checkpointer "memory"}}
+from langgraph.checkpoint.memory import MemorySaver
+{{- else if eq .Checkpointer "sqlite"}}
+from langgraph.checkpoint.sqlite import SqliteSaver
+{{- else if eq .Checkpointer "postgres"}}
+from langgraph.checkpoint.postgres import PostgresSaver
+from psycopg import Connection
+{{- end}}
+{{- if .Pydantic}}
+from pydantic import BaseModel, Field
+{{- end}}
+
+
+def merge_outputs(
+    left: dict[str, dict[str, Any]], right: dict[str, dict[str, Any]]
+) -> dict[str, dict[str, Any]]:
+    """Merge step outputs written by parallel branches."""
+    return {**left, **right}
+
+{{if .Pydantic}}
+class State(BaseModel):
+    """Graph state shared by all steps."""
+
+    input: str = ""
+    outputs: Annotated[dict[str, dict[str, Any]], merge_outputs] = Field(
+        default_factory=dict
+    )
+{{else}}
+class State(TypedDict, total=False):
+    """Graph state shared by all steps."""
+
+    input: str
+    outputs: Annotated[dict[str, dict[str, Any]], merge_outputs]
+{{end}}
+
+AGENTS: dict[str, dict[str, str]] = {
+{{- range .Agents}}
+    {{py .Key}}: {
+        "model": {{py .Model}},
+        "instructions": {{pyText .Instructions}},
+    },
+{{- end}}
+}
+
+STEPS: dict[str, dict[str, Any]] = {
+{{- range .Steps}}
+    {{py .Name}}: {
+        "agent": {{py .Agent}},
+        "inputs": {{pyDict .Inputs}},
+        "outputs": {{pyList .Outputs}},
+    },
+{{- end}}
+}
+
+
+def _get(state: Any, key: str) -> Any:
+    if isinstance(state, dict):
+        return state.get(key)
+    return getattr(state, key, None)
+
+
+def _ref(state: Any, step: str, output: str) -> Any:
+    """Return the value of step.output, or None if it was not produced."""
+    return ((_get(state, "outputs") or {}).get(step) or {}).get(output)
+
+
+def _parse_outputs(reply: str, names: list[str]) -> dict[str, Any]:
+    if len(names) == 1:
+        return {names[0]: reply}
+    try:
+        data = json.loads(reply)
+    except ValueError:
+        data = None
+    if isinstance(data, dict):
+        return {name: data.get(name) for name in names} if names else data
+    return {"result": reply}
+
+
+def _run_step(name: str, state: Any) -> dict[str, Any]:
+    step = STEPS[name]
+    agent = AGENTS[step["agent"]]
+    inputs = {
+        port: _ref(state, *ref.split(".", 1)) for port, ref in step["inputs"].items()
+    }
+    request = {"task": _get(state, "input") or "", "inputs": inputs}
+    if len(step["outputs"]) > 1:
+        request["respond_with"] = (
+            "a JSON object with keys: " + ", ".join(step["outputs"])
+        )
+    llm = init_chat_model(agent["model"])
+    reply = llm.invoke(
+        [
+            SystemMessage(agent["instructions"]),
+            HumanMessage(json.dumps(request, default=str)),
+        ]
+    )
+    return {"outputs": {name: _parse_outputs(str(reply.content), step["outputs"])}}
+
+{{range .Steps}}
+def {{.Func}}(state: State) -> dict[str, Any]:
+    return _run_step({{py .Name}}, state)
+
+{{if .When}}
+def route_{{.Func}}(state: State) -> str:
+    return {{py .Name}} if ({{.When}}) else END
+
+{{end}}{{if .Join}}
+def join_{{.Func}}(state: State) -> dict[str, Any]:
+    return {}
+
+{{end}}{{end}}
+builder = StateGraph(State)
+{{- range .Steps}}
+builder.add_node({{py .Name}}, {{.Func}})
+{{- if .Join}}
+builder.add_node({{py .Join}}, join_{{.Func}})
+{{- end}}
+{{- end}}
+{{range .Steps}}
+{{- if .Join}}
+builder.add_edge({{pyList .Deps}}, {{py .Join}})
+builder.add_conditional_edges({{py .Join}}, route_{{.Func}}, [{{py .Name}}, END])
+{{- else if .When}}
+builder.add_conditional_edges(
+    {{if .Deps}}{{py (index .Deps 0)}}{{else}}START{{end}}, route_{{.Func}}, [{{py .Name}}, END]
+)
+{{- else if not .Deps}}
+builder.add_edge(START, {{py .Name}})
+{{- else if eq (len .Deps) 1}}
+builder.add_edge({{py (index .Deps 0)}}, {{py .Name}})
+{{- else}}
+builder.add_edge({{pyList .Deps}}, {{py .Name}})
+{{- end}}
+{{- if .Terminal}}
+builder.add_edge({{py .Name}}, END)
+{{- end}}
+{{- end}}
+{{if eq .Checkpointer "memory"}}
+checkpointer = MemorySaver()
+{{- else if eq .Checkpointer "sqlite"}}
+checkpointer = SqliteSaver(
+    sqlite3.connect(
+        os.environ.get("` + LangGraphCheckpointEnv + `", {{py .CheckpointerURI}}),
+        check_same_thread=False,
+    )
+)
+{{- else if eq .Checkpointer "postgres"}}
+checkpointer = PostgresSaver(
+    Connection.connect(
+{{- if .CheckpointerURI}}
+        os.environ.get("` + LangGraphCheckpointEnv + `", {{py .CheckpointerURI}}),
+{{- else}}
+        os.environ["` + LangGraphCheckpointEnv + `"],
+{{- end}}
+        autocommit=True,
+        prepare_threshold=0,
+    )
+)
+checkpointer.setup()
+{{- end}}
+{{- if eq .Checkpointer "none"}}
+graph = builder.compile()
+{{- else}}
+graph = builder.compile(checkpointer=checkpointer)
+{{- end}}
+
+
+if __name__ == "__main__":
+    import sys
+
+    result = graph.invoke(
+        {"input": " ".join(sys.argv[1:]), "outputs": {}},
+        {"configurable": {"thread_id": "{{.Team}}"}},
+    )
+    print(json.dumps(result["outputs"], indent=2, default=str))
+`))
@@ -0,0 +1,145 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func langGraphProject() *Project {
+	p := testProject()
+	p.Team.WithWorkflow(&multiagentspec.Workflow{
+		Type: multiagentspec.WorkflowGraph,
+		Steps: []multiagentspec.Step{
+			{Name: "plan", Agent: "pm", Outputs: []multiagentspec.Port{{Name: "plan"}}},
+			{Name: "unit-tests", Agent: "shared/qa", DependsOn: []string{"plan"}, Outputs: []multiagentspec.Port{{Name: "passed"}}},
+			{Name: "lint", Agent: "qa", DependsOn: []string{"plan"}},
+			{
+				Name:      "approve",
+				Agent:     "pm",
+				DependsOn: []string{"unit-tests", "lint"},
+				Inputs:    []multiagentspec.Port{{Name: "tests", From: "unit-tests.passed"}},
+				When:      "unit-tests.passed == true && !(plan.plan == 'skip')",
+			},
+		},
+	})
+	return p
+}
+
+func TestLangGraphGenerator(t *testing.T) {
+	target := &multiagentspec.Target{Name: "lg", Platform: multiagentspec.PlatformLangGraph}
+	files, err := Generate(langGraphProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	if len(got) != 3 {
+		t.Fatalf("got %d files, want 3", len(got))
+	}
+
+	graph := got["langgraph/graph.py"]
+	for _, want := range []string{
+		"class State(TypedDict, total=False):",
+		`builder.add_node("unit-tests", step_unit_tests)`,
+		`builder.add_edge(START, "plan")`,
+		`builder.add_edge("plan", "unit-tests")`,
+		`builder.add_edge(["unit-tests", "lint"], "approve__join")`,
+		`builder.add_conditional_edges("approve__join", route_step_approve, ["approve", END])`,
+		`return "approve" if (_ref(state, "unit-tests", "passed") == True and not (_ref(state, "plan", "plan") == 'skip')) else END`,
+		`builder.add_edge("approve", END)`,
+		`"inputs": {"tests": "unit-tests.passed"}`,
+		"checkpointer = MemorySaver()",
+		`"instructions": """Review the plan.""",`,
+	} {
+		if !strings.Contains(graph, want) {
+			t.Errorf("graph.py missing %q", want)
+		}
+	}
+	if strings.Contains(graph, `builder.add_edge("plan", END)`) {
+		t.Error("non-terminal step wired to END")
+	}
+	if strings.Count(graph, `"shared/qa": {`) != 1 {
+		t.Error("agents reused across steps should be declared once")
+	}
+	if !strings.Contains(got["langgraph/langgraph.json"], `"release-team": "./graph.py:graph"`) {
+		t.Errorf("langgraph.json = %s", got["langgraph/langgraph.json"])
+	}
+}
+
+func TestLangGraphGeneratorOptions(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "lg",
+		Platform: multiagentspec.PlatformLangGraph,
+		LangGraph: &multiagentspec.LangGraphConfig{
+			Checkpointer: "postgres",
+			StateSchema:  "pydantic",
+		},
+	}
+	files, err := Generate(langGraphProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	graph := got["langgraph/graph.py"]
+	for _, want := range []string{"class State(BaseModel):", "PostgresSaver(", `os.environ["LANGGRAPH_CHECKPOINT_URI"]`} {
+		if !strings.Contains(graph, want) {
+			t.Errorf("graph.py missing %q", want)
+		}
+	}
+	if !strings.Contains(got["langgraph/requirements.txt"], "langgraph-checkpoint-postgres") {
+		t.Error("requirements missing postgres checkpointer")
+	}
+}
+
+func TestLangGraphGeneratorErrors(t *testing.T) {
+	tests := map[string]func(p *Project, cfg *multiagentspec.LangGraphConfig){
+		"no workflow": func(p *Project, _ *multiagentspec.LangGraphConfig) { p.Team.Workflow = nil },
+		"self-directed": func(p *Project, _ *multiagentspec.LangGraphConfig) {
+			p.Team.Workflow.Type = multiagentspec.WorkflowSwarm
+		},
+		"unknown dep": func(p *Project, _ *multiagentspec.LangGraphConfig) {
+			p.Team.Workflow.Steps[1].DependsOn = []string{"nope"}
+		},
+		"unknown agent":    func(p *Project, _ *multiagentspec.LangGraphConfig) { p.Team.Workflow.Steps[0].Agent = "ghost" },
+		"bad when":         func(p *Project, _ *multiagentspec.LangGraphConfig) { p.Team.Workflow.Steps[3].When = "approved" },
+		"bad checkpointer": func(_ *Project, cfg *multiagentspec.LangGraphConfig) { cfg.Checkpointer = "redis" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := langGraphProject()
+			cfg := &multiagentspec.LangGraphConfig{}
+			mutate(p, cfg)
+			target := &multiagentspec.Target{Name: "lg", Platform: multiagentspec.PlatformLangGraph, LangGraph: cfg}
+			if _, err := Generate(p, target); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestPythonCondition(t *testing.T) {
+	steps := map[string]bool{"review": true, "test-1": true}
+	tests := []struct {
+		expr, want string
+	}{
+		{"review.approved", `_ref(state, "review", "approved")`},
+		{`review.verdict != "fail  hard" || test-1.count >= 3`, `_ref(state, "review", "verdict") != "fail  hard" or _ref(state, "test-1", "count") >= 3`},
+		{"!review.approved && review.score == null", `not _ref(state, "review", "approved") and _ref(state, "review", "score") == None`},
+	}
+	for _, tt := range tests {
+		got, err := pythonCondition(tt.expr, steps)
+		if err != nil {
+			t.Errorf("pythonCondition(%q): %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("pythonCondition(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"approved", "other.x == 1", "review.x == 'open"} {
+		if _, err := pythonCondition(bad, steps); err == nil {
+			t.Errorf("pythonCondition(%q) succeeded, want error", bad)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+package deploy
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// n8n defaults applied when N8NConfig leaves a field empty.
+const (
+	DefaultN8NOutput   = "n8n"
+	DefaultN8NAgentURL = "http://{agent}:8080/invoke"
+)
+
+// n8n limits on node retry settings.
+const (
+	n8nMaxTries         = 5
+	n8nMaxWaitBetweenMS = 5000
+)
+
+const n8nWebhookNode = "Webhook"
+
+func init() {
+	Register(N8NGenerator{})
+}
+
+// N8NGenerator exports the team's deterministic workflow as an n8n workflow
+// started by a webhook. Each step is an HTTP Request node that POSTs
+// {"agent", "step", "input"} to the agent endpoint and expects {"output"}
+// back. Steps with several dependencies wait on a Merge node. When the
+// workflow has a single final step, the webhook responds with its output;
+// otherwise it responds as soon as the run starts.
+type N8NGenerator struct{}
+
+// Platform returns PlatformN8N.
+func (N8NGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformN8N
+}
+
+type n8nWorkflow struct {
+	Name        string                              `json:"name"`
+	Nodes       []n8nNode                           `json:"nodes"`
+	Connections map[string]map[string][][]n8nTarget `json:"connections"`
+	Settings    map[string]string                   `json:"settings"`
+	Active      bool                                `json:"active"`
+}
+
+type n8nNode struct {
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Type             string                 `json:"type"`
+	TypeVersion      float64                `json:"typeVersion"`
+	Position         [2]int                 `json:"position"`
+	Parameters       map[string]interface{} `json:"parameters"`
+	WebhookID        string                 `json:"webhookId,omitempty"`
+	RetryOnFail      bool                   `json:"retryOnFail,omitempty"`
+	MaxTries         int                    `json:"maxTries,omitempty"`
+	WaitBetweenTries int                    `json:"waitBetweenTries,omitempty"`
+}
+
+type n8nTarget struct {
+	Node  string `json:"node"`
+	Type  string `json:"type"`
+	Index int    `json:"index"`
+}
+
+// Generate returns <team>.json under Target.Output.
+func (N8NGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.N8NConfig{}
+	if target.N8N != nil {
+		cfg = *target.N8N
+	}
+	team := teamName(project)
+	if cfg.WebhookPath == "" {
+		cfg.WebhookPath = team
+	}
+	if cfg.AgentURL == "" {
+		cfg.AgentURL = DefaultN8NAgentURL
+	}
+
+	if project.Team == nil || project.Team.Workflow == nil || len(project.Team.Workflow.Steps) == 0 {
+		return nil, fmt.Errorf("n8n: team has no workflow steps")
+	}
+	wf := project.Team.Workflow
+	if wf.Type != "" && !wf.Type.IsDeterministic() {
+		return nil, fmt.Errorf("n8n: workflow type %q is not deterministic", wf.Type)
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]string, len(agents)*2)
+	for _, a := range agents {
+		byName[a.QualifiedName()] = k8sName(a.QualifiedName())
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = k8sName(a.QualifiedName())
+		}
+	}
+
+	levels, err := n8nLevels(wf.Steps)
+	if err != nil {
+		return nil, err
+	}
+
+	needed := make(map[string]bool)
+	for _, s := range wf.Steps {
+		for _, d := range s.DependsOn {
+			needed[d] = true
+		}
+	}
+	var terminal []string
+	for _, s := range wf.Steps {
+		if !needed[s.Name] {
+			terminal = append(terminal, s.Name)
+		}
+	}
+
+	id := func(node string) string { return n8nID(team + "/" + node) }
+	webhook := map[string]interface{}{
+		"httpMethod":   "POST",
+		"path":         cfg.WebhookPath,
+		"responseMode": "onReceived",
+		"options":      map[string]interface{}{},
+	}
+	if len(terminal) == 1 {
+		webhook["responseMode"] = "lastNode"
+	}
+	w := n8nWorkflow{
+		Name: team,
+		Nodes: []n8nNode{{
+			ID:          id(n8nWebhookNode),
+			Name:        n8nWebhookNode,
+			Type:        "n8n-nodes-base.webhook",
+			TypeVersion: 2,
+			Parameters:  webhook,
+			WebhookID:   id("webhook-id"),
+		}},
+		Connections: make(map[string]map[string][][]n8nTarget),
+		Settings:    map[string]string{"executionOrder": "v1"},
+	}
+	connect := func(from, to string, index int) {
+		if w.Connections[from] == nil {
+			w.Connections[from] = map[string][][]n8nTarget{"main": {{}}}
+		}
+		w.Connections[from]["main"][0] = append(w.Connections[from]["main"][0], n8nTarget{Node: to, Type: "main", Index: index})
+	}
+
+	rows := make(map[int]int)
+	for _, s := range wf.Steps {
+		if s.Name == n8nWebhookNode {
+			return nil, fmt.Errorf("n8n: step name %q is reserved", s.Name)
+		}
+		agent, ok := byName[s.Agent]
+		if !ok {
+			return nil, fmt.Errorf("n8n: step %s references unknown agent %q", s.Name, s.Agent)
+		}
+		rt := target.Runtime.ForStep(s.Name)
+		if s.When != "" || rt.Condition != "" {
+			return nil, fmt.Errorf("n8n: step %s: conditional steps are not supported", s.Name)
+		}
+
+		level := levels[s.Name]
+		pos := [2]int{440 * level, 200 * rows[level]}
+		rows[level]++
+
+		input := fmt.Sprintf("$(%s).first().json.body.input", jsString(n8nWebhookNode))
+		switch len(s.DependsOn) {
+		case 0:
+			connect(n8nWebhookNode, s.Name, 0)
+		case 1:
+			connect(s.DependsOn[0], s.Name, 0)
+		default:
+			merge := s.Name + " inputs"
+			w.Nodes = append(w.Nodes, n8nNode{
+				ID:          id(merge),
+				Name:        merge,
+				Type:        "n8n-nodes-base.merge",
+				TypeVersion: 3,
+				Position:    [2]int{pos[0] - 220, pos[1]},
+				Parameters: map[string]interface{}{
+					"mode":           "chooseBranch",
+					"numberInputs":   len(s.DependsOn),
+					"useDataOfInput": 1,
+				},
+			})
+			for i, d := range s.DependsOn {
+				connect(d, merge, i)
+			}
+			connect(merge, s.Name, 0)
+		}
+		if len(s.DependsOn) > 0 {
+			parts := make([]string, len(s.DependsOn))
+			for i, d := range s.DependsOn {
+				parts[i] = fmt.Sprintf("%s + $(%s).first().json.output", jsString(d+":\n"), jsString(d))
+			}
+			input = strings.Join(parts, ` + "\n\n" + `)
+		}
+
+		options := map[string]interface{}{}
+		if rt.Timeout != "" {
+			d, err := time.ParseDuration(rt.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("n8n: step %s: invalid timeout %q: %w", s.Name, rt.Timeout, err)
+			}
+			options["timeout"] = d.Milliseconds()
+		}
+		node := n8nNode{
+			ID:          id(s.Name),
+			Name:        s.Name,
+			Type:        "n8n-nodes-base.httpRequest",
+			TypeVersion: 4.2,
+			Position:    pos,
+			Parameters: map[string]interface{}{
+				"method":      "POST",
+				"url":         strings.ReplaceAll(cfg.AgentURL, "{agent}", agent),
+				"sendBody":    true,
+				"specifyBody": "json",
+				"jsonBody": fmt.Sprintf("={{ JSON.stringify({ agent: %s, step: %s, input: %s }) }}",
+					jsString(agent), jsString(s.Name), input),
+				"options": options,
+			},
+		}
+		if r := rt.Retry; r != nil && r.MaxAttempts > 0 {
+			node.RetryOnFail = true
+			node.MaxTries = r.MaxAttempts + 1
+			if node.MaxTries > n8nMaxTries {
+				node.MaxTries = n8nMaxTries
+			}
+			if r.InitialDelay != "" {
+				d, err := time.ParseDuration(r.InitialDelay)
+				if err != nil {
+					return nil, fmt.Errorf("n8n: step %s: invalid initial_delay %q: %w", s.Name, r.InitialDelay, err)
+				}
+				node.WaitBetweenTries = int(d.Milliseconds())
+				if node.WaitBetweenTries > n8nMaxWaitBetweenMS {
+					node.WaitBetweenTries = n8nMaxWaitBetweenMS
+				}
+			}
+		}
+		w.Nodes = append(w.Nodes, node)
+	}
+
+	data, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("n8n: marshal workflow: %w", err)
+	}
+	out := target.Output
+	if out == "" {
+		out = DefaultN8NOutput
+	}
+	return []File{{Path: path.Join(out, team+".json"), Content: append(data, '\n')}}, nil
+}
+
+// n8nLevels returns each step's depth: 1 for steps without dependencies,
+// otherwise one more than its deepest dependency.
+func n8nLevels(steps []multiagentspec.Step) (map[string]int, error) {
+	byName := make(map[string]multiagentspec.Step, len(steps))
+	for _, s := range steps {
+		byName[s.Name] = s
+	}
+	levels := make(map[string]int, len(steps))
+	visiting := make(map[string]bool)
+	var level func(name string) (int, error)
+	level = func(name string) (int, error) {
+		if l, ok := levels[name]; ok {
+			return l, nil
+		}
+		if visiting[name] {
+			return 0, fmt.Errorf("n8n: dependency cycle at step %s", name)
+		}
+		visiting[name] = true
+		l := 1
+		for _, d := range byName[name].DependsOn {
+			if _, ok := byName[d]; !ok {
+				return 0, fmt.Errorf("n8n: step %s depends on unknown step %q", name, d)
+			}
+			dl, err := level(d)
+			if err != nil {
+				return 0, err
+			}
+			if dl+1 > l {
+				l = dl + 1
+			}
+		}
+		levels[name] = l
+		return l, nil
+	}
+	for _, s := range steps {
+		if _, err := level(s.Name); err != nil {
+			return nil, err
+		}
+	}
+	return levels, nil
+}
+
+// n8nID derives a stable UUID-formatted node ID from key, so regenerating
+// an unchanged workflow produces identical output.
+func n8nID(key string) string {
+	h := sha1.Sum([]byte(key))
+	return fmt.Sprintf("%x-%x-%x-%x-%x", h[0:4], h[4:6], h[6:8], h[8:10], h[10:16])
+}
+
+// jsString quotes s as a JavaScript string literal.
+func jsString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
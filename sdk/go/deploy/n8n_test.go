@@ -0,0 +1,117 @@
+package deploy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestN8NGenerator(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "n8n",
+		Platform: multiagentspec.PlatformN8N,
+		N8N:      &multiagentspec.N8NConfig{AgentURL: "https://agents.example.com/{agent}"},
+		Runtime: &multiagentspec.RuntimeConfig{
+			Steps: map[string]*multiagentspec.StepRuntime{
+				"lint": {
+					Timeout: "2m",
+					Retry:   &multiagentspec.RetryPolicy{MaxAttempts: 9, InitialDelay: "10s"},
+				},
+			},
+		},
+	}
+	files, err := Generate(semanticKernelProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "n8n/release-team.json" {
+		t.Fatalf("files = %v", files)
+	}
+
+	var w n8nWorkflow
+	if err := json.Unmarshal(files[0].Content, &w); err != nil {
+		t.Fatalf("workflow JSON: %v", err)
+	}
+	nodes := make(map[string]n8nNode, len(w.Nodes))
+	for _, n := range w.Nodes {
+		nodes[n.Name] = n
+	}
+	if len(nodes) != 6 {
+		t.Errorf("nodes = %v, want webhook, 4 steps, and 1 merge", w.Nodes)
+	}
+	if hook := nodes["Webhook"]; hook.Parameters["path"] != "release-team" || hook.Parameters["responseMode"] != "lastNode" {
+		t.Errorf("webhook parameters = %v", hook.Parameters)
+	}
+
+	lint := nodes["lint"]
+	if lint.Parameters["url"] != "https://agents.example.com/shared-qa" {
+		t.Errorf("lint url = %v", lint.Parameters["url"])
+	}
+	if lint.MaxTries != 5 || lint.WaitBetweenTries != 5000 || !lint.RetryOnFail {
+		t.Errorf("lint retry = %d tries, %dms", lint.MaxTries, lint.WaitBetweenTries)
+	}
+	if got := lint.Parameters["options"].(map[string]interface{})["timeout"]; got != float64(120000) {
+		t.Errorf("lint timeout = %v", got)
+	}
+
+	approve := nodes["approve"]
+	body, _ := approve.Parameters["jsonBody"].(string)
+	if !strings.Contains(body, `"unit-tests:\n" + $("unit-tests").first().json.output + "\n\n" + "lint:\n" + $("lint").first().json.output`) {
+		t.Errorf("approve body = %s", body)
+	}
+	if merge := nodes["approve inputs"]; merge.Parameters["numberInputs"] != float64(2) {
+		t.Errorf("merge = %+v", merge)
+	}
+
+	wantConn := map[string][]n8nTarget{
+		"Webhook":        {{Node: "plan", Type: "main", Index: 0}},
+		"plan":           {{Node: "unit-tests", Type: "main", Index: 0}, {Node: "lint", Type: "main", Index: 0}},
+		"unit-tests":     {{Node: "approve inputs", Type: "main", Index: 0}},
+		"lint":           {{Node: "approve inputs", Type: "main", Index: 1}},
+		"approve inputs": {{Node: "approve", Type: "main", Index: 0}},
+	}
+	for from, want := range wantConn {
+		got := w.Connections[from]["main"][0]
+		if len(got) != len(want) {
+			t.Errorf("connections from %s = %v, want %v", from, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("connections from %s = %v, want %v", from, got, want)
+			}
+		}
+	}
+
+	again, err := Generate(semanticKernelProject(), target)
+	if err != nil || string(again[0].Content) != string(files[0].Content) {
+		t.Error("output is not stable across runs")
+	}
+}
+
+func TestN8NGeneratorErrors(t *testing.T) {
+	cycle := semanticKernelProject()
+	cycle.Team.Workflow.Steps[0].DependsOn = []string{"approve"}
+	conditional := semanticKernelProject()
+	conditional.Team.Workflow.Steps[1].When = "plan.ok"
+
+	tests := []struct {
+		name    string
+		project *Project
+		want    string
+	}{
+		{"no workflow", testProject(), "no workflow steps"},
+		{"cycle", cycle, "dependency cycle"},
+		{"when", conditional, "conditional steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.project, &multiagentspec.Target{Name: "n8n", Platform: multiagentspec.PlatformN8N})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// OpenAI defaults applied when OpenAIAgentsConfig leaves a field empty.
+const (
+	DefaultOpenAIOutput = "openai"
+	DefaultOpenAIModel  = "gpt-4o"
+)
+
+func init() {
+	Register(OpenAIAgentsGenerator{})
+}
+
+// OpenAIAgentsGenerator writes an Assistants API definition per agent under
+// assistants/ and a team.py wiring the team with the OpenAI Agents SDK.
+// Handoffs come from each agent's Delegation settings; an orchestrator
+// without delegation settings hands off to every other team agent.
+type OpenAIAgentsGenerator struct{}
+
+// Platform returns PlatformOpenAIAgents.
+func (OpenAIAgentsGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformOpenAIAgents
+}
+
+// openAIAssistant is an Assistants API create/update request body.
+type openAIAssistant struct {
+	ID           string            `json:"id,omitempty"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	Instructions string            `json:"instructions,omitempty"`
+	Model        string            `json:"model"`
+	Tools        []openAITool      `json:"tools"`
+	Metadata     map[string]string `json:"metadata"`
+}
+
+type openAITool struct {
+	Type string `json:"type"`
+}
+
+type openAIAgent struct {
+	Var          string
+	Name         string
+	Description  string
+	Instructions string
+	Model        string
+	Tools        []string
+	Handoffs     []string // Vars of handoff targets
+}
+
+// openAISDKTools maps hosted tool types to Agents SDK constructors.
+var openAISDKTools = map[string]string{
+	"web_search":       "WebSearchTool()",
+	"file_search":      "FileSearchTool(vector_store_ids=VECTOR_STORE_IDS)",
+	"code_interpreter": `CodeInterpreterTool(tool_config={"type": "code_interpreter", "container": {"type": "auto"}})`,
+}
+
+// openAISDKClasses are the Agents SDK classes behind openAISDKTools.
+var openAISDKClasses = map[string]string{
+	"web_search":       "WebSearchTool",
+	"file_search":      "FileSearchTool",
+	"code_interpreter": "CodeInterpreterTool",
+}
+
+// Generate returns the assistant definitions, team.py, and requirements.txt.
+func (OpenAIAgentsGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.OpenAIAgentsConfig{}
+	if target.OpenAIAgents != nil {
+		cfg = *target.OpenAIAgents
+	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultOpenAIModel
+	}
+	for tool, mapped := range cfg.ToolMappings {
+		if _, ok := openAISDKTools[mapped]; mapped != "" && !ok {
+			return nil, fmt.Errorf("openai-agents: tool %s maps to unsupported tool type %q", tool, mapped)
+		}
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("openai-agents: no agents to deploy")
+	}
+
+	handoffs, err := delegationTargets(project, agents)
+	if err != nil {
+		return nil, fmt.Errorf("openai-agents: %w", err)
+	}
+
+	team := teamName(project)
+	out := target.Output
+	if out == "" {
+		out = DefaultOpenAIOutput
+	}
+
+	var files []File
+	wired := make([]openAIAgent, 0, len(agents))
+	varOf := make(map[*multiagentspec.Agent]string, len(agents))
+	for _, a := range agents {
+		varOf[a] = snakeCase(a.QualifiedName()) + "_agent"
+	}
+	entry := varOf[agents[0]]
+	for _, a := range agents {
+		model := cfg.Model
+		if a.Model != "" {
+			model = target.ResolveModel(a.Model)
+		}
+		tools, err := openAIToolTypes(a.Tools, target, cfg.ToolMappings)
+		if err != nil {
+			return nil, fmt.Errorf("openai-agents: agent %s: %w", a.QualifiedName(), err)
+		}
+
+		var targets []string
+		for _, h := range handoffs[a] {
+			targets = append(targets, h.QualifiedName())
+		}
+		def := openAIAssistant{
+			ID:           lookupByAgentName(cfg.AssistantIDs, a),
+			Name:         a.QualifiedName(),
+			Description:  a.Description,
+			Instructions: a.PromptInstructions(multiagentspec.ExampleFormatMarkdown),
+			Model:        model,
+			Tools:        []openAITool{},
+			Metadata:     map[string]string{"mas_team": team},
+		}
+		if len(targets) > 0 {
+			def.Metadata["mas_handoffs"] = strings.Join(targets, ",")
+		}
+		for _, t := range tools {
+			// The Assistants API has no hosted web search tool.
+			if t != "web_search" {
+				def.Tools = append(def.Tools, openAITool{Type: t})
+			}
+		}
+		data, err := json.MarshalIndent(def, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("openai-agents: marshal assistant %s: %w", a.QualifiedName(), err)
+		}
+		files = append(files, File{
+			Path:    path.Join(out, "assistants", k8sName(a.QualifiedName())+".json"),
+			Content: append(data, '\n'),
+		})
+
+		oa := openAIAgent{
+			Var:          varOf[a],
+			Name:         a.QualifiedName(),
+			Description:  a.Description,
+			Instructions: def.Instructions,
+			Model:        model,
+			Tools:        tools,
+		}
+		for _, h := range handoffs[a] {
+			oa.Handoffs = append(oa.Handoffs, varOf[h])
+		}
+		wired = append(wired, oa)
+
+		if project.Team != nil && project.Team.Orchestrator != "" &&
+			(project.Team.Orchestrator == a.QualifiedName() || project.Team.Orchestrator == a.Name) {
+			entry = varOf[a]
+		}
+	}
+
+	used := make(map[string]bool)
+	for _, a := range wired {
+		for _, t := range a.Tools {
+			used[t] = true
+		}
+	}
+	imports := []string{"Agent", "Runner"}
+	for t := range used {
+		imports = append(imports, openAISDKClasses[t])
+	}
+	sort.Strings(imports)
+
+	var buf bytes.Buffer
+	if err := openAIAgentsTemplate.Execute(&buf, struct {
+		Team        string
+		Imports     string
+		FileSearch  bool
+		Agents      []openAIAgent
+		Entry       string
+		HasHandoffs bool
+	}{
+		Team:        team,
+		Imports:     strings.Join(imports, ", "),
+		FileSearch:  used["file_search"],
+		Agents:      wired,
+		Entry:       entry,
+		HasHandoffs: len(handoffs) > 0,
+	}); err != nil {
+		return nil, fmt.Errorf("openai-agents: render team.py: %w", err)
+	}
+
+	files = append(files,
+		File{Path: path.Join(out, "team.py"), Content: buf.Bytes()},
+		File{Path: path.Join(out, "requirements.txt"), Content: []byte("openai-agents>=0.1\n")},
+	)
+	return files, nil
+}
+
+// delegationTargets resolves the team agents each agent may delegate to.
+// Agents allowed to delegate without a CanDelegateTo list, and an
+// orchestrator without delegation settings, may delegate to every other
+// agent that accepts work from them.
+func delegationTargets(project *Project, agents []*multiagentspec.Agent) (map[*multiagentspec.Agent][]*multiagentspec.Agent, error) {
+	byName := make(map[string]*multiagentspec.Agent, len(agents)*2)
+	for _, a := range agents {
+		byName[a.QualifiedName()] = a
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = a
+		}
+	}
+	accepts := func(to, from *multiagentspec.Agent) bool {
+		if to == from {
+			return false
+		}
+		if to.Delegation == nil || len(to.Delegation.CanReceiveFrom) == 0 {
+			return true
+		}
+		for _, name := range to.Delegation.CanReceiveFrom {
+			if name == from.QualifiedName() || name == from.Name {
+				return true
+			}
+		}
+		return false
+	}
+
+	handoffs := make(map[*multiagentspec.Agent][]*multiagentspec.Agent)
+	for _, a := range agents {
+		var candidates []*multiagentspec.Agent
+		switch {
+		case a.Delegation != nil && a.Delegation.AllowDelegation && len(a.Delegation.CanDelegateTo) > 0:
+			for _, name := range a.Delegation.CanDelegateTo {
+				to, ok := byName[name]
+				if !ok {
+					return nil, fmt.Errorf("agent %s delegates to %q, which is not on the team", a.QualifiedName(), name)
+				}
+				candidates = append(candidates, to)
+			}
+		case a.Delegation != nil && a.Delegation.AllowDelegation,
+			a.Delegation == nil && project.Team != nil && project.Team.Orchestrator != "" &&
+				(project.Team.Orchestrator == a.QualifiedName() || project.Team.Orchestrator == a.Name):
+			candidates = agents
+		}
+		for _, to := range candidates {
+			if accepts(to, a) {
+				handoffs[a] = append(handoffs[a], to)
+			}
+		}
+	}
+	return handoffs, nil
+}
+
+// openAIToolTypes maps spec tools to hosted tool types, applying overrides
+// first and dropping duplicates and tools overridden to "". A tool with no
+// hosted equivalent is an error.
+func openAIToolTypes(tools []string, target *multiagentspec.Target, overrides map[string]string) ([]string, error) {
+	var types []string
+	for _, t := range tools {
+		mapped, ok := overrides[t]
+		if !ok {
+			var err error
+			if mapped, err = target.MapTool(multiagentspec.Tool(t)); err != nil {
+				return nil, fmt.Errorf("%w; map it in toolMappings or set it to \"\" to drop it", err)
+			}
+			if _, ok := openAISDKTools[mapped]; !ok {
+				return nil, fmt.Errorf("tool %s has no OpenAI hosted tool type; map it in toolMappings or set it to \"\" to drop it", t)
+			}
+		}
+		if mapped != "" {
+			types = appendUnique(types, mapped)
+		}
+	}
+	return types, nil
+}
+
+// lookupByAgentName returns the entry for the agent's qualified or plain name.
+func lookupByAgentName(m map[string]string, a *multiagentspec.Agent) string {
+	if v, ok := m[a.QualifiedName()]; ok {
+		return v
+	}
+	return m[a.Name]
+}
+
+var openAIAgentsTemplate = template.Must(template.New("agents").Funcs(template.FuncMap{
+	"py":     pyString,
+	"pyText": langGraphFuncs["pyText"],
+	"sdkTool": func(t string) string {
+		return openAISDKTools[t]
+	},
+}).Parse(`"""OpenAI Agents SDK wiring for the {{.Team}} team.
+
+Generated by mas deploy generate. Handoffs follow the agents' delegation
+settings; run with: python team.py "<request>"
+"""
+{{if .FileSearch}}
+import os
+{{end}}
+from agents import {{.Imports}}
+{{- if .FileSearch}}
+
+VECTOR_STORE_IDS = [
+    s for s in os.environ.get("OPENAI_VECTOR_STORE_IDS", "").split(",") if s
+]
+{{- end}}
+{{range .Agents}}
+
+{{.Var}} = Agent(
+    name={{py .Name}},
+    handoff_description={{py .Description}},
+    instructions={{pyText .Instructions}},
+    model={{py .Model}},
+    tools=[{{range $i, $t := .Tools}}{{if $i}}, {{end}}{{sdkTool $t}}{{end}}],
+)
+{{- end}}
+{{if .HasHandoffs}}
+{{range .Agents}}{{if .Handoffs}}
+{{.Var}}.handoffs = [{{range $i, $h := .Handoffs}}{{if $i}}, {{end}}{{$h}}{{end}}]
+{{- end}}{{end}}
+{{end}}
+entry_agent = {{.Entry}}
+
+
+if __name__ == "__main__":
+    import sys
+
+    result = Runner.run_sync(entry_agent, " ".join(sys.argv[1:]))
+    print(result.final_output)
+`))
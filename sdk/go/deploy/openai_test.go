@@ -0,0 +1,133 @@
+package deploy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestOpenAIAgentsGenerator(t *testing.T) {
+	p := testProject()
+	p.Team.Orchestrator = "pm"
+	p.Agents[0].WithTools("Read", "Grep", "Bash", "WebSearch", "Edit").WithModel(multiagentspec.ModelHaiku)
+
+	target := &multiagentspec.Target{
+		Name:     "openai",
+		Platform: multiagentspec.PlatformOpenAIAgents,
+		OpenAIAgents: &multiagentspec.OpenAIAgentsConfig{
+			AssistantIDs: map[string]string{"pm": "asst_123"},
+			ToolMappings: map[string]string{"Bash": "", "Edit": ""},
+		},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+
+	var pm, qa openAIAssistant
+	if err := json.Unmarshal([]byte(got["openai/assistants/pm.json"]), &pm); err != nil {
+		t.Fatalf("pm.json: %v", err)
+	}
+	if err := json.Unmarshal([]byte(got["openai/assistants/shared-qa.json"]), &qa); err != nil {
+		t.Fatalf("shared-qa.json: %v", err)
+	}
+	if pm.ID != "asst_123" || qa.ID != "" {
+		t.Errorf("assistant IDs = %q, %q", pm.ID, qa.ID)
+	}
+	if pm.Metadata["mas_handoffs"] != "shared/qa" {
+		t.Errorf("pm handoffs metadata = %q", pm.Metadata["mas_handoffs"])
+	}
+	if qa.Model != "gpt-4o-mini" {
+		t.Errorf("qa model = %q", qa.Model)
+	}
+	if len(qa.Tools) != 1 || qa.Tools[0].Type != "file_search" {
+		t.Errorf("qa assistant tools = %+v, want file_search only", qa.Tools)
+	}
+
+	team := got["openai/team.py"]
+	for _, want := range []string{
+		"from agents import Agent, FileSearchTool, Runner, WebSearchTool\n",
+		"tools=[FileSearchTool(vector_store_ids=VECTOR_STORE_IDS), WebSearchTool()],",
+		"pm_agent.handoffs = [shared_qa_agent]",
+		"entry_agent = pm_agent",
+	} {
+		if !strings.Contains(team, want) {
+			t.Errorf("team.py missing %q:\n%s", want, team)
+		}
+	}
+	if strings.Contains(team, "shared_qa_agent.handoffs") {
+		t.Error("qa has no delegation settings and should not hand off")
+	}
+}
+
+func TestOpenAIAgentsGeneratorExamples(t *testing.T) {
+	p := testProject()
+	p.Agents[1].Examples = []multiagentspec.Example{{Input: "Ship v2 Friday?", Output: "NO-GO"}}
+	target := &multiagentspec.Target{Name: "openai", Platform: multiagentspec.PlatformOpenAIAgents}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	var pm openAIAssistant
+	if err := json.Unmarshal([]byte(filesByPath(files)["openai/assistants/pm.json"]), &pm); err != nil {
+		t.Fatalf("pm.json: %v", err)
+	}
+	want := "Review the plan.\n\n## Examples\n\n### Example 1\n\nInput:\n\nShip v2 Friday?\n\nOutput:\n\nNO-GO"
+	if pm.Instructions != want {
+		t.Errorf("instructions = %q, want %q", pm.Instructions, want)
+	}
+}
+
+func TestOpenAIAgentsUnsupportedTool(t *testing.T) {
+	p := testProject()
+	p.Agents[1].WithTools("Read", "Edit")
+	target := &multiagentspec.Target{Name: "openai", Platform: multiagentspec.PlatformOpenAIAgents}
+
+	_, err := Generate(p, target)
+	if err == nil || !strings.Contains(err.Error(), "agent pm: tool Edit is not supported on openai-agents") {
+		t.Errorf("Generate error = %v, want unsupported Edit", err)
+	}
+
+	target.OpenAIAgents = &multiagentspec.OpenAIAgentsConfig{ToolMappings: map[string]string{"Edit": ""}}
+	if _, err := Generate(p, target); err != nil {
+		t.Errorf("Generate with Edit dropped: %v", err)
+	}
+}
+
+func TestOpenAIAgentsHandoffsFromDelegation(t *testing.T) {
+	p := testProject()
+	p.Agents[0].WithDelegation(&multiagentspec.DelegationConfig{CanReceiveFrom: []string{"nobody"}})
+	p.Agents[1].WithDelegation(&multiagentspec.DelegationConfig{AllowDelegation: true})
+
+	agents, err := p.TeamAgents()
+	if err != nil {
+		t.Fatalf("TeamAgents: %v", err)
+	}
+	handoffs, err := delegationTargets(p, agents)
+	if err != nil {
+		t.Fatalf("delegationTargets: %v", err)
+	}
+	if len(handoffs) != 0 {
+		t.Errorf("qa only accepts handoffs from nobody, got %v", handoffs)
+	}
+
+	p.Agents[0].Delegation = nil
+	p.Agents[1].Delegation.CanDelegateTo = []string{"unused"}
+	if _, err := delegationTargets(p, agents); err == nil {
+		t.Error("expected error for delegation target outside the team")
+	}
+}
+
+func TestOpenAIAgentsGeneratorBadToolMapping(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:         "openai",
+		Platform:     multiagentspec.PlatformOpenAIAgents,
+		OpenAIAgents: &multiagentspec.OpenAIAgentsConfig{ToolMappings: map[string]string{"Bash": "shell"}},
+	}
+	if _, err := Generate(testProject(), target); err == nil {
+		t.Error("expected error for unsupported tool type")
+	}
+}
@@ -0,0 +1,570 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Semantic Kernel defaults applied when SemanticKernelConfig leaves a field
+// empty.
+const (
+	DefaultSemanticKernelOutput   = "semantic-kernel"
+	DefaultSemanticKernelLanguage = "python"
+	DefaultSemanticKernelService  = "openai"
+)
+
+func init() {
+	Register(SemanticKernelGenerator{})
+}
+
+// SemanticKernelGenerator emits a Semantic Kernel project in Python or .NET.
+// Each agent becomes a ChatCompletionAgent; agents with command tasks get a
+// plugin exposing one kernel function per task. A deterministic workflow
+// becomes an SK process with one step per workflow step, wired by
+// DependsOn. Steps with a When condition are not supported.
+type SemanticKernelGenerator struct{}
+
+// Platform returns PlatformSemanticKernel.
+func (SemanticKernelGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformSemanticKernel
+}
+
+type skAgent struct {
+	Name         string // SK agent name
+	Plugin       string // plugin class, empty without command tasks
+	Description  string
+	Instructions string
+	Model        string
+	Tasks        []skTask
+}
+
+type skTask struct {
+	Func        string
+	Description string
+	Command     string
+}
+
+type skStep struct {
+	Name     string
+	Class    string
+	Var      string
+	Agent    string
+	Params   []skParam
+	Targets  []skParam // dependents and the parameter they receive this step's output on
+	Terminal bool
+}
+
+type skParam struct {
+	Step   string // step name
+	Var    string // step variable
+	Python string // parameter name in Python
+	CSharp string // parameter name in C#
+}
+
+type skData struct {
+	Team      string
+	Namespace string
+	Azure     bool
+	Models    []string
+	Agents    []skAgent
+	Entry     string
+	Steps     []skStep
+	Terminal  []string // names of steps nothing depends on
+}
+
+// Generate returns the project files for the configured language.
+func (SemanticKernelGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.SemanticKernelConfig{}
+	if target.SemanticKernel != nil {
+		cfg = *target.SemanticKernel
+	}
+	if cfg.Language == "" {
+		cfg.Language = DefaultSemanticKernelLanguage
+	}
+	if cfg.Service == "" {
+		cfg.Service = DefaultSemanticKernelService
+	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultOpenAIModel
+	}
+	switch cfg.Language {
+	case "python", "dotnet":
+	default:
+		return nil, fmt.Errorf("semantic-kernel: invalid language %q (want python or dotnet)", cfg.Language)
+	}
+	switch cfg.Service {
+	case "openai", "azure-openai":
+	default:
+		return nil, fmt.Errorf("semantic-kernel: invalid service %q (want openai or azure-openai)", cfg.Service)
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("semantic-kernel: no agents to deploy")
+	}
+
+	team := teamName(project)
+	data := skData{
+		Team:      team,
+		Namespace: pascalCase(team),
+		Azure:     cfg.Service == "azure-openai",
+		Entry:     k8sName(agents[0].QualifiedName()),
+	}
+	byName := make(map[string]string, len(agents)*2)
+	for _, a := range agents {
+		sa := skAgent{
+			Name:         k8sName(a.QualifiedName()),
+			Description:  a.Description,
+			Instructions: a.PromptInstructions(multiagentspec.ExampleFormatMarkdown),
+			Model:        cfg.Model,
+		}
+		if a.Model != "" {
+			sa.Model = target.ResolveModel(a.Model)
+		}
+		data.Models = appendUnique(data.Models, sa.Model)
+		for _, t := range a.Tasks {
+			if t.Type == multiagentspec.TaskTypeCommand && t.Command != "" {
+				sa.Tasks = append(sa.Tasks, skTask{Func: snakeCase(t.ID), Description: t.Description, Command: t.Command})
+			}
+		}
+		if len(sa.Tasks) > 0 {
+			sa.Plugin = pascalCase(a.QualifiedName()) + "Tasks"
+		}
+		data.Agents = append(data.Agents, sa)
+
+		byName[a.QualifiedName()] = sa.Name
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = sa.Name
+		}
+		if project.Team != nil && (project.Team.Orchestrator == a.QualifiedName() || project.Team.Orchestrator == a.Name) {
+			data.Entry = sa.Name
+		}
+	}
+
+	if w := project.Team; w != nil && w.Workflow != nil && len(w.Workflow.Steps) > 0 {
+		if wt := w.Workflow.Type; wt != "" && !wt.IsDeterministic() {
+			return nil, fmt.Errorf("semantic-kernel: workflow type %q is not deterministic", wt)
+		}
+		data.Steps, err = skSteps(w.Workflow.Steps, byName)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range data.Steps {
+			if s.Terminal {
+				data.Terminal = append(data.Terminal, s.Name)
+			}
+		}
+	}
+
+	out := target.Output
+	if out == "" {
+		out = DefaultSemanticKernelOutput
+	}
+
+	var files []File
+	add := func(name string, tmpl *template.Template) error {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("semantic-kernel: render %s: %w", name, err)
+		}
+		files = append(files, File{Path: path.Join(out, name), Content: buf.Bytes()})
+		return nil
+	}
+
+	if cfg.Language == "python" {
+		if err := add("team.py", skPythonTemplate); err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(out, "requirements.txt"), Content: []byte("semantic-kernel>=1.20\n")})
+		return files, nil
+	}
+
+	if err := add(data.Namespace+".csproj", skProjectTemplate); err != nil {
+		return nil, err
+	}
+	if err := add("Agents.cs", skAgentsTemplate); err != nil {
+		return nil, err
+	}
+	if len(data.Steps) > 0 {
+		if err := add("Process.cs", skProcessTemplate); err != nil {
+			return nil, err
+		}
+	}
+	if err := add("Program.cs", skProgramTemplate); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// skSteps converts workflow steps to process steps.
+func skSteps(steps []multiagentspec.Step, agents map[string]string) ([]skStep, error) {
+	index := make(map[string]int, len(steps))
+	out := make([]skStep, len(steps))
+	for i, s := range steps {
+		agent, ok := agents[s.Agent]
+		if !ok {
+			return nil, fmt.Errorf("semantic-kernel: step %s references unknown agent %q", s.Name, s.Agent)
+		}
+		if s.When != "" {
+			return nil, fmt.Errorf("semantic-kernel: step %s: conditional steps (when) are not supported", s.Name)
+		}
+		index[s.Name] = i
+		out[i] = skStep{
+			Name:     s.Name,
+			Class:    pascalCase(s.Name) + "Step",
+			Var:      snakeCase(s.Name) + "_step",
+			Agent:    agent,
+			Terminal: true,
+		}
+	}
+	for i, s := range steps {
+		if len(s.DependsOn) == 0 {
+			out[i].Params = []skParam{{Python: "request", CSharp: "request"}}
+			continue
+		}
+		for _, d := range s.DependsOn {
+			j, ok := index[d]
+			if !ok {
+				return nil, fmt.Errorf("semantic-kernel: step %s depends on unknown step %q", s.Name, d)
+			}
+			pascal := pascalCase(d)
+			p := skParam{
+				Step:   d,
+				Var:    out[j].Var,
+				Python: snakeCase(d),
+				CSharp: strings.ToLower(pascal[:1]) + pascal[1:],
+			}
+			out[i].Params = append(out[i].Params, p)
+			out[j].Targets = append(out[j].Targets, skParam{Step: s.Name, Var: out[i].Var, Python: p.Python, CSharp: p.CSharp})
+			out[j].Terminal = false
+		}
+	}
+	return out, nil
+}
+
+var skFuncs = template.FuncMap{
+	"py":     pyString,
+	"pyText": langGraphFuncs["pyText"],
+	// cs renders s as a C# verbatim string literal.
+	"cs": func(s string) string {
+		return `@"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	},
+}
+
+var skPythonTemplate = template.Must(template.New("team.py").Funcs(skFuncs).Parse(`"""Semantic Kernel agents for the {{.Team}} team.
+
+Generated by mas deploy generate. Set {{if .Azure}}AZURE_OPENAI_ENDPOINT and AZURE_OPENAI_API_KEY{{else}}OPENAI_API_KEY{{end}}, then run:
+python team.py "<request>"
+"""
+
+import asyncio
+import subprocess
+import sys
+
+from semantic_kernel import Kernel
+from semantic_kernel.agents import ChatCompletionAgent
+from semantic_kernel.connectors.ai.open_ai import {{if .Azure}}AzureChatCompletion{{else}}OpenAIChatCompletion{{end}}
+from semantic_kernel.functions import kernel_function
+{{- if .Steps}}
+from semantic_kernel.processes import ProcessBuilder
+from semantic_kernel.processes.kernel_process import (
+    KernelProcessEvent,
+    KernelProcessStep,
+    KernelProcessStepContext,
+)
+from semantic_kernel.processes.local_runtime.local_kernel_process import start
+{{- end}}
+
+
+def _run(command: str) -> str:
+    proc = subprocess.run(command, shell=True, capture_output=True, text=True)
+    return f"exit code {proc.returncode}\n{proc.stdout[-4000:]}{proc.stderr[-4000:]}"
+{{range .Agents}}{{if .Plugin}}
+
+class {{.Plugin}}:
+    """Command tasks of the {{.Name}} agent."""
+{{range .Tasks}}
+    @kernel_function(name={{py .Func}}, description={{py .Description}})
+    def {{.Func}}(self) -> str:
+        return _run({{py .Command}})
+{{end}}{{end}}{{end}}
+
+AGENTS = {
+{{- range .Agents}}
+    {{py .Name}}: ChatCompletionAgent(
+        service={{if $.Azure}}AzureChatCompletion(deployment_name={{py .Model}}){{else}}OpenAIChatCompletion(ai_model_id={{py .Model}}){{end}},
+        name={{py .Name}},
+        description={{py .Description}},
+        instructions={{pyText .Instructions}},
+{{- if .Plugin}}
+        plugins=[{{.Plugin}}()],
+{{- end}}
+    ),
+{{- end}}
+}
+
+
+async def invoke(agent: str, message: str) -> str:
+    response = await AGENTS[agent].get_response(messages=message)
+    return str(response.content)
+{{- if .Steps}}
+
+
+RESULTS: dict[str, str] = {}
+{{range .Steps}}
+
+class {{.Class}}(KernelProcessStep):
+    @kernel_function
+    async def run(
+        self, context: KernelProcessStepContext{{range .Params}}, {{.Python}}: str{{end}}
+    ) -> None:
+{{- if eq (len .Params) 1}}
+        message = {{(index .Params 0).Python}}
+{{- else}}
+        message = "\n\n".join(
+            [{{range $i, $p := .Params}}{{if $i}}, {{end}}f"{{$p.Step}}:\n{ {{- $p.Python -}} }"{{end}}]
+        )
+{{- end}}
+        RESULTS[{{py .Name}}] = await invoke({{py .Agent}}, message)
+        await context.emit_event(process_event={{py (print .Name ".done")}}, data=RESULTS[{{py .Name}}])
+{{end}}
+
+def build_process():
+    process = ProcessBuilder(name={{py .Team}})
+{{- range .Steps}}
+    {{.Var}} = process.add_step({{.Class}})
+{{- end}}
+{{range .Steps}}
+{{- if eq (index .Params 0).Python "request"}}
+    process.on_input_event("start").send_event_to(target={{.Var}}, parameter_name="request")
+{{- end}}
+{{- end}}
+{{- range $s := .Steps}}
+{{- range .Targets}}
+    {{$s.Var}}.on_event({{py (print $s.Name ".done")}}).send_event_to(
+        target={{.Var}}, parameter_name={{py .Python}}
+    )
+{{- end}}
+{{- end}}
+    return process.build()
+
+
+async def main(request: str) -> None:
+    initial = KernelProcessEvent(id="start", data=request)
+    async with await start(process=build_process(), kernel=Kernel(), initial_event=initial):
+        pass
+{{- range .Terminal}}
+    print(RESULTS.get({{py .}}, ""))
+{{- end}}
+{{- else}}
+
+
+async def main(request: str) -> None:
+    print(await invoke({{py .Entry}}, request))
+{{- end}}
+
+
+if __name__ == "__main__":
+    asyncio.run(main(" ".join(sys.argv[1:])))
+`))
+
+var skProjectTemplate = template.Must(template.New("csproj").Parse(`<Project Sdk="Microsoft.NET.Sdk">
+
+  <PropertyGroup>
+    <OutputType>Exe</OutputType>
+    <TargetFramework>net8.0</TargetFramework>
+    <ImplicitUsings>enable</ImplicitUsings>
+    <Nullable>enable</Nullable>
+    <RootNamespace>{{.Namespace}}</RootNamespace>
+    <NoWarn>$(NoWarn);SKEXP0001;SKEXP0080;SKEXP0110</NoWarn>
+  </PropertyGroup>
+
+  <ItemGroup>
+    <PackageReference Include="Microsoft.SemanticKernel" Version="1.*" />
+    <PackageReference Include="Microsoft.SemanticKernel.Agents.Core" Version="1.*" />
+{{- if .Steps}}
+    <PackageReference Include="Microsoft.SemanticKernel.Process.Core" Version="1.*-*" />
+    <PackageReference Include="Microsoft.SemanticKernel.Process.LocalRuntime" Version="1.*-*" />
+{{- end}}
+  </ItemGroup>
+
+</Project>
+`))
+
+var skAgentsTemplate = template.Must(template.New("Agents.cs").Funcs(skFuncs).Parse(`// Semantic Kernel agents for the {{.Team}} team.
+// Generated by mas deploy generate.
+
+using System.ComponentModel;
+using System.Diagnostics;
+using System.Text;
+using Microsoft.SemanticKernel;
+using Microsoft.SemanticKernel.Agents;
+using Microsoft.SemanticKernel.ChatCompletion;
+
+namespace {{.Namespace}};
+
+public static class TeamAgents
+{
+    public static ChatCompletionAgent Create(Kernel kernel, string name)
+    {
+        var agentKernel = kernel.Clone();
+        switch (name)
+        {
+{{- range .Agents}}
+            case {{cs .Name}}:
+{{- if .Plugin}}
+                agentKernel.Plugins.AddFromType<{{.Plugin}}>("tasks");
+{{- end}}
+                return New(agentKernel, {{cs .Name}}, {{cs .Description}}, {{cs .Model}}, {{cs .Instructions}});
+{{- end}}
+            default:
+                throw new ArgumentException($"unknown agent {name}", nameof(name));
+        }
+    }
+
+    public static async Task<string> InvokeAsync(Kernel kernel, string name, string message)
+    {
+        var agent = Create(kernel, name);
+        var reply = new StringBuilder();
+        await foreach (var response in agent.InvokeAsync(new ChatMessageContent(AuthorRole.User, message)))
+        {
+            reply.Append(response.Message.Content);
+        }
+        return reply.ToString();
+    }
+
+    private static ChatCompletionAgent New(Kernel kernel, string name, string description, string model, string instructions) =>
+        new()
+        {
+            Name = name,
+            Description = description,
+            Instructions = instructions,
+            Kernel = kernel,
+            Arguments = new KernelArguments(new PromptExecutionSettings
+            {
+                ServiceId = model,
+                FunctionChoiceBehavior = FunctionChoiceBehavior.Auto(),
+            }),
+        };
+
+    internal static string Run(string command)
+    {
+        var info = new ProcessStartInfo("/bin/sh", new[] { "-c", command })
+        {
+            RedirectStandardOutput = true,
+            RedirectStandardError = true,
+        };
+        using var process = System.Diagnostics.Process.Start(info)!;
+        var output = process.StandardOutput.ReadToEnd() + process.StandardError.ReadToEnd();
+        process.WaitForExit();
+        return $"exit code {process.ExitCode}\n{output}";
+    }
+}
+{{range .Agents}}{{if .Plugin}}
+/// <summary>Command tasks of the {{.Name}} agent.</summary>
+public sealed class {{.Plugin}}
+{
+{{- range .Tasks}}
+    [KernelFunction({{cs .Func}}), Description({{cs .Description}})]
+    public string {{.Func}}() => TeamAgents.Run({{cs .Command}});
+{{- end}}
+}
+{{end}}{{end}}`))
+
+var skProcessTemplate = template.Must(template.New("Process.cs").Funcs(skFuncs).Parse(`// Semantic Kernel process for the {{.Team}} workflow.
+// Generated by mas deploy generate.
+
+using System.Collections.Concurrent;
+using Microsoft.SemanticKernel;
+
+namespace {{.Namespace}};
+
+public static class TeamProcess
+{
+    public static readonly ConcurrentDictionary<string, string> Results = new();
+
+    public static readonly string[] Terminal = { {{range $i, $n := .Terminal}}{{if $i}}, {{end}}{{cs $n}}{{end}} };
+
+    public static KernelProcess Build()
+    {
+        ProcessBuilder process = new({{cs .Team}});
+{{- range .Steps}}
+        var {{.Var}} = process.AddStepFromType<{{.Class}}>();
+{{- end}}
+{{range .Steps}}
+{{- if eq (index .Params 0).CSharp "request"}}
+        process.OnInputEvent("start").SendEventTo(new ProcessFunctionTargetBuilder({{.Var}}, parameterName: "request"));
+{{- end}}
+{{- end}}
+{{- range $s := .Steps}}
+{{- range .Targets}}
+        {{$s.Var}}.OnEvent({{cs (print $s.Name ".done")}}).SendEventTo(new ProcessFunctionTargetBuilder({{.Var}}, parameterName: {{cs .CSharp}}));
+{{- end}}
+{{- end}}
+        return process.Build();
+    }
+}
+{{range .Steps}}
+public sealed class {{.Class}} : KernelProcessStep
+{
+    [KernelFunction]
+    public async Task RunAsync(KernelProcessStepContext context, Kernel kernel{{range .Params}}, string {{.CSharp}}{{end}})
+    {
+{{- if eq (len .Params) 1}}
+        var message = {{(index .Params 0).CSharp}};
+{{- else}}
+        var message = string.Join("\n\n", new[] { {{- range $i, $p := .Params}}{{if $i}},{{end}} $"{{$p.Step}}:\n{ {{- $p.CSharp -}} }"{{end}} });
+{{- end}}
+        var result = await TeamAgents.InvokeAsync(kernel, {{cs .Agent}}, message);
+        TeamProcess.Results[{{cs .Name}}] = result;
+        await context.EmitEventAsync(new KernelProcessEvent { Id = {{cs (print .Name ".done")}}, Data = result });
+    }
+}
+{{end}}`))
+
+var skProgramTemplate = template.Must(template.New("Program.cs").Funcs(skFuncs).Parse(`// Entry point for the {{.Team}} team.
+// Generated by mas deploy generate. Set {{if .Azure}}AZURE_OPENAI_ENDPOINT and AZURE_OPENAI_API_KEY{{else}}OPENAI_API_KEY{{end}}, then run:
+// dotnet run -- "<request>"
+
+using Microsoft.SemanticKernel;
+using {{.Namespace}};
+
+var request = string.Join(" ", args);
+
+var builder = Kernel.CreateBuilder();
+{{- range .Models}}
+{{- if $.Azure}}
+builder.AddAzureOpenAIChatCompletion(
+    deploymentName: {{cs .}},
+    endpoint: Environment.GetEnvironmentVariable("AZURE_OPENAI_ENDPOINT")!,
+    apiKey: Environment.GetEnvironmentVariable("AZURE_OPENAI_API_KEY")!,
+    serviceId: {{cs .}});
+{{- else}}
+builder.AddOpenAIChatCompletion(
+    modelId: {{cs .}},
+    apiKey: Environment.GetEnvironmentVariable("OPENAI_API_KEY")!,
+    serviceId: {{cs .}});
+{{- end}}
+{{- end}}
+var kernel = builder.Build();
+{{if .Steps}}
+var process = TeamProcess.Build();
+await using (await process.StartAsync(kernel, new KernelProcessEvent { Id = "start", Data = request }))
+{
+}
+foreach (var step in TeamProcess.Terminal)
+{
+    Console.WriteLine(TeamProcess.Results.GetValueOrDefault(step, ""));
+}
+{{- else}}
+Console.WriteLine(await TeamAgents.InvokeAsync(kernel, {{cs .Entry}}, request));
+{{- end}}
+`))
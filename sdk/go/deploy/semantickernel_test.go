@@ -0,0 +1,160 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func semanticKernelProject() *Project {
+	p := testProject()
+	p.Agents[0].Tasks = []multiagentspec.Task{
+		{ID: "unit-tests", Description: "Run unit tests", Type: multiagentspec.TaskTypeCommand, Command: "go test ./..."},
+		{ID: "review", Description: "Review the diff", Type: multiagentspec.TaskTypePattern},
+	}
+	p.Team.WithWorkflow(&multiagentspec.Workflow{
+		Type: multiagentspec.WorkflowGraph,
+		Steps: []multiagentspec.Step{
+			{Name: "plan", Agent: "pm"},
+			{Name: "unit-tests", Agent: "shared/qa", DependsOn: []string{"plan"}},
+			{Name: "lint", Agent: "qa", DependsOn: []string{"plan"}},
+			{Name: "approve", Agent: "pm", DependsOn: []string{"unit-tests", "lint"}},
+		},
+	})
+	return p
+}
+
+func TestSemanticKernelGeneratorPython(t *testing.T) {
+	p := semanticKernelProject()
+	target := &multiagentspec.Target{Name: "sk", Platform: multiagentspec.PlatformSemanticKernel}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	if len(got) != 2 || got["semantic-kernel/requirements.txt"] == "" {
+		t.Fatalf("files = %v", files)
+	}
+
+	team := got["semantic-kernel/team.py"]
+	for _, want := range []string{
+		"from semantic_kernel.connectors.ai.open_ai import OpenAIChatCompletion\n",
+		"class SharedQaTasks:",
+		`def unit_tests(self) -> str:`,
+		`return _run("go test ./...")`,
+		`service=OpenAIChatCompletion(ai_model_id="gpt-4o"),`,
+		"plugins=[SharedQaTasks()],",
+		"class ApproveStep(KernelProcessStep):",
+		"self, context: KernelProcessStepContext, unit_tests: str, lint: str",
+		`RESULTS["lint"] = await invoke("shared-qa", message)`,
+		`process.on_input_event("start").send_event_to(target=plan_step, parameter_name="request")`,
+		`plan_step.on_event("plan.done").send_event_to(` + "\n" + `        target=unit_tests_step, parameter_name="plan"`,
+		`lint_step.on_event("lint.done").send_event_to(` + "\n" + `        target=approve_step, parameter_name="lint"`,
+		`print(RESULTS.get("approve", ""))`,
+	} {
+		if !strings.Contains(team, want) {
+			t.Errorf("team.py missing %q:\n%s", want, team)
+		}
+	}
+	if strings.Contains(team, "def review") {
+		t.Error("only command tasks become kernel functions")
+	}
+	if strings.Contains(team, `print(RESULTS.get("plan"`) {
+		t.Error("only terminal steps are printed")
+	}
+}
+
+func TestSemanticKernelGeneratorDotNet(t *testing.T) {
+	p := semanticKernelProject()
+	target := &multiagentspec.Target{
+		Name:     "sk",
+		Platform: multiagentspec.PlatformSemanticKernel,
+		Output:   "sk",
+		SemanticKernel: &multiagentspec.SemanticKernelConfig{
+			Language: "dotnet",
+			Service:  "azure-openai",
+		},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	for _, name := range []string{"sk/ReleaseTeam.csproj", "sk/Agents.cs", "sk/Process.cs", "sk/Program.cs"} {
+		if got[name] == "" {
+			t.Errorf("missing %s", name)
+		}
+	}
+	checks := map[string][]string{
+		"sk/ReleaseTeam.csproj": {`Include="Microsoft.SemanticKernel.Process.LocalRuntime"`},
+		"sk/Agents.cs": {
+			"namespace ReleaseTeam;",
+			`agentKernel.Plugins.AddFromType<SharedQaTasks>("tasks");`,
+			`[KernelFunction(@"unit_tests"), Description(@"Run unit tests")]`,
+			`public string unit_tests() => TeamAgents.Run(@"go test ./...");`,
+		},
+		"sk/Process.cs": {
+			`public static readonly string[] Terminal = { @"approve" };`,
+			"public async Task RunAsync(KernelProcessStepContext context, Kernel kernel, string unitTests, string lint)",
+			`plan_step.OnEvent(@"plan.done").SendEventTo(new ProcessFunctionTargetBuilder(lint_step, parameterName: @"plan"));`,
+		},
+		"sk/Program.cs": {
+			`deploymentName: @"gpt-4o",`,
+			"var process = TeamProcess.Build();",
+		},
+	}
+	for name, wants := range checks {
+		for _, want := range wants {
+			if !strings.Contains(got[name], want) {
+				t.Errorf("%s missing %q:\n%s", name, want, got[name])
+			}
+		}
+	}
+}
+
+func TestSemanticKernelGeneratorWithoutWorkflow(t *testing.T) {
+	p := testProject()
+	p.Team.Orchestrator = "shared/qa"
+	target := &multiagentspec.Target{
+		Name:           "sk",
+		Platform:       multiagentspec.PlatformSemanticKernel,
+		SemanticKernel: &multiagentspec.SemanticKernelConfig{Language: "dotnet"},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	if _, ok := got["semantic-kernel/Process.cs"]; ok {
+		t.Error("Process.cs generated without a workflow")
+	}
+	if want := `TeamAgents.InvokeAsync(kernel, @"shared-qa", request)`; !strings.Contains(got["semantic-kernel/Program.cs"], want) {
+		t.Errorf("Program.cs missing %q:\n%s", want, got["semantic-kernel/Program.cs"])
+	}
+}
+
+func TestSemanticKernelGeneratorErrors(t *testing.T) {
+	conditional := semanticKernelProject()
+	conditional.Team.Workflow.Steps[3].When = "lint.ok == true"
+
+	tests := []struct {
+		name    string
+		project *Project
+		cfg     *multiagentspec.SemanticKernelConfig
+		want    string
+	}{
+		{"language", testProject(), &multiagentspec.SemanticKernelConfig{Language: "java"}, "invalid language"},
+		{"service", testProject(), &multiagentspec.SemanticKernelConfig{Service: "bedrock"}, "invalid service"},
+		{"when", conditional, nil, "conditional steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &multiagentspec.Target{Name: "sk", Platform: multiagentspec.PlatformSemanticKernel, SemanticKernel: tt.cfg}
+			_, err := Generate(tt.project, target)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
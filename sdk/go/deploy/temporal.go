@@ -0,0 +1,488 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Temporal defaults applied when TemporalConfig or the step runtime leave a
+// field empty.
+const (
+	DefaultTemporalOutput    = "temporal"
+	DefaultTemporalHostPort  = "localhost:7233"
+	DefaultTemporalNamespace = "default"
+	DefaultTemporalTimeout   = 10 * time.Minute
+)
+
+func init() {
+	Register(TemporalGenerator{})
+}
+
+// TemporalGenerator emits a Temporal Go worker for the team's deterministic
+// workflow. Each step runs as a RunAgent activity once its dependencies
+// complete; activity timeouts and retry policies come from the target's
+// runtime settings. Activities run agents through the Claude CLI.
+//
+// Temporal has no linear backoff and no allow-list of retryable errors, so
+// backoff "linear" is rejected and retryable_errors is ignored.
+type TemporalGenerator struct{}
+
+// Platform returns PlatformTemporal.
+func (TemporalGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformTemporal
+}
+
+type temporalAgent struct {
+	Name         string
+	Model        string
+	Tools        string
+	Instructions string
+}
+
+type temporalStep struct {
+	Name      string
+	Agent     string
+	DependsOn []string
+	Timeout   string // Go expression
+	Retry     temporalRetry
+}
+
+// temporalRetry holds Go expressions for temporal.RetryPolicy fields; empty
+// fields are omitted.
+type temporalRetry struct {
+	InitialInterval    string
+	BackoffCoefficient string
+	MaximumInterval    string
+	MaximumAttempts    int
+}
+
+type temporalData struct {
+	Module    string
+	Workflow  string
+	TaskQueue string
+	HostPort  string
+	Namespace string
+	Agents    []temporalAgent
+	Steps     []temporalStep
+	Terminal  []string
+}
+
+// Generate returns go.mod, main.go, workflow.go, and activities.go.
+func (TemporalGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.TemporalConfig{}
+	if target.Temporal != nil {
+		cfg = *target.Temporal
+	}
+	team := teamName(project)
+	if cfg.HostPort == "" {
+		cfg.HostPort = DefaultTemporalHostPort
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = DefaultTemporalNamespace
+	}
+	if cfg.TaskQueue == "" {
+		cfg.TaskQueue = team
+	}
+
+	if project.Team == nil || project.Team.Workflow == nil || len(project.Team.Workflow.Steps) == 0 {
+		return nil, fmt.Errorf("temporal: team has no workflow steps")
+	}
+	wf := project.Team.Workflow
+	if wf.Type != "" && !wf.Type.IsDeterministic() {
+		return nil, fmt.Errorf("temporal: workflow type %q is not deterministic", wf.Type)
+	}
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	data := temporalData{
+		Module:    team,
+		Workflow:  pascalCase(team) + "Workflow",
+		TaskQueue: cfg.TaskQueue,
+		HostPort:  cfg.HostPort,
+		Namespace: cfg.Namespace,
+	}
+	byName := make(map[string]string, len(agents)*2)
+	for _, a := range agents {
+		model := a.Model
+		if model == "" {
+			model = multiagentspec.ModelSonnet
+		}
+		ta := temporalAgent{
+			Name:         k8sName(a.QualifiedName()),
+			Model:        target.ResolveModel(model),
+			Tools:        strings.Join(a.Tools, ","),
+			Instructions: a.PromptInstructions(multiagentspec.ExampleFormatMarkdown),
+		}
+		data.Agents = append(data.Agents, ta)
+		byName[a.QualifiedName()] = ta.Name
+		if _, ok := byName[a.Name]; !ok {
+			byName[a.Name] = ta.Name
+		}
+	}
+
+	known := make(map[string]bool, len(wf.Steps))
+	needed := make(map[string]bool, len(wf.Steps))
+	for _, s := range wf.Steps {
+		known[s.Name] = true
+	}
+	for _, s := range wf.Steps {
+		agent, ok := byName[s.Agent]
+		if !ok {
+			return nil, fmt.Errorf("temporal: step %s references unknown agent %q", s.Name, s.Agent)
+		}
+		for _, d := range s.DependsOn {
+			if !known[d] {
+				return nil, fmt.Errorf("temporal: step %s depends on unknown step %q", s.Name, d)
+			}
+			needed[d] = true
+		}
+		rt := target.Runtime.ForStep(s.Name)
+		if s.When != "" || rt.Condition != "" {
+			return nil, fmt.Errorf("temporal: step %s: conditional steps are not supported", s.Name)
+		}
+		ts := temporalStep{Name: s.Name, Agent: agent, DependsOn: s.DependsOn, Timeout: goDuration(DefaultTemporalTimeout)}
+		if rt.Timeout != "" {
+			d, err := time.ParseDuration(rt.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("temporal: step %s: invalid timeout %q: %w", s.Name, rt.Timeout, err)
+			}
+			ts.Timeout = goDuration(d)
+		}
+		ts.Retry, err = temporalRetryPolicy(rt.Retry)
+		if err != nil {
+			return nil, fmt.Errorf("temporal: step %s: %w", s.Name, err)
+		}
+		data.Steps = append(data.Steps, ts)
+	}
+	for _, s := range wf.Steps {
+		if !needed[s.Name] {
+			data.Terminal = append(data.Terminal, s.Name)
+		}
+	}
+
+	out := target.Output
+	if out == "" {
+		out = DefaultTemporalOutput
+	}
+	files := []File{{Path: path.Join(out, "go.mod"), Content: []byte(fmt.Sprintf("module %s\n\ngo 1.23\n", data.Module))}}
+	for _, f := range []struct {
+		name string
+		tmpl *template.Template
+	}{
+		{"main.go", temporalMainTemplate},
+		{"workflow.go", temporalWorkflowTemplate},
+		{"activities.go", temporalActivitiesTemplate},
+	} {
+		var buf bytes.Buffer
+		if err := f.tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("temporal: render %s: %w", f.name, err)
+		}
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("temporal: format %s: %w", f.name, err)
+		}
+		files = append(files, File{Path: path.Join(out, f.name), Content: src})
+	}
+	return files, nil
+}
+
+// temporalRetryPolicy converts a spec retry policy. Without a policy a step
+// runs once; MaxAttempts counts retries, so Temporal's attempt limit is one
+// higher.
+func temporalRetryPolicy(p *multiagentspec.RetryPolicy) (temporalRetry, error) {
+	if p == nil {
+		return temporalRetry{MaximumAttempts: 1}, nil
+	}
+	r := temporalRetry{MaximumAttempts: p.MaxAttempts + 1}
+	switch p.Backoff {
+	case "", "exponential":
+	case "fixed":
+		r.BackoffCoefficient = "1.0"
+	default:
+		return r, fmt.Errorf("unsupported backoff %q (want fixed or exponential)", p.Backoff)
+	}
+	for _, f := range []struct {
+		name  string
+		value string
+		dst   *string
+	}{
+		{"initial_delay", p.InitialDelay, &r.InitialInterval},
+		{"max_delay", p.MaxDelay, &r.MaximumInterval},
+	} {
+		if f.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(f.value)
+		if err != nil {
+			return r, fmt.Errorf("invalid %s %q: %w", f.name, f.value, err)
+		}
+		*f.dst = goDuration(d)
+	}
+	return r, nil
+}
+
+// goDuration renders d as a Go expression in the largest whole unit.
+func goDuration(d time.Duration) string {
+	for _, u := range []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	} {
+		if d >= u.unit && d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+var temporalFuncs = template.FuncMap{
+	"quote": strconv.Quote,
+}
+
+var temporalMainTemplate = template.Must(template.New("main.go").Funcs(temporalFuncs).Parse(`// Command {{.Module}} runs the Temporal worker for the {{.Module}} team.
+// Generated by mas deploy generate.
+//
+// Run the worker:
+//
+//	go run .
+//
+// Start a workflow run and print the results of the final steps:
+//
+//	go run . -start "<request>"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+)
+
+const taskQueue = {{quote .TaskQueue}}
+
+func main() {
+	start := flag.Bool("start", false, "start a workflow run with the remaining arguments as the request")
+	flag.Parse()
+
+	c, err := client.Dial(client.Options{
+		HostPort:  getenv("TEMPORAL_ADDRESS", {{quote .HostPort}}),
+		Namespace: getenv("TEMPORAL_NAMESPACE", {{quote .Namespace}}),
+	})
+	if err != nil {
+		log.Fatalf("connect to temporal: %v", err)
+	}
+	defer c.Close()
+
+	if *start {
+		run, err := c.ExecuteWorkflow(context.Background(), client.StartWorkflowOptions{TaskQueue: taskQueue}, {{.Workflow}}, strings.Join(flag.Args(), " "))
+		if err != nil {
+			log.Fatalf("start workflow: %v", err)
+		}
+		var results map[string]string
+		if err := run.Get(context.Background(), &results); err != nil {
+			log.Fatalf("workflow %s: %v", run.GetID(), err)
+		}
+		for _, name := range terminalSteps {
+			fmt.Println(results[name])
+		}
+		return
+	}
+
+	w := worker.New(c, taskQueue, worker.Options{})
+	w.RegisterWorkflow({{.Workflow}})
+	w.RegisterActivity(RunAgent)
+	if err := w.Run(worker.InterruptCh()); err != nil {
+		log.Fatalf("worker: %v", err)
+	}
+}
+
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+`))
+
+var temporalWorkflowTemplate = template.Must(template.New("workflow.go").Funcs(temporalFuncs).Parse(`// Generated by mas deploy generate.
+
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// step is a workflow step and the options of its activity.
+type step struct {
+	Name      string
+	Agent     string
+	DependsOn []string
+	Options   workflow.ActivityOptions
+}
+
+var steps = []step{
+{{- range .Steps}}
+	{
+		Name:  {{quote .Name}},
+		Agent: {{quote .Agent}},
+{{- if .DependsOn}}
+		DependsOn: []string{ {{- range $i, $d := .DependsOn}}{{if $i}}, {{end}}{{quote $d}}{{end -}} },
+{{- end}}
+		Options: workflow.ActivityOptions{
+			StartToCloseTimeout: {{.Timeout}},
+			RetryPolicy: &temporal.RetryPolicy{
+{{- with .Retry}}
+{{- if .InitialInterval}}
+				InitialInterval: {{.InitialInterval}},
+{{- end}}
+{{- if .BackoffCoefficient}}
+				BackoffCoefficient: {{.BackoffCoefficient}},
+{{- end}}
+{{- if .MaximumInterval}}
+				MaximumInterval: {{.MaximumInterval}},
+{{- end}}
+				MaximumAttempts: {{.MaximumAttempts}},
+{{- end}}
+			},
+		},
+	},
+{{- end}}
+}
+
+// terminalSteps are the steps no other step depends on.
+var terminalSteps = []string{ {{- range $i, $s := .Terminal}}{{if $i}}, {{end}}{{quote $s}}{{end -}} }
+
+// {{.Workflow}} runs every step as soon as its dependencies complete and
+// returns each step's output keyed by step name. A step receives the request
+// if it has no dependencies, or its dependencies' outputs otherwise.
+func {{.Workflow}}(ctx workflow.Context, request string) (map[string]string, error) {
+	futures := make(map[string]workflow.Future, len(steps))
+	settables := make(map[string]workflow.Settable, len(steps))
+	for _, s := range steps {
+		futures[s.Name], settables[s.Name] = workflow.NewFuture(ctx)
+	}
+
+	for _, s := range steps {
+		s := s
+		workflow.Go(ctx, func(ctx workflow.Context) {
+			input := request
+			if len(s.DependsOn) > 0 {
+				parts := make([]string, 0, len(s.DependsOn))
+				for _, dep := range s.DependsOn {
+					var out string
+					if err := futures[dep].Get(ctx, &out); err != nil {
+						settables[s.Name].SetError(fmt.Errorf("dependency %s failed: %w", dep, err))
+						return
+					}
+					parts = append(parts, dep+":\n"+out)
+				}
+				input = strings.Join(parts, "\n\n")
+			}
+			var out string
+			err := workflow.ExecuteActivity(workflow.WithActivityOptions(ctx, s.Options), RunAgent, AgentInput{Agent: s.Agent, Input: input}).Get(ctx, &out)
+			settables[s.Name].Set(out, err)
+		})
+	}
+
+	results := make(map[string]string, len(steps))
+	for _, s := range steps {
+		var out string
+		if err := futures[s.Name].Get(ctx, &out); err != nil {
+			return results, fmt.Errorf("step %s: %w", s.Name, err)
+		}
+		results[s.Name] = out
+	}
+	return results, nil
+}
+
+`))
+
+var temporalActivitiesTemplate = template.Must(template.New("activities.go").Funcs(temporalFuncs).Parse(`// Generated by mas deploy generate.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+// AgentInput is the input of a RunAgent activity.
+type AgentInput struct {
+	Agent string
+	Input string
+}
+
+type agentSpec struct {
+	Model        string
+	Tools        string
+	Instructions string
+}
+
+var agents = map[string]agentSpec{
+{{- range .Agents}}
+	{{quote .Name}}: {
+		Model: {{quote .Model}},
+{{- if .Tools}}
+		Tools: {{quote .Tools}},
+{{- end}}
+{{- if .Instructions}}
+		Instructions: {{quote .Instructions}},
+{{- end}}
+	},
+{{- end}}
+}
+
+// RunAgent runs one agent turn with the Claude CLI and returns its reply.
+// Set CLAUDE_BIN to use a different binary.
+func RunAgent(ctx context.Context, in AgentInput) (string, error) {
+	spec, ok := agents[in.Agent]
+	if !ok {
+		return "", temporal.NewNonRetryableApplicationError("unknown agent "+in.Agent, "UnknownAgent", nil)
+	}
+	bin := os.Getenv("CLAUDE_BIN")
+	if bin == "" {
+		bin = "claude"
+	}
+	args := []string{"-p", in.Input, "--model", spec.Model}
+	if spec.Tools != "" {
+		args = append(args, "--allowedTools", spec.Tools)
+	}
+	if spec.Instructions != "" {
+		args = append(args, "--append-system-prompt", spec.Instructions)
+	}
+	out, err := exec.CommandContext(ctx, bin, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("run agent %s: %w", in.Agent, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+`))
@@ -0,0 +1,120 @@
+package deploy
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func temporalTarget() *multiagentspec.Target {
+	return &multiagentspec.Target{
+		Name:     "temporal",
+		Platform: multiagentspec.PlatformTemporal,
+		Temporal: &multiagentspec.TemporalConfig{TaskQueue: "releases"},
+		Runtime: &multiagentspec.RuntimeConfig{
+			Defaults: &multiagentspec.StepRuntime{Timeout: "5m"},
+			Steps: map[string]*multiagentspec.StepRuntime{
+				"unit-tests": {
+					Timeout: "90s",
+					Retry:   &multiagentspec.RetryPolicy{MaxAttempts: 2, Backoff: "fixed", InitialDelay: "10s"},
+				},
+			},
+		},
+	}
+}
+
+func TestTemporalGenerator(t *testing.T) {
+	files, err := Generate(semanticKernelProject(), temporalTarget())
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+	if got["temporal/go.mod"] != "module release-team\n\ngo 1.23\n" {
+		t.Errorf("go.mod = %q", got["temporal/go.mod"])
+	}
+	for _, name := range []string{"main.go", "workflow.go", "activities.go"} {
+		if _, err := parser.ParseFile(token.NewFileSet(), name, got["temporal/"+name], 0); err != nil {
+			t.Errorf("%s does not parse: %v", name, err)
+		}
+	}
+
+	checks := map[string][]string{
+		"temporal/main.go": {
+			`const taskQueue = "releases"`,
+			`getenv("TEMPORAL_ADDRESS", "localhost:7233")`,
+			"w.RegisterWorkflow(ReleaseTeamWorkflow)",
+		},
+		"temporal/workflow.go": {
+			"StartToCloseTimeout: 5 * time.Minute,\n\t\t\tRetryPolicy: &temporal.RetryPolicy{\n\t\t\t\tMaximumAttempts: 1,",
+			"StartToCloseTimeout: 90 * time.Second,",
+			"InitialInterval:    10 * time.Second,\n\t\t\t\tBackoffCoefficient: 1.0,\n\t\t\t\tMaximumAttempts:    3,",
+			`DependsOn: []string{"unit-tests", "lint"},`,
+			`var terminalSteps = []string{"approve"}`,
+			"func ReleaseTeamWorkflow(ctx workflow.Context, request string) (map[string]string, error) {",
+		},
+		"temporal/activities.go": {
+			`"shared-qa": {`,
+			`Tools: "Read,Bash",`,
+			`Instructions: "Review the plan.",`,
+		},
+	}
+	for name, wants := range checks {
+		for _, want := range wants {
+			if !strings.Contains(got[name], want) {
+				t.Errorf("%s missing %q:\n%s", name, want, got[name])
+			}
+		}
+	}
+}
+
+func TestTemporalGeneratorErrors(t *testing.T) {
+	linear := temporalTarget()
+	linear.Runtime.Steps["lint"] = &multiagentspec.StepRuntime{Retry: &multiagentspec.RetryPolicy{Backoff: "linear"}}
+	badTimeout := temporalTarget()
+	badTimeout.Runtime.Defaults.Timeout = "soon"
+	conditional := temporalTarget()
+	conditional.Runtime.Steps["approve"] = &multiagentspec.StepRuntime{Condition: "lint.ok"}
+
+	tests := []struct {
+		name    string
+		project *Project
+		target  *multiagentspec.Target
+		want    string
+	}{
+		{"no workflow", testProject(), temporalTarget(), "no workflow steps"},
+		{"linear backoff", semanticKernelProject(), linear, `unsupported backoff "linear"`},
+		{"timeout", semanticKernelProject(), badTimeout, `invalid timeout "soon"`},
+		{"condition", semanticKernelProject(), conditional, "conditional steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.project, tt.target)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoDuration(t *testing.T) {
+	tests := map[string]string{
+		"2h":    "2 * time.Hour",
+		"90m":   "90 * time.Minute",
+		"1m30s": "90 * time.Second",
+		"1.5s":  "1500 * time.Millisecond",
+		"0s":    "time.Duration(0)",
+	}
+	for in, want := range tests {
+		d, err := time.ParseDuration(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := goDuration(d); got != want {
+			t.Errorf("goDuration(%s) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Vertex AI defaults applied when VertexAIConfig leaves a field empty.
+const (
+	DefaultVertexAIOutput   = "vertex"
+	DefaultVertexAILocation = "us-central1"
+)
+
+// vertexAIConfigSchema is the ADK agent config schema referenced from every
+// generated YAML file for editor validation.
+const vertexAIConfigSchema = "https://raw.githubusercontent.com/google/adk-python/refs/heads/main/src/google/adk/agents/config_schemas/AgentConfig.json"
+
+func init() {
+	Register(VertexAIGenerator{})
+}
+
+// VertexAIGenerator emits an Agent Builder agent defined in ADK agent
+// config YAML: root_agent.yaml for the orchestrator (or a generated
+// coordinator), one YAML file per sub-agent, and tools.py with a function
+// tool per command task. Configured data stores ground the root agent
+// through Vertex AI Search.
+type VertexAIGenerator struct{}
+
+// Platform returns PlatformVertexAI.
+func (VertexAIGenerator) Platform() multiagentspec.Platform {
+	return multiagentspec.PlatformVertexAI
+}
+
+// vertexAIAgentConfig is an ADK LlmAgent config file.
+type vertexAIAgentConfig struct {
+	AgentClass  string             `yaml:"agent_class"`
+	Name        string             `yaml:"name"`
+	Model       string             `yaml:"model"`
+	Description string             `yaml:"description,omitempty"`
+	Instruction string             `yaml:"instruction"`
+	Tools       []vertexAITool     `yaml:"tools,omitempty"`
+	SubAgents   []vertexAISubAgent `yaml:"sub_agents,omitempty"`
+}
+
+type vertexAITool struct {
+	Name string            `yaml:"name"`
+	Args map[string]string `yaml:"args,omitempty"`
+}
+
+type vertexAISubAgent struct {
+	ConfigPath string `yaml:"config_path"`
+}
+
+type vertexAIFunction struct {
+	Name        string
+	Description string
+	Command     string
+}
+
+// vertexAISearchTool returns a Vertex AI Search tool for the data store
+// ds, given as an ID in the project's default collection or a full
+// resource name.
+func vertexAISearchTool(project, ds string) vertexAITool {
+	if !strings.HasPrefix(ds, "projects/") {
+		ds = fmt.Sprintf("projects/%s/locations/global/collections/default_collection/dataStores/%s", project, ds)
+	}
+	return vertexAITool{
+		Name: "google.adk.tools.VertexAiSearchTool",
+		Args: map[string]string{"data_store_id": ds},
+	}
+}
+
+// vertexAIBuiltinTools maps spec tools to ADK built-in tools.
+var vertexAIBuiltinTools = map[string]string{
+	"WebSearch": "google_search",
+}
+
+// Generate returns the agent config files, tools.py, and .env under
+// Target.Output/<team>.
+func (VertexAIGenerator) Generate(project *Project, target *multiagentspec.Target) ([]File, error) {
+	cfg := multiagentspec.VertexAIConfig{}
+	if target.VertexAI != nil {
+		cfg = *target.VertexAI
+	}
+	if cfg.Project == "" {
+		return nil, fmt.Errorf("vertex-ai: project is required")
+	}
+	if cfg.Location == "" {
+		cfg.Location = DefaultVertexAILocation
+	}
+	if cfg.Model == "" {
+		cfg.Model = DefaultADKGoModel
+	}
+	model := target.ResolveModel(multiagentspec.Model(cfg.Model))
+
+	agents, err := project.TeamAgents()
+	if err != nil {
+		return nil, err
+	}
+	if len(agents) == 0 {
+		return nil, fmt.Errorf("vertex-ai: no agents to deploy")
+	}
+
+	team := teamName(project)
+	pkg := snakeCase(team)
+	out := target.Output
+	if out == "" {
+		out = DefaultVertexAIOutput
+	}
+	dir := path.Join(out, pkg)
+
+	var functions []vertexAIFunction
+	configs := make([]vertexAIAgentConfig, 0, len(agents))
+	orchestrator := -1
+	for i, a := range agents {
+		c := vertexAIAgentConfig{
+			AgentClass:  "LlmAgent",
+			Name:        snakeCase(a.QualifiedName()),
+			Model:       model,
+			Description: a.Description,
+			Instruction: a.PromptInstructions(multiagentspec.ExampleFormatMarkdown),
+		}
+		if a.Model != "" {
+			c.Model = target.ResolveModel(a.Model)
+		}
+		for _, t := range a.Tools {
+			if builtin, ok := vertexAIBuiltinTools[t]; ok {
+				c.Tools = append(c.Tools, vertexAITool{Name: builtin})
+			}
+		}
+		for _, k := range a.VectorIndexes() {
+			c.Tools = append(c.Tools, vertexAISearchTool(cfg.Project, k.VectorIndex))
+		}
+		for _, t := range a.Tasks {
+			if t.Type != multiagentspec.TaskTypeCommand || t.Command == "" {
+				continue
+			}
+			fn := vertexAIFunction{Name: c.Name + "_" + snakeCase(t.ID), Description: t.Description, Command: t.Command}
+			if fn.Description == "" {
+				fn.Description = "Run " + t.Command
+			}
+			functions = append(functions, fn)
+			c.Tools = append(c.Tools, vertexAITool{Name: pkg + ".tools." + fn.Name})
+		}
+		if project.Team != nil && project.Team.Orchestrator != "" &&
+			(project.Team.Orchestrator == a.QualifiedName() || project.Team.Orchestrator == a.Name) {
+			orchestrator = i
+		}
+		configs = append(configs, c)
+	}
+	if orchestrator < 0 && len(configs) == 1 {
+		orchestrator = 0
+	}
+
+	var root vertexAIAgentConfig
+	if orchestrator >= 0 {
+		root = configs[orchestrator]
+		configs = append(configs[:orchestrator:orchestrator], configs[orchestrator+1:]...)
+	} else {
+		root = vertexAIAgentConfig{
+			AgentClass:  "LlmAgent",
+			Name:        pkg,
+			Model:       model,
+			Instruction: "Route each request to the sub-agent best suited to handle it.",
+		}
+		if project.Team != nil {
+			root.Description = project.Team.Description
+		}
+	}
+	for _, c := range configs {
+		root.SubAgents = append(root.SubAgents, vertexAISubAgent{ConfigPath: c.Name + ".yaml"})
+	}
+	for _, ds := range cfg.DataStores {
+		root.Tools = append(root.Tools, vertexAISearchTool(cfg.Project, ds))
+	}
+
+	var files []File
+	addConfig := func(name string, c vertexAIAgentConfig) error {
+		data, err := marshalYAML(c)
+		if err != nil {
+			return fmt.Errorf("vertex-ai: %s: %w", name, err)
+		}
+		content := append([]byte("# yaml-language-server: $schema="+vertexAIConfigSchema+"\n"), data...)
+		files = append(files, File{Path: path.Join(dir, name), Content: content})
+		return nil
+	}
+	if err := addConfig("root_agent.yaml", root); err != nil {
+		return nil, err
+	}
+	for _, c := range configs {
+		if err := addConfig(c.Name+".yaml", c); err != nil {
+			return nil, err
+		}
+	}
+
+	files = append(files,
+		File{Path: path.Join(dir, "__init__.py"), Content: []byte{}},
+		File{Path: path.Join(dir, ".env"), Content: []byte(fmt.Sprintf(
+			"GOOGLE_GENAI_USE_VERTEXAI=TRUE\nGOOGLE_CLOUD_PROJECT=%s\nGOOGLE_CLOUD_LOCATION=%s\n", cfg.Project, cfg.Location))},
+	)
+	if len(functions) > 0 {
+		var buf bytes.Buffer
+		if err := vertexAIToolsTemplate.Execute(&buf, struct {
+			Team      string
+			Functions []vertexAIFunction
+		}{team, functions}); err != nil {
+			return nil, fmt.Errorf("vertex-ai: render tools.py: %w", err)
+		}
+		files = append(files, File{Path: path.Join(dir, "tools.py"), Content: buf.Bytes()})
+	}
+	return files, nil
+}
+
+var vertexAIToolsTemplate = template.Must(template.New("tools.py").Funcs(template.FuncMap{
+	"py":     pyString,
+	"pyText": langGraphFuncs["pyText"],
+}).Parse(`"""Function tools for the {{.Team}} team.
+
+Generated by mas deploy generate from the agents' command tasks.
+"""
+
+import subprocess
+
+
+def _run(command: str) -> dict:
+    proc = subprocess.run(command, shell=True, capture_output=True, text=True)
+    return {"exit_code": proc.returncode, "output": (proc.stdout + proc.stderr)[-8000:]}
+{{range .Functions}}
+
+def {{.Name}}() -> dict:
+    {{pyText .Description}}
+    return _run({{py .Command}})
+{{end}}`))
@@ -0,0 +1,116 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"gopkg.in/yaml.v3"
+)
+
+func TestVertexAIGenerator(t *testing.T) {
+	p := agentCoreProject()
+	p.Team.Orchestrator = "pm"
+	p.Agents[1].WithTools("WebSearch")
+	target := &multiagentspec.Target{
+		Name:     "vertex",
+		Platform: multiagentspec.PlatformVertexAI,
+		VertexAI: &multiagentspec.VertexAIConfig{
+			Project:    "acme-prod",
+			Location:   "europe-west4",
+			DataStores: []string{"runbooks", "projects/other/locations/eu/collections/default_collection/dataStores/docs"},
+		},
+	}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+
+	var root vertexAIAgentConfig
+	if err := yaml.Unmarshal([]byte(got["vertex/release_team/root_agent.yaml"]), &root); err != nil {
+		t.Fatalf("root_agent.yaml: %v", err)
+	}
+	if root.Name != "pm" || root.Model != "gemini-2.5-pro" {
+		t.Errorf("root = %+v", root)
+	}
+	if len(root.SubAgents) != 1 || root.SubAgents[0].ConfigPath != "shared_qa.yaml" {
+		t.Errorf("sub_agents = %+v", root.SubAgents)
+	}
+	if len(root.Tools) != 3 || root.Tools[0].Name != "google_search" ||
+		root.Tools[1].Args["data_store_id"] != "projects/acme-prod/locations/global/collections/default_collection/dataStores/runbooks" ||
+		root.Tools[2].Args["data_store_id"] != "projects/other/locations/eu/collections/default_collection/dataStores/docs" {
+		t.Errorf("root tools = %+v", root.Tools)
+	}
+
+	var qa vertexAIAgentConfig
+	if err := yaml.Unmarshal([]byte(got["vertex/release_team/shared_qa.yaml"]), &qa); err != nil {
+		t.Fatalf("shared_qa.yaml: %v", err)
+	}
+	if len(qa.Tools) != 1 || qa.Tools[0].Name != "release_team.tools.shared_qa_unit_tests" {
+		t.Errorf("qa tools = %+v", qa.Tools)
+	}
+	if !strings.HasPrefix(got["vertex/release_team/shared_qa.yaml"], "# yaml-language-server: $schema=") {
+		t.Error("agent config missing schema modeline")
+	}
+
+	tools := got["vertex/release_team/tools.py"]
+	if !strings.Contains(tools, "def shared_qa_unit_tests() -> dict:\n    \"\"\"Run unit tests\"\"\"\n    return _run(\"go test ./...\")") {
+		t.Errorf("tools.py:\n%s", tools)
+	}
+	if env := got["vertex/release_team/.env"]; !strings.Contains(env, "GOOGLE_CLOUD_PROJECT=acme-prod\nGOOGLE_CLOUD_LOCATION=europe-west4\n") {
+		t.Errorf(".env:\n%s", env)
+	}
+}
+
+func TestVertexAIGeneratorKnowledgeSources(t *testing.T) {
+	p := testProject()
+	p.Agents[0].KnowledgeSources = []multiagentspec.KnowledgeSource{
+		{Name: "tests", VectorIndex: "test-plans"},
+		{Name: "local", Path: "docs"},
+	}
+	target := &multiagentspec.Target{Name: "vertex", Platform: multiagentspec.PlatformVertexAI, VertexAI: &multiagentspec.VertexAIConfig{Project: "acme"}}
+	files, err := Generate(p, target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	var qa vertexAIAgentConfig
+	if err := yaml.Unmarshal([]byte(filesByPath(files)["vertex/release_team/shared_qa.yaml"]), &qa); err != nil {
+		t.Fatalf("shared_qa.yaml: %v", err)
+	}
+	if len(qa.Tools) != 1 || qa.Tools[0].Name != "google.adk.tools.VertexAiSearchTool" ||
+		qa.Tools[0].Args["data_store_id"] != "projects/acme/locations/global/collections/default_collection/dataStores/test-plans" {
+		t.Errorf("qa tools = %+v", qa.Tools)
+	}
+}
+
+func TestVertexAIGeneratorCoordinator(t *testing.T) {
+	target := &multiagentspec.Target{
+		Name:     "vertex",
+		Platform: multiagentspec.PlatformVertexAI,
+		VertexAI: &multiagentspec.VertexAIConfig{Project: "acme"},
+	}
+	files, err := Generate(testProject(), target)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	got := filesByPath(files)
+
+	var root vertexAIAgentConfig
+	if err := yaml.Unmarshal([]byte(got["vertex/release_team/root_agent.yaml"]), &root); err != nil {
+		t.Fatalf("root_agent.yaml: %v", err)
+	}
+	if root.Name != "release_team" || len(root.SubAgents) != 2 {
+		t.Errorf("coordinator = %+v", root)
+	}
+	if _, ok := got["vertex/release_team/tools.py"]; ok {
+		t.Error("tools.py generated without command tasks")
+	}
+}
+
+func TestVertexAIGeneratorRequiresProject(t *testing.T) {
+	target := &multiagentspec.Target{Name: "vertex", Platform: multiagentspec.PlatformVertexAI}
+	if _, err := Generate(testProject(), target); err == nil || !strings.Contains(err.Error(), "project is required") {
+		t.Errorf("err = %v", err)
+	}
+}
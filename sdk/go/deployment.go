@@ -1,24 +1,51 @@
 package multiagentspec
 
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
 // Platform represents supported deployment platforms.
 type Platform string
 
 const (
-	PlatformClaudeCode    Platform = "claude-code"
-	PlatformGeminiCLI     Platform = "gemini-cli"
-	PlatformKiroCLI       Platform = "kiro-cli"
-	PlatformADKGo         Platform = "adk-go"
-	PlatformCrewAI        Platform = "crewai"
-	PlatformAutoGen       Platform = "autogen"
-	PlatformAWSAgentCore  Platform = "aws-agentcore"
-	PlatformAWSEKS        Platform = "aws-eks"
-	PlatformAzureAKS      Platform = "azure-aks"
-	PlatformGCPGKE        Platform = "gcp-gke"
-	PlatformKubernetes    Platform = "kubernetes"
-	PlatformDockerCompose Platform = "docker-compose"
-	PlatformAgentKitLocal Platform = "agentkit-local"
+	PlatformClaudeCode       Platform = "claude-code"
+	PlatformGeminiCLI        Platform = "gemini-cli"
+	PlatformKiroCLI          Platform = "kiro-cli"
+	PlatformADKGo            Platform = "adk-go"
+	PlatformCrewAI           Platform = "crewai"
+	PlatformAutoGen          Platform = "autogen"
+	PlatformAWSAgentCore     Platform = "aws-agentcore"
+	PlatformAWSEKS           Platform = "aws-eks"
+	PlatformAzureAKS         Platform = "azure-aks"
+	PlatformGCPGKE           Platform = "gcp-gke"
+	PlatformKubernetes       Platform = "kubernetes"
+	PlatformDockerCompose    Platform = "docker-compose"
+	PlatformAgentKitLocal    Platform = "agentkit-local"
+	PlatformLangGraph        Platform = "langgraph"
+	PlatformOpenAIAgents     Platform = "openai-agents"
+	PlatformSemanticKernel   Platform = "semantic-kernel"
+	PlatformAWSBedrockAgents Platform = "aws-bedrock-agents"
+	PlatformVertexAI         Platform = "vertex-ai"
+	PlatformTemporal         Platform = "temporal"
+	PlatformN8N              Platform = "n8n"
 )
 
+// Platforms returns all supported deployment platforms in schema order.
+func Platforms() []Platform {
+	return []Platform{
+		PlatformClaudeCode, PlatformGeminiCLI, PlatformKiroCLI, PlatformADKGo,
+		PlatformCrewAI, PlatformAutoGen, PlatformAWSAgentCore, PlatformAWSEKS,
+		PlatformAzureAKS, PlatformGCPGKE, PlatformKubernetes, PlatformDockerCompose,
+		PlatformAgentKitLocal, PlatformLangGraph, PlatformOpenAIAgents,
+		PlatformSemanticKernel, PlatformAWSBedrockAgents, PlatformVertexAI,
+		PlatformTemporal, PlatformN8N,
+	}
+}
+
 // DeploymentMode represents the deployment execution mode.
 type DeploymentMode string
 
@@ -29,6 +56,51 @@ const (
 	ModeServerless    DeploymentMode = "serverless"
 )
 
+// DeploymentModes returns all deployment modes in schema order.
+func DeploymentModes() []DeploymentMode {
+	return []DeploymentMode{ModeSingleProcess, ModeMultiProcess, ModeDistributed, ModeServerless}
+}
+
+// platformModes lists the deployment modes each platform can run in.
+var platformModes = map[Platform][]DeploymentMode{
+	PlatformClaudeCode:       {ModeSingleProcess, ModeMultiProcess},
+	PlatformGeminiCLI:        {ModeSingleProcess},
+	PlatformKiroCLI:          {ModeSingleProcess},
+	PlatformADKGo:            {ModeSingleProcess},
+	PlatformCrewAI:           {ModeSingleProcess},
+	PlatformAutoGen:          {ModeSingleProcess, ModeDistributed},
+	PlatformAWSAgentCore:     {ModeServerless},
+	PlatformAWSEKS:           {ModeMultiProcess, ModeDistributed},
+	PlatformAzureAKS:         {ModeMultiProcess, ModeDistributed},
+	PlatformGCPGKE:           {ModeMultiProcess, ModeDistributed},
+	PlatformKubernetes:       {ModeMultiProcess, ModeDistributed},
+	PlatformDockerCompose:    {ModeMultiProcess},
+	PlatformAgentKitLocal:    {ModeSingleProcess, ModeMultiProcess},
+	PlatformLangGraph:        {ModeSingleProcess},
+	PlatformOpenAIAgents:     {ModeSingleProcess},
+	PlatformSemanticKernel:   {ModeSingleProcess},
+	PlatformAWSBedrockAgents: {ModeServerless},
+	PlatformVertexAI:         {ModeServerless},
+	PlatformTemporal:         {ModeMultiProcess, ModeDistributed},
+	PlatformN8N:              {ModeDistributed},
+}
+
+// Modes returns the deployment modes the platform can run in, or nil for an
+// unknown platform.
+func (p Platform) Modes() []DeploymentMode {
+	return platformModes[p]
+}
+
+// SupportsMode reports whether the platform can run in mode.
+func (p Platform) SupportsMode(mode DeploymentMode) bool {
+	for _, m := range platformModes[p] {
+		if m == mode {
+			return true
+		}
+	}
+	return false
+}
+
 // Priority represents deployment priority levels.
 type Priority string
 
@@ -38,6 +110,11 @@ const (
 	PriorityP3 Priority = "p3"
 )
 
+// Priorities returns all deployment priority levels in schema order.
+func Priorities() []Priority {
+	return []Priority{PriorityP1, PriorityP2, PriorityP3}
+}
+
 // Target represents a deployment target definition.
 type Target struct {
 	// Name is the unique name for this deployment target.
@@ -58,17 +135,32 @@ type Target struct {
 	// Runtime is the runtime configuration for workflow execution.
 	Runtime *RuntimeConfig `json:"runtime,omitempty"`
 
+	// Secrets maps environment variable names exposed to the agents to the
+	// secrets that supply their values.
+	Secrets map[string]SecretRef `json:"secrets,omitempty" jsonschema_description:"Secrets exposed to the agents, keyed by environment variable name"`
+
+	// Models overrides model identifiers for this target, keyed by canonical
+	// tier (haiku, sonnet, opus) or by model name.
+	Models map[Model]string `json:"models,omitempty" jsonschema_description:"Model identifier overrides keyed by canonical model tier or name"`
+
 	// Platform-specific configurations (use the one matching Platform field)
-	ClaudeCode    *ClaudeCodeConfig    `json:"claudeCode,omitempty"`
-	GeminiCLI     *GeminiCLIConfig     `json:"geminiCli,omitempty"`
-	KiroCLI       *KiroCLIConfig       `json:"kiroCli,omitempty"`
-	ADKGo         *ADKGoConfig         `json:"adkGo,omitempty"`
-	CrewAI        *CrewAIConfig        `json:"crewai,omitempty"`
-	AutoGen       *AutoGenConfig       `json:"autogen,omitempty"`
-	AWSAgentCore  *AWSAgentCoreConfig  `json:"awsAgentCore,omitempty"`
-	Kubernetes    *KubernetesConfig    `json:"kubernetes,omitempty"`
-	DockerCompose *DockerComposeConfig `json:"dockerCompose,omitempty"`
-	AgentKitLocal *AgentKitLocalConfig `json:"agentKitLocal,omitempty"`
+	ClaudeCode       *ClaudeCodeConfig       `json:"claudeCode,omitempty"`
+	GeminiCLI        *GeminiCLIConfig        `json:"geminiCli,omitempty"`
+	KiroCLI          *KiroCLIConfig          `json:"kiroCli,omitempty"`
+	ADKGo            *ADKGoConfig            `json:"adkGo,omitempty"`
+	CrewAI           *CrewAIConfig           `json:"crewai,omitempty"`
+	AutoGen          *AutoGenConfig          `json:"autogen,omitempty"`
+	AWSAgentCore     *AWSAgentCoreConfig     `json:"awsAgentCore,omitempty"`
+	Kubernetes       *KubernetesConfig       `json:"kubernetes,omitempty"`
+	DockerCompose    *DockerComposeConfig    `json:"dockerCompose,omitempty"`
+	AgentKitLocal    *AgentKitLocalConfig    `json:"agentKitLocal,omitempty"`
+	LangGraph        *LangGraphConfig        `json:"langgraph,omitempty"`
+	OpenAIAgents     *OpenAIAgentsConfig     `json:"openaiAgents,omitempty"`
+	SemanticKernel   *SemanticKernelConfig   `json:"semanticKernel,omitempty"`
+	AWSBedrockAgents *AWSBedrockAgentsConfig `json:"awsBedrockAgents,omitempty"`
+	VertexAI         *VertexAIConfig         `json:"vertexAi,omitempty"`
+	Temporal         *TemporalConfig         `json:"temporal,omitempty"`
+	N8N              *N8NConfig              `json:"n8n,omitempty"`
 }
 
 // RuntimeConfig holds runtime configuration for workflow execution.
@@ -83,6 +175,67 @@ type RuntimeConfig struct {
 	Observability *ObservabilityConfig `json:"observability,omitempty"`
 }
 
+// ForStep returns the runtime settings for the named step: the step's
+// overrides layered over Defaults, field by field. A nil RuntimeConfig
+// yields the zero StepRuntime.
+func (r *RuntimeConfig) ForStep(name string) StepRuntime {
+	var rt StepRuntime
+	if r == nil {
+		return rt
+	}
+	if r.Defaults != nil {
+		rt = *r.Defaults
+	}
+	o := r.Steps[name]
+	if o == nil {
+		return rt
+	}
+	if o.Timeout != "" {
+		rt.Timeout = o.Timeout
+	}
+	if o.Retry != nil {
+		rt.Retry = o.Retry
+	}
+	if o.Condition != "" {
+		rt.Condition = o.Condition
+	}
+	if o.Concurrency != 0 {
+		rt.Concurrency = o.Concurrency
+	}
+	if o.Resources != nil {
+		rt.Resources = o.Resources
+	}
+	if o.RateLimit != nil {
+		rt.RateLimit = o.RateLimit
+	}
+	return rt
+}
+
+// validateRateLimits checks the default and per-step rate limits.
+func (r *RuntimeConfig) validateRateLimits() error {
+	if r == nil {
+		return nil
+	}
+	if r.Defaults != nil && r.Defaults.RateLimit != nil {
+		if err := r.Defaults.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("runtime defaults: %w", err)
+		}
+	}
+	names := make([]string, 0, len(r.Steps))
+	for name := range r.Steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if rt := r.Steps[name]; rt != nil && rt.RateLimit != nil {
+			if err := rt.RateLimit.Validate(); err != nil {
+				return fmt.Errorf("step %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // StepRuntime holds runtime settings for a workflow step.
 type StepRuntime struct {
 	// Timeout is the step timeout (e.g., 30s, 5m, 1h).
@@ -99,6 +252,10 @@ type StepRuntime struct {
 
 	// Resources are resource limits for this step.
 	Resources *ResourceLimits `json:"resources,omitempty"`
+
+	// RateLimit caps the step's LLM calls across all its concurrent
+	// executions, overriding the agents' own rate limits.
+	RateLimit *RateLimit `json:"rate_limit,omitempty"`
 }
 
 // RetryPolicy defines the retry behavior for step failures.
@@ -131,18 +288,33 @@ type ObservabilityConfig struct {
 	Logging *LoggingConfig `json:"logging,omitempty"`
 }
 
-// TracingConfig holds distributed tracing configuration.
+// TracingConfig holds distributed tracing configuration. The Executor
+// records spans under it; see TracingConfig.Tracer.
 type TracingConfig struct {
-	Enabled    bool    `json:"enabled,omitempty"`
-	Exporter   string  `json:"exporter,omitempty"`
-	Endpoint   string  `json:"endpoint,omitempty"`
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Exporter is otlp, the default, or console.
+	Exporter string `json:"exporter,omitempty"`
+
+	// Endpoint is the OTLP/HTTP collector URL, or host:port; spans are
+	// posted to its /v1/traces path when it has none.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// SampleRate is the fraction of new traces sampled, from 0 to 1; zero
+	// samples every trace.
 	SampleRate float64 `json:"sample_rate,omitempty"`
 }
 
-// MetricsConfig holds metrics collection configuration.
+// MetricsConfig holds metrics collection configuration. See
+// MetricsConfig.Serve for serving an executor's metrics under it.
 type MetricsConfig struct {
-	Enabled  bool   `json:"enabled,omitempty"`
+	Enabled bool `json:"enabled,omitempty"`
+
+	// Exporter is prometheus, the default.
 	Exporter string `json:"exporter,omitempty"`
+
+	// Endpoint is the host:port, or :port, metrics are served on, with an
+	// optional path that defaults to /metrics.
 	Endpoint string `json:"endpoint,omitempty"`
 }
 
@@ -162,6 +334,219 @@ type Deployment struct {
 
 	// Targets is the list of deployment targets.
 	Targets []Target `json:"targets"`
+
+	// Environments holds per-environment target overrides keyed by
+	// environment name (e.g., dev, staging, prod).
+	Environments map[string]*Environment `json:"environments,omitempty" jsonschema_description:"Per-environment target overrides keyed by environment name"`
+
+	// Variables are values for {{ .vars.name }} placeholders in agent
+	// instructions, overriding the team's variables.
+	Variables map[string]string `json:"variables,omitempty" jsonschema_description:"Values for {{ .vars.name }} placeholders in agent instructions, overriding team variables"`
+}
+
+// SecretSource identifies where a secret value is stored.
+type SecretSource string
+
+const (
+	// SecretSourceEnv reads the value from an environment variable of the
+	// deploying or running process. Key is the variable name.
+	SecretSourceEnv SecretSource = "env"
+
+	// SecretSourceFile reads the value from a file. Key is the file path.
+	SecretSourceFile SecretSource = "file"
+
+	// SecretSourceAWSSecretsManager reads the value from AWS Secrets Manager.
+	// Key is the secret name or ARN, optionally followed by #property to
+	// select a field of a JSON secret.
+	SecretSourceAWSSecretsManager SecretSource = "aws-sm"
+
+	// SecretSourceVault reads the value from HashiCorp Vault. Key is the
+	// secret path, optionally followed by #property.
+	SecretSourceVault SecretSource = "vault"
+)
+
+// SecretSources returns all secret sources.
+func SecretSources() []SecretSource {
+	return []SecretSource{SecretSourceEnv, SecretSourceFile, SecretSourceAWSSecretsManager, SecretSourceVault}
+}
+
+// SecretRef references a secret by source and key instead of holding its value.
+type SecretRef struct {
+	// Source is where the secret is stored.
+	Source SecretSource `json:"source"`
+
+	// Key locates the secret within the source.
+	Key string `json:"key" jsonschema:"minLength=1" jsonschema_description:"Variable name, file path, or secret name/path, optionally followed by #property"`
+}
+
+// Validate checks that the reference names a known source and a key.
+func (r SecretRef) Validate() error {
+	switch r.Source {
+	case SecretSourceEnv, SecretSourceFile, SecretSourceAWSSecretsManager, SecretSourceVault:
+	case "":
+		return fmt.Errorf("secret source is required")
+	default:
+		return fmt.Errorf("unknown secret source %q", r.Source)
+	}
+	if r.Key == "" {
+		return fmt.Errorf("secret key is required")
+	}
+	return nil
+}
+
+// Path returns the key without its #property suffix.
+func (r SecretRef) Path() string {
+	path, _, _ := strings.Cut(r.Key, "#")
+	return path
+}
+
+// Property returns the #property suffix of the key, or "" if there is none.
+func (r SecretRef) Property() string {
+	_, property, _ := strings.Cut(r.Key, "#")
+	return property
+}
+
+// ValidateSecrets checks every target's secret references and reports
+// inline credentials: string values under credential-like field names
+// (password, apiKey, token, ...) or values that look like well-known key
+// formats. Environment overrides are checked as well.
+func (d *Deployment) ValidateSecrets() error {
+	for _, t := range d.Targets {
+		for _, name := range sortedKeys(t.Secrets) {
+			if !isEnvName(name) {
+				return fmt.Errorf("target %s: secret name %q is not a valid environment variable name", t.Name, name)
+			}
+			if err := t.Secrets[name].Validate(); err != nil {
+				return fmt.Errorf("target %s: secret %s: %w", t.Name, name, err)
+			}
+		}
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		if err := checkPlaintextCredentials(data); err != nil {
+			return fmt.Errorf("target %s: %w", t.Name, err)
+		}
+	}
+	for _, env := range d.EnvironmentNames() {
+		if d.Environments[env] == nil {
+			continue
+		}
+		for name, patch := range d.Environments[env].Targets {
+			if err := checkPlaintextCredentials(patch); err != nil {
+				return fmt.Errorf("environment %s: target %s: %w", env, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// credentialFields are normalized field-name suffixes that indicate a
+// credential value.
+var credentialFields = []string{"password", "passwd", "apikey", "secret", "secretkey", "accesskey", "privatekey", "accesstoken", "authtoken", "credentials"}
+
+// minCredentialLength is the shortest value checked against
+// credentialPrefixes.
+const minCredentialLength = 20
+
+// credentialPrefixes are value prefixes of well-known key formats.
+var credentialPrefixes = []string{"sk-", "sk_live_", "AKIA", "ASIA", "AIza", "ghp_", "gho_", "github_pat_", "xoxb-", "xoxp-", "-----BEGIN"}
+
+func checkPlaintextCredentials(data []byte) error {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return walkCredentials("", doc)
+}
+
+func walkCredentials(path string, v interface{}) error {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			// Secret references hold names and paths, not values.
+			if k == "secrets" && path == "" {
+				continue
+			}
+			child := k
+			if path != "" {
+				child = path + "." + k
+			}
+			if s, ok := v[k].(string); ok && s != "" && isCredentialField(k) {
+				return fmt.Errorf("%s holds a plaintext credential; use a secret reference", child)
+			}
+			if err := walkCredentials(child, v[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if err := walkCredentials(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+				return err
+			}
+		}
+	case string:
+		if len(v) < minCredentialLength {
+			return nil
+		}
+		for _, prefix := range credentialPrefixes {
+			if strings.HasPrefix(v, prefix) {
+				return fmt.Errorf("%s looks like a plaintext credential; use a secret reference", path)
+			}
+		}
+	}
+	return nil
+}
+
+func isCredentialField(name string) bool {
+	norm := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+	if norm == "token" {
+		return true
+	}
+	for _, f := range credentialFields {
+		if strings.HasSuffix(norm, f) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]SecretRef) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Environment holds target overrides for one deployment environment.
+type Environment struct {
+	// Targets maps base target names to partial target definitions. Each
+	// override is merged onto the base target with JSON merge patch
+	// semantics (RFC 7386): objects merge recursively, other values
+	// replace, and null removes a field.
+	Targets map[string]json.RawMessage `json:"targets" jsonschema_description:"Partial target definitions keyed by base target name, merged onto the base target as a JSON merge patch"`
 }
 
 // NewDeployment creates a new Deployment for the given team.
@@ -178,6 +563,269 @@ func (d *Deployment) AddTarget(target Target) *Deployment {
 	return d
 }
 
+// ResolveModel returns the identifier of model on the target's platform: the
+// target's Models override when present, else the ModelMappings entry.
+func (t *Target) ResolveModel(model Model) string {
+	if id := t.Models[model]; id != "" {
+		return id
+	}
+	return ModelMappings.Resolve(t.Platform, model)
+}
+
+// MapTool returns the name of tool on the target's platform from
+// ToolMappings, or an error if the platform does not support it.
+func (t *Target) MapTool(tool Tool) (string, error) {
+	return ToolMappings.Map(t.Platform, tool)
+}
+
+// Validate checks the deployment for consistency: target names are unique,
+// each target has a known platform, only the config block matching that
+// platform, and a mode the platform supports, and no two targets write to
+// the same output directory, and logging and rate limit settings are
+// valid. Secret
+// references are checked with ValidateSecrets.
+func (d *Deployment) Validate() error {
+	names := make(map[string]bool, len(d.Targets))
+	outputs := make(map[string]string, len(d.Targets))
+	for i := range d.Targets {
+		t := &d.Targets[i]
+		if t.Name == "" {
+			return fmt.Errorf("target %d: name is required", i)
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate target name %q", t.Name)
+		}
+		names[t.Name] = true
+
+		if t.Platform.Modes() == nil {
+			return fmt.Errorf("target %s: unknown platform %q", t.Name, t.Platform)
+		}
+		for _, c := range t.platformConfigs() {
+			if !c.set {
+				continue
+			}
+			ok := false
+			for _, p := range c.platforms {
+				ok = ok || p == t.Platform
+			}
+			if !ok {
+				return fmt.Errorf("target %s: %s config does not apply to platform %s", t.Name, c.field, t.Platform)
+			}
+		}
+		if t.Mode != "" && !t.Platform.SupportsMode(t.Mode) {
+			return fmt.Errorf("target %s: platform %s does not support mode %s", t.Name, t.Platform, t.Mode)
+		}
+
+		out := t.outputKey()
+		for other, prev := range outputs {
+			if outputsOverlap(out, other) {
+				return fmt.Errorf("targets %s and %s write to overlapping output %q and %q", prev, t.Name, other, out)
+			}
+		}
+		outputs[out] = t.Name
+
+		if rt := t.Runtime; rt != nil && rt.Observability != nil && rt.Observability.Logging != nil {
+			if err := rt.Observability.Logging.Validate(); err != nil {
+				return fmt.Errorf("target %s: logging: %w", t.Name, err)
+			}
+		}
+		if rt := t.Runtime; rt != nil && rt.Observability != nil {
+			if err := rt.Observability.Tracing.Validate(); err != nil {
+				return fmt.Errorf("target %s: tracing: %w", t.Name, err)
+			}
+			if err := rt.Observability.Metrics.Validate(); err != nil {
+				return fmt.Errorf("target %s: metrics: %w", t.Name, err)
+			}
+		}
+		if err := t.Runtime.validateRateLimits(); err != nil {
+			return fmt.Errorf("target %s: %w", t.Name, err)
+		}
+	}
+	for _, env := range d.EnvironmentNames() {
+		if d.Environments[env] == nil {
+			return fmt.Errorf("environment %s: no definition", env)
+		}
+	}
+	return d.ValidateSecrets()
+}
+
+// targetConfig is one typed platform config block of a target.
+type targetConfig struct {
+	field     string
+	set       bool
+	platforms []Platform
+}
+
+func (t *Target) platformConfigs() []targetConfig {
+	return []targetConfig{
+		{"claudeCode", t.ClaudeCode != nil, []Platform{PlatformClaudeCode}},
+		{"geminiCli", t.GeminiCLI != nil, []Platform{PlatformGeminiCLI}},
+		{"kiroCli", t.KiroCLI != nil, []Platform{PlatformKiroCLI}},
+		{"adkGo", t.ADKGo != nil, []Platform{PlatformADKGo}},
+		{"crewai", t.CrewAI != nil, []Platform{PlatformCrewAI}},
+		{"autogen", t.AutoGen != nil, []Platform{PlatformAutoGen}},
+		{"awsAgentCore", t.AWSAgentCore != nil, []Platform{PlatformAWSAgentCore}},
+		{"kubernetes", t.Kubernetes != nil, []Platform{PlatformKubernetes, PlatformAWSEKS, PlatformAzureAKS, PlatformGCPGKE}},
+		{"dockerCompose", t.DockerCompose != nil, []Platform{PlatformDockerCompose}},
+		{"agentKitLocal", t.AgentKitLocal != nil, []Platform{PlatformAgentKitLocal}},
+		{"langgraph", t.LangGraph != nil, []Platform{PlatformLangGraph}},
+		{"openaiAgents", t.OpenAIAgents != nil, []Platform{PlatformOpenAIAgents}},
+		{"semanticKernel", t.SemanticKernel != nil, []Platform{PlatformSemanticKernel}},
+		{"awsBedrockAgents", t.AWSBedrockAgents != nil, []Platform{PlatformAWSBedrockAgents}},
+		{"vertexAi", t.VertexAI != nil, []Platform{PlatformVertexAI}},
+		{"temporal", t.Temporal != nil, []Platform{PlatformTemporal}},
+		{"n8n", t.N8N != nil, []Platform{PlatformN8N}},
+	}
+}
+
+// outputKey returns the slash-separated directory the target writes to.
+// Targets without an explicit directory share their platform's default,
+// which is keyed by platform since only generators know the default path.
+// The Kubernetes platforms share one generator and so one default.
+func (t *Target) outputKey() string {
+	dir := t.Output
+	switch {
+	case t.ClaudeCode != nil && t.ClaudeCode.AgentDir != "":
+		dir = t.ClaudeCode.AgentDir
+	case t.GeminiCLI != nil && t.GeminiCLI.ConfigDir != "":
+		dir = t.GeminiCLI.ConfigDir
+	}
+	if dir != "" {
+		return path.Clean(strings.ReplaceAll(dir, "\\", "/"))
+	}
+	p := t.Platform
+	switch p {
+	case PlatformAWSEKS, PlatformAzureAKS, PlatformGCPGKE:
+		p = PlatformKubernetes
+	}
+	return "<default " + string(p) + " output>"
+}
+
+// outputsOverlap reports whether output keys a and b are the same
+// directory or one holds the other. "." holds every relative output,
+// including the platform defaults.
+func outputsOverlap(a, b string) bool {
+	if a == b {
+		return true
+	}
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		dir, sub := pair[0], pair[1]
+		switch {
+		case dir == ".":
+			if !path.IsAbs(sub) && sub != ".." && !strings.HasPrefix(sub, "../") {
+				return true
+			}
+		case dir == "/":
+			if path.IsAbs(sub) {
+				return true
+			}
+		case strings.HasPrefix(sub, dir+"/"):
+			return true
+		}
+	}
+	return false
+}
+
+// EnvironmentNames returns the names of the deployment's environments, sorted.
+func (d *Deployment) EnvironmentNames() []string {
+	names := make([]string, 0, len(d.Environments))
+	for name := range d.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ForEnvironment returns a copy of the deployment with the named
+// environment's overrides merged onto the base targets. The returned
+// deployment has no environments. Overrides must name existing targets and
+// may not rename them.
+func (d *Deployment) ForEnvironment(name string) (*Deployment, error) {
+	env, ok := d.Environments[name]
+	if !ok || env == nil {
+		return nil, fmt.Errorf("deployment has no environment named %q", name)
+	}
+	for target := range env.Targets {
+		if d.target(target) == nil {
+			return nil, fmt.Errorf("environment %s: no base target named %q", name, target)
+		}
+	}
+
+	out := &Deployment{Schema: d.Schema, Team: d.Team, Targets: make([]Target, 0, len(d.Targets))}
+	for _, t := range d.Targets {
+		patch, ok := env.Targets[t.Name]
+		if !ok {
+			out.Targets = append(out.Targets, t)
+			continue
+		}
+		merged, err := mergeTarget(t, patch)
+		if err != nil {
+			return nil, fmt.Errorf("environment %s: target %s: %w", name, t.Name, err)
+		}
+		if merged.Name != t.Name {
+			return nil, fmt.Errorf("environment %s: target %s: overrides may not rename targets", name, t.Name)
+		}
+		out.Targets = append(out.Targets, merged)
+	}
+	return out, nil
+}
+
+func (d *Deployment) target(name string) *Target {
+	for i := range d.Targets {
+		if d.Targets[i].Name == name {
+			return &d.Targets[i]
+		}
+	}
+	return nil
+}
+
+// mergeTarget applies a JSON merge patch to a copy of base.
+func mergeTarget(base Target, patch json.RawMessage) (Target, error) {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return Target{}, err
+	}
+	var doc, p interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Target{}, err
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return Target{}, fmt.Errorf("parse override: %w", err)
+	}
+	if _, ok := p.(map[string]interface{}); !ok {
+		return Target{}, fmt.Errorf("override must be an object")
+	}
+	data, err = json.Marshal(mergePatch(doc, p))
+	if err != nil {
+		return Target{}, err
+	}
+	var merged Target
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return Target{}, fmt.Errorf("apply override: %w", err)
+	}
+	return merged, nil
+}
+
+// mergePatch implements RFC 7386 JSON merge patch on decoded JSON values.
+func mergePatch(doc, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	d, ok := doc.(map[string]interface{})
+	if !ok {
+		d = make(map[string]interface{}, len(p))
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(d, k)
+			continue
+		}
+		d[k] = mergePatch(d[k], v)
+	}
+	return d
+}
+
 // ClaudeCodeConfig is the configuration for Claude Code platform.
 type ClaudeCodeConfig struct {
 	AgentDir string `json:"agentDir"`
@@ -187,14 +835,14 @@ type ClaudeCodeConfig struct {
 
 	// TeamMode specifies whether to use subagents or agent teams.
 	// Values: "subagent" (default), "team"
-	TeamMode string `json:"team_mode,omitempty"`
+	TeamMode string `json:"team_mode,omitempty" jsonschema:"enum=subagent,enum=team,default=subagent" jsonschema_description:"Whether to use subagents or agent teams"`
 
 	// TeammateMode specifies the display mode for agent teams.
 	// Values: "in-process", "tmux", "auto" (default)
-	TeammateMode string `json:"teammate_mode,omitempty"`
+	TeammateMode string `json:"teammate_mode,omitempty" jsonschema:"enum=in-process,enum=tmux,enum=auto,default=auto" jsonschema_description:"Display mode for agent teams"`
 
 	// EnableTeams sets CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS=1.
-	EnableTeams bool `json:"enable_teams,omitempty"`
+	EnableTeams bool `json:"enable_teams,omitempty" jsonschema:"default=false" jsonschema_description:"Set CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS=1"`
 }
 
 // KiroCLIConfig is the configuration for Kiro CLI platform.
@@ -202,7 +850,7 @@ type KiroCLIConfig struct {
 	PluginDir string `json:"pluginDir,omitempty"`
 	Format    string `json:"format,omitempty"`
 	// Prefix is applied to agent names, filenames, and steering files for namespace isolation.
-	Prefix string `json:"prefix,omitempty"`
+	Prefix string `json:"prefix,omitempty" jsonschema_description:"Prefix applied to agent names, filenames, and steering files for namespace isolation"`
 }
 
 // AWSAgentCoreConfig is the configuration for AWS AgentCore platform.
@@ -248,6 +896,124 @@ type ADKGoConfig struct {
 	ToolRegistry string `json:"toolRegistry,omitempty"`
 }
 
+// LangGraphConfig is the configuration for LangGraph (Python) deployment.
+type LangGraphConfig struct {
+	// Model is the default chat model as a LangChain "provider:model" string.
+	Model string `json:"model,omitempty" jsonschema_description:"Default chat model as a LangChain provider:model string"`
+
+	// Checkpointer selects graph state persistence.
+	// Values: "memory" (default), "sqlite", "postgres", "none"
+	Checkpointer string `json:"checkpointer,omitempty" jsonschema:"enum=memory,enum=sqlite,enum=postgres,enum=none,default=memory" jsonschema_description:"Graph state persistence"`
+
+	// CheckpointerURI is the sqlite path or postgres connection string.
+	CheckpointerURI string `json:"checkpointerUri,omitempty" jsonschema_description:"Sqlite path or postgres connection string"`
+
+	// StateSchema selects how the graph state class is declared.
+	// Values: "typeddict" (default), "pydantic"
+	StateSchema string `json:"stateSchema,omitempty" jsonschema:"enum=typeddict,enum=pydantic,default=typeddict" jsonschema_description:"How the graph state class is declared"`
+}
+
+// OpenAIAgentsConfig is the configuration for the OpenAI agent stack
+// (Assistants API and Agents SDK).
+type OpenAIAgentsConfig struct {
+	// Model is the default OpenAI model for agents without a model.
+	Model string `json:"model,omitempty" jsonschema_description:"Default OpenAI model for agents without a model"`
+
+	// AssistantIDs maps agent names to existing assistant IDs to update
+	// instead of creating new assistants.
+	AssistantIDs map[string]string `json:"assistantIds,omitempty" jsonschema_description:"Existing assistant IDs keyed by agent name"`
+
+	// ToolMappings maps spec tool names to OpenAI hosted tool types
+	// (web_search, file_search, code_interpreter), overriding the defaults.
+	// An empty value drops the tool.
+	ToolMappings map[string]string `json:"toolMappings,omitempty" jsonschema_description:"Spec tool name to OpenAI hosted tool type (web_search, file_search, code_interpreter)"`
+}
+
+// SemanticKernelConfig is the configuration for Microsoft Semantic Kernel.
+type SemanticKernelConfig struct {
+	// Language selects the SDK flavor.
+	// Values: "python" (default), "dotnet"
+	Language string `json:"language,omitempty" jsonschema:"enum=python,enum=dotnet,default=python" jsonschema_description:"Semantic Kernel SDK flavor"`
+
+	// Model is the default chat model (or Azure deployment name).
+	Model string `json:"model,omitempty" jsonschema_description:"Default chat model or Azure OpenAI deployment name"`
+
+	// Service selects the chat completion connector.
+	// Values: "openai" (default), "azure-openai"
+	Service string `json:"service,omitempty" jsonschema:"enum=openai,enum=azure-openai,default=openai" jsonschema_description:"Chat completion connector"`
+}
+
+// AWSBedrockAgentsConfig is the configuration for Amazon Bedrock multi-agent
+// collaboration.
+type AWSBedrockAgentsConfig struct {
+	Region          string `json:"region,omitempty" jsonschema_description:"AWS region"`
+	FoundationModel string `json:"foundationModel,omitempty" jsonschema_description:"Default Bedrock model ID for agents without a model"`
+
+	// CollaborationMode is how supervisors use their collaborators.
+	// Values: "SUPERVISOR" (default), "SUPERVISOR_ROUTER"
+	CollaborationMode string `json:"collaborationMode,omitempty" jsonschema:"enum=SUPERVISOR,enum=SUPERVISOR_ROUTER,default=SUPERVISOR" jsonschema_description:"How supervisors use their collaborators"`
+
+	// RelayConversationHistory shares the supervisor's conversation history
+	// with collaborators.
+	RelayConversationHistory bool `json:"relayConversationHistory,omitempty" jsonschema_description:"Share the supervisor's conversation history with collaborators"`
+
+	// KnowledgeBases are existing knowledge bases to associate with agents.
+	KnowledgeBases []BedrockKnowledgeBase `json:"knowledgeBases,omitempty" jsonschema_description:"Existing knowledge bases to associate with agents"`
+}
+
+// BedrockKnowledgeBase references an existing Bedrock knowledge base.
+type BedrockKnowledgeBase struct {
+	// ID is the knowledge base ID.
+	ID string `json:"id" jsonschema_description:"Knowledge base ID"`
+
+	// Description tells the agent when to use the knowledge base.
+	Description string `json:"description" jsonschema_description:"When the agent should use the knowledge base"`
+
+	// Agents lists the agents to associate the knowledge base with.
+	// Empty means every team agent.
+	Agents []string `json:"agents,omitempty" jsonschema_description:"Agents to associate the knowledge base with; empty means every team agent"`
+}
+
+// VertexAIConfig is the configuration for Google Vertex AI Agent Builder.
+type VertexAIConfig struct {
+	// Project is the Google Cloud project ID.
+	Project string `json:"project,omitempty" jsonschema_description:"Google Cloud project ID"`
+
+	// Location is the Vertex AI region (default "us-central1").
+	Location string `json:"location,omitempty" jsonschema:"default=us-central1" jsonschema_description:"Vertex AI region"`
+
+	// Model is the default Gemini model for agents without a model.
+	Model string `json:"model,omitempty" jsonschema_description:"Default Gemini model for agents without a model"`
+
+	// DataStores are Vertex AI Search data stores the root agent is
+	// grounded on, as data store IDs in the project's default collection or
+	// full resource names.
+	DataStores []string `json:"dataStores,omitempty" jsonschema_description:"Vertex AI Search data store IDs or full resource names the root agent is grounded on"`
+}
+
+// TemporalConfig is the configuration for a generated Temporal Go worker.
+type TemporalConfig struct {
+	// HostPort is the Temporal frontend address (default "localhost:7233").
+	HostPort string `json:"hostPort,omitempty" jsonschema:"default=localhost:7233" jsonschema_description:"Temporal frontend address"`
+
+	// Namespace is the Temporal namespace (default "default").
+	Namespace string `json:"namespace,omitempty" jsonschema:"default=default" jsonschema_description:"Temporal namespace"`
+
+	// TaskQueue is the task queue the worker polls (default: team name).
+	TaskQueue string `json:"taskQueue,omitempty" jsonschema_description:"Task queue the worker polls; defaults to the team name"`
+}
+
+// N8NConfig is the configuration for n8n workflow export.
+type N8NConfig struct {
+	// WebhookPath is the path of the webhook that starts the workflow
+	// (default: team name).
+	WebhookPath string `json:"webhookPath,omitempty" jsonschema_description:"Path of the webhook that starts the workflow; defaults to the team name"`
+
+	// AgentURL is the endpoint each step POSTs to. "{agent}" is replaced
+	// with the agent name (default "http://{agent}:8080/invoke").
+	AgentURL string `json:"agentUrl,omitempty" jsonschema:"default=http://{agent}:8080/invoke" jsonschema_description:"Endpoint each step POSTs to; {agent} is replaced with the agent name"`
+}
+
 // CrewAIConfig is the configuration for CrewAI deployment.
 type CrewAIConfig struct {
 	Model         string `json:"model,omitempty"`
@@ -259,10 +1025,10 @@ type CrewAIConfig struct {
 	// Self-directed workflow support
 
 	// AllowDelegation enables agent delegation in CrewAI.
-	AllowDelegation bool `json:"allowDelegation,omitempty"`
+	AllowDelegation bool `json:"allowDelegation,omitempty" jsonschema:"default=true" jsonschema_description:"Enable agent delegation in CrewAI"`
 
 	// ManagerLLM specifies the model for the manager agent in hierarchical process.
-	ManagerLLM string `json:"managerLlm,omitempty"`
+	ManagerLLM string `json:"managerLlm,omitempty" jsonschema_description:"Model for manager agent in hierarchical process"`
 }
 
 // AutoGenConfig is the configuration for Microsoft AutoGen deployment.
@@ -2,6 +2,7 @@ package multiagentspec
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 )
 
@@ -329,6 +330,13 @@ func TestNewPlatformConstants(t *testing.T) {
 		{PlatformADKGo, "adk-go"},
 		{PlatformCrewAI, "crewai"},
 		{PlatformAutoGen, "autogen"},
+		{PlatformLangGraph, "langgraph"},
+		{PlatformOpenAIAgents, "openai-agents"},
+		{PlatformSemanticKernel, "semantic-kernel"},
+		{PlatformAWSBedrockAgents, "aws-bedrock-agents"},
+		{PlatformVertexAI, "vertex-ai"},
+		{PlatformTemporal, "temporal"},
+		{PlatformN8N, "n8n"},
 	}
 
 	for _, tt := range tests {
@@ -448,6 +456,472 @@ func TestAutoGenConfig(t *testing.T) {
 	}
 }
 
+func TestLangGraphConfig(t *testing.T) {
+	config := LangGraphConfig{
+		Model:           "openai:gpt-4o",
+		Checkpointer:    "sqlite",
+		CheckpointerURI: "state.db",
+		StateSchema:     "pydantic",
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var decoded LangGraphConfig
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	if decoded != config {
+		t.Errorf("decoded = %+v, want %+v", decoded, config)
+	}
+	if err := ValidateDeploymentJSON([]byte(`{"team":"t","targets":[{"name":"lg","platform":"langgraph","langgraph":` + string(data) + `}]}`)); err != nil {
+		t.Errorf("schema rejects langgraph target: %v", err)
+	}
+}
+
+func TestOpenAIAgentsConfig(t *testing.T) {
+	data := []byte(`{"team":"t","targets":[{"name":"oa","platform":"openai-agents","openaiAgents":{"model":"gpt-4o","assistantIds":{"pm":"asst_1"},"toolMappings":{"Bash":"code_interpreter"}}}]}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects openai-agents target: %v", err)
+	}
+
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	cfg := dep.Targets[0].OpenAIAgents
+	if cfg == nil {
+		t.Fatal("OpenAIAgents should not be nil")
+	}
+	if cfg.AssistantIDs["pm"] != "asst_1" {
+		t.Errorf("AssistantIDs = %v", cfg.AssistantIDs)
+	}
+	if cfg.ToolMappings["Bash"] != "code_interpreter" {
+		t.Errorf("ToolMappings = %v", cfg.ToolMappings)
+	}
+}
+
+func TestSemanticKernelConfig(t *testing.T) {
+	data := []byte(`{"team":"t","targets":[{"name":"sk","platform":"semantic-kernel","semanticKernel":{"language":"dotnet","service":"azure-openai","model":"gpt-4o-prod"}}]}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects semantic-kernel target: %v", err)
+	}
+
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	cfg := dep.Targets[0].SemanticKernel
+	if cfg == nil {
+		t.Fatal("SemanticKernel should not be nil")
+	}
+	if cfg.Language != "dotnet" || cfg.Service != "azure-openai" || cfg.Model != "gpt-4o-prod" {
+		t.Errorf("SemanticKernel = %+v", cfg)
+	}
+
+	bad := []byte(`{"team":"t","targets":[{"name":"sk","platform":"semantic-kernel","semanticKernel":{"language":"java"}}]}`)
+	if err := ValidateDeploymentJSON(bad); err == nil {
+		t.Error("schema should reject unknown language")
+	}
+}
+
+func TestAWSBedrockAgentsConfig(t *testing.T) {
+	data := []byte(`{"team":"t","targets":[{"name":"b","platform":"aws-bedrock-agents","awsBedrockAgents":{"region":"us-west-2","collaborationMode":"SUPERVISOR","relayConversationHistory":true,"knowledgeBases":[{"id":"KB1","description":"Runbooks","agents":["pm"]}]}}]}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects aws-bedrock-agents target: %v", err)
+	}
+
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	cfg := dep.Targets[0].AWSBedrockAgents
+	if cfg == nil {
+		t.Fatal("AWSBedrockAgents should not be nil")
+	}
+	if !cfg.RelayConversationHistory || len(cfg.KnowledgeBases) != 1 || cfg.KnowledgeBases[0].Agents[0] != "pm" {
+		t.Errorf("AWSBedrockAgents = %+v", cfg)
+	}
+
+	bad := []byte(`{"team":"t","targets":[{"name":"b","platform":"aws-bedrock-agents","awsBedrockAgents":{"knowledgeBases":[{"id":"KB1"}]}}]}`)
+	if err := ValidateDeploymentJSON(bad); err == nil {
+		t.Error("schema should require a knowledge base description")
+	}
+}
+
+func TestVertexAIConfig(t *testing.T) {
+	data := []byte(`{"team":"t","targets":[{"name":"v","platform":"vertex-ai","vertexAi":{"project":"acme","location":"us-east4","model":"gemini-2.5-pro","dataStores":["runbooks"]}}]}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects vertex-ai target: %v", err)
+	}
+
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	cfg := dep.Targets[0].VertexAI
+	if cfg == nil {
+		t.Fatal("VertexAI should not be nil")
+	}
+	if cfg.Project != "acme" || cfg.Location != "us-east4" || len(cfg.DataStores) != 1 {
+		t.Errorf("VertexAI = %+v", cfg)
+	}
+}
+
+func TestRuntimeConfigForStep(t *testing.T) {
+	var nilConfig *RuntimeConfig
+	if rt := nilConfig.ForStep("build"); rt.Timeout != "" || rt.Retry != nil {
+		t.Errorf("nil config ForStep = %+v, want zero", rt)
+	}
+
+	retry := &RetryPolicy{MaxAttempts: 5}
+	rc := &RuntimeConfig{
+		Defaults: &StepRuntime{Timeout: "5m", Retry: &RetryPolicy{MaxAttempts: 2}, Concurrency: 1},
+		Steps: map[string]*StepRuntime{
+			"build": {Timeout: "30m", Retry: retry},
+		},
+	}
+	build := rc.ForStep("build")
+	if build.Timeout != "30m" || build.Retry != retry || build.Concurrency != 1 {
+		t.Errorf("ForStep(build) = %+v", build)
+	}
+	if other := rc.ForStep("lint"); other.Timeout != "5m" || other.Retry.MaxAttempts != 2 {
+		t.Errorf("ForStep(lint) = %+v", other)
+	}
+	if rc.Defaults.Timeout != "5m" {
+		t.Error("ForStep modified Defaults")
+	}
+
+	rc.Steps["fanout"] = &StepRuntime{RateLimit: &RateLimit{RequestsPerMinute: 20}}
+	if fanout := rc.ForStep("fanout"); fanout.RateLimit == nil || fanout.RateLimit.RequestsPerMinute != 20 || fanout.Timeout != "5m" {
+		t.Errorf("ForStep(fanout) = %+v", fanout)
+	}
+}
+
+func TestTemporalConfig(t *testing.T) {
+	data := []byte(`{"team":"t","targets":[{"name":"tw","platform":"temporal","temporal":{"hostPort":"temporal:7233","namespace":"agents","taskQueue":"releases"}}]}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects temporal target: %v", err)
+	}
+
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	cfg := dep.Targets[0].Temporal
+	if cfg == nil {
+		t.Fatal("Temporal should not be nil")
+	}
+	if cfg.HostPort != "temporal:7233" || cfg.Namespace != "agents" || cfg.TaskQueue != "releases" {
+		t.Errorf("Temporal = %+v", cfg)
+	}
+}
+
+func TestN8NConfig(t *testing.T) {
+	data := []byte(`{"team":"t","targets":[{"name":"n","platform":"n8n","n8n":{"webhookPath":"release","agentUrl":"http://{agent}.agents.svc:8080/invoke"}}]}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects n8n target: %v", err)
+	}
+
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	cfg := dep.Targets[0].N8N
+	if cfg == nil {
+		t.Fatal("N8N should not be nil")
+	}
+	if cfg.WebhookPath != "release" || cfg.AgentURL != "http://{agent}.agents.svc:8080/invoke" {
+		t.Errorf("N8N = %+v", cfg)
+	}
+}
+
+func TestDeploymentForEnvironment(t *testing.T) {
+	data := []byte(`{
+		"team": "t",
+		"targets": [
+			{"name": "k8s", "platform": "kubernetes", "kubernetes": {"namespace": "agents", "helmChart": true, "resourceLimits": {"cpu": "500m", "memory": "512Mi"}}},
+			{"name": "bedrock", "platform": "aws-bedrock-agents", "awsBedrockAgents": {"region": "us-east-1"}},
+			{"name": "local", "platform": "claude-code", "output": "local"}
+		],
+		"environments": {
+			"dev": {"targets": {}},
+			"prod": {"targets": {
+				"k8s": {"kubernetes": {"namespace": "agents-prod", "resourceLimits": {"memory": "2Gi"}}},
+				"bedrock": {"awsBedrockAgents": {"region": "eu-west-1", "foundationModel": "anthropic.claude-opus-4-20250514-v1:0"}},
+				"local": {"output": null}
+			}}
+		}
+	}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects environments: %v", err)
+	}
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if got := dep.EnvironmentNames(); len(got) != 2 || got[0] != "dev" || got[1] != "prod" {
+		t.Errorf("EnvironmentNames() = %v", got)
+	}
+
+	prod, err := dep.ForEnvironment("prod")
+	if err != nil {
+		t.Fatalf("ForEnvironment(prod) failed: %v", err)
+	}
+	if prod.Environments != nil || prod.Team != "t" || len(prod.Targets) != 3 {
+		t.Fatalf("ForEnvironment(prod) = %+v", prod)
+	}
+	k8s := prod.Targets[0].Kubernetes
+	if k8s.Namespace != "agents-prod" || !k8s.HelmChart {
+		t.Errorf("Kubernetes = %+v", k8s)
+	}
+	if r := k8s.ResourceLimits; r.CPU != "500m" || r.Memory != "2Gi" {
+		t.Errorf("ResourceLimits = %+v", r)
+	}
+	if b := prod.Targets[1].AWSBedrockAgents; b.Region != "eu-west-1" || b.FoundationModel == "" {
+		t.Errorf("AWSBedrockAgents = %+v", b)
+	}
+	if prod.Targets[2].Output != "" {
+		t.Errorf("Output = %q, want removed", prod.Targets[2].Output)
+	}
+	if dep.Targets[0].Kubernetes.Namespace != "agents" || dep.Targets[0].Kubernetes.ResourceLimits.Memory != "512Mi" {
+		t.Error("ForEnvironment modified the base deployment")
+	}
+
+	dev, err := dep.ForEnvironment("dev")
+	if err != nil {
+		t.Fatalf("ForEnvironment(dev) failed: %v", err)
+	}
+	if dev.Targets[2].Output != "local" {
+		t.Errorf("dev Output = %q, want local", dev.Targets[2].Output)
+	}
+}
+
+func TestDeploymentForEnvironmentErrors(t *testing.T) {
+	dep := Deployment{
+		Team:    "t",
+		Targets: []Target{{Name: "local", Platform: PlatformClaudeCode}},
+		Environments: map[string]*Environment{
+			"unknown": {Targets: map[string]json.RawMessage{"missing": json.RawMessage(`{}`)}},
+			"rename":  {Targets: map[string]json.RawMessage{"local": json.RawMessage(`{"name":"other"}`)}},
+			"scalar":  {Targets: map[string]json.RawMessage{"local": json.RawMessage(`"x"`)}},
+		},
+	}
+	for _, env := range []string{"staging", "unknown", "rename", "scalar"} {
+		if _, err := dep.ForEnvironment(env); err == nil {
+			t.Errorf("ForEnvironment(%s) should fail", env)
+		}
+	}
+}
+
+func TestSecretRefValidate(t *testing.T) {
+	tests := []struct {
+		ref     SecretRef
+		wantErr bool
+	}{
+		{SecretRef{Source: SecretSourceEnv, Key: "GITHUB_TOKEN"}, false},
+		{SecretRef{Source: SecretSourceFile, Key: "/run/secrets/token"}, false},
+		{SecretRef{Source: SecretSourceAWSSecretsManager, Key: "prod/openai#api_key"}, false},
+		{SecretRef{Source: SecretSourceVault, Key: "secret/data/db"}, false},
+		{SecretRef{Source: "ssm", Key: "x"}, true},
+		{SecretRef{Key: "x"}, true},
+		{SecretRef{Source: SecretSourceEnv}, true},
+	}
+	for _, tt := range tests {
+		if err := tt.ref.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%+v.Validate() = %v, wantErr %v", tt.ref, err, tt.wantErr)
+		}
+	}
+
+	ref := SecretRef{Source: SecretSourceVault, Key: "secret/data/db#password"}
+	if ref.Path() != "secret/data/db" || ref.Property() != "password" {
+		t.Errorf("Path() = %q, Property() = %q", ref.Path(), ref.Property())
+	}
+}
+
+func TestDeploymentValidateSecrets(t *testing.T) {
+	data := []byte(`{"team":"t","targets":[{"name":"k8s","platform":"kubernetes","secrets":{"OPENAI_API_KEY":{"source":"aws-sm","key":"prod/openai#api_key"}}}]}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects secrets: %v", err)
+	}
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if err := dep.ValidateSecrets(); err != nil {
+		t.Errorf("ValidateSecrets() = %v", err)
+	}
+	if ref := dep.Targets[0].Secrets["OPENAI_API_KEY"]; ref.Source != SecretSourceAWSSecretsManager {
+		t.Errorf("Secrets = %+v", dep.Targets[0].Secrets)
+	}
+
+	tests := []struct {
+		name string
+		dep  Deployment
+	}{
+		{"invalid name", Deployment{Targets: []Target{{Name: "a", Secrets: map[string]SecretRef{"1X": {Source: SecretSourceEnv, Key: "X"}}}}}},
+		{"invalid ref", Deployment{Targets: []Target{{Name: "a", Secrets: map[string]SecretRef{"X": {Source: "ssm", Key: "X"}}}}}},
+		{"key format", Deployment{Targets: []Target{{Name: "a", Output: "sk-ant-REDACTED"}}}},
+		{"override field", Deployment{
+			Targets:      []Target{{Name: "a"}},
+			Environments: map[string]*Environment{"prod": {Targets: map[string]json.RawMessage{"a": json.RawMessage(`{"temporal":{"apiKey":"hunter2"}}`)}}},
+		}},
+	}
+	for _, tt := range tests {
+		if err := tt.dep.ValidateSecrets(); err == nil {
+			t.Errorf("%s: ValidateSecrets() should fail", tt.name)
+		}
+	}
+
+	ok := Deployment{Targets: []Target{{Name: "a", Output: "sk-agents", Temporal: &TemporalConfig{TaskQueue: "tokens"}}}}
+	if err := ok.ValidateSecrets(); err != nil {
+		t.Errorf("ValidateSecrets() false positive: %v", err)
+	}
+}
+
+func TestDeploymentValidate(t *testing.T) {
+	valid := Deployment{
+		Team: "t",
+		Targets: []Target{
+			{Name: "claude", Platform: PlatformClaudeCode, Mode: ModeMultiProcess, ClaudeCode: &ClaudeCodeConfig{AgentDir: ".claude/agents"}},
+			{Name: "gemini", Platform: PlatformGeminiCLI},
+			{Name: "eks", Platform: PlatformAWSEKS, Mode: ModeDistributed, Kubernetes: &KubernetesConfig{Namespace: "agents"}},
+			{Name: "dev-k8s", Platform: PlatformKubernetes, Output: "k8s-dev"},
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		targets []Target
+		want    string
+	}{
+		{"missing name", []Target{{Platform: PlatformClaudeCode}}, "name is required"},
+		{"duplicate name", []Target{
+			{Name: "a", Platform: PlatformClaudeCode},
+			{Name: "a", Platform: PlatformKiroCLI},
+		}, "duplicate target name"},
+		{"unknown platform", []Target{{Name: "a", Platform: "bogus"}}, "unknown platform"},
+		{"mismatched config", []Target{
+			{Name: "a", Platform: PlatformKubernetes, ClaudeCode: &ClaudeCodeConfig{}},
+		}, "claudeCode config does not apply to platform kubernetes"},
+		{"extra config", []Target{
+			{Name: "a", Platform: PlatformCrewAI, CrewAI: &CrewAIConfig{}, AutoGen: &AutoGenConfig{}},
+		}, "autogen config"},
+		{"mode", []Target{{Name: "a", Platform: PlatformAWSAgentCore, Mode: ModeSingleProcess}}, "does not support mode single-process"},
+		{"adk-go mode", []Target{{Name: "a", Platform: PlatformADKGo, Mode: ModeDistributed}}, "does not support mode distributed"},
+		{"same output", []Target{
+			{Name: "a", Platform: PlatformLangGraph, Output: "out"},
+			{Name: "b", Platform: PlatformTemporal, Output: "./out/"},
+		}, "overlapping output"},
+		{"nested output", []Target{
+			{Name: "a", Platform: PlatformKubernetes, Output: "deploy"},
+			{Name: "b", Platform: PlatformN8N, Output: "deploy/n8n"},
+		}, "overlapping output"},
+		{"current directory", []Target{
+			{Name: "a", Platform: PlatformLangGraph, Output: "."},
+			{Name: "b", Platform: PlatformTemporal, Output: "out/x"},
+		}, "overlapping output"},
+		{"current directory and default", []Target{
+			{Name: "a", Platform: PlatformKubernetes},
+			{Name: "b", Platform: PlatformN8N, Output: "./"},
+		}, "overlapping output"},
+		{"agent dir", []Target{
+			{Name: "a", Platform: PlatformClaudeCode, ClaudeCode: &ClaudeCodeConfig{AgentDir: "agents"}},
+			{Name: "b", Platform: PlatformKiroCLI, Output: "agents"},
+		}, "overlapping output"},
+		{"shared default", []Target{
+			{Name: "a", Platform: PlatformKubernetes},
+			{Name: "b", Platform: PlatformGCPGKE},
+		}, "overlapping output"},
+		{"secrets", []Target{
+			{Name: "a", Platform: PlatformClaudeCode, Secrets: map[string]SecretRef{"X": {Source: SecretSourceEnv}}},
+		}, "secret key is required"},
+		{"logging", []Target{
+			{Name: "a", Platform: PlatformClaudeCode, Runtime: &RuntimeConfig{
+				Observability: &ObservabilityConfig{Logging: &LoggingConfig{Level: "verbose"}},
+			}},
+		}, "target a: logging: invalid log level"},
+		{"tracing", []Target{
+			{Name: "a", Platform: PlatformClaudeCode, Runtime: &RuntimeConfig{
+				Observability: &ObservabilityConfig{Tracing: &TracingConfig{Enabled: true, Exporter: "jaeger"}},
+			}},
+		}, "target a: tracing: invalid trace exporter"},
+		{"metrics", []Target{
+			{Name: "a", Platform: PlatformClaudeCode, Runtime: &RuntimeConfig{
+				Observability: &ObservabilityConfig{Metrics: &MetricsConfig{Enabled: true, Exporter: "statsd"}},
+			}},
+		}, "target a: metrics: invalid metrics exporter"},
+		{"rate limit", []Target{
+			{Name: "a", Platform: PlatformClaudeCode, Runtime: &RuntimeConfig{
+				Steps: map[string]*StepRuntime{"fanout": {RateLimit: &RateLimit{Burst: 2}}},
+			}},
+		}, "target a: step fanout: rate limit sets no limit"},
+	}
+	for _, tt := range tests {
+		dep := Deployment{Team: "t", Targets: tt.targets}
+		err := dep.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Validate() = %v, want error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestPlatformModes(t *testing.T) {
+	for _, p := range Platforms() {
+		if len(p.Modes()) == 0 {
+			t.Errorf("%s has no deployment modes", p)
+		}
+	}
+	if !PlatformKubernetes.SupportsMode(ModeDistributed) || PlatformKubernetes.SupportsMode(ModeServerless) {
+		t.Error("kubernetes modes are wrong")
+	}
+}
+
+func TestTargetResolveModel(t *testing.T) {
+	data := []byte(`{
+		"team": "t",
+		"targets": [
+			{"name": "bedrock", "platform": "aws-bedrock-agents", "models": {"opus": "anthropic.claude-opus-custom-v1:0"}}
+		],
+		"environments": {
+			"dev": {"targets": {"bedrock": {"models": {"sonnet": "anthropic.claude-3-5-haiku-20241022-v1:0"}}}}
+		}
+	}`)
+	if err := ValidateDeploymentJSON(data); err != nil {
+		t.Fatalf("schema rejects models: %v", err)
+	}
+	var dep Deployment
+	if err := json.Unmarshal(data, &dep); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	target := &dep.Targets[0]
+	if got := target.ResolveModel(ModelOpus); got != "anthropic.claude-opus-custom-v1:0" {
+		t.Errorf("ResolveModel(opus) = %q, want override", got)
+	}
+	if got := target.ResolveModel(ModelSonnet); got != BedrockModels[ModelSonnet] {
+		t.Errorf("ResolveModel(sonnet) = %q, want registry default", got)
+	}
+
+	dev, err := dep.ForEnvironment("dev")
+	if err != nil {
+		t.Fatalf("ForEnvironment(dev) failed: %v", err)
+	}
+	devTarget := &dev.Targets[0]
+	if got := devTarget.ResolveModel(ModelSonnet); got != "anthropic.claude-3-5-haiku-20241022-v1:0" {
+		t.Errorf("dev ResolveModel(sonnet) = %q", got)
+	}
+	if got := devTarget.ResolveModel(ModelOpus); got != "anthropic.claude-opus-custom-v1:0" {
+		t.Errorf("dev ResolveModel(opus) = %q, want base override kept", got)
+	}
+}
+
 func TestTargetWithTypedConfigs(t *testing.T) {
 	target := Target{
 		Name:     "gemini-target",
@@ -0,0 +1,83 @@
+package multiagentspec
+
+import "fmt"
+
+// DeprecatedReference is a reference from a team or agent to a deprecated
+// agent.
+type DeprecatedReference struct {
+	// Referrer is what holds the reference, e.g., "team release-team" or
+	// "agent shared/lead".
+	Referrer string
+
+	// Field is the field holding the reference, e.g., agents or
+	// delegation.can_delegate_to.
+	Field string
+
+	// Agent is the deprecated agent referenced.
+	Agent *Agent
+}
+
+// String describes the reference, naming the replacement if there is one.
+func (r DeprecatedReference) String() string {
+	s := fmt.Sprintf("%s: %s references deprecated agent %s", r.Referrer, r.Field, r.Agent.QualifiedName())
+	if r.Agent.ReplacedBy != "" {
+		s += fmt.Sprintf(" (replaced by %s)", r.Agent.ReplacedBy)
+	}
+	return s
+}
+
+// ValidateDeprecation checks that ReplacedBy is set only on a deprecated
+// agent and does not name the agent itself.
+func (a *Agent) ValidateDeprecation() error {
+	if a.ReplacedBy == "" {
+		return nil
+	}
+	if !a.Deprecated {
+		return fmt.Errorf("replaced_by requires deprecated")
+	}
+	if a.ReplacedBy == a.Name || a.ReplacedBy == a.QualifiedName() {
+		return fmt.Errorf("agent cannot be replaced by itself")
+	}
+	return nil
+}
+
+// DeprecatedReferences returns the references to deprecated agents in the
+// team's agents, orchestrator, and collaboration config, and in the
+// delegation configs of its members, resolving names against agents.
+// Names that do not resolve are skipped; ResolveAgents reports them.
+func (t *Team) DeprecatedReferences(agents []*Agent) ([]DeprecatedReference, error) {
+	r, err := NewAgentRegistry(agents)
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", t.Name, err)
+	}
+	var refs []DeprecatedReference
+	check := func(referrer, field string, names ...string) {
+		for _, name := range names {
+			if a, err := r.Resolve(name); err == nil && a.Deprecated {
+				refs = append(refs, DeprecatedReference{Referrer: referrer, Field: field, Agent: a})
+			}
+		}
+	}
+
+	team := "team " + t.Name
+	check(team, "agents", t.Agents...)
+	if t.Orchestrator != "" {
+		check(team, "orchestrator", t.Orchestrator)
+	}
+	if c := t.Collaboration; c != nil {
+		if c.Lead != "" {
+			check(team, "collaboration.lead", c.Lead)
+		}
+		check(team, "collaboration.specialists", c.Specialists...)
+	}
+	for _, entry := range t.Agents {
+		a, err := r.Resolve(entry)
+		if err != nil || a.Delegation == nil {
+			continue
+		}
+		agent := "agent " + a.QualifiedName()
+		check(agent, "delegation.can_delegate_to", a.Delegation.CanDelegateTo...)
+		check(agent, "delegation.can_receive_from", a.Delegation.CanReceiveFrom...)
+	}
+	return refs, nil
+}
@@ -0,0 +1,62 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAgentValidateDeprecation(t *testing.T) {
+	a := &Agent{Name: "old-pm", Deprecated: true, ReplacedBy: "pm"}
+	if err := a.ValidateDeprecation(); err != nil {
+		t.Errorf("ValidateDeprecation: %v", err)
+	}
+	a.Deprecated = false
+	if err := a.ValidateDeprecation(); err == nil || err.Error() != "replaced_by requires deprecated" {
+		t.Errorf("ValidateDeprecation = %v, want replaced_by without deprecated", err)
+	}
+	a = &Agent{Name: "pm", Namespace: "shared", Deprecated: true, ReplacedBy: "shared/pm"}
+	if err := a.ValidateDeprecation(); err == nil {
+		t.Error("expected error for agent replaced by itself")
+	}
+}
+
+func TestTeamDeprecatedReferences(t *testing.T) {
+	oldPM := &Agent{Name: "old-pm", Deprecated: true, ReplacedBy: "pm"}
+	oldQA := &Agent{Name: "qa", Namespace: "legacy", Deprecated: true}
+	lead := &Agent{Name: "lead", Delegation: &DelegationConfig{
+		AllowDelegation: true,
+		CanDelegateTo:   []string{"pm", "old-pm", "unknown"},
+		CanReceiveFrom:  []string{"legacy/qa"},
+	}}
+	agents := []*Agent{oldPM, oldQA, lead, {Name: "pm"}}
+	team := &Team{
+		Name:          "release-team",
+		Agents:        []string{"lead", "pm", "old-pm"},
+		Collaboration: &CollaborationConfig{Lead: "lead", Specialists: []string{"pm", "qa"}},
+	}
+
+	refs, err := team.DeprecatedReferences(agents)
+	if err != nil {
+		t.Fatalf("DeprecatedReferences: %v", err)
+	}
+	var got []string
+	for _, r := range refs {
+		got = append(got, r.String())
+	}
+	want := []string{
+		"team release-team: agents references deprecated agent old-pm (replaced by pm)",
+		"team release-team: collaboration.specialists references deprecated agent legacy/qa",
+		"agent lead: delegation.can_delegate_to references deprecated agent old-pm (replaced by pm)",
+		"agent lead: delegation.can_receive_from references deprecated agent legacy/qa",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DeprecatedReferences =\n%v\nwant\n%v", got, want)
+	}
+
+	team.Agents = []string{"lead@^1"}
+	lead.Delegation = nil
+	team.Collaboration = nil
+	if refs, err := team.DeprecatedReferences(agents); err != nil || len(refs) != 0 {
+		t.Errorf("DeprecatedReferences without deprecated references = %v, %v", refs, err)
+	}
+}
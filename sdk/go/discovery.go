@@ -0,0 +1,176 @@
+package multiagentspec
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// FindBySkill returns the current revision of each agent with skill,
+// compared case-insensitively, sorted by qualified name.
+func (r *AgentRegistry) FindBySkill(skill string) []*Agent {
+	return r.find(func(a *Agent) bool { return containsFold(a.Skills, skill) })
+}
+
+// FindByTool returns the current revision of each agent with tool in its
+// Tools, compared case-insensitively, sorted by qualified name.
+func (r *AgentRegistry) FindByTool(tool string) []*Agent {
+	return r.find(func(a *Agent) bool { return containsFold(a.Tools, tool) })
+}
+
+// FindByRole returns the current revision of each agent whose Role is
+// role, compared case-insensitively, sorted by qualified name.
+func (r *AgentRegistry) FindByRole(role string) []*Agent {
+	return r.find(func(a *Agent) bool { return strings.EqualFold(strings.TrimSpace(a.Role), strings.TrimSpace(role)) })
+}
+
+// Current returns the current revision of each agent, the one Lookup
+// returns, sorted by qualified name.
+func (r *AgentRegistry) Current() []*Agent {
+	return r.find(func(*Agent) bool { return true })
+}
+
+func (r *AgentRegistry) find(match func(*Agent) bool) []*Agent {
+	names := make([]string, 0, len(r.byQualified))
+	for qn := range r.byQualified {
+		names = append(names, qn)
+	}
+	sort.Strings(names)
+	var out []*Agent
+	for _, qn := range names {
+		if a, ok := r.Lookup(qn); ok && match(a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// AgentScorer rates how well an agent suits a task description. Higher
+// scores are better; zero means unsuited. Implementations may call out to
+// an embedding model, hence the context.
+type AgentScorer interface {
+	Score(ctx context.Context, agent *Agent, task string) (float64, error)
+}
+
+// ScoredAgent is an agent with its score for a task.
+type ScoredAgent struct {
+	Agent *Agent
+	Score float64
+}
+
+// BestAgents scores the current revision of each agent for task and returns
+// those with a positive score, best first, ties broken by qualified name.
+// limit caps the results; zero returns all. A nil scorer uses
+// KeywordScorer.
+func (r *AgentRegistry) BestAgents(ctx context.Context, task string, scorer AgentScorer, limit int) ([]ScoredAgent, error) {
+	if scorer == nil {
+		scorer = KeywordScorer{}
+	}
+	var scored []ScoredAgent
+	for _, a := range r.Current() {
+		s, err := scorer.Score(ctx, a, task)
+		if err != nil {
+			return nil, err
+		}
+		if s > 0 {
+			scored = append(scored, ScoredAgent{Agent: a, Score: s})
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+	return scored, nil
+}
+
+// KeywordScorer scores agents by the words they share with the task
+// description, weighting skills and role above descriptive text. Words
+// match when equal or when one is a prefix of the other and at least four
+// letters long, so "review" matches "reviewer".
+type KeywordScorer struct{}
+
+// keywordWeights are the weights of each agent field in KeywordScorer.
+var keywordWeights = []struct {
+	weight float64
+	text   func(*Agent) []string
+}{
+	{3, func(a *Agent) []string { return a.Skills }},
+	{2, func(a *Agent) []string { return []string{a.Role, a.Name} }},
+	{1.5, func(a *Agent) []string { return []string{a.Description} }},
+	{1, func(a *Agent) []string {
+		texts := []string{a.Goal}
+		for _, t := range a.Tasks {
+			texts = append(texts, t.Description)
+		}
+		return texts
+	}},
+	{0.5, func(a *Agent) []string { return a.Tools }},
+}
+
+// Score returns the weighted fraction of the task's words found in each
+// field, summed over fields.
+func (KeywordScorer) Score(_ context.Context, agent *Agent, task string) (float64, error) {
+	words := keywords(task)
+	if len(words) == 0 {
+		return 0, nil
+	}
+	var score float64
+	for _, f := range keywordWeights {
+		fieldWords := keywords(strings.Join(f.text(agent), " "))
+		matched := 0
+		for _, w := range words {
+			for _, fw := range fieldWords {
+				if keywordMatch(w, fw) {
+					matched++
+					break
+				}
+			}
+		}
+		score += f.weight * float64(matched) / float64(len(words))
+	}
+	return score, nil
+}
+
+// stopWords are common words ignored by KeywordScorer.
+var stopWords = map[string]bool{
+	"and": true, "are": true, "for": true, "from": true, "into": true,
+	"that": true, "the": true, "this": true, "with": true, "who": true,
+	"can": true, "our": true, "all": true, "any": true, "its": true,
+}
+
+// keywords returns the distinct lowercase words of s, without stop words
+// and words shorter than three letters.
+func keywords(s string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 3 || stopWords[w] || seen[w] {
+			continue
+		}
+		seen[w] = true
+		out = append(out, w)
+	}
+	return out
+}
+
+func keywordMatch(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) >= 4 && strings.HasPrefix(b, a)
+}
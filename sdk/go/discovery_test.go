@@ -0,0 +1,92 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func discoveryRegistry(t *testing.T) *AgentRegistry {
+	t.Helper()
+	security := NewAgent("security-analyst", "Reviews code for vulnerabilities and insecure dependencies").WithTools("Read", "Grep")
+	security.Role = "Security Analyst"
+	security.Skills = []string{"threat-modeling", "dependency-audit"}
+	oldSecurity := NewAgent("security-analyst", "Old revision").WithTools("Read")
+	oldSecurity.Version = "1.0.0"
+	security.Version = "2.0.0"
+
+	frontend := NewAgent("frontend", "Builds React user interfaces").WithNamespace("web").WithTools("Read", "Write", "Bash")
+	frontend.Role = "Frontend Engineer"
+	frontend.Skills = []string{"react", "accessibility"}
+
+	docs := NewAgent("tech-writer", "Writes release notes and user documentation").WithTools("write")
+	docs.Tasks = []Task{{ID: "notes", Description: "Draft release notes for each version"}}
+
+	r, err := NewAgentRegistry([]*Agent{security, oldSecurity, frontend, docs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestAgentRegistryFind(t *testing.T) {
+	r := discoveryRegistry(t)
+	if got := qualifiedNames(r.FindBySkill("React")); strings.Join(got, ",") != "web/frontend" {
+		t.Errorf("FindBySkill(React) = %v", got)
+	}
+	if got := qualifiedNames(r.FindByTool("Write")); strings.Join(got, ",") != "tech-writer,web/frontend" {
+		t.Errorf("FindByTool(Write) = %v", got)
+	}
+	got := r.FindByRole(" security analyst ")
+	if len(got) != 1 || got[0].Version != "2.0.0" {
+		t.Errorf("FindByRole = %v, want the current security-analyst revision", got)
+	}
+	if got := r.FindBySkill("cobol"); len(got) != 0 {
+		t.Errorf("FindBySkill(cobol) = %v, want none", got)
+	}
+	if got := r.Current(); len(got) != 3 {
+		t.Errorf("Current = %d agents, want 3", len(got))
+	}
+}
+
+func TestAgentRegistryBestAgents(t *testing.T) {
+	r := discoveryRegistry(t)
+	ctx := context.Background()
+
+	best, err := r.BestAgents(ctx, "Audit our dependencies for security vulnerabilities", nil, 0)
+	if err != nil {
+		t.Fatalf("BestAgents: %v", err)
+	}
+	if len(best) == 0 || best[0].Agent.Name != "security-analyst" {
+		t.Fatalf("BestAgents = %v, want security-analyst first", best)
+	}
+	for _, s := range best {
+		if s.Agent.Name == "frontend" {
+			t.Errorf("frontend should not match a security task, score %v", s.Score)
+		}
+	}
+
+	best, err = r.BestAgents(ctx, "write the release notes", KeywordScorer{}, 1)
+	if err != nil {
+		t.Fatalf("BestAgents: %v", err)
+	}
+	if len(best) != 1 || best[0].Agent.Name != "tech-writer" {
+		t.Errorf("BestAgents(release notes, 1) = %v, want tech-writer", best)
+	}
+
+	if best, _ := r.BestAgents(ctx, "the and for", nil, 0); len(best) != 0 {
+		t.Errorf("BestAgents with only stop words = %v, want none", best)
+	}
+
+	wantErr := errors.New("embedding service unavailable")
+	if _, err := r.BestAgents(ctx, "anything", scorerFunc(func(context.Context, *Agent, string) (float64, error) { return 0, wantErr }), 0); !errors.Is(err, wantErr) {
+		t.Errorf("BestAgents error = %v, want scorer error", err)
+	}
+}
+
+type scorerFunc func(ctx context.Context, agent *Agent, task string) (float64, error)
+
+func (f scorerFunc) Score(ctx context.Context, agent *Agent, task string) (float64, error) {
+	return f(ctx, agent, task)
+}
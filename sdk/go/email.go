@@ -0,0 +1,358 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HTMLEmailRenderer renders TeamReport as HTML for email using
+// html/template. The output suits mail clients: a single 640px-wide
+// table layout, inline styles only, and no scripts, images, or external
+// resources.
+type HTMLEmailRenderer struct {
+	w         io.Writer
+	locations *LocationResolver
+}
+
+// HTMLEmailOption configures an HTMLEmailRenderer.
+type HTMLEmailOption func(*HTMLEmailRenderer)
+
+// WithEmailLocations links the source locations of issues and file tasks
+// to the repository l points to. File tasks show theirs before their
+// detail.
+func WithEmailLocations(l *LocationResolver) HTMLEmailOption {
+	return func(r *HTMLEmailRenderer) {
+		r.locations = l
+	}
+}
+
+// NewHTMLEmailRenderer creates a new HTMLEmailRenderer writing to w.
+func NewHTMLEmailRenderer(w io.Writer, opts ...HTMLEmailOption) *HTMLEmailRenderer {
+	r := &HTMLEmailRenderer{w: w}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Render renders the report as an HTML email body.
+// It automatically sorts teams by DAG order before rendering.
+func (r *HTMLEmailRenderer) Render(report *TeamReport) error {
+	_ = report.SortByDAG() // the cycle is noted with the project line
+
+	tmpl, err := template.New("email").Funcs(emailFuncs(r.locations)).Parse(EmailTemplate)
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+	return tmpl.Execute(r.w, report)
+}
+
+// EmailOptions sets the headers of an email message.
+type EmailOptions struct {
+	From string
+	To   []string
+	Cc   []string
+
+	// Subject defaults to "[STATUS] Title: project version".
+	Subject string
+
+	// Date defaults to the current time.
+	Date time.Time
+
+	// Locations, if set, resolves the source locations of issues and
+	// file tasks in both parts.
+	Locations *LocationResolver
+}
+
+// EmailMessage returns report as a MIME message ready to send, e.g., with
+// net/smtp: a multipart/alternative with the box rendering as text/plain
+// and the HTML email rendering as text/html.
+func EmailMessage(report *TeamReport, opts EmailOptions) ([]byte, error) {
+	var text, html bytes.Buffer
+	if err := NewRenderer(&text, WithLocations(opts.Locations)).Render(report); err != nil {
+		return nil, fmt.Errorf("rendering text: %w", err)
+	}
+	if err := NewHTMLEmailRenderer(&html, WithEmailLocations(opts.Locations)).Render(report); err != nil {
+		return nil, fmt.Errorf("rendering html: %w", err)
+	}
+
+	subject := opts.Subject
+	if subject == "" {
+		subject = fmt.Sprintf("[%s] %s: %s %s", emailStatus(report), report.EffectiveTitle(), report.Project, report.Version)
+	}
+	date := opts.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	var msg bytes.Buffer
+	body := multipart.NewWriter(&msg)
+	for _, h := range [][2]string{
+		{"From", opts.From},
+		{"To", strings.Join(opts.To, ", ")},
+		{"Cc", strings.Join(opts.Cc, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", strings.TrimSpace(subject))},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + body.Boundary()},
+	} {
+		if h[1] != "" {
+			fmt.Fprintf(&msg, "%s: %s\r\n", h[0], h[1])
+		}
+	}
+	msg.WriteString("\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// emailStatus returns the report's status, computing it when unset.
+func emailStatus(report *TeamReport) Status {
+	if report.Status != "" {
+		return report.Status
+	}
+	return report.ComputeOverallStatus()
+}
+
+// emailColors are the text and background colors of each status.
+var emailColors = map[Status][2]string{
+	StatusGo:   {"#1a7f37", "#dafbe1"},
+	StatusWarn: {"#9a6700", "#fff8c5"},
+	StatusNoGo: {"#cf222e", "#ffebe9"},
+	StatusSkip: {"#57606a", "#f6f8fa"},
+}
+
+// emailFuncs returns the template function map for HTML email rendering,
+// resolving source locations with l.
+func emailFuncs(l *LocationResolver) template.FuncMap {
+	return template.FuncMap{
+		"locationURL":      l.URL,
+		"locationText":     l.Text,
+		"status":           emailStatus,
+		"statusText":       statusText,
+		"teamStatusText":   teamStatusText,
+		"badge":            emailBadge,
+		"banner":           emailBanner,
+		"hasContentBlocks": hasContentBlocks,
+		"hasNarrative":     hasNarrative,
+		"costSummary":      emailCostSummary,
+		"sortedTags":       sortedTags,
+		"taskLocation": func(task TaskResult) string {
+			if l == nil {
+				return ""
+			}
+			return task.Location()
+		},
+	}
+}
+
+// emailBadge returns the inline style of a status label.
+func emailBadge(s Status) template.CSS {
+	c, ok := emailColors[s]
+	if !ok {
+		c = emailColors[StatusSkip]
+	}
+	return template.CSS(fmt.Sprintf("display:inline-block;padding:2px 8px;border-radius:4px;font-weight:bold;font-size:12px;color:%s;background-color:%s;", c[0], c[1]))
+}
+
+// emailBanner returns the inline style of the report's status banner.
+func emailBanner(s Status) template.CSS {
+	c, ok := emailColors[s]
+	if !ok {
+		c = emailColors[StatusSkip]
+	}
+	return template.CSS(fmt.Sprintf("padding:16px 20px;color:%s;background-color:%s;border-left:6px solid %s;", c[0], c[1], c[0]))
+}
+
+// emailCostSummary returns the report's cost summary block, or nil.
+func emailCostSummary(report *TeamReport) *ContentBlock {
+	block, ok := report.CostSummaryBlock()
+	if !ok {
+		return nil
+	}
+	return &block
+}
+
+// sortedTags returns tags as key-value pairs sorted by key.
+func sortedTags(tags map[string]string) []KVPair {
+	pairs := make([]KVPair, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, KVPair{Key: k, Value: v})
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	return pairs
+}
+
+// EmailTemplate is the HTML email template. Styles are inline because
+// many mail clients drop <style> elements.
+const EmailTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .EffectiveTitle }}</title>
+</head>
+<body style="margin:0;padding:0;background-color:#f6f8fa;">
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="background-color:#f6f8fa;">
+<tr><td align="center" style="padding:24px 8px;">
+<table role="presentation" width="640" cellpadding="0" cellspacing="0" border="0" style="width:100%;max-width:640px;background-color:#ffffff;border:1px solid #d0d7de;font-family:-apple-system,'Segoe UI',Helvetica,Arial,sans-serif;font-size:14px;line-height:1.5;color:#1f2328;">
+{{- $status := status . }}
+<tr><td style="{{ banner $status }}">
+<div style="font-size:20px;font-weight:bold;">{{ .EffectiveTitle }}: {{ statusText $status }}</div>
+<div style="font-size:13px;">{{ .Project }} {{ .Version }}{{ with .Phase }} &middot; {{ . }}{{ end }}{{ with .FilterSummary }} &middot; {{ . }}{{ end }}{{ with .CycleSummary }} &middot; {{ . }}{{ end }}</div>
+</td></tr>
+<tr><td style="padding:16px 20px;">
+<table role="presentation" cellpadding="0" cellspacing="0" border="0" style="font-size:13px;">
+<tr><td style="padding:2px 16px 2px 0;color:#57606a;">Project</td><td>{{ .Project }}</td></tr>
+<tr><td style="padding:2px 16px 2px 0;color:#57606a;">Version</td><td>{{ .Version }}</td></tr>
+{{- with .Target }}
+<tr><td style="padding:2px 16px 2px 0;color:#57606a;">Target</td><td>{{ . }}</td></tr>
+{{- end }}
+<tr><td style="padding:2px 16px 2px 0;color:#57606a;">Phase</td><td>{{ .Phase }}</td></tr>
+{{- if not .GeneratedAt.IsZero }}
+<tr><td style="padding:2px 16px 2px 0;color:#57606a;">Generated</td><td>{{ .GeneratedAt.UTC.Format "2006-01-02 15:04 UTC" }}</td></tr>
+{{- end }}
+{{- range sortedTags .Tags }}
+<tr><td style="padding:2px 16px 2px 0;color:#57606a;">{{ .Key }}</td><td>{{ .Value }}</td></tr>
+{{- end }}
+</table>
+</td></tr>
+{{- with .Summary }}
+<tr><td style="padding:0 20px 16px 20px;white-space:pre-wrap;">{{ . }}</td></tr>
+{{- end }}
+{{- range .SummaryBlocks }}
+<tr><td style="padding:0 20px 16px 20px;">{{ template "block" . }}</td></tr>
+{{- end }}
+<tr><td style="padding:0 20px 16px 20px;">
+<div style="font-size:16px;font-weight:bold;margin-bottom:8px;">Team Results</div>
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="border-collapse:collapse;font-size:13px;">
+<tr><th align="left" style="padding:6px 8px;border-bottom:2px solid #d0d7de;">Team</th><th align="left" style="padding:6px 8px;border-bottom:2px solid #d0d7de;">Status</th></tr>
+{{- range .Teams }}
+<tr><td style="padding:6px 8px;border-bottom:1px solid #eaeef2;">{{ .Name }}</td><td style="padding:6px 8px;border-bottom:1px solid #eaeef2;"><span style="{{ badge .Status }}">{{ teamStatusText . }}</span></td></tr>
+{{- end }}
+</table>
+</td></tr>
+{{- range .Teams }}
+<tr><td style="padding:0 20px 16px 20px;">
+<div style="font-size:15px;font-weight:bold;border-top:1px solid #d0d7de;padding-top:12px;">{{ .Name }} <span style="{{ badge .Status }}">{{ teamStatusText . }}</span></div>
+{{- with .Verdict }}
+<div style="margin-top:4px;"><strong>Verdict:</strong> {{ . }}</div>
+{{- end }}
+{{- if hasNarrative . }}
+{{- with .Narrative.Problem }}
+<div style="margin-top:8px;"><strong>Problem</strong></div><div style="white-space:pre-wrap;">{{ . }}</div>
+{{- end }}
+{{- with .Narrative.Analysis }}
+<div style="margin-top:8px;"><strong>Analysis</strong></div><div style="white-space:pre-wrap;">{{ . }}</div>
+{{- end }}
+{{- with .Narrative.Recommendation }}
+<div style="margin-top:8px;"><strong>Recommendation</strong></div><div style="white-space:pre-wrap;">{{ . }}</div>
+{{- end }}
+{{- end }}
+{{- if .Tasks }}
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="border-collapse:collapse;font-size:13px;margin-top:8px;">
+{{- range .Tasks }}
+<tr><td valign="top" style="padding:4px 8px 4px 0;border-bottom:1px solid #eaeef2;white-space:nowrap;"><span style="{{ badge .Status }}">{{ statusText .Status }}</span></td><td valign="top" style="padding:4px 8px;border-bottom:1px solid #eaeef2;">{{ .ID }}{{ with .Severity }} <span style="color:#57606a;">({{ . }})</span>{{ end }}</td><td valign="top" style="padding:4px 0 4px 8px;border-bottom:1px solid #eaeef2;color:#57606a;">{{ $detail := .Detail }}{{ with taskLocation . }}{{ template "location" . }}{{ if $detail }}: {{ end }}{{ end }}{{ $detail }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if hasContentBlocks . }}
+{{- range .ContentBlocks }}
+<div style="margin-top:8px;">{{ template "block" . }}</div>
+{{- end }}
+{{- end }}
+{{- with .Artifacts }}
+<div style="margin-top:8px;"><strong>Artifacts</strong></div>
+<ul style="margin:4px 0;padding-left:20px;">
+{{- range . }}
+<li><a href="{{ .Link }}" style="color:#0969da;">{{ .Name }}</a>{{ with .MediaType }} <span style="color:#57606a;">({{ . }})</span>{{ end }}</li>
+{{- end }}
+</ul>
+{{- end }}
+</td></tr>
+{{- end }}
+{{- with costSummary . }}
+<tr><td style="padding:0 20px 16px 20px;">{{ template "block" . }}</td></tr>
+{{- end }}
+{{- range .FooterBlocks }}
+<tr><td style="padding:0 20px 16px 20px;">{{ template "block" . }}</td></tr>
+{{- end }}
+{{- with .Conclusion }}
+<tr><td style="padding:0 20px 16px 20px;white-space:pre-wrap;">{{ . }}</td></tr>
+{{- end }}
+{{- with .Issues }}
+<tr><td style="padding:0 20px 16px 20px;">
+<div style="font-size:16px;font-weight:bold;margin-bottom:8px;">Issues</div>
+{{- range . }}
+<div style="margin-bottom:8px;"><strong>{{ with .ID }}{{ . }}: {{ end }}{{ .Problem }}</strong>{{ with .Severity }} <span style="color:#57606a;">({{ . }})</span>{{ end }}{{ with .Location }}<br><span style="color:#57606a;">{{ template "location" . }}</span>{{ end }}{{ with .Recommendation }}<br>{{ . }}{{ end }}</div>
+{{- end }}
+</td></tr>
+{{- end }}
+<tr><td style="padding:12px 20px;border-top:1px solid #d0d7de;font-size:12px;color:#57606a;">{{ .FinalMessage }}{{ with .GeneratedBy }} &middot; Generated by {{ . }}{{ end }}</td></tr>
+</table>
+</td></tr>
+</table>
+</body>
+</html>
+{{- define "location" }}{{ with locationURL . }}<a href="{{ . }}" style="color:#0969da;">{{ locationText $ }}</a>{{ else }}{{ . }}{{ end }}{{ end }}
+{{- define "block" }}
+{{- with .Title }}<div style="font-weight:bold;margin-bottom:4px;">{{ . }}</div>{{ end }}
+{{- if eq .Type "kv_pairs" }}
+<table role="presentation" cellpadding="0" cellspacing="0" border="0" style="font-size:13px;">
+{{- range .Pairs }}
+<tr><td style="padding:2px 16px 2px 0;color:#57606a;">{{ with .Icon }}{{ . }} {{ end }}{{ .Key }}</td><td>{{ .Value }}</td></tr>
+{{- end }}
+</table>
+{{- else if eq .Type "list" }}
+<ul style="margin:0;padding-left:20px;">
+{{- range .Items }}
+<li>{{ with .EffectiveIcon }}{{ . }} {{ end }}{{ .Text }}</li>
+{{- end }}
+</ul>
+{{- else if eq .Type "table" }}
+<table role="presentation" width="100%" cellpadding="0" cellspacing="0" border="0" style="border-collapse:collapse;font-size:13px;">
+<tr>{{ range .Headers }}<th align="left" style="padding:4px 8px;border-bottom:2px solid #d0d7de;">{{ . }}</th>{{ end }}</tr>
+{{- range .Rows }}
+<tr>{{ range . }}<td style="padding:4px 8px;border-bottom:1px solid #eaeef2;">{{ . }}</td>{{ end }}</tr>
+{{- end }}
+</table>
+{{- else if eq .Type "text" }}
+<div style="white-space:pre-wrap;">{{ .Content }}</div>
+{{- else if eq .Type "metric" }}
+<div><strong>{{ .Label }}:</strong> {{ .Value }}{{ with .Target }} (target: {{ . }}){{ end }} <span style="{{ badge .Status }}">{{ statusText .Status }}</span></div>
+{{- end }}
+{{- end }}
+`
@@ -0,0 +1,143 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+func emailTestReport() *TeamReport {
+	return &TeamReport{
+		Project:     "my-app",
+		Version:     "v1.2.0",
+		Phase:       "RELEASE",
+		Tags:        map[string]string{"environment": "prod"},
+		GeneratedAt: time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC),
+		Teams: []TeamSection{
+			{ID: "qa", Name: "QA", Status: StatusGo, Tasks: []TaskResult{{ID: "unit", Status: StatusGo, Detail: "42 passed"}}},
+			{ID: "security", Name: "Security", Status: StatusNoGo, Tasks: []TaskResult{{ID: "scan", Status: StatusNoGo, Detail: `<script>alert("x")</script>`}},
+				ContentBlocks: []ContentBlock{NewMetricBlock("Coverage", "71%", StatusWarn, "80%")},
+				Artifacts:     []Artifact{{Name: "SBOM", Path: "sbom.spdx.json", MediaType: "application/spdx+json"}, {Name: "Bad", URL: "javascript:alert(1)"}}},
+		},
+	}
+}
+
+func TestHTMLEmailRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewHTMLEmailRenderer(&buf).Render(emailTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, want := range []string{
+		"TEAM STATUS REPORT: FAIL",
+		"my-app v1.2.0 &middot; RELEASE",
+		"2026-01-02 03:04 UTC",
+		">environment</td><td>prod<",
+		"&lt;script&gt;",
+		"<strong>Coverage:</strong> 71% (target: 80%)",
+		"color:#cf222e;background-color:#ffebe9;",
+		`<a href="sbom.spdx.json" style="color:#0969da;">SBOM</a> <span style="color:#57606a;">(application/spdx&#43;json)</span>`,
+		`<a href="#ZgotmplZ" style="color:#0969da;">Bad</a>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"<script", "<style", "<link", "<img", "javascript:"} {
+		if strings.Contains(html, unwanted) {
+			t.Errorf("HTML contains %q", unwanted)
+		}
+	}
+}
+
+func TestEmailMessage(t *testing.T) {
+	data, err := EmailMessage(emailTestReport(), EmailOptions{
+		From: "ci@example.com",
+		To:   []string{"dev@example.com", "qa@example.com"},
+		Date: time.Date(2026, 1, 2, 4, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := msg.Header.Get("Subject"); got != "[NO-GO] TEAM STATUS REPORT: my-app v1.2.0" {
+		t.Errorf("Subject = %q", got)
+	}
+	if got := msg.Header.Get("To"); got != "dev@example.com, qa@example.com" {
+		t.Errorf("To = %q", got)
+	}
+	if msg.Header.Get("Cc") != "" {
+		t.Error("empty Cc header written")
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", msg.Header.Get("Content-Type"), err)
+	}
+
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	var types []string
+	var bodies []string
+	for {
+		part, err := r.NextPart() // decodes quoted-printable
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		types = append(types, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+	if len(types) != 2 || types[0] != "text/plain; charset=utf-8" || types[1] != "text/html; charset=utf-8" {
+		t.Fatalf("parts = %v", types)
+	}
+	if !strings.HasPrefix(bodies[0], "╔") || !strings.Contains(bodies[0], "42 passed") {
+		t.Errorf("text part is not the box rendering:\n%s", bodies[0])
+	}
+	if !strings.HasPrefix(bodies[1], "<!DOCTYPE html>") {
+		t.Errorf("html part = %.80q", bodies[1])
+	}
+
+	data, err = EmailMessage(emailTestReport(), EmailOptions{Subject: "Nightly ✅"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Subject: =?utf-8?q?Nightly_=E2=9C=85?=\r\n") {
+		t.Errorf("subject not Q-encoded:\n%.200s", data)
+	}
+}
+
+func TestHTMLEmailLocations(t *testing.T) {
+	l := &LocationResolver{BaseURL: "https://github.com/org/repo", Commit: "abc123"}
+	var buf bytes.Buffer
+	if err := NewHTMLEmailRenderer(&buf, WithEmailLocations(l)).Render(locationTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		`<a href="https://github.com/org/repo/blob/abc123/cmd/main.go#L42" style="color:#0969da;">cmd/main.go:42</a>: unused variable`,
+		`<a href="https://github.com/org/repo/blob/abc123/pkg/run.go#L10" style="color:#0969da;">pkg/run.go:10</a>`,
+		`requirements.functional[2]`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := NewHTMLEmailRenderer(&buf).Render(locationTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "<a href") || strings.Contains(out, "cmd/main.go") {
+		t.Errorf("output without WithEmailLocations:\n%s", out)
+	}
+}
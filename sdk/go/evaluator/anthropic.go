@@ -0,0 +1,79 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+const anthropicVersion = "2023-06-01"
+
+// Anthropic evaluates with the Anthropic Messages API.
+type Anthropic struct {
+	cfg   Config
+	model string
+}
+
+// NewAnthropic returns an Anthropic evaluator. It needs cfg.APIKey or
+// ANTHROPIC_API_KEY.
+func NewAnthropic(cfg Config) (*Anthropic, error) {
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv("ANTHROPIC_API_KEY")
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("anthropic: api key is required (set ANTHROPIC_API_KEY)")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.anthropic.com"
+	}
+	return &Anthropic{cfg: cfg, model: multiagentspec.MapModelToAnthropic(cfg.model())}, nil
+}
+
+// Model returns the provider model ID recorded in evaluations.
+func (e *Anthropic) Model() string { return e.model }
+
+// PromptVersion returns the prompt version recorded in evaluations.
+func (e *Anthropic) PromptVersion() string { return e.cfg.promptVersion() }
+
+// Evaluate implements multiagentspec.Evaluator.
+func (e *Anthropic) Evaluate(ctx context.Context, rubric, content string) (*multiagentspec.LLMEvaluation, error) {
+	return evaluate(e.cfg, ProviderAnthropic, e.model, func() (*completion, error) {
+		req := map[string]any{
+			"model":       e.model,
+			"max_tokens":  e.cfg.maxTokens(),
+			"temperature": 0,
+			"system":      e.cfg.systemPrompt(),
+			"messages": []map[string]any{
+				{"role": "user", "content": userPrompt(rubric, content)},
+			},
+		}
+		var resp struct {
+			Content []struct {
+				Type string `json:"type"`
+				Text string `json:"text"`
+			} `json:"content"`
+			Usage struct {
+				InputTokens  int `json:"input_tokens"`
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+		}
+		header := http.Header{}
+		header.Set("x-api-key", e.cfg.APIKey)
+		header.Set("anthropic-version", anthropicVersion)
+		url := strings.TrimSuffix(e.cfg.BaseURL, "/") + "/v1/messages"
+		if err := postJSON(ctx, e.cfg.httpClient(), url, header, req, &resp, nil); err != nil {
+			return nil, err
+		}
+		var text strings.Builder
+		for _, block := range resp.Content {
+			if block.Type == "text" {
+				text.WriteString(block.Text)
+			}
+		}
+		return &completion{text: text.String(), tokens: resp.Usage.InputTokens + resp.Usage.OutputTokens}, nil
+	})
+}
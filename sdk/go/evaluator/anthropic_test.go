@@ -0,0 +1,61 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestAnthropicEvaluate(t *testing.T) {
+	srv, req, body := testServer(t, map[string]any{
+		"content": []map[string]string{{"type": "text", "text": testAnswer}},
+		"usage":   map[string]int{"input_tokens": 300, "output_tokens": 80},
+	})
+	ev, err := NewAnthropic(Config{APIKey: "sk-test", BaseURL: srv.URL, Model: "haiku"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eval, err := ev.Evaluate(context.Background(), "Score the plan's completeness.", "# Release plan")
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	if req.URL.Path != "/v1/messages" || req.Header.Get("x-api-key") != "sk-test" || req.Header.Get("anthropic-version") == "" {
+		t.Errorf("request = %s %v", req.URL.Path, req.Header)
+	}
+	var sent struct {
+		Model    string `json:"model"`
+		System   string `json:"system"`
+		Messages []struct {
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(*body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.Model != "claude-3-5-haiku-latest" || sent.System != DefaultSystemPrompt {
+		t.Errorf("sent model = %q, system = %q", sent.Model, sent.System)
+	}
+	if len(sent.Messages) != 1 || !containsAll(sent.Messages[0].Content, "<rubric>\nScore the plan's completeness.\n</rubric>", "# Release plan") {
+		t.Errorf("sent messages = %+v", sent.Messages)
+	}
+
+	if eval.Score != 7.5 || eval.Provider != ProviderAnthropic || eval.Model != "claude-3-5-haiku-latest" {
+		t.Errorf("eval = %+v", eval)
+	}
+	if eval.TokensUsed != 380 || eval.PromptVersion != DefaultPromptVersion {
+		t.Errorf("TokensUsed = %d, PromptVersion = %q", eval.TokensUsed, eval.PromptVersion)
+	}
+}
+
+func TestNewAnthropicAPIKeyFromEnv(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "")
+	if _, err := NewAnthropic(Config{}); err == nil {
+		t.Error("expected error without api key")
+	}
+	t.Setenv("ANTHROPIC_API_KEY", "sk-env")
+	ev, err := NewAnthropic(Config{})
+	if err != nil || ev.cfg.APIKey != "sk-env" || ev.model != "claude-sonnet-4-0" {
+		t.Errorf("NewAnthropic = %+v, %v", ev, err)
+	}
+}
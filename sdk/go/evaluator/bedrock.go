@@ -0,0 +1,112 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/internal/sigv4"
+)
+
+// AWSCredentials sign Bedrock requests.
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is set for temporary credentials.
+	SessionToken string
+}
+
+// awsCredentialsFromEnv reads AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and
+// AWS_SESSION_TOKEN.
+func awsCredentialsFromEnv() *AWSCredentials {
+	creds, ok := sigv4.CredentialsFromEnv()
+	if !ok {
+		return nil
+	}
+	return &AWSCredentials{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+	}
+}
+
+// Bedrock evaluates with the AWS Bedrock Converse API.
+type Bedrock struct {
+	cfg   Config
+	model string
+	now   func() time.Time
+}
+
+// NewBedrock returns a Bedrock evaluator. It needs a region and
+// credentials, from cfg or the standard AWS environment variables; shared
+// config files and instance roles are not read.
+func NewBedrock(cfg Config) (*Bedrock, error) {
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_REGION")
+	}
+	if cfg.Region == "" {
+		cfg.Region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("bedrock: region is required (set AWS_REGION)")
+	}
+	if cfg.Credentials == nil {
+		cfg.Credentials = awsCredentialsFromEnv()
+	}
+	if cfg.Credentials == nil {
+		return nil, fmt.Errorf("bedrock: credentials are required (set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://bedrock-runtime." + cfg.Region + ".amazonaws.com"
+	}
+	return &Bedrock{cfg: cfg, model: multiagentspec.MapModelToBedrock(cfg.model()), now: time.Now}, nil
+}
+
+// Model returns the provider model ID recorded in evaluations.
+func (e *Bedrock) Model() string { return e.model }
+
+// PromptVersion returns the prompt version recorded in evaluations.
+func (e *Bedrock) PromptVersion() string { return e.cfg.promptVersion() }
+
+// Evaluate implements multiagentspec.Evaluator.
+func (e *Bedrock) Evaluate(ctx context.Context, rubric, content string) (*multiagentspec.LLMEvaluation, error) {
+	return evaluate(e.cfg, ProviderBedrock, e.model, func() (*completion, error) {
+		req := map[string]any{
+			"system": []map[string]string{{"text": e.cfg.systemPrompt()}},
+			"messages": []map[string]any{
+				{"role": "user", "content": []map[string]string{{"text": userPrompt(rubric, content)}}},
+			},
+			"inferenceConfig": map[string]any{"maxTokens": e.cfg.maxTokens(), "temperature": 0},
+		}
+		var resp struct {
+			Output struct {
+				Message struct {
+					Content []struct {
+						Text string `json:"text"`
+					} `json:"content"`
+				} `json:"message"`
+			} `json:"output"`
+			Usage struct {
+				TotalTokens int `json:"totalTokens"`
+			} `json:"usage"`
+		}
+		url := strings.TrimSuffix(e.cfg.BaseURL, "/") + "/model/" + sigv4.URIEncode(e.model, true) + "/converse"
+		sign := func(r *http.Request, body []byte) error {
+			signV4(r, body, e.cfg.Credentials, e.cfg.Region, "bedrock", e.now())
+			return nil
+		}
+		if err := postJSON(ctx, e.cfg.httpClient(), url, nil, req, &resp, sign); err != nil {
+			return nil, err
+		}
+		var text strings.Builder
+		for _, block := range resp.Output.Message.Content {
+			text.WriteString(block.Text)
+		}
+		return &completion{text: text.String(), tokens: resp.Usage.TotalTokens}, nil
+	})
+}
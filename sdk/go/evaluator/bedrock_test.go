@@ -0,0 +1,78 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBedrockEvaluate(t *testing.T) {
+	srv, req, body := testServer(t, map[string]any{
+		"output": map[string]any{"message": map[string]any{
+			"role":    "assistant",
+			"content": []map[string]string{{"text": testAnswer}},
+		}},
+		"usage": map[string]int{"inputTokens": 290, "outputTokens": 90, "totalTokens": 380},
+	})
+	ev, err := NewBedrock(Config{
+		BaseURL:     srv.URL,
+		Region:      "us-west-2",
+		Credentials: &AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ev.now = func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	eval, err := ev.Evaluate(context.Background(), "rubric", "content")
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	if got := req.URL.EscapedPath(); got != "/model/anthropic.claude-3-5-sonnet-20241022-v2%3A0/converse" {
+		t.Errorf("path = %q", got)
+	}
+	if got := req.Header.Get("Authorization"); !containsAll(got, "Credential=AKID/20260102/us-west-2/bedrock/aws4_request", "SignedHeaders=content-type;host;x-amz-date") {
+		t.Errorf("Authorization = %q", got)
+	}
+	var sent struct {
+		System []struct {
+			Text string `json:"text"`
+		} `json:"system"`
+		InferenceConfig struct {
+			MaxTokens int `json:"maxTokens"`
+		} `json:"inferenceConfig"`
+	}
+	if err := json.Unmarshal(*body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent.System) != 1 || sent.System[0].Text != DefaultSystemPrompt || sent.InferenceConfig.MaxTokens != 1024 {
+		t.Errorf("sent = %+v", sent)
+	}
+	if eval.Provider != ProviderBedrock || eval.Model != "anthropic.claude-3-5-sonnet-20241022-v2:0" || eval.TokensUsed != 380 {
+		t.Errorf("eval = %+v", eval)
+	}
+}
+
+func TestNewBedrockFromEnv(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "eu-west-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "token")
+	ev, err := NewBedrock(Config{Model: "opus"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ev.cfg.Region != "eu-west-1" || ev.cfg.BaseURL != "https://bedrock-runtime.eu-west-1.amazonaws.com" {
+		t.Errorf("cfg = %+v", ev.cfg)
+	}
+	if ev.cfg.Credentials.SessionToken != "token" || ev.model != "anthropic.claude-3-opus-20240229-v1:0" {
+		t.Errorf("credentials = %+v, model = %q", ev.cfg.Credentials, ev.model)
+	}
+
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	if _, err := NewBedrock(Config{}); err == nil {
+		t.Error("expected error without region")
+	}
+}
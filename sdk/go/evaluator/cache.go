@@ -0,0 +1,236 @@
+package evaluator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Cache stores evaluations by key. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the evaluation stored under key, if any.
+	Get(ctx context.Context, key string) (*multiagentspec.LLMEvaluation, bool, error)
+
+	// Put stores eval under key.
+	Put(ctx context.Context, key string, eval *multiagentspec.LLMEvaluation) error
+}
+
+// Versioned is implemented by evaluators that report the model and prompt
+// version their evaluations record, as this package's evaluators do, so
+// Cached can re-evaluate when either changes.
+type Versioned interface {
+	Model() string
+	PromptVersion() string
+}
+
+// CacheKey identifies an evaluation: the same content graded against the
+// same rubric with the same prompt and model gets the same result.
+type CacheKey struct {
+	// Content is the evaluated content.
+	Content string
+
+	// Rubric is the rubric text. Rubric.Prompt includes the rubric's
+	// version, so a new rubric version is a new key.
+	Rubric string
+
+	// PromptVersion is the evaluator's prompt version.
+	PromptVersion string
+
+	// Model is the evaluator's model ID.
+	Model string
+}
+
+// String returns the key as a hex SHA-256 digest, usable as a file name.
+func (k CacheKey) String() string {
+	h := sha256.New()
+	for _, part := range []string{k.Content, k.Rubric, k.PromptVersion, k.Model} {
+		// Length-prefix each part so boundaries cannot shift.
+		fmt.Fprintf(h, "%d:%s\n", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CacheStats counts the lookups of a Cached evaluator.
+type CacheStats struct {
+	// Hits is the number of evaluations served from the cache.
+	Hits int `json:"hits"`
+
+	// Misses is the number of evaluations sent to the model.
+	Misses int `json:"misses"`
+
+	// TokensSaved is the sum of TokensUsed over cache hits.
+	TokensSaved int `json:"tokensSaved,omitempty"`
+}
+
+// HitRate returns the fraction of lookups that hit, or zero if there were
+// none.
+func (s CacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// ContentBlock returns the stats as a report block, e.g., for a report's
+// FooterBlocks.
+func (s CacheStats) ContentBlock() multiagentspec.ContentBlock {
+	return multiagentspec.NewKVPairsBlock("Evaluation Cache",
+		multiagentspec.KVPair{Key: "Hits", Value: strconv.Itoa(s.Hits)},
+		multiagentspec.KVPair{Key: "Misses", Value: strconv.Itoa(s.Misses)},
+		multiagentspec.KVPair{Key: "Hit rate", Value: fmt.Sprintf("%.0f%%", 100*s.HitRate())},
+		multiagentspec.KVPair{Key: "Tokens saved", Value: strconv.Itoa(s.TokensSaved)},
+	)
+}
+
+// Cached evaluates with another Evaluator and reuses its evaluations from
+// a Cache, so unchanged documents are not re-sent to the model.
+type Cached struct {
+	ev    multiagentspec.Evaluator
+	cache Cache
+
+	mu    sync.Mutex
+	stats CacheStats
+}
+
+// NewCached returns ev with its evaluations cached in cache. Evaluations
+// are keyed on the content, rubric, and, if ev implements Versioned, the
+// prompt version and model.
+func NewCached(ev multiagentspec.Evaluator, cache Cache) *Cached {
+	return &Cached{ev: ev, cache: cache}
+}
+
+// Evaluate implements multiagentspec.Evaluator, returning the cached
+// evaluation for the key when present.
+func (c *Cached) Evaluate(ctx context.Context, rubric, content string) (*multiagentspec.LLMEvaluation, error) {
+	key := CacheKey{Content: content, Rubric: rubric}
+	if v, ok := c.ev.(Versioned); ok {
+		key.PromptVersion, key.Model = v.PromptVersion(), v.Model()
+	}
+	eval, ok, err := c.cache.Get(ctx, key.String())
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+	if ok {
+		c.record(func(s *CacheStats) { s.Hits++; s.TokensSaved += eval.TokensUsed })
+		return eval, nil
+	}
+	c.record(func(s *CacheStats) { s.Misses++ })
+	if eval, err = c.ev.Evaluate(ctx, rubric, content); err != nil {
+		return nil, err
+	}
+	if err := c.cache.Put(ctx, key.String(), eval); err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
+	return eval, nil
+}
+
+// Stats returns the lookups so far.
+func (c *Cached) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *Cached) record(update func(*CacheStats)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	update(&c.stats)
+}
+
+// FileCache stores each evaluation as a JSON file named by its key in a
+// directory, created on first write.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache returns a cache in dir.
+func NewFileCache(dir string) *FileCache {
+	return &FileCache{dir: dir}
+}
+
+// Get implements Cache. Unreadable entries are misses, so they are
+// re-evaluated and overwritten.
+func (c *FileCache) Get(_ context.Context, key string) (*multiagentspec.LLMEvaluation, bool, error) {
+	data, err := os.ReadFile(c.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var eval multiagentspec.LLMEvaluation
+	if err := json.Unmarshal(data, &eval); err != nil {
+		return nil, false, nil
+	}
+	return &eval, true, nil
+}
+
+// Put implements Cache, writing through a temporary file so readers never
+// see a partial entry.
+func (c *FileCache) Put(_ context.Context, key string, eval *multiagentspec.LLMEvaluation) error {
+	data, err := json.MarshalIndent(eval, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *FileCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// MemoryCache stores evaluations in memory, e.g., for one run or tests.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]multiagentspec.LLMEvaluation
+}
+
+// NewMemoryCache returns an empty in-memory cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]multiagentspec.LLMEvaluation)}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(_ context.Context, key string) (*multiagentspec.LLMEvaluation, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	eval, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return &eval, true, nil
+}
+
+// Put implements Cache.
+func (c *MemoryCache) Put(_ context.Context, key string, eval *multiagentspec.LLMEvaluation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = *eval
+	return nil
+}
@@ -0,0 +1,125 @@
+package evaluator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// countingEvaluator scores every call 6 and counts calls.
+type countingEvaluator struct {
+	calls         int
+	model, prompt string
+}
+
+func (e *countingEvaluator) Evaluate(_ context.Context, _, _ string) (*multiagentspec.LLMEvaluation, error) {
+	e.calls++
+	return &multiagentspec.LLMEvaluation{Score: 6, Model: e.model, TokensUsed: 100, PromptVersion: e.prompt}, nil
+}
+
+func (e *countingEvaluator) Model() string         { return e.model }
+func (e *countingEvaluator) PromptVersion() string { return e.prompt }
+
+func TestCachedEvaluate(t *testing.T) {
+	ctx := context.Background()
+	for name, cache := range map[string]Cache{
+		"memory": NewMemoryCache(),
+		"file":   NewFileCache(filepath.Join(t.TempDir(), "cache")),
+	} {
+		inner := &countingEvaluator{model: "claude-sonnet-4-0", prompt: "v1"}
+		ev := NewCached(inner, cache)
+		for i := 0; i < 3; i++ {
+			eval, err := ev.Evaluate(ctx, "rubric", "doc")
+			if err != nil {
+				t.Fatalf("%s: Evaluate: %v", name, err)
+			}
+			if eval.Score != 6 || eval.Model != "claude-sonnet-4-0" {
+				t.Errorf("%s: eval = %+v", name, eval)
+			}
+		}
+		if _, err := ev.Evaluate(ctx, "rubric", "changed doc"); err != nil {
+			t.Fatal(err)
+		}
+		inner.prompt = "v2"
+		if _, err := ev.Evaluate(ctx, "rubric", "doc"); err != nil {
+			t.Fatal(err)
+		}
+		if inner.calls != 3 {
+			t.Errorf("%s: inner evaluator called %d times, want 3", name, inner.calls)
+		}
+		want := CacheStats{Hits: 2, Misses: 3, TokensSaved: 200}
+		if got := ev.Stats(); got != want {
+			t.Errorf("%s: Stats() = %+v, want %+v", name, got, want)
+		}
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	base := CacheKey{Content: "doc", Rubric: "rubric", PromptVersion: "v1", Model: "m"}
+	if len(base.String()) != 64 {
+		t.Errorf("String() = %q", base.String())
+	}
+	for _, k := range []CacheKey{
+		{Content: "doc2", Rubric: "rubric", PromptVersion: "v1", Model: "m"},
+		{Content: "doc", Rubric: "rubric v2", PromptVersion: "v1", Model: "m"},
+		{Content: "doc", Rubric: "rubric", PromptVersion: "v2", Model: "m"},
+		{Content: "doc", Rubric: "rubric", PromptVersion: "v1", Model: "m2"},
+		{Content: "docr", Rubric: "ubric", PromptVersion: "v1", Model: "m"},
+	} {
+		if k.String() == base.String() {
+			t.Errorf("%+v has the same key as %+v", k, base)
+		}
+	}
+}
+
+func TestFileCacheInvalidEntry(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewFileCache(dir)
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := cache.Get(context.Background(), "bad"); ok || err != nil {
+		t.Errorf("Get(invalid) = %v, %v; want a miss", ok, err)
+	}
+	if err := cache.Put(context.Background(), "bad", &multiagentspec.LLMEvaluation{Score: 4}); err != nil {
+		t.Fatal(err)
+	}
+	eval, ok, err := cache.Get(context.Background(), "bad")
+	if err != nil || !ok || eval.Score != 4 {
+		t.Errorf("Get = %+v, %v, %v", eval, ok, err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("cache dir has %d entries, want 1", len(entries))
+	}
+}
+
+func TestCacheStatsContentBlock(t *testing.T) {
+	block := CacheStats{Hits: 3, Misses: 1, TokensSaved: 1200}.ContentBlock()
+	want := map[string]string{"Hits": "3", "Misses": "1", "Hit rate": "75%", "Tokens saved": "1200"}
+	if block.Title != "Evaluation Cache" || len(block.Pairs) != len(want) {
+		t.Fatalf("block = %+v", block)
+	}
+	for _, p := range block.Pairs {
+		if want[p.Key] != p.Value {
+			t.Errorf("%s = %q, want %q", p.Key, p.Value, want[p.Key])
+		}
+	}
+	if (CacheStats{}).HitRate() != 0 {
+		t.Error("HitRate() of no lookups != 0")
+	}
+}
+
+func TestProviderVersioned(t *testing.T) {
+	ev, err := NewOpenAI(Config{APIKey: "k", Model: "haiku"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v Versioned = ev
+	if v.Model() != "gpt-4o-mini" || v.PromptVersion() != DefaultPromptVersion {
+		t.Errorf("Model() = %q, PromptVersion() = %q", v.Model(), v.PromptVersion())
+	}
+}
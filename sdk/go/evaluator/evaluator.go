@@ -0,0 +1,268 @@
+// Package evaluator implements multiagentspec.Evaluator on the Anthropic,
+// OpenAI, and AWS Bedrock APIs, so agents and CLI commands can grade
+// content against a rubric and get results as the spec's LLMEvaluation.
+//
+// Example:
+//
+//	ev, err := evaluator.New(evaluator.Config{Provider: evaluator.ProviderAnthropic, Model: "sonnet"})
+//	if err != nil {
+//	    return err
+//	}
+//	eval, err := ev.Evaluate(ctx, rubric, document)
+package evaluator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Providers recorded in LLMEvaluation.Provider.
+const (
+	ProviderAnthropic = "anthropic"
+	ProviderOpenAI    = "openai"
+	ProviderBedrock   = "bedrock"
+)
+
+// DefaultPromptVersion identifies DefaultSystemPrompt in
+// LLMEvaluation.PromptVersion.
+const DefaultPromptVersion = "mas-eval-v1"
+
+// DefaultSystemPrompt instructs the model to grade content against the
+// rubric and answer with the JSON the evaluators parse.
+const DefaultSystemPrompt = `You are a strict, fair evaluator. Grade the content against the rubric.
+
+Respond with only a JSON object, no prose or code fences, of this form:
+{
+  "score": <number from 0 to 10>,
+  "confidence": <number from 0 to 1>,
+  "reasoning": "<why the content earned this score>",
+  "strengths": ["<positive aspect>", ...],
+  "concerns": ["<issue or problem>", ...],
+  "suggestions": ["<actionable improvement>", ...]
+}`
+
+// maxScore is the scale DefaultSystemPrompt asks for.
+const maxScore = 10
+
+// Config configures an evaluator.
+type Config struct {
+	// Provider is anthropic, openai, or bedrock; used by New.
+	Provider string
+
+	// Model is a canonical tier (haiku, sonnet, opus), mapped to the
+	// provider's identifier, or a provider model ID (default: sonnet).
+	Model string
+
+	// APIKey authenticates with Anthropic or OpenAI (default: the
+	// ANTHROPIC_API_KEY or OPENAI_API_KEY environment variable).
+	APIKey string
+
+	// BaseURL overrides the API endpoint, e.g., for a proxy.
+	BaseURL string
+
+	// Region is the Bedrock region (default: AWS_REGION, then
+	// AWS_DEFAULT_REGION).
+	Region string
+
+	// Credentials sign Bedrock requests (default: from the environment).
+	Credentials *AWSCredentials
+
+	// MaxTokens caps the response length (default: 1024).
+	MaxTokens int
+
+	// SystemPrompt replaces DefaultSystemPrompt. It must ask for the same
+	// JSON response.
+	SystemPrompt string
+
+	// PromptVersion is recorded in each evaluation (default:
+	// DefaultPromptVersion when SystemPrompt is empty).
+	PromptVersion string
+
+	// Prompt, if set, is a prompt library template used as the system
+	// prompt and recorded as its PromptVersion, name@version, instead of
+	// SystemPrompt and PromptVersion. It must ask for the same JSON
+	// response.
+	Prompt *multiagentspec.PromptTemplate
+
+	// HTTPClient sends requests (default: http.DefaultClient).
+	HTTPClient *http.Client
+}
+
+// New returns the evaluator for cfg.Provider.
+func New(cfg Config) (multiagentspec.Evaluator, error) {
+	switch cfg.Provider {
+	case ProviderAnthropic:
+		return NewAnthropic(cfg)
+	case ProviderOpenAI:
+		return NewOpenAI(cfg)
+	case ProviderBedrock:
+		return NewBedrock(cfg)
+	case "":
+		return nil, fmt.Errorf("provider is required")
+	default:
+		return nil, fmt.Errorf("unknown provider %q", cfg.Provider)
+	}
+}
+
+func (c Config) model() multiagentspec.Model {
+	if c.Model == "" {
+		return multiagentspec.ModelSonnet
+	}
+	return multiagentspec.Model(c.Model)
+}
+
+func (c Config) maxTokens() int {
+	if c.MaxTokens > 0 {
+		return c.MaxTokens
+	}
+	return 1024
+}
+
+func (c Config) systemPrompt() string {
+	if c.Prompt != nil {
+		return c.Prompt.Template
+	}
+	if c.SystemPrompt != "" {
+		return c.SystemPrompt
+	}
+	return DefaultSystemPrompt
+}
+
+func (c Config) promptVersion() string {
+	if c.Prompt != nil {
+		return c.Prompt.PromptVersion()
+	}
+	if c.PromptVersion == "" && c.SystemPrompt == "" {
+		return DefaultPromptVersion
+	}
+	return c.PromptVersion
+}
+
+func (c Config) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// userPrompt returns the message carrying the rubric and content.
+func userPrompt(rubric, content string) string {
+	return "<rubric>\n" + strings.TrimSpace(rubric) + "\n</rubric>\n\n<content>\n" + strings.TrimSpace(content) + "\n</content>"
+}
+
+// completion is a provider's answer to a prompt.
+type completion struct {
+	text   string
+	tokens int
+}
+
+// evaluate runs complete and parses its answer into an evaluation.
+func evaluate(cfg Config, provider, model string, complete func() (*completion, error)) (*multiagentspec.LLMEvaluation, error) {
+	start := time.Now()
+	c, err := complete()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", provider, err)
+	}
+	eval, err := parseEvaluation(c.text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", provider, err)
+	}
+	eval.Model = model
+	eval.Provider = provider
+	eval.TokensUsed = c.tokens
+	eval.LatencyMs = int(time.Since(start).Milliseconds())
+	eval.PromptVersion = cfg.promptVersion()
+	return eval, nil
+}
+
+// parseEvaluation decodes the JSON object in a model's answer, tolerating
+// code fences and prose around it.
+func parseEvaluation(text string) (*multiagentspec.LLMEvaluation, error) {
+	start, end := strings.Index(text, "{"), strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("response has no JSON object: %q", truncate(text))
+	}
+	var out struct {
+		Score       *float64 `json:"score"`
+		Confidence  float64  `json:"confidence"`
+		Reasoning   string   `json:"reasoning"`
+		Strengths   []string `json:"strengths"`
+		Concerns    []string `json:"concerns"`
+		Suggestions []string `json:"suggestions"`
+	}
+	if err := json.Unmarshal([]byte(text[start:end+1]), &out); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	if out.Score == nil {
+		return nil, fmt.Errorf("response has no score")
+	}
+	if *out.Score < 0 || *out.Score > maxScore {
+		return nil, fmt.Errorf("score %g is outside 0-%d", *out.Score, maxScore)
+	}
+	if out.Confidence < 0 || out.Confidence > 1 {
+		return nil, fmt.Errorf("confidence %g is outside 0-1", out.Confidence)
+	}
+	return &multiagentspec.LLMEvaluation{
+		Score:       *out.Score,
+		MaxScore:    maxScore,
+		Confidence:  out.Confidence,
+		Reasoning:   out.Reasoning,
+		Strengths:   out.Strengths,
+		Concerns:    out.Concerns,
+		Suggestions: out.Suggestions,
+	}, nil
+}
+
+// postJSON sends body as JSON to url and decodes the JSON response into
+// out. sign, if non-nil, adds authentication to the request.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body, out any, sign func(*http.Request, []byte) error) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if sign != nil {
+		if err := sign(req, data); err != nil {
+			return err
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %s", resp.Status, truncate(string(respBody)))
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func truncate(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 200 {
+		return s[:200] + "..."
+	}
+	return s
+}
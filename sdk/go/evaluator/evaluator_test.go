@@ -0,0 +1,128 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+const testAnswer = `{"score": 7.5, "confidence": 0.8, "reasoning": "Clear but thin on risks.",
+"strengths": ["clear goals"], "concerns": ["no rollback plan"], "suggestions": ["add a rollback section"]}`
+
+// testServer serves handler and records the last request and its body.
+func testServer(t *testing.T, response any) (*httptest.Server, *http.Request, *[]byte) {
+	t.Helper()
+	var last http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		last = *r.Clone(context.Background())
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &last, &body
+}
+
+func containsAll(s string, subs ...string) bool {
+	for _, sub := range subs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNew(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	tests := []struct {
+		cfg     Config
+		wantErr string
+	}{
+		{Config{Provider: ProviderAnthropic, APIKey: "k"}, ""},
+		{Config{Provider: ProviderOpenAI, APIKey: "k"}, ""},
+		{Config{Provider: ProviderBedrock, Region: "us-east-1", Credentials: &AWSCredentials{AccessKeyID: "a", SecretAccessKey: "s"}}, ""},
+		{Config{}, "provider is required"},
+		{Config{Provider: "gemini"}, `unknown provider "gemini"`},
+		{Config{Provider: ProviderBedrock, Region: "us-east-1"}, "bedrock: credentials are required"},
+	}
+	for _, tt := range tests {
+		_, err := New(tt.cfg)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("New(%+v) = %v", tt.cfg, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("New(%+v) = %v, want %q", tt.cfg, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseEvaluation(t *testing.T) {
+	eval, err := parseEvaluation("Here is my evaluation:\n```json\n" + testAnswer + "\n```")
+	if err != nil {
+		t.Fatalf("parseEvaluation: %v", err)
+	}
+	if eval.Score != 7.5 || eval.MaxScore != 10 || eval.Confidence != 0.8 {
+		t.Errorf("eval = %+v", eval)
+	}
+	if len(eval.Concerns) != 1 || eval.Concerns[0] != "no rollback plan" {
+		t.Errorf("Concerns = %v", eval.Concerns)
+	}
+
+	for text, want := range map[string]string{
+		"I cannot evaluate this.":            "response has no JSON object",
+		`{"reasoning": "ok"}`:                "response has no score",
+		`{"score": 12}`:                      "score 12 is outside 0-10",
+		`{"score": 5, "confidence": 2}`:      "confidence 2 is outside 0-1",
+		`{"score": "high", "confidence": 1}`: "parse response",
+	} {
+		if _, err := parseEvaluation(text); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parseEvaluation(%q) = %v, want %q", text, err, want)
+		}
+	}
+}
+
+func TestPromptVersion(t *testing.T) {
+	if got := (Config{}).promptVersion(); got != DefaultPromptVersion {
+		t.Errorf("default promptVersion = %q", got)
+	}
+	if got := (Config{SystemPrompt: "custom"}).promptVersion(); got != "" {
+		t.Errorf("custom prompt without version = %q, want empty", got)
+	}
+	if got := (Config{SystemPrompt: "custom", PromptVersion: "v2"}).promptVersion(); got != "v2" {
+		t.Errorf("promptVersion = %q", got)
+	}
+
+	cfg := Config{SystemPrompt: "custom", PromptVersion: "v2", Prompt: &multiagentspec.PromptTemplate{Name: "prd-review", Version: "1.1.0", Template: "Grade PRDs."}}
+	if got := cfg.promptVersion(); got != "prd-review@1.1.0" {
+		t.Errorf("library promptVersion = %q", got)
+	}
+	if got := cfg.systemPrompt(); got != "Grade PRDs." {
+		t.Errorf("library systemPrompt = %q", got)
+	}
+}
+
+func TestEvaluateHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "overloaded"}`, http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	ev, err := NewOpenAI(Config{APIKey: "k", BaseURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ev.Evaluate(context.Background(), "rubric", "content")
+	if err == nil || !containsAll(err.Error(), "openai: 503", "overloaded") {
+		t.Errorf("Evaluate error = %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package evaluator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// OpenAI evaluates with the OpenAI Chat Completions API.
+type OpenAI struct {
+	cfg   Config
+	model string
+}
+
+// NewOpenAI returns an OpenAI evaluator. It needs cfg.APIKey or
+// OPENAI_API_KEY.
+func NewOpenAI(cfg Config) (*OpenAI, error) {
+	if cfg.APIKey == "" {
+		cfg.APIKey = os.Getenv("OPENAI_API_KEY")
+	}
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("openai: api key is required (set OPENAI_API_KEY)")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.openai.com/v1"
+	}
+	return &OpenAI{cfg: cfg, model: multiagentspec.MapModelToOpenAI(cfg.model())}, nil
+}
+
+// Model returns the provider model ID recorded in evaluations.
+func (e *OpenAI) Model() string { return e.model }
+
+// PromptVersion returns the prompt version recorded in evaluations.
+func (e *OpenAI) PromptVersion() string { return e.cfg.promptVersion() }
+
+// Evaluate implements multiagentspec.Evaluator.
+func (e *OpenAI) Evaluate(ctx context.Context, rubric, content string) (*multiagentspec.LLMEvaluation, error) {
+	return evaluate(e.cfg, ProviderOpenAI, e.model, func() (*completion, error) {
+		req := map[string]any{
+			"model":                 e.model,
+			"max_completion_tokens": e.cfg.maxTokens(),
+			"response_format":       map[string]string{"type": "json_object"},
+			"messages": []map[string]string{
+				{"role": "system", "content": e.cfg.systemPrompt()},
+				{"role": "user", "content": userPrompt(rubric, content)},
+			},
+		}
+		var resp struct {
+			Choices []struct {
+				Message struct {
+					Content string `json:"content"`
+				} `json:"message"`
+			} `json:"choices"`
+			Usage struct {
+				TotalTokens int `json:"total_tokens"`
+			} `json:"usage"`
+		}
+		header := http.Header{}
+		header.Set("Authorization", "Bearer "+e.cfg.APIKey)
+		url := strings.TrimSuffix(e.cfg.BaseURL, "/") + "/chat/completions"
+		if err := postJSON(ctx, e.cfg.httpClient(), url, header, req, &resp, nil); err != nil {
+			return nil, err
+		}
+		if len(resp.Choices) == 0 {
+			return nil, fmt.Errorf("response has no choices")
+		}
+		return &completion{text: resp.Choices[0].Message.Content, tokens: resp.Usage.TotalTokens}, nil
+	})
+}
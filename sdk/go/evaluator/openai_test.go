@@ -0,0 +1,50 @@
+package evaluator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestOpenAIEvaluate(t *testing.T) {
+	srv, req, body := testServer(t, map[string]any{
+		"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": testAnswer}}},
+		"usage":   map[string]int{"total_tokens": 410},
+	})
+	ev, err := NewOpenAI(Config{APIKey: "sk-test", BaseURL: srv.URL, Model: "gpt-4.1", SystemPrompt: "Grade harshly.", PromptVersion: "strict-v3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	eval, err := ev.Evaluate(context.Background(), "rubric", "content")
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+
+	if req.URL.Path != "/chat/completions" || req.Header.Get("Authorization") != "Bearer sk-test" {
+		t.Errorf("request = %s %v", req.URL.Path, req.Header)
+	}
+	var sent struct {
+		Model    string `json:"model"`
+		Messages []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+		ResponseFormat map[string]string `json:"response_format"`
+	}
+	if err := json.Unmarshal(*body, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent.Model != "gpt-4.1" || sent.ResponseFormat["type"] != "json_object" {
+		t.Errorf("sent = %+v", sent)
+	}
+	if len(sent.Messages) != 2 || sent.Messages[0].Role != "system" || sent.Messages[0].Content != "Grade harshly." {
+		t.Errorf("sent messages = %+v", sent.Messages)
+	}
+
+	if eval.Provider != ProviderOpenAI || eval.Model != "gpt-4.1" || eval.TokensUsed != 410 || eval.PromptVersion != "strict-v3" {
+		t.Errorf("eval = %+v", eval)
+	}
+	if len(eval.Suggestions) != 1 {
+		t.Errorf("Suggestions = %v", eval.Suggestions)
+	}
+}
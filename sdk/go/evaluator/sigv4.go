@@ -0,0 +1,17 @@
+package evaluator
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/plexusone/multi-agent-spec/sdk/go/internal/sigv4"
+)
+
+// signV4 adds AWS Signature Version 4 headers to req, whose body is body.
+func signV4(req *http.Request, body []byte, creds *AWSCredentials, region, service string, t time.Time) {
+	sigv4.Sign(req, body, sigv4.Credentials{
+		AccessKeyID:     creds.AccessKeyID,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+	}, region, service, t)
+}
@@ -0,0 +1,45 @@
+package evaluator
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestSignV4 checks the signer against the GET ListUsers example from the
+// AWS Signature Version 4 documentation.
+func TestSignV4(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	creds := &AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+
+	signV4(req, nil, creds, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization =\n%s\nwant\n%s", got, want)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date = %q", got)
+	}
+}
+
+func TestSignV4SessionToken(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://bedrock-runtime.us-east-1.amazonaws.com/model/m/converse", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := &AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token"}
+	signV4(req, []byte("{}"), creds, "us-east-1", "bedrock", time.Now())
+	if req.Header.Get("X-Amz-Security-Token") != "token" {
+		t.Error("session token header not set")
+	}
+	if got := req.Header.Get("Authorization"); !containsAll(got, "SignedHeaders=host;x-amz-date;x-amz-security-token", "/us-east-1/bedrock/aws4_request") {
+		t.Errorf("Authorization = %q", got)
+	}
+}
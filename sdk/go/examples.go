@@ -0,0 +1,97 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Example is a few-shot example of the input an agent receives and the
+// output expected from it.
+type Example struct {
+	// Input is the example request.
+	Input string `json:"input" yaml:"input" jsonschema:"minLength=1" jsonschema_description:"Example request"`
+
+	// Output is the expected response.
+	Output string `json:"output" yaml:"output" jsonschema:"minLength=1" jsonschema_description:"Expected response"`
+
+	// Commentary explains why the output is right, e.g., which
+	// instruction it follows.
+	Commentary string `json:"commentary,omitempty" yaml:"commentary,omitempty" jsonschema_description:"Why the output is right"`
+}
+
+// Validate checks that the example has an input and an output.
+func (e *Example) Validate() error {
+	if strings.TrimSpace(e.Input) == "" {
+		return fmt.Errorf("input is required")
+	}
+	if strings.TrimSpace(e.Output) == "" {
+		return fmt.Errorf("output is required")
+	}
+	return nil
+}
+
+// ValidateExamples validates each of the agent's examples.
+func (a *Agent) ValidateExamples() error {
+	for i := range a.Examples {
+		if err := a.Examples[i].Validate(); err != nil {
+			return fmt.Errorf("example %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ExampleFormat is how examples are written into a prompt.
+type ExampleFormat string
+
+const (
+	// ExampleFormatMarkdown writes an "## Examples" section with a
+	// subsection per example.
+	ExampleFormatMarkdown ExampleFormat = "markdown"
+
+	// ExampleFormatXML writes <examples> with an <example> element per
+	// example, the form Claude models are tuned for.
+	ExampleFormatXML ExampleFormat = "xml"
+)
+
+// PromptInstructions returns the agent's instructions followed by its
+// examples in format, for generators that emit a system prompt. Without
+// examples it returns the trimmed instructions.
+func (a *Agent) PromptInstructions(format ExampleFormat) string {
+	instructions := strings.TrimSpace(a.Instructions)
+	if len(a.Examples) == 0 {
+		return instructions
+	}
+	var b strings.Builder
+	if instructions != "" {
+		b.WriteString(instructions)
+		b.WriteString("\n\n")
+	}
+	if format == ExampleFormatXML {
+		writeExamplesXML(&b, a.Examples)
+	} else {
+		writeExamplesMarkdown(&b, a.Examples)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+func writeExamplesMarkdown(b *strings.Builder, examples []Example) {
+	b.WriteString("## Examples\n")
+	for i, e := range examples {
+		fmt.Fprintf(b, "\n### Example %d\n\nInput:\n\n%s\n\nOutput:\n\n%s\n", i+1, strings.TrimSpace(e.Input), strings.TrimSpace(e.Output))
+		if c := strings.TrimSpace(e.Commentary); c != "" {
+			fmt.Fprintf(b, "\nCommentary: %s\n", c)
+		}
+	}
+}
+
+func writeExamplesXML(b *strings.Builder, examples []Example) {
+	b.WriteString("<examples>\n")
+	for _, e := range examples {
+		fmt.Fprintf(b, "<example>\n<input>\n%s\n</input>\n<output>\n%s\n</output>\n", strings.TrimSpace(e.Input), strings.TrimSpace(e.Output))
+		if c := strings.TrimSpace(e.Commentary); c != "" {
+			fmt.Fprintf(b, "<commentary>\n%s\n</commentary>\n", c)
+		}
+		b.WriteString("</example>\n")
+	}
+	b.WriteString("</examples>\n")
+}
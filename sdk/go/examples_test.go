@@ -0,0 +1,88 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAgentValidateExamples(t *testing.T) {
+	a := &Agent{Name: "triage", Examples: []Example{{Input: "App crashes on start", Output: "bug"}}}
+	if err := a.ValidateExamples(); err != nil {
+		t.Errorf("ValidateExamples: %v", err)
+	}
+	a.Examples = append(a.Examples, Example{Input: "Add dark mode", Output: "  "})
+	if err := a.ValidateExamples(); err == nil || err.Error() != "example 2: output is required" {
+		t.Errorf("ValidateExamples = %v, want missing output", err)
+	}
+	a.Examples = []Example{{Output: "bug"}}
+	if err := a.ValidateExamples(); err == nil || err.Error() != "example 1: input is required" {
+		t.Errorf("ValidateExamples = %v, want missing input", err)
+	}
+}
+
+func TestAgentPromptInstructions(t *testing.T) {
+	a := &Agent{
+		Name:         "triage",
+		Instructions: "\nLabel each issue as bug or feature.\n",
+		Examples: []Example{
+			{Input: "App crashes on start", Output: "bug", Commentary: "Broken existing behavior is a bug."},
+			{Input: "Add dark mode", Output: "feature"},
+		},
+	}
+
+	md := a.PromptInstructions(ExampleFormatMarkdown)
+	wantMD := `Label each issue as bug or feature.
+
+## Examples
+
+### Example 1
+
+Input:
+
+App crashes on start
+
+Output:
+
+bug
+
+Commentary: Broken existing behavior is a bug.
+
+### Example 2
+
+Input:
+
+Add dark mode
+
+Output:
+
+feature`
+	if md != wantMD {
+		t.Errorf("markdown =\n%s\nwant\n%s", md, wantMD)
+	}
+
+	xml := a.PromptInstructions(ExampleFormatXML)
+	for _, want := range []string{
+		"Label each issue as bug or feature.\n\n<examples>\n<example>\n<input>\nApp crashes on start\n</input>\n<output>\nbug\n</output>\n<commentary>\nBroken existing behavior is a bug.\n</commentary>\n</example>\n",
+		"<example>\n<input>\nAdd dark mode\n</input>\n<output>\nfeature\n</output>\n</example>\n</examples>",
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("xml missing %q:\n%s", want, xml)
+		}
+	}
+
+	a.Examples = nil
+	if got := a.PromptInstructions(ExampleFormatXML); got != "Label each issue as bug or feature." {
+		t.Errorf("without examples = %q", got)
+	}
+}
+
+func TestExamplesSchema(t *testing.T) {
+	agent := []byte(`{"name": "triage", "examples": [{"input": "App crashes", "output": "bug", "commentary": "regression"}]}`)
+	if err := ValidateAgentJSON(agent); err != nil {
+		t.Errorf("ValidateAgentJSON: %v", err)
+	}
+	bad := []byte(`{"name": "triage", "examples": [{"input": "App crashes"}]}`)
+	if err := ValidateAgentJSON(bad); err == nil {
+		t.Error("ValidateAgentJSON accepted example without output")
+	}
+}
@@ -0,0 +1,929 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StepRun is a workflow step for an AgentRunner to run.
+type StepRun struct {
+	// Step is the workflow step.
+	Step Step
+
+	// Agent is the agent the step's entry resolves to.
+	Agent *Agent
+
+	// Inputs are the step's input values: the upstream outputs its inputs
+	// come from, or their defaults.
+	Inputs map[string]interface{}
+
+	// Traceparent is the W3C trace context of the step's span, with
+	// WithTraceContext or a tracer; runners pass it to agent processes in
+	// TraceparentEnv.
+	Traceparent string
+}
+
+// AgentRunner runs a workflow step's agent. TaskRunner runs agents'
+// command, pattern, and file tasks; agents driven by an LLM need a runner
+// that calls one.
+type AgentRunner interface {
+	RunStep(ctx context.Context, run StepRun) (*AgentResult, error)
+}
+
+// AgentRunnerFunc adapts a function to AgentRunner.
+type AgentRunnerFunc func(ctx context.Context, run StepRun) (*AgentResult, error)
+
+// RunStep calls f.
+func (f AgentRunnerFunc) RunStep(ctx context.Context, run StepRun) (*AgentResult, error) {
+	return f(ctx, run)
+}
+
+// RunStep runs every task of the step's agent, as RunAgent does, with the
+// step's Traceparent in the commands' environment.
+func (r *TaskRunner) RunStep(ctx context.Context, run StepRun) (*AgentResult, error) {
+	if run.Traceparent != "" {
+		env := r.Env
+		if env == nil {
+			env = os.Environ()
+		}
+		traced := *r
+		traced.Env = append(append([]string(nil), env...), TraceparentEnv+"="+run.Traceparent)
+		r = &traced
+	}
+	result, err := r.RunAgent(ctx, run.Agent)
+	if err != nil {
+		return nil, err
+	}
+	result.StepID = run.Step.Name
+	result.Inputs = run.Inputs
+	return result, nil
+}
+
+// StepState is the progress of a workflow step in a StepEvent.
+type StepState string
+
+const (
+	// StepRunning is a step whose agent has started.
+	StepRunning StepState = "running"
+
+	// StepDone is a step whose agent has finished.
+	StepDone StepState = "done"
+
+	// StepSkipped is a step that did not run: its condition was false, an
+	// upstream step is NO-GO or skipped, the run was canceled, or its
+	// runner skipped it.
+	StepSkipped StepState = "skipped"
+
+	// StepWaiting is a step that did not run because an approval gate is
+	// pending; running the workflow again resumes it once decided.
+	StepWaiting StepState = "waiting"
+
+	// StepRetrying is a step whose agent failed or timed out and is about
+	// to run again under its runtime retry policy.
+	StepRetrying StepState = "retrying"
+)
+
+// StepEvent reports a workflow step's progress to a step observer.
+type StepEvent struct {
+	// Step is the step name.
+	Step string
+
+	// Agent is the qualified name of the step's agent.
+	Agent string
+
+	// State is the step's progress.
+	State StepState
+
+	// Result is the step's result, or nil while it is running. For a
+	// retrying step, it is the failed attempt's result.
+	Result *AgentResult
+
+	// Attempt is the number of the failed attempt, from 1, for a retrying
+	// step.
+	Attempt int
+}
+
+// StepAuditor records workflow step events in an audit log.
+// audit.Log.StepAuditor adapts the audit package's log.
+type StepAuditor interface {
+	AuditStep(ev StepEvent) error
+}
+
+// ExecutorOption configures an Executor.
+type ExecutorOption func(*Executor)
+
+// WithTaskRunner runs agents with command, pattern, or file tasks on r
+// instead of a TaskRunner in the current directory.
+func WithTaskRunner(r AgentRunner) ExecutorOption {
+	return func(e *Executor) {
+		e.tasks = r
+	}
+}
+
+// WithLLMRunner runs agents without command, pattern, or file tasks on r.
+// Without it, their steps are skipped.
+func WithLLMRunner(r AgentRunner) ExecutorOption {
+	return func(e *Executor) {
+		e.llm = r
+	}
+}
+
+// WithStepObserver calls fn as each step starts, retries, and finishes.
+// Calls are not concurrent.
+func WithStepObserver(fn func(StepEvent)) ExecutorOption {
+	return func(e *Executor) {
+		e.observe = fn
+	}
+}
+
+// WithMaxParallel runs at most n steps at once; n <= 0 means no limit.
+func WithMaxParallel(n int) ExecutorOption {
+	return func(e *Executor) {
+		e.parallel = n
+	}
+}
+
+// WithApprovals enforces the HumanInLoop tasks of each step's agent as
+// approval gates of run, kept in store: the executor requests a pending
+// approval under policy when it first reaches a gate and runs the step
+// only once every gate is approved. A rejected or expired gate makes the
+// step NO-GO. An approved manual task reports GO in the step's result.
+// Without it, manual tasks are skipped.
+func WithApprovals(store ApprovalStore, run string, policy ApprovalPolicy) ExecutorOption {
+	return func(e *Executor) {
+		e.approvals = store
+		e.run = run
+		e.policy = policy
+	}
+}
+
+// WithPriorResults reuses results from an earlier attempt at the run,
+// matched by StepID: a step with a prior result that was not skipped is
+// not run again, so a run resumed after an approval picks up where it
+// paused.
+func WithPriorResults(results []AgentResult) ExecutorOption {
+	return func(e *Executor) {
+		e.prior = make(map[string]AgentResult, len(results))
+		for _, r := range results {
+			if r.Status != StatusSkip {
+				e.prior[r.StepID] = r
+			}
+		}
+	}
+}
+
+// WithRateLimiters makes each step wait for its limiter in limiters, as
+// RateLimiters.ForStep picks it, before running its agent: the step's
+// runtime rate limit, with WithRuntime, or its agent's RateLimit. Limiters
+// shared between executors share their limits.
+func WithRateLimiters(limiters *RateLimiters) ExecutorOption {
+	return func(e *Executor) {
+		e.limiters = limiters
+	}
+}
+
+// WithBudget records the usage of each step's agent in budgets once the
+// step finishes and acts on the decision: a step over budget gets a budget
+// task; after an abort, the agent's remaining steps are skipped, and after
+// a downgrade, they run with the cheaper model.
+func WithBudget(budgets *BudgetTracker) ExecutorOption {
+	return func(e *Executor) {
+		e.budget = budgets
+	}
+}
+
+// WithRuntime applies each step's runtime settings from rt, as
+// RuntimeConfig.ForStep layers them: each attempt at the step is bounded
+// by its Timeout, a failed or timed-out attempt is retried under its
+// Retry policy, and, with WithRateLimiters, its rate limit applies.
+func WithRuntime(rt *RuntimeConfig) ExecutorOption {
+	return func(e *Executor) {
+		e.runtime = rt
+	}
+}
+
+// WithGuardrails enforces each step's agent's Guardrails, or defaults
+// for agents without their own, on the step's string inputs and outputs:
+// PII is redacted, and a denied input makes the step NO-GO without
+// running, as does a denied or overlong output, which is then dropped. A
+// nil defaults enforces only the agents' own guardrails.
+func WithGuardrails(defaults *Guardrails) ExecutorOption {
+	return func(e *Executor) {
+		e.guarded = true
+		e.defaultGuardrails = defaults
+	}
+}
+
+// WithAuditLog records every step event in log, as WithStepObserver
+// reports them. A failure to record one is logged and does not stop the
+// run.
+func WithAuditLog(log StepAuditor) ExecutorOption {
+	return func(e *Executor) {
+		e.audit = log
+	}
+}
+
+// WithStepLogger logs step events to l, such as one from NewLogger, at
+// debug level, and retries at warn level. Without it, nothing is logged.
+func WithStepLogger(l *slog.Logger) ExecutorOption {
+	return func(e *Executor) {
+		e.logger = l
+	}
+}
+
+// WithTraceContext runs each step in a child span of the W3C trace
+// context traceparent, or of TraceparentEnv when traceparent is empty,
+// or of a new trace when both are: the span is passed to runners in
+// StepRun.Traceparent and set on results without trace IDs of their own.
+func WithTraceContext(traceparent string) ExecutorOption {
+	return func(e *Executor) {
+		e.traced = true
+		e.traceparent = traceparent
+	}
+}
+
+// WithTracer records a span for the run, a child span for each step that
+// runs, and a span for each attempt at the step under it, and exports them
+// with t once the run finishes, as the trace context options place them:
+// in the trace of WithTraceContext, or a new one. Without it, a tracer is
+// built from the tracing settings of WithRuntime, if enabled, with console
+// spans written to stderr. A failure to export is logged and does not
+// fail the run.
+func WithTracer(t *Tracer) ExecutorOption {
+	return func(e *Executor) {
+		e.tracer = t
+	}
+}
+
+// WithMetrics records the run's step events in m: finished runs and
+// steps by status, step durations and retries, token usage, and the steps
+// queued and running. Collectors shared between executors share their
+// series, labeled by team.
+func WithMetrics(m *ExecutorMetrics) ExecutorOption {
+	return func(e *Executor) {
+		e.metrics = m
+	}
+}
+
+// Executor is a reference implementation of a team's workflow: it runs
+// each step's agent once its dependencies have finished, concurrently
+// where the DAG allows, and reports the results as a TeamReport.
+//
+// A chain workflow's steps without depends_on follow the previous step,
+// and a team without workflow steps runs its agents in order, one step
+// each. Self-directed workflows run as their steps' DAG; the executor
+// does not plan or delegate. A step is skipped if its when condition is
+// false or an upstream step is NO-GO or skipped, and fails without
+// running if a required input has no value. With WithApprovals, a step
+// also waits for its approval gates, and with WithRateLimiters, for its
+// rate limit. The other options enforce budgets, guardrails, and runtime
+// timeouts and retries, and record step events in an audit log, logger,
+// metrics, and trace.
+type Executor struct {
+	team     *Team
+	steps    []Step
+	deps     map[string][]string // step name to the steps it waits for
+	agents   map[string]*Agent   // step name to its agent
+	conds    map[string]*condition
+	tasks    AgentRunner
+	llm      AgentRunner
+	observe  func(StepEvent)
+	parallel int
+
+	approvals ApprovalStore
+	run       string
+	policy    ApprovalPolicy
+	prior     map[string]AgentResult
+
+	limiters *RateLimiters
+	runtime  *RuntimeConfig
+
+	budget  *BudgetTracker
+	mu      sync.Mutex
+	models  map[string]Model  // agent to the tier it was downgraded to
+	aborted map[string]string // agent to the limits it exceeded, once aborted
+
+	guarded           bool
+	defaultGuardrails *Guardrails
+	enforcers         map[string]GuardrailEnforcer // step name to its agent's
+	stepPolicies      map[string]stepPolicy        // step name to its timeout and retries
+
+	audit   StepAuditor
+	logger  *slog.Logger
+	metrics *ExecutorMetrics
+	emitMu  sync.Mutex
+
+	traced      bool
+	traceparent string
+	trace       traceContext
+	tracer      *Tracer
+}
+
+// NewExecutor returns an executor for team whose agent entries and steps
+// resolve against agents. It fails if an entry does not resolve, a step
+// is unnamed, duplicated, or depends on or refers to an unknown step, a
+// when condition, guardrail pattern, or runtime timeout, retry policy, or
+// tracing setting is invalid, the steps form a cycle, or the trace context
+// is malformed.
+func NewExecutor(team *Team, agents []*Agent, opts ...ExecutorOption) (*Executor, error) {
+	e := &Executor{
+		team:         team,
+		deps:         make(map[string][]string),
+		agents:       make(map[string]*Agent),
+		conds:        make(map[string]*condition),
+		tasks:        &TaskRunner{},
+		models:       make(map[string]Model),
+		aborted:      make(map[string]string),
+		logger:       discardLogger,
+		enforcers:    make(map[string]GuardrailEnforcer),
+		stepPolicies: make(map[string]stepPolicy),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	registry, err := NewAgentRegistry(agents)
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", team.Name, err)
+	}
+	members, err := team.ResolveAgents(agents)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*Agent)
+	for _, a := range members {
+		for _, n := range []string{a.QualifiedName(), a.Name} {
+			if _, ok := byName[n]; !ok {
+				byName[n] = a
+			}
+		}
+	}
+
+	if team.Workflow != nil && len(team.Workflow.Steps) > 0 {
+		e.steps = team.Workflow.Steps
+	} else {
+		for i, a := range members {
+			e.steps = append(e.steps, Step{Name: a.Name, Agent: team.Agents[i]})
+		}
+	}
+	chain := team.Workflow == nil || len(team.Workflow.Steps) == 0 || team.Workflow.Type == WorkflowChain
+	known := make(map[string]bool, len(e.steps))
+	for _, s := range e.steps {
+		if s.Name == "" {
+			return nil, fmt.Errorf("team %s: workflow step without a name", team.Name)
+		}
+		if known[s.Name] {
+			return nil, fmt.Errorf("team %s: duplicate workflow step %q", team.Name, s.Name)
+		}
+		known[s.Name] = true
+	}
+	for i, s := range e.steps {
+		a, ok := byName[s.Agent]
+		if !ok {
+			if a, err = registry.Resolve(s.Agent); err != nil {
+				return nil, fmt.Errorf("team %s: step %s: %w", team.Name, s.Name, err)
+			}
+		}
+		e.agents[s.Name] = a
+		if e.guarded {
+			g := a.Guardrails
+			if g == nil {
+				g = e.defaultGuardrails
+			}
+			if e.enforcers[s.Name], err = g.Enforcer(); err != nil {
+				return nil, fmt.Errorf("team %s: step %s: guardrails: %w", team.Name, s.Name, err)
+			}
+		}
+		if e.stepPolicies[s.Name], err = newStepPolicy(e.runtime.ForStep(s.Name)); err != nil {
+			return nil, fmt.Errorf("team %s: step %s: %w", team.Name, s.Name, err)
+		}
+
+		deps := stepDependencies(e.steps, i, chain)
+		var refs []string
+		for _, in := range s.Inputs {
+			if in.From != "" {
+				from, _, _ := strings.Cut(in.From, ".")
+				refs = append(refs, from)
+			}
+		}
+		if s.When != "" {
+			c, err := parseCondition(s.When)
+			if err != nil {
+				return nil, fmt.Errorf("team %s: step %s: %w", team.Name, s.Name, err)
+			}
+			e.conds[s.Name] = c
+			refs = append(refs, c.refs...)
+		}
+		for _, ref := range append(append([]string(nil), deps...), refs...) {
+			if !known[ref] {
+				return nil, fmt.Errorf("team %s: step %s: unknown step %q", team.Name, s.Name, ref)
+			}
+		}
+		e.deps[s.Name] = deps
+	}
+	if cycle := e.findCycle(); cycle != nil {
+		return nil, fmt.Errorf("team %s: workflow steps form a cycle: %s", team.Name, strings.Join(cycle, " → "))
+	}
+	if e.tracer == nil && e.runtime != nil && e.runtime.Observability != nil {
+		if e.tracer, err = e.runtime.Observability.Tracing.Tracer(os.Stderr); err != nil {
+			return nil, fmt.Errorf("team %s: tracing: %w", team.Name, err)
+		}
+	}
+	if e.traced || e.tracer != nil {
+		e.traced = true
+		if e.trace, err = newTraceContext(e.traceparent, e.tracer.sample()); err != nil {
+			return nil, fmt.Errorf("team %s: %w", team.Name, err)
+		}
+	}
+	return e, nil
+}
+
+// findCycle returns the steps of a dependency cycle, the first repeated
+// at the end, or nil.
+func (e *Executor) findCycle() []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var path []string
+	var visit func(string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			for i, n := range path {
+				if n == name {
+					return append(append([]string(nil), path[i:]...), name)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range e.deps[name] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, s := range e.steps {
+		if cycle := visit(s.Name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+type stepOutcome struct {
+	step   Step
+	result *AgentResult
+}
+
+// Run runs the workflow and returns its report, with a team per step in
+// workflow order. If ctx is canceled, steps that have not started are
+// skipped and Run returns the report with ctx's error. If steps are
+// waiting for approval, and so are the steps after them, Run returns the
+// report with an *ApprovalPendingError.
+func (e *Executor) Run(ctx context.Context) (*TeamReport, error) {
+	results := make(map[string]*AgentResult, len(e.steps))
+	ran := make(map[string]bool)
+	waiting := make(map[string]bool)
+	var pending []string
+	outputs := make(map[string]map[string]interface{})
+	started := make(map[string]bool)
+	done := make(chan stepOutcome)
+	running := 0
+
+	e.metrics.queue(e.team.Name, len(e.steps))
+
+	// With a tracer, steps' spans are children of the run's.
+	trace := e.trace
+	var runSpan *span
+	if e.tracer != nil {
+		runSpan = e.tracer.start(e.trace, "run "+e.team.Name, "mas.team", e.team.Name)
+		trace = runSpan.context()
+	}
+
+	// A step that was skipped, by the executor or its runner, did not run.
+	record := func(s Step, result *AgentResult, wasRun bool) {
+		results[s.Name] = result
+		state := StepSkipped
+		switch {
+		case wasRun:
+			ran[s.Name] = true
+			outputs[s.Name] = result.Outputs
+			state = StepDone
+		case waiting[s.Name]:
+			state = StepWaiting
+		}
+		e.emit(StepEvent{Step: s.Name, Agent: result.AgentID, State: state, Result: result})
+	}
+
+	for len(results) < len(e.steps) {
+		for progress := true; progress; {
+			progress = false
+			for _, s := range e.steps {
+				if started[s.Name] || !e.ready(s, results) || e.parallel > 0 && running >= e.parallel {
+					continue
+				}
+				started[s.Name] = true
+				progress = true
+				if prior, ok := e.prior[s.Name]; ok {
+					record(s, &prior, true)
+					continue
+				}
+				inputs, result := e.prepare(ctx, s, results, ran, waiting, outputs)
+				if result != nil {
+					record(s, result, result.Status != StatusSkip)
+					continue
+				}
+				approved, result, gates := e.checkGates(ctx, s)
+				if len(gates) > 0 {
+					waiting[s.Name] = true
+					pending = append(pending, gates...)
+				}
+				if result != nil {
+					record(s, result, result.Status != StatusSkip)
+					continue
+				}
+				running++
+				e.emit(StepEvent{Step: s.Name, Agent: e.agents[s.Name].QualifiedName(), State: StepRunning})
+				upstream := upstreamOutputs(s, outputs)
+				go func(s Step) {
+					done <- stepOutcome{step: s, result: e.runStep(ctx, trace, s, inputs, upstream, approved)}
+				}(s)
+			}
+		}
+		if running == 0 {
+			break
+		}
+		outcome := <-done
+		running--
+		record(outcome.step, outcome.result, outcome.result.Status != StatusSkip)
+	}
+
+	agg := NewStreamAggregator(e.team.Name, e.team.Version, "")
+	for _, s := range e.steps {
+		agg.Add(*results[s.Name])
+	}
+	report := agg.Report()
+	for i := range report.Teams {
+		report.Teams[i].DependsOn = e.deps[report.Teams[i].ID]
+	}
+	if runSpan != nil {
+		e.endRun(ctx, runSpan, report)
+	}
+	e.metrics.finish(e.team.Name, len(e.steps)-len(results), report.Status)
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	if len(pending) > 0 {
+		return report, &ApprovalPendingError{Run: e.run, Gates: pending}
+	}
+	return report, nil
+}
+
+// endRun ends the run's span with the report's status and exports the
+// run's spans.
+func (e *Executor) endRun(ctx context.Context, sp *span, report *TeamReport) {
+	sp.set("mas.status", string(report.Status))
+	errMsg := ""
+	if report.Status == StatusNoGo {
+		errMsg = "run is NO-GO"
+	}
+	sp.end(errMsg)
+	if err := e.tracer.flush(context.WithoutCancel(ctx)); err != nil {
+		e.logger.Error("exporting trace spans", "team", e.team.Name, "error", err)
+	}
+}
+
+// emit reports ev to the observer, audit log, logger, and metrics, one
+// event at a time.
+func (e *Executor) emit(ev StepEvent) {
+	e.emitMu.Lock()
+	defer e.emitMu.Unlock()
+	e.logStep(ev)
+	e.metrics.observe(e.team.Name, ev)
+	if e.audit != nil {
+		if err := e.audit.AuditStep(ev); err != nil {
+			e.logger.Error("recording step event in audit log", "step", ev.Step, "state", ev.State, "error", err)
+		}
+	}
+	if e.observe != nil {
+		e.observe(ev)
+	}
+}
+
+// ready reports whether every step s depends on has a result.
+func (e *Executor) ready(s Step, results map[string]*AgentResult) bool {
+	for _, dep := range e.deps[s.Name] {
+		if results[dep] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// prepare returns the inputs of a ready step, or the result it has without
+// running: skipped or failed.
+func (e *Executor) prepare(ctx context.Context, s Step, results map[string]*AgentResult, ran, waiting map[string]bool, outputs map[string]map[string]interface{}) (map[string]interface{}, *AgentResult) {
+	for _, dep := range e.deps[s.Name] {
+		switch {
+		case results[dep].Status == StatusNoGo:
+			return nil, e.stepResult(s, SkipTask(s.Name, fmt.Sprintf("upstream step %s is NO-GO", dep)))
+		case waiting[dep]:
+			return nil, e.stepResult(s, SkipTask(s.Name, fmt.Sprintf("upstream step %s is waiting for approval", dep)))
+		case !ran[dep]:
+			return nil, e.stepResult(s, SkipTask(s.Name, fmt.Sprintf("upstream step %s was skipped", dep)))
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, e.stepResult(s, SkipTask(s.Name, err.Error()))
+	}
+	if c := e.conds[s.Name]; c != nil {
+		ok, err := c.eval(outputs)
+		if err != nil {
+			return nil, e.failResult(s, err)
+		}
+		if !ok {
+			return nil, e.stepResult(s, SkipTask(s.Name, fmt.Sprintf("when %s is false", s.When)))
+		}
+	}
+	var inputs map[string]interface{}
+	for _, in := range s.Inputs {
+		value := in.Default
+		if in.From != "" {
+			from, output, _ := strings.Cut(in.From, ".")
+			if v, ok := outputs[from][output]; ok {
+				value = v
+			}
+		}
+		if value == nil {
+			if in.Required != nil && *in.Required {
+				return nil, e.failResult(s, fmt.Errorf("input %s has no value", in.Name))
+			}
+			continue
+		}
+		if inputs == nil {
+			inputs = make(map[string]interface{})
+		}
+		inputs[in.Name] = value
+	}
+	return inputs, nil
+}
+
+// checkGates checks the approval gates of s's agent, requesting approvals
+// not yet requested. It returns the results of the approved manual tasks,
+// or the step's result if it must not run: failed if a gate is rejected or
+// expired, or skipped with the pending gates while any is pending.
+func (e *Executor) checkGates(ctx context.Context, s Step) ([]TaskResult, *AgentResult, []string) {
+	if e.approvals == nil {
+		return nil, nil, nil
+	}
+	var approved []TaskResult
+	var pending []string
+	for _, task := range e.agents[s.Name].Tasks {
+		if task.HumanInLoop == "" {
+			continue
+		}
+		gate := ApprovalGate(s.Name, task.ID)
+		a, err := e.approval(ctx, gate, task.HumanInLoop)
+		if err != nil {
+			return nil, e.failResult(s, err), nil
+		}
+		result := a.TaskResult(task.ID, time.Now())
+		switch result.Status {
+		case StatusSkip:
+			pending = append(pending, gate)
+		case StatusNoGo:
+			result.Detail = fmt.Sprintf("gate %s %s", gate, result.Detail)
+			return nil, e.stepResult(s, result), nil
+		default:
+			if taskType(task) == TaskTypeManual {
+				approved = append(approved, result)
+			}
+		}
+	}
+	if len(pending) > 0 {
+		return nil, e.stepResult(s, SkipTask(s.Name, "waiting for approval of "+strings.Join(pending, ", "))), pending
+	}
+	return approved, nil, nil
+}
+
+// approval returns the approval for gate in the run, requesting it if it
+// is new.
+func (e *Executor) approval(ctx context.Context, gate, request string) (*Approval, error) {
+	a, err := e.approvals.GetApproval(ctx, e.run, gate)
+	if err == nil || !errors.Is(err, ErrApprovalNotFound) {
+		return a, err
+	}
+	now := time.Now().UTC()
+	a = &Approval{
+		Run:         e.run,
+		Gate:        gate,
+		Request:     request,
+		Approvers:   e.policy.Approvers,
+		Decision:    ApprovalPending,
+		RequestedAt: now,
+	}
+	if e.policy.TTL > 0 {
+		expires := now.Add(e.policy.TTL)
+		a.ExpiresAt = &expires
+	}
+	if err := e.approvals.PutApproval(ctx, a); err != nil {
+		return nil, fmt.Errorf("requesting approval of %s: %w", gate, err)
+	}
+	return a, nil
+}
+
+// upstreamOutputs returns the outputs of the steps s reads inputs from,
+// for checking its contract while other steps finish.
+func upstreamOutputs(s Step, outputs map[string]map[string]interface{}) map[string]map[string]interface{} {
+	upstream := make(map[string]map[string]interface{})
+	for _, in := range s.Inputs {
+		if in.From == "" {
+			continue
+		}
+		from, _, _ := strings.Cut(in.From, ".")
+		if out, ok := outputs[from]; ok {
+			upstream[from] = out
+		}
+	}
+	return upstream
+}
+
+// runStep runs a step's agent on the runner for its kind of agent, with
+// the results of its approved manual tasks in place of theirs, and checks
+// the result against the step's ports, comparing its inputs with the
+// upstream outputs. With tracing, the step runs in a child span of trace.
+func (e *Executor) runStep(ctx context.Context, trace traceContext, s Step, inputs map[string]interface{}, upstream map[string]map[string]interface{}, approved []TaskResult) (result *AgentResult) {
+	sp := e.tracer.start(trace, "step "+s.Name, "mas.step", s.Name, "mas.agent", e.agents[s.Name].QualifiedName())
+	defer func() {
+		sp.set("mas.status", string(result.Status))
+		errMsg := ""
+		if result.Status == StatusNoGo {
+			if errMsg = result.Error; errMsg == "" {
+				errMsg = "step is NO-GO"
+			}
+		}
+		sp.end(errMsg)
+	}()
+	a, exceeded := e.budgetedAgent(e.agents[s.Name])
+	if exceeded != "" {
+		return e.stepResult(s, SkipTask(s.Name, fmt.Sprintf("agent %s was aborted over budget: %s", a.QualifiedName(), exceeded)))
+	}
+	runner := e.tasks
+	if !hasRunnableTasks(a) {
+		if e.llm == nil {
+			return e.stepResult(s, SkipTask(s.Name, "agent has no command, pattern, or file tasks and no LLM runner is configured"))
+		}
+		runner = e.llm
+	}
+	inputs, err := e.guardInputs(s, inputs)
+	if err != nil {
+		return e.stepResult(s, guardrailTask(err))
+	}
+	if e.limiters != nil {
+		release, err := e.limiters.ForStep(s.Name, e.runtime.ForStep(s.Name), a).Acquire(ctx)
+		if err != nil {
+			return e.stepResult(s, SkipTask(s.Name, "waiting for rate limit: "+err.Error()))
+		}
+		defer release()
+	}
+	run := StepRun{Step: s, Agent: a, Inputs: inputs}
+	if e.traced {
+		run.Traceparent = sp.traceparent()
+	}
+	start := time.Now()
+	result, err = e.attempt(ctx, runner, run, sp.context())
+	if err != nil {
+		result = e.failResult(s, err)
+	}
+	if run.Traceparent != "" && result.TraceID == "" {
+		_ = result.SetTraceContext(run.Traceparent)
+	}
+	if err != nil {
+		return result
+	}
+	result.StepID = s.Name
+	if result.AgentID == "" {
+		result.AgentID = a.QualifiedName()
+	}
+	if len(approved) > 0 {
+		result.Tasks = mergeTaskResults(result.Tasks, approved)
+		result.Status = result.ComputeStatus()
+	}
+	if w := e.team.Workflow; w != nil {
+		if violations := w.CheckContract(*result, upstream); len(violations) > 0 {
+			task := contractTask(violations)
+			result.Tasks = append(result.Tasks, task)
+			result.Status = worseStatus(result.Status, task.Status)
+		}
+	}
+	e.guardOutputs(s, result)
+	e.recordUsage(a, result)
+	result.Stamp()
+	if result.Duration == "" {
+		result.Duration = time.Since(start).Round(time.Millisecond).String()
+	}
+	return result
+}
+
+// budgetedAgent returns a as its step is to run it: with the model it was
+// downgraded to, if any, or with the limits it exceeded if it was aborted.
+func (e *Executor) budgetedAgent(a *Agent) (*Agent, string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if exceeded := e.aborted[a.QualifiedName()]; exceeded != "" {
+		return a, exceeded
+	}
+	if m, ok := e.models[a.QualifiedName()]; ok && m != a.Model {
+		downgraded := *a
+		downgraded.Model = m
+		return &downgraded, ""
+	}
+	return a, ""
+}
+
+// recordUsage records the usage of a's step in the budget tracker, adding
+// a budget task to result if a is over budget.
+func (e *Executor) recordUsage(a *Agent, result *AgentResult) {
+	if e.budget == nil {
+		return
+	}
+	d := e.budget.Record(a.QualifiedName(), result.TotalUsage())
+	task, ok := d.TaskResult()
+	if !ok {
+		return
+	}
+	result.Tasks = append(result.Tasks, task)
+	result.Status = worseStatus(result.Status, task.Status)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch d.Action {
+	case BudgetActionAbort:
+		e.aborted[d.Agent] = d.Exceeded
+	case BudgetActionDowngrade:
+		e.models[d.Agent] = d.Model
+	}
+}
+
+// stepResult returns a result for a step that did not run its agent.
+func (e *Executor) stepResult(s Step, task TaskResult) *AgentResult {
+	a := e.agents[s.Name]
+	result := &AgentResult{
+		Schema:     SchemaURL(SchemaAgentResult),
+		AgentID:    a.QualifiedName(),
+		StepID:     s.Name,
+		Tasks:      []TaskResult{task},
+		ExecutedAt: time.Now().UTC(),
+		AgentModel: string(a.Model),
+	}
+	result.Status = result.ComputeStatus()
+	return result
+}
+
+func (e *Executor) failResult(s Step, err error) *AgentResult {
+	result := e.stepResult(s, FailTask(s.Name, err, ""))
+	result.Error = err.Error()
+	return result
+}
+
+// mergeTaskResults replaces the tasks with the IDs of results, appending
+// those not in tasks.
+func mergeTaskResults(tasks, results []TaskResult) []TaskResult {
+	merged := append([]TaskResult(nil), tasks...)
+	for _, r := range results {
+		found := false
+		for i := range merged {
+			if merged[i].ID == r.ID {
+				merged[i] = r
+				found = true
+			}
+		}
+		if !found {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
+
+// hasRunnableTasks reports whether a has a command, pattern, or file task.
+func hasRunnableTasks(a *Agent) bool {
+	for _, t := range a.Tasks {
+		switch taskType(t) {
+		case TaskTypeCommand, TaskTypePattern, TaskTypeFile:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+package multiagentspec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsExporterPrometheus serves metrics in the Prometheus text
+// exposition format; it is the only exporter MetricsConfig accepts.
+const MetricsExporterPrometheus = "prometheus"
+
+// DefaultMetricsEndpoint is where metrics are served when
+// MetricsConfig.Endpoint is empty.
+const DefaultMetricsEndpoint = ":9464/metrics"
+
+// stepDurationBuckets are the upper bounds, in seconds, of the step
+// duration histogram's buckets.
+var stepDurationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800}
+
+// ExecutorMetrics collects metrics from the step events of workflow runs,
+// as WithMetrics reports them, and serves them in the Prometheus text
+// exposition format. One collector may be shared by executors for several
+// teams and runs.
+//
+//	mas_runs_total{team,status}
+//	mas_steps_total{team,step,status}
+//	mas_step_duration_seconds{team,step} (histogram)
+//	mas_step_retries_total{team,step}
+//	mas_step_tokens_total{team,agent,direction}
+//	mas_step_cost_usd_total{team,agent}
+//	mas_steps_queued{team}
+//	mas_steps_running{team}
+type ExecutorMetrics struct {
+	mu sync.Mutex
+
+	// Series by their Prometheus label sets.
+	runs      map[string]float64
+	steps     map[string]float64
+	durations map[string]*histogram
+	retries   map[string]float64
+	tokens    map[string]float64
+	costs     map[string]float64
+	queued    map[string]float64
+	running   map[string]float64
+
+	started map[[2]string]int // team and step to its running count
+}
+
+// NewExecutorMetrics returns an empty collector.
+func NewExecutorMetrics() *ExecutorMetrics {
+	return &ExecutorMetrics{
+		runs:      make(map[string]float64),
+		steps:     make(map[string]float64),
+		durations: make(map[string]*histogram),
+		retries:   make(map[string]float64),
+		tokens:    make(map[string]float64),
+		costs:     make(map[string]float64),
+		queued:    make(map[string]float64),
+		running:   make(map[string]float64),
+		started:   make(map[[2]string]int),
+	}
+}
+
+// histogram counts observations in stepDurationBuckets.
+type histogram struct {
+	counts []float64 // per bucket, not cumulative
+	sum    float64
+	count  float64
+}
+
+func (h *histogram) observe(v float64) {
+	if h.counts == nil {
+		h.counts = make([]float64, len(stepDurationBuckets))
+	}
+	for i, le := range stepDurationBuckets {
+		if v <= le {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += v
+	h.count++
+}
+
+// queue counts steps of a run of team that have yet to start.
+func (m *ExecutorMetrics) queue(team string, steps int) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queued[labels(nil, "team", team)] += float64(steps)
+}
+
+// observe records a step event of a run of team. A step leaves the queue
+// when it starts, or when it finishes without having run, and counts as
+// running until it finishes.
+func (m *ExecutorMetrics) observe(team string, ev StepEvent) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	teamLabels := labels(nil, "team", team)
+	stepLabels := labels(nil, "team", team, "step", ev.Step)
+	step := [2]string{team, ev.Step}
+	switch ev.State {
+	case StepRunning:
+		m.queued[teamLabels]--
+		m.running[teamLabels]++
+		m.started[step]++
+		return
+	case StepRetrying:
+		m.retries[stepLabels]++
+		return
+	}
+	if m.started[step] > 0 {
+		if m.started[step]--; m.started[step] == 0 {
+			delete(m.started, step)
+		}
+		m.running[teamLabels]--
+		if d, err := time.ParseDuration(ev.Result.Duration); err == nil {
+			h := m.durations[stepLabels]
+			if h == nil {
+				h = &histogram{}
+				m.durations[stepLabels] = h
+			}
+			h.observe(d.Seconds())
+		}
+	} else {
+		m.queued[teamLabels]--
+	}
+	m.steps[labels(nil, "team", team, "step", ev.Step, "status", string(ev.Result.Status))]++
+	if u := ev.Result.TotalUsage(); !u.IsZero() {
+		m.tokens[labels(nil, "team", team, "agent", ev.Agent, "direction", "in")] += float64(u.TokensIn)
+		m.tokens[labels(nil, "team", team, "agent", ev.Agent, "direction", "out")] += float64(u.TokensOut)
+		m.costs[labels(nil, "team", team, "agent", ev.Agent)] += u.CostUSD
+	}
+}
+
+// finish records a finished run of team with status, dropping its steps
+// that never left the queue.
+func (m *ExecutorMetrics) finish(team string, unfinished int, status Status) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queued[labels(nil, "team", team)] -= float64(unfinished)
+	m.runs[labels(nil, "team", team, "status", string(status))]++
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition
+// format, series sorted by their labels.
+func (m *ExecutorMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sb strings.Builder
+	writeSeries(&sb, "mas_runs_total", "counter", "Workflow runs finished, by overall status.", m.runs)
+	writeSeries(&sb, "mas_steps_total", "counter", "Workflow steps finished, by status.", m.steps)
+
+	writeMetricHeader(&sb, "mas_step_duration_seconds", "histogram", "Run time of workflow steps in seconds.")
+	for _, set := range sortedLabelSets(m.durations) {
+		h := m.durations[set]
+		cumulative := 0.0
+		for i, le := range stepDurationBuckets {
+			cumulative += h.counts[i]
+			writeSample(&sb, "mas_step_duration_seconds_bucket", set+`,le="`+strconv.FormatFloat(le, 'g', -1, 64)+`"`, cumulative)
+		}
+		writeSample(&sb, "mas_step_duration_seconds_bucket", set+`,le="+Inf"`, h.count)
+		writeSample(&sb, "mas_step_duration_seconds_sum", set, h.sum)
+		writeSample(&sb, "mas_step_duration_seconds_count", set, h.count)
+	}
+
+	writeSeries(&sb, "mas_step_retries_total", "counter", "Failed step attempts that were retried.", m.retries)
+	writeSeries(&sb, "mas_step_tokens_total", "counter", "LLM tokens used by finished steps, by agent and direction (in or out).", m.tokens)
+	writeSeries(&sb, "mas_step_cost_usd_total", "counter", "LLM cost of finished steps in US dollars, by agent.", m.costs)
+	writeSeries(&sb, "mas_steps_queued", "gauge", "Steps of running workflows that have yet to start.", m.queued)
+	writeSeries(&sb, "mas_steps_running", "gauge", "Steps of running workflows whose agents are running.", m.running)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// ServeHTTP serves the metrics for a Prometheus scrape.
+func (m *ExecutorMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// writeSeries writes a metric's header and its series, by label set.
+func writeSeries(sb *strings.Builder, name, typ, help string, series map[string]float64) {
+	writeMetricHeader(sb, name, typ, help)
+	for _, set := range sortedLabelSets(series) {
+		writeSample(sb, name, set, series[set])
+	}
+}
+
+// sortedLabelSets returns the keys of series in order.
+func sortedLabelSets[V any](series map[string]V) []string {
+	sets := make([]string, 0, len(series))
+	for set := range series {
+		sets = append(sets, set)
+	}
+	sort.Strings(sets)
+	return sets
+}
+
+// metricsEndpoint returns the listen address and path of endpoint,
+// host:port followed by an optional path, which defaults to /metrics.
+func metricsEndpoint(endpoint string) (addr, path string, err error) {
+	if endpoint == "" {
+		endpoint = DefaultMetricsEndpoint
+	}
+	addr, path = endpoint, "/metrics"
+	if i := strings.Index(endpoint, "/"); i >= 0 {
+		addr, path = endpoint[:i], endpoint[i:]
+	}
+	if _, _, err := net.SplitHostPort(addr); err != nil || strings.Contains(endpoint, "://") {
+		return "", "", fmt.Errorf("invalid metrics endpoint %q (want host:port or :port, with an optional path)", endpoint)
+	}
+	return addr, path, nil
+}
+
+// Validate checks the exporter and endpoint.
+func (c *MetricsConfig) Validate() error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+	switch strings.ToLower(c.Exporter) {
+	case "", MetricsExporterPrometheus:
+	default:
+		return fmt.Errorf("invalid metrics exporter %q (want prometheus)", c.Exporter)
+	}
+	_, _, err := metricsEndpoint(c.Endpoint)
+	return err
+}
+
+// Serve serves m on the config's endpoint until ctx is done, and returns
+// the URL metrics are served at, or "" if c is nil or not enabled. It
+// fails if the config is invalid or the address cannot be listened on.
+func (c *MetricsConfig) Serve(ctx context.Context, m *ExecutorMetrics) (string, error) {
+	if c == nil || !c.Enabled {
+		return "", nil
+	}
+	if err := c.Validate(); err != nil {
+		return "", err
+	}
+	addr, path, _ := metricsEndpoint(c.Endpoint)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, m)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	go func() { _ = srv.Serve(ln) }()
+	return "http://" + ln.Addr().String() + path, nil
+}
@@ -0,0 +1,169 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExecutorMetrics(t *testing.T) {
+	m := NewExecutorMetrics()
+	var mu sync.Mutex
+	var during string
+	failed := false
+	runner := AgentRunnerFunc(func(ctx context.Context, run StepRun) (*AgentResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if run.Step.Name == "build" && !failed {
+			failed = true
+			return nil, errors.New("connection reset")
+		}
+		if run.Step.Name == "build" {
+			var sb strings.Builder
+			if err := m.WritePrometheus(&sb); err != nil {
+				t.Error(err)
+			}
+			during = sb.String()
+		}
+		return &AgentResult{
+			Tasks:    []TaskResult{PassTask("x", "")},
+			Duration: "2s",
+			TokensIn: 100, TokensOut: 20, CostUSD: 0.5,
+		}, nil
+	})
+	team := &Team{Name: "t", Agents: []string{"a", "b"}, Workflow: &Workflow{Type: WorkflowChain, Steps: []Step{
+		{Name: "build", Agent: "a"},
+		{Name: "lint", Agent: "b", When: "build.ok == true"},
+		{Name: "test", Agent: "b", DependsOn: []string{"build"}},
+	}}}
+	rt := &RuntimeConfig{Steps: map[string]*StepRuntime{"build": {Retry: &RetryPolicy{MaxAttempts: 1, InitialDelay: "1ms"}}}}
+	e, err := NewExecutor(team, []*Agent{commandAgent("a", "x"), commandAgent("b", "x")},
+		WithTaskRunner(runner), WithRuntime(rt), WithMetrics(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`mas_steps_queued{team="t"} 2`, `mas_steps_running{team="t"} 1`, `mas_step_retries_total{team="t",step="build"} 1`} {
+		if !strings.Contains(during, want+"\n") {
+			t.Errorf("metrics while build runs missing %s:\n%s", want, during)
+		}
+	}
+	var sb strings.Builder
+	if err := m.WritePrometheus(&sb); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# TYPE mas_runs_total counter",
+		`mas_runs_total{team="t",status="GO"} 1`,
+		`mas_steps_total{team="t",step="build",status="GO"} 1`,
+		`mas_steps_total{team="t",step="lint",status="SKIP"} 1`,
+		`mas_steps_total{team="t",step="test",status="GO"} 1`,
+		"# TYPE mas_step_duration_seconds histogram",
+		`mas_step_duration_seconds_bucket{team="t",step="build",le="1"} 0`,
+		`mas_step_duration_seconds_bucket{team="t",step="build",le="5"} 1`,
+		`mas_step_duration_seconds_bucket{team="t",step="build",le="+Inf"} 1`,
+		`mas_step_duration_seconds_sum{team="t",step="build"} 2`,
+		`mas_step_duration_seconds_count{team="t",step="test"} 1`,
+		`mas_step_retries_total{team="t",step="build"} 1`,
+		`mas_step_tokens_total{team="t",agent="a",direction="in"} 100`,
+		`mas_step_tokens_total{team="t",agent="b",direction="out"} 20`,
+		`mas_step_cost_usd_total{team="t",agent="a"} 0.5`,
+		`mas_steps_queued{team="t"} 0`,
+		`mas_steps_running{team="t"} 0`,
+	} {
+		if !strings.Contains(sb.String(), want+"\n") {
+			t.Errorf("metrics missing %s:\n%s", want, sb.String())
+		}
+	}
+	if strings.Contains(sb.String(), `mas_step_duration_seconds_count{team="t",step="lint"}`) {
+		t.Errorf("skipped step has a duration:\n%s", sb.String())
+	}
+}
+
+func TestMetricsConfigServe(t *testing.T) {
+	for _, cfg := range []*MetricsConfig{nil, {Endpoint: "nowhere"}} {
+		if url, err := cfg.Serve(context.Background(), NewExecutorMetrics()); url != "" || err != nil {
+			t.Errorf("Serve(%+v) = %q, %v; want no server for metrics off", cfg, url, err)
+		}
+	}
+	for _, tt := range []struct {
+		cfg  MetricsConfig
+		want string
+	}{
+		{MetricsConfig{Enabled: true, Exporter: "statsd"}, `invalid metrics exporter "statsd"`},
+		{MetricsConfig{Enabled: true, Endpoint: "http://localhost:9464/metrics"}, `invalid metrics endpoint "http://localhost:9464/metrics"`},
+		{MetricsConfig{Enabled: true, Endpoint: "9464"}, `invalid metrics endpoint "9464"`},
+	} {
+		if err := tt.cfg.Validate(); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Validate(%+v) = %v, want %s", tt.cfg, err, tt.want)
+		}
+	}
+	for endpoint, want := range map[string][2]string{
+		"":                     {":9464", "/metrics"},
+		"localhost:9090":       {"localhost:9090", "/metrics"},
+		":8080/internal/stats": {":8080", "/internal/stats"},
+	} {
+		if addr, path, err := metricsEndpoint(endpoint); err != nil || addr != want[0] || path != want[1] {
+			t.Errorf("metricsEndpoint(%q) = %q, %q, %v; want %q", endpoint, addr, path, err, want)
+		}
+	}
+
+	m := NewExecutorMetrics()
+	e, err := NewExecutor(&Team{Name: "t", Agents: []string{"a"}}, []*Agent{commandAgent("a", "x")},
+		WithTaskRunner(&fakeRunner{}), WithMetrics(m))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	url, err := (&MetricsConfig{Enabled: true, Exporter: MetricsExporterPrometheus, Endpoint: "127.0.0.1:0/stats"}).Serve(ctx, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(url, "http://127.0.0.1:") || !strings.HasSuffix(url, "/stats") {
+		t.Fatalf("Serve() = %s, want the /stats URL", url)
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain; version=0.0.4") ||
+		!strings.Contains(string(body), `mas_steps_total{team="t",step="a",status="GO"} 1`) {
+		t.Errorf("GET /stats = %s %s:\n%s", resp.Status, resp.Header.Get("Content-Type"), body)
+	}
+	addr := strings.TrimSuffix(strings.TrimPrefix(url, "http://"), "/stats")
+	if resp, err := http.Get("http://" + addr + "/metrics"); err != nil || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /metrics = %v, %v; want 404 off the configured path", resp, err)
+	} else {
+		resp.Body.Close()
+	}
+	if _, err := (&MetricsConfig{Enabled: true, Endpoint: addr}).Serve(ctx, m); err == nil {
+		t.Errorf("Serve() on %s twice succeeded", addr)
+	}
+
+	// The server stops with ctx.
+	cancel()
+	for i := 0; i < 100; i++ {
+		if resp, err = http.Get(url); err != nil {
+			break
+		}
+		resp.Body.Close()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err == nil {
+		t.Errorf("GET %s after cancel succeeded", url)
+	}
+}
@@ -0,0 +1,288 @@
+package multiagentspec
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GuardrailsTaskID is the ID of the task that reports a step's guardrail
+// violation.
+const GuardrailsTaskID = "guardrails"
+
+// defaultRetryDelay is the delay before a step's first retry when its
+// retry policy sets no initial_delay.
+const defaultRetryDelay = time.Second
+
+// stepPolicy is a step's runtime timeout and retry policy, parsed.
+type stepPolicy struct {
+	timeout  time.Duration // zero is unlimited
+	retry    *RetryPolicy
+	initial  time.Duration
+	maxDelay time.Duration // zero is uncapped
+}
+
+// newStepPolicy parses the timeout and retry policy of rt.
+func newStepPolicy(rt StepRuntime) (stepPolicy, error) {
+	var p stepPolicy
+	var err error
+	if p.timeout, err = parseRuntimeDuration("timeout", rt.Timeout); err != nil {
+		return p, err
+	}
+	r := rt.Retry
+	if r == nil || r.MaxAttempts <= 0 {
+		return p, nil
+	}
+	switch r.Backoff {
+	case "", "exponential", "fixed", "linear":
+	default:
+		return p, fmt.Errorf("unsupported backoff %q (want fixed, exponential, or linear)", r.Backoff)
+	}
+	p.retry = r
+	p.initial = defaultRetryDelay
+	if r.InitialDelay != "" {
+		if p.initial, err = parseRuntimeDuration("initial_delay", r.InitialDelay); err != nil {
+			return p, err
+		}
+	}
+	if p.maxDelay, err = parseRuntimeDuration("max_delay", r.MaxDelay); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+func parseRuntimeDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("invalid %s %q: must not be negative", name, value)
+	}
+	return d, nil
+}
+
+// retryable reports whether a failed attempt may be retried: any error if
+// the policy lists no retryable errors, otherwise one whose message
+// contains one of them, with "timeout" also matching a timed-out attempt.
+func (p stepPolicy) retryable(err error) bool {
+	if len(p.retry.RetryableErrors) == 0 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, want := range p.retry.RetryableErrors {
+		want = strings.ToLower(want)
+		if strings.Contains(msg, want) || want == "timeout" && errors.Is(err, context.DeadlineExceeded) {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns the delay before the retry after the given failed
+// attempt, from 1, under the policy's backoff.
+func (p stepPolicy) delay(attempt int) time.Duration {
+	d := p.initial
+	switch p.retry.Backoff {
+	case "fixed":
+	case "linear":
+		d *= time.Duration(attempt)
+	default:
+		for i := 1; i < attempt && (p.maxDelay == 0 || d < p.maxDelay); i++ {
+			d *= 2
+		}
+	}
+	if p.maxDelay > 0 && d > p.maxDelay {
+		d = p.maxDelay
+	}
+	return d
+}
+
+// attempt runs the step on runner, bounding each attempt by the step's
+// timeout and retrying failed attempts under its retry policy. An attempt
+// fails if the runner returns an error or the attempt times out. With
+// tracing, each attempt runs in a child span of trace.
+func (e *Executor) attempt(ctx context.Context, runner AgentRunner, run StepRun, trace traceContext) (*AgentResult, error) {
+	p := e.stepPolicies[run.Step.Name]
+	for attempt := 1; ; attempt++ {
+		sp := e.tracer.start(trace, fmt.Sprintf("attempt %d", attempt), "mas.step", run.Step.Name, "mas.attempt", strconv.Itoa(attempt))
+		result, err := runAttempt(ctx, runner, run, p.timeout)
+		if err != nil {
+			sp.end(err.Error())
+		} else {
+			sp.end("")
+		}
+		if err == nil || p.retry == nil || attempt > p.retry.MaxAttempts || ctx.Err() != nil || !p.retryable(err) {
+			return result, err
+		}
+		delay := p.delay(attempt)
+		e.emit(StepEvent{
+			Step:    run.Step.Name,
+			Agent:   run.Agent.QualifiedName(),
+			State:   StepRetrying,
+			Result:  e.failResult(run.Step, err),
+			Attempt: attempt,
+		})
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		}
+	}
+}
+
+// runAttempt runs the step once, within timeout if it is set.
+func runAttempt(ctx context.Context, runner AgentRunner, run StepRun, timeout time.Duration) (*AgentResult, error) {
+	if timeout <= 0 {
+		return runner.RunStep(ctx, run)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := runner.RunStep(attemptCtx, run)
+	if ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("step timed out after %s: %w", timeout, context.DeadlineExceeded)
+	}
+	return result, err
+}
+
+// guardInputs checks the step's string inputs against its agent's
+// guardrails, returning them with PII redacted.
+func (e *Executor) guardInputs(s Step, inputs map[string]interface{}) (map[string]interface{}, error) {
+	g := e.enforcers[s.Name]
+	if g == nil || len(inputs) == 0 {
+		return inputs, nil
+	}
+	guarded := make(map[string]interface{}, len(inputs))
+	for name, value := range inputs {
+		v, err := guardValue(value, g.CheckInput)
+		if err != nil {
+			return nil, fmt.Errorf("input %s: %w", name, err)
+		}
+		guarded[name] = v
+	}
+	return guarded, nil
+}
+
+// guardOutputs checks the result's string outputs against its agent's
+// guardrails, redacting PII. A violation drops the outputs, so they reach
+// no other step, and adds a NO-GO guardrails task.
+func (e *Executor) guardOutputs(s Step, result *AgentResult) {
+	g := e.enforcers[s.Name]
+	if g == nil || len(result.Outputs) == 0 {
+		return
+	}
+	guarded := make(map[string]interface{}, len(result.Outputs))
+	for name, value := range result.Outputs {
+		v, err := guardValue(value, g.CheckOutput)
+		if err != nil {
+			task := guardrailTask(fmt.Errorf("output %s: %w", name, err))
+			result.Outputs = nil
+			result.Tasks = append(result.Tasks, task)
+			result.Status = worseStatus(result.Status, task.Status)
+			return
+		}
+		guarded[name] = v
+	}
+	result.Outputs = guarded
+}
+
+// guardValue applies check to the strings in value, a port value that may
+// hold them in JSON objects and arrays. A value without strings to change
+// is returned as is.
+func guardValue(value interface{}, check func(string) (string, error)) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return check(v)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			checked, err := guardValue(item, check)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = checked
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			checked, err := guardValue(item, check)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = checked
+		}
+		return out, nil
+	}
+	return value, nil
+}
+
+// guardrailTask reports a guardrail violation as a NO-GO task.
+func guardrailTask(err error) TaskResult {
+	return TaskResult{ID: GuardrailsTaskID, Status: StatusNoGo, Severity: "high", Detail: err.Error()}
+}
+
+// traceContext is the W3C trace a run's spans belong to, and the span
+// its new spans are children of.
+type traceContext struct {
+	traceID  string
+	parentID string
+	flags    string
+}
+
+// newTraceContext returns the trace of traceparent, of TraceparentEnv when
+// it is empty, or a new trace, sampled if sampled is set, when both are.
+func newTraceContext(traceparent string, sampled bool) (traceContext, error) {
+	if traceparent == "" {
+		traceparent = os.Getenv(TraceparentEnv)
+	}
+	if traceparent == "" {
+		flags := "00"
+		if sampled {
+			flags = "01"
+		}
+		return traceContext{traceID: randomHex(16), flags: flags}, nil
+	}
+	traceID, spanID, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return traceContext{}, err
+	}
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	return traceContext{traceID: traceID, parentID: spanID, flags: parts[3]}, nil
+}
+
+// randomHex returns n random bytes in hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// logStep logs a step event, at debug level but for retries.
+func (e *Executor) logStep(ev StepEvent) {
+	switch ev.State {
+	case StepRunning:
+		e.logger.Debug("step started", "step", ev.Step, "agent", ev.Agent)
+	case StepRetrying:
+		e.logger.Warn("step failed, retrying", "step", ev.Step, "agent", ev.Agent, "attempt", ev.Attempt, "error", ev.Result.Error)
+	case StepDone:
+		e.logger.Debug("step finished", "step", ev.Step, "agent", ev.Agent, "status", ev.Result.Status, "duration", ev.Result.Duration)
+	default:
+		reason := ""
+		if len(ev.Result.Tasks) > 0 {
+			reason = ev.Result.Tasks[0].Detail
+		}
+		e.logger.Debug("step "+string(ev.State), "step", ev.Step, "agent", ev.Agent, "reason", reason)
+	}
+}
@@ -0,0 +1,367 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRunner passes every task of an agent, or fails those it names; an
+// agent without tasks gets one for its step.
+type fakeRunner struct {
+	mu      sync.Mutex
+	fail    map[string]bool // task IDs
+	outputs map[string]map[string]interface{}
+	runs    []StepRun
+}
+
+func (f *fakeRunner) RunStep(_ context.Context, run StepRun) (*AgentResult, error) {
+	f.mu.Lock()
+	f.runs = append(f.runs, run)
+	f.mu.Unlock()
+	result := &AgentResult{AgentID: run.Agent.QualifiedName(), Outputs: f.outputs[run.Step.Name]}
+	for _, t := range run.Agent.Tasks {
+		if f.fail[t.ID] {
+			result.Tasks = append(result.Tasks, FailTask(t.ID, errors.New("failed"), ""))
+		} else {
+			result.Tasks = append(result.Tasks, PassTask(t.ID, ""))
+		}
+	}
+	if len(result.Tasks) == 0 {
+		result.Tasks = append(result.Tasks, PassTask(run.Step.Name, ""))
+	}
+	return result, nil
+}
+
+func (f *fakeRunner) steps() []string {
+	var names []string
+	for _, r := range f.runs {
+		names = append(names, r.Step.Name)
+	}
+	return names
+}
+
+func commandAgent(name string, tasks ...string) *Agent {
+	a := &Agent{Name: name, Instructions: name}
+	for _, id := range tasks {
+		a.Tasks = append(a.Tasks, Task{ID: id, Type: TaskTypeCommand, Command: "true"})
+	}
+	return a
+}
+
+func TestExecutorRun(t *testing.T) {
+	agents := []*Agent{
+		commandAgent("builder", "build"),
+		commandAgent("linter", "lint"),
+		commandAgent("tester", "unit", "e2e"),
+		{Name: "reviewer", Instructions: "Review the change."},
+		commandAgent("releaser", "tag"),
+		commandAgent("notifier", "notify"),
+	}
+	required := true
+	team := &Team{
+		Name:    "release",
+		Version: "1.0.0",
+		Agents:  []string{"builder", "linter", "tester", "reviewer", "releaser", "notifier"},
+		Workflow: &Workflow{Type: WorkflowGraph, Steps: []Step{
+			{Name: "build", Agent: "builder"},
+			{Name: "lint", Agent: "linter", DependsOn: []string{"build"}},
+			{Name: "test", Agent: "tester", DependsOn: []string{"build"}},
+			{Name: "review", Agent: "reviewer", DependsOn: []string{"lint"},
+				Inputs: []Port{{Name: "artifact", From: "build.artifact", Required: &required}, {Name: "mode", Default: "strict"}}},
+			{Name: "release", Agent: "releaser", DependsOn: []string{"review", "test"}},
+			{Name: "notify", Agent: "notifier", DependsOn: []string{"review"}, When: "review.approved == false"},
+		}},
+	}
+	tasks := &fakeRunner{
+		fail:    map[string]bool{"e2e": true},
+		outputs: map[string]map[string]interface{}{"build": {"artifact": "app.tar.gz"}},
+	}
+	llm := &fakeRunner{outputs: map[string]map[string]interface{}{"review": {"approved": true}}}
+	var events []string
+	e, err := NewExecutor(team, agents,
+		WithTaskRunner(tasks),
+		WithLLMRunner(llm),
+		WithMaxParallel(1),
+		WithStepObserver(func(ev StepEvent) { events = append(events, ev.Step+":"+string(ev.State)) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, team := range report.Teams {
+		got = append(got, team.ID+"="+string(team.Status))
+	}
+	want := []string{"build=GO", "lint=GO", "test=NO-GO", "review=GO", "release=SKIP", "notify=SKIP"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("teams = %v, want %v", got, want)
+	}
+	if report.Status != StatusNoGo || report.Project != "release" || report.Schema != SchemaURL(SchemaTeamReport) {
+		t.Errorf("report = %s %s %s", report.Status, report.Project, report.Schema)
+	}
+	if deps := report.Teams[4].DependsOn; !reflect.DeepEqual(deps, []string{"review", "test"}) {
+		t.Errorf("release depends on %v", deps)
+	}
+	if d := report.Teams[4].Tasks[0].Detail; d != "upstream step test is NO-GO" {
+		t.Errorf("release detail = %q", d)
+	}
+	if d := report.Teams[5].Tasks[0].Detail; d != "when review.approved == false is false" {
+		t.Errorf("notify detail = %q", d)
+	}
+
+	if got := tasks.steps(); !reflect.DeepEqual(got, []string{"build", "lint", "test"}) {
+		t.Errorf("task runner ran %v", got)
+	}
+	if len(llm.runs) != 1 || !reflect.DeepEqual(llm.runs[0].Inputs, map[string]interface{}{"artifact": "app.tar.gz", "mode": "strict"}) {
+		t.Errorf("LLM runner runs = %+v", llm.runs)
+	}
+	wantEvents := []string{
+		"build:running", "build:done", "lint:running", "lint:done", "test:running", "test:done",
+		"review:running", "review:done", "release:skipped", "notify:skipped",
+	}
+	if !reflect.DeepEqual(events, wantEvents) {
+		t.Errorf("events = %v, want %v", events, wantEvents)
+	}
+}
+
+func TestExecutorChain(t *testing.T) {
+	agents := []*Agent{commandAgent("plan", "p"), {Name: "write"}, commandAgent("check", "c")}
+	team := &Team{Name: "docs", Agents: []string{"plan", "write", "check"}}
+	tasks := &fakeRunner{}
+	e, err := NewExecutor(team, agents, WithTaskRunner(tasks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without workflow steps, agents run in order; write has no runnable
+	// tasks and no LLM runner, so it and the steps after it are skipped.
+	var details []string
+	for _, team := range report.Teams {
+		details = append(details, team.ID+": "+string(team.Status))
+		if team.Status == StatusSkip {
+			details[len(details)-1] += " " + team.Tasks[0].Detail
+		}
+	}
+	want := []string{
+		"plan: GO",
+		"write: SKIP agent has no command, pattern, or file tasks and no LLM runner is configured",
+		"check: SKIP upstream step write was skipped",
+	}
+	if !reflect.DeepEqual(details, want) {
+		t.Errorf("teams =\n%s\nwant\n%s", strings.Join(details, "\n"), strings.Join(want, "\n"))
+	}
+	if deps := report.Teams[2].DependsOn; !reflect.DeepEqual(deps, []string{"write"}) {
+		t.Errorf("check depends on %v", deps)
+	}
+}
+
+func TestExecutorMissingInput(t *testing.T) {
+	required := true
+	team := &Team{Name: "t", Agents: []string{"a", "b"}, Workflow: &Workflow{Type: WorkflowChain, Steps: []Step{
+		{Name: "first", Agent: "a"},
+		{Name: "second", Agent: "b", Inputs: []Port{{Name: "plan", From: "first.plan", Required: &required}}},
+	}}}
+	e, err := NewExecutor(team, []*Agent{commandAgent("a", "x"), commandAgent("b", "y")}, WithTaskRunner(&fakeRunner{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, _ := e.Run(context.Background())
+	if s := report.Teams[1]; s.Status != StatusNoGo || s.Tasks[0].Detail != "input plan has no value" {
+		t.Errorf("second = %s %q", s.Status, s.Tasks[0].Detail)
+	}
+}
+
+func TestExecutorCanceled(t *testing.T) {
+	team := &Team{Name: "t", Agents: []string{"a"}}
+	e, err := NewExecutor(team, []*Agent{commandAgent("a", "x")}, WithTaskRunner(&fakeRunner{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report, err := e.Run(ctx)
+	if !errors.Is(err, context.Canceled) || report.Teams[0].Status != StatusSkip {
+		t.Errorf("Run() = %s, %v; want SKIP, context.Canceled", report.Teams[0].Status, err)
+	}
+}
+
+func TestExecutorContracts(t *testing.T) {
+	required := true
+	team := &Team{Name: "t", Agents: []string{"builder", "tester"}, Workflow: &Workflow{Type: WorkflowChain, Steps: []Step{
+		{Name: "build", Agent: "builder", Outputs: []Port{{Name: "artifact", Type: PortTypeString}, {Name: "size", Type: PortTypeNumber}}},
+		{Name: "test", Agent: "tester", Inputs: []Port{{Name: "artifact", From: "build.artifact", Required: &required}},
+			Outputs: []Port{{Name: "coverage", Type: PortTypeNumber}}},
+	}}}
+	runner := AgentRunnerFunc(func(ctx context.Context, run StepRun) (*AgentResult, error) {
+		result := &AgentResult{Tasks: []TaskResult{PassTask("x", "")}, Inputs: map[string]interface{}{}}
+		if run.Step.Name == "build" {
+			result.Outputs = map[string]interface{}{"artifact": "app.tar", "size": 42}
+		}
+		return result, nil
+	})
+	e, err := NewExecutor(team, []*Agent{commandAgent("builder", "x"), commandAgent("tester", "x")}, WithTaskRunner(runner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TaskResult{
+		{ID: "x", Status: StatusGo},
+		{ID: ContractTaskID, Status: StatusNoGo, Detail: "output coverage is missing; input artifact was not received from build.artifact"},
+	}
+	for i, w := range want {
+		tasks := report.Teams[i].Tasks
+		if got := tasks[len(tasks)-1]; got.ID != w.ID || got.Status != w.Status || got.Detail != w.Detail || report.Teams[i].Status != w.Status {
+			t.Errorf("step %s: %s with last task %+v, want %+v", report.Teams[i].ID, report.Teams[i].Status, got, w)
+		}
+	}
+}
+
+func TestExecutorRateLimits(t *testing.T) {
+	worker := commandAgent("worker", "x")
+	worker.RateLimit = &RateLimit{MaxConcurrent: 1}
+	team := &Team{Name: "t", Agents: []string{"worker"}, Workflow: &Workflow{Type: WorkflowGraph, Steps: []Step{
+		{Name: "one", Agent: "worker"}, {Name: "two", Agent: "worker"}, {Name: "three", Agent: "worker"},
+	}}}
+	var mu sync.Mutex
+	running, most := 0, 0
+	runner := AgentRunnerFunc(func(ctx context.Context, run StepRun) (*AgentResult, error) {
+		mu.Lock()
+		running++
+		if running > most {
+			most = running
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return &AgentResult{Tasks: []TaskResult{PassTask("x", "")}}, nil
+	})
+	limiters := NewRateLimiters()
+	e, err := NewExecutor(team, []*Agent{worker}, WithTaskRunner(runner), WithRateLimiters(limiters))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := e.Run(context.Background())
+	if err != nil || report.Status != StatusGo || most != 1 {
+		t.Fatalf("Run() = %s, %v with %d steps at once; want GO with 1", report.Status, err, most)
+	}
+
+	// A step still waiting for its limiter when the run is canceled is
+	// skipped without running.
+	release, err := limiters.ForStep("one", StepRuntime{}, worker).Acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	report, err = e.Run(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || report.Teams[0].Status != StatusSkip ||
+		!strings.HasPrefix(report.Teams[0].Tasks[0].Detail, "waiting for rate limit") {
+		t.Errorf("Run() = %+v, %v; want steps skipped waiting for rate limit", report.Teams[0], err)
+	}
+}
+
+func TestExecutorBudget(t *testing.T) {
+	var mu sync.Mutex
+	models := map[string]Model{}
+	runner := AgentRunnerFunc(func(ctx context.Context, run StepRun) (*AgentResult, error) {
+		mu.Lock()
+		models[run.Step.Name] = run.Agent.Model
+		mu.Unlock()
+		return &AgentResult{Tasks: []TaskResult{{ID: "x", Status: StatusGo, TokensIn: 600}}}, nil
+	})
+	steps := []Step{{Name: "one", Agent: "writer"}, {Name: "two", Agent: "writer"}}
+
+	tests := []struct {
+		budget Budget
+		want   map[string]Model // steps that ran, with their model
+		status []Status
+		detail string
+	}{
+		{
+			Budget{MaxTokens: 1000},
+			map[string]Model{"one": ModelOpus, "two": ModelOpus},
+			[]Status{StatusGo, StatusNoGo}, "Budget exceeded, agent aborted: 1200 tokens exceeds limit of 1000",
+		},
+		{
+			Budget{MaxTokens: 500},
+			map[string]Model{"one": ModelOpus},
+			[]Status{StatusNoGo, StatusSkip}, "agent writer was aborted over budget: 600 tokens exceeds limit of 500",
+		},
+		{
+			Budget{MaxTokens: 500, OnExceed: BudgetActionDowngrade},
+			map[string]Model{"one": ModelOpus, "two": ModelSonnet},
+			[]Status{StatusWarn, StatusWarn}, "Budget exceeded, downgraded to haiku: 1200 tokens exceeds limit of 500",
+		},
+	}
+	for _, tt := range tests {
+		models = map[string]Model{}
+		writer := commandAgent("writer", "x")
+		writer.Model = ModelOpus
+		writer.Budget = &tt.budget
+		team := &Team{Name: "t", Agents: []string{"writer"}, Workflow: &Workflow{Type: WorkflowGraph, Steps: steps}}
+		e, err := NewExecutor(team, []*Agent{writer}, WithTaskRunner(runner), WithMaxParallel(1),
+			WithBudget(NewBudgetTracker([]*Agent{writer})))
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := e.Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(models, tt.want) {
+			t.Errorf("%+v: steps ran with %v, want %v", tt.budget, models, tt.want)
+		}
+		for i, want := range tt.status {
+			if got := report.Teams[i].Status; got != want {
+				t.Errorf("%+v: step %s = %s, want %s", tt.budget, steps[i].Name, got, want)
+			}
+		}
+		last := report.Teams[len(report.Teams)-1].Tasks
+		if got := last[len(last)-1].Detail; got != tt.detail {
+			t.Errorf("%+v: last task = %q, want %q", tt.budget, got, tt.detail)
+		}
+	}
+}
+
+func TestNewExecutorErrors(t *testing.T) {
+	agents := []*Agent{commandAgent("a", "x")}
+	tests := []struct {
+		steps []Step
+		want  string
+	}{
+		{[]Step{{Name: "one", Agent: "a", DependsOn: []string{"zero"}}}, `team t: step one: unknown step "zero"`},
+		{[]Step{{Name: "one", Agent: "a"}, {Name: "one", Agent: "a"}}, `team t: duplicate workflow step "one"`},
+		{[]Step{{Name: "one", Agent: "nobody"}}, `team t: step one: unknown agent "nobody"`},
+		{[]Step{{Name: "one", Agent: "a", When: "two.ok"}}, `team t: step one: unknown step "two"`},
+		{[]Step{{Name: "one", Agent: "a", When: "ok"}}, `team t: step one: when "ok": unknown name "ok" (reference outputs as step_name.output_name)`},
+		{[]Step{
+			{Name: "one", Agent: "a", DependsOn: []string{"three"}},
+			{Name: "two", Agent: "a", DependsOn: []string{"one"}},
+			{Name: "three", Agent: "a", DependsOn: []string{"two"}},
+		}, "team t: workflow steps form a cycle: one → three → two → one"},
+	}
+	for _, tt := range tests {
+		team := &Team{Name: "t", Agents: []string{"a"}, Workflow: &Workflow{Type: WorkflowGraph, Steps: tt.steps}}
+		if _, err := NewExecutor(team, agents); err == nil || err.Error() != tt.want {
+			t.Errorf("NewExecutor() error = %v, want %s", err, tt.want)
+		}
+	}
+}
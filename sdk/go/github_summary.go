@@ -0,0 +1,178 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// severityOrder is the display order of the documented task severities.
+var severityOrder = []string{"critical", "high", "medium", "low", "info"}
+
+// WriteGitHubSummary writes report as GitHub-flavored markdown for a GitHub
+// Actions job summary, e.g., the file named by $GITHUB_STEP_SUMMARY. The
+// summary has a status table with one row per team, finding counts by
+// severity, and a collapsible section per team listing its WARN and NO-GO
+// tasks and content blocks. Sections of NO-GO teams start expanded. A cost
+// summary table follows when any team recorded token usage or cost.
+// It sorts teams by DAG order before writing.
+func WriteGitHubSummary(w io.Writer, report *TeamReport) error {
+	report.SortByDAG()
+
+	status := report.Status
+	if status == "" {
+		status = report.ComputeOverallStatus()
+	}
+
+	var sb strings.Builder
+	heading := fmt.Sprintf("%s %s: %s", status.Icon(), report.EffectiveTitle(), status)
+	if f := report.FilterSummary(); f != "" {
+		heading += " (" + f + ")"
+	}
+	fmt.Fprintf(&sb, "## %s\n\n", heading)
+
+	var meta []string
+	for _, f := range []struct{ label, value string }{
+		{"Project", report.Project},
+		{"Version", report.Version},
+		{"Target", report.Target},
+		{"Phase", report.Phase},
+	} {
+		if f.value != "" {
+			meta = append(meta, fmt.Sprintf("**%s:** %s", f.label, f.value))
+		}
+	}
+	if len(meta) > 0 {
+		sb.WriteString(strings.Join(meta, " · "))
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("| Team | Status | Verdict | Tasks |\n")
+	sb.WriteString("|------|--------|---------|-------|\n")
+	severities := map[string]int{}
+	for _, team := range report.Teams {
+		counts := map[Status]int{}
+		for _, task := range team.Tasks {
+			counts[task.Status]++
+			if isFinding(task) && task.Severity != "" {
+				severities[strings.ToLower(task.Severity)]++
+			}
+		}
+		var tasks []string
+		for _, s := range Statuses() {
+			if counts[s] > 0 {
+				tasks = append(tasks, fmt.Sprintf("%d %s", counts[s], s))
+			}
+		}
+		fmt.Fprintf(&sb, "| %s %s | %s | %s | %s |\n",
+			team.Status.Icon(), ghCell(team.Name), team.StatusLabel(), ghCell(team.Verdict), strings.Join(tasks, ", "))
+	}
+
+	if len(severities) > 0 {
+		sb.WriteString("\n### Findings by Severity\n\n")
+		sb.WriteString("| Severity | Count |\n")
+		sb.WriteString("|----------|-------|\n")
+		for _, s := range orderedSeverities(severities) {
+			fmt.Fprintf(&sb, "| %s | %d |\n", ghCell(s), severities[s])
+		}
+	}
+
+	wroteHeading := false
+	for _, team := range report.Teams {
+		var findings []TaskResult
+		for _, task := range team.Tasks {
+			if isFinding(task) {
+				findings = append(findings, task)
+			}
+		}
+		if len(findings) == 0 && len(team.ContentBlocks) == 0 {
+			continue
+		}
+		if !wroteHeading {
+			sb.WriteString("\n### Details\n")
+			wroteHeading = true
+		}
+
+		open := ""
+		if team.Status == StatusNoGo {
+			open = " open"
+		}
+		summary := fmt.Sprintf("%s %s: %s", team.Status.Icon(), team.Name, team.Status)
+		if len(findings) > 0 {
+			summary += fmt.Sprintf(" (%d %s)", len(findings), plural(len(findings), "finding", "findings"))
+		}
+		fmt.Fprintf(&sb, "\n<details%s>\n<summary>%s</summary>\n\n", open, htmlEscaper.Replace(summary))
+		if len(findings) > 0 {
+			sb.WriteString("| Task | Status | Severity | Detail |\n")
+			sb.WriteString("|------|--------|----------|--------|\n")
+			for _, task := range findings {
+				fmt.Fprintf(&sb, "| %s | %s %s | %s | %s |\n",
+					ghCell(task.ID), task.Status.Icon(), task.Status, ghCell(task.Severity), ghCell(task.Detail))
+			}
+			sb.WriteString("\n")
+		}
+		for _, block := range team.ContentBlocks {
+			sb.WriteString(renderBlockMD(block))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("</details>\n")
+	}
+
+	if cost := costSummaryMD(report); cost != "" {
+		sb.WriteString("\n### Cost Summary\n\n")
+		sb.WriteString(cost)
+	}
+
+	if len(report.FooterBlocks) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(renderBlocksMD(report.FooterBlocks))
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "\n**%s**\n", report.FinalMessage())
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// isFinding reports whether a task result needs attention.
+func isFinding(task TaskResult) bool {
+	return task.Status == StatusWarn || task.Status == StatusNoGo
+}
+
+// orderedSeverities returns the severities in counts, documented levels
+// first, then any others alphabetically.
+func orderedSeverities(counts map[string]int) []string {
+	var out, other []string
+	known := map[string]bool{}
+	for _, s := range severityOrder {
+		known[s] = true
+		if counts[s] > 0 {
+			out = append(out, s)
+		}
+	}
+	for s := range counts {
+		if !known[s] {
+			other = append(other, s)
+		}
+	}
+	sort.Strings(other)
+	return append(out, other...)
+}
+
+// htmlEscaper escapes text placed inside HTML elements.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// ghCell escapes text for a GitHub markdown table cell.
+func ghCell(s string) string {
+	s = strings.ReplaceAll(strings.TrimSpace(s), "|", "\\|")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
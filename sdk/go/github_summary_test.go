@@ -0,0 +1,92 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteGitHubSummary(t *testing.T) {
+	report := &TeamReport{
+		Project: "github.com/example/app",
+		Version: "v1.2.0",
+		Phase:   "PHASE 1: REVIEW",
+		Status:  StatusNoGo,
+		Teams: []TeamSection{
+			{
+				ID:        "security",
+				Name:      "security",
+				DependsOn: []string{"qa"},
+				Status:    StatusNoGo,
+				Verdict:   "NEEDS_WORK",
+				Tasks: []TaskResult{
+					{ID: "secrets", Status: StatusNoGo, Severity: "critical", Detail: "Token in config|prod.yaml"},
+					{ID: "deps", Status: StatusWarn, Severity: "High", Detail: "2 outdated"},
+					{ID: "license", Status: StatusGo, Severity: "info"},
+				},
+			},
+			{
+				ID:     "qa",
+				Name:   "qa",
+				Status: StatusGo,
+				Tasks:  []TaskResult{{ID: "unit", Status: StatusGo}, {ID: "e2e", Status: StatusSkip}},
+			},
+			{
+				ID:     "docs",
+				Name:   "docs",
+				Status: StatusWarn,
+				Tasks:  []TaskResult{{ID: "readme", Status: StatusWarn, Severity: "cosmetic", Detail: "Typos"}},
+				ContentBlocks: []ContentBlock{
+					{Type: ContentBlockList, Title: "Suggestions", Items: []ListItem{{Text: "Fix the install section"}}},
+				},
+			},
+		},
+		FooterBlocks: []ContentBlock{{Type: ContentBlockText, Content: "Re-run after fixes."}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteGitHubSummary(&buf, report); err != nil {
+		t.Fatalf("WriteGitHubSummary: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"## 🔴 TEAM STATUS REPORT: NO-GO\n\n**Project:** github.com/example/app · **Version:** v1.2.0 · **Phase:** PHASE 1: REVIEW\n",
+		"| 🟢 qa | GO |  | 1 GO, 1 SKIP |\n| 🔴 security | NO-GO | NEEDS_WORK | 1 GO, 1 NO-GO, 1 WARN |\n",
+		"| critical | 1 |\n| high | 1 |\n| cosmetic | 1 |\n",
+		"<details open>\n<summary>🔴 security: NO-GO (2 findings)</summary>\n\n",
+		"| secrets | 🔴 NO-GO | critical | Token in config\\|prod.yaml |\n",
+		"<details>\n<summary>🟡 docs: WARN (1 finding)</summary>\n",
+		"**Suggestions**\n\n- Fix the install section\n",
+		"Re-run after fixes.\n",
+		"**🛑 TEAM: NO-GO for v1.2.0 🛑**\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "qa: GO") || strings.Contains(out, "| license |") {
+		t.Errorf("summary lists passing tasks:\n%s", out)
+	}
+	if strings.Index(out, "| 🟢 qa") > strings.Index(out, "| 🔴 security") {
+		t.Errorf("teams not in DAG order:\n%s", out)
+	}
+}
+
+func TestWriteGitHubSummaryAllGo(t *testing.T) {
+	report := &TeamReport{
+		Version: "v1.0.0",
+		Teams:   []TeamSection{{ID: "qa", Name: "qa", Status: StatusGo, Tasks: []TaskResult{{ID: "unit", Status: StatusGo}}}},
+	}
+	var buf bytes.Buffer
+	if err := WriteGitHubSummary(&buf, report); err != nil {
+		t.Fatalf("WriteGitHubSummary: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "## 🟢 TEAM STATUS REPORT: GO\n\n**Version:** v1.0.0\n\n| Team |") {
+		t.Errorf("summary header:\n%s", out)
+	}
+	if strings.Contains(out, "Findings by Severity") || strings.Contains(out, "<details") {
+		t.Errorf("unexpected findings sections:\n%s", out)
+	}
+}
@@ -0,0 +1,225 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// PIIType is a category of personally identifiable information a guardrail
+// redacts.
+type PIIType string
+
+const (
+	// PIIEmail matches email addresses.
+	PIIEmail PIIType = "email"
+
+	// PIIPhone matches phone numbers.
+	PIIPhone PIIType = "phone"
+
+	// PIISSN matches US social security numbers.
+	PIISSN PIIType = "ssn"
+
+	// PIICreditCard matches payment card numbers.
+	PIICreditCard PIIType = "credit_card"
+
+	// PIIIPAddress matches IPv4 addresses.
+	PIIIPAddress PIIType = "ip_address"
+)
+
+// PIITypes returns all PII types in schema order.
+func PIITypes() []PIIType {
+	return []PIIType{PIIEmail, PIIPhone, PIISSN, PIICreditCard, PIIIPAddress}
+}
+
+// piiRedactOrder is the order PII filters are applied in, longest formats
+// first so that, e.g., a card number is not partly redacted as a phone
+// number.
+var piiRedactOrder = []PIIType{PIICreditCard, PIISSN, PIIPhone, PIIIPAddress, PIIEmail}
+
+// piiPatterns are the detectors for each PII type.
+var piiPatterns = map[PIIType]*regexp.Regexp{
+	PIIEmail:      regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	PIIPhone:      regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?\(?\d{3}\)?[ .-]?\d{3}[ .-]\d{4}\b`),
+	PIISSN:        regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+	PIICreditCard: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+	PIIIPAddress:  regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`),
+}
+
+// Guardrails restricts what an agent accepts and produces. Executors
+// enforce them through a GuardrailEnforcer; deployment generators map what
+// their platform supports.
+type Guardrails struct {
+	// InputDenyPatterns are regular expressions; input matching any of
+	// them is rejected.
+	InputDenyPatterns []string `json:"input_deny_patterns,omitempty" yaml:"input_deny_patterns,omitempty" jsonschema_description:"Regular expressions; matching input is rejected"`
+
+	// OutputDenyPatterns are regular expressions; output matching any of
+	// them is rejected.
+	OutputDenyPatterns []string `json:"output_deny_patterns,omitempty" yaml:"output_deny_patterns,omitempty" jsonschema_description:"Regular expressions; matching output is rejected"`
+
+	// MaxOutputLength is the maximum output length in characters; zero
+	// is unlimited.
+	MaxOutputLength int `json:"max_output_length,omitempty" yaml:"max_output_length,omitempty" jsonschema:"minimum=0" jsonschema_description:"Maximum output length in characters; 0 is unlimited"`
+
+	// PIIFilters are the PII types redacted from input and output.
+	PIIFilters []PIIType `json:"pii_filters,omitempty" yaml:"pii_filters,omitempty" jsonschema_description:"PII types redacted from input and output"`
+
+	// AllowedDomains limits WebFetch to these domains and their
+	// subdomains. Empty allows any domain.
+	AllowedDomains []string `json:"allowed_domains,omitempty" yaml:"allowed_domains,omitempty" jsonschema_description:"Domains, with their subdomains, WebFetch may fetch from; empty allows any"`
+}
+
+// Validate checks that the patterns compile, the length limit is not
+// negative, the PII types are known, and the domains are bare host names.
+func (g *Guardrails) Validate() error {
+	_, err := g.Enforcer()
+	return err
+}
+
+// GuardrailEnforcer checks agent input, output, and fetched URLs against
+// guardrails.
+type GuardrailEnforcer interface {
+	// CheckInput returns input with PII redacted, or a *GuardrailViolation
+	// if it is denied.
+	CheckInput(input string) (string, error)
+
+	// CheckOutput returns output with PII redacted, or a
+	// *GuardrailViolation if it is denied or too long.
+	CheckOutput(output string) (string, error)
+
+	// CheckURL returns a *GuardrailViolation if rawURL may not be fetched.
+	CheckURL(rawURL string) error
+}
+
+// GuardrailViolation reports input, output, or a URL that a guardrail
+// rejected.
+type GuardrailViolation struct {
+	// Rule is the guardrail field that was violated, e.g.,
+	// output_deny_patterns.
+	Rule string
+
+	// Detail describes the violation.
+	Detail string
+}
+
+func (v *GuardrailViolation) Error() string {
+	return fmt.Sprintf("guardrail %s: %s", v.Rule, v.Detail)
+}
+
+// Enforcer compiles the guardrails into a GuardrailEnforcer. A nil
+// Guardrails yields an enforcer that allows everything.
+func (g *Guardrails) Enforcer() (GuardrailEnforcer, error) {
+	e := &guardrailEnforcer{}
+	if g == nil {
+		return e, nil
+	}
+	var err error
+	if e.inputDeny, err = compilePatterns("input_deny_patterns", g.InputDenyPatterns); err != nil {
+		return nil, err
+	}
+	if e.outputDeny, err = compilePatterns("output_deny_patterns", g.OutputDenyPatterns); err != nil {
+		return nil, err
+	}
+	if g.MaxOutputLength < 0 {
+		return nil, fmt.Errorf("max_output_length must not be negative")
+	}
+	e.maxOutput = g.MaxOutputLength
+	enabled := make(map[PIIType]bool, len(g.PIIFilters))
+	for _, t := range g.PIIFilters {
+		if _, ok := piiPatterns[t]; !ok {
+			return nil, fmt.Errorf("unknown pii filter %q", t)
+		}
+		enabled[t] = true
+	}
+	for _, t := range piiRedactOrder {
+		if enabled[t] {
+			e.pii = append(e.pii, piiFilter{typ: t, re: piiPatterns[t]})
+		}
+	}
+	for _, d := range g.AllowedDomains {
+		d = strings.ToLower(d)
+		if d == "" || strings.ContainsAny(d, "/:@ *") {
+			return nil, fmt.Errorf("allowed domain %q is not a host name", d)
+		}
+		e.domains = append(e.domains, strings.TrimSuffix(d, "."))
+	}
+	return e, nil
+}
+
+func compilePatterns(field string, patterns []string) ([]*regexp.Regexp, error) {
+	out := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		out = append(out, re)
+	}
+	return out, nil
+}
+
+type piiFilter struct {
+	typ PIIType
+	re  *regexp.Regexp
+}
+
+type guardrailEnforcer struct {
+	inputDeny  []*regexp.Regexp
+	outputDeny []*regexp.Regexp
+	maxOutput  int
+	pii        []piiFilter
+	domains    []string
+}
+
+func (e *guardrailEnforcer) CheckInput(input string) (string, error) {
+	if err := checkDeny("input_deny_patterns", e.inputDeny, input); err != nil {
+		return "", err
+	}
+	return e.redact(input), nil
+}
+
+func (e *guardrailEnforcer) CheckOutput(output string) (string, error) {
+	if err := checkDeny("output_deny_patterns", e.outputDeny, output); err != nil {
+		return "", err
+	}
+	if n := len([]rune(output)); e.maxOutput > 0 && n > e.maxOutput {
+		return "", &GuardrailViolation{Rule: "max_output_length", Detail: fmt.Sprintf("output is %d characters, limit is %d", n, e.maxOutput)}
+	}
+	return e.redact(output), nil
+}
+
+func (e *guardrailEnforcer) CheckURL(rawURL string) error {
+	if len(e.domains) == 0 {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Hostname() == "" {
+		return &GuardrailViolation{Rule: "allowed_domains", Detail: fmt.Sprintf("cannot determine host of %q", rawURL)}
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	for _, d := range e.domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return nil
+		}
+	}
+	return &GuardrailViolation{Rule: "allowed_domains", Detail: fmt.Sprintf("host %s is not allowed", host)}
+}
+
+func checkDeny(rule string, patterns []*regexp.Regexp, text string) error {
+	for _, re := range patterns {
+		if re.MatchString(text) {
+			return &GuardrailViolation{Rule: rule, Detail: fmt.Sprintf("matches %q", re.String())}
+		}
+	}
+	return nil
+}
+
+// redact replaces each PII match with [REDACTED:<type>].
+func (e *guardrailEnforcer) redact(text string) string {
+	for _, f := range e.pii {
+		text = f.re.ReplaceAllString(text, "[REDACTED:"+string(f.typ)+"]")
+	}
+	return text
+}
@@ -0,0 +1,243 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/internal/slug"
+)
+
+// AutoGen group-chat defaults.
+const (
+	DefaultAutoGenTeamName    = "group-chat"
+	DefaultAutoGenManagerName = "chat_manager"
+)
+
+// autoGenConfig is an AutoGen group-chat config. Its fields mirror the
+// keyword arguments of ConversableAgent, GroupChat, and GroupChatManager.
+type autoGenConfig struct {
+	Name      string            `yaml:"name"`
+	Agents    []yaml.Node       `yaml:"agents"`
+	GroupChat *autoGenGroupChat `yaml:"group_chat"`
+	Manager   *autoGenAgent     `yaml:"manager"`
+}
+
+type autoGenGroupChat struct {
+	SpeakerSelectionMethod string `yaml:"speaker_selection_method"`
+	MaxRound               int    `yaml:"max_round"`
+}
+
+type autoGenAgent struct {
+	Name                    string    `yaml:"name"`
+	Description             string    `yaml:"description"`
+	SystemMessage           string    `yaml:"system_message"`
+	LLMConfig               yaml.Node `yaml:"llm_config"`
+	HumanInputMode          string    `yaml:"human_input_mode"`
+	MaxConsecutiveAutoReply int       `yaml:"max_consecutive_auto_reply"`
+	CodeExecutionConfig     yaml.Node `yaml:"code_execution_config"`
+}
+
+var autoGenAgentFields = map[string]bool{
+	"name": true, "type": true, "description": true, "system_message": true, "llm_config": true,
+	"human_input_mode": true, "max_consecutive_auto_reply": true, "code_execution_config": true,
+}
+
+// autoGenLLMConfig is an llm_config object; the model is read from the
+// model key or the first config_list entry.
+type autoGenLLMConfig struct {
+	Model      string `yaml:"model"`
+	ConfigList []struct {
+		Model string `yaml:"model"`
+	} `yaml:"config_list"`
+}
+
+type autoGenCodeExecution struct {
+	WorkDir   string `yaml:"work_dir"`
+	UseDocker bool   `yaml:"use_docker"`
+}
+
+// ImportAutoGen converts an AutoGen group-chat config, in YAML or JSON, into
+// spec agents, a team, and a draft deployment with an autogen target:
+//
+//	name: research-chat
+//	agents:
+//	  - name: user_proxy
+//	    human_input_mode: ALWAYS
+//	    code_execution_config: {work_dir: coding, use_docker: false}
+//	    llm_config: false
+//	  - name: coder
+//	    system_message: You write Python.
+//	    llm_config: {config_list: [{model: gpt-4o}]}
+//	group_chat:
+//	  speaker_selection_method: auto
+//	manager:
+//	  name: chat_manager
+//
+// Agent names have underscores replaced by hyphens, system messages become
+// instructions, and llm_config models map to model tiers. The autogen
+// target takes the first model, human_input_mode, max_consecutive_auto_reply,
+// and code_execution_config set on any agent. With a group_chat, the
+// manager (default DefaultAutoGenManagerName) is imported as an agent that
+// leads a crew workflow, or, for round_robin speaker selection, the agents
+// take turns in a chain workflow.
+func ImportAutoGen(data []byte) (*Result, error) {
+	var cfg autoGenConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	if len(cfg.Agents) == 0 {
+		return nil, fmt.Errorf("no agents defined")
+	}
+	if cfg.Name == "" {
+		cfg.Name = DefaultAutoGenTeamName
+	}
+
+	res := &Result{}
+	target := &multiagentspec.AutoGenConfig{}
+	for i := range cfg.Agents {
+		node := &cfg.Agents[i]
+		var ag autoGenAgent
+		if err := node.Decode(&ag); err != nil {
+			return nil, fmt.Errorf("agent %d: %w", i, err)
+		}
+		if ag.Name == "" {
+			return nil, fmt.Errorf("agent %d: no name", i)
+		}
+		a, err := importAutoGenAgent(res, &ag, target)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range (mappingEntry{value: node}).keys() {
+			if !autoGenAgentFields[key] {
+				res.warnf("agent %s: dropped unsupported field %s", a.Name, key)
+			}
+		}
+		res.Agents = append(res.Agents, a)
+	}
+
+	res.Team = multiagentspec.NewTeam(slug.Make(cfg.Name), DefaultTeamVersion)
+	if cfg.GroupChat != nil {
+		workflow := &multiagentspec.Workflow{}
+		switch cfg.GroupChat.SpeakerSelectionMethod {
+		case "round_robin":
+			workflow.Type = multiagentspec.WorkflowChain
+			for _, a := range res.Agents {
+				workflow.Steps = append(workflow.Steps, multiagentspec.Step{Name: a.Name, Agent: a.Name})
+			}
+		case "", "auto", "random", "manual":
+			if m := cfg.GroupChat.SpeakerSelectionMethod; m == "random" || m == "manual" {
+				res.warnf("group chat: speaker_selection_method %s imported as a crew workflow", m)
+			}
+			manager := cfg.Manager
+			if manager == nil {
+				manager = &autoGenAgent{}
+			}
+			if manager.Name == "" {
+				manager.Name = DefaultAutoGenManagerName
+			}
+			if manager.Description == "" {
+				manager.Description = "Selects the next speaker in the group chat"
+			}
+			m, err := importAutoGenAgent(res, manager, target)
+			if err != nil {
+				return nil, err
+			}
+			m.Delegation = &multiagentspec.DelegationConfig{AllowDelegation: true}
+			res.Agents = append(res.Agents, m)
+			workflow.Type = multiagentspec.WorkflowCrew
+			res.Team.Collaboration = &multiagentspec.CollaborationConfig{Lead: m.Name}
+		default:
+			return nil, fmt.Errorf("group chat: invalid speaker_selection_method %q", cfg.GroupChat.SpeakerSelectionMethod)
+		}
+		if cfg.GroupChat.MaxRound > 0 {
+			res.warnf("group chat: dropped max_round %d", cfg.GroupChat.MaxRound)
+		}
+		res.Team.Workflow = workflow
+	} else if cfg.Manager != nil {
+		res.warnf("manager %s: ignored without a group_chat", cfg.Manager.Name)
+	}
+	for _, a := range res.Agents {
+		res.Team.Agents = append(res.Team.Agents, a.Name)
+	}
+
+	res.Deployment = &multiagentspec.Deployment{
+		Team: res.Team.Name,
+		Targets: []multiagentspec.Target{{
+			Name:     string(multiagentspec.PlatformAutoGen),
+			Platform: multiagentspec.PlatformAutoGen,
+			AutoGen:  target,
+		}},
+	}
+	return res, nil
+}
+
+// importAutoGenAgent converts one agent, folding its runtime settings into
+// target when target does not have them yet.
+func importAutoGenAgent(res *Result, ag *autoGenAgent, target *multiagentspec.AutoGenConfig) (*multiagentspec.Agent, error) {
+	a := &multiagentspec.Agent{
+		Name:         slug.Make(ag.Name),
+		Description:  oneLine(ag.Description),
+		Instructions: strings.TrimSpace(ag.SystemMessage),
+	}
+
+	if ag.LLMConfig.Kind == yaml.MappingNode {
+		var llm autoGenLLMConfig
+		if err := ag.LLMConfig.Decode(&llm); err != nil {
+			return nil, fmt.Errorf("agent %s: llm_config: %w", a.Name, err)
+		}
+		if llm.Model == "" && len(llm.ConfigList) > 0 {
+			llm.Model = llm.ConfigList[0].Model
+		}
+		if llm.Model != "" {
+			if model, ok := parseModel(llm.Model); ok {
+				a.Model = model
+			}
+			setFirst(res, a.Name, "model", &target.Model, llm.Model)
+		}
+	}
+
+	switch ag.HumanInputMode {
+	case "", "ALWAYS", "TERMINATE", "NEVER":
+	default:
+		return nil, fmt.Errorf("agent %s: invalid human_input_mode %q (want ALWAYS, TERMINATE, or NEVER)", a.Name, ag.HumanInputMode)
+	}
+	setFirst(res, a.Name, "human_input_mode", &target.HumanInputMode, ag.HumanInputMode)
+	if ag.MaxConsecutiveAutoReply > 0 {
+		if target.MaxConsecutiveAutoReply == 0 {
+			target.MaxConsecutiveAutoReply = ag.MaxConsecutiveAutoReply
+		} else if target.MaxConsecutiveAutoReply != ag.MaxConsecutiveAutoReply {
+			res.warnf("agent %s: max_consecutive_auto_reply %d differs from %d; using %d",
+				a.Name, ag.MaxConsecutiveAutoReply, target.MaxConsecutiveAutoReply, target.MaxConsecutiveAutoReply)
+		}
+	}
+
+	if ag.CodeExecutionConfig.Kind == yaml.MappingNode {
+		var ce autoGenCodeExecution
+		if err := ag.CodeExecutionConfig.Decode(&ce); err != nil {
+			return nil, fmt.Errorf("agent %s: code_execution_config: %w", a.Name, err)
+		}
+		got := &multiagentspec.CodeExecutionConfig{WorkDir: ce.WorkDir, UseDocker: ce.UseDocker}
+		switch {
+		case target.CodeExecutionConfig == nil:
+			target.CodeExecutionConfig = got
+		case *target.CodeExecutionConfig != *got:
+			res.warnf("agent %s: code_execution_config differs from an earlier agent's; using the earlier one", a.Name)
+		}
+	}
+	return a, nil
+}
+
+// setFirst sets *dst to v unless it is already set, warning when the
+// values differ.
+func setFirst(res *Result, agent, field string, dst *string, v string) {
+	switch {
+	case v == "":
+	case *dst == "":
+		*dst = v
+	case *dst != v:
+		res.warnf("agent %s: %s %s differs from %s; using %s", agent, field, v, *dst, *dst)
+	}
+}
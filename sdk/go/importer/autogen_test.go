@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+const autoGenConfigYAML = `
+name: Research Chat
+agents:
+  - name: user_proxy
+    type: UserProxyAgent
+    human_input_mode: ALWAYS
+    max_consecutive_auto_reply: 5
+    code_execution_config: {work_dir: coding, use_docker: false}
+    llm_config: false
+  - name: coder
+    description: Writes Python
+    system_message: |
+      You write Python.
+    llm_config:
+      config_list: [{model: claude-3-5-sonnet-20241022}]
+      temperature: 0
+    human_input_mode: NEVER
+    is_termination_msg: null
+group_chat:
+  speaker_selection_method: auto
+  max_round: 12
+manager:
+  name: lead
+  llm_config: {model: gpt-4o}
+`
+
+func TestImportAutoGen(t *testing.T) {
+	res, err := ImportAutoGen([]byte(autoGenConfigYAML))
+	if err != nil {
+		t.Fatalf("ImportAutoGen: %v", err)
+	}
+
+	if len(res.Agents) != 3 {
+		t.Fatalf("got %d agents, want 3", len(res.Agents))
+	}
+	proxy, coder, lead := res.Agents[0], res.Agents[1], res.Agents[2]
+	if proxy.Name != "user-proxy" || proxy.Model != "" || proxy.Instructions != "" {
+		t.Errorf("user proxy = %+v", proxy)
+	}
+	if coder.Name != "coder" || coder.Description != "Writes Python" || coder.Instructions != "You write Python." ||
+		coder.Model != multiagentspec.ModelSonnet {
+		t.Errorf("coder = %+v", coder)
+	}
+	if lead.Name != "lead" || lead.Delegation == nil || !lead.Delegation.AllowDelegation {
+		t.Errorf("manager = %+v", lead)
+	}
+
+	team := res.Team
+	if team.Name != "research-chat" || !reflect.DeepEqual(team.Agents, []string{"user-proxy", "coder", "lead"}) ||
+		team.Workflow == nil || team.Workflow.Type != multiagentspec.WorkflowCrew || team.EffectiveLead() != "lead" {
+		t.Errorf("team = %+v", team)
+	}
+	if err := team.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+
+	want := &multiagentspec.AutoGenConfig{
+		Model:                   "claude-3-5-sonnet-20241022",
+		HumanInputMode:          "ALWAYS",
+		MaxConsecutiveAutoReply: 5,
+		CodeExecutionConfig:     &multiagentspec.CodeExecutionConfig{WorkDir: "coding"},
+	}
+	if res.Deployment == nil || res.Deployment.Team != "research-chat" || len(res.Deployment.Targets) != 1 ||
+		res.Deployment.Targets[0].Platform != multiagentspec.PlatformAutoGen || !reflect.DeepEqual(res.Deployment.Targets[0].AutoGen, want) {
+		t.Errorf("deployment = %+v", res.Deployment)
+	}
+
+	if err := res.Deployment.Validate(); err != nil {
+		t.Errorf("deployment Validate: %v", err)
+	}
+
+	warnings := strings.Join(res.Warnings, "\n")
+	for _, w := range []string{
+		"agent coder: human_input_mode NEVER differs from ALWAYS; using ALWAYS",
+		"agent coder: dropped unsupported field is_termination_msg",
+		"agent lead: model gpt-4o differs from claude-3-5-sonnet-20241022; using claude-3-5-sonnet-20241022",
+		"group chat: dropped max_round 12",
+	} {
+		if !strings.Contains(warnings, w) {
+			t.Errorf("warnings missing %q:\n%s", w, warnings)
+		}
+	}
+}
+
+func TestImportAutoGenRoundRobin(t *testing.T) {
+	data := `{"agents": [{"name": "a"}, {"name": "b"}], "group_chat": {"speaker_selection_method": "round_robin"}}`
+	res, err := ImportAutoGen([]byte(data))
+	if err != nil {
+		t.Fatalf("ImportAutoGen: %v", err)
+	}
+	want := &multiagentspec.Workflow{
+		Type:  multiagentspec.WorkflowChain,
+		Steps: []multiagentspec.Step{{Name: "a", Agent: "a"}, {Name: "b", Agent: "b"}},
+	}
+	if res.Team.Name != DefaultAutoGenTeamName || !reflect.DeepEqual(res.Team.Workflow, want) || len(res.Agents) != 2 {
+		t.Errorf("team = %+v", res.Team)
+	}
+}
+
+func TestImportAutoGenErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"no agents", `{"name": "x"}`, "no agents defined"},
+		{"unnamed agent", `{"agents": [{"system_message": "hi"}]}`, "agent 0: no name"},
+		{"bad mode", `{"agents": [{"name": "a", "human_input_mode": "SOMETIMES"}]}`, "invalid human_input_mode"},
+		{"bad selection", `{"agents": [{"name": "a"}], "group_chat": {"speaker_selection_method": "vote"}}`, "invalid speaker_selection_method"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportAutoGen([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/internal/slug"
+)
+
+// claudeAgent is the frontmatter of a Claude Code subagent file. Claude Code
+// accepts tools as a comma-separated string as well as a list.
+type claudeAgent struct {
+	Name         string     `yaml:"name"`
+	Description  string     `yaml:"description"`
+	Model        string     `yaml:"model"`
+	Tools        stringList `yaml:"tools"`
+	AllowedTools stringList `yaml:"allowedTools"`
+	Skills       stringList `yaml:"skills"`
+}
+
+// claudeAgentFields are the frontmatter keys claudeAgent reads.
+var claudeAgentFields = map[string]bool{
+	"name": true, "description": true, "model": true,
+	"tools": true, "allowedTools": true, "skills": true,
+}
+
+// claudeMCPConfig is a project .mcp.json file.
+type claudeMCPConfig struct {
+	MCPServers map[string]claudeMCPServer `json:"mcpServers"`
+}
+
+type claudeMCPServer struct {
+	Type    string            `json:"type"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	URL     string            `json:"url"`
+	Env     map[string]string `json:"env"`
+	Headers map[string]string `json:"headers"`
+}
+
+// ImportClaudeCode reads the Claude Code subagent files under dir (typically
+// .claude/agents). Agents in subdirectories get the subdirectory as their
+// namespace, as with LoadAgentsFromDir. The draft team is named after the
+// project directory two levels above dir.
+//
+// MCP tools in an agent's tool list (mcp__<server>__<tool>, or
+// mcp__<server> for all of a server's tools) become MCP server declarations
+// on the agent, completed from the project .mcp.json next to the .claude
+// directory, where ClaudeCodeGenerator writes it.
+func ImportClaudeCode(dir string) (*Result, error) {
+	mcpConfig, err := readClaudeMCPConfig(filepath.Join(dir, "..", "..", ".mcp.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	referenced := map[string]bool{}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) != ".md" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		a, err := parseClaudeAgent(res, data, strings.TrimSuffix(d.Name(), ".md"), mcpConfig, referenced)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if rel, err := filepath.Rel(dir, filepath.Dir(path)); err == nil && rel != "." {
+			a.Namespace = filepath.ToSlash(rel)
+		}
+		res.Agents = append(res.Agents, a)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir %s: %w", dir, err)
+	}
+	if len(res.Agents) == 0 {
+		return nil, fmt.Errorf("no agent files in %s", dir)
+	}
+
+	for _, name := range sortedKeys(mcpConfig.MCPServers) {
+		if !referenced[name] {
+			res.warnf("mcp server %s: not in any agent's tools; skipped", name)
+		}
+	}
+
+	name := "claude-agents"
+	if abs, err := filepath.Abs(dir); err == nil {
+		if project := slug.Make(filepath.Base(filepath.Dir(filepath.Dir(abs)))); project != "" {
+			name = project
+		}
+	}
+	res.Team = multiagentspec.NewTeam(name, DefaultTeamVersion)
+	for _, a := range res.Agents {
+		res.Team.Agents = append(res.Team.Agents, a.QualifiedName())
+	}
+	return res, nil
+}
+
+func readClaudeMCPConfig(path string) (*claudeMCPConfig, error) {
+	config := &claudeMCPConfig{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// parseClaudeAgent converts one subagent file. fallbackName is used when the
+// frontmatter has no name. Servers named by MCP tools are recorded in
+// referenced.
+func parseClaudeAgent(res *Result, data []byte, fallbackName string, mcpConfig *claudeMCPConfig, referenced map[string]bool) (*multiagentspec.Agent, error) {
+	frontmatter, body, err := splitFrontmatter(data)
+	if err != nil {
+		return nil, err
+	}
+	var fm claudeAgent
+	if err := yaml.Unmarshal(frontmatter, &fm); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(frontmatter, &raw); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+
+	a := &multiagentspec.Agent{
+		Name:         fm.Name,
+		Description:  fm.Description,
+		AllowedTools: fm.AllowedTools,
+		Skills:       fm.Skills,
+		Instructions: strings.TrimSpace(string(body)),
+	}
+	if a.Name == "" {
+		a.Name = fallbackName
+	}
+	for _, key := range sortedKeys(raw) {
+		if !claudeAgentFields[key] {
+			res.warnf("agent %s: dropped unsupported field %s", a.Name, key)
+		}
+	}
+
+	switch fm.Model {
+	case "", "inherit":
+	default:
+		model, ok := parseModel(fm.Model)
+		switch {
+		case !ok:
+			res.warnf("agent %s: model %s matches no model tier; left unset", a.Name, fm.Model)
+		case string(model) != fm.Model:
+			res.warnf("agent %s: model %s imported as tier %s", a.Name, fm.Model, model)
+		}
+		a.Model = model
+	}
+
+	// MCP servers in first-reference order; a nil allowed list means all
+	// of the server's tools.
+	var servers []*multiagentspec.MCPServer
+	byName := map[string]*multiagentspec.MCPServer{}
+	allTools := map[string]bool{}
+	for _, tool := range fm.Tools {
+		if !strings.HasPrefix(tool, "mcp__") {
+			if !isCanonicalTool(tool) {
+				res.warnf("agent %s: tool %s is not a canonical tool; kept as is", a.Name, tool)
+			}
+			a.Tools = append(a.Tools, tool)
+			continue
+		}
+
+		server, serverTool, _ := strings.Cut(strings.TrimPrefix(tool, "mcp__"), "__")
+		s, ok := byName[server]
+		if !ok {
+			cfg, declared := mcpConfig.MCPServers[server]
+			if !declared {
+				res.warnf("agent %s: mcp server %s is not declared in .mcp.json; dropped tool %s", a.Name, server, tool)
+				continue
+			}
+			s = &multiagentspec.MCPServer{
+				Name:      server,
+				Transport: multiagentspec.MCPTransport(cfg.Type),
+				Command:   cfg.Command,
+				Args:      cfg.Args,
+				URL:       cfg.URL,
+			}
+			if len(cfg.Env) > 0 || len(cfg.Headers) > 0 {
+				res.warnf("agent %s: mcp server %s: dropped env and headers", a.Name, server)
+			}
+			if err := s.Validate(); err != nil {
+				return nil, err
+			}
+			referenced[server] = true
+			byName[server] = s
+			servers = append(servers, s)
+		}
+		if serverTool == "" {
+			allTools[server] = true
+		} else {
+			s.AllowedTools = append(s.AllowedTools, serverTool)
+		}
+	}
+	for _, s := range servers {
+		if allTools[s.Name] {
+			s.AllowedTools = nil
+		}
+		a.MCPServers = append(a.MCPServers, *s)
+	}
+	return a, nil
+}
+
+// stringList is a YAML list of strings that also accepts a single
+// comma-separated string.
+type stringList []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, item := range strings.Split(value.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*l = append(*l, item)
+			}
+		}
+		return nil
+	}
+	var items []string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+func isCanonicalTool(tool string) bool {
+	for _, t := range multiagentspec.Tools() {
+		if string(t) == tool {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/deploy"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestImportClaudeCode(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "Acme API")
+	writeFiles(t, root, map[string]string{
+		".claude/agents/reviewer.md": "---\nname: reviewer\ndescription: Reviews pull requests\n" +
+			"tools: Read, Grep, MultiEdit, mcp__github__get_pr, mcp__github__list_files\n" +
+			"model: claude-opus-4-1\ncolor: blue\n---\n\nReview the diff.\n",
+		".claude/agents/ops/deployer.md": "---\nname: deployer\ntools: [Bash, mcp__docs, mcp__missing__x]\nmodel: inherit\n---\nDeploy.\n",
+		".claude/agents/notes.txt":       "not an agent",
+		".mcp.json": `{"mcpServers": {
+			"github": {"command": "gh-mcp", "args": ["serve"], "env": {"TOKEN": "x"}},
+			"docs": {"type": "http", "url": "https://docs.example.com/mcp"},
+			"unused": {"command": "unused"}
+		}}`,
+	})
+
+	res, err := ImportClaudeCode(filepath.Join(root, ".claude", "agents"))
+	if err != nil {
+		t.Fatalf("ImportClaudeCode: %v", err)
+	}
+
+	if res.Team.Name != "acme-api" || res.Team.Version != DefaultTeamVersion ||
+		!reflect.DeepEqual(res.Team.Agents, []string{"ops/deployer", "reviewer"}) {
+		t.Errorf("team = %+v", res.Team)
+	}
+	if len(res.Agents) != 2 {
+		t.Fatalf("got %d agents, want 2", len(res.Agents))
+	}
+
+	deployer, reviewer := res.Agents[0], res.Agents[1]
+	if reviewer.Model != multiagentspec.ModelOpus || reviewer.Description != "Reviews pull requests" ||
+		reviewer.Instructions != "Review the diff." || !reflect.DeepEqual(reviewer.Tools, []string{"Read", "Grep", "MultiEdit"}) {
+		t.Errorf("reviewer = %+v", reviewer)
+	}
+	wantGitHub := []multiagentspec.MCPServer{{Name: "github", Command: "gh-mcp", Args: []string{"serve"}, AllowedTools: []string{"get_pr", "list_files"}}}
+	if !reflect.DeepEqual(reviewer.MCPServers, wantGitHub) {
+		t.Errorf("reviewer MCP servers = %+v", reviewer.MCPServers)
+	}
+
+	if deployer.Namespace != "ops" || deployer.Model != "" || !reflect.DeepEqual(deployer.Tools, []string{"Bash"}) {
+		t.Errorf("deployer = %+v", deployer)
+	}
+	wantDocs := []multiagentspec.MCPServer{{Name: "docs", Transport: multiagentspec.MCPTransportHTTP, URL: "https://docs.example.com/mcp"}}
+	if !reflect.DeepEqual(deployer.MCPServers, wantDocs) {
+		t.Errorf("deployer MCP servers = %+v", deployer.MCPServers)
+	}
+
+	warnings := strings.Join(res.Warnings, "\n")
+	for _, want := range []string{
+		"agent deployer: mcp server missing is not declared in .mcp.json; dropped tool mcp__missing__x",
+		"agent reviewer: dropped unsupported field color",
+		"agent reviewer: model claude-opus-4-1 imported as tier opus",
+		"agent reviewer: tool MultiEdit is not a canonical tool; kept as is",
+		"agent reviewer: mcp server github: dropped env and headers",
+		"mcp server unused: not in any agent's tools; skipped",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings missing %q:\n%s", want, warnings)
+		}
+	}
+}
+
+func TestImportClaudeCodeErrors(t *testing.T) {
+	root := t.TempDir()
+	if _, err := ImportClaudeCode(root); err == nil || !strings.Contains(err.Error(), "no agent files") {
+		t.Errorf("empty dir error = %v", err)
+	}
+
+	writeFiles(t, root, map[string]string{"plain.md": "# No frontmatter\n"})
+	if _, err := ImportClaudeCode(root); err == nil || !strings.Contains(err.Error(), "missing frontmatter delimiter") {
+		t.Errorf("plain markdown error = %v", err)
+	}
+}
+
+// TestImportClaudeCodeRoundTrip imports the output of ClaudeCodeGenerator.
+func TestImportClaudeCodeRoundTrip(t *testing.T) {
+	qa := multiagentspec.NewAgent("qa", "Runs tests").WithNamespace("shared").WithTools("Read", "Bash").WithInstructions("Run the suite.")
+	qa.MCPServers = []multiagentspec.MCPServer{{Name: "ci", Transport: multiagentspec.MCPTransportStdio, Command: "ci-mcp", AllowedTools: []string{"status"}}}
+	pm := multiagentspec.NewAgent("pm", "Plans releases").WithModel(multiagentspec.ModelOpus).WithInstructions("Plan.")
+	project := &deploy.Project{
+		Team:   multiagentspec.NewTeam("release", "1.0.0").WithAgents("pm", "shared/qa"),
+		Agents: []*multiagentspec.Agent{qa, pm},
+	}
+	files, err := deploy.ClaudeCodeGenerator{}.Generate(project, &multiagentspec.Target{Name: "local", Platform: multiagentspec.PlatformClaudeCode})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	root := t.TempDir()
+	for _, f := range files {
+		writeFiles(t, root, map[string]string{f.Path: string(f.Content)})
+	}
+
+	res, err := ImportClaudeCode(filepath.Join(root, ".claude", "agents"))
+	if err != nil {
+		t.Fatalf("ImportClaudeCode: %v", err)
+	}
+	if len(res.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", res.Warnings)
+	}
+	for i, want := range []*multiagentspec.Agent{pm, qa} {
+		if !reflect.DeepEqual(res.Agents[i], want) {
+			t.Errorf("agent %d = %+v, want %+v", i, res.Agents[i], want)
+		}
+	}
+}
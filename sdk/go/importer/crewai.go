@@ -0,0 +1,273 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+	"github.com/plexusone/multi-agent-spec/sdk/go/internal/slug"
+)
+
+// CrewAI process types.
+const (
+	CrewAIProcessSequential   = "sequential"
+	CrewAIProcessHierarchical = "hierarchical"
+)
+
+// DefaultCrewAITeamName is the team name used when CrewAIOptions.Name is
+// empty.
+const DefaultCrewAITeamName = "crew"
+
+// CrewAIOptions are the crew settings that live in Python code rather than
+// in the YAML configs.
+type CrewAIOptions struct {
+	// Name is the team name (default DefaultCrewAITeamName).
+	Name string
+
+	// Process is the crew process, sequential (default) or hierarchical.
+	Process string
+
+	// Manager is the agent that leads a hierarchical crew.
+	Manager string
+}
+
+// crewAIAgent is an entry of a CrewAI agents.yaml.
+type crewAIAgent struct {
+	Role            string `yaml:"role"`
+	Goal            string `yaml:"goal"`
+	Backstory       string `yaml:"backstory"`
+	LLM             string `yaml:"llm"`
+	AllowDelegation bool   `yaml:"allow_delegation"`
+}
+
+var crewAIAgentFields = map[string]bool{
+	"role": true, "goal": true, "backstory": true, "llm": true, "allow_delegation": true,
+}
+
+// crewAITask is an entry of a CrewAI tasks.yaml.
+type crewAITask struct {
+	Description    string   `yaml:"description"`
+	ExpectedOutput string   `yaml:"expected_output"`
+	Agent          string   `yaml:"agent"`
+	Context        []string `yaml:"context"`
+	HumanInput     bool     `yaml:"human_input"`
+}
+
+var crewAITaskFields = map[string]bool{
+	"description": true, "expected_output": true, "agent": true, "context": true, "human_input": true,
+}
+
+// crewAIHumanInLoop is the HumanInLoop text for tasks with human_input set.
+const crewAIHumanInLoop = "Ask a human to review the output before completing the task"
+
+// ImportCrewAI converts a CrewAI agents.yaml and tasks.yaml into spec agents,
+// a team, and a draft deployment with a crewai target. tasksYAML may be nil.
+//
+// Agent keys become agent names, with underscores replaced by hyphens, and
+// keep their role, goal, backstory, and delegation setting. Each task is
+// added to its agent's tasks and becomes a workflow step named after the
+// task, in file order, depending on the tasks in its context. A sequential
+// crew gets a chain workflow; a hierarchical crew gets a crew workflow led
+// by opts.Manager, which also runs tasks that name no agent.
+func ImportCrewAI(agentsYAML, tasksYAML []byte, opts CrewAIOptions) (*Result, error) {
+	if opts.Name == "" {
+		opts.Name = DefaultCrewAITeamName
+	}
+	if opts.Process == "" {
+		opts.Process = CrewAIProcessSequential
+	}
+	var workflowType multiagentspec.WorkflowType
+	switch opts.Process {
+	case CrewAIProcessSequential:
+		workflowType = multiagentspec.WorkflowChain
+	case CrewAIProcessHierarchical:
+		workflowType = multiagentspec.WorkflowCrew
+		if opts.Manager == "" {
+			return nil, fmt.Errorf("hierarchical process requires a manager agent")
+		}
+	default:
+		return nil, fmt.Errorf("invalid process %q (want %s or %s)", opts.Process, CrewAIProcessSequential, CrewAIProcessHierarchical)
+	}
+
+	res := &Result{}
+	agentEntries, err := yamlMapping(agentsYAML)
+	if err != nil {
+		return nil, fmt.Errorf("agents: %w", err)
+	}
+	if len(agentEntries) == 0 {
+		return nil, fmt.Errorf("agents: no agents defined")
+	}
+	byKey := map[string]*multiagentspec.Agent{}
+	for _, e := range agentEntries {
+		var ca crewAIAgent
+		if err := e.value.Decode(&ca); err != nil {
+			return nil, fmt.Errorf("agent %s: %w", e.key, err)
+		}
+		a := &multiagentspec.Agent{
+			Name:        slug.Make(e.key),
+			Description: oneLine(ca.Role),
+			Role:        strings.TrimSpace(ca.Role),
+			Goal:        strings.TrimSpace(ca.Goal),
+			Backstory:   strings.TrimSpace(ca.Backstory),
+		}
+		if ca.AllowDelegation {
+			a.Delegation = &multiagentspec.DelegationConfig{AllowDelegation: true}
+		}
+		if ca.LLM != "" {
+			model, ok := parseModel(ca.LLM)
+			switch {
+			case !ok:
+				res.warnf("agent %s: llm %s matches no model tier; left unset", a.Name, ca.LLM)
+			case string(model) != ca.LLM:
+				res.warnf("agent %s: llm %s imported as tier %s", a.Name, ca.LLM, model)
+			}
+			a.Model = model
+		}
+		for _, key := range e.keys() {
+			if !crewAIAgentFields[key] {
+				res.warnf("agent %s: dropped unsupported field %s", a.Name, key)
+			}
+		}
+		byKey[e.key] = a
+		res.Agents = append(res.Agents, a)
+	}
+
+	manager := ""
+	if opts.Manager != "" {
+		m, ok := lookupCrewAIAgent(byKey, opts.Manager)
+		if !ok {
+			return nil, fmt.Errorf("manager %s is not a defined agent", opts.Manager)
+		}
+		manager = m.Name
+	}
+
+	taskEntries, err := yamlMapping(tasksYAML)
+	if err != nil {
+		return nil, fmt.Errorf("tasks: %w", err)
+	}
+	workflow := &multiagentspec.Workflow{Type: workflowType}
+	tasks := map[string]bool{}
+	for _, e := range taskEntries {
+		var ct crewAITask
+		if err := e.value.Decode(&ct); err != nil {
+			return nil, fmt.Errorf("task %s: %w", e.key, err)
+		}
+		var a *multiagentspec.Agent
+		switch {
+		case ct.Agent != "":
+			var ok bool
+			if a, ok = lookupCrewAIAgent(byKey, ct.Agent); !ok {
+				return nil, fmt.Errorf("task %s: agent %s is not defined", e.key, ct.Agent)
+			}
+		case manager != "":
+			a, _ = lookupCrewAIAgent(byKey, manager)
+		default:
+			return nil, fmt.Errorf("task %s: no agent assigned", e.key)
+		}
+		for _, dep := range ct.Context {
+			if !tasks[dep] {
+				return nil, fmt.Errorf("task %s: context task %s is not defined before it", e.key, dep)
+			}
+		}
+		tasks[e.key] = true
+
+		task := multiagentspec.Task{
+			ID:             e.key,
+			Description:    strings.TrimSpace(ct.Description),
+			ExpectedOutput: strings.TrimSpace(ct.ExpectedOutput),
+		}
+		if ct.HumanInput {
+			task.HumanInLoop = crewAIHumanInLoop
+		}
+		a.Tasks = append(a.Tasks, task)
+		workflow.Steps = append(workflow.Steps, multiagentspec.Step{
+			Name:      e.key,
+			Agent:     a.Name,
+			DependsOn: ct.Context,
+		})
+		for _, key := range e.keys() {
+			if !crewAITaskFields[key] {
+				res.warnf("task %s: dropped unsupported field %s", e.key, key)
+			}
+		}
+	}
+
+	res.Team = multiagentspec.NewTeam(opts.Name, DefaultTeamVersion)
+	for _, a := range res.Agents {
+		res.Team.Agents = append(res.Team.Agents, a.Name)
+	}
+	if len(workflow.Steps) > 0 || manager != "" {
+		res.Team.Workflow = workflow
+	}
+	if manager != "" {
+		res.Team.Collaboration = &multiagentspec.CollaborationConfig{Lead: manager}
+	}
+	res.Deployment = &multiagentspec.Deployment{
+		Team: res.Team.Name,
+		Targets: []multiagentspec.Target{{
+			Name:     string(multiagentspec.PlatformCrewAI),
+			Platform: multiagentspec.PlatformCrewAI,
+			CrewAI:   &multiagentspec.CrewAIConfig{ProcessType: opts.Process},
+		}},
+	}
+	return res, nil
+}
+
+// lookupCrewAIAgent finds an agent by its CrewAI key or its spec name.
+func lookupCrewAIAgent(byKey map[string]*multiagentspec.Agent, name string) (*multiagentspec.Agent, bool) {
+	if a, ok := byKey[name]; ok {
+		return a, true
+	}
+	for _, a := range byKey {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// mappingEntry is a key of a YAML mapping and its value.
+type mappingEntry struct {
+	key   string
+	value *yaml.Node
+}
+
+// keys returns the keys of the entry's value when it is a mapping.
+func (e mappingEntry) keys() []string {
+	var keys []string
+	if e.value.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(e.value.Content); i += 2 {
+			keys = append(keys, e.value.Content[i].Value)
+		}
+	}
+	return keys
+}
+
+// yamlMapping returns the entries of a top-level YAML mapping in document
+// order. Empty input has no entries.
+func yamlMapping(data []byte) ([]mappingEntry, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse yaml: top level is not a mapping")
+	}
+	entries := make([]mappingEntry, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		entries = append(entries, mappingEntry{key: root.Content[i].Value, value: root.Content[i+1]})
+	}
+	return entries, nil
+}
+
+// oneLine collapses whitespace runs, including the newlines of YAML block
+// scalars, into single spaces.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,157 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+const crewAIAgentsYAML = `
+researcher:
+  role: >
+    {topic} Senior Data
+    Researcher
+  goal: Uncover developments in {topic}
+  backstory: You're a seasoned researcher.
+  llm: anthropic/claude-3-5-sonnet-20241022
+  verbose: true
+reporting_analyst:
+  role: Reporting Analyst
+  goal: Write reports
+  backstory: You're meticulous.
+  llm: openai/gpt-4o
+  allow_delegation: true
+`
+
+const crewAITasksYAML = `
+research_task:
+  description: >
+    Research {topic}.
+  expected_output: A list of 10 bullet points
+  agent: researcher
+reporting_task:
+  description: Expand the research into a report.
+  expected_output: A markdown report
+  agent: reporting_analyst
+  context: [research_task]
+  human_input: true
+  output_file: report.md
+`
+
+func TestImportCrewAI(t *testing.T) {
+	res, err := ImportCrewAI([]byte(crewAIAgentsYAML), []byte(crewAITasksYAML), CrewAIOptions{Name: "research-crew"})
+	if err != nil {
+		t.Fatalf("ImportCrewAI: %v", err)
+	}
+
+	if len(res.Agents) != 2 {
+		t.Fatalf("got %d agents, want 2", len(res.Agents))
+	}
+	researcher, analyst := res.Agents[0], res.Agents[1]
+	if researcher.Name != "researcher" || researcher.Description != "{topic} Senior Data Researcher" ||
+		researcher.Goal != "Uncover developments in {topic}" || researcher.Backstory != "You're a seasoned researcher." ||
+		researcher.Model != multiagentspec.ModelSonnet || researcher.Delegation != nil {
+		t.Errorf("researcher = %+v", researcher)
+	}
+	if analyst.Name != "reporting-analyst" || analyst.Model != "" || analyst.Delegation == nil || !analyst.Delegation.AllowDelegation {
+		t.Errorf("analyst = %+v", analyst)
+	}
+	wantTask := multiagentspec.Task{
+		ID:             "reporting_task",
+		Description:    "Expand the research into a report.",
+		ExpectedOutput: "A markdown report",
+		HumanInLoop:    crewAIHumanInLoop,
+	}
+	if len(analyst.Tasks) != 1 || !reflect.DeepEqual(analyst.Tasks[0], wantTask) {
+		t.Errorf("analyst tasks = %+v", analyst.Tasks)
+	}
+	if len(researcher.Tasks) != 1 || researcher.Tasks[0].Description != "Research {topic}." {
+		t.Errorf("researcher tasks = %+v", researcher.Tasks)
+	}
+
+	team := res.Team
+	if team.Name != "research-crew" || !reflect.DeepEqual(team.Agents, []string{"researcher", "reporting-analyst"}) {
+		t.Errorf("team = %+v", team)
+	}
+	wantWorkflow := &multiagentspec.Workflow{
+		Type: multiagentspec.WorkflowChain,
+		Steps: []multiagentspec.Step{
+			{Name: "research_task", Agent: "researcher"},
+			{Name: "reporting_task", Agent: "reporting-analyst", DependsOn: []string{"research_task"}},
+		},
+	}
+	if !reflect.DeepEqual(team.Workflow, wantWorkflow) {
+		t.Errorf("workflow = %+v", team.Workflow)
+	}
+
+	if res.Deployment == nil || res.Deployment.Team != "research-crew" || len(res.Deployment.Targets) != 1 ||
+		res.Deployment.Targets[0].CrewAI == nil || res.Deployment.Targets[0].CrewAI.ProcessType != CrewAIProcessSequential {
+		t.Errorf("deployment = %+v", res.Deployment)
+	}
+
+	if err := res.Deployment.Validate(); err != nil {
+		t.Errorf("deployment Validate: %v", err)
+	}
+
+	warnings := strings.Join(res.Warnings, "\n")
+	for _, want := range []string{
+		"agent researcher: llm anthropic/claude-3-5-sonnet-20241022 imported as tier sonnet",
+		"agent researcher: dropped unsupported field verbose",
+		"agent reporting-analyst: llm openai/gpt-4o matches no model tier; left unset",
+		"task reporting_task: dropped unsupported field output_file",
+	} {
+		if !strings.Contains(warnings, want) {
+			t.Errorf("warnings missing %q:\n%s", want, warnings)
+		}
+	}
+}
+
+func TestImportCrewAIHierarchical(t *testing.T) {
+	tasks := "triage:\n  description: Triage issues\n"
+	res, err := ImportCrewAI([]byte(crewAIAgentsYAML), []byte(tasks), CrewAIOptions{
+		Process: CrewAIProcessHierarchical,
+		Manager: "reporting_analyst",
+	})
+	if err != nil {
+		t.Fatalf("ImportCrewAI: %v", err)
+	}
+	if res.Team.Name != DefaultCrewAITeamName || res.Team.Workflow.Type != multiagentspec.WorkflowCrew ||
+		res.Team.Collaboration == nil || res.Team.Collaboration.Lead != "reporting-analyst" {
+		t.Errorf("team = %+v", res.Team)
+	}
+	if err := res.Team.Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+	if steps := res.Team.Workflow.Steps; len(steps) != 1 || steps[0].Agent != "reporting-analyst" {
+		t.Errorf("steps = %+v", steps)
+	}
+}
+
+func TestImportCrewAIErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		agents string
+		tasks  string
+		opts   CrewAIOptions
+		want   string
+	}{
+		{"no agents", "", "", CrewAIOptions{}, "no agents defined"},
+		{"not a mapping", "- a\n", "", CrewAIOptions{}, "top level is not a mapping"},
+		{"bad process", crewAIAgentsYAML, "", CrewAIOptions{Process: "parallel"}, "invalid process"},
+		{"no manager", crewAIAgentsYAML, "", CrewAIOptions{Process: CrewAIProcessHierarchical}, "requires a manager"},
+		{"unknown manager", crewAIAgentsYAML, "", CrewAIOptions{Process: CrewAIProcessHierarchical, Manager: "boss"}, "manager boss is not a defined agent"},
+		{"unknown agent", crewAIAgentsYAML, "t:\n  agent: writer\n", CrewAIOptions{}, "task t: agent writer is not defined"},
+		{"unassigned", crewAIAgentsYAML, "t:\n  description: x\n", CrewAIOptions{}, "task t: no agent assigned"},
+		{"unknown context", crewAIAgentsYAML, "t:\n  agent: researcher\n  context: [later]\n", CrewAIOptions{}, "context task later is not defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportCrewAI([]byte(tt.agents), []byte(tt.tasks), tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Package importer converts agent configurations written for other
+// frameworks into multi-agent-spec definitions, easing migration of
+// existing setups.
+//
+// Each importer returns a Result holding the spec agents, a draft team
+// listing them, and warnings for settings that have no spec equivalent and
+// were dropped or approximated. The draft team is a starting point: review
+// its workflow and orchestrator before deploying it.
+//
+// Example:
+//
+//	res, err := importer.ImportClaudeCode(".claude/agents")
+//	if err != nil {
+//	    return err
+//	}
+//	for _, w := range res.Warnings {
+//	    fmt.Println("warning:", w)
+//	}
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// DefaultTeamVersion is the version given to imported teams.
+const DefaultTeamVersion = "0.1.0"
+
+// Result is the outcome of an import.
+type Result struct {
+	// Team is a draft team listing the imported agents.
+	Team *multiagentspec.Team
+
+	// Agents are the imported agent definitions.
+	Agents []*multiagentspec.Agent
+
+	// Deployment is a draft deployment carrying the source framework's
+	// platform settings, or nil when the source has none.
+	Deployment *multiagentspec.Deployment
+
+	// Warnings describe settings that were dropped or approximated.
+	Warnings []string
+}
+
+func (r *Result) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// parseModel maps a framework model setting to a spec model tier. Tier
+// names match exactly; concrete model identifiers map to the tier they
+// name (e.g., claude-opus-4-1 to opus). Other values return ok false.
+func parseModel(v string) (model multiagentspec.Model, ok bool) {
+	v = strings.ToLower(strings.TrimSpace(v))
+	for _, m := range multiagentspec.Models() {
+		if v == string(m) {
+			return m, true
+		}
+	}
+	for _, m := range multiagentspec.Models() {
+		if strings.Contains(v, string(m)) {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// splitFrontmatter splits a markdown file into its YAML frontmatter, delimited
+// by --- lines, and the body that follows.
+func splitFrontmatter(data []byte) (frontmatter, body []byte, err error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if len(lines) == 0 || strings.TrimSpace(string(lines[0])) != "---" {
+		return nil, nil, fmt.Errorf("missing frontmatter delimiter")
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(string(lines[i])) == "---" {
+			return bytes.Join(lines[1:i], nil), bytes.Join(lines[i+1:], nil), nil
+		}
+	}
+	return nil, nil, fmt.Errorf("missing closing frontmatter delimiter")
+}
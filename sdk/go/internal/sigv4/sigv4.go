@@ -0,0 +1,140 @@
+// Package sigv4 signs HTTP requests with AWS Signature Version 4, for the
+// Bedrock evaluator and the S3 report store.
+package sigv4
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Credentials are AWS access keys.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is set for temporary credentials.
+	SessionToken string
+}
+
+// CredentialsFromEnv reads AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and
+// AWS_SESSION_TOKEN, reporting whether the keys are set.
+func CredentialsFromEnv() (Credentials, bool) {
+	creds := Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	return creds, creds.AccessKeyID != "" && creds.SecretAccessKey != ""
+}
+
+// Sign adds Signature Version 4 headers to req, whose body is body. For
+// service s3 it also sets X-Amz-Content-Sha256 and signs the path as
+// escaped, as S3 requires.
+func Sign(req *http.Request, body []byte, creds Credentials, region, service string, t time.Time) {
+	amzDate := t.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+	bodyHash := sha256Hex(body)
+	uri := CanonicalURI(req.URL.EscapedPath())
+	if service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", bodyHash)
+		if uri = req.URL.EscapedPath(); uri == "" {
+			uri = "/"
+		}
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if name == "content-type" || strings.HasPrefix(name, "x-amz-") {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		uri,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		bodyHash,
+	}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// CanonicalURI encodes each segment of an already escaped path again, as
+// SigV4 requires for services other than S3.
+func CanonicalURI(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return URIEncode(path, false)
+}
+
+func canonicalQuery(values map[string][]string) string {
+	var pairs []string
+	for k, vs := range values {
+		for _, v := range vs {
+			pairs = append(pairs, URIEncode(k, true)+"="+URIEncode(v, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// URIEncode percent-encodes every byte except unreserved characters
+// and, unless encodeSlash is set, slashes.
+func URIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,23 @@
+// Package slug turns names and titles into lowercase, hyphenated
+// identifiers, for the agentsmd and importer packages.
+package slug
+
+import "strings"
+
+// Make lowercases s and replaces runs of other characters with hyphens.
+func Make(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
@@ -0,0 +1,18 @@
+package slug
+
+import "testing"
+
+func TestMake(t *testing.T) {
+	tests := map[string]string{
+		"Release Manager":    "release-manager",
+		"  QA / Security!  ": "qa-security",
+		"v2.1 Agent":         "v2-1-agent",
+		"Ünïcode":            "n-code",
+		"---":                "",
+	}
+	for in, want := range tests {
+		if got := Make(in); got != want {
+			t.Errorf("Make(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
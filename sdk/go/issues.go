@@ -0,0 +1,173 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// severityRank orders issue severities most serious first.
+var severityRank = map[Severity]int{
+	SeverityCritical:   0,
+	SeverityMajor:      1,
+	SeverityMinor:      2,
+	SeveritySuggestion: 3,
+}
+
+// effortRank orders efforts least work first.
+var effortRank = map[Effort]int{
+	EffortTrivial: 0,
+	EffortLow:     1,
+	EffortMedium:  2,
+	EffortHigh:    3,
+}
+
+// rank returns the position of s in severityRank, unknown severities last.
+func (s Severity) rank() int {
+	if r, ok := severityRank[s]; ok {
+		return r
+	}
+	return len(severityRank)
+}
+
+// rank returns the position of e in effortRank, unset efforts last.
+func (e Effort) rank() int {
+	if r, ok := effortRank[e]; ok {
+		return r
+	}
+	return len(effortRank)
+}
+
+// Issues returns the issues of all teams, in team order, aggregated by
+// AggregateIssues.
+func (r *TeamReport) Issues() []Issue {
+	var issues []Issue
+	for i := range r.Teams {
+		issues = append(issues, r.Teams[i].Issues...)
+	}
+	return AggregateIssues(issues)
+}
+
+// AggregateIssues deduplicates, sorts, and cross-links issues collected
+// from several evaluations. Issues with the same category and location
+// (or, without a location, the same category and problem) are merged into
+// the first: it takes the most serious severity, fills empty fields from
+// the duplicates, and references to a duplicate's ID are redirected to it.
+// The result is sorted by severity, most serious first, then by effort,
+// least first, keeping input order for ties. RelatedIssues are made
+// symmetric, limited to IDs in the result, and sorted. The input is not
+// modified.
+func AggregateIssues(issues []Issue) []Issue {
+	var out []Issue
+	index := make(map[string]int)      // dedup key -> position in out
+	aliases := make(map[string]string) // duplicate ID -> merged ID
+	for _, issue := range issues {
+		key := strings.ToLower(strings.TrimSpace(issue.Category)) + "\x00"
+		if issue.Location != "" {
+			key += "@" + issue.Location
+		} else {
+			key += strings.TrimSpace(issue.Problem)
+		}
+		i, ok := index[key]
+		if !ok {
+			issue.RelatedIssues = append([]string(nil), issue.RelatedIssues...)
+			index[key] = len(out)
+			out = append(out, issue)
+			continue
+		}
+		merged := &out[i]
+		if issue.Severity.rank() < merged.Severity.rank() {
+			merged.Severity = issue.Severity
+		}
+		if merged.Analysis == "" {
+			merged.Analysis = issue.Analysis
+		}
+		if merged.Recommendation == "" {
+			merged.Recommendation = issue.Recommendation
+		}
+		if merged.Example == "" {
+			merged.Example = issue.Example
+		}
+		if merged.Effort == "" {
+			merged.Effort = issue.Effort
+		}
+		merged.RelatedIssues = append(merged.RelatedIssues, issue.RelatedIssues...)
+		if issue.ID != "" && issue.ID != merged.ID {
+			aliases[issue.ID] = merged.ID
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if si, sj := out[i].Severity.rank(), out[j].Severity.rank(); si != sj {
+			return si < sj
+		}
+		return out[i].Effort.rank() < out[j].Effort.rank()
+	})
+
+	ids := make(map[string]bool, len(out))
+	for _, issue := range out {
+		ids[issue.ID] = true
+	}
+	related := make(map[string]map[string]bool)
+	link := func(a, b string) {
+		if related[a] == nil {
+			related[a] = make(map[string]bool)
+		}
+		related[a][b] = true
+	}
+	for _, issue := range out {
+		for _, id := range issue.RelatedIssues {
+			if alias, ok := aliases[id]; ok {
+				id = alias
+			}
+			if id != issue.ID && ids[id] {
+				link(issue.ID, id)
+				link(id, issue.ID)
+			}
+		}
+	}
+	for i := range out {
+		out[i].RelatedIssues = nil
+		for id := range related[out[i].ID] {
+			out[i].RelatedIssues = append(out[i].RelatedIssues, id)
+		}
+		sort.Strings(out[i].RelatedIssues)
+	}
+	return out
+}
+
+// IssuesBlock returns a condensed list of the report's aggregated issues,
+// one line per issue, for box output. It returns false if no team
+// reported issues.
+func (r *TeamReport) IssuesBlock() (ContentBlock, bool) {
+	return r.issuesBlock(nil)
+}
+
+// issuesBlock is IssuesBlock with file locations formatted by l.
+func (r *TeamReport) issuesBlock(l *LocationResolver) (ContentBlock, bool) {
+	issues := r.Issues()
+	if len(issues) == 0 {
+		return ContentBlock{}, false
+	}
+	items := make([]ListItem, len(issues))
+	for i, issue := range issues {
+		text := fmt.Sprintf("%s [%s] %s", issue.ID, issue.Severity, issue.Problem)
+		if issue.Location != "" {
+			text += " (" + l.Text(issue.Location) + ")"
+		}
+		items[i] = ListItem{Text: strings.TrimSpace(text), Status: issue.Severity.status()}
+	}
+	return NewListBlock("Issues", items...), true
+}
+
+// status returns the report status an issue of severity s implies: NO-GO
+// for critical, WARN for major, and none for minor issues and suggestions.
+func (s Severity) status() Status {
+	switch s {
+	case SeverityCritical:
+		return StatusNoGo
+	case SeverityMajor:
+		return StatusWarn
+	}
+	return ""
+}
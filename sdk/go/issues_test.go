@@ -0,0 +1,166 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func issuesReport() *TeamReport {
+	return &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Status:  StatusWarn,
+		Teams: []TeamSection{
+			{
+				ID: "pm", Name: "pm", Status: StatusWarn,
+				Issues: []Issue{
+					{ID: "PM-1", Category: "clarity", Severity: SeverityMinor, Problem: "Vague goal", Location: "goals[0]", Effort: EffortLow},
+					{ID: "PM-2", Category: "metrics", Severity: SeverityMajor, Problem: "No success metrics", Location: "metrics", Effort: EffortMedium, RelatedIssues: []string{"PM-1"}},
+				},
+			},
+			{
+				ID: "qa", Name: "qa", Status: StatusNoGo,
+				Issues: []Issue{
+					{ID: "QA-1", Category: "Metrics", Severity: SeverityCritical, Problem: "Metrics are not measurable", Location: "metrics", Recommendation: "Define targets."},
+					{ID: "QA-2", Category: "testing", Severity: SeverityMajor, Problem: "No rollback plan", Effort: EffortTrivial, RelatedIssues: []string{"QA-1", "ISS-404"}},
+				},
+			},
+		},
+	}
+}
+
+func TestAggregateIssues(t *testing.T) {
+	report := issuesReport()
+	issues := report.Issues()
+
+	var ids []string
+	for _, issue := range issues {
+		ids = append(ids, issue.ID)
+	}
+	// PM-2 and QA-1 share category and location: merged into PM-2.
+	if want := []string{"PM-2", "QA-2", "PM-1"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("issue order = %v, want %v", ids, want)
+	}
+
+	merged := issues[0]
+	if merged.Severity != SeverityCritical || merged.Effort != EffortMedium || merged.Recommendation != "Define targets." {
+		t.Errorf("merged issue = %+v", merged)
+	}
+	for id, want := range map[string][]string{
+		"PM-2": {"PM-1", "QA-2"},
+		"QA-2": {"PM-2"},
+		"PM-1": {"PM-2"},
+	} {
+		for _, issue := range issues {
+			if issue.ID == id && !reflect.DeepEqual(issue.RelatedIssues, want) {
+				t.Errorf("%s RelatedIssues = %v, want %v", id, issue.RelatedIssues, want)
+			}
+		}
+	}
+
+	if got := report.Teams[0].Issues[1]; got.Severity != SeverityMajor || len(got.RelatedIssues) != 1 {
+		t.Errorf("AggregateIssues modified its input: %+v", got)
+	}
+}
+
+func TestAggregateIssuesWithoutLocation(t *testing.T) {
+	issues := AggregateIssues([]Issue{
+		{ID: "A", Category: "style", Severity: SeverityMinor, Problem: "Passive voice"},
+		{ID: "B", Category: "style", Severity: SeverityMinor, Problem: "Passive voice", Example: "We ship it."},
+		{ID: "C", Category: "style", Severity: SeverityMinor, Problem: "Long sentences"},
+	})
+	if len(issues) != 2 || issues[0].ID != "A" || issues[0].Example != "We ship it." || issues[1].ID != "C" {
+		t.Errorf("issues = %+v", issues)
+	}
+	if AggregateIssues(nil) != nil {
+		t.Error("AggregateIssues(nil) != nil")
+	}
+}
+
+func TestIssuesBlock(t *testing.T) {
+	block, ok := issuesReport().IssuesBlock()
+	if !ok {
+		t.Fatal("IssuesBlock() = false")
+	}
+	if block.Type != ContentBlockList || block.Title != "Issues" || len(block.Items) != 3 {
+		t.Fatalf("block = %+v", block)
+	}
+	first := block.Items[0]
+	if first.Text != "PM-2 [critical] No success metrics (metrics)" || first.Status != StatusNoGo {
+		t.Errorf("first item = %+v", first)
+	}
+	if block.Items[2].Status != "" {
+		t.Errorf("minor issue status = %q, want none", block.Items[2].Status)
+	}
+
+	if _, ok := (&TeamReport{Teams: []TeamSection{{ID: "qa"}}}).IssuesBlock(); ok {
+		t.Error("IssuesBlock() = true without issues")
+	}
+}
+
+func TestRenderersIssues(t *testing.T) {
+	renders := map[string]func(*bytes.Buffer, *TeamReport) error{
+		"box":             func(b *bytes.Buffer, r *TeamReport) error { return NewRenderer(b).Render(r) },
+		"quick box":       func(b *bytes.Buffer, r *TeamReport) error { return NewQuickRenderer(b).Render(r) },
+		"narrative":       func(b *bytes.Buffer, r *TeamReport) error { return NewNarrativeRenderer(b).Render(r) },
+		"quick narrative": func(b *bytes.Buffer, r *TeamReport) error { return NewQuickNarrativeRenderer(b).Render(r) },
+	}
+	for name, render := range renders {
+		var buf bytes.Buffer
+		if err := render(&buf, issuesReport()); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out := buf.String()
+		if !strings.Contains(out, "Issues") || !strings.Contains(out, "No rollback plan") {
+			t.Errorf("%s output missing issues:\n%s", name, out)
+		}
+		if strings.Count(out, "No success metrics") != 1 || strings.Contains(out, "Metrics are not measurable") {
+			t.Errorf("%s output does not deduplicate issues:\n%s", name, out)
+		}
+
+		buf.Reset()
+		plain := &TeamReport{Project: "app", Version: "v1", Teams: []TeamSection{{ID: "qa", Name: "qa", Status: StatusGo}}}
+		if err := render(&buf, plain); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if strings.Contains(buf.String(), "Issues") {
+			t.Errorf("%s renders issues without any", name)
+		}
+	}
+}
+
+func TestNarrativeIssuesAppendix(t *testing.T) {
+	var text, quick bytes.Buffer
+	if err := NewNarrativeRenderer(&text).Render(issuesReport()); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewQuickNarrativeRenderer(&quick).Render(issuesReport()); err != nil {
+		t.Fatal(err)
+	}
+	out := text.String()
+	for _, want := range []string{
+		"## Issues\n\n### PM-2: No success metrics\n\n- **Severity**: critical\n- **Category**: metrics\n- **Location**: metrics\n- **Effort**: medium\n- **Related**: PM-1, QA-2\n\n**Recommendation**: Define targets.",
+		"### QA-2: No rollback plan",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("narrative output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(quick.String(), "### PM-2: No success metrics\n\n- **Severity**: critical") {
+		t.Errorf("quick narrative output missing issues appendix:\n%s", quick.String())
+	}
+}
+
+func TestIssuesSchema(t *testing.T) {
+	data, err := json.Marshal(issuesReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateTeamReportJSON(data); err != nil {
+		t.Errorf("schema rejects issues: %v", err)
+	}
+}
@@ -4,12 +4,23 @@ import (
 	"github.com/invopop/jsonschema"
 )
 
+// enumOf converts typed enum values into a JSON Schema enum list.
+// Enum lists are always derived from the Go constants so the generated
+// schemas cannot drift from the types.
+func enumOf[T ~string](values []T) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
 // JSONSchema implements jsonschema.Schema for Model type.
 func (Model) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"haiku", "sonnet", "opus"},
-		Default:     "sonnet",
+		Enum:        enumOf(Models()),
+		Default:     string(ModelSonnet),
 		Description: "Model capability tier (mapped to platform-specific models)",
 	}
 }
@@ -17,11 +28,8 @@ func (Model) JSONSchema() *jsonschema.Schema {
 // JSONSchema implements jsonschema.Schema for Tool type.
 func (Tool) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
-		Type: "string",
-		Enum: []interface{}{
-			"WebSearch", "WebFetch", "Read", "Write",
-			"Glob", "Grep", "Bash", "Edit", "Task",
-		},
+		Type:        "string",
+		Enum:        enumOf(Tools()),
 		Description: "Canonical tool name (mapped to platform-specific names)",
 	}
 }
@@ -30,32 +38,93 @@ func (Tool) JSONSchema() *jsonschema.Schema {
 func (TaskType) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"command", "pattern", "file", "manual"},
-		Default:     "manual",
+		Enum:        enumOf(TaskTypes()),
+		Default:     string(TaskTypeManual),
 		Description: "How the task is executed",
 	}
 }
 
+// JSONSchema implements jsonschema.Schema for MCPTransport type.
+func (MCPTransport) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(MCPTransports()),
+		Default:     string(MCPTransportStdio),
+		Description: "How the agent connects to the MCP server",
+	}
+}
+
+// JSONSchema implements jsonschema.Schema for ToolBindingType type.
+func (ToolBindingType) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(ToolBindingTypes()),
+		Description: "How a custom tool is executed",
+	}
+}
+
+// JSONSchema implements jsonschema.Schema for BudgetAction type.
+func (BudgetAction) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(BudgetActions()),
+		Default:     string(BudgetActionAbort),
+		Description: "What happens when the agent exceeds its budget",
+	}
+}
+
+// JSONSchema implements jsonschema.Schema for PIIType type.
+func (PIIType) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(PIITypes()),
+		Description: "Category of personally identifiable information to redact",
+	}
+}
+
+// JSONSchema implements jsonschema.Schema for MemoryType type.
+func (MemoryType) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(MemoryTypes()),
+		Description: "How long the agent remembers past interactions",
+	}
+}
+
+// JSONSchema implements jsonschema.Schema for MemoryBackend type.
+func (MemoryBackend) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(MemoryBackends()),
+		Description: "Where agent memory is stored",
+	}
+}
+
+// JSONSchema implements jsonschema.Schema for RefreshPolicy type.
+func (RefreshPolicy) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(RefreshPolicies()),
+		Default:     string(RefreshNever),
+		Description: "When a knowledge source is re-ingested",
+	}
+}
+
 // JSONSchema implements jsonschema.Schema for WorkflowType type.
 func (WorkflowType) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"sequential", "parallel", "dag", "orchestrated"},
-		Default:     "orchestrated",
-		Description: "Workflow execution pattern",
+		Enum:        enumOf(WorkflowTypes()),
+		Default:     string(WorkflowGraph),
+		Description: "Workflow execution pattern. Deterministic (schema controls): chain, scatter, graph. Self-directed (agents control): crew, swarm, council.",
 	}
 }
 
 // JSONSchema implements jsonschema.Schema for Platform type.
 func (Platform) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
-		Type: "string",
-		Enum: []interface{}{
-			"claude-code", "gemini-cli", "kiro-cli", "adk-go",
-			"crewai", "autogen", "aws-agentcore", "aws-eks",
-			"azure-aks", "gcp-gke", "kubernetes", "docker-compose",
-			"agentkit-local",
-		},
+		Type:        "string",
+		Enum:        enumOf(Platforms()),
 		Description: "Supported deployment platform",
 	}
 }
@@ -64,8 +133,8 @@ func (Platform) JSONSchema() *jsonschema.Schema {
 func (Priority) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"p1", "p2", "p3"},
-		Default:     "p2",
+		Enum:        enumOf(Priorities()),
+		Default:     string(PriorityP2),
 		Description: "Deployment priority level",
 	}
 }
@@ -74,7 +143,7 @@ func (Priority) JSONSchema() *jsonschema.Schema {
 func (DeploymentMode) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"single-process", "multi-process", "distributed", "serverless"},
+		Enum:        enumOf(DeploymentModes()),
 		Description: "Deployment execution mode",
 	}
 }
@@ -83,7 +152,7 @@ func (DeploymentMode) JSONSchema() *jsonschema.Schema {
 func (PortType) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"string", "number", "boolean", "object", "array", "file"},
+		Enum:        enumOf(PortTypes()),
 		Description: "Data type of a port",
 	}
 }
@@ -92,7 +161,7 @@ func (PortType) JSONSchema() *jsonschema.Schema {
 func (Status) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"GO", "NO-GO", "WARN", "SKIP"},
+		Enum:        enumOf(Statuses()),
 		Description: "Validation status",
 	}
 }
@@ -101,7 +170,167 @@ func (Status) JSONSchema() *jsonschema.Schema {
 func (ContentBlockType) JSONSchema() *jsonschema.Schema {
 	return &jsonschema.Schema{
 		Type:        "string",
-		Enum:        []interface{}{"kv_pairs", "list", "table", "text", "metric"},
+		Enum:        enumOf(ContentBlockTypes()),
 		Description: "Content block type discriminator",
 	}
 }
+
+// JSONSchema implements jsonschema.Schema for ChannelType type.
+func (ChannelType) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(ChannelTypes()),
+		Description: "Communication channel type",
+	}
+}
+
+// JSONSchema implements jsonschema.Schema for SecretSource type.
+func (SecretSource) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(SecretSources()),
+		Description: "Where a secret value is stored",
+	}
+}
+
+// findingSeverity stands in for the plain-string TaskResult.Severity so the
+// report schema defines its values. It is named Severity in the schema; the
+// Go Severity type is the severity of an LLM evaluation issue.
+type findingSeverity string
+
+// JSONSchemaName names the schema definition.
+func (findingSeverity) JSONSchemaName() string {
+	return "Severity"
+}
+
+// JSONSchema implements jsonschema.Schema for findingSeverity type.
+func (findingSeverity) JSONSchema() *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type:        "string",
+		Enum:        enumOf(severityOrder),
+		Description: "Finding severity/impact level. Orthogonal to status (status = pass/fail, severity = impact).",
+	}
+}
+
+// JSONSchemaName keeps the Go Severity type, whose values the Issue schema
+// lists inline, from resolving to the report schema's Severity definition.
+func (Severity) JSONSchemaName() string {
+	return "IssueSeverity"
+}
+
+// JSONSchemaProperty implements jsonschema property aliases for TaskResult.
+func (TaskResult) JSONSchemaProperty(prop string) any {
+	if prop == "severity" {
+		return findingSeverity("")
+	}
+	return nil
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Artifact type.
+func (Artifact) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.AnyOf = []*jsonschema.Schema{{Required: []string{"path"}}, {Required: []string{"url"}}}
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Target type.
+func (Target) JSONSchemaExtend(s *jsonschema.Schema) {
+	if secrets, ok := s.Properties.Get("secrets"); ok {
+		secrets.PropertyNames = &jsonschema.Schema{Pattern: "^[A-Za-z_][A-Za-z0-9_]*$"}
+	}
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Environment type.
+func (Environment) JSONSchemaExtend(s *jsonschema.Schema) {
+	if targets, ok := s.Properties.Get("targets"); ok {
+		targets.AdditionalProperties = &jsonschema.Schema{Type: "object"}
+	}
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Budget type.
+func (Budget) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Per-run cap on the agent's LLM usage; zero limits are unlimited"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for DelegationConfig type.
+func (DelegationConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Delegation permissions for self-directed workflows"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Example type.
+func (Example) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Few-shot example of an input and the expected output"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Guardrails type.
+func (Guardrails) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Restrictions on the agent's input, output, and fetched URLs"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for KnowledgeSource type.
+func (KnowledgeSource) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Corpus the agent grounds its answers on; exactly one of path, url, and vector_index is set"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Memory type.
+func (Memory) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "What the agent remembers between calls and where"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for RateLimit type.
+func (RateLimit) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Cap on LLM provider calls; zero limits are unlimited"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for CollaborationConfig type.
+func (CollaborationConfig) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Configuration for how agents interact in self-directed workflows"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for ConsensusRules type.
+func (ConsensusRules) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Rules for how agents reach agreement (council workflow)"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Channel type.
+func (Channel) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Communication channel between agents"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for SecretRef type.
+func (SecretRef) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Reference to a secret stored outside the spec"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for ToolSpec type.
+func (ToolSpec) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Non-canonical tool agents can list in their tools, e.g., a function-calling or MCP tool"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for ToolBinding type.
+func (ToolBinding) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "How the tool is executed"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Skill type.
+func (Skill) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Reusable capability that agents reference by name in their skills list"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for Rubric type.
+func (Rubric) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Versioned evaluation criteria that agents and evaluation tasks reference by name"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for RubricCategory type.
+func (RubricCategory) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "One scored dimension of a rubric"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for ScoreAnchor type.
+func (ScoreAnchor) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Description of content deserving a particular score"
+}
+
+// JSONSchemaExtend implements jsonschema.Schema for PromptTemplate type.
+func (PromptTemplate) JSONSchemaExtend(s *jsonschema.Schema) {
+	s.Description = "Named, versioned prompt template, such as an evaluator's system prompt, that evaluations reference as name@version"
+}
@@ -0,0 +1,178 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// enumLists are the value lists that back each enum type's JSONSchema.
+func enumLists() map[string][]string {
+	return map[string][]string{
+		"Model":            toStrings(Models()),
+		"Tool":             toStrings(Tools()),
+		"TaskType":         toStrings(TaskTypes()),
+		"MCPTransport":     toStrings(MCPTransports()),
+		"BudgetAction":     toStrings(BudgetActions()),
+		"PIIType":          toStrings(PIITypes()),
+		"MemoryType":       toStrings(MemoryTypes()),
+		"MemoryBackend":    toStrings(MemoryBackends()),
+		"RefreshPolicy":    toStrings(RefreshPolicies()),
+		"WorkflowType":     toStrings(WorkflowTypes()),
+		"PortType":         toStrings(PortTypes()),
+		"Platform":         toStrings(Platforms()),
+		"DeploymentMode":   toStrings(DeploymentModes()),
+		"Priority":         toStrings(Priorities()),
+		"Status":           toStrings(Statuses()),
+		"ContentBlockType": toStrings(ContentBlockTypes()),
+		"ChannelType":      toStrings(ChannelTypes()),
+		"ToolBindingType":  toStrings(ToolBindingTypes()),
+	}
+}
+
+func toStrings[T ~string](values []T) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = string(v)
+	}
+	return out
+}
+
+func sortedCopy(s []string) []string {
+	out := append([]string(nil), s...)
+	sort.Strings(out)
+	return out
+}
+
+// TestEnumListsCoverConstants parses the package source and verifies that
+// every typed string constant is included in its type's value list.
+func TestEnumListsCoverConstants(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("glob sources: %v", err)
+	}
+
+	lists := enumLists()
+	declared := make(map[string][]string)
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", file, err)
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				ident, ok := vs.Type.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if _, tracked := lists[ident.Name]; !tracked {
+					continue
+				}
+				for _, v := range vs.Values {
+					lit, ok := v.(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						continue
+					}
+					s, _ := strconv.Unquote(lit.Value)
+					declared[ident.Name] = append(declared[ident.Name], s)
+				}
+			}
+		}
+	}
+
+	for name, values := range lists {
+		got := strings.Join(sortedCopy(values), ",")
+		want := strings.Join(sortedCopy(declared[name]), ",")
+		if got != want {
+			t.Errorf("%s value list = [%s], constants = [%s]", name, got, want)
+		}
+	}
+}
+
+func TestJSONSchemaEnumsMatchValueLists(t *testing.T) {
+	schemas := map[string][]interface{}{
+		"Model":            Model("").JSONSchema().Enum,
+		"Tool":             Tool("").JSONSchema().Enum,
+		"TaskType":         TaskType("").JSONSchema().Enum,
+		"MCPTransport":     MCPTransport("").JSONSchema().Enum,
+		"BudgetAction":     BudgetAction("").JSONSchema().Enum,
+		"PIIType":          PIIType("").JSONSchema().Enum,
+		"MemoryType":       MemoryType("").JSONSchema().Enum,
+		"MemoryBackend":    MemoryBackend("").JSONSchema().Enum,
+		"RefreshPolicy":    RefreshPolicy("").JSONSchema().Enum,
+		"WorkflowType":     WorkflowType("").JSONSchema().Enum,
+		"PortType":         PortType("").JSONSchema().Enum,
+		"Platform":         Platform("").JSONSchema().Enum,
+		"DeploymentMode":   DeploymentMode("").JSONSchema().Enum,
+		"Priority":         Priority("").JSONSchema().Enum,
+		"Status":           Status("").JSONSchema().Enum,
+		"ContentBlockType": ContentBlockType("").JSONSchema().Enum,
+		"ChannelType":      ChannelType("").JSONSchema().Enum,
+		"ToolBindingType":  ToolBindingType("").JSONSchema().Enum,
+	}
+
+	for name, values := range enumLists() {
+		enum := schemas[name]
+		if len(enum) != len(values) {
+			t.Errorf("%s schema enum has %d values, want %d", name, len(enum), len(values))
+			continue
+		}
+		for i, v := range values {
+			if enum[i] != v {
+				t.Errorf("%s schema enum[%d] = %v, want %q", name, i, enum[i], v)
+			}
+		}
+	}
+}
+
+// TestCheckedInSchemasMatchValueLists guards the published schema files
+// against drifting from the Go constants.
+func TestCheckedInSchemasMatchValueLists(t *testing.T) {
+	files := map[string][]string{
+		"agent/agent.schema.json":           {"Model", "TaskType", "MCPTransport", "BudgetAction", "PIIType", "MemoryType", "MemoryBackend", "RefreshPolicy"},
+		"orchestration/team.schema.json":    {"WorkflowType", "PortType", "ChannelType"},
+		"deployment/deployment.schema.json": {"Platform", "DeploymentMode", "Priority"},
+		"report/team-report.schema.json":    {"Status", "ContentBlockType"},
+		"tool/tool.schema.json":             {"ToolBindingType"},
+	}
+
+	lists := enumLists()
+	for file, defs := range files {
+		path := filepath.Join("..", "..", "schema", filepath.FromSlash(file))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		var schema struct {
+			Defs map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"$defs"`
+		}
+		if err := json.Unmarshal(data, &schema); err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		for _, def := range defs {
+			got := strings.Join(sortedCopy(schema.Defs[def].Enum), ",")
+			want := strings.Join(sortedCopy(lists[def]), ",")
+			if got != want {
+				t.Errorf("%s $defs.%s enum = [%s], want [%s]", file, def, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RefreshPolicy is when a knowledge source is re-ingested.
+type RefreshPolicy string
+
+const (
+	// RefreshNever ingests the source once.
+	RefreshNever RefreshPolicy = "never"
+
+	// RefreshOnRun re-ingests the source at the start of every run.
+	RefreshOnRun RefreshPolicy = "on-run"
+
+	// RefreshInterval re-ingests the source every
+	// KnowledgeSource.RefreshInterval.
+	RefreshInterval RefreshPolicy = "interval"
+)
+
+// RefreshPolicies returns all refresh policies in schema order.
+func RefreshPolicies() []RefreshPolicy {
+	return []RefreshPolicy{RefreshNever, RefreshOnRun, RefreshInterval}
+}
+
+// KnowledgeSource is a corpus an agent grounds its answers on. Exactly one
+// of Path, URL, and VectorIndex is set.
+type KnowledgeSource struct {
+	// Name identifies the source within the agent.
+	Name string `json:"name" yaml:"name" jsonschema:"minLength=1" jsonschema_description:"Identifies the source within the agent"`
+
+	// Description tells the agent what the source holds and when to use it.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" jsonschema_description:"What the source holds and when to use it"`
+
+	// Path is a local file or directory. Relative paths are resolved
+	// against the directory of the agent file.
+	Path string `json:"path,omitempty" yaml:"path,omitempty" jsonschema_description:"Local file or directory, relative to the agent file"`
+
+	// URL is an http or https document or site.
+	URL string `json:"url,omitempty" yaml:"url,omitempty" jsonschema_description:"http or https document or site"`
+
+	// VectorIndex is the ID of an existing vector index or knowledge base
+	// on the deployment platform, e.g., a Bedrock knowledge base ID or a
+	// Vertex AI Search data store.
+	VectorIndex string `json:"vector_index,omitempty" yaml:"vector_index,omitempty" jsonschema_description:"ID of an existing vector index or knowledge base on the deployment platform"`
+
+	// Refresh is when the source is re-ingested (default: never).
+	Refresh RefreshPolicy `json:"refresh,omitempty" yaml:"refresh,omitempty"`
+
+	// RefreshInterval is the time between refreshes with the interval
+	// policy (e.g., 24h).
+	RefreshInterval string `json:"refresh_interval,omitempty" yaml:"refresh_interval,omitempty" jsonschema_description:"Time between refreshes with the interval policy (e.g., 24h)"`
+}
+
+// EffectiveRefresh returns the refresh policy, defaulting to never.
+func (k *KnowledgeSource) EffectiveRefresh() RefreshPolicy {
+	if k.Refresh == "" {
+		return RefreshNever
+	}
+	return k.Refresh
+}
+
+// Validate checks that the source has a name and exactly one reference,
+// that a URL is absolute http or https, and that the refresh policy is
+// known and has an interval exactly when it needs one.
+func (k *KnowledgeSource) Validate() error {
+	if k.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	refs := 0
+	for _, r := range []string{k.Path, k.URL, k.VectorIndex} {
+		if r != "" {
+			refs++
+		}
+	}
+	if refs != 1 {
+		return fmt.Errorf("exactly one of path, url, and vector_index is required")
+	}
+	if k.URL != "" {
+		u, err := url.Parse(k.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("url %q is not an absolute http or https URL", k.URL)
+		}
+	}
+	switch k.EffectiveRefresh() {
+	case RefreshNever, RefreshOnRun:
+		if k.RefreshInterval != "" {
+			return fmt.Errorf("refresh_interval requires refresh %s", RefreshInterval)
+		}
+	case RefreshInterval:
+		d, err := time.ParseDuration(k.RefreshInterval)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("refresh %s requires a positive refresh_interval, got %q", RefreshInterval, k.RefreshInterval)
+		}
+	default:
+		return fmt.Errorf("unknown refresh policy %q", k.Refresh)
+	}
+	return nil
+}
+
+// ValidateKnowledgeSources validates each of the agent's knowledge sources
+// and checks that their names are unique. When baseDir is not empty,
+// local paths must also exist, with relative paths resolved against it.
+func (a *Agent) ValidateKnowledgeSources(baseDir string) error {
+	seen := make(map[string]bool, len(a.KnowledgeSources))
+	for i := range a.KnowledgeSources {
+		k := &a.KnowledgeSources[i]
+		if err := k.Validate(); err != nil {
+			if k.Name == "" {
+				return fmt.Errorf("knowledge source %d: %w", i, err)
+			}
+			return fmt.Errorf("knowledge source %s: %w", k.Name, err)
+		}
+		if seen[k.Name] {
+			return fmt.Errorf("duplicate knowledge source %q", k.Name)
+		}
+		seen[k.Name] = true
+		if k.Path == "" || baseDir == "" {
+			continue
+		}
+		p := k.Path
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(baseDir, p)
+		}
+		if _, err := os.Stat(p); err != nil {
+			return fmt.Errorf("knowledge source %s: %w", k.Name, err)
+		}
+	}
+	return nil
+}
+
+// VectorIndexes returns the agent's vector index knowledge sources, for
+// generators of platforms with managed knowledge bases.
+func (a *Agent) VectorIndexes() []KnowledgeSource {
+	var out []KnowledgeSource
+	for _, k := range a.KnowledgeSources {
+		if k.VectorIndex != "" {
+			out = append(out, k)
+		}
+	}
+	return out
+}
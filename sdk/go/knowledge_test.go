@@ -0,0 +1,111 @@
+package multiagentspec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestKnowledgeSourceValidate(t *testing.T) {
+	valid := []KnowledgeSource{
+		{Name: "docs", Path: "docs/"},
+		{Name: "site", URL: "https://go.dev/doc/", Refresh: RefreshOnRun},
+		{Name: "kb", VectorIndex: "KB123", Refresh: RefreshInterval, RefreshInterval: "24h"},
+	}
+	for _, k := range valid {
+		if err := k.Validate(); err != nil {
+			t.Errorf("%+v: Validate() = %v", k, err)
+		}
+	}
+
+	tests := []struct {
+		k    KnowledgeSource
+		want string
+	}{
+		{KnowledgeSource{Path: "docs"}, "name is required"},
+		{KnowledgeSource{Name: "x"}, "exactly one of path, url, and vector_index"},
+		{KnowledgeSource{Name: "x", Path: "docs", URL: "https://go.dev"}, "exactly one of path, url, and vector_index"},
+		{KnowledgeSource{Name: "x", URL: "ftp://files.example.com"}, "not an absolute http or https URL"},
+		{KnowledgeSource{Name: "x", URL: "/docs"}, "not an absolute http or https URL"},
+		{KnowledgeSource{Name: "x", Path: "docs", Refresh: "hourly"}, `unknown refresh policy "hourly"`},
+		{KnowledgeSource{Name: "x", Path: "docs", Refresh: RefreshInterval}, "requires a positive refresh_interval"},
+		{KnowledgeSource{Name: "x", Path: "docs", RefreshInterval: "1h"}, "refresh_interval requires refresh interval"},
+	}
+	for _, tt := range tests {
+		err := tt.k.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: Validate() = %v, want %q", tt.k, err, tt.want)
+		}
+	}
+}
+
+func TestAgentValidateKnowledgeSources(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "docs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	a := &Agent{Name: "writer", KnowledgeSources: []KnowledgeSource{
+		{Name: "docs", Path: "docs"},
+		{Name: "kb", VectorIndex: "KB1"},
+	}}
+	if err := a.ValidateKnowledgeSources(dir); err != nil {
+		t.Errorf("ValidateKnowledgeSources: %v", err)
+	}
+	if got := a.VectorIndexes(); len(got) != 1 || got[0].Name != "kb" {
+		t.Errorf("VectorIndexes() = %+v", got)
+	}
+
+	a.KnowledgeSources = append(a.KnowledgeSources, KnowledgeSource{Name: "missing", Path: "nope"})
+	if err := a.ValidateKnowledgeSources(""); err != nil {
+		t.Errorf("ValidateKnowledgeSources without base dir checked paths: %v", err)
+	}
+	if err := a.ValidateKnowledgeSources(dir); err == nil || !strings.Contains(err.Error(), "knowledge source missing") {
+		t.Errorf("ValidateKnowledgeSources = %v, want missing path", err)
+	}
+
+	a.KnowledgeSources = []KnowledgeSource{{Name: "kb", VectorIndex: "A"}, {Name: "kb", VectorIndex: "B"}}
+	if err := a.ValidateKnowledgeSources(""); err == nil || !strings.Contains(err.Error(), `duplicate knowledge source "kb"`) {
+		t.Errorf("ValidateKnowledgeSources = %v, want duplicate", err)
+	}
+}
+
+func TestLoadAgentKnowledgeSources(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "guide.txt"), []byte("Guide\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	agentFile := filepath.Join(dir, "writer.md")
+	write := func(path string) {
+		t.Helper()
+		content := "---\nname: writer\nknowledge_sources:\n  - name: guide\n    path: " + path + "\n    refresh: on-run\n---\n\nWrite.\n"
+		if err := os.WriteFile(agentFile, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("guide.txt")
+	agent, err := LoadAgentFromFile(agentFile)
+	if err != nil {
+		t.Fatalf("LoadAgentFromFile: %v", err)
+	}
+	if len(agent.KnowledgeSources) != 1 || agent.KnowledgeSources[0].EffectiveRefresh() != RefreshOnRun {
+		t.Errorf("KnowledgeSources = %+v", agent.KnowledgeSources)
+	}
+
+	write("missing.txt")
+	if _, err := LoadAgentsFromDir(dir); err == nil || !strings.Contains(err.Error(), "knowledge source guide") {
+		t.Errorf("LoadAgentsFromDir = %v, want missing knowledge source", err)
+	}
+}
+
+func TestKnowledgeSourcesSchema(t *testing.T) {
+	agent := []byte(`{"name": "writer", "knowledge_sources": [{"name": "kb", "vector_index": "KB1", "refresh": "interval", "refresh_interval": "24h"}]}`)
+	if err := ValidateAgentJSON(agent); err != nil {
+		t.Errorf("ValidateAgentJSON: %v", err)
+	}
+	bad := []byte(`{"name": "writer", "knowledge_sources": [{"name": "kb", "refresh": "hourly"}]}`)
+	if err := ValidateAgentJSON(bad); err == nil {
+		t.Error("ValidateAgentJSON accepted unknown refresh policy")
+	}
+}
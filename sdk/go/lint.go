@@ -0,0 +1,257 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LintRule identifies the check a LintFinding failed.
+type LintRule string
+
+const (
+	// LintUnknownTool is a tools or allowedTools entry that is neither a
+	// canonical tool, a permission pattern on one, nor a custom tool.
+	LintUnknownTool LintRule = "unknown-tool"
+
+	// LintUnknownSkill is a skills entry no skill defines.
+	LintUnknownSkill LintRule = "unknown-skill"
+
+	// LintUnresolvedDependency is a dependencies entry that resolves to no
+	// agent.
+	LintUnresolvedDependency LintRule = "unresolved-dependency"
+
+	// LintEmptyInstructions is an agent without instructions.
+	LintEmptyInstructions LintRule = "empty-instructions"
+
+	// LintMissingExpectedOutput is a command task without expected_output,
+	// so its result cannot be judged.
+	LintMissingExpectedOutput LintRule = "missing-expected-output"
+
+	// LintUnknownDelegate is a delegation entry that resolves to no agent.
+	LintUnknownDelegate LintRule = "unknown-delegate"
+)
+
+// LintFinding is a problem LintAgent found in an agent.
+type LintFinding struct {
+	// Agent is the qualified name of the agent.
+	Agent string
+
+	// Rule is the check that failed.
+	Rule LintRule
+
+	// Field is the field holding the problem, e.g., tools or
+	// tasks[2].expected_output.
+	Field string
+
+	// Value is the offending entry, e.g., the unknown tool's name, or ""
+	// for a missing value.
+	Value string
+
+	// Suggestion is the likely intended value, e.g., "Read" for "read", or
+	// "" if there is none.
+	Suggestion string
+}
+
+// String describes the finding as "<agent>: <field>: <problem>".
+func (f LintFinding) String() string {
+	var problem string
+	switch f.Rule {
+	case LintUnknownTool:
+		problem = fmt.Sprintf("unknown tool %q", f.Value)
+	case LintUnknownSkill:
+		problem = fmt.Sprintf("unknown skill %q", f.Value)
+	case LintUnresolvedDependency:
+		problem = fmt.Sprintf("dependency %q does not resolve to an agent", f.Value)
+	case LintEmptyInstructions:
+		problem = "instructions are empty"
+	case LintMissingExpectedOutput:
+		problem = "command task has no expected_output"
+	case LintUnknownDelegate:
+		problem = fmt.Sprintf("unknown agent %q", f.Value)
+	default:
+		problem = string(f.Rule)
+	}
+	if f.Suggestion != "" {
+		problem += fmt.Sprintf(" (did you mean %q?)", f.Suggestion)
+	}
+	return fmt.Sprintf("%s: %s: %s", f.Agent, f.Field, problem)
+}
+
+// LintOption supplies LintAgent with the definitions an agent's references
+// are checked against.
+type LintOption func(*linter)
+
+// WithLintRegistry resolves dependencies and delegation entries against r.
+// Without it, they are not checked.
+func WithLintRegistry(r *AgentRegistry) LintOption {
+	return func(l *linter) {
+		l.registry = r
+	}
+}
+
+// WithLintSkills checks skills entries against skills. Without it, they
+// are not checked.
+func WithLintSkills(skills []*Skill) LintOption {
+	return func(l *linter) {
+		l.skills = make(map[string]bool, len(skills))
+		for _, s := range skills {
+			l.skills[s.Name] = true
+		}
+	}
+}
+
+// WithLintTools accepts the custom tools in tools in addition to the
+// canonical tools.
+func WithLintTools(tools []*ToolSpec) LintOption {
+	return func(l *linter) {
+		for _, t := range tools {
+			l.tools[t.Name] = true
+		}
+	}
+}
+
+type linter struct {
+	registry *AgentRegistry
+	skills   map[string]bool // nil: not checked
+	tools    map[string]bool // custom tools
+}
+
+// LintAgent checks an agent for problems that loading does not catch and
+// returns them in field order: unknown tools, skills that no skill
+// defines, dependencies that do not resolve, empty instructions, command
+// tasks without expected_output, and delegation entries naming unknown
+// agents. Checks needing definitions the agent refers to run only when
+// given them, e.g., with WithLintSkills.
+func LintAgent(a *Agent, opts ...LintOption) []LintFinding {
+	l := &linter{tools: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(l)
+	}
+	name := a.QualifiedName()
+	var findings []LintFinding
+	add := func(rule LintRule, field, value, suggestion string) {
+		findings = append(findings, LintFinding{Agent: name, Rule: rule, Field: field, Value: value, Suggestion: suggestion})
+	}
+
+	for _, field := range []struct {
+		name  string
+		tools []string
+	}{{"tools", a.Tools}, {"allowedTools", a.AllowedTools}} {
+		for _, tool := range field.tools {
+			if base, _, _ := strings.Cut(tool, "("); !isCanonicalTool(Tool(base)) && !l.tools[tool] {
+				add(LintUnknownTool, field.name, tool, suggest(tool, l.toolNames()))
+			}
+		}
+	}
+	if l.skills != nil {
+		for _, skill := range a.Skills {
+			if !l.skills[skill] {
+				add(LintUnknownSkill, "skills", skill, suggest(skill, mapKeys(l.skills)))
+			}
+		}
+	}
+	if l.registry != nil {
+		for _, dep := range a.Dependencies {
+			if _, err := l.registry.ResolveFrom(a, dep); err != nil {
+				add(LintUnresolvedDependency, "dependencies", dep, l.suggestAgent(dep))
+			}
+		}
+	}
+	if strings.TrimSpace(a.Instructions) == "" {
+		add(LintEmptyInstructions, "instructions", "", "")
+	}
+	for i, task := range a.Tasks {
+		if task.Type == TaskTypeCommand && strings.TrimSpace(task.ExpectedOutput) == "" {
+			add(LintMissingExpectedOutput, fmt.Sprintf("tasks[%d].expected_output", i), task.ID, "")
+		}
+	}
+	if l.registry != nil && a.Delegation != nil {
+		for _, field := range []struct {
+			name   string
+			agents []string
+		}{
+			{"delegation.can_delegate_to", a.Delegation.CanDelegateTo},
+			{"delegation.can_receive_from", a.Delegation.CanReceiveFrom},
+		} {
+			for _, entry := range field.agents {
+				if _, err := l.registry.ResolveFrom(a, entry); err != nil {
+					add(LintUnknownDelegate, field.name, entry, l.suggestAgent(entry))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// toolNames returns the canonical and custom tool names.
+func (l *linter) toolNames() []string {
+	names := mapKeys(l.tools)
+	for _, t := range Tools() {
+		names = append(names, string(t))
+	}
+	return names
+}
+
+// suggestAgent returns the registered agent name closest to entry.
+func (l *linter) suggestAgent(entry string) string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, a := range l.registry.Agents() {
+		for _, n := range []string{a.Name, a.QualifiedName()} {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+	}
+	name, _, _ := strings.Cut(entry, "@")
+	return suggest(name, names)
+}
+
+func mapKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// suggest returns the candidate closest to name: one differing only in
+// case, or else the nearest within an edit distance of 2, preferring the
+// first in sorted order on ties. It returns "" if there is none.
+func suggest(name string, candidates []string) string {
+	best, bestDist := "", 3
+	for _, c := range candidates {
+		if c == name {
+			continue
+		}
+		if strings.EqualFold(c, name) {
+			return c
+		}
+		if d := editDistance(strings.ToLower(name), strings.ToLower(c)); d < bestDist || d == bestDist && best != "" && c < best {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
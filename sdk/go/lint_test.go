@@ -0,0 +1,110 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLintAgent(t *testing.T) {
+	helper := &Agent{Name: "helper", Instructions: "Help."}
+	reviewer := &Agent{Name: "reviewer", Namespace: "shared", Instructions: "Review."}
+	agent := &Agent{
+		Name:         "builder",
+		Tools:        []string{"Read", "Bash(git:*)", "read", "deploy", "Frobnicate"},
+		AllowedTools: []string{"Grep", "Glob(*.go)", "Wirte"},
+		Skills:       []string{"coding", "codng"},
+		Dependencies: []string{"helper", "helpr@^1.0.0", "shared/reviewer"},
+		Tasks: []Task{
+			{ID: "build", Type: TaskTypeCommand, Command: "go build ./...", ExpectedOutput: "exit 0"},
+			{ID: "test", Type: TaskTypeCommand, Command: "go test ./..."},
+			{ID: "check", Type: TaskTypeManual},
+		},
+		Delegation: &DelegationConfig{
+			AllowDelegation: true,
+			CanDelegateTo:   []string{"helper", "shared/reviewr"},
+			CanReceiveFrom:  []string{"nobody-like-this"},
+		},
+	}
+	registry, err := NewAgentRegistry([]*Agent{helper, reviewer, agent})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := LintAgent(agent,
+		WithLintRegistry(registry),
+		WithLintSkills([]*Skill{{Name: "coding"}}),
+		WithLintTools([]*ToolSpec{{Name: "deploy"}}),
+	)
+	want := []LintFinding{
+		{Agent: "builder", Rule: LintUnknownTool, Field: "tools", Value: "read", Suggestion: "Read"},
+		{Agent: "builder", Rule: LintUnknownTool, Field: "tools", Value: "Frobnicate"},
+		{Agent: "builder", Rule: LintUnknownTool, Field: "allowedTools", Value: "Wirte", Suggestion: "Write"},
+		{Agent: "builder", Rule: LintUnknownSkill, Field: "skills", Value: "codng", Suggestion: "coding"},
+		{Agent: "builder", Rule: LintUnresolvedDependency, Field: "dependencies", Value: "helpr@^1.0.0", Suggestion: "helper"},
+		{Agent: "builder", Rule: LintEmptyInstructions, Field: "instructions"},
+		{Agent: "builder", Rule: LintMissingExpectedOutput, Field: "tasks[1].expected_output", Value: "test"},
+		{Agent: "builder", Rule: LintUnknownDelegate, Field: "delegation.can_delegate_to", Value: "shared/reviewr", Suggestion: "shared/reviewer"},
+		{Agent: "builder", Rule: LintUnknownDelegate, Field: "delegation.can_receive_from", Value: "nobody-like-this"},
+	}
+	if !reflect.DeepEqual(findings, want) {
+		t.Errorf("findings =\n%v\nwant\n%v", findings, want)
+	}
+
+	// Without definitions, only the self-contained checks run.
+	findings = LintAgent(agent)
+	var rules []LintRule
+	for _, f := range findings {
+		rules = append(rules, f.Rule)
+	}
+	wantRules := []LintRule{LintUnknownTool, LintUnknownTool, LintUnknownTool, LintUnknownTool, LintEmptyInstructions, LintMissingExpectedOutput}
+	if !reflect.DeepEqual(rules, wantRules) {
+		t.Errorf("rules without definitions = %v, want %v", rules, wantRules)
+	}
+
+	if findings := LintAgent(helper, WithLintRegistry(registry)); len(findings) != 0 {
+		t.Errorf("findings for a clean agent = %v", findings)
+	}
+}
+
+func TestLintFindingString(t *testing.T) {
+	tests := []struct {
+		finding LintFinding
+		want    string
+	}{
+		{LintFinding{Agent: "shared/lead", Rule: LintUnknownTool, Field: "tools", Value: "read", Suggestion: "Read"},
+			`shared/lead: tools: unknown tool "read" (did you mean "Read"?)`},
+		{LintFinding{Agent: "lead", Rule: LintEmptyInstructions, Field: "instructions"},
+			"lead: instructions: instructions are empty"},
+		{LintFinding{Agent: "lead", Rule: LintMissingExpectedOutput, Field: "tasks[0].expected_output", Value: "test"},
+			"lead: tasks[0].expected_output: command task has no expected_output"},
+	}
+	for _, tt := range tests {
+		if got := tt.finding.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"Read", "Write", "Grep", "Glob"}
+	tests := map[string]string{
+		"read":     "Read",
+		"Raed":     "Read",
+		"Glb":      "Glob",
+		"Deploy":   "",
+		"Read":     "",
+		"WebFetch": "",
+	}
+	for name, want := range tests {
+		if got := suggest(name, candidates); got != want {
+			t.Errorf("suggest(%q) = %q, want %q", name, got, want)
+		}
+	}
+	if got := editDistance("kitten", "sitting"); got != 3 {
+		t.Errorf("editDistance = %d, want 3", got)
+	}
+	if !strings.EqualFold(suggest("GREP", candidates), "grep") {
+		t.Error("suggest did not match case-insensitively")
+	}
+}
@@ -1,5 +1,7 @@
 package multiagentspec
 
+import "context"
+
 // EvaluationType discriminates between rule-based and LLM evaluation.
 type EvaluationType string
 
@@ -56,6 +58,13 @@ type LLMEvaluation struct {
 	PromptVersion string `json:"promptVersion,omitempty"`
 }
 
+// Evaluator produces an LLM evaluation of content against a rubric, the
+// evaluation criteria as prompt text. Implementations for Anthropic,
+// OpenAI, and Bedrock are in the evaluator package.
+type Evaluator interface {
+	Evaluate(ctx context.Context, rubric, content string) (*LLMEvaluation, error)
+}
+
 // CombinedWeights specifies weighting for combined rule + LLM evaluation.
 type CombinedWeights struct {
 	// Rule is the weight for rule-based score (0-1).
@@ -69,35 +78,35 @@ type CombinedWeights struct {
 // Used in narrative reports for detailed fix guidance.
 type Issue struct {
 	// ID is the issue identifier (e.g., "ISS-001").
-	ID string `json:"id"`
+	ID string `json:"id" jsonschema_description:"Issue identifier (e.g., ISS-001)"`
 
 	// Category is the evaluation category this issue belongs to.
-	Category string `json:"category"`
+	Category string `json:"category" jsonschema_description:"Evaluation category the issue belongs to"`
 
 	// Severity indicates how serious the issue is.
-	Severity Severity `json:"severity"`
+	Severity Severity `json:"severity" jsonschema:"enum=critical,enum=major,enum=minor,enum=suggestion" jsonschema_description:"How serious the issue is"`
 
 	// Problem describes what the issue is.
-	Problem string `json:"problem"`
+	Problem string `json:"problem" jsonschema_description:"What the issue is"`
 
 	// Location indicates where in the document the issue occurs
 	// (e.g., "requirements.functional[2]", "executiveSummary.problemStatement").
-	Location string `json:"location,omitempty"`
+	Location string `json:"location,omitempty" jsonschema_description:"Where in the document the issue occurs (e.g., requirements.functional[2])"`
 
 	// Analysis explains why this is a problem.
-	Analysis string `json:"analysis,omitempty"`
+	Analysis string `json:"analysis,omitempty" jsonschema_description:"Why this is a problem"`
 
 	// Recommendation describes how to fix the issue.
-	Recommendation string `json:"recommendation,omitempty"`
+	Recommendation string `json:"recommendation,omitempty" jsonschema_description:"How to fix the issue"`
 
 	// Example provides sample improved text or structure.
-	Example string `json:"example,omitempty"`
+	Example string `json:"example,omitempty" jsonschema_description:"Sample improved text or structure"`
 
 	// Effort estimates the work required to fix this issue.
-	Effort Effort `json:"effort,omitempty"`
+	Effort Effort `json:"effort,omitempty" jsonschema:"enum=trivial,enum=low,enum=medium,enum=high" jsonschema_description:"Estimated work to fix the issue"`
 
 	// RelatedIssues lists IDs of related issues.
-	RelatedIssues []string `json:"relatedIssues,omitempty"`
+	RelatedIssues []string `json:"relatedIssues,omitempty" jsonschema_description:"IDs of related issues"`
 }
 
 // Severity indicates how serious an issue is.
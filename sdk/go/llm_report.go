@@ -0,0 +1,99 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ContentBlocks converts the evaluation into report content: a metric
+// block for the score with its status under thresholds, a text block for
+// the reasoning, and list blocks for strengths, concerns, and suggestions.
+// Empty sections are omitted.
+func (e *LLMEvaluation) ContentBlocks(thresholds ScoreThresholds) ([]ContentBlock, error) {
+	score, err := e.NormalizedScore()
+	if err != nil {
+		return nil, err
+	}
+	target := "≥ " + formatScore(thresholds.Go) + "/" + strconv.Itoa(DefaultLLMMaxScore)
+	blocks := []ContentBlock{
+		NewMetricBlock("LLM score", e.scoreText(), thresholds.Status(score), target),
+	}
+	if e.Reasoning != "" {
+		blocks = append(blocks, NewTextBlock("Reasoning", e.Reasoning))
+	}
+	for _, section := range []struct {
+		title string
+		texts []string
+		item  ListItem
+	}{
+		{"Strengths", e.Strengths, ListItem{Status: StatusGo}},
+		{"Concerns", e.Concerns, ListItem{Status: StatusWarn}},
+		{"Suggestions", e.Suggestions, ListItem{Icon: "\U0001F4A1"}}, // 💡
+	} {
+		if len(section.texts) == 0 {
+			continue
+		}
+		items := make([]ListItem, len(section.texts))
+		for i, text := range section.texts {
+			items[i] = section.item
+			items[i].Text = text
+		}
+		blocks = append(blocks, NewListBlock(section.title, items...))
+	}
+	return blocks, nil
+}
+
+// TaskResult converts the evaluation into a task result with the given id.
+// Status is the score mapped through thresholds, Detail is the score and
+// reasoning, and Metadata records the score, confidence, model, and
+// prompt version.
+func (e *LLMEvaluation) TaskResult(id string, thresholds ScoreThresholds) (TaskResult, error) {
+	score, err := e.NormalizedScore()
+	if err != nil {
+		return TaskResult{}, fmt.Errorf("task %s: %w", id, err)
+	}
+	task := TaskResult{
+		ID:     id,
+		Status: thresholds.Status(score),
+		Detail: e.scoreText(),
+		Metadata: map[string]interface{}{
+			"evaluation_type": string(EvaluationTypeLLM),
+			"llm_score":       score,
+		},
+	}
+	if e.Reasoning != "" {
+		task.Detail += ": " + e.Reasoning
+	}
+	if e.Confidence > 0 {
+		task.Metadata["confidence"] = e.Confidence
+	}
+	if e.Model != "" {
+		task.Metadata["model"] = e.Model
+	}
+	if e.Provider != "" {
+		task.Metadata["provider"] = e.Provider
+	}
+	if e.PromptVersion != "" {
+		task.Metadata["prompt_version"] = e.PromptVersion
+	}
+	if e.TokensUsed > 0 {
+		task.Metadata["tokens_used"] = e.TokensUsed
+	}
+	if e.LatencyMs > 0 {
+		task.DurationMs = int64(e.LatencyMs)
+	}
+	return task, nil
+}
+
+// scoreText returns the score on its own scale, e.g., "7.5/10".
+func (e *LLMEvaluation) scoreText() string {
+	max := e.MaxScore
+	if max == 0 {
+		max = DefaultLLMMaxScore
+	}
+	return formatScore(e.Score) + "/" + formatScore(max)
+}
+
+func formatScore(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,92 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func testLLMEvaluation() *LLMEvaluation {
+	return &LLMEvaluation{
+		Score:         6.5,
+		Confidence:    0.8,
+		Reasoning:     "Clear goals, no rollback plan.",
+		Strengths:     []string{"clear goals"},
+		Concerns:      []string{"no rollback plan", "metrics undefined"},
+		Model:         "claude-sonnet-4-0",
+		Provider:      "anthropic",
+		TokensUsed:    380,
+		LatencyMs:     1200,
+		PromptVersion: "mas-eval-v1",
+	}
+}
+
+func TestLLMEvaluationContentBlocks(t *testing.T) {
+	blocks, err := testLLMEvaluation().ContentBlocks(DefaultScoreThresholds)
+	if err != nil {
+		t.Fatalf("ContentBlocks: %v", err)
+	}
+	if len(blocks) != 4 {
+		t.Fatalf("got %d blocks, want metric, reasoning, strengths, concerns", len(blocks))
+	}
+	metric := blocks[0]
+	if metric.Type != ContentBlockMetric || metric.Value != "6.5/10" || metric.Status != StatusWarn || metric.Target != "≥ 7/10" {
+		t.Errorf("metric = %+v", metric)
+	}
+	if blocks[1].Type != ContentBlockText || blocks[1].Content != "Clear goals, no rollback plan." {
+		t.Errorf("reasoning = %+v", blocks[1])
+	}
+	concerns := blocks[3]
+	if concerns.Title != "Concerns" || len(concerns.Items) != 2 || concerns.Items[1].Text != "metrics undefined" || concerns.Items[1].Status != StatusWarn {
+		t.Errorf("concerns = %+v", concerns)
+	}
+
+	eval := &LLMEvaluation{Score: 90, MaxScore: 100, Suggestions: []string{"add a glossary"}}
+	blocks, err = eval.ContentBlocks(DefaultScoreThresholds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks) != 2 || blocks[0].Value != "90/100" || blocks[0].Status != StatusGo || blocks[1].Items[0].EffectiveIcon() != "\U0001F4A1" {
+		t.Errorf("blocks = %+v", blocks)
+	}
+
+	if _, err := (&LLMEvaluation{Score: 11}).ContentBlocks(DefaultScoreThresholds); err == nil {
+		t.Error("expected error for out-of-range score")
+	}
+}
+
+func TestLLMEvaluationTaskResult(t *testing.T) {
+	task, err := testLLMEvaluation().TaskResult("prd-quality", ScoreThresholds{Go: 6, Warn: 4})
+	if err != nil {
+		t.Fatalf("TaskResult: %v", err)
+	}
+	if task.ID != "prd-quality" || task.Status != StatusGo || task.DurationMs != 1200 {
+		t.Errorf("task = %+v", task)
+	}
+	if task.Detail != "6.5/10: Clear goals, no rollback plan." {
+		t.Errorf("Detail = %q", task.Detail)
+	}
+	for key, want := range map[string]interface{}{
+		"evaluation_type": "llm",
+		"llm_score":       6.5,
+		"model":           "claude-sonnet-4-0",
+		"prompt_version":  "mas-eval-v1",
+		"tokens_used":     380,
+	} {
+		if task.Metadata[key] != want {
+			t.Errorf("Metadata[%s] = %v, want %v", key, task.Metadata[key], want)
+		}
+	}
+
+	task, err = (&LLMEvaluation{Score: 2}).TaskResult("x", DefaultScoreThresholds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.Status != StatusNoGo || task.Detail != "2/10" || len(task.Metadata) != 2 {
+		t.Errorf("task = %+v", task)
+	}
+
+	_, err = (&LLMEvaluation{Score: -1}).TaskResult("x", DefaultScoreThresholds)
+	if err == nil || !strings.Contains(err.Error(), "task x: llm score -1") {
+		t.Errorf("TaskResult error = %v", err)
+	}
+}
@@ -1,27 +1,48 @@
 package multiagentspec
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"gopkg.in/yaml.v3"
 )
 
 // Loader loads multi-agent-spec definitions from files.
-type Loader struct{}
+type Loader struct {
+	logger    *slog.Logger
+	agentOpts []AgentParseOption
+}
 
 // LoaderOption configures the loader.
 type LoaderOption func(*Loader)
 
+// WithLogger sets the logger the loader reports loaded files to, at debug
+// level. By default nothing is logged.
+func WithLogger(logger *slog.Logger) LoaderOption {
+	return func(l *Loader) {
+		l.logger = logger
+	}
+}
+
+// WithAgentParseOptions sets the options agent markdown files are parsed
+// with, e.g., AllowMissingFrontmatter.
+func WithAgentParseOptions(opts ...AgentParseOption) LoaderOption {
+	return func(l *Loader) {
+		l.agentOpts = opts
+	}
+}
+
 // NewLoader creates a new loader with the given options.
 func NewLoader(opts ...LoaderOption) *Loader {
-	l := &Loader{}
+	l := &Loader{logger: discardLogger}
 	for _, opt := range opts {
 		opt(l)
 	}
@@ -30,17 +51,115 @@ func NewLoader(opts ...LoaderOption) *Loader {
 
 // LoadTeam loads a Team from a JSON file.
 func (l *Loader) LoadTeam(path string) (*Team, error) {
-	return LoadTeamFromFile(path)
+	team, err := LoadTeamFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l.logger.Debug("loaded team", "path", path, "team", team.Name, "agents", len(team.Agents))
+	return team, nil
 }
 
 // LoadAgent loads an Agent from a markdown file.
 func (l *Loader) LoadAgent(path string) (*Agent, error) {
-	return LoadAgentFromFile(path)
+	agent, err := LoadAgentFromFile(path, l.agentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	l.logger.Debug("loaded agent", "path", path, "agent", agent.QualifiedName())
+	return agent, nil
+}
+
+// LoadAgentsFromDir loads all Agent definitions under dir, as the
+// LoadAgentsFromDir function does.
+func (l *Loader) LoadAgentsFromDir(dir string) ([]*Agent, error) {
+	agents, err := LoadAgentsFromDir(dir, l.agentOpts...)
+	for _, agent := range agents {
+		l.logger.Debug("loaded agent", "dir", dir, "agent", agent.QualifiedName())
+	}
+	return agents, err
+}
+
+// ResolveTeamAgents resolves the team's agent entries against agents, as
+// Team.ResolveAgents does, and reports the revision each entry resolved to.
+// References to deprecated agents are logged as warnings.
+func (l *Loader) ResolveTeamAgents(team *Team, agents []*Agent) ([]*Agent, error) {
+	resolved, err := team.ResolveAgents(agents)
+	if err != nil {
+		return nil, err
+	}
+	for i, agent := range resolved {
+		l.logger.Debug("resolved team agent", "team", team.Name, "entry", team.Agents[i], "agent", agent.QualifiedName(), "version", agent.Version)
+	}
+	refs, err := team.DeprecatedReferences(agents)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		l.logger.Warn("reference to deprecated agent", "referrer", ref.Referrer, "field", ref.Field, "agent", ref.Agent.QualifiedName(), "replaced_by", ref.Agent.ReplacedBy)
+	}
+	return resolved, nil
+}
+
+// LoadSkillsFromDir loads all Skill definitions under dir, as the
+// LoadSkillsFromDir function does.
+func (l *Loader) LoadSkillsFromDir(dir string) ([]*Skill, error) {
+	skills, err := LoadSkillsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, skill := range skills {
+		l.logger.Debug("loaded skill", "dir", dir, "skill", skill.Name)
+	}
+	return skills, nil
+}
+
+// LoadToolsFromDir loads all ToolSpec definitions under dir, as the
+// LoadToolsFromDir function does.
+func (l *Loader) LoadToolsFromDir(dir string) ([]*ToolSpec, error) {
+	tools, err := LoadToolsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, tool := range tools {
+		l.logger.Debug("loaded tool", "dir", dir, "tool", tool.Name)
+	}
+	return tools, nil
+}
+
+// LoadRubricsFromDir loads all Rubric definitions under dir, as the
+// LoadRubricsFromDir function does.
+func (l *Loader) LoadRubricsFromDir(dir string) ([]*Rubric, error) {
+	rubrics, err := LoadRubricsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, rubric := range rubrics {
+		l.logger.Debug("loaded rubric", "dir", dir, "rubric", rubric.Name, "version", rubric.Version)
+	}
+	return rubrics, nil
+}
+
+// LoadPromptsFromDir loads all PromptTemplate definitions under dir, as
+// the LoadPromptsFromDir function does.
+func (l *Loader) LoadPromptsFromDir(dir string) ([]*PromptTemplate, error) {
+	prompts, err := LoadPromptsFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, prompt := range prompts {
+		l.logger.Debug("loaded prompt", "dir", dir, "prompt", prompt.PromptVersion())
+	}
+	return prompts, nil
 }
 
 // LoadDeployment loads a Deployment from a JSON file.
 func (l *Loader) LoadDeployment(path string) (*Deployment, error) {
-	return LoadDeploymentFromFile(path)
+	dep, err := LoadDeploymentFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	l.logger.Debug("loaded deployment", "path", path, "team", dep.Team, "targets", len(dep.Targets))
+	return dep, nil
 }
 
 // LoadAgentFromFile loads an Agent from a markdown file with YAML frontmatter.
@@ -60,25 +179,123 @@ func (l *Loader) LoadDeployment(path string) (*Deployment, error) {
 //	# Agent Name
 //
 //	Instructions in markdown...
-func LoadAgentFromFile(path string) (*Agent, error) {
+//
+// The frontmatter may also be TOML between "+++" lines. With
+// AllowMissingFrontmatter, a file without frontmatter is an agent
+// named after the file.
+func LoadAgentFromFile(path string, opts ...AgentParseOption) (*Agent, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("read file %s: %w", path, err)
 	}
 
-	return ParseAgentMarkdown(data)
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	agent, err := ParseAgentMarkdown(data, append([]AgentParseOption{withAgentName(name)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if agent.Version != "" {
+		if _, err := ParseVersion(agent.Version); err != nil {
+			return nil, fmt.Errorf("validate %s: %w", path, err)
+		}
+	}
+	if err := agent.ValidateDeprecation(); err != nil {
+		return nil, fmt.Errorf("validate %s: %w", path, err)
+	}
+	if err := agent.ValidateKnowledgeSources(filepath.Dir(path)); err != nil {
+		return nil, fmt.Errorf("validate %s: %w", path, err)
+	}
+	return agent, nil
+}
+
+// AgentParseOption configures ParseAgentMarkdown and the functions that
+// load agents from markdown files.
+type AgentParseOption func(*agentParseOptions)
+
+type agentParseOptions struct {
+	allowMissingFrontmatter bool
+	continueOnError         bool
+
+	// name names agents without frontmatter.
+	name string
 }
 
-// ParseAgentMarkdown parses an Agent from markdown bytes with YAML frontmatter.
-func ParseAgentMarkdown(data []byte) (*Agent, error) {
-	frontmatter, body, err := splitFrontmatter(data)
-	if err != nil {
-		return nil, fmt.Errorf("parse frontmatter: %w", err)
+// AllowMissingFrontmatter parses markdown without YAML frontmatter as an
+// agent with instructions only, instead of failing. Agents loaded from
+// files are named after the file, e.g., "reviewer" for reviewer.md.
+func AllowMissingFrontmatter() AgentParseOption {
+	return func(o *agentParseOptions) {
+		o.allowMissingFrontmatter = true
+	}
+}
+
+// ContinueOnError makes LoadAgentsFromDir and LoadAgentsFromDirFlat load
+// every file they can instead of stopping at the first bad one. They
+// return the agents loaded along with a LoadErrors of the files that
+// failed.
+func ContinueOnError() AgentParseOption {
+	return func(o *agentParseOptions) {
+		o.continueOnError = true
+	}
+}
+
+// FileError is an error loading the file at Path. Its message is Err's,
+// which names the file.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string { return e.Err.Error() }
+
+func (e *FileError) Unwrap() error { return e.Err }
+
+// LoadErrors reports the files a directory loader could not load, in the
+// order it read them.
+type LoadErrors []*FileError
+
+func (e LoadErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the file errors, for errors.Is and errors.As.
+func (e LoadErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// withAgentName names agents parsed without frontmatter.
+func withAgentName(name string) AgentParseOption {
+	return func(o *agentParseOptions) {
+		o.name = name
+	}
+}
+
+// ParseAgentMarkdown parses an Agent from markdown bytes with YAML
+// frontmatter, or TOML frontmatter between "+++" lines. A UTF-8 byte order
+// mark and CRLF line endings are accepted, and syntax errors report the
+// line they occurred on.
+func ParseAgentMarkdown(data []byte, opts ...AgentParseOption) (*Agent, error) {
+	var o agentParseOptions
+	for _, opt := range opts {
+		opt(&o)
 	}
 
 	var agent Agent
-	if err := yaml.Unmarshal(frontmatter, &agent); err != nil {
-		return nil, fmt.Errorf("parse yaml: %w", err)
+	body, err := unmarshalFrontmatter(data, &agent)
+	if errors.Is(err, errNoFrontmatter) && o.allowMissingFrontmatter {
+		text := bytes.ReplaceAll(bytes.TrimPrefix(data, utf8BOM), []byte("\r\n"), []byte("\n"))
+		return &Agent{Name: o.name, Instructions: strings.TrimSpace(string(text))}, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	// Set instructions from markdown body
@@ -87,10 +304,36 @@ func ParseAgentMarkdown(data []byte) (*Agent, error) {
 	return &agent, nil
 }
 
+// MarshalAgentMarkdown renders an Agent as markdown with YAML frontmatter,
+// the format read by ParseAgentMarkdown. The namespace is left out, since
+// LoadAgentsFromDir derives it from the file's subdirectory.
+func MarshalAgentMarkdown(a *Agent) ([]byte, error) {
+	fm := *a
+	fm.Namespace = ""
+	fm.Instructions = ""
+	header, err := yaml.Marshal(&fm)
+	if err != nil {
+		return nil, fmt.Errorf("marshal frontmatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(header)
+	buf.WriteString("---\n")
+	if instructions := strings.TrimSpace(a.Instructions); instructions != "" {
+		buf.WriteString("\n")
+		buf.WriteString(instructions)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
 // LoadAgentsFromDir loads all Agent definitions from a directory.
 // It recursively scans subdirectories. Agents in subdirectories have their
 // namespace set to the subdirectory name (relative to the root dir), unless
-// an explicit namespace is specified in the agent's frontmatter.
+// an explicit namespace is specified in the agent's frontmatter. With
+// ContinueOnError, bad files are reported in a LoadErrors rather than
+// ending the walk.
 //
 // Example structure:
 //
@@ -100,11 +343,21 @@ func ParseAgentMarkdown(data []byte) (*Agent, error) {
 //	├── prd/
 //	│   └── lead.md            → namespace: "prd", name: "lead"
 //	└── orchestrator.md        → namespace: "", name: "orchestrator"
-func LoadAgentsFromDir(dir string) ([]*Agent, error) {
+func LoadAgentsFromDir(dir string, opts ...AgentParseOption) ([]*Agent, error) {
+	var o agentParseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var agents []*Agent
+	var loadErrs LoadErrors
 
 	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
+			if o.continueOnError {
+				loadErrs = append(loadErrs, &FileError{Path: path, Err: err})
+				return nil
+			}
 			return err
 		}
 
@@ -118,8 +371,12 @@ func LoadAgentsFromDir(dir string) ([]*Agent, error) {
 			return nil
 		}
 
-		agent, err := LoadAgentFromFile(path)
+		agent, err := LoadAgentFromFile(path, opts...)
 		if err != nil {
+			if o.continueOnError {
+				loadErrs = append(loadErrs, &FileError{Path: path, Err: err})
+				return nil
+			}
 			return fmt.Errorf("load %s: %w", path, err)
 		}
 
@@ -144,6 +401,9 @@ func LoadAgentsFromDir(dir string) ([]*Agent, error) {
 	if err != nil {
 		return nil, fmt.Errorf("walk dir %s: %w", dir, err)
 	}
+	if len(loadErrs) > 0 {
+		return agents, loadErrs
+	}
 
 	return agents, nil
 }
@@ -151,13 +411,19 @@ func LoadAgentsFromDir(dir string) ([]*Agent, error) {
 // LoadAgentsFromDirFlat loads agents from a single directory without recursion.
 // This preserves the original non-recursive behavior for cases where
 // subdirectories should be ignored.
-func LoadAgentsFromDirFlat(dir string) ([]*Agent, error) {
+func LoadAgentsFromDirFlat(dir string, opts ...AgentParseOption) ([]*Agent, error) {
+	var o agentParseOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("read dir %s: %w", dir, err)
 	}
 
 	var agents []*Agent
+	var loadErrs LoadErrors
 	for _, entry := range entries {
 		if entry.IsDir() {
 			continue
@@ -167,16 +433,288 @@ func LoadAgentsFromDirFlat(dir string) ([]*Agent, error) {
 		}
 
 		path := filepath.Join(dir, entry.Name())
-		agent, err := LoadAgentFromFile(path)
+		agent, err := LoadAgentFromFile(path, opts...)
 		if err != nil {
+			if o.continueOnError {
+				loadErrs = append(loadErrs, &FileError{Path: path, Err: err})
+				continue
+			}
 			return nil, fmt.Errorf("load %s: %w", entry.Name(), err)
 		}
 		agents = append(agents, agent)
 	}
+	if len(loadErrs) > 0 {
+		return agents, loadErrs
+	}
 
 	return agents, nil
 }
 
+// LoadSkillFromFile loads a Skill from a JSON file or a markdown file with
+// YAML frontmatter, whose body becomes the skill's instructions, and
+// validates it.
+func LoadSkillFromFile(path string) (*Skill, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+
+	var skill Skill
+	if filepath.Ext(path) == ".json" {
+		if err := json.Unmarshal(data, &skill); err != nil {
+			return nil, fmt.Errorf("parse json: %w", err)
+		}
+	} else {
+		body, err := unmarshalFrontmatter(data, &skill)
+		if err != nil {
+			return nil, err
+		}
+		skill.Instructions = strings.TrimSpace(string(body))
+	}
+	if err := skill.Validate(); err != nil {
+		return nil, fmt.Errorf("validate %s: %w", path, err)
+	}
+	return &skill, nil
+}
+
+// LoadSkillsFromDir loads every .md and .json skill file under dir,
+// recursively, e.g., a project's skills/ directory.
+func LoadSkillsFromDir(dir string) ([]*Skill, error) {
+	var skills []*Skill
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if ext := filepath.Ext(d.Name()); ext != ".md" && ext != ".json" {
+			return nil
+		}
+		skill, err := LoadSkillFromFile(path)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", path, err)
+		}
+		skills = append(skills, skill)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir %s: %w", dir, err)
+	}
+	return skills, nil
+}
+
+// LoadToolFromFile loads a ToolSpec from a JSON or YAML file and validates
+// it.
+func LoadToolFromFile(path string) (*ToolSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+
+	var tool ToolSpec
+	if filepath.Ext(path) == ".json" {
+		if err := json.Unmarshal(data, &tool); err != nil {
+			return nil, fmt.Errorf("parse json: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &tool); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if err := tool.Validate(); err != nil {
+		return nil, fmt.Errorf("validate %s: %w", path, err)
+	}
+	return &tool, nil
+}
+
+// LoadToolsFromDir loads every .json, .yaml, and .yml tool file under dir,
+// recursively, e.g., a project's tools/ directory.
+func LoadToolsFromDir(dir string) ([]*ToolSpec, error) {
+	var tools []*ToolSpec
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(d.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		tool, err := LoadToolFromFile(path)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", path, err)
+		}
+		tools = append(tools, tool)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir %s: %w", dir, err)
+	}
+	return tools, nil
+}
+
+// LoadRubricFromFile loads a Rubric from a JSON or YAML file and validates
+// it.
+func LoadRubricFromFile(path string) (*Rubric, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+
+	var rubric Rubric
+	if filepath.Ext(path) == ".json" {
+		if err := json.Unmarshal(data, &rubric); err != nil {
+			return nil, fmt.Errorf("parse json: %w", err)
+		}
+	} else if err := yaml.Unmarshal(data, &rubric); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if err := rubric.Validate(); err != nil {
+		return nil, fmt.Errorf("validate %s: %w", path, err)
+	}
+	return &rubric, nil
+}
+
+// LoadRubricsFromDir loads every .json, .yaml, and .yml rubric file under
+// dir, recursively, e.g., a project's rubrics/ directory.
+func LoadRubricsFromDir(dir string) ([]*Rubric, error) {
+	var rubrics []*Rubric
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(d.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		rubric, err := LoadRubricFromFile(path)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", path, err)
+		}
+		rubrics = append(rubrics, rubric)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir %s: %w", dir, err)
+	}
+	return rubrics, nil
+}
+
+// LoadPromptFromFile loads a PromptTemplate and validates it. .json files
+// are JSON, .md files are YAML frontmatter followed by the template, and
+// other files are YAML.
+func LoadPromptFromFile(path string) (*PromptTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+
+	var prompt PromptTemplate
+	switch filepath.Ext(path) {
+	case ".json":
+		if err := json.Unmarshal(data, &prompt); err != nil {
+			return nil, fmt.Errorf("parse json: %w", err)
+		}
+	case ".md":
+		body, err := unmarshalFrontmatter(data, &prompt)
+		if err != nil {
+			return nil, err
+		}
+		prompt.Template = strings.TrimSpace(string(body))
+	default:
+		if err := yaml.Unmarshal(data, &prompt); err != nil {
+			return nil, fmt.Errorf("parse yaml: %w", err)
+		}
+	}
+	if err := prompt.Validate(); err != nil {
+		return nil, fmt.Errorf("validate %s: %w", path, err)
+	}
+	return &prompt, nil
+}
+
+// LoadPromptsFromDir loads every .md, .json, .yaml, and .yml prompt file
+// under dir, recursively, e.g., a project's prompts/ directory.
+func LoadPromptsFromDir(dir string) ([]*PromptTemplate, error) {
+	var prompts []*PromptTemplate
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(d.Name()) {
+		case ".md", ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		prompt, err := LoadPromptFromFile(path)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", path, err)
+		}
+		prompts = append(prompts, prompt)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir %s: %w", dir, err)
+	}
+	return prompts, nil
+}
+
+// LoadTeamReportFromFile loads a TeamReport. .yaml and .yml files are
+// YAML; other files are JSON.
+func LoadTeamReportFromFile(path string) (*TeamReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		return ParseTeamReportYAML(data)
+	}
+	report, err := ParseTeamReport(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse json: %w", err)
+	}
+	return report, nil
+}
+
+// LoadTeamReportsFromDir loads every .json, .yaml, and .yml report file
+// under dir, recursively, keyed by path.
+func LoadTeamReportsFromDir(dir string) (map[string]*TeamReport, error) {
+	reports := make(map[string]*TeamReport)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch filepath.Ext(d.Name()) {
+		case ".json", ".yaml", ".yml":
+		default:
+			return nil
+		}
+		report, err := LoadTeamReportFromFile(path)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", path, err)
+		}
+		reports[path] = report
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk dir %s: %w", dir, err)
+	}
+	return reports, nil
+}
+
 // LoadTeamFromFile loads a Team from a JSON file.
 func LoadTeamFromFile(path string) (*Team, error) {
 	data, err := os.ReadFile(path)
@@ -207,46 +745,98 @@ func LoadDeploymentFromFile(path string) (*Deployment, error) {
 	return &deployment, nil
 }
 
-// splitFrontmatter splits YAML frontmatter from markdown body.
-// Frontmatter is delimited by --- at the start and end.
-func splitFrontmatter(data []byte) (frontmatter, body []byte, err error) {
-	scanner := bufio.NewScanner(bytes.NewReader(data))
+// utf8BOM is the byte order mark some editors write at the start of
+// UTF-8 files.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// errNoFrontmatter is returned by splitFrontmatter for markdown that does
+// not start with a frontmatter delimiter.
+var errNoFrontmatter = errors.New("line 1: missing frontmatter delimiter")
+
+// frontmatterFormat is the language of a markdown file's frontmatter.
+type frontmatterFormat int
 
-	// Check for opening delimiter
-	if !scanner.Scan() {
-		return nil, nil, fmt.Errorf("empty file")
+const (
+	frontmatterYAML frontmatterFormat = iota // between "---" lines
+	frontmatterTOML                          // between "+++" lines
+)
+
+// unmarshalFrontmatter decodes the YAML or TOML frontmatter of markdown
+// into v and returns the body.
+func unmarshalFrontmatter(data []byte, v any) (body []byte, err error) {
+	frontmatter, body, format, err := splitFrontmatter(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse frontmatter: %w", err)
 	}
-	if strings.TrimSpace(scanner.Text()) != "---" {
-		return nil, nil, fmt.Errorf("missing frontmatter delimiter")
+	if format == frontmatterTOML {
+		if err := unmarshalTOML(frontmatter, v); err != nil {
+			return nil, fmt.Errorf("parse toml: %w", err)
+		}
+		return body, nil
+	}
+	if err := yaml.Unmarshal(frontmatter, v); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
 	}
+	return body, nil
+}
 
-	// Read frontmatter until closing delimiter
-	var fm bytes.Buffer
-	foundEnd := false
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.TrimSpace(line) == "---" {
-			foundEnd = true
-			break
-		}
-		fm.WriteString(line)
-		fm.WriteString("\n")
+// unmarshalTOML decodes TOML into v by way of JSON, so the spec types'
+// json tags name the TOML keys.
+func unmarshalTOML(data []byte, v any) error {
+	var m map[string]any
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
 	}
+	return json.Unmarshal(b, v)
+}
 
-	if !foundEnd {
-		return nil, nil, fmt.Errorf("missing closing frontmatter delimiter")
+// splitFrontmatter splits markdown into its frontmatter and body. YAML
+// frontmatter is delimited by "---" lines and TOML frontmatter by "+++"
+// lines. A UTF-8 byte order mark is skipped and CRLF line endings are read
+// as LF. Delimiters may be followed by spaces but not indented, so an
+// indented "---" in a YAML block scalar does not end the frontmatter. The
+// frontmatter starts with a blank line in place of the opening delimiter,
+// so parse errors report the file's line numbers.
+func splitFrontmatter(data []byte) (frontmatter, body []byte, format frontmatterFormat, err error) {
+	data = bytes.ReplaceAll(bytes.TrimPrefix(data, utf8BOM), []byte("\r\n"), []byte("\n"))
+	if len(data) == 0 {
+		return nil, nil, 0, fmt.Errorf("empty file")
 	}
 
-	// Rest is body
-	var bd bytes.Buffer
-	for scanner.Scan() {
-		bd.WriteString(scanner.Text())
-		bd.WriteString("\n")
+	first, _, _ := bytes.Cut(data, []byte("\n"))
+	delim := "---"
+	switch {
+	case isFrontmatterDelimiter(first, "---"):
+	case isFrontmatterDelimiter(first, "+++"):
+		delim, format = "+++", frontmatterTOML
+	default:
+		return nil, nil, 0, errNoFrontmatter
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, nil, fmt.Errorf("scan error: %w", err)
+	start := len(first) + 1
+	for off := start; off < len(data); {
+		end := len(data)
+		if i := bytes.IndexByte(data[off:], '\n'); i >= 0 {
+			end = off + i
+		}
+		if isFrontmatterDelimiter(data[off:end], delim) {
+			frontmatter = append([]byte("\n"), data[start:off]...)
+			if end < len(data) {
+				body = data[end+1:]
+			}
+			return frontmatter, body, format, nil
+		}
+		off = end + 1
 	}
+	return nil, nil, 0, fmt.Errorf("missing closing frontmatter delimiter for the one on line 1")
+}
 
-	return fm.Bytes(), bd.Bytes(), nil
+// isFrontmatterDelimiter returns true if line is the frontmatter delimiter
+// delim.
+func isFrontmatterDelimiter(line []byte, delim string) bool {
+	return string(bytes.TrimRight(line, " \t")) == delim
 }
@@ -1,8 +1,11 @@
 package multiagentspec
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -86,6 +89,267 @@ Follow these steps:
 	}
 }
 
+func TestParseAgentMarkdownEdgeCases(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		instructions string
+	}{
+		{"BOM", "\ufeff---\nname: a\n---\n\nDo it.\n", "Do it."},
+		{"CRLF", "---\r\nname: a\r\n---\r\n\r\nDo it.\r\nThen stop.\r\n", "Do it.\nThen stop."},
+		{"block scalar", "---\nname: a\ndescription: |\n  before\n  ---\n  after\n---\nDo it.\n", "Do it."},
+		{"delimiter with trailing spaces", "---  \nname: a\n--- \nDo it.", "Do it."},
+		{"body with rules", "---\nname: a\n---\nDo it.\n\n---\n\nThen stop.\n", "Do it.\n\n---\n\nThen stop."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent, err := ParseAgentMarkdown([]byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if agent.Name != "a" {
+				t.Errorf("Name = %q, want %q", agent.Name, "a")
+			}
+			if agent.Instructions != tt.instructions {
+				t.Errorf("Instructions = %q, want %q", agent.Instructions, tt.instructions)
+			}
+		})
+	}
+
+	agent, err := ParseAgentMarkdown([]byte("---\nname: a\ndescription: |\n  before\n  ---\n  after\n---\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.Description != "before\n---\nafter\n" {
+		t.Errorf("Description = %q", agent.Description)
+	}
+}
+
+func TestParseAgentMarkdownErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", "empty file"},
+		{"BOM only", "\ufeff", "empty file"},
+		{"no frontmatter", "# Agent\n", "line 1: missing frontmatter delimiter"},
+		{"unclosed", "---\nname: a\n", "missing closing frontmatter delimiter for the one on line 1"},
+		{"YAML", "---\nname: a\ndescription: a: b\n---\n", "line 3"},
+		{"YAML type", "---\nname: a\n\ntools: 5\n---\n", "line 4"},
+		{"YAML after CRLF", "\ufeff---\r\nname: a\r\n\r\nmodel: [\r\n---\r\n", "line 4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAgentMarkdown([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAgentMarkdownTOML(t *testing.T) {
+	input := `+++
+name = "test-agent"
+description = "A test agent"
+model = "sonnet"
+tools = ["Read", "Bash"]
+
+[[tasks]]
+id = "run-tests"
+type = "command"
+command = "go test ./..."
+expected_output = "PASS"
++++
+
+# Test Agent
+
+---
+
+Run the tests.
+`
+	agent, err := ParseAgentMarkdown([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.Name != "test-agent" || agent.Description != "A test agent" || agent.Model != ModelSonnet {
+		t.Errorf("agent = %+v", agent)
+	}
+	if len(agent.Tools) != 2 || agent.Tools[1] != "Bash" {
+		t.Errorf("Tools = %v", agent.Tools)
+	}
+	if len(agent.Tasks) != 1 || agent.Tasks[0].ID != "run-tests" || agent.Tasks[0].ExpectedOutput != "PASS" {
+		t.Errorf("Tasks = %+v", agent.Tasks)
+	}
+	if agent.Instructions != "# Test Agent\n\n---\n\nRun the tests." {
+		t.Errorf("Instructions = %q", agent.Instructions)
+	}
+
+	yamlAgent, err := ParseAgentMarkdown([]byte("---\nname: a\ndescription: |\n  +++\n---\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if yamlAgent.Name != "a" || yamlAgent.Description != "+++\n" {
+		t.Errorf("YAML frontmatter with a +++ line = %+v", yamlAgent)
+	}
+
+	for _, tt := range []struct {
+		name, input, want string
+	}{
+		{"syntax", "+++\nname = \"a\"\n\ntools = [\n+++\n", "parse toml: toml: line 4"},
+		{"type", "+++\nname = \"a\"\ntools = 5\n+++\n", "parse toml: json: cannot unmarshal number"},
+		{"unclosed", "+++\nname = \"a\"\n---\n", "missing closing frontmatter delimiter"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAgentMarkdown([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllowMissingFrontmatter(t *testing.T) {
+	agent, err := ParseAgentMarkdown([]byte("\ufeff# Reviewer\r\n\r\nReview the diff.\r\n"), AllowMissingFrontmatter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.Name != "" || agent.Instructions != "# Reviewer\n\nReview the diff." {
+		t.Errorf("agent = %+v", agent)
+	}
+	if _, err := ParseAgentMarkdown([]byte("---\nname: a\n"), AllowMissingFrontmatter()); err == nil {
+		t.Error("AllowMissingFrontmatter accepted an unclosed frontmatter")
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"reviewer.md": "# Reviewer\n\nReview the diff.\n",
+		"writer.md":   "---\nname: writer\n---\nWrite.\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(dir, "reviewer.md")
+
+	if _, err := LoadAgentFromFile(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("LoadAgentFromFile error = %v, want one naming %s", err, path)
+	}
+	agent, err = LoadAgentFromFile(path, AllowMissingFrontmatter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if agent.Name != "reviewer" || agent.Instructions != "# Reviewer\n\nReview the diff." {
+		t.Errorf("agent = %+v", agent)
+	}
+
+	if _, err := LoadAgentsFromDir(dir); err == nil {
+		t.Error("LoadAgentsFromDir accepted a file without frontmatter")
+	}
+	agents, err := NewLoader(WithAgentParseOptions(AllowMissingFrontmatter())).LoadAgentsFromDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 2 {
+		t.Errorf("loaded %d agents, want 2", len(agents))
+	}
+	agents, err = LoadAgentsFromDirFlat(dir, AllowMissingFrontmatter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(agents) != 2 {
+		t.Errorf("loaded %d agents flat, want 2", len(agents))
+	}
+}
+
+func TestLoadAgentsFromDirContinueOnError(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"good.md":        "---\nname: good\n---\nWork.\n",
+		"bad-yaml.md":    "---\nname: bad\ndescription: a: b\n---\n",
+		"shared/fine.md": "---\nname: fine\n---\nWork.\n",
+		"shared/bare.md": "# No frontmatter\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if agents, err := LoadAgentsFromDir(dir); err == nil || agents != nil {
+		t.Errorf("LoadAgentsFromDir = %d agents, %v; want an error", len(agents), err)
+	}
+
+	agents, err := LoadAgentsFromDir(dir, ContinueOnError())
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) {
+		t.Fatalf("error = %v, want LoadErrors", err)
+	}
+	if len(agents) != 2 {
+		t.Errorf("loaded %d agents, want 2", len(agents))
+	}
+	wantPaths := []string{filepath.Join(dir, "bad-yaml.md"), filepath.Join(dir, "shared", "bare.md")}
+	if len(loadErrs) != len(wantPaths) {
+		t.Fatalf("LoadErrors = %v, want %d", loadErrs, len(wantPaths))
+	}
+	for i, want := range wantPaths {
+		if loadErrs[i].Path != want || !strings.Contains(loadErrs[i].Error(), want) {
+			t.Errorf("LoadErrors[%d] = %s: %v, want %s", i, loadErrs[i].Path, loadErrs[i], want)
+		}
+	}
+	if !errors.Is(err, errNoFrontmatter) {
+		t.Errorf("error %v does not wrap the missing frontmatter error", err)
+	}
+	if !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("error = %v, want the YAML line", err)
+	}
+
+	agents, err = NewLoader(WithAgentParseOptions(ContinueOnError(), AllowMissingFrontmatter())).LoadAgentsFromDir(dir)
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 1 || len(agents) != 3 {
+		t.Errorf("Loader.LoadAgentsFromDir = %d agents, %v; want 3 and one error", len(agents), err)
+	}
+
+	agents, err = LoadAgentsFromDirFlat(dir, ContinueOnError())
+	if !errors.As(err, &loadErrs) || len(loadErrs) != 1 || len(agents) != 1 || agents[0].Name != "good" {
+		t.Errorf("LoadAgentsFromDirFlat = %d agents, %v; want good and one error", len(agents), err)
+	}
+
+	if agents, err := LoadAgentsFromDir(filepath.Join(dir, "shared"), ContinueOnError(), AllowMissingFrontmatter()); err != nil || len(agents) != 2 {
+		t.Errorf("LoadAgentsFromDir of good files = %d agents, %v", len(agents), err)
+	}
+}
+
+func TestMarshalAgentMarkdown(t *testing.T) {
+	a := NewAgent("qa", "Runs tests").WithNamespace("shared").WithTools("Read", "Bash").
+		WithInstructions("\nRun the suite.\n\n## Reporting\n\nSummarize failures.\n")
+	a.MCPServers = []MCPServer{{Name: "db", Command: "db-mcp"}}
+	a.Tasks = []Task{{ID: "unit", Description: "Run unit tests", ExpectedOutput: "All tests pass"}}
+
+	data, err := MarshalAgentMarkdown(a)
+	if err != nil {
+		t.Fatalf("MarshalAgentMarkdown: %v", err)
+	}
+	if strings.Contains(string(data), "namespace:") || strings.Contains(string(data), "command: \"\"") ||
+		!strings.Contains(string(data), "expected_output: All tests pass") || !strings.HasSuffix(string(data), "---\n\nRun the suite.\n\n## Reporting\n\nSummarize failures.\n") {
+		t.Errorf("markdown =\n%s", data)
+	}
+
+	got, err := ParseAgentMarkdown(data)
+	if err != nil {
+		t.Fatalf("ParseAgentMarkdown: %v", err)
+	}
+	if got.Name != "qa" || got.Model != ModelSonnet || strings.Join(got.Tools, ",") != "Read,Bash" ||
+		len(got.MCPServers) != 1 || got.Instructions != strings.TrimSpace(a.Instructions) ||
+		len(got.Tasks) != 1 || got.Tasks[0] != a.Tasks[0] {
+		t.Errorf("round trip = %+v", got)
+	}
+}
+
 func TestLoadAgentsFromDir(t *testing.T) {
 	// Create temp directory with test files
 	tmpDir := t.TempDir()
@@ -460,6 +724,26 @@ func TestNewLoader(t *testing.T) {
 	}
 }
 
+func TestLoaderWithLogger(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "team.json")
+	if err := os.WriteFile(path, []byte(`{"name": "test-team", "version": "1.0.0", "agents": ["a"]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, &LoggingConfig{Level: "debug", Format: LogFormatJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewLoader(WithLogger(logger)).LoadTeam(path); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); !strings.Contains(out, `"msg":"loaded team"`) || !strings.Contains(out, `"team":"test-team"`) {
+		t.Errorf("log output = %s", out)
+	}
+}
+
 func TestLoader_LoadTeam(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -489,3 +773,345 @@ func TestLoader_LoadTeam(t *testing.T) {
 		t.Errorf("Workflow.Type = %q, want %q", team.Workflow.Type, WorkflowChain)
 	}
 }
+
+func TestLoaderResolveTeamAgents(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, version := range map[string]string{"analyst-v1.md": "1.3.0", "analyst-v2.md": "2.1.0"} {
+		agent := "---\nname: security-analyst\nversion: " + version + "\n---\n\nReview for vulnerabilities.\n"
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(agent), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, &LoggingConfig{Level: "debug", Format: LogFormatJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader := NewLoader(WithLogger(logger))
+	agents, err := loader.LoadAgentsFromDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	team := NewTeam("security-team", "1.0.0").WithAgents("security-analyst@^1")
+	resolved, err := loader.ResolveTeamAgents(team, agents)
+	if err != nil {
+		t.Fatalf("ResolveTeamAgents: %v", err)
+	}
+	if len(resolved) != 1 || resolved[0].Version != "1.3.0" {
+		t.Errorf("ResolveTeamAgents = %+v, want security-analyst 1.3.0", resolved)
+	}
+	if out := buf.String(); !strings.Contains(out, `"msg":"resolved team agent"`) || !strings.Contains(out, `"version":"1.3.0"`) {
+		t.Errorf("log output = %s", out)
+	}
+
+	bad := filepath.Join(tmpDir, "bad.md")
+	if err := os.WriteFile(bad, []byte("---\nname: bad\nversion: two\n---\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAgentFromFile(bad); err == nil || !strings.Contains(err.Error(), `invalid version "two"`) {
+		t.Errorf("LoadAgentFromFile error = %v, want invalid version", err)
+	}
+}
+
+func TestLoaderDeprecatedAgentWarning(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"old-reviewer.md": "---\nname: old-reviewer\ndeprecated: true\nreplaced_by: reviewer\n---\n",
+		"reviewer.md":     "---\nname: reviewer\n---\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, &LoggingConfig{Level: "warn", Format: LogFormatJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	loader := NewLoader(WithLogger(logger))
+	agents, err := loader.LoadAgentsFromDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.ResolveTeamAgents(NewTeam("review-team", "1.0.0").WithAgents("old-reviewer"), agents); err != nil {
+		t.Fatalf("ResolveTeamAgents: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, `"msg":"reference to deprecated agent"`) || !strings.Contains(out, `"replaced_by":"reviewer"`) {
+		t.Errorf("log output = %s", out)
+	}
+
+	bad := filepath.Join(tmpDir, "bad.md")
+	if err := os.WriteFile(bad, []byte("---\nname: bad\nreplaced_by: reviewer\n---\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadAgentFromFile(bad); err == nil || !strings.Contains(err.Error(), "replaced_by requires deprecated") {
+		t.Errorf("LoadAgentFromFile error = %v", err)
+	}
+}
+
+func TestLoadSkillsFromDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	review := `---
+name: code-review
+description: Review a diff for defects
+inputs:
+  type: object
+  required: [diff]
+  properties:
+    diff: {type: string}
+implementation: scripts/review.sh
+---
+
+Read the diff and list defects by severity.
+`
+	summarize := `{"name": "summarize", "outputs": {"type": "string", "maxLength": 500}}`
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, "writing"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "code-review.md"), []byte(review), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "writing", "summarize.json"), []byte(summarize), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "readme.txt"), []byte("ignore me"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := NewLoader().LoadSkillsFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadSkillsFromDir failed: %v", err)
+	}
+	if len(skills) != 2 {
+		t.Fatalf("Skill count = %d, want 2", len(skills))
+	}
+	byName := make(map[string]*Skill)
+	for _, s := range skills {
+		byName[s.Name] = s
+	}
+	cr := byName["code-review"]
+	if cr == nil {
+		t.Fatal("code-review not found")
+	}
+	if cr.Instructions != "Read the diff and list defects by severity." {
+		t.Errorf("Instructions = %q", cr.Instructions)
+	}
+	if cr.Implementation != "scripts/review.sh" || cr.Inputs["type"] != "object" {
+		t.Errorf("code-review = %+v", cr)
+	}
+	if s := byName["summarize"]; s == nil || s.Outputs["type"] != "string" {
+		t.Errorf("summarize = %+v", s)
+	}
+}
+
+func TestLoadSkillFromFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(path, []byte(`{"name": "bad", "inputs": {"type": 42}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSkillFromFile(path); err == nil || !strings.Contains(err.Error(), "inputs is not a valid JSON Schema") {
+		t.Errorf("LoadSkillFromFile error = %v, want invalid inputs schema", err)
+	}
+}
+
+func TestLoadToolsFromDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	search := `name: jira_search
+description: Search Jira issues
+parameters:
+  type: object
+  required: [query]
+  properties:
+    query: {type: string}
+binding:
+  type: mcp
+  server: jira
+  tool: search_issues
+`
+	deploy := `{"name": "deploy", "binding": {"type": "command", "command": "scripts/deploy.sh"}}`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "jira_search.yaml"), []byte(search), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "deploy.json"), []byte(deploy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte("ignore me"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tools, err := NewLoader().LoadToolsFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadToolsFromDir failed: %v", err)
+	}
+	if len(tools) != 2 {
+		t.Fatalf("Tool count = %d, want 2", len(tools))
+	}
+	byName := make(map[string]*ToolSpec)
+	for _, tool := range tools {
+		byName[tool.Name] = tool
+	}
+	js := byName["jira_search"]
+	if js == nil || js.Binding == nil || js.Binding.Type != ToolBindingMCP || js.Binding.Tool != "search_issues" {
+		t.Errorf("jira_search = %+v", js)
+	}
+	if js != nil && js.Parameters["type"] != "object" {
+		t.Errorf("jira_search parameters = %v", js.Parameters)
+	}
+	if d := byName["deploy"]; d == nil || d.Binding.Command != "scripts/deploy.sh" {
+		t.Errorf("deploy = %+v", d)
+	}
+}
+
+func TestLoadToolFromFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deploy.json")
+	if err := os.WriteFile(path, []byte(`{"name": "deploy", "binding": {"type": "command"}}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadToolFromFile(path); err == nil || !strings.Contains(err.Error(), "binding: command binding requires command") {
+		t.Errorf("LoadToolFromFile error = %v, want missing command", err)
+	}
+}
+
+func TestLoadRubricsFromDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	prd := `name: prd-quality
+version: 1.2.0
+categories:
+  - id: problem-clarity
+    weight: 0.4
+    criteria:
+      - Is the user problem stated?
+    anchors:
+      - score: 10
+        description: Problem stated with evidence
+  - id: scope
+    weight: 0.6
+`
+	security := `{"name": "threat-model", "categories": [{"id": "coverage"}]}`
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "prd-quality.yaml"), []byte(prd), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "threat-model.json"), []byte(security), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	rubrics, err := NewLoader().LoadRubricsFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadRubricsFromDir failed: %v", err)
+	}
+	if len(rubrics) != 2 {
+		t.Fatalf("Rubric count = %d, want 2", len(rubrics))
+	}
+	byName := make(map[string]*Rubric)
+	for _, r := range rubrics {
+		byName[r.Name] = r
+	}
+	r := byName["prd-quality"]
+	if r == nil || r.Version != "1.2.0" || len(r.Categories) != 2 || r.Categories[0].Anchors[0].Score != 10 {
+		t.Errorf("prd-quality = %+v", r)
+	}
+	if byName["threat-model"] == nil {
+		t.Error("threat-model not found")
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "bad.yaml"), []byte("name: bad\ncategories: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRubricsFromDir(tmpDir); err == nil || !strings.Contains(err.Error(), "at least one category is required") {
+		t.Errorf("LoadRubricsFromDir error = %v, want missing categories", err)
+	}
+}
+
+func TestLoadPromptsFromDir(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	review := `---
+name: prd-review
+version: 1.1.0
+description: System prompt for PRD reviews
+---
+
+You grade PRDs for {{ .vars.company }}.
+`
+	files := map[string]string{
+		"prd-review.md":     review,
+		"prd-review-1.json": `{"name": "prd-review", "version": "1.0.0", "template": "You grade PRDs."}`,
+		"summary.yaml":      "name: summary\nversion: 2.0.0\ntemplate: Summarize the findings.\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prompts, err := NewLoader().LoadPromptsFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadPromptsFromDir failed: %v", err)
+	}
+	if len(prompts) != 3 {
+		t.Fatalf("Prompt count = %d, want 3", len(prompts))
+	}
+	byRef := make(map[string]*PromptTemplate)
+	for _, p := range prompts {
+		byRef[p.PromptVersion()] = p
+	}
+	p := byRef["prd-review@1.1.0"]
+	if p == nil || p.Description != "System prompt for PRD reviews" || p.Template != "You grade PRDs for {{ .vars.company }}." {
+		t.Errorf("prd-review@1.1.0 = %+v", p)
+	}
+	if byRef["prd-review@1.0.0"] == nil || byRef["summary@2.0.0"] == nil {
+		t.Errorf("prompts = %v", byRef)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "bad.yaml"), []byte("name: bad\ntemplate: x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPromptsFromDir(tmpDir); err == nil || !strings.Contains(err.Error(), "version is required") {
+		t.Errorf("LoadPromptsFromDir error = %v, want missing version", err)
+	}
+}
+
+func TestLoadTeamReportsFromDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		"acme.json":          `{"project": "app", "version": "v1.0.0", "tags": {"customer": "acme"}, "teams": [], "status": "GO"}`,
+		"nested/globex.yaml": "project: app\nversion: v1.1.0\ntags:\n  customer: globex\nstatus: WARN\n",
+		"notes.txt":          "not a report",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reports, err := LoadTeamReportsFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadTeamReportsFromDir failed: %v", err)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("Report count = %d, want 2", len(reports))
+	}
+	if r := reports[filepath.Join(tmpDir, "nested", "globex.yaml")]; r == nil || r.Tags["customer"] != "globex" || r.Status != StatusWarn {
+		t.Errorf("globex report = %+v", r)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "broken.json"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTeamReportsFromDir(tmpDir); err == nil {
+		t.Error("LoadTeamReportsFromDir accepted an invalid report")
+	}
+}
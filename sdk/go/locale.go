@@ -0,0 +1,159 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog translates the status words and section headings of narrative
+// reports for non-English readers. A nil Catalog renders English.
+type Catalog struct {
+	// Lang is the report's BCP 47 language tag (e.g., "de"), set as the
+	// Pandoc lang metadata so PDFs hyphenate and quote correctly.
+	Lang string `json:"lang,omitempty" yaml:"lang,omitempty"`
+
+	// Messages maps the English messages listed by NarrativeMessages to
+	// their translations. Messages without a translation stay English.
+	Messages map[string]string `json:"messages" yaml:"messages"`
+}
+
+// T returns the translation of msg, or msg if c has none.
+func (c *Catalog) T(msg string) string {
+	if c != nil {
+		if t, ok := c.Messages[msg]; ok && t != "" {
+			return t
+		}
+	}
+	return msg
+}
+
+// StatusText returns the translated text of a status: PASS, WARNING, FAIL,
+// or SKIP in English.
+func (c *Catalog) StatusText(s Status) string {
+	return c.T(statusText(s))
+}
+
+// lang returns c's language tag, or "" for English.
+func (c *Catalog) lang() string {
+	if c == nil {
+		return ""
+	}
+	return c.Lang
+}
+
+// NarrativeMessages returns the English messages of narrative reports
+// that a Catalog can translate, sorted.
+func NarrativeMessages() []string {
+	msgs := []string{
+		"TEAM STATUS REPORT", "PASS", "WARNING", "FAIL", "SKIP", "non-blocking",
+		"Project", "Version", "Phase", "Overall Status", "Tags",
+		"Executive Summary", "Overview", "Team Results",
+		"Status", "Verdict", "Problem", "Analysis", "Recommendation",
+		"Tasks", "Task", "Severity", "Duration", "Detail", "Details", "target",
+		"Cost Summary", "Team", "Tokens In", "Tokens Out", "Cost (USD)", "Total",
+		"Action Items", "Conclusion",
+		"Issues", "Category", "Location", "Effort", "Related", "Artifacts",
+	}
+	sort.Strings(msgs)
+	return msgs
+}
+
+// catalogs are the built-in catalogs by language.
+var catalogs = map[string]map[string]string{
+	"de": {
+		"TEAM STATUS REPORT": "TEAMSTATUSBERICHT",
+		"PASS":               "BESTANDEN", "WARNING": "WARNUNG", "FAIL": "FEHLGESCHLAGEN", "SKIP": "ÜBERSPRUNGEN",
+		"non-blocking": "nicht blockierend",
+		"Project":      "Projekt", "Version": "Version", "Phase": "Phase", "Overall Status": "Gesamtstatus", "Tags": "Tags",
+		"Executive Summary": "Zusammenfassung", "Overview": "Überblick", "Team Results": "Teamergebnisse",
+		"Status": "Status", "Verdict": "Bewertung", "Problem": "Problem", "Analysis": "Analyse", "Recommendation": "Empfehlung",
+		"Tasks": "Aufgaben", "Task": "Aufgabe", "Severity": "Schweregrad", "Duration": "Dauer", "Detail": "Detail", "Details": "Details", "target": "Ziel",
+		"Cost Summary": "Kostenübersicht", "Team": "Team", "Tokens In": "Eingabe-Tokens", "Tokens Out": "Ausgabe-Tokens",
+		"Cost (USD)": "Kosten (USD)", "Total": "Gesamt",
+		"Action Items": "Maßnahmen", "Conclusion": "Fazit",
+		"Issues": "Probleme", "Category": "Kategorie", "Location": "Ort", "Effort": "Aufwand", "Related": "Verwandt", "Artifacts": "Artefakte",
+	},
+	"es": {
+		"TEAM STATUS REPORT": "INFORME DE ESTADO DEL EQUIPO",
+		"PASS":               "APROBADO", "WARNING": "ADVERTENCIA", "FAIL": "FALLIDO", "SKIP": "OMITIDO",
+		"non-blocking": "no bloqueante",
+		"Project":      "Proyecto", "Version": "Versión", "Phase": "Fase", "Overall Status": "Estado general", "Tags": "Etiquetas",
+		"Executive Summary": "Resumen ejecutivo", "Overview": "Descripción general", "Team Results": "Resultados de los equipos",
+		"Status": "Estado", "Verdict": "Veredicto", "Problem": "Problema", "Analysis": "Análisis", "Recommendation": "Recomendación",
+		"Tasks": "Tareas", "Task": "Tarea", "Severity": "Gravedad", "Duration": "Duración", "Detail": "Detalle", "Details": "Detalles", "target": "objetivo",
+		"Cost Summary": "Resumen de costos", "Team": "Equipo", "Tokens In": "Tokens de entrada", "Tokens Out": "Tokens de salida",
+		"Cost (USD)": "Costo (USD)", "Total": "Total",
+		"Action Items": "Acciones pendientes", "Conclusion": "Conclusión",
+		"Issues": "Problemas", "Category": "Categoría", "Location": "Ubicación", "Effort": "Esfuerzo", "Related": "Relacionados", "Artifacts": "Artefactos",
+	},
+	"fr": {
+		"TEAM STATUS REPORT": "RAPPORT D'ÉTAT DES ÉQUIPES",
+		"PASS":               "RÉUSSI", "WARNING": "AVERTISSEMENT", "FAIL": "ÉCHEC", "SKIP": "IGNORÉ",
+		"non-blocking": "non bloquant",
+		"Project":      "Projet", "Version": "Version", "Phase": "Phase", "Overall Status": "Statut global", "Tags": "Étiquettes",
+		"Executive Summary": "Résumé", "Overview": "Vue d'ensemble", "Team Results": "Résultats des équipes",
+		"Status": "Statut", "Verdict": "Verdict", "Problem": "Problème", "Analysis": "Analyse", "Recommendation": "Recommandation",
+		"Tasks": "Tâches", "Task": "Tâche", "Severity": "Gravité", "Duration": "Durée", "Detail": "Détail", "Details": "Détails", "target": "cible",
+		"Cost Summary": "Récapitulatif des coûts", "Team": "Équipe", "Tokens In": "Jetons en entrée", "Tokens Out": "Jetons en sortie",
+		"Cost (USD)": "Coût (USD)", "Total": "Total",
+		"Action Items": "Actions à mener", "Conclusion": "Conclusion",
+		"Issues": "Problèmes", "Category": "Catégorie", "Location": "Emplacement", "Effort": "Effort", "Related": "Liés", "Artifacts": "Artefacts",
+	},
+}
+
+// Locales returns the languages with a built-in catalog, sorted.
+func Locales() []string {
+	locales := []string{"en"}
+	for lang := range catalogs {
+		locales = append(locales, lang)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// CatalogFor returns the built-in catalog for locale, a language such as
+// "de" or a locale such as "de-DE" or "de_DE.UTF-8", matched by language.
+// English has an empty catalog.
+func CatalogFor(locale string) (*Catalog, error) {
+	lang := strings.ToLower(locale)
+	if i := strings.IndexAny(lang, ".@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "en" {
+		return &Catalog{Lang: "en"}, nil
+	}
+	messages, ok := catalogs[lang]
+	if !ok {
+		return nil, fmt.Errorf("unknown locale %q (want one of %s)", locale, strings.Join(Locales(), ", "))
+	}
+	return &Catalog{Lang: lang, Messages: messages}, nil
+}
+
+// LoadCatalog reads a catalog from a JSON or YAML file, detected by the
+// .yaml or .yml extension.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading catalog: %w", err)
+	}
+	var c Catalog
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &c)
+	default:
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing catalog %s: %w", path, err)
+	}
+	return &c, nil
+}
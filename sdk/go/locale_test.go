@@ -0,0 +1,80 @@
+package multiagentspec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCatalogFor(t *testing.T) {
+	for _, locale := range []string{"de", "de-DE", "de_DE.UTF-8", "DE"} {
+		c, err := CatalogFor(locale)
+		if err != nil {
+			t.Fatalf("CatalogFor(%q): %v", locale, err)
+		}
+		if c.Lang != "de" || c.StatusText(StatusGo) != "BESTANDEN" {
+			t.Errorf("CatalogFor(%q) = %q, PASS = %q", locale, c.Lang, c.StatusText(StatusGo))
+		}
+	}
+	c, err := CatalogFor("en_US")
+	if err != nil || c.Lang != "en" || c.T("Overview") != "Overview" {
+		t.Errorf("CatalogFor(en_US) = %+v, %v", c, err)
+	}
+	if _, err := CatalogFor("xx"); err == nil || !strings.Contains(err.Error(), "de, en, es, fr") {
+		t.Errorf("CatalogFor(xx) error = %v", err)
+	}
+}
+
+func TestBuiltinCatalogsComplete(t *testing.T) {
+	messages := NarrativeMessages()
+	for lang, catalog := range catalogs {
+		for _, msg := range messages {
+			if catalog[msg] == "" {
+				t.Errorf("%s catalog lacks %q", lang, msg)
+			}
+		}
+		if len(catalog) != len(messages) {
+			t.Errorf("%s catalog has %d messages, want %d", lang, len(catalog), len(messages))
+		}
+	}
+}
+
+func TestCatalogT(t *testing.T) {
+	var nilCatalog *Catalog
+	if got := nilCatalog.T("Overview"); got != "Overview" {
+		t.Errorf("nil T = %q", got)
+	}
+	c := &Catalog{Messages: map[string]string{"Overview": "Übersicht", "Tags": ""}}
+	if got := c.T("Overview"); got != "Übersicht" {
+		t.Errorf("T = %q", got)
+	}
+	if got := c.T("Tags"); got != "Tags" {
+		t.Errorf("T of an empty translation = %q, want English", got)
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir := t.TempDir()
+	want := &Catalog{Lang: "nl", Messages: map[string]string{"PASS": "GESLAAGD"}}
+	for name, data := range map[string]string{
+		"nl.json": `{"lang": "nl", "messages": {"PASS": "GESLAAGD"}}`,
+		"nl.yaml": "lang: nl\nmessages:\n  PASS: GESLAAGD\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LoadCatalog(path)
+		if err != nil {
+			t.Fatalf("LoadCatalog(%s): %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("LoadCatalog(%s) = %+v, want %+v", name, got, want)
+		}
+	}
+	if _, err := LoadCatalog(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadCatalog of a missing file succeeded")
+	}
+}
@@ -0,0 +1,140 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// LocationResolver turns the source locations of issues and file tasks
+// into links to a repository's web view, so findings in rendered reports
+// are navigable.
+type LocationResolver struct {
+	// BaseURL is the repository's web URL, e.g.,
+	// "https://github.com/org/repo". Links follow the GitHub layout
+	// (BaseURL/blob/Commit/file#Lline), which Gitea, Forgejo, and GitLab
+	// also serve.
+	BaseURL string
+
+	// Commit is the commit SHA, branch, or tag the locations refer to,
+	// "HEAD" if empty. Pin it to the commit the report was made for so
+	// links keep pointing at the lines that were reported.
+	Commit string
+}
+
+// URL returns the link to location, or "" if l is nil, has no BaseURL, or
+// location is not a file (see ParseLocation).
+func (l *LocationResolver) URL(location string) string {
+	if l == nil || l.BaseURL == "" {
+		return ""
+	}
+	file, line, ok := ParseLocation(location)
+	if !ok {
+		return ""
+	}
+	commit := l.Commit
+	if commit == "" {
+		commit = "HEAD"
+	}
+	u := strings.TrimSuffix(l.BaseURL, "/") + "/blob/" + url.PathEscape(commit) + "/" + (&url.URL{Path: file}).EscapedPath()
+	if line > 0 {
+		u += fmt.Sprintf("#L%d", line)
+	}
+	return u
+}
+
+// Text returns location as "file:line", or "file" without a line, if it
+// is a file. Other locations, and all locations if l is nil, are returned
+// unchanged.
+func (l *LocationResolver) Text(location string) string {
+	if l == nil {
+		return location
+	}
+	file, line, ok := ParseLocation(location)
+	if !ok {
+		return location
+	}
+	if line > 0 {
+		return fmt.Sprintf("%s:%d", file, line)
+	}
+	return file
+}
+
+// ParseLocation splits a source location such as "cmd/main.go:42",
+// "cmd/main.go:42:7", "cmd/main.go#L42", or "README.md" into its file and
+// line, 0 if it has none. It returns false for locations that are not
+// files, e.g., document paths such as "requirements.functional[2]" and
+// URLs.
+func ParseLocation(location string) (file string, line int, ok bool) {
+	file = strings.TrimPrefix(strings.TrimSpace(location), "./")
+	if file == "" || strings.ContainsAny(file, " \t[]()") || strings.Contains(file, "://") {
+		return "", 0, false
+	}
+	rest := ""
+	if i := strings.Index(file, "#L"); i >= 0 {
+		file, rest = file[:i], strings.SplitN(file[i+2:], "-", 2)[0] // #L10-L20
+	} else if i := strings.Index(file, ":"); i >= 0 {
+		file, rest = file[:i], file[i+1:]
+		if j := strings.Index(rest, ":"); j >= 0 {
+			if _, err := strconv.Atoi(rest[j+1:]); err != nil {
+				return "", 0, false
+			}
+			rest = rest[:j] // column
+		}
+	}
+	if rest != "" {
+		n, err := strconv.Atoi(rest)
+		if err != nil || n <= 0 {
+			return "", 0, false
+		}
+		line = n
+	} else if !strings.Contains(file, "/") && !hasFileExt(file) {
+		return "", 0, false
+	}
+	if file == "" {
+		return "", 0, false
+	}
+	return file, line, true
+}
+
+// hasFileExt returns true if name ends in a short lowercase extension such
+// as ".go" or ".yaml", telling files from dotted document paths.
+func hasFileExt(name string) bool {
+	ext := path.Ext(name)
+	if len(ext) < 2 || len(ext) > 5 {
+		return false
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Location returns the source location of a file task from the "file"
+// and optional "line" entries of its Metadata, e.g., "cmd/main.go:42",
+// or "" if it has no file.
+func (t TaskResult) Location() string {
+	file, _ := t.Metadata["file"].(string)
+	if file == "" {
+		return ""
+	}
+	var line int64
+	switch v := t.Metadata["line"].(type) {
+	case int:
+		line = int64(v)
+	case int64:
+		line = v
+	case float64: // decoded from JSON
+		line = int64(v)
+	case string:
+		line, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if line > 0 {
+		return fmt.Sprintf("%s:%d", file, line)
+	}
+	return file
+}
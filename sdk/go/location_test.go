@@ -0,0 +1,93 @@
+package multiagentspec
+
+import "testing"
+
+func locationTestReport() *TeamReport {
+	return &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Teams: []TeamSection{
+			{ID: "lint", Name: "Lint", Status: StatusWarn,
+				Tasks: []TaskResult{
+					{ID: "vet", Status: StatusWarn, Detail: "unused variable",
+						Metadata: map[string]interface{}{"file": "cmd/main.go", "line": float64(42)}},
+					{ID: "gofmt", Status: StatusGo},
+				},
+				Issues: []Issue{
+					{ID: "ISS-001", Category: "style", Severity: SeverityMinor, Problem: "long function", Location: "pkg/run.go#L10"},
+					{ID: "ISS-002", Category: "docs", Severity: SeverityMinor, Problem: "vague goal", Location: "requirements.functional[2]"},
+				}},
+		},
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		location string
+		file     string
+		line     int
+		ok       bool
+	}{
+		{"cmd/main.go:42", "cmd/main.go", 42, true},
+		{"cmd/main.go:42:7", "cmd/main.go", 42, true},
+		{"./cmd/main.go#L42", "cmd/main.go", 42, true},
+		{"cmd/main.go#L10-L20", "cmd/main.go", 10, true},
+		{"README.md", "README.md", 0, true},
+		{"docs/guide", "docs/guide", 0, true},
+		{"requirements.functional[2]", "", 0, false},
+		{"executiveSummary.problemStatement", "", 0, false},
+		{"main.go:top", "", 0, false},
+		{"https://example.com/a.go", "", 0, false},
+		{"", "", 0, false},
+	}
+	for _, tt := range tests {
+		file, line, ok := ParseLocation(tt.location)
+		if file != tt.file || line != tt.line || ok != tt.ok {
+			t.Errorf("ParseLocation(%q) = %q, %d, %v, want %q, %d, %v", tt.location, file, line, ok, tt.file, tt.line, tt.ok)
+		}
+	}
+}
+
+func TestLocationResolver(t *testing.T) {
+	l := &LocationResolver{BaseURL: "https://github.com/org/repo/", Commit: "abc123"}
+	for _, tt := range []struct{ location, url, text string }{
+		{"cmd/main.go#L42", "https://github.com/org/repo/blob/abc123/cmd/main.go#L42", "cmd/main.go:42"},
+		{"docs/my guide.md", "", "docs/my guide.md"},
+		{"docs/guide.md", "https://github.com/org/repo/blob/abc123/docs/guide.md", "docs/guide.md"},
+		{"requirements.functional[2]", "", "requirements.functional[2]"},
+	} {
+		if got := l.URL(tt.location); got != tt.url {
+			t.Errorf("URL(%q) = %q, want %q", tt.location, got, tt.url)
+		}
+		if got := l.Text(tt.location); got != tt.text {
+			t.Errorf("Text(%q) = %q, want %q", tt.location, got, tt.text)
+		}
+	}
+	if got := (&LocationResolver{BaseURL: "https://git.example.com/repo"}).URL("a.go:1"); got != "https://git.example.com/repo/blob/HEAD/a.go#L1" {
+		t.Errorf("URL without a commit = %q", got)
+	}
+	var none *LocationResolver
+	if none.URL("a.go:1") != "" || none.Text("a.go#L1") != "a.go#L1" {
+		t.Error("nil resolver changed a location")
+	}
+}
+
+func TestTaskResultLocation(t *testing.T) {
+	tests := []struct {
+		metadata map[string]interface{}
+		want     string
+	}{
+		{nil, ""},
+		{map[string]interface{}{"line": 3}, ""},
+		{map[string]interface{}{"file": "a.go"}, "a.go"},
+		{map[string]interface{}{"file": "a.go", "line": 3}, "a.go:3"},
+		{map[string]interface{}{"file": "a.go", "line": float64(4)}, "a.go:4"},
+		{map[string]interface{}{"file": "a.go", "line": "5"}, "a.go:5"},
+	}
+	for _, tt := range tests {
+		if got := (TaskResult{Metadata: tt.metadata}).Location(); got != tt.want {
+			t.Errorf("Location() with %v = %q, want %q", tt.metadata, got, tt.want)
+		}
+	}
+}
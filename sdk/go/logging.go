@@ -0,0 +1,60 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Log formats accepted in LoggingConfig.Format.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// ParseLogLevel parses a LoggingConfig level: debug, info, warn (or
+// warning), or error, in any case. An empty level is info.
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level %q (want debug, info, warn, or error)", level)
+}
+
+// NewLogger returns a logger writing to w at the level and in the format
+// set by cfg. A nil cfg, or empty fields, mean info level and text format.
+func NewLogger(w io.Writer, cfg *LoggingConfig) (*slog.Logger, error) {
+	if cfg == nil {
+		cfg = &LoggingConfig{}
+	}
+	level, err := ParseLogLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(cfg.Format) {
+	case "", LogFormatText:
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case LogFormatJSON:
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("invalid log format %q (want text or json)", cfg.Format)
+}
+
+// Validate checks the level and format.
+func (c *LoggingConfig) Validate() error {
+	_, err := NewLogger(io.Discard, c)
+	return err
+}
+
+// discardLogger drops all records; it is the default for components that
+// take an optional logger.
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError + 1}))
@@ -0,0 +1,60 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want slog.Level
+	}{
+		{"", slog.LevelInfo},
+		{"debug", slog.LevelDebug},
+		{"INFO", slog.LevelInfo},
+		{"warn", slog.LevelWarn},
+		{"warning", slog.LevelWarn},
+		{"error", slog.LevelError},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogLevel(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLogLevel(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseLogLevel("trace"); err == nil {
+		t.Error("ParseLogLevel(trace) should fail")
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, &LoggingConfig{Level: "warn", Format: LogFormatJSON})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	logger.Info("hidden")
+	logger.Warn("shown", "target", "claude")
+	out := buf.String()
+	if strings.Contains(out, "hidden") || !strings.Contains(out, `"level":"WARN","msg":"shown","target":"claude"`) {
+		t.Errorf("log output = %s", out)
+	}
+
+	buf.Reset()
+	logger, err = NewLogger(&buf, nil)
+	if err != nil {
+		t.Fatalf("NewLogger(nil): %v", err)
+	}
+	logger.Debug("hidden")
+	logger.Info("shown")
+	if out := buf.String(); strings.Contains(out, "hidden") || !strings.Contains(out, "level=INFO msg=shown") {
+		t.Errorf("log output = %s", out)
+	}
+
+	if _, err := NewLogger(&buf, &LoggingConfig{Format: "logfmt"}); err == nil || !strings.Contains(err.Error(), "invalid log format") {
+		t.Errorf("NewLogger(logfmt) = %v", err)
+	}
+}
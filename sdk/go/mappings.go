@@ -1,5 +1,7 @@
 package multiagentspec
 
+import "fmt"
+
 // ClaudeCodeModels maps canonical model names to Claude Code identifiers.
 var ClaudeCodeModels = map[Model]string{
 	ModelHaiku:  "haiku",
@@ -21,6 +23,38 @@ var BedrockModels = map[Model]string{
 	ModelOpus:   "anthropic.claude-3-opus-20240229-v1:0",
 }
 
+// GeminiCLIModels maps canonical model names to Gemini CLI identifiers by
+// relative capability tier.
+var GeminiCLIModels = map[Model]string{
+	ModelHaiku:  "gemini-2.5-flash",
+	ModelSonnet: "gemini-2.5-pro",
+	ModelOpus:   "gemini-2.5-pro",
+}
+
+// LangChainModels maps canonical model names to LangChain "provider:model"
+// identifiers accepted by init_chat_model.
+var LangChainModels = map[Model]string{
+	ModelHaiku:  "anthropic:claude-3-5-haiku-latest",
+	ModelSonnet: "anthropic:claude-sonnet-4-0",
+	ModelOpus:   "anthropic:claude-opus-4-0",
+}
+
+// AnthropicModels maps canonical model names to Anthropic API model
+// identifiers.
+var AnthropicModels = map[Model]string{
+	ModelHaiku:  "claude-3-5-haiku-latest",
+	ModelSonnet: "claude-sonnet-4-0",
+	ModelOpus:   "claude-opus-4-0",
+}
+
+// OpenAIModels maps canonical model names to OpenAI model identifiers by
+// relative capability tier.
+var OpenAIModels = map[Model]string{
+	ModelHaiku:  "gpt-4o-mini",
+	ModelSonnet: "gpt-4o",
+	ModelOpus:   "o1",
+}
+
 // KiroCLITools maps canonical tool names to Kiro CLI identifiers.
 var KiroCLITools = map[Tool]string{
 	ToolWebSearch: "web_search",
@@ -47,6 +81,147 @@ var AgentKitTools = map[Tool]string{
 	ToolTask:      "shell",
 }
 
+// GeminiCLITools maps canonical tool names to Gemini CLI built-in tool names.
+var GeminiCLITools = map[Tool]string{
+	ToolWebSearch: "google_web_search",
+	ToolWebFetch:  "web_fetch",
+	ToolRead:      "read_file",
+	ToolWrite:     "write_file",
+	ToolGlob:      "glob",
+	ToolGrep:      "search_file_content",
+	ToolBash:      "run_shell_command",
+	ToolEdit:      "replace",
+	ToolTask:      "delegate_to_agent",
+}
+
+// OpenAITools maps canonical tool names to OpenAI hosted tool types. Tools
+// without a hosted equivalent are absent.
+var OpenAITools = map[Tool]string{
+	ToolWebSearch: "web_search",
+	ToolRead:      "file_search",
+	ToolGlob:      "file_search",
+	ToolGrep:      "file_search",
+	ToolBash:      "code_interpreter",
+}
+
+// ModelRegistry maps canonical model tiers to concrete model identifiers per
+// platform. Platforms or models without an entry resolve to the model name
+// unchanged, so concrete identifiers pass through.
+type ModelRegistry map[Platform]map[Model]string
+
+// DefaultModelRegistry returns a registry populated from the built-in
+// mapping tables. The returned registry is a copy and may be modified.
+func DefaultModelRegistry() ModelRegistry {
+	r := ModelRegistry{}
+	for platform, models := range map[Platform]map[Model]string{
+		PlatformClaudeCode:       ClaudeCodeModels,
+		PlatformTemporal:         ClaudeCodeModels,
+		PlatformKiroCLI:          KiroCLIModels,
+		PlatformGeminiCLI:        GeminiCLIModels,
+		PlatformADKGo:            GeminiCLIModels,
+		PlatformVertexAI:         GeminiCLIModels,
+		PlatformAWSAgentCore:     BedrockModels,
+		PlatformAWSBedrockAgents: BedrockModels,
+		PlatformLangGraph:        LangChainModels,
+		PlatformOpenAIAgents:     OpenAIModels,
+		PlatformSemanticKernel:   OpenAIModels,
+	} {
+		for model, id := range models {
+			r.Set(platform, model, id)
+		}
+	}
+	return r
+}
+
+// ModelMappings is the registry used by Target.ResolveModel. Programs may
+// add or replace entries before generating deployments.
+var ModelMappings = DefaultModelRegistry()
+
+// Set maps model to id on platform.
+func (r ModelRegistry) Set(platform Platform, model Model, id string) {
+	if r[platform] == nil {
+		r[platform] = make(map[Model]string)
+	}
+	r[platform][model] = id
+}
+
+// Resolve returns the identifier for model on platform.
+func (r ModelRegistry) Resolve(platform Platform, model Model) string {
+	if id, ok := r[platform][model]; ok {
+		return id
+	}
+	return string(model)
+}
+
+// ToolMapper maps canonical tool names to platform tool names per platform.
+// A platform with an entry supports only the canonical tools it lists;
+// platforms without an entry, and tool names that are not canonical (MCP
+// tools, permission patterns, platform built-ins), pass through unchanged.
+type ToolMapper map[Platform]map[Tool]string
+
+// DefaultToolMapper returns a mapper populated from the built-in mapping
+// tables. The returned mapper is a copy and may be modified.
+func DefaultToolMapper() ToolMapper {
+	claudeTools := make(map[Tool]string)
+	for _, tool := range Tools() {
+		claudeTools[tool] = string(tool)
+	}
+	m := ToolMapper{}
+	for platform, tools := range map[Platform]map[Tool]string{
+		PlatformClaudeCode:    claudeTools,
+		PlatformTemporal:      claudeTools,
+		PlatformKiroCLI:       KiroCLITools,
+		PlatformGeminiCLI:     GeminiCLITools,
+		PlatformAgentKitLocal: AgentKitTools,
+		PlatformOpenAIAgents:  OpenAITools,
+	} {
+		for tool, name := range tools {
+			m.Set(platform, tool, name)
+		}
+	}
+	return m
+}
+
+// ToolMappings is the mapper used by Target.MapTool. Programs may add or
+// replace entries before generating deployments.
+var ToolMappings = DefaultToolMapper()
+
+// Set maps tool to name on platform.
+func (m ToolMapper) Set(platform Platform, tool Tool, name string) {
+	if m[platform] == nil {
+		m[platform] = make(map[Tool]string)
+	}
+	m[platform][tool] = name
+}
+
+// Supports reports whether tool can be mapped on platform.
+func (m ToolMapper) Supports(platform Platform, tool Tool) bool {
+	_, err := m.Map(platform, tool)
+	return err == nil
+}
+
+// Map returns the name of tool on platform, or an error if the platform has
+// no equivalent for the canonical tool.
+func (m ToolMapper) Map(platform Platform, tool Tool) (string, error) {
+	tools, ok := m[platform]
+	if !ok || !isCanonicalTool(tool) {
+		return string(tool), nil
+	}
+	if name, ok := tools[tool]; ok {
+		return name, nil
+	}
+	return "", fmt.Errorf("tool %s is not supported on %s", tool, platform)
+}
+
+func isCanonicalTool(tool Tool) bool {
+	for _, t := range Tools() {
+		if t == tool {
+			return true
+		}
+	}
+	return false
+}
+
 // MapModelToClaudeCode converts a canonical model to Claude Code format.
 func MapModelToClaudeCode(model Model) string {
 	if mapped, ok := ClaudeCodeModels[model]; ok {
@@ -71,6 +246,38 @@ func MapModelToBedrock(model Model) string {
 	return string(model)
 }
 
+// MapModelToGeminiCLI converts a canonical model to Gemini CLI format.
+func MapModelToGeminiCLI(model Model) string {
+	if mapped, ok := GeminiCLIModels[model]; ok {
+		return mapped
+	}
+	return string(model)
+}
+
+// MapModelToLangChain converts a canonical model to LangChain format.
+func MapModelToLangChain(model Model) string {
+	if mapped, ok := LangChainModels[model]; ok {
+		return mapped
+	}
+	return string(model)
+}
+
+// MapModelToAnthropic converts a canonical model to Anthropic API format.
+func MapModelToAnthropic(model Model) string {
+	if mapped, ok := AnthropicModels[model]; ok {
+		return mapped
+	}
+	return string(model)
+}
+
+// MapModelToOpenAI converts a canonical model to OpenAI format.
+func MapModelToOpenAI(model Model) string {
+	if mapped, ok := OpenAIModels[model]; ok {
+		return mapped
+	}
+	return string(model)
+}
+
 // MapToolToKiroCLI converts a canonical tool to Kiro CLI format.
 func MapToolToKiroCLI(tool Tool) string {
 	if mapped, ok := KiroCLITools[tool]; ok {
@@ -86,3 +293,11 @@ func MapToolToAgentKit(tool Tool) string {
 	}
 	return string(tool)
 }
+
+// MapToolToGeminiCLI converts a canonical tool to Gemini CLI format.
+func MapToolToGeminiCLI(tool Tool) string {
+	if mapped, ok := GeminiCLITools[tool]; ok {
+		return mapped
+	}
+	return string(tool)
+}
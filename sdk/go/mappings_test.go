@@ -227,6 +227,96 @@ func TestMapToolToAgentKit(t *testing.T) {
 	}
 }
 
+func TestMapModelToGeminiCLI(t *testing.T) {
+	tests := []struct {
+		model Model
+		want  string
+	}{
+		{ModelHaiku, "gemini-2.5-flash"},
+		{ModelSonnet, "gemini-2.5-pro"},
+		{Model("gemini-2.0-flash"), "gemini-2.0-flash"}, // Fallback case
+	}
+
+	for _, tt := range tests {
+		got := MapModelToGeminiCLI(tt.model)
+		if got != tt.want {
+			t.Errorf("MapModelToGeminiCLI(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestMapModelToLangChain(t *testing.T) {
+	tests := []struct {
+		model Model
+		want  string
+	}{
+		{ModelSonnet, "anthropic:claude-sonnet-4-0"},
+		{Model("openai:gpt-4o"), "openai:gpt-4o"}, // Fallback case
+	}
+
+	for _, tt := range tests {
+		got := MapModelToLangChain(tt.model)
+		if got != tt.want {
+			t.Errorf("MapModelToLangChain(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestMapModelToAnthropic(t *testing.T) {
+	tests := []struct {
+		model Model
+		want  string
+	}{
+		{ModelHaiku, "claude-3-5-haiku-latest"},
+		{ModelOpus, "claude-opus-4-0"},
+		{Model("claude-3-7-sonnet-latest"), "claude-3-7-sonnet-latest"}, // Fallback case
+	}
+
+	for _, tt := range tests {
+		got := MapModelToAnthropic(tt.model)
+		if got != tt.want {
+			t.Errorf("MapModelToAnthropic(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestMapModelToOpenAI(t *testing.T) {
+	tests := []struct {
+		model Model
+		want  string
+	}{
+		{ModelHaiku, "gpt-4o-mini"},
+		{ModelSonnet, "gpt-4o"},
+		{Model("gpt-4.1"), "gpt-4.1"}, // Fallback case
+	}
+
+	for _, tt := range tests {
+		got := MapModelToOpenAI(tt.model)
+		if got != tt.want {
+			t.Errorf("MapModelToOpenAI(%q) = %q, want %q", tt.model, got, tt.want)
+		}
+	}
+}
+
+func TestMapToolToGeminiCLI(t *testing.T) {
+	tests := []struct {
+		tool Tool
+		want string
+	}{
+		{ToolRead, "read_file"},
+		{ToolGrep, "search_file_content"},
+		{ToolBash, "run_shell_command"},
+		{Tool("unknown"), "unknown"}, // Fallback case
+	}
+
+	for _, tt := range tests {
+		got := MapToolToGeminiCLI(tt.tool)
+		if got != tt.want {
+			t.Errorf("MapToolToGeminiCLI(%q) = %q, want %q", tt.tool, got, tt.want)
+		}
+	}
+}
+
 // Test completeness of mappings
 func TestMappingCompleteness(t *testing.T) {
 	models := []Model{ModelHaiku, ModelSonnet, ModelOpus}
@@ -253,6 +343,34 @@ func TestMappingCompleteness(t *testing.T) {
 		}
 	}
 
+	// Check GeminiCLIModels
+	for _, m := range models {
+		if _, ok := GeminiCLIModels[m]; !ok {
+			t.Errorf("GeminiCLIModels missing %q", m)
+		}
+	}
+
+	// Check LangChainModels
+	for _, m := range models {
+		if _, ok := LangChainModels[m]; !ok {
+			t.Errorf("LangChainModels missing %q", m)
+		}
+	}
+
+	// Check AnthropicModels
+	for _, m := range models {
+		if _, ok := AnthropicModels[m]; !ok {
+			t.Errorf("AnthropicModels missing %q", m)
+		}
+	}
+
+	// Check OpenAIModels
+	for _, m := range models {
+		if _, ok := OpenAIModels[m]; !ok {
+			t.Errorf("OpenAIModels missing %q", m)
+		}
+	}
+
 	// Check KiroCLITools
 	for _, tool := range tools {
 		if _, ok := KiroCLITools[tool]; !ok {
@@ -260,6 +378,13 @@ func TestMappingCompleteness(t *testing.T) {
 		}
 	}
 
+	// Check GeminiCLITools
+	for _, tool := range tools {
+		if _, ok := GeminiCLITools[tool]; !ok {
+			t.Errorf("GeminiCLITools missing %q", tool)
+		}
+	}
+
 	// Check AgentKitTools
 	for _, tool := range tools {
 		if _, ok := AgentKitTools[tool]; !ok {
@@ -267,3 +392,73 @@ func TestMappingCompleteness(t *testing.T) {
 		}
 	}
 }
+
+func TestModelRegistry(t *testing.T) {
+	r := DefaultModelRegistry()
+	tests := []struct {
+		platform Platform
+		model    Model
+		want     string
+	}{
+		{PlatformClaudeCode, ModelOpus, "opus"},
+		{PlatformAWSAgentCore, ModelSonnet, BedrockModels[ModelSonnet]},
+		{PlatformVertexAI, ModelHaiku, GeminiCLIModels[ModelHaiku]},
+		{PlatformOpenAIAgents, ModelOpus, OpenAIModels[ModelOpus]},
+		{PlatformClaudeCode, Model("claude-custom"), "claude-custom"}, // Unknown model
+		{PlatformN8N, ModelSonnet, "sonnet"},                          // Unmapped platform
+	}
+	for _, tt := range tests {
+		if got := r.Resolve(tt.platform, tt.model); got != tt.want {
+			t.Errorf("Resolve(%q, %q) = %q, want %q", tt.platform, tt.model, got, tt.want)
+		}
+	}
+
+	// Set changes the registry without touching the built-in tables
+	r.Set(PlatformClaudeCode, ModelOpus, "claude-opus-custom")
+	r.Set(PlatformN8N, ModelSonnet, "gpt-4o")
+	if got := r.Resolve(PlatformClaudeCode, ModelOpus); got != "claude-opus-custom" {
+		t.Errorf("Resolve after Set = %q", got)
+	}
+	if got := r.Resolve(PlatformN8N, ModelSonnet); got != "gpt-4o" {
+		t.Errorf("Resolve after Set on new platform = %q", got)
+	}
+	if ClaudeCodeModels[ModelOpus] != "opus" || ModelMappings.Resolve(PlatformClaudeCode, ModelOpus) != "opus" {
+		t.Error("Set on a DefaultModelRegistry copy modified the built-in mappings")
+	}
+}
+
+func TestToolMapper(t *testing.T) {
+	m := DefaultToolMapper()
+	tests := []struct {
+		platform Platform
+		tool     Tool
+		want     string
+		wantErr  bool
+	}{
+		{PlatformClaudeCode, ToolRead, "Read", false},
+		{PlatformGeminiCLI, ToolBash, "run_shell_command", false},
+		{PlatformKiroCLI, ToolWebFetch, "web_fetch", false},
+		{PlatformOpenAIAgents, ToolGrep, "file_search", false},
+		{PlatformOpenAIAgents, ToolEdit, "", true},
+		{PlatformClaudeCode, Tool("mcp__github__create_issue"), "mcp__github__create_issue", false}, // Not canonical
+		{PlatformADKGo, ToolEdit, "Edit", false},                                                    // Unmapped platform
+	}
+	for _, tt := range tests {
+		got, err := m.Map(tt.platform, tt.tool)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Map(%q, %q) = %q, %v; want %q, error %v", tt.platform, tt.tool, got, err, tt.want, tt.wantErr)
+		}
+		if m.Supports(tt.platform, tt.tool) == tt.wantErr {
+			t.Errorf("Supports(%q, %q) = %v", tt.platform, tt.tool, !tt.wantErr)
+		}
+	}
+
+	// Set changes the mapper without touching the built-in tables
+	m.Set(PlatformOpenAIAgents, ToolEdit, "code_interpreter")
+	if got, err := m.Map(PlatformOpenAIAgents, ToolEdit); err != nil || got != "code_interpreter" {
+		t.Errorf("Map after Set = %q, %v", got, err)
+	}
+	if _, ok := OpenAITools[ToolEdit]; ok || ToolMappings.Supports(PlatformOpenAIAgents, ToolEdit) {
+		t.Error("Set on a DefaultToolMapper copy modified the built-in mappings")
+	}
+}
@@ -0,0 +1,165 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"time"
+)
+
+// MemoryType is how long an agent remembers past interactions.
+type MemoryType string
+
+const (
+	// MemoryNone keeps nothing between calls.
+	MemoryNone MemoryType = "none"
+
+	// MemoryShortTerm keeps context for the duration of a session or run.
+	MemoryShortTerm MemoryType = "short-term"
+
+	// MemoryLongTerm persists context across sessions and runs.
+	MemoryLongTerm MemoryType = "long-term"
+)
+
+// MemoryTypes returns all memory types in schema order.
+func MemoryTypes() []MemoryType {
+	return []MemoryType{MemoryNone, MemoryShortTerm, MemoryLongTerm}
+}
+
+// rank orders memory types from none to long-term.
+func (t MemoryType) rank() int {
+	switch t {
+	case MemoryShortTerm:
+		return 1
+	case MemoryLongTerm:
+		return 2
+	}
+	return 0
+}
+
+// MemoryBackend is where agent memory is stored.
+type MemoryBackend string
+
+const (
+	// MemoryBackendFile stores memory in files under Memory.Path.
+	MemoryBackendFile MemoryBackend = "file"
+
+	// MemoryBackendSQLite stores memory in the SQLite database at
+	// Memory.Path.
+	MemoryBackendSQLite MemoryBackend = "sqlite"
+
+	// MemoryBackendVector stores memory in the vector store named by
+	// Memory.VectorStore.
+	MemoryBackendVector MemoryBackend = "vector"
+)
+
+// MemoryBackends returns all memory backends in schema order.
+func MemoryBackends() []MemoryBackend {
+	return []MemoryBackend{MemoryBackendFile, MemoryBackendSQLite, MemoryBackendVector}
+}
+
+// Memory configures what an agent remembers and where.
+type Memory struct {
+	// Type is how long the agent remembers past interactions.
+	Type MemoryType `json:"type" yaml:"type"`
+
+	// Backend is where memory is stored. Required for long-term memory;
+	// short-term memory defaults to the platform's in-process store.
+	Backend MemoryBackend `json:"backend,omitempty" yaml:"backend,omitempty"`
+
+	// Path is the directory for the file backend or the database file for
+	// the sqlite backend.
+	Path string `json:"path,omitempty" yaml:"path,omitempty" jsonschema_description:"Directory for the file backend or database file for the sqlite backend"`
+
+	// VectorStore references the vector store for the vector backend,
+	// e.g., a collection name or connection URI.
+	VectorStore string `json:"vector_store,omitempty" yaml:"vector_store,omitempty" jsonschema_description:"Vector store reference for the vector backend, e.g., a collection name or URI"`
+
+	// Retention is how long memories are kept (e.g., 24h, 720h); empty
+	// keeps them until removed.
+	Retention string `json:"retention,omitempty" yaml:"retention,omitempty" jsonschema_description:"How long memories are kept (e.g., 24h, 720h); empty keeps them until removed"`
+}
+
+// EffectiveType returns the memory type, treating a nil Memory as none.
+func (m *Memory) EffectiveType() MemoryType {
+	if m == nil || m.Type == "" {
+		return MemoryNone
+	}
+	return m.Type
+}
+
+// RetentionDuration returns Retention parsed as a duration, or zero when
+// it is empty.
+func (m *Memory) RetentionDuration() (time.Duration, error) {
+	if m == nil || m.Retention == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(m.Retention)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retention %q: %w", m.Retention, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("retention %q must be positive", m.Retention)
+	}
+	return d, nil
+}
+
+// Validate checks that the type and backend are known, that long-term
+// memory names a backend, that each backend has its location, and that
+// retention is a positive duration. Memory of type none takes no other
+// settings.
+func (m *Memory) Validate() error {
+	if m == nil {
+		return nil
+	}
+	switch m.Type {
+	case MemoryNone:
+		if m.Backend != "" || m.Path != "" || m.VectorStore != "" || m.Retention != "" {
+			return fmt.Errorf("memory type none takes no backend, path, vector_store, or retention")
+		}
+		return nil
+	case MemoryShortTerm:
+	case MemoryLongTerm:
+		if m.Backend == "" {
+			return fmt.Errorf("long-term memory requires a backend")
+		}
+	case "":
+		return fmt.Errorf("memory type is required")
+	default:
+		return fmt.Errorf("unknown memory type %q", m.Type)
+	}
+	switch m.Backend {
+	case "":
+		if m.Path != "" || m.VectorStore != "" {
+			return fmt.Errorf("path and vector_store require a backend")
+		}
+	case MemoryBackendFile, MemoryBackendSQLite:
+		if m.Path == "" {
+			return fmt.Errorf("%s backend requires a path", m.Backend)
+		}
+		if m.VectorStore != "" {
+			return fmt.Errorf("vector_store requires the vector backend")
+		}
+	case MemoryBackendVector:
+		if m.VectorStore == "" {
+			return fmt.Errorf("vector backend requires a vector_store")
+		}
+		if m.Path != "" {
+			return fmt.Errorf("path requires the file or sqlite backend")
+		}
+	default:
+		return fmt.Errorf("unknown memory backend %q", m.Backend)
+	}
+	_, err := m.RetentionDuration()
+	return err
+}
+
+// TeamMemoryType returns the longest-lived memory type among agents, for
+// platforms that configure memory once per team.
+func TeamMemoryType(agents []*Agent) MemoryType {
+	t := MemoryNone
+	for _, a := range agents {
+		if at := a.Memory.EffectiveType(); at.rank() > t.rank() {
+			t = at
+		}
+	}
+	return t
+}
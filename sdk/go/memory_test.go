@@ -0,0 +1,87 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryValidate(t *testing.T) {
+	valid := []*Memory{
+		nil,
+		{Type: MemoryNone},
+		{Type: MemoryShortTerm},
+		{Type: MemoryShortTerm, Retention: "2h"},
+		{Type: MemoryLongTerm, Backend: MemoryBackendSQLite, Path: "memory.db", Retention: "720h"},
+		{Type: MemoryLongTerm, Backend: MemoryBackendFile, Path: ".memory/"},
+		{Type: MemoryLongTerm, Backend: MemoryBackendVector, VectorStore: "qdrant://localhost:6334/notes"},
+	}
+	for _, m := range valid {
+		if err := m.Validate(); err != nil {
+			t.Errorf("%+v: Validate() = %v", m, err)
+		}
+	}
+
+	tests := []struct {
+		m    Memory
+		want string
+	}{
+		{Memory{}, "memory type is required"},
+		{Memory{Type: "forever"}, `unknown memory type "forever"`},
+		{Memory{Type: MemoryNone, Retention: "1h"}, "type none takes no"},
+		{Memory{Type: MemoryLongTerm}, "long-term memory requires a backend"},
+		{Memory{Type: MemoryShortTerm, Path: "x"}, "require a backend"},
+		{Memory{Type: MemoryLongTerm, Backend: "redis"}, `unknown memory backend "redis"`},
+		{Memory{Type: MemoryLongTerm, Backend: MemoryBackendSQLite}, "sqlite backend requires a path"},
+		{Memory{Type: MemoryLongTerm, Backend: MemoryBackendVector}, "vector backend requires a vector_store"},
+		{Memory{Type: MemoryLongTerm, Backend: MemoryBackendVector, VectorStore: "v", Path: "p"}, "path requires the file or sqlite backend"},
+		{Memory{Type: MemoryShortTerm, Retention: "30d"}, `invalid retention "30d"`},
+		{Memory{Type: MemoryShortTerm, Retention: "-1h"}, "must be positive"},
+	}
+	for _, tt := range tests {
+		err := tt.m.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: Validate() = %v, want %q", tt.m, err, tt.want)
+		}
+	}
+}
+
+func TestMemoryRetentionDuration(t *testing.T) {
+	m := &Memory{Type: MemoryShortTerm, Retention: "36h"}
+	if d, err := m.RetentionDuration(); err != nil || d != 36*time.Hour {
+		t.Errorf("RetentionDuration() = %v, %v", d, err)
+	}
+	var none *Memory
+	if d, err := none.RetentionDuration(); err != nil || d != 0 {
+		t.Errorf("nil RetentionDuration() = %v, %v", d, err)
+	}
+}
+
+func TestTeamMemoryType(t *testing.T) {
+	agents := []*Agent{{Name: "a"}, {Name: "b", Memory: &Memory{Type: MemoryShortTerm}}}
+	if got := TeamMemoryType(agents); got != MemoryShortTerm {
+		t.Errorf("TeamMemoryType = %q, want short-term", got)
+	}
+	agents = append(agents, &Agent{Name: "c", Memory: &Memory{Type: MemoryLongTerm, Backend: MemoryBackendFile, Path: "m"}})
+	if got := TeamMemoryType(agents); got != MemoryLongTerm {
+		t.Errorf("TeamMemoryType = %q, want long-term", got)
+	}
+	if got := TeamMemoryType(nil); got != MemoryNone {
+		t.Errorf("TeamMemoryType(nil) = %q, want none", got)
+	}
+}
+
+func TestMemorySchema(t *testing.T) {
+	agent := []byte(`{"name": "assistant", "memory": {"type": "long-term", "backend": "vector", "vector_store": "notes", "retention": "720h"}}`)
+	if err := ValidateAgentJSON(agent); err != nil {
+		t.Errorf("ValidateAgentJSON: %v", err)
+	}
+	for _, bad := range []string{
+		`{"name": "assistant", "memory": {"backend": "file"}}`,
+		`{"name": "assistant", "memory": {"type": "episodic"}}`,
+	} {
+		if err := ValidateAgentJSON([]byte(bad)); err == nil {
+			t.Errorf("ValidateAgentJSON accepted %s", bad)
+		}
+	}
+}
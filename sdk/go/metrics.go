@@ -0,0 +1,110 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WritePrometheusMetrics writes report as metrics in the Prometheus text
+// exposition format, for the node_exporter textfile collector or a
+// Pushgateway. Every series carries project and version labels; status
+// metrics are set to 1 for the current status only.
+//
+//	mas_report_status{project,version,status}
+//	mas_team_status{project,version,team,status}
+//	mas_team_tasks{project,version,team,status}
+//	mas_task_status{project,version,team,task,status,severity}
+//	mas_task_duration_seconds{project,version,team,task}
+//	mas_team_tokens{project,version,team,direction}
+//	mas_team_cost_usd{project,version,team}
+func WritePrometheusMetrics(w io.Writer, report *TeamReport) error {
+	status := report.Status
+	if status == "" {
+		status = report.ComputeOverallStatus()
+	}
+	base := []string{"project", report.Project, "version", report.Version}
+
+	var sb strings.Builder
+	writeMetricHeader(&sb, "mas_report_status", "gauge", "Overall report status; 1 for the current status.")
+	writeSample(&sb, "mas_report_status", labels(base, "status", string(status)), 1)
+
+	writeMetricHeader(&sb, "mas_team_status", "gauge", "Team status; 1 for the current status.")
+	for _, team := range report.Teams {
+		writeSample(&sb, "mas_team_status", labels(base, "team", team.ID, "status", string(team.Status)), 1)
+	}
+
+	writeMetricHeader(&sb, "mas_team_tasks", "gauge", "Number of team tasks by status.")
+	for _, team := range report.Teams {
+		counts := map[Status]int{}
+		for _, task := range team.Tasks {
+			counts[task.Status]++
+		}
+		for _, s := range Statuses() {
+			writeSample(&sb, "mas_team_tasks", labels(base, "team", team.ID, "status", string(s)), float64(counts[s]))
+		}
+	}
+
+	writeMetricHeader(&sb, "mas_task_status", "gauge", "Task status; 1 for the current status.")
+	for _, team := range report.Teams {
+		for _, task := range team.Tasks {
+			writeSample(&sb, "mas_task_status",
+				labels(base, "team", team.ID, "task", task.ID, "status", string(task.Status), "severity", task.Severity), 1)
+		}
+	}
+
+	writeMetricHeader(&sb, "mas_task_duration_seconds", "gauge", "Task execution time in seconds.")
+	for _, team := range report.Teams {
+		for _, task := range team.Tasks {
+			if task.DurationMs > 0 {
+				writeSample(&sb, "mas_task_duration_seconds",
+					labels(base, "team", team.ID, "task", task.ID), float64(task.DurationMs)/1000)
+			}
+		}
+	}
+
+	writeMetricHeader(&sb, "mas_team_tokens", "gauge", "LLM tokens used by the team, by direction (in or out).")
+	for i := range report.Teams {
+		if u := report.Teams[i].TotalUsage(); !u.IsZero() {
+			id := report.Teams[i].ID
+			writeSample(&sb, "mas_team_tokens", labels(base, "team", id, "direction", "in"), float64(u.TokensIn))
+			writeSample(&sb, "mas_team_tokens", labels(base, "team", id, "direction", "out"), float64(u.TokensOut))
+		}
+	}
+
+	writeMetricHeader(&sb, "mas_team_cost_usd", "gauge", "LLM cost of the team in US dollars.")
+	for i := range report.Teams {
+		if u := report.Teams[i].TotalUsage(); !u.IsZero() {
+			writeSample(&sb, "mas_team_cost_usd", labels(base, "team", report.Teams[i].ID), u.CostUSD)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeMetricHeader(sb *strings.Builder, name, typ, help string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func writeSample(sb *strings.Builder, name, labels string, value float64) {
+	fmt.Fprintf(sb, "%s{%s} %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// labels formats base followed by kv, both as name, value pairs, as a
+// Prometheus label set. Labels with empty values are left out.
+func labels(base []string, kv ...string) string {
+	pairs := append(append([]string(nil), base...), kv...)
+	var parts []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			continue
+		}
+		parts = append(parts, pairs[i]+`="`+labelEscaper.Replace(pairs[i+1])+`"`)
+	}
+	return strings.Join(parts, ",")
+}
+
+// labelEscaper escapes Prometheus label values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
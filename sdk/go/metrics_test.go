@@ -0,0 +1,52 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWritePrometheusMetrics(t *testing.T) {
+	report := &TeamReport{
+		Project: "github.com/example/app",
+		Version: "v1.2.0",
+		Teams: []TeamSection{
+			{
+				ID:     "security",
+				Status: StatusWarn,
+				Tasks: []TaskResult{
+					{ID: "deps", Status: StatusWarn, Severity: "high", DurationMs: 1500, TokensIn: 1200, TokensOut: 300, CostUSD: 0.02},
+					{ID: "say \"hi\"", Status: StatusGo},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePrometheusMetrics(&buf, report); err != nil {
+		t.Fatalf("WritePrometheusMetrics: %v", err)
+	}
+	out := buf.String()
+
+	base := `project="github.com/example/app",version="v1.2.0"`
+	for _, want := range []string{
+		"# HELP mas_report_status Overall report status; 1 for the current status.\n# TYPE mas_report_status gauge\n",
+		"mas_report_status{" + base + `,status="WARN"} 1` + "\n",
+		"mas_team_status{" + base + `,team="security",status="WARN"} 1` + "\n",
+		"mas_team_tasks{" + base + `,team="security",status="GO"} 1` + "\n",
+		"mas_team_tasks{" + base + `,team="security",status="NO-GO"} 0` + "\n",
+		"mas_task_status{" + base + `,team="security",task="deps",status="WARN",severity="high"} 1` + "\n",
+		"mas_task_status{" + base + `,team="security",task="say \"hi\"",status="GO"} 1` + "\n",
+		"mas_task_duration_seconds{" + base + `,team="security",task="deps"} 1.5` + "\n",
+		"mas_team_tokens{" + base + `,team="security",direction="in"} 1200` + "\n",
+		"mas_team_tokens{" + base + `,team="security",direction="out"} 300` + "\n",
+		"mas_team_cost_usd{" + base + `,team="security"} 0.02` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "mas_task_duration_seconds{") != 1 {
+		t.Errorf("duration written for task without one:\n%s", out)
+	}
+}
@@ -0,0 +1,238 @@
+// Package migrate upgrades multi-agent-spec documents written against older
+// spec versions to the current SpecVersion.
+//
+// Migration operates on the generic JSON form of a document so that shapes
+// which no longer exist in the Go types (e.g., report "checks" arrays or
+// deployment "config" blobs) can still be read and rewritten. Every applied
+// transform is recorded as a Change so callers can show what was modified.
+//
+// Example:
+//
+//	res, err := migrate.Migrate(data)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, c := range res.Changes {
+//	    fmt.Printf("%s: %s\n", c.Path, c.Description)
+//	}
+//	os.WriteFile(path, res.Document, 0o644)
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Change describes a single modification applied during migration.
+type Change struct {
+	// Transform is the ID of the transform that made the change.
+	Transform string `json:"transform"`
+
+	// Path is the JSON path of the modified value (e.g., "teams[0].checks").
+	Path string `json:"path"`
+
+	// Description explains the change in human-readable form.
+	Description string `json:"description"`
+}
+
+// Result is the outcome of migrating a document.
+type Result struct {
+	// Kind is the detected or requested document kind.
+	Kind multiagentspec.SchemaKind `json:"kind"`
+
+	// FromVersion is the spec version declared by the document's $schema,
+	// or empty if the document did not declare a versioned schema.
+	FromVersion string `json:"from_version,omitempty"`
+
+	// ToVersion is the spec version the document was migrated to.
+	ToVersion string `json:"to_version"`
+
+	// Document is the migrated document as indented JSON.
+	Document []byte `json:"-"`
+
+	// Changes lists the applied modifications in order.
+	Changes []Change `json:"changes"`
+}
+
+// Changed returns true if any transform modified the document.
+func (r *Result) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// Migrate detects the kind of a JSON document and upgrades it to SpecVersion.
+func Migrate(data []byte) (*Result, error) {
+	doc, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	kind := DetectKind(doc)
+	if kind == "" {
+		return nil, fmt.Errorf("unable to detect document kind")
+	}
+	return migrate(doc, kind)
+}
+
+// MigrateKind upgrades a JSON document of a known kind to SpecVersion.
+func MigrateKind(data []byte, kind multiagentspec.SchemaKind) (*Result, error) {
+	if multiagentspec.SchemaPath(kind) == "" {
+		return nil, fmt.Errorf("unknown document kind %q", kind)
+	}
+	doc, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return migrate(doc, kind)
+}
+
+// DetectKind infers the document kind from its $schema URL or, failing that,
+// from its shape. Returns an empty kind if the document is not recognized.
+func DetectKind(doc map[string]interface{}) multiagentspec.SchemaKind {
+	if s, ok := doc["$schema"].(string); ok {
+		if kind, _, ok := multiagentspec.ParseSchemaURL(s); ok {
+			return kind
+		}
+	}
+
+	switch {
+	case has(doc, "teams"):
+		return multiagentspec.SchemaTeamReport
+	case has(doc, "agent_id") && has(doc, "step_id"):
+		return multiagentspec.SchemaAgentResult
+	case has(doc, "targets"):
+		return multiagentspec.SchemaDeployment
+	case has(doc, "agents") && has(doc, "name"):
+		return multiagentspec.SchemaTeam
+	case has(doc, "from") && has(doc, "content"):
+		return multiagentspec.SchemaMessage
+	case has(doc, "name") && (has(doc, "inputs") || has(doc, "outputs") || has(doc, "implementation")):
+		return multiagentspec.SchemaSkill
+	case has(doc, "name") && has(doc, "categories"):
+		return multiagentspec.SchemaRubric
+	case has(doc, "name") && (has(doc, "parameters") || has(doc, "binding")):
+		return multiagentspec.SchemaTool
+	case has(doc, "name") && has(doc, "template"):
+		return multiagentspec.SchemaPrompt
+	case has(doc, "name"):
+		return multiagentspec.SchemaAgent
+	default:
+		return ""
+	}
+}
+
+func migrate(doc map[string]interface{}, kind multiagentspec.SchemaKind) (*Result, error) {
+	res := &Result{
+		Kind:      kind,
+		ToVersion: multiagentspec.SpecVersion,
+		Changes:   []Change{},
+	}
+	if s, ok := doc["$schema"].(string); ok {
+		if _, version, ok := multiagentspec.ParseSchemaURL(s); ok {
+			res.FromVersion = version
+		}
+	}
+
+	for _, t := range transforms {
+		if !t.appliesTo(kind) {
+			continue
+		}
+		rec := &recorder{transform: t.ID}
+		t.apply(doc, kind, rec)
+		res.Changes = append(res.Changes, rec.changes...)
+	}
+
+	out, err := encode(doc, kind)
+	if err != nil {
+		return nil, err
+	}
+	res.Document = out
+	return res, nil
+}
+
+// decode parses JSON into a generic object, preserving number precision.
+func decode(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse json: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("document is not a JSON object")
+	}
+	return doc, nil
+}
+
+// encode serializes the migrated document. When the document round-trips
+// through the current Go types without loss or additions, the typed form is
+// used so fields come out in canonical order; otherwise the generic form is
+// written unchanged.
+func encode(doc map[string]interface{}, kind multiagentspec.SchemaKind) ([]byte, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal document: %w", err)
+	}
+
+	if typed := newTyped(kind); typed != nil {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(typed); err == nil {
+			out, err := json.MarshalIndent(typed, "", "  ")
+			if err == nil && sameJSON(raw, out) {
+				return out, nil
+			}
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// sameJSON reports whether two JSON documents are semantically identical.
+func sameJSON(a, b []byte) bool {
+	da, err := decode(a)
+	if err != nil {
+		return false
+	}
+	db, err := decode(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(da, db)
+}
+
+// newTyped returns a pointer to the Go type for kind, or nil if the kind
+// has no canonical Go representation.
+func newTyped(kind multiagentspec.SchemaKind) interface{} {
+	switch kind {
+	case multiagentspec.SchemaAgent:
+		return &multiagentspec.Agent{}
+	case multiagentspec.SchemaTeam:
+		return &multiagentspec.Team{}
+	case multiagentspec.SchemaDeployment:
+		return &multiagentspec.Deployment{}
+	case multiagentspec.SchemaTeamReport:
+		return &multiagentspec.TeamReport{}
+	case multiagentspec.SchemaAgentResult:
+		return &multiagentspec.AgentResult{}
+	case multiagentspec.SchemaMessage:
+		return &multiagentspec.Message{}
+	case multiagentspec.SchemaSkill:
+		return &multiagentspec.Skill{}
+	case multiagentspec.SchemaTool:
+		return &multiagentspec.ToolSpec{}
+	case multiagentspec.SchemaRubric:
+		return &multiagentspec.Rubric{}
+	case multiagentspec.SchemaPrompt:
+		return &multiagentspec.PromptTemplate{}
+	default:
+		return nil
+	}
+}
+
+func has(doc map[string]interface{}, key string) bool {
+	_, ok := doc[key]
+	return ok
+}
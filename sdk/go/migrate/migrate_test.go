@@ -0,0 +1,218 @@
+package migrate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestMigrateTeamWorkflowType(t *testing.T) {
+	tests := []struct {
+		old  string
+		want multiagentspec.WorkflowType
+	}{
+		{"sequential", multiagentspec.WorkflowChain},
+		{"parallel", multiagentspec.WorkflowScatter},
+		{"dag", multiagentspec.WorkflowGraph},
+		{"orchestrated", multiagentspec.WorkflowCrew},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.old, func(t *testing.T) {
+			input := `{"name":"t","version":"1.0.0","agents":["a"],"orchestrator":"a","workflow":{"type":"` + tt.old + `"}}`
+			res, err := Migrate([]byte(input))
+			if err != nil {
+				t.Fatalf("Migrate failed: %v", err)
+			}
+			if res.Kind != multiagentspec.SchemaTeam {
+				t.Errorf("Kind = %q, want %q", res.Kind, multiagentspec.SchemaTeam)
+			}
+			if len(res.Changes) != 1 || res.Changes[0].Path != "workflow.type" {
+				t.Fatalf("Changes = %+v, want one workflow.type change", res.Changes)
+			}
+
+			var team multiagentspec.Team
+			if err := json.Unmarshal(res.Document, &team); err != nil {
+				t.Fatalf("unmarshal migrated team: %v", err)
+			}
+			if team.Workflow.Type != tt.want {
+				t.Errorf("Workflow.Type = %q, want %q", team.Workflow.Type, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigrateReportChecks(t *testing.T) {
+	input := `{
+  "$schema": "https://raw.githubusercontent.com/agentplexus/multi-agent-spec/main/schema/report/team-report.schema.json",
+  "project": "p",
+  "version": "v1",
+  "phase": "REVIEW",
+  "status": "GO",
+  "generated_at": "2026-01-01T00:00:00Z",
+  "teams": [
+    {"id": "qa", "name": "qa", "status": "GO", "checks": [{"id": "tests", "status": "GO"}]}
+  ]
+}`
+	res, err := Migrate([]byte(input))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if len(res.Changes) != 2 {
+		t.Fatalf("expected 2 changes, got %+v", res.Changes)
+	}
+	if res.Changes[0].Path != "teams[0].checks" {
+		t.Errorf("first change path = %q, want teams[0].checks", res.Changes[0].Path)
+	}
+
+	report, err := multiagentspec.ParseTeamReport(res.Document)
+	if err != nil {
+		t.Fatalf("ParseTeamReport failed: %v", err)
+	}
+	if len(report.Teams[0].Tasks) != 1 || report.Teams[0].Tasks[0].ID != "tests" {
+		t.Errorf("Tasks = %+v, want migrated tests task", report.Teams[0].Tasks)
+	}
+	if report.Schema != multiagentspec.SchemaURL(multiagentspec.SchemaTeamReport) {
+		t.Errorf("Schema = %q, want versioned URL", report.Schema)
+	}
+}
+
+func TestMigrateAgentResultChecks(t *testing.T) {
+	input := `{"agent_id":"qa","step_id":"qa-validation","status":"GO","executed_at":"2026-01-01T00:00:00Z","checks":[{"id":"lint","status":"WARN"}]}`
+	res, err := Migrate([]byte(input))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if res.Kind != multiagentspec.SchemaAgentResult {
+		t.Fatalf("Kind = %q, want agent-result", res.Kind)
+	}
+	result, err := multiagentspec.ParseAgentResult(res.Document)
+	if err != nil {
+		t.Fatalf("ParseAgentResult failed: %v", err)
+	}
+	if len(result.Tasks) != 1 || result.Tasks[0].Status != multiagentspec.StatusWarn {
+		t.Errorf("Tasks = %+v, want migrated lint task", result.Tasks)
+	}
+}
+
+func TestMigrateDeploymentConfig(t *testing.T) {
+	input := `{
+  "team": "stats",
+  "targets": [
+    {"name": "local", "platform": "claude-code", "config": {"agentDir": ".claude/agents", "format": "markdown"}},
+    {"name": "k8s", "platform": "gcp-gke", "config": {"namespace": "agents", "helmChart": true}},
+    {"name": "odd", "platform": "custom", "config": {"x": 1}}
+  ]
+}`
+	res, err := Migrate([]byte(input))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if len(res.Changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", res.Changes)
+	}
+	if !strings.Contains(res.Changes[2].Description, "unknown platform") {
+		t.Errorf("expected unknown platform note, got %q", res.Changes[2].Description)
+	}
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal(res.Document, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	targets := doc["targets"].([]interface{})
+	local := targets[0].(map[string]interface{})
+	if _, ok := local["claudeCode"]; !ok {
+		t.Errorf("expected claudeCode config on first target: %v", local)
+	}
+	if _, ok := local["config"]; ok {
+		t.Errorf("legacy config should be removed: %v", local)
+	}
+	gke := targets[1].(map[string]interface{})
+	if _, ok := gke["kubernetes"]; !ok {
+		t.Errorf("expected kubernetes config on gke target: %v", gke)
+	}
+	odd := targets[2].(map[string]interface{})
+	if _, ok := odd["config"]; !ok {
+		t.Errorf("unknown platform config should be preserved: %v", odd)
+	}
+}
+
+func TestMigrateCurrentDocumentIsNoOp(t *testing.T) {
+	team := multiagentspec.NewTeam("t", "1.0.0").
+		WithAgents("a", "b").
+		WithWorkflow(&multiagentspec.Workflow{Type: multiagentspec.WorkflowGraph})
+	data, _ := json.Marshal(team)
+
+	res, err := Migrate(data)
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if res.Changed() {
+		t.Errorf("expected no changes, got %+v", res.Changes)
+	}
+}
+
+func TestMigrateKindUnknown(t *testing.T) {
+	if _, err := MigrateKind([]byte(`{}`), "bogus"); err == nil {
+		t.Error("expected error for unknown kind")
+	}
+	if _, err := Migrate([]byte(`{"foo": 1}`)); err == nil {
+		t.Error("expected error for undetectable document")
+	}
+	if _, err := Migrate([]byte(`[]`)); err == nil {
+		t.Error("expected error for non-object document")
+	}
+}
+
+func TestMigratePreservesUnknownFields(t *testing.T) {
+	input := `{"name":"t","version":"1","agents":[],"workflow":{"type":"dag"},"x-extra":true}`
+	res, err := Migrate([]byte(input))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if !strings.Contains(string(res.Document), "x-extra") {
+		t.Errorf("unknown field dropped: %s", res.Document)
+	}
+}
+
+func TestMigrateDetectsSkill(t *testing.T) {
+	res, err := Migrate([]byte(`{"name":"code-review","inputs":{"type":"object"}}`))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if res.Kind != multiagentspec.SchemaSkill {
+		t.Errorf("Kind = %q, want %q", res.Kind, multiagentspec.SchemaSkill)
+	}
+}
+
+func TestMigrateDetectsTool(t *testing.T) {
+	res, err := Migrate([]byte(`{"name":"jira_search","binding":{"type":"mcp","server":"jira"}}`))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if res.Kind != multiagentspec.SchemaTool {
+		t.Errorf("Kind = %q, want %q", res.Kind, multiagentspec.SchemaTool)
+	}
+}
+
+func TestMigrateDetectsRubric(t *testing.T) {
+	res, err := Migrate([]byte(`{"name":"prd-quality","categories":[{"id":"scope"}]}`))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if res.Kind != multiagentspec.SchemaRubric {
+		t.Errorf("Kind = %q, want %q", res.Kind, multiagentspec.SchemaRubric)
+	}
+}
+
+func TestMigrateDetectsPrompt(t *testing.T) {
+	res, err := Migrate([]byte(`{"name":"prd-review","version":"1.0.0","template":"Grade the PRD."}`))
+	if err != nil {
+		t.Fatalf("Migrate failed: %v", err)
+	}
+	if res.Kind != multiagentspec.SchemaPrompt {
+		t.Errorf("Kind = %q, want %q", res.Kind, multiagentspec.SchemaPrompt)
+	}
+}
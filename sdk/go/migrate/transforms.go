@@ -0,0 +1,224 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Transform is a single, idempotent upgrade step.
+type Transform struct {
+	// ID is the stable transform identifier recorded in Change entries.
+	ID string
+
+	// Since is the spec version that introduced the shape this transform produces.
+	Since string
+
+	// Description summarizes what the transform does.
+	Description string
+
+	// Kinds are the document kinds the transform applies to.
+	Kinds []multiagentspec.SchemaKind
+
+	apply func(doc map[string]interface{}, kind multiagentspec.SchemaKind, rec *recorder)
+}
+
+func (t Transform) appliesTo(kind multiagentspec.SchemaKind) bool {
+	for _, k := range t.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// transforms are applied in order. Each must be a no-op on current documents.
+var transforms = []Transform{
+	{
+		ID:          "checks-to-tasks",
+		Since:       "0.2.0",
+		Description: "Rename report checks arrays to tasks",
+		Kinds:       []multiagentspec.SchemaKind{multiagentspec.SchemaTeamReport, multiagentspec.SchemaAgentResult},
+		apply:       checksToTasks,
+	},
+	{
+		ID:          "typed-target-config",
+		Since:       "0.4.0",
+		Description: "Move untyped target config into the platform-specific config field",
+		Kinds:       []multiagentspec.SchemaKind{multiagentspec.SchemaDeployment},
+		apply:       typedTargetConfig,
+	},
+	{
+		ID:          "workflow-type-rename",
+		Since:       "0.6.0",
+		Description: "Rename workflow types sequential/parallel/dag/orchestrated to chain/scatter/graph/crew",
+		Kinds:       []multiagentspec.SchemaKind{multiagentspec.SchemaTeam},
+		apply:       workflowTypeRename,
+	},
+	{
+		ID:          "schema-url",
+		Since:       multiagentspec.SpecVersion,
+		Description: "Point $schema at the versioned schema URL for the current spec",
+		Kinds: []multiagentspec.SchemaKind{
+			multiagentspec.SchemaDeployment,
+			multiagentspec.SchemaTeamReport,
+			multiagentspec.SchemaAgentResult,
+		},
+		apply: schemaURL,
+	},
+}
+
+// Transforms returns the registered transforms in application order.
+func Transforms() []Transform {
+	out := make([]Transform, len(transforms))
+	copy(out, transforms)
+	return out
+}
+
+// recorder collects changes made by a single transform.
+type recorder struct {
+	transform string
+	changes   []Change
+}
+
+func (r *recorder) record(path, format string, args ...interface{}) {
+	r.changes = append(r.changes, Change{
+		Transform:   r.transform,
+		Path:        path,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// checksToTasks renames "checks" to "tasks" on agent results and report teams.
+func checksToTasks(doc map[string]interface{}, kind multiagentspec.SchemaKind, rec *recorder) {
+	if kind == multiagentspec.SchemaAgentResult {
+		renameKey(doc, "checks", "tasks", "", rec)
+		return
+	}
+	teams, _ := doc["teams"].([]interface{})
+	for i, t := range teams {
+		if team, ok := t.(map[string]interface{}); ok {
+			renameKey(team, "checks", "tasks", fmt.Sprintf("teams[%d]", i), rec)
+		}
+	}
+}
+
+// targetConfigKeys maps platforms to their typed config field in Target.
+var targetConfigKeys = map[multiagentspec.Platform]string{
+	multiagentspec.PlatformClaudeCode:    "claudeCode",
+	multiagentspec.PlatformGeminiCLI:     "geminiCli",
+	multiagentspec.PlatformKiroCLI:       "kiroCli",
+	multiagentspec.PlatformADKGo:         "adkGo",
+	multiagentspec.PlatformCrewAI:        "crewai",
+	multiagentspec.PlatformAutoGen:       "autogen",
+	multiagentspec.PlatformAWSAgentCore:  "awsAgentCore",
+	multiagentspec.PlatformAWSEKS:        "kubernetes",
+	multiagentspec.PlatformAzureAKS:      "kubernetes",
+	multiagentspec.PlatformGCPGKE:        "kubernetes",
+	multiagentspec.PlatformKubernetes:    "kubernetes",
+	multiagentspec.PlatformDockerCompose: "dockerCompose",
+	multiagentspec.PlatformAgentKitLocal: "agentKitLocal",
+}
+
+// typedTargetConfig converts the pre-0.4 "config" blob into typed config fields.
+func typedTargetConfig(doc map[string]interface{}, _ multiagentspec.SchemaKind, rec *recorder) {
+	targets, _ := doc["targets"].([]interface{})
+	for i, t := range targets {
+		target, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		config, ok := target["config"]
+		if !ok {
+			continue
+		}
+		path := fmt.Sprintf("targets[%d]", i)
+		platform, _ := target["platform"].(string)
+		key, known := targetConfigKeys[multiagentspec.Platform(platform)]
+		if !known {
+			rec.record(path+".config", "left untyped config in place: unknown platform %q", platform)
+			continue
+		}
+		delete(target, "config")
+		if _, exists := target[key]; exists {
+			rec.record(path+".config", "dropped legacy config; %s is already set", key)
+			continue
+		}
+		target[key] = config
+		rec.record(path+".config", "moved config to %s", key)
+	}
+}
+
+// workflowTypeRenames maps pre-0.6 workflow types to their replacements.
+var workflowTypeRenames = map[string]multiagentspec.WorkflowType{
+	"sequential":   multiagentspec.WorkflowChain,
+	"parallel":     multiagentspec.WorkflowScatter,
+	"dag":          multiagentspec.WorkflowGraph,
+	"orchestrated": multiagentspec.WorkflowCrew,
+}
+
+// workflowTypeRename rewrites legacy workflow type names.
+func workflowTypeRename(doc map[string]interface{}, _ multiagentspec.SchemaKind, rec *recorder) {
+	workflow, ok := doc["workflow"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	old, _ := workflow["type"].(string)
+	replacement, ok := workflowTypeRenames[old]
+	if !ok {
+		return
+	}
+	workflow["type"] = string(replacement)
+	if replacement == multiagentspec.WorkflowCrew {
+		rec.record("workflow.type", "renamed %q to %q; the orchestrator acts as crew lead", old, replacement)
+		return
+	}
+	rec.record("workflow.type", "renamed %q to %q", old, replacement)
+}
+
+// schemaURL rewrites recognized $schema URLs to the current versioned URL.
+// Relative or third-party schema references are left untouched.
+func schemaURL(doc map[string]interface{}, kind multiagentspec.SchemaKind, rec *recorder) {
+	current, ok := doc["$schema"].(string)
+	if !ok {
+		return
+	}
+	if _, _, known := multiagentspec.ParseSchemaURL(current); !known {
+		return
+	}
+	want := multiagentspec.SchemaURL(kind)
+	if current == want {
+		return
+	}
+	doc["$schema"] = want
+	rec.record("$schema", "updated %s to %s", shortURL(current), shortURL(want))
+}
+
+// renameKey moves obj[from] to obj[to] unless obj[to] is already present.
+func renameKey(obj map[string]interface{}, from, to, path string, rec *recorder) {
+	v, ok := obj[from]
+	if !ok {
+		return
+	}
+	delete(obj, from)
+	full := joinPath(path, from)
+	if _, exists := obj[to]; exists {
+		rec.record(full, "dropped legacy %s; %s is already set", from, to)
+		return
+	}
+	obj[to] = v
+	rec.record(full, "renamed %s to %s", from, to)
+}
+
+func joinPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
+
+// shortURL trims the common raw-content prefix for readable change logs.
+func shortURL(url string) string {
+	return strings.TrimPrefix(url, "https://raw.githubusercontent.com/")
+}
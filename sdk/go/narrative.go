@@ -5,6 +5,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
 )
 
@@ -25,28 +26,91 @@ type NarrativeSection struct {
 //
 //	pandoc report.md -o report.pdf --pdf-engine=xelatex
 type NarrativeRenderer struct {
-	w io.Writer
+	w           io.Writer
+	catalog     *Catalog
+	noDurations bool
+	locations   *LocationResolver
+}
+
+// NarrativeOption configures a NarrativeRenderer.
+type NarrativeOption func(*NarrativeRenderer)
+
+// WithCatalog translates status words and section headings with c, e.g.,
+// a catalog from CatalogFor or LoadCatalog.
+func WithCatalog(c *Catalog) NarrativeOption {
+	return func(r *NarrativeRenderer) {
+		r.catalog = c
+	}
+}
+
+// WithNarrativeDurations sets whether task tables have a Duration column
+// for teams with timed tasks. Durations are shown by default.
+func WithNarrativeDurations(show bool) NarrativeOption {
+	return func(r *NarrativeRenderer) {
+		r.noDurations = !show
+	}
+}
+
+// WithNarrativeLocations links the source locations of issues and file
+// tasks to the repository l points to. File tasks show theirs before
+// their detail.
+func WithNarrativeLocations(l *LocationResolver) NarrativeOption {
+	return func(r *NarrativeRenderer) {
+		r.locations = l
+	}
 }
 
 // NewNarrativeRenderer creates a new NarrativeRenderer writing to w.
-func NewNarrativeRenderer(w io.Writer) *NarrativeRenderer {
-	return &NarrativeRenderer{w: w}
+func NewNarrativeRenderer(w io.Writer, opts ...NarrativeOption) *NarrativeRenderer {
+	r := &NarrativeRenderer{w: w}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 // Render renders the report as Pandoc-friendly Markdown.
-// No emojis are used - status is rendered as text (PASS, FAIL, WARNING, SKIP).
+// No emojis are used - status is rendered as text (PASS, FAIL, WARNING,
+// SKIP, or their translations). Teams are sorted by DAG order in place;
+// RenderNarrative leaves the report unchanged.
 func (r *NarrativeRenderer) Render(report *TeamReport) error {
-	report.SortByDAG()
+	_ = report.SortByDAG() // the cycle is noted with the overall status
+	return r.render(report)
+}
+
+// RenderNarrative renders report in the narrative format to w, configured
+// by opts. Unlike NarrativeRenderer.Render, it sorts a copy of the
+// report's teams, leaving report unchanged, so it is safe for concurrent
+// use, including on the same report.
+func RenderNarrative(w io.Writer, report *TeamReport, opts ...NarrativeOption) error {
+	return NewNarrativeRenderer(w, opts...).render(report.sortedByDAG())
+}
 
-	tmpl, err := template.New("narrative").Funcs(narrativeFuncs()).Parse(NarrativeTemplate)
+var (
+	narrativeTemplateOnce sync.Once
+	narrativeTemplate     *template.Template
+	narrativeTemplateErr  error
+)
+
+// render renders the sorted report. NarrativeTemplate is parsed once on
+// first use; each render gets a copy with r's functions.
+func (r *NarrativeRenderer) render(report *TeamReport) error {
+	narrativeTemplateOnce.Do(func() {
+		narrativeTemplate, narrativeTemplateErr = template.New("narrative").Funcs((&NarrativeRenderer{}).funcs()).Parse(NarrativeTemplate)
+	})
+	if narrativeTemplateErr != nil {
+		return fmt.Errorf("parsing template: %w", narrativeTemplateErr)
+	}
+	tmpl, err := narrativeTemplate.Clone()
 	if err != nil {
-		return fmt.Errorf("parsing template: %w", err)
+		return err
 	}
-	return tmpl.Execute(r.w, report)
+	return tmpl.Funcs(r.funcs()).Execute(r.w, report)
 }
 
 // QuickNarrativeRenderer renders TeamReport using quicktemplate (compile-time type-safe).
 // This is an alternative to NarrativeRenderer that uses generated code instead of reflection.
+// It renders English only, without task durations.
 type QuickNarrativeRenderer struct {
 	w io.Writer
 }
@@ -59,26 +123,107 @@ func NewQuickNarrativeRenderer(w io.Writer) *QuickNarrativeRenderer {
 // Render renders the report using quicktemplate.
 // It automatically sorts teams by DAG order before rendering.
 func (r *QuickNarrativeRenderer) Render(report *TeamReport) error {
-	report.SortByDAG()
+	_ = report.SortByDAG() // the cycle is noted with the overall status
 	WriteNarrativeReport(r.w, report)
 	return nil
 }
 
-// narrativeFuncs returns the template function map for narrative rendering.
-func narrativeFuncs() template.FuncMap {
+// funcs returns the template function map for narrative rendering with
+// r's options.
+func (r *NarrativeRenderer) funcs() template.FuncMap {
+	c, l := r.catalog, r.locations
 	return template.FuncMap{
-		"statusText":       statusText,
+		"t":                c.T,
+		"lang":             c.lang,
+		"statusText":       c.StatusText,
+		"teamStatusText":   c.teamStatusText,
 		"hasNarrative":     hasNarrative,
 		"hasSummary":       hasSummary,
 		"hasConclusion":    hasConclusion,
+		"costSummaryMD":    c.costSummaryMD,
 		"hasContentBlocks": hasContentBlocks,
-		"renderBlockMD":    renderBlockMD,
-		"renderBlocksMD":   renderBlocksMD,
+		"renderBlockMD":    c.renderBlockMD,
+		"renderBlocksMD":   c.renderBlocksMD,
 		"indent":           indent,
 		"hasVerdict":       hasVerdict,
+		"artifactsMD":      artifactsMD,
 		"hasTags":          hasTagsNarrative,
 		"renderTagsMD":     renderTagsMD,
+		"tasksMD": func(tasks []TaskResult) string {
+			return c.tasksMD(tasks, !r.noDurations, l)
+		},
+		"issuesMD": func(report *TeamReport) string {
+			return c.issuesMD(report, l)
+		},
+	}
+}
+
+// tasksMD renders tasks as a Markdown table, with a Duration column if
+// durations is set and any task has one, and details led by file task
+// locations if l is set.
+func (c *Catalog) tasksMD(tasks []TaskResult, durations bool, l *LocationResolver) string {
+	durations = durations && hasDurations(tasks)
+	var sb strings.Builder
+	sb.Grow(64 * (len(tasks) + 2))
+	sb.WriteString("| " + c.T("Task") + " | " + c.T("Status") + " | " + c.T("Severity") + " |")
+	if durations {
+		sb.WriteString(" " + c.T("Duration") + " |")
+	}
+	sb.WriteString(" " + c.T("Detail") + " |\n| --- | --- | --- |")
+	if durations {
+		sb.WriteString(" --- |")
+	}
+	sb.WriteString(" --- |")
+	var buf [24]byte
+	for _, task := range tasks {
+		sb.WriteString("\n| ")
+		sb.WriteString(task.ID)
+		sb.WriteString(" | ")
+		sb.WriteString(c.StatusText(task.Status))
+		sb.WriteString(" | ")
+		sb.WriteString(task.Severity)
+		sb.WriteString(" |")
+		if durations {
+			sb.WriteString(" ")
+			if task.DurationMs > 0 {
+				sb.Write(appendDuration(buf[:0], task.DurationMs))
+			}
+			sb.WriteString(" |")
+		}
+		sb.WriteString(" ")
+		sb.WriteString(taskDetailMD(task, l))
+		sb.WriteString(" |")
+	}
+	return sb.String()
+}
+
+// locationMD returns location as a Markdown link to its file if l
+// resolves it, else unchanged.
+func locationMD(location string, l *LocationResolver) string {
+	if u := l.URL(location); u != "" {
+		return fmt.Sprintf("[%s](%s)", l.Text(location), u)
 	}
+	return location
+}
+
+// taskDetailMD returns the task's detail, led by its location linked by
+// locationMD if it is a file task and l is set.
+func taskDetailMD(task TaskResult, l *LocationResolver) string {
+	loc := task.Location()
+	if l == nil || loc == "" {
+		return task.Detail
+	}
+	return strings.TrimSuffix(locationMD(loc, l)+": "+task.Detail, ": ")
+}
+
+// hasDurations returns true if any of the tasks has a duration.
+func hasDurations(tasks []TaskResult) bool {
+	for _, task := range tasks {
+		if task.DurationMs > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // hasVerdict returns true if the team has a verdict.
@@ -123,6 +268,22 @@ func statusText(s Status) string {
 	}
 }
 
+// english is the nil catalog, for renderers that are not translated.
+var english *Catalog
+
+// teamStatusText returns the team's status as text, marked
+// "(non-blocking)" when the team is allowed to fail.
+func teamStatusText(team TeamSection) string {
+	return english.teamStatusText(team)
+}
+
+func (c *Catalog) teamStatusText(team TeamSection) string {
+	if team.AllowFailure {
+		return c.StatusText(team.Status) + " (" + c.T("non-blocking") + ")"
+	}
+	return c.StatusText(team.Status)
+}
+
 // hasNarrative returns true if the team has narrative content.
 func hasNarrative(team TeamSection) bool {
 	return team.Narrative != nil && (team.Narrative.Problem != "" ||
@@ -140,17 +301,93 @@ func hasConclusion(report *TeamReport) bool {
 	return report.Conclusion != ""
 }
 
+// costSummaryMD renders the report's cost summary table without its title,
+// or returns "" if no team recorded token usage or cost.
+func costSummaryMD(report *TeamReport) string {
+	return english.costSummaryMD(report)
+}
+
+func (c *Catalog) costSummaryMD(report *TeamReport) string {
+	block, ok := report.CostSummaryBlock()
+	if !ok {
+		return ""
+	}
+	block.Title = ""
+	for i, h := range block.Headers {
+		block.Headers[i] = c.T(h)
+	}
+	total := block.Rows[len(block.Rows)-1]
+	total[0] = c.T(total[0])
+	return c.renderBlockMD(block)
+}
+
+// issuesMD renders the report's aggregated issues as Markdown, one
+// subsection per issue, or returns "" if no team reported issues.
+func issuesMD(report *TeamReport) string {
+	return english.issuesMD(report, nil)
+}
+
+func (c *Catalog) issuesMD(report *TeamReport, l *LocationResolver) string {
+	var sections []string
+	for _, issue := range report.Issues() {
+		var sb strings.Builder
+		sb.WriteString("### ")
+		if issue.ID != "" {
+			sb.WriteString(issue.ID)
+			sb.WriteString(": ")
+		}
+		sb.WriteString(issue.Problem)
+		sb.WriteString("\n\n")
+		for _, field := range [][2]string{
+			{"Severity", string(issue.Severity)},
+			{"Category", issue.Category},
+			{"Location", locationMD(issue.Location, l)},
+			{"Effort", string(issue.Effort)},
+			{"Related", strings.Join(issue.RelatedIssues, ", ")},
+		} {
+			if field[1] != "" {
+				fmt.Fprintf(&sb, "- **%s**: %s\n", c.T(field[0]), field[1])
+			}
+		}
+		if issue.Analysis != "" {
+			sb.WriteString("\n")
+			sb.WriteString(issue.Analysis)
+			sb.WriteString("\n")
+		}
+		if issue.Recommendation != "" {
+			sb.WriteString("\n**" + c.T("Recommendation") + "**: ")
+			sb.WriteString(issue.Recommendation)
+			sb.WriteString("\n")
+		}
+		if issue.Example != "" {
+			sb.WriteString("\n```\n")
+			sb.WriteString(strings.TrimRight(issue.Example, "\n"))
+			sb.WriteString("\n```\n")
+		}
+		sections = append(sections, strings.TrimRight(sb.String(), "\n"))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
 // renderBlocksMD renders multiple content blocks as Markdown.
 func renderBlocksMD(blocks []ContentBlock) string {
+	return english.renderBlocksMD(blocks)
+}
+
+func (c *Catalog) renderBlocksMD(blocks []ContentBlock) string {
 	var parts []string
 	for _, block := range blocks {
-		parts = append(parts, renderBlockMD(block))
+		parts = append(parts, c.renderBlockMD(block))
 	}
 	return strings.Join(parts, "\n\n")
 }
 
 // renderBlockMD renders a single content block as Markdown.
 func renderBlockMD(block ContentBlock) string {
+	return english.renderBlockMD(block)
+}
+
+func (c *Catalog) renderBlockMD(block ContentBlock) string {
 	var sb strings.Builder
 
 	if block.Title != "" {
@@ -202,12 +439,12 @@ func renderBlockMD(block ContentBlock) string {
 		sb.WriteString("**: ")
 		sb.WriteString(block.Value)
 		if block.Target != "" {
-			sb.WriteString(" (target: ")
+			sb.WriteString(" (" + c.T("target") + ": ")
 			sb.WriteString(block.Target)
 			sb.WriteString(")")
 		}
 		sb.WriteString(" — ")
-		sb.WriteString(statusText(block.Status))
+		sb.WriteString(c.StatusText(block.Status))
 		sb.WriteString("\n")
 	}
 
@@ -228,91 +465,108 @@ func indent(prefix, text string) string {
 // NarrativeTemplate is the Pandoc-friendly Markdown template.
 // Designed for PDF generation via: pandoc report.md -o report.pdf --pdf-engine=xelatex
 const NarrativeTemplate = `---
-title: "{{ .EffectiveTitle }}"
+title: "{{ t .EffectiveTitle }}"
 date: "{{ .GeneratedAt.Format "2006-01-02" }}"
+{{- with lang }}
+lang: "{{ . }}"
+{{- end }}
 ---
 
-# {{ .EffectiveTitle }}
+# {{ t .EffectiveTitle }}
 
-**Project**: {{ .Project }}
-**Version**: {{ .Version }}
-**Phase**: {{ .Phase }}
-**Overall Status**: {{ statusText .Status }}
+**{{ t "Project" }}**: {{ .Project }}
+**{{ t "Version" }}**: {{ .Version }}
+**{{ t "Phase" }}**: {{ .Phase }}
+**{{ t "Overall Status" }}**: {{ statusText .Status }}{{ with .FilterSummary }} ({{ . }}){{ end }}{{ with .CycleSummary }} ({{ . }}){{ end }}
 {{- if hasTags . }}
 
-### Tags
+### {{ t "Tags" }}
 
 {{ renderTagsMD .Tags }}
 {{- end }}
 {{- if hasSummary . }}
 
-## Executive Summary
+## {{ t "Executive Summary" }}
 
 {{ .Summary }}
 {{- end }}
 {{- if .SummaryBlocks }}
 
-## Overview
+## {{ t "Overview" }}
 
 {{ renderBlocksMD .SummaryBlocks }}
 {{- end }}
 
-## Team Results
+## {{ t "Team Results" }}
 {{- range .Teams }}
 
 ### {{ .Name }}
 
-**Status**: {{ statusText .Status }}
+**{{ t "Status" }}**: {{ teamStatusText . }}
 {{- if hasVerdict . }}
-**Verdict**: {{ .Verdict }}
+**{{ t "Verdict" }}**: {{ .Verdict }}
 {{- end }}
 {{- if hasNarrative . }}
 {{- if .Narrative.Problem }}
 
-#### Problem
+#### {{ t "Problem" }}
 
 {{ .Narrative.Problem }}
 {{- end }}
 {{- if .Narrative.Analysis }}
 
-#### Analysis
+#### {{ t "Analysis" }}
 
 {{ .Narrative.Analysis }}
 {{- end }}
 {{- if .Narrative.Recommendation }}
 
-#### Recommendation
+#### {{ t "Recommendation" }}
 
 {{ .Narrative.Recommendation }}
 {{- end }}
 {{- end }}
 {{- if .Tasks }}
 
-#### Tasks
+#### {{ t "Tasks" }}
 
-| Task | Status | Severity | Detail |
-| --- | --- | --- | --- |
-{{- range .Tasks }}
-| {{ .ID }} | {{ statusText .Status }} | {{ .Severity }} | {{ .Detail }} |
-{{- end }}
+{{ tasksMD .Tasks }}
 {{- end }}
 {{- if hasContentBlocks . }}
 
-#### Details
+#### {{ t "Details" }}
 
 {{ renderBlocksMD .ContentBlocks }}
 {{- end }}
+{{- if .Artifacts }}
+
+#### {{ t "Artifacts" }}
+
+{{ artifactsMD .Artifacts }}
+{{- end }}
+{{- end }}
+{{- with costSummaryMD . }}
+
+## {{ t "Cost Summary" }}
+
+{{ . }}
 {{- end }}
 {{- if .FooterBlocks }}
 
-## Action Items
+## {{ t "Action Items" }}
 
 {{ renderBlocksMD .FooterBlocks }}
 {{- end }}
 {{- if hasConclusion . }}
 
-## Conclusion
+## {{ t "Conclusion" }}
 
 {{ .Conclusion }}
 {{- end }}
+{{- with issuesMD . }}
+
+## {{ t "Issues" }}
+
+{{ . }}
+{{- end }}
 `
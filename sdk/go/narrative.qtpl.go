@@ -64,6 +64,26 @@ date: "`)
 **Overall Status**: `)
 //line narrative.qtpl:17
 	qw422016.E().S(narrativeStatusText(report.Status))
+//line narrative.qtpl:17
+	if report.FilterSummary() != "" {
+//line narrative.qtpl:17
+		qw422016.N().S(` (`)
+//line narrative.qtpl:17
+		qw422016.E().S(report.FilterSummary())
+//line narrative.qtpl:17
+		qw422016.N().S(`)`)
+//line narrative.qtpl:17
+	}
+//line narrative.qtpl:17
+	if report.CycleSummary() != "" {
+//line narrative.qtpl:17
+		qw422016.N().S(` (`)
+//line narrative.qtpl:17
+		qw422016.E().S(report.CycleSummary())
+//line narrative.qtpl:17
+		qw422016.N().S(`)`)
+//line narrative.qtpl:17
+	}
 //line narrative.qtpl:17
 	qw422016.N().S(`
 `)
@@ -136,7 +156,7 @@ date: "`)
 
 **Status**: `)
 //line narrative.qtpl:42
-		qw422016.E().S(narrativeStatusText(team.Status))
+		qw422016.E().S(teamStatusText(team))
 //line narrative.qtpl:42
 		qw422016.N().S(`
 `)
@@ -280,368 +300,428 @@ date: "`)
 		qw422016.N().S(`
 `)
 //line narrative.qtpl:82
-	}
+		if len(team.Artifacts) > 0 {
 //line narrative.qtpl:82
+			qw422016.N().S(`
+
+#### Artifacts
+
+`)
+//line narrative.qtpl:86
+			qw422016.N().S(artifactsMD(team.Artifacts))
+//line narrative.qtpl:86
+			qw422016.N().S(`
+`)
+//line narrative.qtpl:87
+		}
+//line narrative.qtpl:87
+		qw422016.N().S(`
+`)
+//line narrative.qtpl:88
+	}
+//line narrative.qtpl:88
+	qw422016.N().S(`
+`)
+//line narrative.qtpl:89
+	if cost, ok := report.CostSummaryBlock(); ok {
+//line narrative.qtpl:89
+		qw422016.N().S(`
+`)
+//line narrative.qtpl:90
+		cost.Title = ""
+
+//line narrative.qtpl:90
+		qw422016.N().S(`
+
+## Cost Summary
+
+`)
+//line narrative.qtpl:94
+		streamnarrativeRenderBlock(qw422016, cost)
+//line narrative.qtpl:94
+		qw422016.N().S(`
+`)
+//line narrative.qtpl:95
+	}
+//line narrative.qtpl:95
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:83
+//line narrative.qtpl:96
 	if len(report.FooterBlocks) > 0 {
-//line narrative.qtpl:83
+//line narrative.qtpl:96
 		qw422016.N().S(`
 
 ## Action Items
 
 `)
-//line narrative.qtpl:87
+//line narrative.qtpl:100
 		streamnarrativeRenderBlocks(qw422016, report.FooterBlocks)
-//line narrative.qtpl:87
+//line narrative.qtpl:100
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:88
+//line narrative.qtpl:101
 	}
-//line narrative.qtpl:88
+//line narrative.qtpl:101
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:89
+//line narrative.qtpl:102
 	if report.Conclusion != "" {
-//line narrative.qtpl:89
+//line narrative.qtpl:102
 		qw422016.N().S(`
 
 ## Conclusion
 
 `)
-//line narrative.qtpl:93
+//line narrative.qtpl:106
 		qw422016.E().S(report.Conclusion)
-//line narrative.qtpl:93
+//line narrative.qtpl:106
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:94
+//line narrative.qtpl:107
 	}
-//line narrative.qtpl:94
+//line narrative.qtpl:107
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:95
+//line narrative.qtpl:108
+	if issues := issuesMD(report); issues != "" {
+//line narrative.qtpl:108
+		qw422016.N().S(`
+
+## Issues
+
+`)
+//line narrative.qtpl:112
+		qw422016.N().S(issues)
+//line narrative.qtpl:112
+		qw422016.N().S(`
+`)
+//line narrative.qtpl:113
+	}
+//line narrative.qtpl:113
+	qw422016.N().S(`
+`)
+//line narrative.qtpl:114
 }
 
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 func WriteNarrativeReport(qq422016 qtio422016.Writer, report *TeamReport) {
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	StreamNarrativeReport(qw422016, report)
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	qt422016.ReleaseWriter(qw422016)
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 }
 
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 func NarrativeReport(report *TeamReport) string {
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	qb422016 := qt422016.AcquireByteBuffer()
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	WriteNarrativeReport(qb422016, report)
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	qs422016 := string(qb422016.B)
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	qt422016.ReleaseByteBuffer(qb422016)
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 	return qs422016
-//line narrative.qtpl:95
+//line narrative.qtpl:114
 }
 
-//line narrative.qtpl:97
+//line narrative.qtpl:116
 func streamnarrativeRenderTags(qw422016 *qt422016.Writer, tags map[string]string) {
-//line narrative.qtpl:97
+//line narrative.qtpl:116
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:99
+//line narrative.qtpl:118
 	keys := make([]string, 0, len(tags))
 	for k := range tags {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-//line narrative.qtpl:104
+//line narrative.qtpl:123
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:105
+//line narrative.qtpl:124
 	for _, k := range keys {
-//line narrative.qtpl:105
+//line narrative.qtpl:124
 		qw422016.N().S(`
 - **`)
-//line narrative.qtpl:106
+//line narrative.qtpl:125
 		qw422016.E().S(k)
-//line narrative.qtpl:106
+//line narrative.qtpl:125
 		qw422016.N().S(`**: `)
-//line narrative.qtpl:106
+//line narrative.qtpl:125
 		qw422016.E().S(tags[k])
-//line narrative.qtpl:106
+//line narrative.qtpl:125
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:107
+//line narrative.qtpl:126
 	}
-//line narrative.qtpl:107
+//line narrative.qtpl:126
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 }
 
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 func writenarrativeRenderTags(qq422016 qtio422016.Writer, tags map[string]string) {
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	streamnarrativeRenderTags(qw422016, tags)
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	qt422016.ReleaseWriter(qw422016)
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 }
 
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 func narrativeRenderTags(tags map[string]string) string {
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	qb422016 := qt422016.AcquireByteBuffer()
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	writenarrativeRenderTags(qb422016, tags)
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	qs422016 := string(qb422016.B)
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	qt422016.ReleaseByteBuffer(qb422016)
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 	return qs422016
-//line narrative.qtpl:108
+//line narrative.qtpl:127
 }
 
-//line narrative.qtpl:110
+//line narrative.qtpl:129
 func streamnarrativeRenderBlocks(qw422016 *qt422016.Writer, blocks []ContentBlock) {
-//line narrative.qtpl:110
+//line narrative.qtpl:129
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:111
+//line narrative.qtpl:130
 	for i, block := range blocks {
-//line narrative.qtpl:111
+//line narrative.qtpl:130
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:112
+//line narrative.qtpl:131
 		streamnarrativeRenderBlock(qw422016, block)
-//line narrative.qtpl:112
+//line narrative.qtpl:131
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:113
+//line narrative.qtpl:132
 		if i < len(blocks)-1 {
-//line narrative.qtpl:113
+//line narrative.qtpl:132
 			qw422016.N().S(`
 
 `)
-//line narrative.qtpl:115
+//line narrative.qtpl:134
 		}
-//line narrative.qtpl:115
+//line narrative.qtpl:134
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:116
+//line narrative.qtpl:135
 	}
-//line narrative.qtpl:116
+//line narrative.qtpl:135
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 }
 
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 func writenarrativeRenderBlocks(qq422016 qtio422016.Writer, blocks []ContentBlock) {
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	streamnarrativeRenderBlocks(qw422016, blocks)
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	qt422016.ReleaseWriter(qw422016)
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 }
 
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 func narrativeRenderBlocks(blocks []ContentBlock) string {
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	qb422016 := qt422016.AcquireByteBuffer()
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	writenarrativeRenderBlocks(qb422016, blocks)
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	qs422016 := string(qb422016.B)
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	qt422016.ReleaseByteBuffer(qb422016)
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 	return qs422016
-//line narrative.qtpl:117
+//line narrative.qtpl:136
 }
 
-//line narrative.qtpl:119
+//line narrative.qtpl:138
 func streamnarrativeRenderBlock(qw422016 *qt422016.Writer, block ContentBlock) {
-//line narrative.qtpl:119
+//line narrative.qtpl:138
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:120
+//line narrative.qtpl:139
 	if block.Title != "" {
-//line narrative.qtpl:120
+//line narrative.qtpl:139
 		qw422016.N().S(`
 **`)
-//line narrative.qtpl:121
+//line narrative.qtpl:140
 		qw422016.E().S(block.Title)
-//line narrative.qtpl:121
+//line narrative.qtpl:140
 		qw422016.N().S(`**
 
 `)
-//line narrative.qtpl:123
+//line narrative.qtpl:142
 	}
-//line narrative.qtpl:123
+//line narrative.qtpl:142
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:124
+//line narrative.qtpl:143
 	switch block.Type {
-//line narrative.qtpl:125
+//line narrative.qtpl:144
 	case ContentBlockKVPairs:
-//line narrative.qtpl:125
+//line narrative.qtpl:144
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:126
+//line narrative.qtpl:145
 		for _, pair := range block.Pairs {
-//line narrative.qtpl:126
+//line narrative.qtpl:145
 			qw422016.N().S(`
 - **`)
-//line narrative.qtpl:127
+//line narrative.qtpl:146
 			qw422016.E().S(pair.Key)
-//line narrative.qtpl:127
+//line narrative.qtpl:146
 			qw422016.N().S(`**: `)
-//line narrative.qtpl:127
+//line narrative.qtpl:146
 			qw422016.E().S(pair.Value)
-//line narrative.qtpl:127
+//line narrative.qtpl:146
 			qw422016.N().S(`
 `)
-//line narrative.qtpl:128
+//line narrative.qtpl:147
 		}
-//line narrative.qtpl:128
+//line narrative.qtpl:147
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:129
+//line narrative.qtpl:148
 	case ContentBlockList:
-//line narrative.qtpl:129
+//line narrative.qtpl:148
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:130
+//line narrative.qtpl:149
 		for _, item := range block.Items {
-//line narrative.qtpl:130
+//line narrative.qtpl:149
 			qw422016.N().S(`
 - `)
-//line narrative.qtpl:131
+//line narrative.qtpl:150
 			qw422016.E().S(item.Text)
-//line narrative.qtpl:131
+//line narrative.qtpl:150
 			qw422016.N().S(`
 `)
-//line narrative.qtpl:132
+//line narrative.qtpl:151
 		}
-//line narrative.qtpl:132
+//line narrative.qtpl:151
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:133
+//line narrative.qtpl:152
 	case ContentBlockText:
-//line narrative.qtpl:133
+//line narrative.qtpl:152
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:134
+//line narrative.qtpl:153
 		qw422016.E().S(block.Content)
-//line narrative.qtpl:134
+//line narrative.qtpl:153
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:135
+//line narrative.qtpl:154
 	case ContentBlockTable:
-//line narrative.qtpl:135
+//line narrative.qtpl:154
 		qw422016.N().S(`
 | `)
-//line narrative.qtpl:136
+//line narrative.qtpl:155
 		qw422016.E().S(strings.Join(block.Headers, " | "))
-//line narrative.qtpl:136
+//line narrative.qtpl:155
 		qw422016.N().S(` |
 | `)
-//line narrative.qtpl:137
+//line narrative.qtpl:156
 		qw422016.E().S(narrativeTableSep(len(block.Headers)))
-//line narrative.qtpl:137
+//line narrative.qtpl:156
 		qw422016.N().S(` |
 `)
-//line narrative.qtpl:138
+//line narrative.qtpl:157
 		for _, row := range block.Rows {
-//line narrative.qtpl:138
+//line narrative.qtpl:157
 			qw422016.N().S(`
 | `)
-//line narrative.qtpl:139
+//line narrative.qtpl:158
 			qw422016.E().S(strings.Join(row, " | "))
-//line narrative.qtpl:139
+//line narrative.qtpl:158
 			qw422016.N().S(` |
 `)
-//line narrative.qtpl:140
+//line narrative.qtpl:159
 		}
-//line narrative.qtpl:140
+//line narrative.qtpl:159
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:141
+//line narrative.qtpl:160
 	case ContentBlockMetric:
-//line narrative.qtpl:141
+//line narrative.qtpl:160
 		qw422016.N().S(`
 - **`)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		qw422016.E().S(block.Label)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		qw422016.N().S(`**: `)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		qw422016.E().S(block.Value)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		if block.Target != "" {
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 			qw422016.N().S(` (target: `)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 			qw422016.E().S(block.Target)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 			qw422016.N().S(`)`)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		}
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		qw422016.N().S(` — `)
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		qw422016.E().S(narrativeStatusText(block.Status))
-//line narrative.qtpl:142
+//line narrative.qtpl:161
 		qw422016.N().S(`
 `)
-//line narrative.qtpl:143
+//line narrative.qtpl:162
 	}
-//line narrative.qtpl:143
+//line narrative.qtpl:162
 	qw422016.N().S(`
 `)
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 }
 
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 func writenarrativeRenderBlock(qq422016 qtio422016.Writer, block ContentBlock) {
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	streamnarrativeRenderBlock(qw422016, block)
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	qt422016.ReleaseWriter(qw422016)
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 }
 
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 func narrativeRenderBlock(block ContentBlock) string {
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	qb422016 := qt422016.AcquireByteBuffer()
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	writenarrativeRenderBlock(qb422016, block)
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	qs422016 := string(qb422016.B)
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	qt422016.ReleaseByteBuffer(qb422016)
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 	return qs422016
-//line narrative.qtpl:144
+//line narrative.qtpl:163
 }
 
-//line narrative.qtpl:147
+//line narrative.qtpl:166
 func narrativeStatusText(s Status) string {
 	switch s {
 	case StatusGo:
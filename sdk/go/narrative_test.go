@@ -2,7 +2,9 @@ package multiagentspec
 
 import (
 	"bytes"
+	"io"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -281,6 +283,7 @@ func TestQuickNarrativeRendererParity(t *testing.T) {
 				Tasks: []TaskResult{
 					{ID: "vuln-scan", Status: StatusWarn, Severity: "high", Detail: "2 findings"},
 				},
+				Artifacts: []Artifact{{Name: "Scan log", Path: "logs/scan log.txt", MediaType: "text/plain"}},
 			},
 		},
 		Status: StatusWarn,
@@ -313,6 +316,7 @@ func TestQuickNarrativeRendererParity(t *testing.T) {
 		"high",
 		"customer",
 		"acme",
+		"#### Artifacts\n\n- [Scan log](<logs/scan log.txt>) (text/plain)\n",
 	} {
 		if !strings.Contains(stdOutput, expected) {
 			t.Errorf("standard output missing %q", expected)
@@ -322,3 +326,193 @@ func TestQuickNarrativeRendererParity(t *testing.T) {
 		}
 	}
 }
+
+func TestNarrativeWithCatalog(t *testing.T) {
+	c, err := CatalogFor("de-DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := &TeamReport{
+		Project:     "app",
+		Version:     "1.0.0",
+		Phase:       "REVIEW",
+		Status:      StatusNoGo,
+		GeneratedAt: time.Date(2026, 2, 12, 0, 0, 0, 0, time.UTC),
+		Teams: []TeamSection{
+			{ID: "qa", Name: "QA", Status: StatusNoGo, AllowFailure: true,
+				Tasks:         []TaskResult{{ID: "unit", Status: StatusGo, TokensIn: 10, CostUSD: 0.01}},
+				ContentBlocks: []ContentBlock{NewMetricBlock("Coverage", "71%", StatusWarn, "80%")}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := NewNarrativeRenderer(&buf, WithCatalog(c)).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"title: \"TEAMSTATUSBERICHT\"",
+		"lang: \"de\"\n---",
+		"**Projekt**: app",
+		"**Gesamtstatus**: FEHLGESCHLAGEN",
+		"## Teamergebnisse",
+		"**Status**: FEHLGESCHLAGEN (nicht blockierend)",
+		"| Aufgabe | Status | Schweregrad | Detail |",
+		"| unit | BESTANDEN |",
+		"(Ziel: 80%) — WARNUNG",
+		"## Kostenübersicht",
+		"| Team | Eingabe-Tokens | Ausgabe-Tokens | Kosten (USD) |",
+		"| Gesamt |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "PASS") || strings.Contains(out, "Team Results") {
+		t.Errorf("output has untranslated text:\n%s", out)
+	}
+
+	// Without a catalog, output stays English without lang metadata
+	buf.Reset()
+	if err := NewNarrativeRenderer(&buf).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "lang:") || !strings.Contains(out, "**Overall Status**: FAIL") {
+		t.Errorf("English output:\n%s", out)
+	}
+}
+
+func TestNarrativeDurations(t *testing.T) {
+	report := &TeamReport{
+		Project: "app",
+		Teams: []TeamSection{
+			{ID: "qa", Name: "QA", Status: StatusGo, Tasks: []TaskResult{
+				{ID: "unit", Status: StatusGo, DurationMs: 340},
+				{ID: "lint", Status: StatusGo},
+			}},
+			{ID: "docs", Name: "Docs", Status: StatusGo, Tasks: []TaskResult{{ID: "readme", Status: StatusGo}}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := NewNarrativeRenderer(&buf).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"| Task | Status | Severity | Duration | Detail |\n| --- | --- | --- | --- | --- |",
+		"| unit | PASS |  | 340ms |  |",
+		"| lint | PASS |  |  |  |",
+		"| readme | PASS |  |  |\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "| Duration |") != 1 {
+		t.Errorf("want a Duration column only for the timed team:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := NewNarrativeRenderer(&buf, WithNarrativeDurations(false)).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "Duration") || strings.Contains(out, "340ms") {
+		t.Errorf("WithNarrativeDurations(false) output has durations:\n%s", out)
+	}
+}
+
+func TestNarrativeLocations(t *testing.T) {
+	l := &LocationResolver{BaseURL: "https://github.com/org/repo", Commit: "abc123"}
+	var buf bytes.Buffer
+	if err := NewNarrativeRenderer(&buf, WithNarrativeLocations(l)).Render(locationTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"| vet | WARNING |  | [cmd/main.go:42](https://github.com/org/repo/blob/abc123/cmd/main.go#L42): unused variable |",
+		"| gofmt | PASS |  |  |",
+		"- **Location**: [pkg/run.go:10](https://github.com/org/repo/blob/abc123/pkg/run.go#L10)",
+		"- **Location**: requirements.functional[2]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := NewNarrativeRenderer(&buf).Render(locationTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	if out := buf.String(); strings.Contains(out, "](") || !strings.Contains(out, "- **Location**: pkg/run.go#L10") {
+		t.Errorf("output without WithNarrativeLocations:\n%s", out)
+	}
+}
+
+func TestRenderNarrativeConcurrent(t *testing.T) {
+	c, err := CatalogFor("fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Teams: []TeamSection{
+			{ID: "qa", Name: "QA", Status: StatusGo, DependsOn: []string{"pm"}, Tasks: []TaskResult{{ID: "unit", Status: StatusGo}}},
+			{ID: "pm", Name: "PM", Status: StatusGo},
+		},
+	}
+	var wg sync.WaitGroup
+	outs := make([]bytes.Buffer, 8)
+	errs := make([]error, len(outs))
+	for i := range outs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var opts []NarrativeOption
+			if i%2 == 1 {
+				opts = append(opts, WithCatalog(c))
+			}
+			errs[i] = RenderNarrative(&outs[i], report, opts...)
+		}(i)
+	}
+	wg.Wait()
+	for i := range outs {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if outs[i].String() != outs[i%2].String() {
+			t.Errorf("output %d differs:\n%s\n%s", i, outs[i].String(), outs[i%2].String())
+		}
+	}
+	if !strings.Contains(outs[0].String(), "| unit | PASS |") || !strings.Contains(outs[1].String(), "| unit | RÉUSSI |") {
+		t.Errorf("renders mixed up their options:\n%s\n%s", outs[0].String(), outs[1].String())
+	}
+	if strings.Index(outs[0].String(), "PM") > strings.Index(outs[0].String(), "QA") {
+		t.Errorf("teams not in DAG order:\n%s", outs[0].String())
+	}
+	if report.Teams[0].ID != "qa" {
+		t.Errorf("RenderNarrative sorted the report's teams: %s first", report.Teams[0].ID)
+	}
+}
+
+func BenchmarkRenderNarrative(b *testing.B) {
+	report := benchmarkReport()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := RenderNarrative(io.Discard, report); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkQuickNarrativeRenderer(b *testing.B) {
+	report := benchmarkReport()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := NewQuickNarrativeRenderer(io.Discard).Render(report); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
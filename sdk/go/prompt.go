@@ -0,0 +1,145 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PromptTemplate is a named, versioned prompt, such as an evaluator's
+// system prompt, kept with the agents so prompt changes are reviewed like
+// any other spec change. Evaluations record the prompt that produced them
+// in LLMEvaluation.PromptVersion.
+type PromptTemplate struct {
+	// Schema is the JSON Schema reference.
+	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+
+	// Name is the prompt identifier.
+	Name string `json:"name" yaml:"name" jsonschema:"pattern=^[^@]+$" jsonschema_description:"Prompt identifier"`
+
+	// Version is the semantic version of the prompt (e.g., 1.2.0).
+	Version string `json:"version" yaml:"version" jsonschema_description:"Semantic version of the prompt, e.g., 1.2.0"`
+
+	// Description explains what the prompt is for.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" jsonschema_description:"What the prompt is for"`
+
+	// Template is the prompt text. It may reference {{ .vars.name }}
+	// placeholders, filled in by Render.
+	Template string `json:"template" yaml:"template" jsonschema:"minLength=1" jsonschema_description:"Prompt text; may reference {{ .vars.name }} placeholders"`
+}
+
+// Validate checks that the prompt has a name without "@", a valid
+// version, and a template that parses.
+func (p *PromptTemplate) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if strings.Contains(p.Name, "@") {
+		return fmt.Errorf("prompt %s: name cannot contain @", p.Name)
+	}
+	if p.Version == "" {
+		return fmt.Errorf("prompt %s: version is required", p.Name)
+	}
+	if _, err := ParseVersion(p.Version); err != nil {
+		return fmt.Errorf("prompt %s: %w", p.Name, err)
+	}
+	if strings.TrimSpace(p.Template) == "" {
+		return fmt.Errorf("prompt %s: template is required", p.Name)
+	}
+	if _, err := parseTemplate("template", p.Template); err != nil {
+		return fmt.Errorf("prompt %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// PromptVersion returns the reference evaluations record for the prompt,
+// name@version, e.g., prd-review@1.2.0.
+func (p *PromptTemplate) PromptVersion() string {
+	return p.Name + "@" + p.Version
+}
+
+// Render returns the template with {{ .vars.name }} placeholders replaced
+// from vars. Referencing an undefined variable is an error.
+func (p *PromptTemplate) Render(vars map[string]string) (string, error) {
+	out, err := resolveVariables("template", p.Template, vars)
+	if err != nil {
+		return "", fmt.Errorf("prompt %s: %w", p.PromptVersion(), err)
+	}
+	return out, nil
+}
+
+// PromptLibrary indexes prompt templates by PromptVersion.
+type PromptLibrary struct {
+	prompts map[string]*PromptTemplate
+}
+
+// NewPromptLibrary returns a library of prompts. A name and version may be
+// defined only once.
+func NewPromptLibrary(prompts []*PromptTemplate) (*PromptLibrary, error) {
+	l := &PromptLibrary{prompts: make(map[string]*PromptTemplate, len(prompts))}
+	for _, p := range prompts {
+		ref := p.PromptVersion()
+		if _, ok := l.prompts[ref]; ok {
+			return nil, fmt.Errorf("prompt %s is defined more than once", ref)
+		}
+		l.prompts[ref] = p
+	}
+	return l, nil
+}
+
+// Lookup returns the prompt with PromptVersion ref (name@version).
+func (l *PromptLibrary) Lookup(ref string) (*PromptTemplate, bool) {
+	p, ok := l.prompts[ref]
+	return p, ok
+}
+
+// Latest returns the highest version of the prompt named name.
+func (l *PromptLibrary) Latest(name string) (*PromptTemplate, bool) {
+	var latest *PromptTemplate
+	var latestVersion Version
+	for _, p := range l.prompts {
+		if p.Name != name {
+			continue
+		}
+		v, err := ParseVersion(p.Version)
+		if err != nil {
+			continue
+		}
+		if latest == nil || v.Compare(latestVersion) > 0 {
+			latest, latestVersion = p, v
+		}
+	}
+	return latest, latest != nil
+}
+
+// PromptVersions returns the PromptVersion of every prompt, sorted.
+func (l *PromptLibrary) PromptVersions() []string {
+	refs := make([]string, 0, len(l.prompts))
+	for ref := range l.prompts {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// CheckPromptReferences returns an error for the first evaluation, in
+// name order, whose PromptVersion is not in lib. evals maps a name for
+// messages, such as the file it was read from, to each evaluation.
+// Evaluations without a PromptVersion are not checked.
+func CheckPromptReferences(evals map[string]*LLMEvaluation, lib *PromptLibrary) error {
+	names := make([]string, 0, len(evals))
+	for name := range evals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ref := evals[name].PromptVersion
+		if ref == "" {
+			continue
+		}
+		if _, ok := lib.Lookup(ref); !ok {
+			return fmt.Errorf("evaluation %s: unknown prompt version %q", name, ref)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPromptTemplateValidate(t *testing.T) {
+	tests := []struct {
+		prompt  PromptTemplate
+		wantErr string
+	}{
+		{PromptTemplate{Name: "review", Version: "1.0.0", Template: "Grade it."}, ""},
+		{PromptTemplate{Version: "1.0.0", Template: "x"}, "name is required"},
+		{PromptTemplate{Name: "a@b", Version: "1.0.0", Template: "x"}, "name cannot contain @"},
+		{PromptTemplate{Name: "review", Template: "x"}, "version is required"},
+		{PromptTemplate{Name: "review", Version: "v1", Template: "x"}, "invalid version"},
+		{PromptTemplate{Name: "review", Version: "1.0.0", Template: "  "}, "template is required"},
+		{PromptTemplate{Name: "review", Version: "1.0.0", Template: "{{ .vars.x"}, "prompt review: template:"},
+	}
+	for _, tt := range tests {
+		err := tt.prompt.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("Validate(%+v) = %v", tt.prompt, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("Validate(%+v) = %v, want %q", tt.prompt, err, tt.wantErr)
+		}
+	}
+}
+
+func TestPromptTemplateRender(t *testing.T) {
+	p := &PromptTemplate{Name: "review", Version: "1.0.0", Template: "Grade PRDs for {{ .vars.company }}."}
+	out, err := p.Render(map[string]string{"company": "Acme"})
+	if err != nil || out != "Grade PRDs for Acme." {
+		t.Errorf("Render = %q, %v", out, err)
+	}
+	if _, err := p.Render(nil); err == nil || !strings.Contains(err.Error(), "prompt review@1.0.0: template:") {
+		t.Errorf("Render(nil) error = %v", err)
+	}
+	plain := &PromptTemplate{Name: "plain", Version: "1.0.0", Template: "No {placeholders}."}
+	if out, err := plain.Render(nil); err != nil || out != plain.Template {
+		t.Errorf("Render = %q, %v", out, err)
+	}
+}
+
+func TestPromptLibrary(t *testing.T) {
+	prompts := []*PromptTemplate{
+		{Name: "review", Version: "1.2.0", Template: "a"},
+		{Name: "review", Version: "1.10.0", Template: "b"},
+		{Name: "summary", Version: "0.1.0", Template: "c"},
+	}
+	lib, err := NewPromptLibrary(prompts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := lib.Lookup("review@1.2.0"); !ok || p.Template != "a" {
+		t.Errorf("Lookup(review@1.2.0) = %+v, %v", p, ok)
+	}
+	if _, ok := lib.Lookup("review"); ok {
+		t.Error("Lookup(review) found a prompt without a version")
+	}
+	if p, ok := lib.Latest("review"); !ok || p.Version != "1.10.0" {
+		t.Errorf("Latest(review) = %+v, %v", p, ok)
+	}
+	if _, ok := lib.Latest("missing"); ok {
+		t.Error("Latest(missing) = true")
+	}
+	want := []string{"review@1.10.0", "review@1.2.0", "summary@0.1.0"}
+	if got := lib.PromptVersions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("PromptVersions() = %v, want %v", got, want)
+	}
+
+	_, err = NewPromptLibrary(append(prompts, &PromptTemplate{Name: "summary", Version: "0.1.0", Template: "d"}))
+	if err == nil || !strings.Contains(err.Error(), "prompt summary@0.1.0 is defined more than once") {
+		t.Errorf("NewPromptLibrary error = %v", err)
+	}
+}
+
+func TestCheckPromptReferences(t *testing.T) {
+	lib, err := NewPromptLibrary([]*PromptTemplate{{Name: "review", Version: "1.0.0", Template: "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	evals := map[string]*LLMEvaluation{
+		"a.json": {Score: 7, PromptVersion: "review@1.0.0"},
+		"b.json": {Score: 7},
+	}
+	if err := CheckPromptReferences(evals, lib); err != nil {
+		t.Errorf("CheckPromptReferences = %v", err)
+	}
+	evals["c.json"] = &LLMEvaluation{Score: 7, PromptVersion: "review@2.0.0"}
+	evals["d.json"] = &LLMEvaluation{Score: 7, PromptVersion: "other@1.0.0"}
+	err = CheckPromptReferences(evals, lib)
+	if err == nil || err.Error() != `evaluation c.json: unknown prompt version "review@2.0.0"` {
+		t.Errorf("CheckPromptReferences error = %v", err)
+	}
+}
+
+func TestPromptSchema(t *testing.T) {
+	data, err := json.Marshal(PromptTemplate{Name: "review", Version: "1.0.0", Template: "Grade it."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidatePromptJSON(data); err != nil {
+		t.Errorf("schema rejects prompt: %v", err)
+	}
+	if err := ValidatePromptJSON([]byte(`{"name": "a@b", "version": "1.0.0", "template": "x"}`)); err == nil {
+		t.Error("schema accepts a name with @")
+	}
+}
@@ -0,0 +1,404 @@
+// Package publish posts a report summary to a webhook, Slack, or Microsoft
+// Teams, so a pipeline can notify people when a report is ready.
+//
+// Build prepares the request body without sending it, for dry runs; Send
+// posts it, retrying network errors, 429s, and 5xx responses with
+// exponential backoff; Publish does both.
+//
+// Example:
+//
+//	err := publish.Publish(ctx, report, publish.Config{
+//	    Target: publish.Slack,
+//	    URL:    os.Getenv("SLACK_WEBHOOK_URL"),
+//	})
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// Target is where a summary is posted.
+type Target string
+
+const (
+	// Webhook posts JSON with the summary as "text" alongside the report's
+	// project, version, phase, and status, which chat services that accept
+	// a "text" field display as is.
+	Webhook Target = "webhook"
+
+	// Slack posts a Block Kit message to an incoming webhook.
+	Slack Target = "slack"
+
+	// Teams posts an Adaptive Card to a Microsoft Teams incoming webhook or
+	// workflow.
+	Teams Target = "teams"
+)
+
+// Targets returns all targets.
+func Targets() []Target {
+	return []Target{Webhook, Slack, Teams}
+}
+
+// Format is how the report is summarized.
+type Format string
+
+const (
+	// Summary lists the overall status and each team's status, laid out
+	// natively for the target: a Slack section or a Teams fact set.
+	Summary Format = "summary"
+
+	// Box, Narrative, and GitHubSummary embed the report rendered by
+	// Renderer, NarrativeRenderer, or WriteGitHubSummary. Box text is
+	// shown in a monospace block.
+	Box           Format = "box"
+	Narrative     Format = "narrative"
+	GitHubSummary Format = "gh-summary"
+
+	// JSON posts the report itself. It is only valid for Webhook.
+	JSON Format = "json"
+)
+
+// Formats returns all formats.
+func Formats() []Format {
+	return []Format{Summary, Box, Narrative, GitHubSummary, JSON}
+}
+
+// Limits on embedded text, below Slack's 3,000 characters per section and
+// Teams' 28 KB per card.
+const (
+	slackTextLimit = 2900
+	teamsTextLimit = 20000
+)
+
+// Config configures publishing.
+type Config struct {
+	Target Target
+	URL    string
+
+	// Format defaults to Summary.
+	Format Format
+
+	// Attempts is the number of tries before giving up, default 3.
+	Attempts int
+
+	// Backoff is the wait before the first retry, doubling after each,
+	// default 1s. A Retry-After header overrides it.
+	Backoff time.Duration
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// Message is a request body ready to send.
+type Message struct {
+	ContentType string
+	Body        []byte
+}
+
+// Publish builds the message for report and sends it.
+func Publish(ctx context.Context, report *multiagentspec.TeamReport, cfg Config) error {
+	msg, err := Build(report, cfg)
+	if err != nil {
+		return err
+	}
+	return Send(ctx, msg, cfg)
+}
+
+// Build returns the message cfg.Target expects for report. It sorts teams
+// by DAG order.
+func Build(report *multiagentspec.TeamReport, cfg Config) (*Message, error) {
+	format := cfg.Format
+	if format == "" {
+		format = Summary
+	}
+	if format == JSON && cfg.Target != Webhook {
+		return nil, fmt.Errorf("format json is only valid for target webhook")
+	}
+	report.SortByDAG()
+	status := report.Status
+	if status == "" {
+		status = report.ComputeOverallStatus()
+	}
+	heading := fmt.Sprintf("%s %s: %s", status.Icon(), report.EffectiveTitle(), status)
+	if f := report.FilterSummary(); f != "" {
+		heading += " (" + f + ")"
+	}
+
+	var text string
+	if format != JSON {
+		var err error
+		if text, err = render(report, format, heading); err != nil {
+			return nil, err
+		}
+	}
+
+	var payload any
+	switch cfg.Target {
+	case Webhook:
+		if format == JSON {
+			data, err := report.ToJSON()
+			if err != nil {
+				return nil, fmt.Errorf("encode report: %w", err)
+			}
+			return &Message{ContentType: "application/json", Body: data}, nil
+		}
+		payload = map[string]any{
+			"text":    text,
+			"project": report.Project,
+			"version": report.Version,
+			"phase":   report.Phase,
+			"status":  status,
+		}
+	case Slack:
+		payload = slackMessage(report, format, heading, text)
+	case Teams:
+		payload = teamsMessage(report, status, format, heading, text)
+	default:
+		return nil, fmt.Errorf("unknown target %q (want webhook, slack, or teams)", cfg.Target)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode message: %w", err)
+	}
+	return &Message{ContentType: "application/json", Body: data}, nil
+}
+
+// render returns the report's text in format.
+func render(report *multiagentspec.TeamReport, format Format, heading string) (string, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case Summary:
+		buf.WriteString(heading + "\n")
+		for _, line := range teamLines(report) {
+			buf.WriteString(line + "\n")
+		}
+	case Box:
+		err = multiagentspec.NewRenderer(&buf).Render(report)
+	case Narrative:
+		err = multiagentspec.NewNarrativeRenderer(&buf).Render(report)
+	case GitHubSummary:
+		err = multiagentspec.WriteGitHubSummary(&buf, report)
+	default:
+		return "", fmt.Errorf("unknown format %q (want summary, box, narrative, gh-summary, or json)", format)
+	}
+	if err != nil {
+		return "", fmt.Errorf("render %s: %w", format, err)
+	}
+	return strings.TrimRight(buf.String(), "\n"), nil
+}
+
+// teamLines returns "🟢 QA: GO" for each team.
+func teamLines(report *multiagentspec.TeamReport) []string {
+	lines := make([]string, 0, len(report.Teams))
+	for i := range report.Teams {
+		t := &report.Teams[i]
+		lines = append(lines, fmt.Sprintf("%s %s: %s", t.Status.Icon(), t.Name, t.StatusLabel()))
+	}
+	return lines
+}
+
+// metaFacts returns the report's identifying fields that are set.
+func metaFacts(report *multiagentspec.TeamReport) [][2]string {
+	var facts [][2]string
+	for _, f := range [][2]string{
+		{"Project", report.Project},
+		{"Version", report.Version},
+		{"Target", report.Target},
+		{"Phase", report.Phase},
+	} {
+		if f[1] != "" {
+			facts = append(facts, f)
+		}
+	}
+	return facts
+}
+
+func slackMessage(report *multiagentspec.TeamReport, format Format, heading, text string) map[string]any {
+	type obj = map[string]any
+	blocks := []obj{{
+		"type": "header",
+		"text": obj{"type": "plain_text", "text": truncate(heading, 150), "emoji": true},
+	}}
+	if facts := metaFacts(report); len(facts) > 0 {
+		parts := make([]string, len(facts))
+		for i, f := range facts {
+			parts[i] = f[0] + ": *" + slackEscape(f[1]) + "*"
+		}
+		blocks = append(blocks, obj{
+			"type":     "context",
+			"elements": []obj{{"type": "mrkdwn", "text": strings.Join(parts, "  ·  ")}},
+		})
+	}
+	var body string
+	switch format {
+	case Summary:
+		lines := make([]string, len(report.Teams))
+		for i := range report.Teams {
+			t := &report.Teams[i]
+			lines[i] = fmt.Sprintf("%s *%s*: %s", t.Status.Icon(), slackEscape(t.Name), t.StatusLabel())
+		}
+		body = strings.Join(lines, "\n")
+	case Box:
+		body = "```\n" + truncate(slackEscape(text), slackTextLimit-8) + "\n```"
+	default:
+		body = truncate(slackEscape(text), slackTextLimit)
+	}
+	if body != "" {
+		blocks = append(blocks, obj{"type": "section", "text": obj{"type": "mrkdwn", "text": truncate(body, slackTextLimit)}})
+	}
+	return obj{"text": heading, "blocks": blocks}
+}
+
+func teamsMessage(report *multiagentspec.TeamReport, status multiagentspec.Status, format Format, heading, text string) map[string]any {
+	type obj = map[string]any
+	color := map[multiagentspec.Status]string{
+		multiagentspec.StatusGo:   "Good",
+		multiagentspec.StatusWarn: "Warning",
+		multiagentspec.StatusNoGo: "Attention",
+	}[status]
+	if color == "" {
+		color = "Default"
+	}
+	body := []obj{{
+		"type": "TextBlock", "text": heading, "weight": "Bolder", "size": "Large", "color": color, "wrap": true,
+	}}
+	if facts := metaFacts(report); len(facts) > 0 {
+		body = append(body, factSet(facts))
+	}
+	switch format {
+	case Summary:
+		var facts [][2]string
+		for i := range report.Teams {
+			t := &report.Teams[i]
+			facts = append(facts, [2]string{t.Name, t.Status.Icon() + " " + t.StatusLabel()})
+		}
+		if len(facts) > 0 {
+			body = append(body, factSet(facts))
+		}
+	case Box:
+		body = append(body, obj{"type": "TextBlock", "text": truncate(text, teamsTextLimit), "fontType": "Monospace", "wrap": true})
+	default:
+		body = append(body, obj{"type": "TextBlock", "text": truncate(text, teamsTextLimit), "wrap": true})
+	}
+	return obj{
+		"type": "message",
+		"attachments": []obj{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content": obj{
+				"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+				"type":    "AdaptiveCard",
+				"version": "1.4",
+				"msteams": obj{"width": "Full"},
+				"body":    body,
+			},
+		}},
+	}
+}
+
+func factSet(facts [][2]string) map[string]any {
+	out := make([]map[string]any, len(facts))
+	for i, f := range facts {
+		out[i] = map[string]any{"title": f[0], "value": f[1]}
+	}
+	return map[string]any{"type": "FactSet", "facts": out}
+}
+
+// slackEscape escapes the characters Slack mrkdwn treats as control
+// characters.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// truncate shortens s to at most n runes, marking the cut.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	const mark = "… (truncated)"
+	return string(runes[:n-len([]rune(mark))]) + mark
+}
+
+// Send posts msg to cfg.URL, retrying network errors, 429s, and 5xx
+// responses.
+func Send(ctx context.Context, msg *Message, cfg Config) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("no URL to publish to")
+	}
+	attempts := cfg.Attempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+	wait := cfg.Backoff
+	if wait <= 0 {
+		wait = time.Second
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryAfter, err := post(ctx, client, cfg.URL, msg)
+		if err == nil {
+			return nil
+		}
+		if retryAfter < 0 || ctx.Err() != nil {
+			return err
+		}
+		if attempt >= attempts {
+			return fmt.Errorf("after %d attempts: %w", attempts, err)
+		}
+		delay := wait
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		wait *= 2
+	}
+}
+
+// post sends msg once. On failure it returns the Retry-After delay, zero
+// for a retryable failure without one, or -1 if retrying cannot help.
+func post(ctx context.Context, client *http.Client, url string, msg *Message) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(msg.Body))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", msg.ContentType)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode/100 == 2 {
+		return 0, nil
+	}
+	err = fmt.Errorf("%s", resp.Status)
+	if s := strings.TrimSpace(string(body)); s != "" {
+		err = fmt.Errorf("%s: %s", resp.Status, s)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return -1, err
+	}
+	if secs, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil && secs > 0 {
+		return time.Duration(secs) * time.Second, err
+	}
+	return 0, err
+}
@@ -0,0 +1,167 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func testReport() *multiagentspec.TeamReport {
+	return &multiagentspec.TeamReport{
+		Project:     "my-app",
+		Version:     "v1.2.0",
+		Phase:       "RELEASE",
+		GeneratedAt: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Teams: []multiagentspec.TeamSection{
+			{ID: "qa", Name: "QA", Status: multiagentspec.StatusGo},
+			{ID: "docs", Name: "Docs <beta>", Status: multiagentspec.StatusNoGo, AllowFailure: true},
+		},
+	}
+}
+
+func decode(t *testing.T, msg *Message) map[string]any {
+	t.Helper()
+	var v map[string]any
+	if err := json.Unmarshal(msg.Body, &v); err != nil {
+		t.Fatalf("message is not JSON: %v\n%s", err, msg.Body)
+	}
+	return v
+}
+
+func TestBuildSlack(t *testing.T) {
+	msg, err := Build(testReport(), Config{Target: Slack})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := decode(t, msg)
+	if v["text"] != "🟡 TEAM STATUS REPORT: WARN" {
+		t.Errorf("text = %q", v["text"])
+	}
+	blocks := v["blocks"].([]any)
+	if len(blocks) != 3 {
+		t.Fatalf("blocks = %v", blocks)
+	}
+	section := blocks[2].(map[string]any)["text"].(map[string]any)["text"].(string)
+	if !strings.Contains(section, "🟢 *QA*: GO") || !strings.Contains(section, "*Docs &lt;beta&gt;*: NO-GO (non-blocking)") {
+		t.Errorf("section = %q", section)
+	}
+
+	msg, err = Build(testReport(), Config{Target: Slack, Format: Box})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(msg.Body), "```\\n╔") {
+		t.Errorf("box message lacks a code block:\n%s", msg.Body)
+	}
+}
+
+func TestBuildTeams(t *testing.T) {
+	msg, err := Build(testReport(), Config{Target: Teams})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := decode(t, msg)
+	card := v["attachments"].([]any)[0].(map[string]any)
+	if card["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("contentType = %v", card["contentType"])
+	}
+	body := card["content"].(map[string]any)["body"].([]any)
+	if color := body[0].(map[string]any)["color"]; color != "Warning" {
+		t.Errorf("heading color = %v, want Warning", color)
+	}
+	facts := body[2].(map[string]any)["facts"].([]any)
+	if len(facts) != 2 || facts[0].(map[string]any)["value"] != "🔴 NO-GO (non-blocking)" {
+		t.Errorf("team facts = %v", facts)
+	}
+}
+
+func TestBuildWebhook(t *testing.T) {
+	msg, err := Build(testReport(), Config{Target: Webhook, Format: GitHubSummary})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := decode(t, msg)
+	if v["status"] != "WARN" || v["project"] != "my-app" || !strings.HasPrefix(v["text"].(string), "## 🟡") {
+		t.Errorf("webhook message = %v", v)
+	}
+
+	msg, err = Build(testReport(), Config{Target: Webhook, Format: JSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := multiagentspec.ParseTeamReport(msg.Body); err != nil {
+		t.Errorf("json format body is not a report: %v", err)
+	}
+
+	for _, cfg := range []Config{
+		{Target: Slack, Format: JSON},
+		{Target: "email"},
+		{Target: Webhook, Format: "html"},
+	} {
+		if _, err := Build(testReport(), cfg); err == nil {
+			t.Errorf("Build(%+v) succeeded", cfg)
+		}
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Type") != "application/json" || string(body) != `{"text":"hi"}` {
+			t.Errorf("request = %s %q", r.Header.Get("Content-Type"), body)
+		}
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			io.WriteString(w, "ok")
+		}
+	}))
+	defer srv.Close()
+	msg := &Message{ContentType: "application/json", Body: []byte(`{"text":"hi"}`)}
+
+	if err := Send(context.Background(), msg, Config{URL: srv.URL, Backoff: time.Millisecond}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+
+	calls.Store(0)
+	err := Send(context.Background(), msg, Config{URL: srv.URL, Attempts: 2, Backoff: time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") || !strings.Contains(err.Error(), "429") {
+		t.Errorf("Send() with 2 attempts error = %v", err)
+	}
+}
+
+func TestSendPermanentFailure(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, "invalid_payload")
+	}))
+	defer srv.Close()
+
+	err := Send(context.Background(), &Message{ContentType: "application/json", Body: []byte("{}")}, Config{URL: srv.URL, Backoff: time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "400 Bad Request: invalid_payload") {
+		t.Errorf("Send() error = %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1 for a client error", calls.Load())
+	}
+	if err := Send(context.Background(), &Message{}, Config{}); err == nil {
+		t.Error("Send() without a URL succeeded")
+	}
+}
@@ -0,0 +1,164 @@
+package multiagentspec
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimit caps how fast an agent or step calls its LLM provider, so
+// scatter workflows fanning out many instances stay under provider limits.
+// Zero fields are unlimited.
+type RateLimit struct {
+	// RequestsPerMinute is the sustained request rate.
+	RequestsPerMinute int `json:"requests_per_minute,omitempty" yaml:"requests_per_minute,omitempty" jsonschema:"minimum=0" jsonschema_description:"Sustained request rate"`
+
+	// MaxConcurrent is the maximum number of calls in flight at once.
+	MaxConcurrent int `json:"max_concurrent,omitempty" yaml:"max_concurrent,omitempty" jsonschema:"minimum=0" jsonschema_description:"Maximum calls in flight at once"`
+
+	// Burst is the number of requests allowed at once above the sustained
+	// rate (default: 1).
+	Burst int `json:"burst,omitempty" yaml:"burst,omitempty" jsonschema:"minimum=0" jsonschema_description:"Requests allowed at once above the sustained rate (default: 1)"`
+}
+
+// Validate checks that the limits are non-negative, that at least one is
+// set, and that Burst is used only with RequestsPerMinute.
+func (r *RateLimit) Validate() error {
+	if r.RequestsPerMinute < 0 || r.MaxConcurrent < 0 || r.Burst < 0 {
+		return fmt.Errorf("rate limits must not be negative")
+	}
+	if r.RequestsPerMinute == 0 && r.MaxConcurrent == 0 {
+		return fmt.Errorf("rate limit sets no limit")
+	}
+	if r.Burst > 0 && r.RequestsPerMinute == 0 {
+		return fmt.Errorf("burst requires requests_per_minute")
+	}
+	return nil
+}
+
+// EffectiveBurst returns the burst size, defaulting to 1.
+func (r *RateLimit) EffectiveBurst() int {
+	if r.Burst <= 0 {
+		return 1
+	}
+	return r.Burst
+}
+
+// RateLimiter enforces a RateLimit with a token bucket for the request rate
+// and a semaphore for concurrent calls. It is safe for concurrent use. A
+// limiter built from a nil RateLimit never blocks.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // time to earn one token; zero means no rate limit
+	burst    float64
+	tokens   float64
+	last     time.Time
+	sem      chan struct{}
+	now      func() time.Time
+}
+
+// NewRateLimiter returns a limiter enforcing limit. The bucket starts full.
+func NewRateLimiter(limit *RateLimit) *RateLimiter {
+	l := &RateLimiter{now: time.Now}
+	if limit == nil {
+		return l
+	}
+	if limit.RequestsPerMinute > 0 {
+		l.interval = time.Minute / time.Duration(limit.RequestsPerMinute)
+		l.burst = float64(limit.EffectiveBurst())
+		l.tokens = l.burst
+		l.last = l.now()
+	}
+	if limit.MaxConcurrent > 0 {
+		l.sem = make(chan struct{}, limit.MaxConcurrent)
+	}
+	return l
+}
+
+// Acquire blocks until a call is allowed or ctx is done. On success the
+// caller must call release when the call completes.
+func (l *RateLimiter) Acquire(ctx context.Context) (release func(), err error) {
+	release = func() {}
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+			var once sync.Once
+			release = func() { once.Do(func() { <-l.sem }) }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := l.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// wait takes one token from the bucket, sleeping until one is earned.
+func (l *RateLimiter) wait(ctx context.Context) error {
+	if l.interval == 0 {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		now := l.now()
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) * float64(l.interval))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// RateLimiters holds one shared limiter per agent or step, so every
+// instance of a scattered agent draws from the same limits. It is safe for concurrent use.
+type RateLimiters struct {
+	mu       sync.Mutex
+	limiters map[string]*RateLimiter
+}
+
+// NewRateLimiters returns an empty limiter set.
+func NewRateLimiters() *RateLimiters {
+	return &RateLimiters{limiters: make(map[string]*RateLimiter)}
+}
+
+// For returns the limiter for key, creating it from limit on first use.
+// Later calls for the same key return the same limiter and ignore limit.
+func (s *RateLimiters) For(key string, limit *RateLimit) *RateLimiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.limiters[key]
+	if !ok {
+		l = NewRateLimiter(limit)
+		s.limiters[key] = l
+	}
+	return l
+}
+
+// ForStep returns the limiter for a call agent makes in the named workflow
+// step, whose runtime settings are rt. A rate limit on rt covers all of the
+// step's calls; otherwise the agent's own limit applies, shared across every
+// step the agent runs in.
+func (s *RateLimiters) ForStep(step string, rt StepRuntime, agent *Agent) *RateLimiter {
+	if rt.RateLimit != nil {
+		return s.For("step:"+step, rt.RateLimit)
+	}
+	return s.For("agent:"+agent.QualifiedName(), agent.RateLimit)
+}
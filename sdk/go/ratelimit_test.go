@@ -0,0 +1,132 @@
+package multiagentspec
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitValidate(t *testing.T) {
+	for _, r := range []RateLimit{{RequestsPerMinute: 60}, {MaxConcurrent: 2}, {RequestsPerMinute: 60, Burst: 5, MaxConcurrent: 3}} {
+		if err := r.Validate(); err != nil {
+			t.Errorf("%+v: Validate() = %v", r, err)
+		}
+	}
+	tests := []struct {
+		limit RateLimit
+		want  string
+	}{
+		{RateLimit{}, "sets no limit"},
+		{RateLimit{RequestsPerMinute: -1}, "must not be negative"},
+		{RateLimit{MaxConcurrent: 2, Burst: 3}, "burst requires requests_per_minute"},
+	}
+	for _, tt := range tests {
+		err := tt.limit.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: Validate() = %v, want %q", tt.limit, err, tt.want)
+		}
+	}
+}
+
+func TestRateLimiterRate(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(&RateLimit{RequestsPerMinute: 60, Burst: 2})
+	l.now = func() time.Time { return now }
+	l.last = now
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := l.Acquire(ctx); err != nil {
+			t.Fatalf("burst acquire %d: %v", i, err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := l.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire over burst = %v, want deadline exceeded", err)
+	}
+
+	now = now.Add(time.Second)
+	if _, err := l.Acquire(context.Background()); err != nil {
+		t.Errorf("acquire after refill: %v", err)
+	}
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	l := NewRateLimiter(&RateLimit{MaxConcurrent: 2})
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := l.Acquire(context.Background())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer release()
+			mu.Lock()
+			inFlight++
+			if inFlight > peak {
+				peak = inFlight
+			}
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if peak > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", peak)
+	}
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	l := NewRateLimiter(nil)
+	for i := 0; i < 100; i++ {
+		release, err := l.Acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+}
+
+func TestRateLimitersForStep(t *testing.T) {
+	s := NewRateLimiters()
+	writer := &Agent{Name: "writer", RateLimit: &RateLimit{MaxConcurrent: 1}}
+	other := &Agent{Name: "other"}
+	stepLimit := StepRuntime{RateLimit: &RateLimit{RequestsPerMinute: 30}}
+
+	if s.ForStep("draft", StepRuntime{}, writer) != s.ForStep("edit", StepRuntime{}, writer) {
+		t.Error("agent limiter not shared across steps")
+	}
+	if s.ForStep("fanout", stepLimit, writer) != s.ForStep("fanout", stepLimit, other) {
+		t.Error("step limiter not shared across agents")
+	}
+	if s.ForStep("fanout", stepLimit, writer) == s.ForStep("draft", StepRuntime{}, writer) {
+		t.Error("step limit did not override agent limit")
+	}
+}
+
+func TestRateLimitSchema(t *testing.T) {
+	agent := []byte(`{"name": "writer", "rate_limit": {"requests_per_minute": 50, "burst": 5, "max_concurrent": 4}}`)
+	if err := ValidateAgentJSON(agent); err != nil {
+		t.Errorf("ValidateAgentJSON: %v", err)
+	}
+	bad := []byte(`{"name": "writer", "rate_limit": {"rpm": 50}}`)
+	if err := ValidateAgentJSON(bad); err == nil {
+		t.Error("ValidateAgentJSON accepted unknown rate limit field")
+	}
+	dep := []byte(`{"team": "t", "targets": [{"name": "a", "platform": "claude-code", "runtime": {"steps": {"fanout": {"rate_limit": {"requests_per_minute": 20}}}}}]}`)
+	if err := ValidateDeploymentJSON(dep); err != nil {
+		t.Errorf("ValidateDeploymentJSON: %v", err)
+	}
+}
@@ -0,0 +1,417 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AgentRef is a team's reference to an agent: an agent name or qualified
+// name, optionally pinned to compatible versions with name@constraint
+// (e.g., security-analyst@^2).
+type AgentRef struct {
+	// Name is the agent's name or qualified name.
+	Name string
+
+	// Constraint limits the versions that satisfy the reference; nil
+	// accepts any version, including unversioned agents.
+	Constraint *VersionConstraint
+}
+
+// ParseAgentRef parses a Team.Agents entry.
+func ParseAgentRef(entry string) (AgentRef, error) {
+	name, constraint, pinned := strings.Cut(entry, "@")
+	if name == "" {
+		return AgentRef{}, fmt.Errorf("agent reference %q has no name", entry)
+	}
+	ref := AgentRef{Name: name}
+	if !pinned {
+		return ref, nil
+	}
+	if strings.TrimSpace(constraint) == "" {
+		return AgentRef{}, fmt.Errorf("agent reference %q has an empty version constraint", entry)
+	}
+	c, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return AgentRef{}, fmt.Errorf("agent %s: %w", name, err)
+	}
+	ref.Constraint = c
+	return ref, nil
+}
+
+// String returns the reference in Team.Agents form.
+func (r AgentRef) String() string {
+	if r.Constraint == nil {
+		return r.Name
+	}
+	return r.Name + "@" + r.Constraint.String()
+}
+
+// AgentRegistry indexes agent definitions by qualified name and version so
+// team references can be resolved to a specific revision.
+type AgentRegistry struct {
+	byQualified map[string][]registeredAgent
+	byName      map[string]string // plain name -> first qualified name seen
+}
+
+type registeredAgent struct {
+	agent   *Agent
+	version *Version // nil for unversioned agents
+}
+
+// NewAgentRegistry returns a registry holding agents. Several revisions of
+// one agent may be registered if their versions differ.
+func NewAgentRegistry(agents []*Agent) (*AgentRegistry, error) {
+	r := &AgentRegistry{
+		byQualified: make(map[string][]registeredAgent, len(agents)),
+		byName:      make(map[string]string, len(agents)),
+	}
+	for _, a := range agents {
+		if err := r.Add(a); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Add registers an agent. It fails if the agent's version is invalid or
+// the same revision is already registered.
+func (r *AgentRegistry) Add(a *Agent) error {
+	qn := a.QualifiedName()
+	entry := registeredAgent{agent: a}
+	if a.Version != "" {
+		v, err := ParseVersion(a.Version)
+		if err != nil {
+			return fmt.Errorf("agent %s: %w", qn, err)
+		}
+		entry.version = &v
+	}
+	for _, e := range r.byQualified[qn] {
+		if e.agent.Version == a.Version || (e.version != nil && entry.version != nil && e.version.Compare(*entry.version) == 0) {
+			if a.Version == "" {
+				return fmt.Errorf("agent %s is defined more than once", qn)
+			}
+			return fmt.Errorf("agent %s version %s is defined more than once", qn, a.Version)
+		}
+	}
+	r.byQualified[qn] = append(r.byQualified[qn], entry)
+	if _, ok := r.byName[a.Name]; !ok {
+		r.byName[a.Name] = qn
+	}
+	return nil
+}
+
+// Resolve returns the agent a Team.Agents entry refers to: the highest
+// version satisfying its constraint, matching the qualified name or else
+// the plain name. Without a constraint the highest release is chosen,
+// falling back to pre-releases and then to an unversioned revision.
+func (r *AgentRegistry) Resolve(entry string) (*Agent, error) {
+	ref, err := ParseAgentRef(entry)
+	if err != nil {
+		return nil, err
+	}
+	qn := ref.Name
+	if _, ok := r.byQualified[qn]; !ok {
+		qn = r.byName[ref.Name]
+	}
+	candidates := r.byQualified[qn]
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("unknown agent %q", ref.Name)
+	}
+
+	var best *registeredAgent
+	for i := range candidates {
+		c := &candidates[i]
+		if ref.Constraint != nil && (c.version == nil || !ref.Constraint.Allows(*c.version)) {
+			continue
+		}
+		if best == nil || c.preferredTo(best) {
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("agent %s: no version satisfies %s (available: %s)", qn, ref.Constraint, availableVersions(candidates))
+	}
+	return best.agent, nil
+}
+
+// preferredTo reports whether e should be chosen over other: releases
+// beat pre-releases, which beat unversioned agents, and higher versions
+// beat lower ones.
+func (e *registeredAgent) preferredTo(other *registeredAgent) bool {
+	if e.rank() != other.rank() {
+		return e.rank() > other.rank()
+	}
+	return e.version != nil && e.version.Compare(*other.version) > 0
+}
+
+func (e *registeredAgent) rank() int {
+	switch {
+	case e.version == nil:
+		return 0
+	case e.version.Prerelease != "":
+		return 1
+	}
+	return 2
+}
+
+func availableVersions(candidates []registeredAgent) string {
+	versions := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		if c.agent.Version == "" {
+			versions = append(versions, "unversioned")
+		} else {
+			versions = append(versions, c.agent.Version)
+		}
+	}
+	return strings.Join(versions, ", ")
+}
+
+// ResolveAgents resolves the team's agent entries against agents, in team
+// order, checking that each pinned agent has a compatible version.
+func (t *Team) ResolveAgents(agents []*Agent) ([]*Agent, error) {
+	r, err := NewAgentRegistry(agents)
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", t.Name, err)
+	}
+	resolved := make([]*Agent, 0, len(t.Agents))
+	for _, entry := range t.Agents {
+		a, err := r.Resolve(entry)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", t.Name, err)
+		}
+		resolved = append(resolved, a)
+	}
+	return resolved, nil
+}
+
+// Lookup returns the agent registered under qualifiedName, preferring the
+// highest release when several revisions are registered.
+func (r *AgentRegistry) Lookup(qualifiedName string) (*Agent, bool) {
+	candidates := r.byQualified[qualifiedName]
+	var best *registeredAgent
+	for i := range candidates {
+		if best == nil || candidates[i].preferredTo(best) {
+			best = &candidates[i]
+		}
+	}
+	if best == nil {
+		return nil, false
+	}
+	return best.agent, true
+}
+
+// ResolveFrom resolves an entry referenced by agent a, such as one of its
+// Dependencies. An entry without a namespace is looked up in a's namespace
+// first, so agents in one namespace can refer to each other by plain name;
+// otherwise it resolves as Resolve does, which allows cross-namespace
+// references by qualified name.
+func (r *AgentRegistry) ResolveFrom(a *Agent, entry string) (*Agent, error) {
+	if a.Namespace != "" && !strings.Contains(entry, "/") {
+		name, _, _ := strings.Cut(entry, "@")
+		if _, ok := r.byQualified[a.Namespace+"/"+name]; ok {
+			return r.Resolve(a.Namespace + "/" + entry)
+		}
+	}
+	return r.Resolve(entry)
+}
+
+// Dependencies resolves the agent's Dependencies, in order.
+func (r *AgentRegistry) Dependencies(a *Agent) ([]*Agent, error) {
+	deps := make([]*Agent, 0, len(a.Dependencies))
+	for _, entry := range a.Dependencies {
+		d, err := r.ResolveFrom(a, entry)
+		if err != nil {
+			return nil, fmt.Errorf("agent %s: dependency: %w", a.QualifiedName(), err)
+		}
+		deps = append(deps, d)
+	}
+	return deps, nil
+}
+
+// Closure returns the given agents and everything they transitively depend
+// on, each once: roots first in order, then dependencies, each after its
+// own dependencies. It fails if a dependency does not resolve or the
+// dependency graph has a cycle, reported as a *DependencyCycleError.
+func (r *AgentRegistry) Closure(roots []*Agent) ([]*Agent, error) {
+	return r.closure(roots, true)
+}
+
+// closure implements Closure; unless strict, unresolved dependencies are
+// skipped rather than reported.
+func (r *AgentRegistry) closure(roots []*Agent, strict bool) ([]*Agent, error) {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[*Agent]int)
+	var path []*Agent
+	var deps []*Agent
+	var visit func(a *Agent) error
+	visit = func(a *Agent) error {
+		switch state[a] {
+		case done:
+			return nil
+		case visiting:
+			return newCycleError(path, a)
+		}
+		state[a] = visiting
+		path = append(path, a)
+		for _, entry := range a.Dependencies {
+			d, err := r.ResolveFrom(a, entry)
+			if err != nil {
+				if !strict {
+					continue
+				}
+				return fmt.Errorf("agent %s: dependency: %w", a.QualifiedName(), err)
+			}
+			if err := visit(d); err != nil {
+				return err
+			}
+			if !containsAgent(roots, d) && !containsAgent(deps, d) {
+				deps = append(deps, d)
+			}
+		}
+		path = path[:len(path)-1]
+		state[a] = done
+		return nil
+	}
+	for _, a := range roots {
+		if err := visit(a); err != nil {
+			return nil, err
+		}
+	}
+	out := make([]*Agent, 0, len(roots)+len(deps))
+	for _, a := range roots {
+		if !containsAgent(out, a) {
+			out = append(out, a)
+		}
+	}
+	return append(out, deps...), nil
+}
+
+// CheckCycles returns a *DependencyCycleError if the dependencies of the
+// registered agents form a cycle. Unresolved dependencies are ignored.
+func (r *AgentRegistry) CheckCycles() error {
+	_, err := r.closure(r.Agents(), false)
+	return err
+}
+
+// Agents returns every registered agent revision, sorted by qualified name
+// and then version.
+func (r *AgentRegistry) Agents() []*Agent {
+	names := make([]string, 0, len(r.byQualified))
+	for qn := range r.byQualified {
+		names = append(names, qn)
+	}
+	sort.Strings(names)
+	var out []*Agent
+	for _, qn := range names {
+		candidates := append([]registeredAgent(nil), r.byQualified[qn]...)
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[j].preferredTo(&candidates[i]) })
+		for _, c := range candidates {
+			out = append(out, c.agent)
+		}
+	}
+	return out
+}
+
+// TeamClosure returns every agent needed to run the team: its resolved
+// members followed by their transitive dependencies.
+func (r *AgentRegistry) TeamClosure(t *Team) ([]*Agent, error) {
+	members := make([]*Agent, 0, len(t.Agents))
+	for _, entry := range t.Agents {
+		a, err := r.Resolve(entry)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", t.Name, err)
+		}
+		members = append(members, a)
+	}
+	closure, err := r.Closure(members)
+	if err != nil {
+		return nil, fmt.Errorf("team %s: %w", t.Name, err)
+	}
+	return closure, nil
+}
+
+// DependencyCycleError reports agents whose dependencies form a cycle.
+type DependencyCycleError struct {
+	// Cycle is the qualified names along the cycle, starting and ending
+	// with the same agent.
+	Cycle []string
+}
+
+func (e *DependencyCycleError) Error() string {
+	return "dependency cycle: " + strings.Join(e.Cycle, " -> ")
+}
+
+func newCycleError(path []*Agent, back *Agent) error {
+	start := 0
+	for i, a := range path {
+		if a == back {
+			start = i
+		}
+	}
+	cycle := make([]string, 0, len(path)-start+1)
+	for _, a := range path[start:] {
+		cycle = append(cycle, a.QualifiedName())
+	}
+	return &DependencyCycleError{Cycle: append(cycle, back.QualifiedName())}
+}
+
+func containsAgent(agents []*Agent, a *Agent) bool {
+	for _, b := range agents {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
+// SharedNamespace is the namespace whose agents a team member may depend
+// on without the team listing them.
+const SharedNamespace = "shared"
+
+// CheckTeamDependencies verifies that every dependency of the team's
+// members resolves to another member or to an agent in SharedNamespace (or
+// a namespace below it), so the team is self-contained when deployed.
+func (r *AgentRegistry) CheckTeamDependencies(t *Team) error {
+	members := make([]*Agent, 0, len(t.Agents))
+	for _, entry := range t.Agents {
+		a, err := r.Resolve(entry)
+		if err != nil {
+			return fmt.Errorf("team %s: %w", t.Name, err)
+		}
+		members = append(members, a)
+	}
+	for _, a := range members {
+		for _, entry := range a.Dependencies {
+			d, err := r.ResolveFrom(a, entry)
+			if err != nil {
+				return fmt.Errorf("team %s: agent %s: dependency: %w", t.Name, a.QualifiedName(), err)
+			}
+			if containsAgent(members, d) || d.Namespace == SharedNamespace || strings.HasPrefix(d.Namespace, SharedNamespace+"/") {
+				continue
+			}
+			return fmt.Errorf("team %s: agent %s depends on %s, which is neither on the team nor in the %s namespace", t.Name, a.QualifiedName(), d.QualifiedName(), SharedNamespace)
+		}
+	}
+	return nil
+}
+
+// RequiredBinaries returns the external tools and binaries (Agent.Requires)
+// needed by agents, sorted and without duplicates.
+func RequiredBinaries(agents []*Agent) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, a := range agents {
+		for _, bin := range a.Requires {
+			if bin = strings.TrimSpace(bin); bin != "" && !seen[bin] {
+				seen[bin] = true
+				out = append(out, bin)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
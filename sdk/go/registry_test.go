@@ -0,0 +1,242 @@
+package multiagentspec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func versionedAgent(name, version string) *Agent {
+	a := NewAgent(name, "")
+	a.Version = version
+	return a
+}
+
+func TestParseAgentRef(t *testing.T) {
+	ref, err := ParseAgentRef("security-analyst@^2")
+	if err != nil {
+		t.Fatalf("ParseAgentRef: %v", err)
+	}
+	if ref.Name != "security-analyst" || ref.Constraint == nil || ref.String() != "security-analyst@^2" {
+		t.Errorf("ParseAgentRef = %+v", ref)
+	}
+	if ref, err := ParseAgentRef("shared/qa"); err != nil || ref.Name != "shared/qa" || ref.Constraint != nil {
+		t.Errorf("ParseAgentRef(shared/qa) = %+v, %v", ref, err)
+	}
+	for _, bad := range []string{"@^2", "qa@", "qa@^x"} {
+		if _, err := ParseAgentRef(bad); err == nil {
+			t.Errorf("ParseAgentRef(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestAgentRegistryResolve(t *testing.T) {
+	r, err := NewAgentRegistry([]*Agent{
+		versionedAgent("security-analyst", "1.4.0"),
+		versionedAgent("security-analyst", "2.0.0"),
+		versionedAgent("security-analyst", "2.3.1"),
+		versionedAgent("security-analyst", "3.0.0-rc.1"),
+		NewAgent("qa", "").WithNamespace("shared"),
+	})
+	if err != nil {
+		t.Fatalf("NewAgentRegistry: %v", err)
+	}
+
+	tests := []struct {
+		entry string
+		want  string
+	}{
+		{"security-analyst", "2.3.1"},
+		{"security-analyst@^2", "2.3.1"},
+		{"security-analyst@~2.0", "2.0.0"},
+		{"security-analyst@1", "1.4.0"},
+		{"security-analyst@>=3.0.0-rc.1", "3.0.0-rc.1"},
+		{"qa", ""},
+		{"shared/qa", ""},
+	}
+	for _, tt := range tests {
+		a, err := r.Resolve(tt.entry)
+		if err != nil {
+			t.Errorf("Resolve(%q): %v", tt.entry, err)
+			continue
+		}
+		if a.Version != tt.want {
+			t.Errorf("Resolve(%q) = version %q, want %q", tt.entry, a.Version, tt.want)
+		}
+	}
+
+	_, err = r.Resolve("security-analyst@^4")
+	if err == nil || !strings.Contains(err.Error(), "no version satisfies ^4 (available: 1.4.0, 2.0.0, 2.3.1, 3.0.0-rc.1)") {
+		t.Errorf("Resolve(^4) error = %v", err)
+	}
+	if _, err := r.Resolve("qa@^1"); err == nil || !strings.Contains(err.Error(), "available: unversioned") {
+		t.Errorf("Resolve(qa@^1) error = %v, want unversioned agent rejected", err)
+	}
+	if _, err := r.Resolve("missing"); err == nil {
+		t.Error("expected error for unknown agent")
+	}
+}
+
+func TestNewAgentRegistryErrors(t *testing.T) {
+	if _, err := NewAgentRegistry([]*Agent{versionedAgent("pm", "2.0.0"), versionedAgent("pm", "v2.0.0")}); err == nil || !strings.Contains(err.Error(), "defined more than once") {
+		t.Errorf("duplicate version error = %v", err)
+	}
+	if _, err := NewAgentRegistry([]*Agent{NewAgent("pm", ""), NewAgent("pm", "")}); err == nil {
+		t.Error("expected error for duplicate unversioned agent")
+	}
+	if _, err := NewAgentRegistry([]*Agent{versionedAgent("pm", "2")}); err == nil {
+		t.Error("expected error for invalid version")
+	}
+}
+
+func TestTeamResolveAgents(t *testing.T) {
+	team := NewTeam("release-team", "1.0.0").WithAgents("pm@^1", "qa")
+	agents := []*Agent{versionedAgent("pm", "1.2.0"), versionedAgent("pm", "2.0.0"), NewAgent("qa", "")}
+	resolved, err := team.ResolveAgents(agents)
+	if err != nil {
+		t.Fatalf("ResolveAgents: %v", err)
+	}
+	if len(resolved) != 2 || resolved[0] != agents[0] || resolved[1] != agents[2] {
+		t.Errorf("ResolveAgents = %v", resolved)
+	}
+
+	team.Agents[0] = "pm@^3"
+	if _, err := team.ResolveAgents(agents); err == nil || !strings.HasPrefix(err.Error(), "team release-team: agent pm: no version satisfies ^3") {
+		t.Errorf("ResolveAgents error = %v", err)
+	}
+
+	team.Agents[0] = "pm@^x"
+	if err := team.Validate(); err == nil {
+		t.Error("expected Validate error for malformed constraint")
+	}
+}
+
+func dependentAgent(qualifiedName string, deps ...string) *Agent {
+	ns, name := ParseQualifiedName(qualifiedName)
+	a := NewAgent(name, "").WithNamespace(ns)
+	a.Dependencies = deps
+	return a
+}
+
+func qualifiedNames(agents []*Agent) []string {
+	names := make([]string, 0, len(agents))
+	for _, a := range agents {
+		names = append(names, a.QualifiedName())
+	}
+	return names
+}
+
+func TestAgentRegistryDependencies(t *testing.T) {
+	r, err := NewAgentRegistry([]*Agent{
+		dependentAgent("prd/lead", "requirements", "shared/review-board"),
+		dependentAgent("prd/requirements", "research"),
+		dependentAgent("research"),
+		dependentAgent("requirements"),
+		dependentAgent("shared/review-board", "research"),
+	})
+	if err != nil {
+		t.Fatalf("NewAgentRegistry: %v", err)
+	}
+
+	lead, ok := r.Lookup("prd/lead")
+	if !ok {
+		t.Fatal("Lookup(prd/lead) not found")
+	}
+	if _, ok := r.Lookup("lead"); ok {
+		t.Error("Lookup should match qualified names only")
+	}
+	deps, err := r.Dependencies(lead)
+	if err != nil {
+		t.Fatalf("Dependencies: %v", err)
+	}
+	if got, want := strings.Join(qualifiedNames(deps), ","), "prd/requirements,shared/review-board"; got != want {
+		t.Errorf("Dependencies = %s, want %s (same-namespace name first)", got, want)
+	}
+
+	closure, err := r.TeamClosure(NewTeam("prd-team", "1.0.0").WithAgents("prd/lead"))
+	if err != nil {
+		t.Fatalf("TeamClosure: %v", err)
+	}
+	if got, want := strings.Join(qualifiedNames(closure), ","), "prd/lead,research,prd/requirements,shared/review-board"; got != want {
+		t.Errorf("TeamClosure = %s, want %s", got, want)
+	}
+	if err := r.CheckCycles(); err != nil {
+		t.Errorf("CheckCycles: %v", err)
+	}
+
+	if err := r.Add(dependentAgent("orphan", "missing")); err != nil {
+		t.Fatal(err)
+	}
+	orphan, _ := r.Lookup("orphan")
+	if _, err := r.Closure([]*Agent{orphan}); err == nil || !strings.Contains(err.Error(), `agent orphan: dependency: unknown agent "missing"`) {
+		t.Errorf("Closure error = %v, want unresolved dependency", err)
+	}
+	if err := r.CheckCycles(); err != nil {
+		t.Errorf("CheckCycles should ignore unresolved dependencies: %v", err)
+	}
+}
+
+func TestAgentRegistryCycles(t *testing.T) {
+	r, err := NewAgentRegistry([]*Agent{
+		dependentAgent("a", "b"),
+		dependentAgent("b", "shared/c"),
+		dependentAgent("shared/c", "a"),
+		dependentAgent("d", "a"),
+	})
+	if err != nil {
+		t.Fatalf("NewAgentRegistry: %v", err)
+	}
+	err = r.CheckCycles()
+	var cycle *DependencyCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("CheckCycles = %v, want *DependencyCycleError", err)
+	}
+	if got, want := strings.Join(cycle.Cycle, " -> "), "a -> b -> shared/c -> a"; got != want {
+		t.Errorf("cycle = %s, want %s", got, want)
+	}
+	d, _ := r.Lookup("d")
+	if _, err := r.Closure([]*Agent{d}); !errors.As(err, &cycle) {
+		t.Errorf("Closure = %v, want cycle error", err)
+	}
+}
+
+func TestAgentRegistryCheckTeamDependencies(t *testing.T) {
+	r, err := NewAgentRegistry([]*Agent{
+		dependentAgent("pm", "qa", "shared/review-board"),
+		dependentAgent("qa", "shared/security/scanner"),
+		dependentAgent("shared/review-board"),
+		dependentAgent("shared/security/scanner"),
+		dependentAgent("designer"),
+		dependentAgent("prd/lead", "designer"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CheckTeamDependencies(NewTeam("release-team", "1.0.0").WithAgents("pm", "qa")); err != nil {
+		t.Errorf("CheckTeamDependencies: %v", err)
+	}
+
+	err = r.CheckTeamDependencies(NewTeam("release-team", "1.0.0").WithAgents("pm"))
+	if err == nil || err.Error() != "team release-team: agent pm depends on qa, which is neither on the team nor in the shared namespace" {
+		t.Errorf("CheckTeamDependencies error = %v", err)
+	}
+	if err := r.CheckTeamDependencies(NewTeam("prd-team", "1.0.0").WithAgents("prd/lead")); err == nil {
+		t.Error("expected error for top-level dependency outside the team")
+	}
+	if err := r.Add(dependentAgent("broken", "missing")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CheckTeamDependencies(NewTeam("t", "1.0.0").WithAgents("broken")); err == nil || !strings.Contains(err.Error(), `unknown agent "missing"`) {
+		t.Errorf("CheckTeamDependencies error = %v, want unresolved dependency", err)
+	}
+}
+
+func TestRequiredBinaries(t *testing.T) {
+	a := NewAgent("go-dev", "")
+	a.Requires = []string{"go", "git", " golangci-lint "}
+	b := NewAgent("release", "")
+	b.Requires = []string{"git", "gh", ""}
+	if got := strings.Join(RequiredBinaries([]*Agent{a, b}), ","); got != "gh,git,go,golangci-lint" {
+		t.Errorf("RequiredBinaries = %s", got)
+	}
+}
@@ -3,43 +3,219 @@ package multiagentspec
 import (
 	"fmt"
 	"io"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"text/template"
+	"unicode/utf8"
+
+	"golang.org/x/term"
 )
 
 const (
-	// boxWidth is the inner width of the box (between the border characters).
+	// boxWidth is the default inner width of the box (between the border
+	// characters), for 80 columns.
 	boxWidth = 78
+
+	// minBoxColumns is the narrowest box, borders included, that fits a
+	// task line.
+	minBoxColumns = 60
 )
 
 // Renderer renders TeamReport to various formats using text/template.
 type Renderer struct {
-	w io.Writer
+	w           io.Writer
+	theme       *Theme
+	columns     int
+	auto        bool
+	compact     bool
+	noDurations bool
+	locations   *LocationResolver
+	dag         bool
+}
+
+// RendererOption configures a Renderer.
+type RendererOption func(*Renderer)
+
+// WithTheme draws the box with theme instead of ThemeDouble.
+func WithTheme(theme Theme) RendererOption {
+	return func(r *Renderer) {
+		r.theme = &theme
+	}
+}
+
+// WithWidth renders the box columns wide, borders included, instead of
+// 80. Widths under 60 columns are rendered at 60.
+func WithWidth(columns int) RendererOption {
+	return func(r *Renderer) {
+		r.columns = columns
+		r.auto = false
+	}
+}
+
+// WithAutoWidth renders the box as wide as the terminal the renderer
+// writes to, or 80 columns when it does not write to a terminal.
+func WithAutoWidth() RendererOption {
+	return func(r *Renderer) {
+		r.auto = true
+	}
+}
+
+// WithCompact renders a short executive box, e.g., for chat or CI logs:
+// teams with status GO collapse to a single summary line, SKIP tasks are
+// hidden, and content blocks with nothing to show are left out.
+func WithCompact() RendererOption {
+	return func(r *Renderer) {
+		r.compact = true
+	}
+}
+
+// WithDurations sets whether task lines end with the task's duration,
+// when it has one. Durations are shown by default.
+func WithDurations(show bool) RendererOption {
+	return func(r *Renderer) {
+		r.noDurations = !show
+	}
+}
+
+// WithLocations shows the source locations of file tasks before their
+// detail, and those of issues, as "file:line", resolved by l.
+func WithLocations(l *LocationResolver) RendererOption {
+	return func(r *Renderer) {
+		r.locations = l
+	}
+}
+
+// WithDAG draws the teams' dependency DAG after the phase, as a diagram
+// of levels from left to right joined by arrows, when any team depends on
+// another.
+func WithDAG() RendererOption {
+	return func(r *Renderer) {
+		r.dag = true
+	}
 }
 
 // NewRenderer creates a new Renderer writing to w.
-func NewRenderer(w io.Writer) *Renderer {
-	return &Renderer{w: w}
+func NewRenderer(w io.Writer, opts ...RendererOption) *Renderer {
+	r := &Renderer{w: w, theme: &ThemeDouble, columns: boxWidth + 2}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.auto {
+		r.columns = boxWidth + 2
+		if columns, ok := terminalWidth(w); ok {
+			r.columns = columns
+		}
+	}
+	r.columns = max(r.columns, minBoxColumns)
+	return r
+}
+
+// terminalWidth returns the width of the terminal w writes to, if any.
+func terminalWidth(w io.Writer) (int, bool) {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		return 0, false
+	}
+	columns, _, err := term.GetSize(int(f.Fd()))
+	if err != nil || columns <= 0 {
+		return 0, false
+	}
+	return columns, true
+}
+
+// box draws the box format with a theme at a width.
+type box struct {
+	*Theme
+
+	// width is the inner width, between the border characters.
+	width int
+
+	// compact is set by WithCompact.
+	compact bool
+
+	// durations is unset by WithDurations(false).
+	durations bool
+
+	// locations is set by WithLocations.
+	locations *LocationResolver
+
+	// dag is set by WithDAG.
+	dag bool
+
+	// rule is the horizontal border, width characters long.
+	rule string
 }
 
 // Render renders the report using the box template.
-// It automatically sorts teams by DAG order before rendering.
+// It automatically sorts teams by DAG order before rendering, in place;
+// RenderBox leaves the report unchanged. Teams in a dependency cycle are
+// rendered after the others in report order, and the final status line
+// names the cycle (see FinalMessage).
 func (r *Renderer) Render(report *TeamReport) error {
-	report.SortByDAG()
+	_ = report.SortByDAG() // the cycle is noted in the final status line
 	return r.renderBox(report)
 }
 
-// renderBox renders the report in the box format.
+// RenderBox renders report in the box format to w, configured by opts.
+// Unlike Renderer.Render, it sorts a copy of the report's teams, leaving
+// report unchanged, so it is safe for concurrent use, including on the
+// same report.
+func RenderBox(w io.Writer, report *TeamReport, opts ...RendererOption) error {
+	return NewRenderer(w, opts...).renderBox(report.sortedByDAG())
+}
+
+var (
+	boxTemplateOnce sync.Once
+	boxTemplate     *template.Template
+	boxTemplateErr  error
+)
+
+// template returns BoxTemplate drawing with b. The template is parsed
+// once on first use; each call gets a copy with b's functions.
+func (b *box) template() (*template.Template, error) {
+	boxTemplateOnce.Do(func() {
+		boxTemplate, boxTemplateErr = template.New("report").Funcs((&box{}).templateFuncs()).Parse(BoxTemplate)
+	})
+	if boxTemplateErr != nil {
+		return nil, fmt.Errorf("parsing template: %w", boxTemplateErr)
+	}
+	tmpl, err := boxTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.Funcs(b.templateFuncs()), nil
+}
+
+// renderBox renders the report in the box format, inside a code fence
+// if the theme has one.
 func (r *Renderer) renderBox(report *TeamReport) error {
-	tmpl, err := template.New("report").Funcs(templateFuncs()).Parse(BoxTemplate)
+	b := &box{Theme: r.theme, width: r.columns - 2, compact: r.compact, durations: !r.noDurations, locations: r.locations, dag: r.dag}
+	b.rule = strings.Repeat(b.Horizontal, b.width)
+	tmpl, err := b.template()
 	if err != nil {
-		return fmt.Errorf("parsing template: %w", err)
+		return err
+	}
+	if r.theme.Fence {
+		if _, err := io.WriteString(r.w, "```\n"); err != nil {
+			return err
+		}
 	}
-	return tmpl.Execute(r.w, report)
+	if err := tmpl.Execute(r.w, report); err != nil {
+		return err
+	}
+	if r.theme.Fence {
+		if _, err := io.WriteString(r.w, "```\n"); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // QuickRenderer renders TeamReport using quicktemplate (compile-time type-safe).
 // This is an alternative to Renderer that uses generated code instead of reflection.
+// It draws ThemeDouble only.
 type QuickRenderer struct {
 	w io.Writer
 }
@@ -50,79 +226,147 @@ func NewQuickRenderer(w io.Writer) *QuickRenderer {
 }
 
 // Render renders the report using quicktemplate.
-// It automatically sorts teams by DAG order before rendering.
+// It automatically sorts teams by DAG order before rendering, noting any
+// cycle as Renderer.Render does.
 func (r *QuickRenderer) Render(report *TeamReport) error {
-	report.SortByDAG()
+	_ = report.SortByDAG() // the cycle is noted in the final status line
 	WriteBoxReport(r.w, report)
 	return nil
 }
 
-// templateFuncs returns the template function map.
-func templateFuncs() template.FuncMap {
+// templateFuncs returns the template function map, drawing with b.
+func (b *box) templateFuncs() template.FuncMap {
 	return template.FuncMap{
-		"header":           header,
-		"separator":        separator,
-		"footer":           footer,
-		"teamHeader":       teamHeader,
-		"taskLine":         taskLine,
-		"centerLine":       centerLine,
-		"paddedLine":       paddedLine,
-		"finalMessage":     finalMessage,
-		"renderBlock":      renderBlock,
-		"renderBlocks":     renderBlocks,
-		"hasContentBlocks": hasContentBlocks,
-		"hasSummaryBlocks": hasSummaryBlocks,
-		"hasFooterBlocks":  hasFooterBlocks,
+		"header":           b.header,
+		"separator":        b.separator,
+		"footer":           b.footer,
+		"teamHeader":       b.teamHeader,
+		"taskLines":        b.taskLines,
+		"centerLine":       b.centerLine,
+		"paddedLine":       b.paddedLine,
+		"finalMessage":     b.finalMessage,
+		"renderBlock":      b.renderBlock,
+		"renderBlocks":     b.renderBlocks,
+		"hasContentBlocks": b.hasContentBlocks,
+		"hasSummaryBlocks": b.hasSummaryBlocks,
+		"hasFooterBlocks":  b.hasFooterBlocks,
+		"teams":            b.teams,
+		"collapsedTeams":   b.collapsedTeams,
+		"dagDiagram":       b.dagDiagram,
+		"costSummary":      costSummaryBlocks,
+		"issues":           b.issues,
 		"hasTags":          hasTags,
-		"renderTags":       renderTags,
+		"renderTags":       b.renderTags,
 	}
 }
 
 // header returns the top border of the box.
-func header() string {
-	return "╔" + strings.Repeat("═", boxWidth) + "╗"
+func (b *box) header() string {
+	return b.TopLeft + b.rule + b.TopRight
 }
 
 // separator returns a separator line.
-func separator() string {
-	return "╠" + strings.Repeat("═", boxWidth) + "╣"
+func (b *box) separator() string {
+	return b.SeparatorLeft + b.rule + b.SeparatorRight
 }
 
 // footer returns the bottom border of the box.
-func footer() string {
-	return "╚" + strings.Repeat("═", boxWidth) + "╝"
+func (b *box) footer() string {
+	return b.BottomLeft + b.rule + b.BottomRight
 }
 
 // centerLine centers text within the box.
-func centerLine(text string) string {
+func (b *box) centerLine(text string) string {
 	visualLen := visualLength(text)
-	padding := max(0, boxWidth-visualLen)
+	padding := max(0, b.width-visualLen)
 	left := padding / 2
-	right := padding - left
-	return "║" + strings.Repeat(" ", left) + text + strings.Repeat(" ", right) + "║"
+	var sb strings.Builder
+	sb.Grow(b.lineSize(text))
+	sb.WriteString(b.Vertical)
+	writeSpaces(&sb, left)
+	sb.WriteString(text)
+	writeSpaces(&sb, padding-left)
+	sb.WriteString(b.Vertical)
+	return sb.String()
 }
 
 // paddedLine left-aligns text with padding.
-func paddedLine(text string) string {
-	visualLen := visualLength(text)
-	padding := max(0, boxWidth-visualLen-1)
-	return "║ " + text + strings.Repeat(" ", padding) + "║"
+func (b *box) paddedLine(text string) string {
+	var sb strings.Builder
+	sb.Grow(b.lineSize(text))
+	b.writePaddedLine(&sb, text)
+	return sb.String()
+}
+
+// writePaddedLine writes text as paddedLine does.
+func (b *box) writePaddedLine(sb *strings.Builder, text string) {
+	sb.WriteString(b.Vertical)
+	sb.WriteString(" ")
+	sb.WriteString(text)
+	writeSpaces(sb, max(0, b.width-visualLength(text)-1))
+	sb.WriteString(b.Vertical)
+}
+
+// lineSize returns the size in bytes of a line holding text, for
+// preallocating it.
+func (b *box) lineSize(text string) int {
+	return 2*len(b.Vertical) + b.width + len(text)
+}
+
+// spaces is a run of spaces that lines are padded from.
+var spaces = strings.Repeat(" ", 128)
+
+// writeSpaces writes n spaces to sb.
+func writeSpaces(sb *strings.Builder, n int) {
+	for n > 0 {
+		k := min(n, len(spaces))
+		sb.WriteString(spaces[:k])
+		n -= k
+	}
+}
+
+// writePadded writes s padded with spaces to n runes, like fmt's %-*s, and
+// returns the columns written.
+func writePadded(sb *strings.Builder, s string, n int) int {
+	sb.WriteString(s)
+	padding := max(0, n-utf8.RuneCountInString(s))
+	writeSpaces(sb, padding)
+	return visualLength(s) + padding
 }
 
 // teamHeader formats a team header line with status icon and optional verdict.
-func teamHeader(team TeamSection) string {
+func (b *box) teamHeader(team TeamSection) string {
 	icon := team.Status.Icon()
 	var text string
 	if team.Verdict != "" {
-		text = fmt.Sprintf("%s %s — %s — %s", icon, team.Name, team.Status, team.Verdict)
+		text = fmt.Sprintf("%s %s — %s — %s", icon, team.Name, team.StatusLabel(), team.Verdict)
 	} else {
-		text = fmt.Sprintf("%s %s — %s", icon, team.Name, team.Status)
+		text = fmt.Sprintf("%s %s — %s", icon, team.Name, team.StatusLabel())
 	}
-	return paddedLine(text)
+	return b.paddedLine(text)
 }
 
-// taskLine formats a single task result line with optional severity.
-func taskLine(task TaskResult) string {
+// taskLines formats the lines of the team's tasks, as returned by tasks,
+// or returns "" if there are none.
+func (b *box) taskLines(team TeamSection) string {
+	tasks := b.tasks(team)
+	if len(tasks) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(len(tasks) * (b.lineSize("") + 32))
+	for i, task := range tasks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		b.writeTaskLine(&sb, task)
+	}
+	return sb.String()
+}
+
+// writeTaskLine writes a single task result line, with optional
+// severity, to sb.
+func (b *box) writeTaskLine(sb *strings.Builder, task TaskResult) {
 	id := task.ID
 	if len(id) > 24 {
 		id = id[:21] + "..."
@@ -133,22 +377,63 @@ func taskLine(task TaskResult) string {
 
 	// Add severity in brackets if present
 	if task.Severity != "" {
-		statusText = fmt.Sprintf("%s [%s]", statusText, task.Severity)
+		statusText += " [" + task.Severity + "]"
+	}
+
+	var buf [24]byte
+	var duration []byte
+	if b.durations && task.DurationMs > 0 {
+		duration = appendDuration(buf[:0], task.DurationMs)
 	}
 
 	detail := task.Detail
-	maxDetail := boxWidth - 45 // Reduced to accommodate severity
+	if b.locations != nil {
+		if loc := task.Location(); loc != "" {
+			detail = strings.TrimSuffix(b.locations.Text(loc)+": "+detail, ": ")
+		}
+	}
+	maxDetail := b.width - 47 // The rest of the line and the left margin take 47 columns
+	if len(duration) > 0 {
+		// Drop the duration when it leaves no room on a narrow box
+		if maxDetail < len(duration)+2 {
+			duration = nil
+		} else {
+			maxDetail -= len(duration) + 2
+		}
+	}
 	if len(detail) > maxDetail {
-		detail = detail[:maxDetail-3] + "..."
+		if maxDetail > 3 {
+			detail = detail[:maxDetail-3] + "..."
+		} else {
+			detail = ""
+		}
 	}
 
-	line := fmt.Sprintf("  %-24s %s %-15s %s", id, icon, statusText, detail)
-	return paddedLine(line)
+	// "  %-24s %s %-15s %s" inside the left border and margin
+	sb.WriteString(b.Vertical)
+	sb.WriteString("   ")
+	cols := 2 + writePadded(sb, id, 24)
+	sb.WriteString(" ")
+	sb.WriteString(icon)
+	sb.WriteString(" ")
+	cols += 2 + visualLength(icon) + writePadded(sb, statusText, 15)
+	sb.WriteString(" ")
+	sb.WriteString(detail)
+	cols += 1 + visualLength(detail)
+	if len(duration) > 0 {
+		// Right-align the duration, one column from the border
+		padding := max(1, b.width-2-cols-len(duration))
+		writeSpaces(sb, padding)
+		sb.Write(duration)
+		cols += padding + len(duration)
+	}
+	writeSpaces(sb, max(0, b.width-cols-1))
+	sb.WriteString(b.Vertical)
 }
 
 // finalMessage formats the final status message line.
-func finalMessage(report *TeamReport) string {
-	return centerLine(report.FinalMessage())
+func (b *box) finalMessage(report *TeamReport) string {
+	return b.centerLine(report.FinalMessage())
 }
 
 // visualLength calculates the visual length of a string,
@@ -172,14 +457,109 @@ func hasContentBlocks(team TeamSection) bool {
 	return len(team.ContentBlocks) > 0
 }
 
-// hasSummaryBlocks returns true if the report has summary blocks.
-func hasSummaryBlocks(report *TeamReport) bool {
-	return len(report.SummaryBlocks) > 0
+// teams returns the teams to render in full: in compact mode, those not
+// collapsed by collapsedTeams.
+func (b *box) teams(report *TeamReport) []TeamSection {
+	if !b.compact {
+		return report.Teams
+	}
+	var teams []TeamSection
+	for _, t := range report.Teams {
+		if t.Status != StatusGo {
+			teams = append(teams, t)
+		}
+	}
+	return teams
+}
+
+// collapsedTeams returns the compact mode line summarizing the GO teams,
+// or "" if there is none.
+func (b *box) collapsedTeams(report *TeamReport) string {
+	if !b.compact {
+		return ""
+	}
+	var names []string
+	for _, t := range report.Teams {
+		if t.Status == StatusGo {
+			names = append(names, t.Name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("%s %d %s GO: %s", StatusGo.Icon(), len(names), plural(len(names), "team", "teams"), strings.Join(names, ", "))
+	return strings.Join(b.wrapText(text, b.width-2), "\n")
 }
 
-// hasFooterBlocks returns true if the report has footer blocks.
-func hasFooterBlocks(report *TeamReport) bool {
-	return len(report.FooterBlocks) > 0
+// tasks returns the team's tasks to render: in compact mode, those not
+// skipped.
+func (b *box) tasks(team TeamSection) []TaskResult {
+	if !b.compact {
+		return team.Tasks
+	}
+	var tasks []TaskResult
+	for _, task := range team.Tasks {
+		if task.Status != StatusSkip {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// blocks returns the blocks to render: in compact mode, those that are
+// not empty.
+func (b *box) blocks(blocks []ContentBlock) []ContentBlock {
+	if !b.compact {
+		return blocks
+	}
+	var shown []ContentBlock
+	for _, block := range blocks {
+		if !block.IsEmpty() {
+			shown = append(shown, block)
+		}
+	}
+	return shown
+}
+
+// hasContentBlocks, hasSummaryBlocks, and hasFooterBlocks report whether
+// there are blocks to render.
+func (b *box) hasContentBlocks(team TeamSection) bool {
+	return len(b.blocks(team.ContentBlocks)) > 0
+}
+
+func (b *box) hasSummaryBlocks(report *TeamReport) bool {
+	return len(b.blocks(report.SummaryBlocks)) > 0
+}
+
+func (b *box) hasFooterBlocks(report *TeamReport) bool {
+	return len(b.blocks(report.FooterBlocks)) > 0
+}
+
+// costSummaryBlocks returns the report's cost summary block, or nil if no
+// team recorded token usage or cost.
+func costSummaryBlocks(report *TeamReport) []ContentBlock {
+	if block, ok := report.CostSummaryBlock(); ok {
+		return []ContentBlock{block}
+	}
+	return nil
+}
+
+// issuesBlocks returns the report's condensed issue list, or nil if no
+// team reported issues.
+func issuesBlocks(report *TeamReport) []ContentBlock {
+	if block, ok := report.IssuesBlock(); ok {
+		return []ContentBlock{block}
+	}
+	return nil
+}
+
+// issues returns the report's condensed issue list with locations
+// formatted by WithLocations, or nil if no team reported issues.
+func (b *box) issues(report *TeamReport) []ContentBlock {
+	if block, ok := report.issuesBlock(b.locations); ok {
+		return []ContentBlock{block}
+	}
+	return nil
 }
 
 // hasTags returns true if the report has tags.
@@ -188,57 +568,57 @@ func hasTags(report *TeamReport) bool {
 }
 
 // renderTags renders tags as key-value lines, sorted by key.
-func renderTags(tags map[string]string) string {
+func (b *box) renderTags(tags map[string]string) string {
 	// Sort keys for deterministic output
 	keys := make([]string, 0, len(tags))
 	for k := range tags {
 		keys = append(keys, k)
 	}
-	sortStrings(keys)
+	sort.Strings(keys)
 
 	var lines []string
 	for _, k := range keys {
-		lines = append(lines, paddedLine(fmt.Sprintf("  %s: %s", k, tags[k])))
+		lines = append(lines, b.paddedLine(fmt.Sprintf("  %s: %s", k, tags[k])))
 	}
 	return strings.Join(lines, "\n")
 }
 
 // renderBlocks renders multiple content blocks, returning joined lines.
-func renderBlocks(blocks []ContentBlock) string {
+func (b *box) renderBlocks(blocks []ContentBlock) string {
 	var lines []string
-	for _, block := range blocks {
-		lines = append(lines, renderBlock(block))
+	for _, block := range b.blocks(blocks) {
+		lines = append(lines, b.renderBlock(block))
 	}
 	return strings.Join(lines, "\n")
 }
 
 // renderBlock renders a single content block to box-formatted lines.
-func renderBlock(block ContentBlock) string {
+func (b *box) renderBlock(block ContentBlock) string {
 	var lines []string
 
 	// Add title if present
 	if block.Title != "" {
-		lines = append(lines, paddedLine(block.Title))
+		lines = append(lines, b.paddedLine(block.Title))
 	}
 
 	switch block.Type {
 	case ContentBlockKVPairs:
-		lines = append(lines, renderKVPairs(block.Pairs)...)
+		lines = append(lines, b.renderKVPairs(block.Pairs)...)
 	case ContentBlockList:
-		lines = append(lines, renderList(block.Items)...)
+		lines = append(lines, b.renderList(block.Items)...)
 	case ContentBlockText:
-		lines = append(lines, wrapText(block.Content, boxWidth-2)...)
+		lines = append(lines, b.wrapText(block.Content, b.width-2)...)
 	case ContentBlockTable:
-		lines = append(lines, renderTable(block.Headers, block.Rows)...)
+		lines = append(lines, b.renderTable(block.Headers, block.Rows)...)
 	case ContentBlockMetric:
-		lines = append(lines, renderMetric(block.Label, block.Value, block.Status, block.Target))
+		lines = append(lines, b.renderMetric(block.Label, block.Value, block.Status, block.Target))
 	}
 
 	return strings.Join(lines, "\n")
 }
 
 // renderKVPairs renders key-value pairs.
-func renderKVPairs(pairs []KVPair) []string {
+func (b *box) renderKVPairs(pairs []KVPair) []string {
 	var lines []string
 	for _, pair := range pairs {
 		var text string
@@ -247,13 +627,13 @@ func renderKVPairs(pairs []KVPair) []string {
 		} else {
 			text = fmt.Sprintf("%s: %s", pair.Key, pair.Value)
 		}
-		lines = append(lines, paddedLine(text))
+		lines = append(lines, b.paddedLine(text))
 	}
 	return lines
 }
 
 // renderList renders list items.
-func renderList(items []ListItem) []string {
+func (b *box) renderList(items []ListItem) []string {
 	var lines []string
 	for _, item := range items {
 		icon := item.EffectiveIcon()
@@ -263,38 +643,54 @@ func renderList(items []ListItem) []string {
 		} else {
 			text = fmt.Sprintf("  %s", item.Text)
 		}
-		lines = append(lines, paddedLine(text))
+		lines = append(lines, b.paddedLine(text))
 	}
 	return lines
 }
 
 // wrapText wraps text to fit within maxWidth, returning padded lines.
-func wrapText(content string, maxWidth int) []string {
+func (b *box) wrapText(content string, maxWidth int) []string {
 	var lines []string
 	words := strings.Fields(content)
-	if len(words) == 0 {
-		return lines
-	}
-
-	currentLine := ""
-	for _, word := range words {
-		if currentLine == "" {
-			currentLine = word
-		} else if len(currentLine)+1+len(word) <= maxWidth {
-			currentLine += " " + word
-		} else {
-			lines = append(lines, paddedLine(currentLine))
-			currentLine = word
+	start, length := 0, 0 // the current line is words[start:i], length bytes long
+	for i, word := range words {
+		if i > start && length+1+len(word) > maxWidth {
+			lines = append(lines, b.wordsLine(words[start:i], length))
+			start, length = i, 0
+		}
+		if i > start {
+			length++
 		}
+		length += len(word)
 	}
-	if currentLine != "" {
-		lines = append(lines, paddedLine(currentLine))
+	if start < len(words) {
+		lines = append(lines, b.wordsLine(words[start:], length))
 	}
 	return lines
 }
 
+// wordsLine returns paddedLine of the words joined by spaces, length
+// bytes long, without joining them first.
+func (b *box) wordsLine(words []string, length int) string {
+	var sb strings.Builder
+	sb.Grow(2*len(b.Vertical) + b.width + length)
+	sb.WriteString(b.Vertical)
+	sb.WriteString(" ")
+	cols := len(words) - 1
+	for i, word := range words {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(word)
+		cols += visualLength(word)
+	}
+	writeSpaces(&sb, max(0, b.width-cols-1))
+	sb.WriteString(b.Vertical)
+	return sb.String()
+}
+
 // renderTable renders a simple table.
-func renderTable(headers []string, rows [][]string) []string {
+func (b *box) renderTable(headers []string, rows [][]string) []string {
 	var lines []string
 
 	// Calculate column widths
@@ -315,14 +711,14 @@ func renderTable(headers []string, rows [][]string) []string {
 	for i, h := range headers {
 		headerParts[i] = fmt.Sprintf("%-*s", colWidths[i], h)
 	}
-	lines = append(lines, paddedLine(strings.Join(headerParts, " │ ")))
+	lines = append(lines, b.paddedLine(strings.Join(headerParts, " "+b.TableColumn+" ")))
 
 	// Render separator
 	sepParts := make([]string, len(headers))
 	for i := range headers {
-		sepParts[i] = strings.Repeat("─", colWidths[i])
+		sepParts[i] = strings.Repeat(b.TableRule, colWidths[i])
 	}
-	lines = append(lines, paddedLine(strings.Join(sepParts, "─┼─")))
+	lines = append(lines, b.paddedLine(strings.Join(sepParts, b.TableRule+b.TableCross+b.TableRule)))
 
 	// Render data rows
 	for _, row := range rows {
@@ -334,14 +730,14 @@ func renderTable(headers []string, rows [][]string) []string {
 			}
 			rowParts[i] = fmt.Sprintf("%-*s", colWidths[i], cell)
 		}
-		lines = append(lines, paddedLine(strings.Join(rowParts, " │ ")))
+		lines = append(lines, b.paddedLine(strings.Join(rowParts, " "+b.TableColumn+" ")))
 	}
 
 	return lines
 }
 
 // renderMetric renders a single metric with status icon and optional target.
-func renderMetric(label, value string, status Status, target string) string {
+func (b *box) renderMetric(label, value string, status Status, target string) string {
 	icon := status.Icon()
 	var text string
 	if target != "" {
@@ -349,7 +745,7 @@ func renderMetric(label, value string, status Status, target string) string {
 	} else {
 		text = fmt.Sprintf("%s %s: %s", icon, label, value)
 	}
-	return paddedLine(text)
+	return b.paddedLine(text)
 }
 
 // BoxTemplate is the text/template for the box format report.
@@ -372,16 +768,32 @@ const BoxTemplate = `{{ header }}
 {{ separator }}
 {{- end }}
 {{ paddedLine .Phase }}
-{{- range .Teams }}
+{{- with dagDiagram . }}
+{{ separator }}
+{{ . }}
+{{- end }}
+{{- with collapsedTeams . }}
+{{ separator }}
+{{ . }}
+{{- end }}
+{{- range teams . }}
 {{ separator }}
 {{ teamHeader . }}
-{{- range .Tasks }}
-{{ taskLine . }}
+{{- with taskLines . }}
+{{ . }}
 {{- end }}
 {{- if hasContentBlocks . }}
 {{ renderBlocks .ContentBlocks }}
 {{- end }}
 {{- end }}
+{{- with costSummary . }}
+{{ separator }}
+{{ renderBlocks . }}
+{{- end }}
+{{- with issues . }}
+{{ separator }}
+{{ renderBlocks . }}
+{{- end }}
 {{- if hasFooterBlocks . }}
 {{ separator }}
 {{ renderBlocks .FooterBlocks }}
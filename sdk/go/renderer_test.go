@@ -0,0 +1,327 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func widthTestReport() *TeamReport {
+	report := themeTestReport()
+	report.Teams[0].Tasks[0].Detail = strings.Repeat("x", 60)
+	return report
+}
+
+func boxLineWidths(t *testing.T, out string) map[int]int {
+	t.Helper()
+	widths := map[int]int{}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		widths[visualLength(line)]++
+	}
+	return widths
+}
+
+func TestRendererWidth(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []RendererOption
+		columns int
+	}{
+		{"default", nil, 80},
+		{"wide", []RendererOption{WithWidth(120)}, 120},
+		{"too narrow", []RendererOption{WithWidth(10)}, minBoxColumns},
+		{"auto without a terminal", []RendererOption{WithWidth(120), WithAutoWidth()}, 80},
+		{"width after auto", []RendererOption{WithAutoWidth(), WithWidth(100)}, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewRenderer(&buf, tt.opts...).Render(widthTestReport()); err != nil {
+				t.Fatal(err)
+			}
+			widths := boxLineWidths(t, buf.String())
+			if len(widths) != 1 || widths[tt.columns] == 0 {
+				t.Errorf("line widths = %v, want all %d:\n%s", widths, tt.columns, buf.String())
+			}
+		})
+	}
+}
+
+func TestRendererWidthDetail(t *testing.T) {
+	var narrow, wide bytes.Buffer
+	if err := NewRenderer(&narrow).Render(widthTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewRenderer(&wide, WithWidth(140)).Render(widthTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	full := strings.Repeat("x", 60)
+	if strings.Contains(narrow.String(), full) {
+		t.Error("80-column box did not truncate the task detail")
+	}
+	if !strings.Contains(wide.String(), full) {
+		t.Errorf("140-column box truncated the task detail:\n%s", wide.String())
+	}
+}
+
+func TestTerminalWidth(t *testing.T) {
+	if _, ok := terminalWidth(&bytes.Buffer{}); ok {
+		t.Error("terminalWidth of a buffer reported a terminal")
+	}
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, ok := terminalWidth(f); ok {
+		t.Error("terminalWidth of a regular file reported a terminal")
+	}
+}
+
+func TestRendererCompact(t *testing.T) {
+	report := &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Teams: []TeamSection{
+			{ID: "pm", Name: "pm", Status: StatusGo, Tasks: []TaskResult{{ID: "scope", Status: StatusGo}}},
+			{ID: "docs", Name: "docs", Status: StatusGo, Tasks: []TaskResult{{ID: "readme", Status: StatusGo}}},
+			{ID: "qa", Name: "qa", Status: StatusNoGo, DependsOn: []string{"pm"},
+				Tasks: []TaskResult{
+					{ID: "unit", Status: StatusNoGo, Detail: "3 failed"},
+					{ID: "e2e", Status: StatusSkip},
+				},
+				ContentBlocks: []ContentBlock{
+					NewListBlock("Empty"),
+					NewTextBlock("Notes", "flaky runner"),
+				}},
+		},
+	}
+
+	var full, compact bytes.Buffer
+	if err := NewRenderer(&full).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewRenderer(&compact, WithCompact()).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	out := compact.String()
+	for _, want := range []string{"\U0001F7E2 2 teams GO: docs, pm", "qa — NO-GO", "unit", "flaky runner"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("compact output lacks %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"scope", "readme", "e2e", "Empty"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("compact output has %q:\n%s", unwanted, out)
+		}
+	}
+	if strings.Count(out, "\n") >= strings.Count(full.String(), "\n") {
+		t.Errorf("compact output is not shorter:\n%s", out)
+	}
+	if !strings.Contains(full.String(), "e2e") || !strings.Contains(full.String(), "Empty") {
+		t.Errorf("full output lacks skipped tasks or empty blocks:\n%s", full.String())
+	}
+	if widths := boxLineWidths(t, out); len(widths) != 1 || widths[80] == 0 {
+		t.Errorf("compact line widths = %v, want all 80:\n%s", widths, out)
+	}
+}
+
+func TestRendererDurations(t *testing.T) {
+	report := widthTestReport()
+	report.Teams[0].Tasks[0].DurationMs = 1234
+
+	var shown, hidden bytes.Buffer
+	if err := NewRenderer(&shown).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewRenderer(&hidden, WithDurations(false)).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(shown.String(), "... 1.2s "+ThemeDouble.Vertical) {
+		t.Errorf("task line lacks a right-aligned duration:\n%s", shown.String())
+	}
+	if strings.Contains(hidden.String(), "1.2s") {
+		t.Errorf("WithDurations(false) output has the duration:\n%s", hidden.String())
+	}
+	for _, out := range []string{shown.String(), hidden.String()} {
+		if widths := boxLineWidths(t, out); len(widths) != 1 || widths[80] == 0 {
+			t.Errorf("line widths = %v, want all 80:\n%s", widths, out)
+		}
+	}
+
+	// Long durations on the narrowest box crowd out the detail
+	for _, ms := range []int64{2 * 60 * 60 * 1000, (123*60 + 45) * 1000} {
+		report.Teams[0].Tasks[0].DurationMs = ms
+		var narrow bytes.Buffer
+		if err := RenderBox(&narrow, report, WithWidth(minBoxColumns)); err != nil {
+			t.Fatalf("RenderBox(%dms) at %d columns: %v", ms, minBoxColumns, err)
+		}
+		if widths := boxLineWidths(t, narrow.String()); len(widths) != 1 || widths[minBoxColumns] == 0 {
+			t.Errorf("narrow line widths = %v, want all %d:\n%s", widths, minBoxColumns, narrow.String())
+		}
+	}
+	report.Teams[0].Tasks[0].DurationMs = 1234
+
+	var quick bytes.Buffer
+	if err := NewQuickRenderer(&quick).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(shown.String(), "\n") {
+		if strings.Contains(line, "1.2s") && !strings.Contains(quick.String(), line) {
+			t.Errorf("QuickRenderer output lacks %q:\n%s", line, quick.String())
+		}
+	}
+}
+
+func TestRendererLocations(t *testing.T) {
+	var plain, located bytes.Buffer
+	if err := NewRenderer(&plain).Render(locationTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	l := &LocationResolver{BaseURL: "https://github.com/org/repo", Commit: "abc123"}
+	if err := NewRenderer(&located, WithLocations(l)).Render(locationTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	out := located.String()
+	for _, want := range []string{"cmd/main.go:42: unused variable", "(pkg/run.go:10)", "(requirements.functional[2])"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "https://") {
+		t.Errorf("box output has links:\n%s", out)
+	}
+	if strings.Contains(plain.String(), "cmd/main.go") || !strings.Contains(plain.String(), "(pkg/run.go#L10)") {
+		t.Errorf("output without WithLocations changed:\n%s", plain.String())
+	}
+}
+
+func TestRenderBox(t *testing.T) {
+	report := &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Teams: []TeamSection{
+			{ID: "qa", Name: "QA", Status: StatusGo, DependsOn: []string{"pm"}},
+			{ID: "pm", Name: "PM", Status: StatusGo},
+		},
+	}
+	var want bytes.Buffer
+	if err := NewRenderer(&want, WithWidth(100)).Render(report.sortedByDAG()); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	outs := make([]bytes.Buffer, 8)
+	errs := make([]error, len(outs))
+	for i := range outs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = RenderBox(&outs[i], report, WithWidth(100))
+		}(i)
+	}
+	wg.Wait()
+	for i := range outs {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if outs[i].String() != want.String() {
+			t.Errorf("RenderBox output differs from Renderer:\n%s\n%s", outs[i].String(), want.String())
+		}
+	}
+	if report.Teams[0].ID != "qa" {
+		t.Errorf("RenderBox sorted the report's teams: %s first", report.Teams[0].ID)
+	}
+}
+
+func TestRendererCycle(t *testing.T) {
+	report := &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Teams: []TeamSection{
+			{ID: "qa", Name: "QA", Status: StatusGo, DependsOn: []string{"release"}},
+			{ID: "release", Name: "Release", Status: StatusGo, DependsOn: []string{"qa"}},
+			{ID: "pm", Name: "PM", Status: StatusGo},
+		},
+	}
+	var buf bytes.Buffer
+	if err := NewRenderer(&buf, WithWidth(100)).Render(report); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "TEAM: GO for v1.0.0 (cycle qa -> release -> qa)") {
+		t.Errorf("final status line does not name the cycle:\n%s", out)
+	}
+	if pm, qa := strings.Index(out, "PM"), strings.Index(out, "QA"); pm < 0 || qa < pm {
+		t.Errorf("teams in the cycle are not after the others:\n%s", out)
+	}
+
+	buf.Reset()
+	if err := RenderNarrative(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "(cycle qa -> release -> qa)") {
+		t.Errorf("narrative does not name the cycle:\n%s", buf.String())
+	}
+}
+
+// benchmarkReport returns a synthetic report of 1,000 teams with 50
+// tasks each.
+func benchmarkReport() *TeamReport {
+	report := &TeamReport{Project: "app", Version: "v1.0.0", Phase: "REVIEW"}
+	statuses := []Status{StatusGo, StatusGo, StatusWarn, StatusNoGo, StatusSkip}
+	for i := 0; i < 1000; i++ {
+		team := TeamSection{
+			ID:     fmt.Sprintf("team-%04d", i),
+			Name:   fmt.Sprintf("Team %d", i),
+			Status: statuses[i%len(statuses)],
+			ContentBlocks: []ContentBlock{
+				NewKVPairsBlock("Stats", KVPair{Key: "Tasks", Value: "50"}),
+				NewTextBlock("Notes", strings.Repeat("lorem ipsum dolor sit amet ", 8)),
+			},
+		}
+		if i > 0 {
+			team.DependsOn = []string{fmt.Sprintf("team-%04d", i-1)}
+		}
+		for j := 0; j < 50; j++ {
+			team.Tasks = append(team.Tasks, TaskResult{
+				ID:         fmt.Sprintf("task-%02d", j),
+				Status:     statuses[j%len(statuses)],
+				Detail:     "checked 42 files",
+				DurationMs: int64(j * 37),
+			})
+		}
+		report.Teams = append(report.Teams, team)
+	}
+	return report
+}
+
+func BenchmarkRenderBox(b *testing.B) {
+	report := benchmarkReport()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := RenderBox(io.Discard, report); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkQuickRenderer(b *testing.B) {
+	report := benchmarkReport()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := NewQuickRenderer(io.Discard).Render(report); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,8 +1,15 @@
 package multiagentspec
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Status represents the validation status following NASA Go/No-Go terminology.
@@ -15,6 +22,35 @@ const (
 	StatusSkip Status = "SKIP"
 )
 
+// Statuses returns all validation statuses in schema order.
+func Statuses() []Status {
+	return []Status{StatusGo, StatusNoGo, StatusWarn, StatusSkip}
+}
+
+// ParseStatus returns the status named by s, ignoring case, e.g., "no-go"
+// for StatusNoGo.
+func ParseStatus(s string) (Status, error) {
+	for _, v := range Statuses() {
+		if strings.EqualFold(s, string(v)) {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("unknown status %q (want GO, NO-GO, WARN, or SKIP)", s)
+}
+
+// valid reports whether s is empty or one of Statuses.
+func (s Status) valid() bool {
+	if s == "" {
+		return true
+	}
+	for _, v := range Statuses() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
 // Icon returns the UTF-8 icon for the status.
 func (s Status) Icon() string {
 	switch s {
@@ -50,6 +86,15 @@ type TaskResult struct {
 	// DurationMs is the task execution time in milliseconds
 	DurationMs int64 `json:"duration_ms,omitempty"`
 
+	// TokensIn is the number of input (prompt) tokens the task consumed
+	TokensIn int64 `json:"tokens_in,omitempty" jsonschema:"minimum=0" jsonschema_description:"Input (prompt) tokens consumed by the task"`
+
+	// TokensOut is the number of output (completion) tokens the task produced
+	TokensOut int64 `json:"tokens_out,omitempty" jsonschema:"minimum=0" jsonschema_description:"Output (completion) tokens produced by the task"`
+
+	// CostUSD is the task's LLM cost in US dollars
+	CostUSD float64 `json:"cost_usd,omitempty" jsonschema:"minimum=0" jsonschema_description:"LLM cost of the task in US dollars"`
+
 	// Metadata allows tasks to include structured data
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
@@ -80,6 +125,9 @@ type AgentResult struct {
 	// Allows agents to include findings, action items, etc.
 	ContentBlocks []ContentBlock `json:"content_blocks,omitempty"`
 
+	// Artifacts reference raw outputs supporting the results, e.g., logs
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+
 	// Status is the overall status for this agent (computed from tasks)
 	Status Status `json:"status"`
 
@@ -94,6 +142,22 @@ type AgentResult struct {
 
 	// Error is set if the agent failed to execute
 	Error string `json:"error,omitempty"`
+
+	// TraceID is the W3C trace ID of the trace the agent ran in
+	TraceID string `json:"trace_id,omitempty"`
+
+	// SpanID is the W3C span ID of the agent's span
+	SpanID string `json:"span_id,omitempty"`
+
+	// TokensIn is the total input tokens the agent consumed. If unset,
+	// totals are summed over Tasks.
+	TokensIn int64 `json:"tokens_in,omitempty"`
+
+	// TokensOut is the total output tokens the agent produced
+	TokensOut int64 `json:"tokens_out,omitempty"`
+
+	// CostUSD is the agent's total LLM cost in US dollars
+	CostUSD float64 `json:"cost_usd,omitempty"`
 }
 
 // TeamSection represents a team/agent section in the report.
@@ -123,7 +187,11 @@ type TeamSection struct {
 	// Verdict is a domain-specific verdict label, richer than the 4-value Status.
 	// Status is machine-readable GO/NO-GO; Verdict is the human-readable domain assessment.
 	// Examples: "BLOCKED_PENDING_ENHANCEMENT", "COMPLIANT", "NEEDS_WORK"
-	Verdict string `json:"verdict,omitempty"`
+	Verdict string `json:"verdict,omitempty" jsonschema_description:"Domain-specific verdict label. Richer than status. Status is machine-readable GO/NO-GO; verdict is the human-readable domain assessment."`
+
+	// AllowFailure marks an experimental or advisory team whose NO-GO
+	// does not block the report: it counts as WARN in the overall status.
+	AllowFailure bool `json:"allow_failure,omitempty" jsonschema_description:"Experimental or advisory team whose NO-GO does not block the report; it counts as WARN in the overall status"`
 
 	// ContentBlocks holds rich content for this team section.
 	// Supports lists, kv_pairs, tables, text, metrics.
@@ -131,6 +199,31 @@ type TeamSection struct {
 
 	// Narrative holds prose content for narrative reports.
 	Narrative *NarrativeSection `json:"narrative,omitempty"`
+
+	// Issues are the specific problems the team identified. Reports
+	// aggregate them across teams; see TeamReport.Issues.
+	Issues []Issue `json:"issues,omitempty" jsonschema_description:"Specific problems the team identified; reports aggregate them across teams"`
+
+	// Artifacts reference the raw outputs supporting the team's results,
+	// such as logs, SBOMs, and screenshots.
+	Artifacts []Artifact `json:"artifacts,omitempty" jsonschema_description:"Raw outputs supporting the team's results, such as logs, SBOMs, and screenshots"`
+
+	// TraceID is the W3C trace ID of the trace the agent ran in, so report
+	// tasks can be correlated with traces.
+	TraceID string `json:"trace_id,omitempty" jsonschema:"pattern=^[0-9a-f]{32}$" jsonschema_description:"W3C trace ID of the trace the agent ran in, for correlating report tasks with traces"`
+
+	// SpanID is the W3C span ID of the agent's span.
+	SpanID string `json:"span_id,omitempty" jsonschema:"pattern=^[0-9a-f]{16}$" jsonschema_description:"W3C span ID of the agent's span"`
+
+	// TokensIn is the total input tokens the team consumed. If no usage
+	// field is set, totals are summed over Tasks.
+	TokensIn int64 `json:"tokens_in,omitempty" jsonschema:"minimum=0" jsonschema_description:"Total input (prompt) tokens the team consumed; when unset, the sum over its tasks"`
+
+	// TokensOut is the total output tokens the team produced.
+	TokensOut int64 `json:"tokens_out,omitempty" jsonschema:"minimum=0" jsonschema_description:"Total output (completion) tokens the team produced; when unset, the sum over its tasks"`
+
+	// CostUSD is the team's total LLM cost in US dollars.
+	CostUSD float64 `json:"cost_usd,omitempty" jsonschema:"minimum=0" jsonschema_description:"Total LLM cost of the team in US dollars; when unset, the sum over its tasks"`
 }
 
 // TeamReport is the complete JSON-serializable report.
@@ -157,7 +250,7 @@ type TeamReport struct {
 
 	// Tags are key-value pairs for filtering and aggregation across reports.
 	// Examples: customer, environment, use_case, target_system
-	Tags map[string]string `json:"tags,omitempty"`
+	Tags map[string]string `json:"tags,omitempty" jsonschema_description:"Key-value tags for filtering and aggregation across reports."`
 
 	// SummaryBlocks appear after the header, before the phase.
 	// For metadata, disposition, use-case descriptions.
@@ -184,6 +277,10 @@ type TeamReport struct {
 
 	// GeneratedBy identifies the coordinator
 	GeneratedBy string `json:"generated_by,omitempty"`
+
+	// teamsBeforeFilter is the number of teams before FilterTeams, or
+	// zero for an unfiltered report.
+	teamsBeforeFilter int
 }
 
 // EffectiveTitle returns Title if set, otherwise the default.
@@ -199,6 +296,35 @@ func (a *AgentResult) ComputeStatus() Status {
 	return computeStatusFromTasks(a.Tasks)
 }
 
+// Stamp fills in what an agent result needs to match the schema: $schema
+// is set to the agent result schema URL for SpecVersion, nil Tasks to an
+// empty list, an unset Status to ComputeStatus, and an unset ExecutedAt
+// to the current time.
+func (a *AgentResult) Stamp() {
+	a.Schema = SchemaURL(SchemaAgentResult)
+	if a.Tasks == nil {
+		a.Tasks = []TaskResult{}
+	}
+	if a.Status == "" {
+		a.Status = a.ComputeStatus()
+	}
+	if a.ExecutedAt.IsZero() {
+		a.ExecutedAt = time.Now().UTC()
+	}
+}
+
+// ToJSON serializes a stamped copy of the result to JSON (see Stamp),
+// returning an error instead if the copy fails Validate. The result is
+// not modified.
+func (a *AgentResult) ToJSON() ([]byte, error) {
+	stamped := *a
+	stamped.Stamp()
+	if err := stamped.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid agent result: %w", err)
+	}
+	return json.MarshalIndent(&stamped, "", "  ")
+}
+
 // ToTeamSection converts an AgentResult to a TeamSection for the report.
 func (a *AgentResult) ToTeamSection() TeamSection {
 	return TeamSection{
@@ -208,7 +334,13 @@ func (a *AgentResult) ToTeamSection() TeamSection {
 		Model:         a.AgentModel,
 		Tasks:         a.Tasks,
 		ContentBlocks: a.ContentBlocks,
+		Artifacts:     a.Artifacts,
 		Status:        a.ComputeStatus(),
+		TraceID:       a.TraceID,
+		SpanID:        a.SpanID,
+		TokensIn:      a.TokensIn,
+		TokensOut:     a.TokensOut,
+		CostUSD:       a.CostUSD,
 	}
 }
 
@@ -217,13 +349,37 @@ func (t *TeamSection) OverallStatus() Status {
 	return computeStatusFromTasks(t.Tasks)
 }
 
-// ComputeOverallStatus computes the overall status from all teams.
+// BlockingStatus returns the team's status as it counts toward the
+// report's: WARN for a NO-GO team that is allowed to fail, else Status.
+func (t *TeamSection) BlockingStatus() Status {
+	if t.AllowFailure && t.Status == StatusNoGo {
+		return StatusWarn
+	}
+	return t.Status
+}
+
+// StatusLabel returns the team's status for display, marked
+// "(non-blocking)" when the team is allowed to fail.
+func (t *TeamSection) StatusLabel() string {
+	if t.AllowFailure {
+		return string(t.Status) + " (non-blocking)"
+	}
+	return string(t.Status)
+}
+
+// ComputeOverallStatus computes the overall status from all teams, using
+// each team's BlockingStatus. A report whose teams were all skipped is
+// SKIP; skipped teams otherwise do not affect the status.
 func (r *TeamReport) ComputeOverallStatus() Status {
 	hasNoGo := false
 	hasWarn := false
+	allSkipped := len(r.Teams) > 0
 
 	for _, t := range r.Teams {
-		switch t.Status {
+		if t.Status != StatusSkip {
+			allSkipped = false
+		}
+		switch t.BlockingStatus() {
 		case StatusNoGo:
 			hasNoGo = true
 		case StatusWarn:
@@ -231,6 +387,9 @@ func (r *TeamReport) ComputeOverallStatus() Status {
 		}
 	}
 
+	if allSkipped {
+		return StatusSkip
+	}
 	if hasNoGo {
 		return StatusNoGo
 	}
@@ -240,10 +399,24 @@ func (r *TeamReport) ComputeOverallStatus() Status {
 	return StatusGo
 }
 
-// IsGo returns true if all teams pass validation.
+// TeamStatusCounts returns the number of teams with each status.
+func (r *TeamReport) TeamStatusCounts() map[Status]int {
+	counts := make(map[Status]int)
+	for _, t := range r.Teams {
+		counts[t.Status]++
+	}
+	return counts
+}
+
+// SkippedTeams returns the number of teams that were skipped.
+func (r *TeamReport) SkippedTeams() int {
+	return r.TeamStatusCounts()[StatusSkip]
+}
+
+// IsGo returns true if no team that blocks the report failed validation.
 func (r *TeamReport) IsGo() bool {
 	for _, t := range r.Teams {
-		if t.Status == StatusNoGo {
+		if t.BlockingStatus() == StatusNoGo {
 			return false
 		}
 	}
@@ -254,106 +427,339 @@ func (r *TeamReport) IsGo() bool {
 // Teams with no dependencies appear first, followed by teams whose dependencies
 // have been satisfied. This uses Kahn's algorithm for topological sorting.
 // Teams at the same level are sorted alphabetically by ID for deterministic output.
-// If the DAG has cycles, teams in cycles appear at the end in their original order.
-func (r *TeamReport) SortByDAG() {
-	if len(r.Teams) <= 1 {
-		return
+// If the DAG has cycles, teams in cycles, and those depending on them, appear
+// at the end in their original order, and SortByDAG returns a
+// *TeamCycleError listing the cycles.
+func (r *TeamReport) SortByDAG() error {
+	deps := r.dependencyGraph()
+	inDegree := make([]int, len(r.Teams))
+	downstream := make([][]int, len(r.Teams))
+	for i, ds := range deps {
+		inDegree[i] = len(ds)
+		for _, d := range ds {
+			downstream[d] = append(downstream[d], i)
+		}
 	}
-
-	// Build ID -> index mapping and in-degree count
-	idToTeam := make(map[string]*TeamSection)
-	inDegree := make(map[string]int)
-
-	for i := range r.Teams {
-		id := r.Teams[i].ID
-		idToTeam[id] = &r.Teams[i]
-		inDegree[id] = 0
+	byID := func(teams []int) {
+		sort.SliceStable(teams, func(a, b int) bool { return r.Teams[teams[a]].ID < r.Teams[teams[b]].ID })
 	}
 
-	// Calculate in-degrees (count of dependencies)
+	// Kahn's algorithm: start with the teams that have no dependencies,
+	// and queue each batch of newly ready teams in ID order.
+	var queue []int
 	for i := range r.Teams {
-		for _, dep := range r.Teams[i].DependsOn {
-			if _, exists := idToTeam[dep]; exists {
-				inDegree[r.Teams[i].ID]++
+		if inDegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+	byID(queue)
+	sorted := make([]TeamSection, 0, len(r.Teams))
+	processed := make([]bool, len(r.Teams))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		processed[i] = true
+		sorted = append(sorted, r.Teams[i])
+
+		var ready []int
+		for _, d := range downstream[i] {
+			inDegree[d]--
+			if inDegree[d] == 0 {
+				ready = append(ready, d)
 			}
 		}
+		byID(ready)
+		queue = append(queue, ready...)
+	}
+	if len(sorted) == len(r.Teams) {
+		r.Teams = sorted
+		return nil
 	}
 
-	// Build adjacency list (downstream teams for each team)
-	downstream := make(map[string][]string)
+	// The rest are in or behind cycles.
+	err := &TeamCycleError{Cycles: r.DetectCycles()}
 	for i := range r.Teams {
-		for _, dep := range r.Teams[i].DependsOn {
-			if _, exists := idToTeam[dep]; exists {
-				downstream[dep] = append(downstream[dep], r.Teams[i].ID)
-			}
+		if !processed[i] {
+			sorted = append(sorted, r.Teams[i])
 		}
 	}
+	r.Teams = sorted
+	return err
+}
 
-	// Kahn's algorithm: start with teams that have no dependencies
-	// Collect all teams with zero in-degree, sort alphabetically
-	queue := make([]string, 0)
+// DetectCycles returns the cycles in the teams' DependsOn relationships,
+// one for each set of teams that depend on each other, in report order of
+// their first team. Each cycle is the team IDs along it, each depending on
+// the next, starting and ending with the same team. It returns nil if the
+// teams form a DAG.
+func (r *TeamReport) DetectCycles() [][]string {
+	deps := r.dependencyGraph()
+
+	// Tarjan's algorithm finds the strongly connected components.
+	index := make([]int, len(r.Teams))
+	low := make([]int, len(r.Teams))
+	onStack := make([]bool, len(r.Teams))
+	component := make([]int, len(r.Teams))
+	for i := range index {
+		index[i] = -1
+	}
+	var stack []int
+	next, components := 0, 0
+	var connect func(i int)
+	connect = func(i int) {
+		index[i], low[i] = next, next
+		next++
+		stack = append(stack, i)
+		onStack[i] = true
+		for _, d := range deps[i] {
+			if index[d] < 0 {
+				connect(d)
+				low[i] = min(low[i], low[d])
+			} else if onStack[d] {
+				low[i] = min(low[i], index[d])
+			}
+		}
+		if low[i] != index[i] {
+			return
+		}
+		for {
+			j := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[j] = false
+			component[j] = components
+			if j == i {
+				break
+			}
+		}
+		components++
+	}
 	for i := range r.Teams {
-		if inDegree[r.Teams[i].ID] == 0 {
-			queue = append(queue, r.Teams[i].ID)
+		if index[i] < 0 {
+			connect(i)
 		}
 	}
-	sortStrings(queue)
 
-	sorted := make([]TeamSection, 0, len(r.Teams))
-	processed := make(map[string]bool)
+	var cycles [][]string
+	seen := make([]bool, components)
+	for i := range r.Teams {
+		if seen[component[i]] {
+			continue
+		}
+		if path := r.cycleFrom(i, deps, component); path != nil {
+			seen[component[i]] = true
+			cycles = append(cycles, path)
+		}
+	}
+	return cycles
+}
 
+// cycleFrom returns the shortest cycle from team start back to itself
+// through teams of its component, or nil if there is none.
+func (r *TeamReport) cycleFrom(start int, deps [][]int, component []int) []string {
+	prev := map[int]int{}
+	queue := []int{start}
 	for len(queue) > 0 {
-		// Dequeue
-		id := queue[0]
+		i := queue[0]
 		queue = queue[1:]
+		for _, d := range deps[i] {
+			if component[d] != component[start] {
+				continue
+			}
+			if d == start {
+				path := []string{r.Teams[start].ID}
+				for j := i; j != start; j = prev[j] {
+					path = append(path, r.Teams[j].ID)
+				}
+				for a, b := 1, len(path)-1; a < b; a, b = a+1, b-1 {
+					path[a], path[b] = path[b], path[a]
+				}
+				return append(path, r.Teams[start].ID)
+			}
+			if _, ok := prev[d]; !ok {
+				prev[d] = i
+				queue = append(queue, d)
+			}
+		}
+	}
+	return nil
+}
 
-		if processed[id] {
-			continue
+// dependencyGraph returns, for each team by index, the indexes of the
+// teams its DependsOn names, ignoring IDs not in the report.
+func (r *TeamReport) dependencyGraph() [][]int {
+	byID := make(map[string][]int, len(r.Teams))
+	for i, t := range r.Teams {
+		byID[t.ID] = append(byID[t.ID], i)
+	}
+	deps := make([][]int, len(r.Teams))
+	for i, t := range r.Teams {
+		for _, dep := range t.DependsOn {
+			deps[i] = append(deps[i], byID[dep]...)
 		}
-		processed[id] = true
+	}
+	return deps
+}
 
-		sorted = append(sorted, *idToTeam[id])
+// TeamCycleError reports teams whose DependsOn relationships form cycles,
+// as returned by SortByDAG.
+type TeamCycleError struct {
+	// Cycles are the cycles, as returned by DetectCycles.
+	Cycles [][]string
+}
 
-		// Collect newly ready teams
-		newlyReady := make([]string, 0)
-		for _, downID := range downstream[id] {
-			inDegree[downID]--
-			if inDegree[downID] == 0 {
-				newlyReady = append(newlyReady, downID)
-			}
-		}
-		// Sort and append to maintain alphabetical order at each level
-		sortStrings(newlyReady)
-		queue = append(queue, newlyReady...)
+func (e *TeamCycleError) Error() string {
+	cycles := make([]string, len(e.Cycles))
+	for i, c := range e.Cycles {
+		cycles[i] = strings.Join(c, " -> ")
 	}
+	return "team dependency cycle: " + strings.Join(cycles, "; ")
+}
 
-	// Add any remaining teams (cycles or missing dependencies) at the end
-	for i := range r.Teams {
-		if !processed[r.Teams[i].ID] {
-			sorted = append(sorted, r.Teams[i])
+// sortedByDAG returns a copy of r with its teams sorted by SortByDAG,
+// leaving r unchanged. The copy shares everything but the Teams slice.
+func (r *TeamReport) sortedByDAG() *TeamReport {
+	sorted := *r
+	sorted.SortByDAG()
+	return &sorted
+}
+
+// Levels groups the teams by depth in the DependsOn DAG, e.g., to show
+// them in waves or run each level's teams in parallel: level 0 holds the
+// teams that depend on no other team in the report, and each other team
+// is one level after its deepest dependency. Teams keep their report order
+// within a level. A dependency that would close a cycle is ignored.
+func (r *TeamReport) Levels() [][]TeamSection {
+	var levels [][]TeamSection
+	for _, level := range dagLevels(r.dependencyGraph()) {
+		teams := make([]TeamSection, len(level))
+		for i, t := range level {
+			teams[i] = r.Teams[t]
 		}
+		levels = append(levels, teams)
 	}
-
-	r.Teams = sorted
+	return levels
 }
 
-// sortStrings sorts a slice of strings in place.
-func sortStrings(s []string) {
-	for i := 0; i < len(s)-1; i++ {
-		for j := i + 1; j < len(s); j++ {
-			if s[j] < s[i] {
-				s[i], s[j] = s[j], s[i]
+// dagLevels groups the nodes of a dependency graph, given as the
+// dependencies of each node by index, by their longest path from a node
+// without dependencies, in index order within a level. Edges that would
+// close a cycle are ignored.
+func dagLevels(deps [][]int) [][]int {
+	level := make([]int, len(deps))
+	state := make([]int, len(deps)) // 1 visiting, 2 done
+	var visit func(i int) int
+	visit = func(i int) int {
+		if state[i] == 2 {
+			return level[i]
+		}
+		state[i] = 1
+		for _, d := range deps[i] {
+			if state[d] != 1 {
+				level[i] = max(level[i], visit(d)+1)
 			}
 		}
+		state[i] = 2
+		return level[i]
+	}
+
+	var levels [][]int
+	for i := range deps {
+		l := visit(i)
+		for len(levels) <= l {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], i)
 	}
+	return levels
 }
 
 // FinalMessage returns the final status message for display.
+// When only some teams were skipped, or the report is filtered, it notes
+// how many, and it names any dependency cycle, whose teams renderers show
+// after the others in report order.
 func (r *TeamReport) FinalMessage() string {
-	if r.IsGo() {
-		return "\U0001F680 TEAM: GO for " + r.Version + " \U0001F680" // 🚀 TEAM: GO for vX.Y.Z 🚀
+	var notes []string
+	if f := r.FilterSummary(); f != "" {
+		notes = append(notes, f)
+	}
+	if c := r.CycleSummary(); c != "" {
+		notes = append(notes, c)
 	}
-	return "\U0001F6D1 TEAM: NO-GO for " + r.Version + " \U0001F6D1" // 🛑 TEAM: NO-GO for vX.Y.Z 🛑
+	skipped := r.SkippedTeams()
+	allSkipped := skipped > 0 && skipped == len(r.Teams)
+	switch {
+	case allSkipped:
+	case skipped == 1:
+		notes = append(notes, "1 team skipped")
+	case skipped > 1:
+		notes = append(notes, fmt.Sprintf("%d teams skipped", skipped))
+	}
+	version := r.Version
+	if len(notes) > 0 {
+		version += " (" + strings.Join(notes, ", ") + ")"
+	}
+	switch {
+	case allSkipped:
+		return "\u26AA TEAM: SKIP for " + version + " \u26AA" // ⚪ TEAM: SKIP for vX.Y.Z ⚪
+	case r.IsGo():
+		return "\U0001F680 TEAM: GO for " + version + " \U0001F680" // 🚀 TEAM: GO for vX.Y.Z 🚀
+	}
+	return "\U0001F6D1 TEAM: NO-GO for " + version + " \U0001F6D1" // 🛑 TEAM: NO-GO for vX.Y.Z 🛑
+}
+
+// FilterTeams returns a copy of the report with only the teams whose ID or
+// name is in names, in their original order, and the overall status
+// recomputed for them. Renderers note the filtering in the overall status
+// line. It returns an error for a name that matches no team.
+func (r *TeamReport) FilterTeams(names []string) (*TeamReport, error) {
+	want := make(map[string]bool, len(names))
+	for _, name := range names {
+		want[name] = true
+	}
+	filtered := *r
+	filtered.Teams = nil
+	for _, t := range r.Teams {
+		if want[t.ID] || want[t.Name] {
+			filtered.Teams = append(filtered.Teams, t)
+		}
+	}
+	for _, name := range names {
+		found := false
+		for _, t := range filtered.Teams {
+			found = found || t.ID == name || t.Name == name
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown team %s", name)
+		}
+	}
+	filtered.teamsBeforeFilter = len(r.Teams)
+	if r.teamsBeforeFilter > 0 {
+		filtered.teamsBeforeFilter = r.teamsBeforeFilter
+	}
+	filtered.Status = filtered.ComputeOverallStatus()
+	return &filtered, nil
+}
+
+// FilterSummary describes the filtering applied by FilterTeams, e.g., "2
+// of 40 teams", or returns "" for an unfiltered report.
+func (r *TeamReport) FilterSummary() string {
+	if r.teamsBeforeFilter == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d of %d teams", len(r.Teams), r.teamsBeforeFilter)
+}
+
+// CycleSummary names the teams' dependency cycles, e.g., "cycle qa ->
+// release -> qa", or returns "" if the teams form a DAG. Renderers show
+// it with the overall status, since the teams in a cycle cannot be put in
+// DAG order.
+func (r *TeamReport) CycleSummary() string {
+	cycles := r.DetectCycles()
+	notes := make([]string, len(cycles))
+	for i, c := range cycles {
+		notes[i] = "cycle " + strings.Join(c, " -> ")
+	}
+	return strings.Join(notes, ", ")
 }
 
 // ToJSON serializes the report to JSON.
@@ -368,20 +774,25 @@ func AggregateResults(results []AgentResult, project, version, phase string) *Te
 		teams = append(teams, r.ToTeamSection())
 	}
 
-	report := &TeamReport{
-		Schema:      "https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/report/team-report.schema.json",
+	report := newAggregateReport(project, version, phase)
+	report.Teams = teams
+	report.Status = report.ComputeOverallStatus()
+
+	return report
+}
+
+// newAggregateReport returns an empty report as generated by the release
+// coordinator.
+func newAggregateReport(project, version, phase string) *TeamReport {
+	return &TeamReport{
+		Schema:      SchemaURL(SchemaTeamReport),
 		Project:     project,
 		Version:     version,
 		Target:      version,
 		Phase:       phase,
-		Teams:       teams,
 		GeneratedAt: time.Now().UTC(),
 		GeneratedBy: "release-coordinator",
 	}
-
-	report.Status = report.ComputeOverallStatus()
-
-	return report
 }
 
 // ParseAgentResult parses JSON into an AgentResult.
@@ -402,6 +813,76 @@ func ParseTeamReport(data []byte) (*TeamReport, error) {
 	return &report, nil
 }
 
+// ParseAgentResultYAML parses YAML into an AgentResult, using the same
+// field names as the JSON form.
+func ParseAgentResultYAML(data []byte) (*AgentResult, error) {
+	j, err := YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAgentResult(j)
+}
+
+// ParseTeamReportYAML parses YAML into a TeamReport, using the same field
+// names as the JSON form.
+func ParseTeamReportYAML(data []byte) (*TeamReport, error) {
+	j, err := YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTeamReport(j)
+}
+
+// YAMLToJSON converts a YAML document to JSON, e.g., to validate it
+// against a JSON Schema. Mapping keys must be strings.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var v any
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("convert yaml to json: %w", err)
+	}
+	return j, nil
+}
+
+// ParseAgentResultStrict parses JSON into an AgentResult like
+// ParseAgentResult, but rejects fields the AgentResult types do not
+// define, usually a sign that the agent emitting it targets a different
+// spec version.
+func ParseAgentResultStrict(data []byte) (*AgentResult, error) {
+	var result AgentResult
+	if err := decodeStrict(data, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ParseTeamReportStrict parses JSON into a TeamReport like
+// ParseTeamReport, but rejects fields the TeamReport types do not define.
+func ParseTeamReportStrict(data []byte) (*TeamReport, error) {
+	var report TeamReport
+	if err := decodeStrict(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// decodeStrict decodes a single JSON value into v, rejecting unknown
+// fields and trailing data.
+func decodeStrict(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON value")
+	}
+	return nil
+}
+
 // computeStatusFromTasks is a helper to compute status from a slice of task results.
 func computeStatusFromTasks(tasks []TaskResult) Status {
 	hasNoGo := false
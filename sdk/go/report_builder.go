@@ -0,0 +1,255 @@
+package multiagentspec
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReportBuilder builds a TeamReport step by step:
+//
+//	report, err := NewReportBuilder("my-app", "v1.2.0").
+//	    Phase("PHASE 1: REVIEW").
+//	    AddTeam("qa-validation", "qa").
+//	    AddTask(TaskResult{ID: "unit-tests", Status: StatusGo}).
+//	    AddFinding("sql-injection", "critical", "SQL injection in auth module").
+//	    Build()
+//
+// Team methods (AddTask, AddFinding, AddBlock, ...) apply to the team most
+// recently added. Mistakes, such as adding a task before any team, are
+// reported by Build.
+type ReportBuilder struct {
+	report TeamReport
+	team   *TeamSection
+	ids    map[string]bool
+	errs   []error
+}
+
+// NewReportBuilder returns a builder for a report on project at version.
+func NewReportBuilder(project, version string) *ReportBuilder {
+	return &ReportBuilder{
+		report: TeamReport{Project: project, Version: version, Target: version},
+		ids:    make(map[string]bool),
+	}
+}
+
+// Title sets the report title.
+func (b *ReportBuilder) Title(title string) *ReportBuilder {
+	b.report.Title = title
+	return b
+}
+
+// Target sets the human-readable target (default: the version).
+func (b *ReportBuilder) Target(target string) *ReportBuilder {
+	b.report.Target = target
+	return b
+}
+
+// Phase sets the workflow phase.
+func (b *ReportBuilder) Phase(phase string) *ReportBuilder {
+	b.report.Phase = phase
+	return b
+}
+
+// Tag adds a tag for filtering and aggregation across reports.
+func (b *ReportBuilder) Tag(key, value string) *ReportBuilder {
+	if b.report.Tags == nil {
+		b.report.Tags = make(map[string]string)
+	}
+	b.report.Tags[key] = value
+	return b
+}
+
+// Summary sets the executive summary for narrative reports.
+func (b *ReportBuilder) Summary(summary string) *ReportBuilder {
+	b.report.Summary = summary
+	return b
+}
+
+// Conclusion sets the closing section for narrative reports.
+func (b *ReportBuilder) Conclusion(conclusion string) *ReportBuilder {
+	b.report.Conclusion = conclusion
+	return b
+}
+
+// GeneratedBy sets the coordinator that produced the report.
+func (b *ReportBuilder) GeneratedBy(name string) *ReportBuilder {
+	b.report.GeneratedBy = name
+	return b
+}
+
+// GeneratedAt sets the generation time (default: when Build is called).
+func (b *ReportBuilder) GeneratedAt(t time.Time) *ReportBuilder {
+	b.report.GeneratedAt = t
+	return b
+}
+
+// AddSummaryBlock adds blocks shown after the header.
+func (b *ReportBuilder) AddSummaryBlock(blocks ...ContentBlock) *ReportBuilder {
+	b.report.SummaryBlocks = append(b.report.SummaryBlocks, blocks...)
+	return b
+}
+
+// AddFooterBlock adds blocks shown after all teams.
+func (b *ReportBuilder) AddFooterBlock(blocks ...ContentBlock) *ReportBuilder {
+	b.report.FooterBlocks = append(b.report.FooterBlocks, blocks...)
+	return b
+}
+
+// AddTeam starts a team section; the team methods that follow apply to
+// it. Team IDs must be unique.
+func (b *ReportBuilder) AddTeam(id, name string) *ReportBuilder {
+	if b.ids[id] {
+		b.errs = append(b.errs, fmt.Errorf("team %s is added more than once", id))
+	}
+	b.ids[id] = true
+	b.flush()
+	b.team = &TeamSection{ID: id, Name: name}
+	return b
+}
+
+// AddAgentResult adds an agent's result as a team section, as
+// AgentResult.ToTeamSection converts it; the team methods that follow
+// apply to it.
+func (b *ReportBuilder) AddAgentResult(result AgentResult) *ReportBuilder {
+	b.AddTeam(result.StepID, result.AgentID)
+	*b.team = result.ToTeamSection()
+	return b
+}
+
+// DependsOn records the upstream teams of the current team.
+func (b *ReportBuilder) DependsOn(ids ...string) *ReportBuilder {
+	if t := b.current("DependsOn"); t != nil {
+		t.DependsOn = append(t.DependsOn, ids...)
+	}
+	return b
+}
+
+// Model records the model the current team's agent used.
+func (b *ReportBuilder) Model(model string) *ReportBuilder {
+	if t := b.current("Model"); t != nil {
+		t.Model = model
+	}
+	return b
+}
+
+// Verdict sets the current team's domain-specific verdict.
+func (b *ReportBuilder) Verdict(verdict string) *ReportBuilder {
+	if t := b.current("Verdict"); t != nil {
+		t.Verdict = verdict
+	}
+	return b
+}
+
+// AllowFailure marks the current team as non-blocking: its NO-GO counts
+// as WARN in the overall status.
+func (b *ReportBuilder) AllowFailure() *ReportBuilder {
+	if t := b.current("AllowFailure"); t != nil {
+		t.AllowFailure = true
+	}
+	return b
+}
+
+// Status sets the current team's status instead of computing it from its
+// tasks.
+func (b *ReportBuilder) Status(status Status) *ReportBuilder {
+	if t := b.current("Status"); t != nil {
+		t.Status = status
+	}
+	return b
+}
+
+// AddTask adds task results to the current team.
+func (b *ReportBuilder) AddTask(tasks ...TaskResult) *ReportBuilder {
+	if t := b.current("AddTask"); t != nil {
+		t.Tasks = append(t.Tasks, tasks...)
+	}
+	return b
+}
+
+// AddFinding adds a task result for a finding to the current team, with
+// status from its severity: NO-GO for critical and high, GO for info, and
+// WARN otherwise.
+func (b *ReportBuilder) AddFinding(id, severity, detail string) *ReportBuilder {
+	status := StatusWarn
+	switch severity {
+	case "critical", "high":
+		status = StatusNoGo
+	case "info":
+		status = StatusGo
+	}
+	return b.AddTask(TaskResult{ID: id, Status: status, Severity: severity, Detail: detail})
+}
+
+// AddIssue adds issues to the current team.
+func (b *ReportBuilder) AddIssue(issues ...Issue) *ReportBuilder {
+	if t := b.current("AddIssue"); t != nil {
+		t.Issues = append(t.Issues, issues...)
+	}
+	return b
+}
+
+// AddArtifact adds artifacts to the current team.
+func (b *ReportBuilder) AddArtifact(artifacts ...Artifact) *ReportBuilder {
+	if t := b.current("AddArtifact"); t != nil {
+		t.Artifacts = append(t.Artifacts, artifacts...)
+	}
+	return b
+}
+
+// AddBlock adds content blocks to the current team.
+func (b *ReportBuilder) AddBlock(blocks ...ContentBlock) *ReportBuilder {
+	if t := b.current("AddBlock"); t != nil {
+		t.ContentBlocks = append(t.ContentBlocks, blocks...)
+	}
+	return b
+}
+
+// Narrative sets the current team's prose for narrative reports.
+func (b *ReportBuilder) Narrative(problem, analysis, recommendation string) *ReportBuilder {
+	if t := b.current("Narrative"); t != nil {
+		t.Narrative = &NarrativeSection{Problem: problem, Analysis: analysis, Recommendation: recommendation}
+	}
+	return b
+}
+
+// Build returns the report with the schema URL, the generation time if
+// unset, each team's status computed from its tasks unless set with
+// Status, and the overall status computed from the teams. It returns the
+// mistakes made while building, if any.
+func (b *ReportBuilder) Build() (*TeamReport, error) {
+	b.flush()
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+	report := b.report
+	report.Schema = SchemaURL(SchemaTeamReport)
+	if report.GeneratedAt.IsZero() {
+		report.GeneratedAt = time.Now().UTC()
+	}
+	report.Teams = append([]TeamSection(nil), b.report.Teams...)
+	for i := range report.Teams {
+		if report.Teams[i].Status == "" {
+			report.Teams[i].Status = report.Teams[i].OverallStatus()
+		}
+	}
+	report.Status = report.ComputeOverallStatus()
+	return &report, nil
+}
+
+// current returns the team being built, recording an error for method if
+// there is none.
+func (b *ReportBuilder) current(method string) *TeamSection {
+	if b.team == nil {
+		b.errs = append(b.errs, fmt.Errorf("%s called before AddTeam", method))
+	}
+	return b.team
+}
+
+// flush appends the team being built to the report.
+func (b *ReportBuilder) flush() {
+	if b.team != nil {
+		b.report.Teams = append(b.report.Teams, *b.team)
+		b.team = nil
+	}
+}
@@ -0,0 +1,103 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportBuilder(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	report, err := NewReportBuilder("my-app", "v1.2.0").
+		Phase("PHASE 1: REVIEW").
+		Tag("environment", "staging").
+		GeneratedAt(at).
+		AddTeam("qa-validation", "qa").
+		Model("sonnet").
+		AddTask(TaskResult{ID: "unit-tests", Status: StatusGo}, TaskResult{ID: "lint", Status: StatusWarn}).
+		AddTeam("security-audit", "security").
+		DependsOn("qa-validation").
+		AddFinding("sql-injection", "critical", "SQL injection in auth module").
+		AddFinding("banner", "info", "Server banner exposed").
+		Verdict("BLOCKED_SECURITY_ISSUES").
+		AddTeam("perf", "perf").
+		AllowFailure().
+		AddTask(TaskResult{ID: "latency", Status: StatusNoGo}).
+		AddTeam("docs", "docs").
+		AddBlock(NewTextBlock("Notes", "Docs reviewed manually.")).
+		AddArtifact(Artifact{Name: "Preview", URL: "https://example.com/docs/", MediaType: "text/html"}).
+		Status(StatusGo).
+		AddFooterBlock(NewTextBlock("Next", "Fix the injection.")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	if report.Schema != SchemaURL(SchemaTeamReport) || report.Target != "v1.2.0" || !report.GeneratedAt.Equal(at) {
+		t.Errorf("report = %+v", report)
+	}
+	if len(report.Teams) != 4 {
+		t.Fatalf("got %d teams, want 4", len(report.Teams))
+	}
+	qa, sec, perf, docs := report.Teams[0], report.Teams[1], report.Teams[2], report.Teams[3]
+	if perf.Status != StatusNoGo || !perf.AllowFailure {
+		t.Errorf("perf = %+v", perf)
+	}
+	if qa.Status != StatusWarn || qa.Model != "sonnet" || len(qa.Tasks) != 2 {
+		t.Errorf("qa = %+v", qa)
+	}
+	if sec.Status != StatusNoGo || sec.Verdict != "BLOCKED_SECURITY_ISSUES" || sec.DependsOn[0] != "qa-validation" {
+		t.Errorf("security = %+v", sec)
+	}
+	if sec.Tasks[0].Status != StatusNoGo || sec.Tasks[0].Severity != "critical" || sec.Tasks[1].Status != StatusGo {
+		t.Errorf("findings = %+v", sec.Tasks)
+	}
+	if docs.Status != StatusGo || len(docs.ContentBlocks) != 1 || len(docs.Artifacts) != 1 {
+		t.Errorf("docs = %+v", docs)
+	}
+	if report.Status != StatusNoGo || len(report.FooterBlocks) != 1 || report.Tags["environment"] != "staging" {
+		t.Errorf("report status = %s, footer = %v, tags = %v", report.Status, report.FooterBlocks, report.Tags)
+	}
+
+	data, err := report.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateTeamReportJSON(data); err != nil {
+		t.Errorf("built report fails schema validation: %v", err)
+	}
+}
+
+func TestReportBuilderDefaults(t *testing.T) {
+	before := time.Now().UTC()
+	result := AgentResult{AgentID: "pm", StepID: "pm-validation", Tasks: []TaskResult{{ID: "prd", Status: StatusGo}}}
+	report, err := NewReportBuilder("app", "v1").AddAgentResult(result).AddIssue(Issue{ID: "ISS-1", Category: "scope", Severity: SeverityMinor, Problem: "Vague"}).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.GeneratedAt.Before(before) {
+		t.Errorf("GeneratedAt = %v, want now", report.GeneratedAt)
+	}
+	if len(report.Teams) != 1 || report.Teams[0].ID != "pm-validation" || report.Teams[0].Status != StatusGo || len(report.Teams[0].Issues) != 1 {
+		t.Errorf("teams = %+v", report.Teams)
+	}
+	if report.Status != StatusGo {
+		t.Errorf("Status = %s, want GO", report.Status)
+	}
+}
+
+func TestReportBuilderErrors(t *testing.T) {
+	_, err := NewReportBuilder("app", "v1").
+		AddTask(TaskResult{ID: "orphan", Status: StatusGo}).
+		AddTeam("qa", "qa").
+		AddTeam("qa", "qa again").
+		Build()
+	if err == nil {
+		t.Fatal("Build succeeded, want errors")
+	}
+	for _, want := range []string{"AddTask called before AddTeam", "team qa is added more than once"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Build error = %v, want %q", err, want)
+		}
+	}
+}
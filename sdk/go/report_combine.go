@@ -0,0 +1,183 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CombineReports merges reports, such as the reports of the components in
+// a release train, into one report with a section per input: each input's
+// teams, prefixed with its component label, and its summary and footer
+// blocks, titled with the label. A "Components" table listing each input's
+// status leads the summary blocks.
+//
+// A component's label is its project, with the version added when
+// projects repeat. The combined project, version, and phase are the
+// inputs' values when they agree, else their distinct values joined with
+// ", ". Tags are those every input shares. The status is the worst input
+// status, or SKIP when every input was skipped. The inputs are not
+// modified.
+func CombineReports(reports ...*TeamReport) *TeamReport {
+	labels := componentLabels(reports)
+	combined := &TeamReport{
+		Project: joinDistinct(reports, func(r *TeamReport) string { return r.Project }),
+		Version: joinDistinct(reports, func(r *TeamReport) string { return r.Version }),
+		Target:  joinDistinct(reports, func(r *TeamReport) string { return r.Target }),
+		Phase:   joinDistinct(reports, func(r *TeamReport) string { return r.Phase }),
+		Tags:    sharedTags(reports),
+		Teams:   []TeamSection{},
+	}
+
+	rows := make([][]string, 0, len(reports))
+	var summaries, conclusions []string
+	for i, r := range reports {
+		label := labels[i]
+		rows = append(rows, []string{label, r.Version, string(reportStatus(r)), fmt.Sprint(len(r.Teams))})
+
+		for _, t := range r.Teams {
+			t.ID = label + "/" + t.ID
+			t.Name = label + ": " + t.Name
+			deps := make([]string, len(t.DependsOn))
+			for j, dep := range t.DependsOn {
+				deps[j] = label + "/" + dep
+			}
+			t.DependsOn = deps
+			combined.Teams = append(combined.Teams, t)
+		}
+		for _, b := range r.SummaryBlocks {
+			combined.SummaryBlocks = append(combined.SummaryBlocks, labelBlock(label, b))
+		}
+		for _, b := range r.FooterBlocks {
+			combined.FooterBlocks = append(combined.FooterBlocks, labelBlock(label, b))
+		}
+		if r.Summary != "" {
+			summaries = append(summaries, label+": "+r.Summary)
+		}
+		if r.Conclusion != "" {
+			conclusions = append(conclusions, label+": "+r.Conclusion)
+		}
+		if r.GeneratedAt.After(combined.GeneratedAt) {
+			combined.GeneratedAt = r.GeneratedAt
+		}
+	}
+	combined.SummaryBlocks = append([]ContentBlock{
+		NewTableBlock("Components", []string{"Component", "Version", "Status", "Teams"}, rows),
+	}, combined.SummaryBlocks...)
+	combined.Summary = strings.Join(summaries, "\n\n")
+	combined.Conclusion = strings.Join(conclusions, "\n\n")
+	combined.Status = worstStatus(reports)
+	return combined
+}
+
+// reportStatus returns the report's status, computing it when unset.
+func reportStatus(r *TeamReport) Status {
+	if r.Status != "" {
+		return r.Status
+	}
+	return r.ComputeOverallStatus()
+}
+
+// worstStatus returns the worst status of reports, or SKIP when every
+// report was skipped.
+func worstStatus(reports []*TeamReport) Status {
+	allSkipped := len(reports) > 0
+	hasNoGo, hasWarn := false, false
+	for _, r := range reports {
+		switch reportStatus(r) {
+		case StatusNoGo:
+			hasNoGo = true
+		case StatusWarn:
+			hasWarn = true
+		case StatusSkip:
+			continue
+		}
+		allSkipped = false
+	}
+	switch {
+	case allSkipped:
+		return StatusSkip
+	case hasNoGo:
+		return StatusNoGo
+	case hasWarn:
+		return StatusWarn
+	}
+	return StatusGo
+}
+
+// componentLabels returns a distinct label for each report: its project,
+// with its version when projects repeat, and a position when both repeat.
+func componentLabels(reports []*TeamReport) []string {
+	labels := make([]string, len(reports))
+	projects := make(map[string]int)
+	for i, r := range reports {
+		labels[i] = r.Project
+		if labels[i] == "" {
+			labels[i] = fmt.Sprintf("report %d", i+1)
+		}
+		projects[labels[i]]++
+	}
+	seen := make(map[string]int)
+	for i, r := range reports {
+		if projects[labels[i]] > 1 && r.Version != "" {
+			labels[i] += " " + r.Version
+		}
+		seen[labels[i]]++
+	}
+	counts := make(map[string]int)
+	for i := range labels {
+		if seen[labels[i]] > 1 {
+			counts[labels[i]]++
+			labels[i] = fmt.Sprintf("%s (%d)", labels[i], counts[labels[i]])
+		}
+	}
+	return labels
+}
+
+// joinDistinct returns the distinct non-empty values of field across
+// reports, in order, joined with ", ".
+func joinDistinct(reports []*TeamReport, field func(*TeamReport) string) string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, r := range reports {
+		if v := field(r); v != "" && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// sharedTags returns the tags set to the same value on every report, or
+// nil if there are none.
+func sharedTags(reports []*TeamReport) map[string]string {
+	if len(reports) == 0 {
+		return nil
+	}
+	var tags map[string]string
+	for k, v := range reports[0].Tags {
+		shared := true
+		for _, r := range reports[1:] {
+			if w, ok := r.Tags[k]; !ok || w != v {
+				shared = false
+				break
+			}
+		}
+		if shared {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[k] = v
+		}
+	}
+	return tags
+}
+
+// labelBlock returns b with its title prefixed by label.
+func labelBlock(label string, b ContentBlock) ContentBlock {
+	if b.Title == "" {
+		b.Title = label
+	} else {
+		b.Title = label + ": " + b.Title
+	}
+	return b
+}
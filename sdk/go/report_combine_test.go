@@ -0,0 +1,127 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func combineInputs() []*TeamReport {
+	return []*TeamReport{
+		{
+			Project: "api",
+			Version: "v2.0.0",
+			Phase:   "RELEASE",
+			Tags:    map[string]string{"train": "2026.10", "env": "staging"},
+			Teams: []TeamSection{
+				{ID: "qa", Name: "qa", Status: StatusGo},
+				{ID: "security", Name: "security", Status: StatusWarn, DependsOn: []string{"qa"}},
+			},
+			SummaryBlocks: []ContentBlock{NewTextBlock("Scope", "API only")},
+			Summary:       "API is ready.",
+			Status:        StatusWarn,
+			GeneratedAt:   time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Project: "web",
+			Version: "v1.4.0",
+			Phase:   "RELEASE",
+			Tags:    map[string]string{"train": "2026.10", "env": "prod"},
+			Teams: []TeamSection{
+				{ID: "qa", Name: "qa", Status: StatusNoGo},
+			},
+			FooterBlocks: []ContentBlock{NewListBlock("", ListItem{Text: "fix login"})},
+			GeneratedAt:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestCombineReports(t *testing.T) {
+	inputs := combineInputs()
+	combined := CombineReports(inputs...)
+
+	if combined.Project != "api, web" || combined.Version != "v2.0.0, v1.4.0" || combined.Phase != "RELEASE" {
+		t.Errorf("header = %q %q %q", combined.Project, combined.Version, combined.Phase)
+	}
+	if !reflect.DeepEqual(combined.Tags, map[string]string{"train": "2026.10"}) {
+		t.Errorf("Tags = %v, want the shared train tag", combined.Tags)
+	}
+	if combined.Status != StatusNoGo {
+		t.Errorf("Status = %s, want NO-GO", combined.Status)
+	}
+	if !combined.GeneratedAt.Equal(inputs[1].GeneratedAt) {
+		t.Errorf("GeneratedAt = %v, want the latest", combined.GeneratedAt)
+	}
+
+	var ids []string
+	for _, team := range combined.Teams {
+		ids = append(ids, team.ID)
+	}
+	if want := []string{"api/qa", "api/security", "web/qa"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("team IDs = %v, want %v", ids, want)
+	}
+	if got := combined.Teams[1]; got.Name != "api: security" || !reflect.DeepEqual(got.DependsOn, []string{"api/qa"}) {
+		t.Errorf("team = %q depends on %v", got.Name, got.DependsOn)
+	}
+	if inputs[0].Teams[0].ID != "qa" || inputs[0].Teams[1].DependsOn[0] != "qa" {
+		t.Error("CombineReports modified its input")
+	}
+
+	if len(combined.SummaryBlocks) != 2 {
+		t.Fatalf("SummaryBlocks = %d, want the components table and one input block", len(combined.SummaryBlocks))
+	}
+	table := combined.SummaryBlocks[0]
+	if table.Title != "Components" || len(table.Rows) != 2 || !reflect.DeepEqual(table.Rows[1], []string{"web", "v1.4.0", "NO-GO", "1"}) {
+		t.Errorf("components table = %+v", table)
+	}
+	if combined.SummaryBlocks[1].Title != "api: Scope" {
+		t.Errorf("summary block title = %q", combined.SummaryBlocks[1].Title)
+	}
+	if len(combined.FooterBlocks) != 1 || combined.FooterBlocks[0].Title != "web" {
+		t.Errorf("FooterBlocks = %+v", combined.FooterBlocks)
+	}
+	if combined.Summary != "api: API is ready." {
+		t.Errorf("Summary = %q", combined.Summary)
+	}
+
+	var buf bytes.Buffer
+	if err := NewRenderer(&buf).Render(combined); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "api: security") {
+		t.Errorf("box output lacks the prefixed team:\n%s", buf.String())
+	}
+}
+
+func TestCombineReportsSkipped(t *testing.T) {
+	combined := CombineReports(
+		&TeamReport{Project: "a", Status: StatusSkip},
+		&TeamReport{Project: "b", Teams: []TeamSection{{ID: "qa", Status: StatusSkip}}},
+	)
+	if combined.Status != StatusSkip {
+		t.Errorf("Status = %s, want SKIP", combined.Status)
+	}
+	combined = CombineReports(
+		&TeamReport{Project: "a", Status: StatusSkip},
+		&TeamReport{Project: "b", Status: StatusGo},
+	)
+	if combined.Status != StatusGo {
+		t.Errorf("Status = %s, want GO", combined.Status)
+	}
+}
+
+func TestComponentLabels(t *testing.T) {
+	got := componentLabels([]*TeamReport{
+		{Project: "api", Version: "v1"},
+		{Project: "api", Version: "v2"},
+		{Project: "web", Version: "v1"},
+		{Project: "web", Version: "v1"},
+		{},
+	})
+	want := []string{"api v1", "api v2", "web v1 (1)", "web v1 (2)", "report 5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("componentLabels = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package multiagentspec
+
+// ReportChange describes how a team or task differs between two reports.
+type ReportChange string
+
+const (
+	ReportChangeAdded   ReportChange = "added"
+	ReportChangeRemoved ReportChange = "removed"
+	ReportChangeChanged ReportChange = "changed"
+)
+
+// ReportDiff lists the status differences between a base and a head report,
+// such as two runs of the same validation team.
+type ReportDiff struct {
+	// BaseStatus is the overall status of the base report.
+	BaseStatus Status `json:"base_status"`
+
+	// HeadStatus is the overall status of the head report.
+	HeadStatus Status `json:"head_status"`
+
+	// Teams are the team sections that were added, removed, or changed.
+	Teams []TeamDiff `json:"teams,omitempty"`
+}
+
+// TeamDiff is a team section that differs between the reports.
+type TeamDiff struct {
+	// ID is the team section ID (its name when the ID is empty).
+	ID string `json:"id"`
+
+	// Change is how the team differs.
+	Change ReportChange `json:"change"`
+
+	// BaseStatus is the team's status in the base report.
+	BaseStatus Status `json:"base_status,omitempty"`
+
+	// HeadStatus is the team's status in the head report.
+	HeadStatus Status `json:"head_status,omitempty"`
+
+	// Tasks are the tasks that were added, removed, or changed status.
+	Tasks []TaskDiff `json:"tasks,omitempty"`
+}
+
+// TaskDiff is a task result that differs between the reports.
+type TaskDiff struct {
+	// ID is the task identifier.
+	ID string `json:"id"`
+
+	// Change is how the task differs.
+	Change ReportChange `json:"change"`
+
+	// BaseStatus is the task's status in the base report.
+	BaseStatus Status `json:"base_status,omitempty"`
+
+	// HeadStatus is the task's status in the head report.
+	HeadStatus Status `json:"head_status,omitempty"`
+
+	// Detail is the task's detail in the head report, or in the base
+	// report for removed tasks.
+	Detail string `json:"detail,omitempty"`
+}
+
+// Empty reports whether the reports have the same teams, tasks, and statuses.
+func (d *ReportDiff) Empty() bool {
+	return d.BaseStatus == d.HeadStatus && len(d.Teams) == 0
+}
+
+// DiffReports compares the team and task statuses of two reports. Teams are
+// matched by ID and tasks by ID within their team. Differences are listed in
+// head order, followed by removed entries in base order.
+func DiffReports(base, head *TeamReport) *ReportDiff {
+	diff := &ReportDiff{BaseStatus: base.Status, HeadStatus: head.Status}
+
+	baseTeams := make(map[string]*TeamSection, len(base.Teams))
+	for i := range base.Teams {
+		baseTeams[teamKey(&base.Teams[i])] = &base.Teams[i]
+	}
+	seen := make(map[string]bool, len(head.Teams))
+	for i := range head.Teams {
+		h := &head.Teams[i]
+		id := teamKey(h)
+		seen[id] = true
+		b, ok := baseTeams[id]
+		if !ok {
+			diff.Teams = append(diff.Teams, TeamDiff{ID: id, Change: ReportChangeAdded, HeadStatus: h.Status, Tasks: diffTasks(nil, h.Tasks)})
+			continue
+		}
+		tasks := diffTasks(b.Tasks, h.Tasks)
+		if b.Status != h.Status || len(tasks) > 0 {
+			diff.Teams = append(diff.Teams, TeamDiff{ID: id, Change: ReportChangeChanged, BaseStatus: b.Status, HeadStatus: h.Status, Tasks: tasks})
+		}
+	}
+	for i := range base.Teams {
+		b := &base.Teams[i]
+		if id := teamKey(b); !seen[id] {
+			diff.Teams = append(diff.Teams, TeamDiff{ID: id, Change: ReportChangeRemoved, BaseStatus: b.Status, Tasks: diffTasks(b.Tasks, nil)})
+		}
+	}
+	return diff
+}
+
+func teamKey(t *TeamSection) string {
+	if t.ID != "" {
+		return t.ID
+	}
+	return t.Name
+}
+
+func diffTasks(base, head []TaskResult) []TaskDiff {
+	baseTasks := make(map[string]*TaskResult, len(base))
+	for i := range base {
+		baseTasks[base[i].ID] = &base[i]
+	}
+	var diffs []TaskDiff
+	seen := make(map[string]bool, len(head))
+	for _, h := range head {
+		seen[h.ID] = true
+		b, ok := baseTasks[h.ID]
+		switch {
+		case !ok:
+			diffs = append(diffs, TaskDiff{ID: h.ID, Change: ReportChangeAdded, HeadStatus: h.Status, Detail: h.Detail})
+		case b.Status != h.Status:
+			diffs = append(diffs, TaskDiff{ID: h.ID, Change: ReportChangeChanged, BaseStatus: b.Status, HeadStatus: h.Status, Detail: h.Detail})
+		}
+	}
+	for _, b := range base {
+		if !seen[b.ID] {
+			diffs = append(diffs, TaskDiff{ID: b.ID, Change: ReportChangeRemoved, BaseStatus: b.Status, Detail: b.Detail})
+		}
+	}
+	return diffs
+}
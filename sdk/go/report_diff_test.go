@@ -0,0 +1,67 @@
+package multiagentspec
+
+import (
+	"testing"
+)
+
+func TestDiffReports(t *testing.T) {
+	base := &TeamReport{
+		Status: StatusNoGo,
+		Teams: []TeamSection{
+			{ID: "pm-validation", Status: StatusGo, Tasks: []TaskResult{{ID: "scope", Status: StatusGo}}},
+			{ID: "qa-validation", Status: StatusNoGo, Tasks: []TaskResult{
+				{ID: "unit-tests", Status: StatusNoGo, Detail: "3 failures"},
+				{ID: "lint", Status: StatusGo},
+			}},
+			{Name: "docs", Status: StatusWarn},
+		},
+	}
+	head := &TeamReport{
+		Status: StatusGo,
+		Teams: []TeamSection{
+			{ID: "pm-validation", Status: StatusGo, Tasks: []TaskResult{{ID: "scope", Status: StatusGo, Detail: "reworded"}}},
+			{ID: "qa-validation", Status: StatusGo, Tasks: []TaskResult{
+				{ID: "unit-tests", Status: StatusGo, Detail: "all passing"},
+				{ID: "coverage", Status: StatusGo},
+			}},
+			{ID: "security-validation", Status: StatusGo, Tasks: []TaskResult{{ID: "secrets", Status: StatusGo}}},
+		},
+	}
+
+	diff := DiffReports(base, head)
+	if diff.Empty() || diff.BaseStatus != StatusNoGo || diff.HeadStatus != StatusGo {
+		t.Fatalf("diff = %+v", diff)
+	}
+	if len(diff.Teams) != 3 {
+		t.Fatalf("got %d team diffs, want 3: %+v", len(diff.Teams), diff.Teams)
+	}
+
+	qa := diff.Teams[0]
+	if qa.ID != "qa-validation" || qa.Change != ReportChangeChanged || qa.BaseStatus != StatusNoGo || qa.HeadStatus != StatusGo {
+		t.Errorf("qa diff = %+v", qa)
+	}
+	wantTasks := []TaskDiff{
+		{ID: "unit-tests", Change: ReportChangeChanged, BaseStatus: StatusNoGo, HeadStatus: StatusGo, Detail: "all passing"},
+		{ID: "coverage", Change: ReportChangeAdded, HeadStatus: StatusGo},
+		{ID: "lint", Change: ReportChangeRemoved, BaseStatus: StatusGo},
+	}
+	if len(qa.Tasks) != len(wantTasks) {
+		t.Fatalf("qa tasks = %+v", qa.Tasks)
+	}
+	for i, want := range wantTasks {
+		if qa.Tasks[i] != want {
+			t.Errorf("qa task %d = %+v, want %+v", i, qa.Tasks[i], want)
+		}
+	}
+
+	if s := diff.Teams[1]; s.ID != "security-validation" || s.Change != ReportChangeAdded || len(s.Tasks) != 1 {
+		t.Errorf("security diff = %+v", s)
+	}
+	if d := diff.Teams[2]; d.ID != "docs" || d.Change != ReportChangeRemoved || d.BaseStatus != StatusWarn {
+		t.Errorf("docs diff = %+v", d)
+	}
+
+	if same := DiffReports(head, head); !same.Empty() {
+		t.Errorf("DiffReports(head, head) = %+v, want empty", same)
+	}
+}
@@ -0,0 +1,117 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// IndexEntry is a report listed in an index page, with a link to its own
+// rendering.
+type IndexEntry struct {
+	Report *TeamReport
+
+	// Link is the URL or relative path of the rendered report.
+	Link string
+}
+
+// indexRow is an index entry as displayed.
+type indexRow struct {
+	Label   string
+	Version string
+	Status  Status
+	Teams   string
+	Link    string
+}
+
+// indexRows returns the display rows of entries and the overall status:
+// the worst entry status, or SKIP when every entry was skipped.
+func indexRows(entries []IndexEntry) ([]indexRow, Status) {
+	reports := make([]*TeamReport, len(entries))
+	for i, e := range entries {
+		reports[i] = e.Report
+	}
+	labels := componentLabels(reports)
+	rows := make([]indexRow, len(entries))
+	for i, e := range entries {
+		status := reportStatus(e.Report)
+		teams := fmt.Sprint(len(e.Report.Teams))
+		counts := e.Report.TeamStatusCounts()
+		var notes []string
+		for _, s := range []Status{StatusNoGo, StatusWarn, StatusSkip} {
+			if counts[s] > 0 {
+				notes = append(notes, fmt.Sprintf("%d %s", counts[s], s))
+			}
+		}
+		if len(notes) > 0 {
+			teams += " (" + strings.Join(notes, ", ") + ")"
+		}
+		rows[i] = indexRow{Label: labels[i], Version: e.Report.Version, Status: status, Teams: teams, Link: e.Link}
+	}
+	return rows, worstStatus(reports)
+}
+
+// WriteIndexMarkdown writes a markdown page with a table linking each
+// entry's rendered report, with its status and team counts, under a
+// heading with title and the overall status.
+func WriteIndexMarkdown(w io.Writer, title string, entries []IndexEntry) error {
+	rows, status := indexRows(entries)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s %s: %s\n\n", status.Icon(), title, status)
+	sb.WriteString("| Component | Version | Status | Teams | Report |\n")
+	sb.WriteString("|-----------|---------|--------|-------|--------|\n")
+	for _, r := range rows {
+		link := ""
+		if r.Link != "" {
+			link = fmt.Sprintf("[view](%s)", strings.ReplaceAll(r.Link, " ", "%20"))
+		}
+		fmt.Fprintf(&sb, "| %s | %s | %s %s | %s | %s |\n",
+			ghCell(r.Label), ghCell(r.Version), r.Status.Icon(), r.Status, r.Teams, link)
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteIndexHTML writes a standalone HTML page with a table linking each
+// entry's rendered report, styled like the HTML email rendering.
+func WriteIndexHTML(w io.Writer, title string, entries []IndexEntry) error {
+	rows, status := indexRows(entries)
+	tmpl, err := template.New("index").Funcs(template.FuncMap{
+		"badge":      emailBadge,
+		"banner":     emailBanner,
+		"statusText": statusText,
+	}).Parse(IndexTemplate)
+	if err != nil {
+		return fmt.Errorf("parsing template: %w", err)
+	}
+	return tmpl.Execute(w, struct {
+		Title  string
+		Status Status
+		Rows   []indexRow
+	}{title, status, rows})
+}
+
+// IndexTemplate is the HTML index page template.
+const IndexTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+</head>
+<body style="margin:0;padding:24px 8px;background-color:#f6f8fa;font-family:-apple-system,'Segoe UI',Helvetica,Arial,sans-serif;font-size:14px;line-height:1.5;color:#1f2328;">
+<div style="max-width:800px;margin:0 auto;background-color:#ffffff;border:1px solid #d0d7de;">
+<div style="{{ banner .Status }}">
+<div style="font-size:20px;font-weight:bold;">{{ .Title }}: {{ statusText .Status }}</div>
+</div>
+<table width="100%" cellpadding="0" cellspacing="0" border="0" style="border-collapse:collapse;font-size:13px;">
+<tr><th align="left" style="padding:8px 20px;border-bottom:2px solid #d0d7de;">Component</th><th align="left" style="padding:8px;border-bottom:2px solid #d0d7de;">Version</th><th align="left" style="padding:8px;border-bottom:2px solid #d0d7de;">Status</th><th align="left" style="padding:8px;border-bottom:2px solid #d0d7de;">Teams</th></tr>
+{{- range .Rows }}
+<tr><td style="padding:8px 20px;border-bottom:1px solid #eaeef2;">{{ if .Link }}<a href="{{ .Link }}" style="color:#0969da;">{{ .Label }}</a>{{ else }}{{ .Label }}{{ end }}</td><td style="padding:8px;border-bottom:1px solid #eaeef2;">{{ .Version }}</td><td style="padding:8px;border-bottom:1px solid #eaeef2;"><span style="{{ badge .Status }}">{{ statusText .Status }}</span></td><td style="padding:8px;border-bottom:1px solid #eaeef2;">{{ .Teams }}</td></tr>
+{{- end }}
+</table>
+</div>
+</body>
+</html>
+`
@@ -0,0 +1,51 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func indexEntries() []IndexEntry {
+	inputs := combineInputs()
+	return []IndexEntry{
+		{Report: inputs[0], Link: "api report.html"},
+		{Report: inputs[1], Link: "web.html"},
+	}
+}
+
+func TestWriteIndexMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteIndexMarkdown(&buf, "Release 2026.10", indexEntries()); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"# \U0001F534 Release 2026.10: NO-GO\n",
+		"| api | v2.0.0 | \U0001F7E1 WARN | 2 (1 WARN) | [view](api%20report.html) |\n",
+		"| web | v1.4.0 | \U0001F534 NO-GO | 1 (1 NO-GO) | [view](web.html) |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("index lacks %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteIndexHTML(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteIndexHTML(&buf, "Release <2026.10>", indexEntries()); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"<title>Release &lt;2026.10&gt;</title>",
+		`<a href="api%20report.html" style="color:#0969da;">api</a>`,
+		`<a href="web.html" style="color:#0969da;">web</a>`,
+		"1 (1 NO-GO)",
+		"Release &lt;2026.10&gt;: FAIL",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("index lacks %q:\n%s", want, got)
+		}
+	}
+}
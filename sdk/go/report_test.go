@@ -2,7 +2,12 @@ package multiagentspec
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSortByDAG(t *testing.T) {
@@ -68,7 +73,9 @@ func TestSortByDAG(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			report := &TeamReport{Teams: tt.teams}
-			report.SortByDAG()
+			if err := report.SortByDAG(); err != nil {
+				t.Fatal(err)
+			}
 
 			if len(report.Teams) != len(tt.expected) {
 				t.Fatalf("expected %d teams, got %d", len(tt.expected), len(report.Teams))
@@ -83,6 +90,112 @@ func TestSortByDAG(t *testing.T) {
 	}
 }
 
+func TestSortByDAGCycles(t *testing.T) {
+	report := &TeamReport{Teams: []TeamSection{
+		{ID: "release", DependsOn: []string{"qa"}},
+		{ID: "qa", DependsOn: []string{"security"}},
+		{ID: "security", DependsOn: []string{"release", "build"}},
+		{ID: "build"},
+		{ID: "docs", DependsOn: []string{"docs"}},
+		{ID: "publish", DependsOn: []string{"docs"}},
+	}}
+	err := report.SortByDAG()
+	var cycleErr *TeamCycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("SortByDAG() error = %v, want *TeamCycleError", err)
+	}
+	want := [][]string{{"release", "qa", "security", "release"}, {"docs", "docs"}}
+	if !reflect.DeepEqual(cycleErr.Cycles, want) {
+		t.Errorf("Cycles = %v, want %v", cycleErr.Cycles, want)
+	}
+	if got := err.Error(); got != "team dependency cycle: release -> qa -> security -> release; docs -> docs" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	var ids []string
+	for _, team := range report.Teams {
+		ids = append(ids, team.ID)
+	}
+	if want := []string{"build", "release", "qa", "security", "docs", "publish"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("order = %v, want %v", ids, want)
+	}
+}
+
+func TestDetectCycles(t *testing.T) {
+	tests := []struct {
+		name  string
+		teams []TeamSection
+		want  [][]string
+	}{
+		{"DAG", []TeamSection{{ID: "a"}, {ID: "b", DependsOn: []string{"a", "missing"}}}, nil},
+		{"shortest cycle", []TeamSection{
+			{ID: "a", DependsOn: []string{"b"}},
+			{ID: "b", DependsOn: []string{"c", "a"}},
+			{ID: "c", DependsOn: []string{"a"}},
+		}, [][]string{{"a", "b", "a"}}},
+		{"duplicate IDs", []TeamSection{
+			{ID: "a", DependsOn: []string{"b"}},
+			{ID: "b", DependsOn: []string{"a"}},
+			{ID: "a"},
+		}, [][]string{{"a", "b", "a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &TeamReport{Teams: tt.teams}
+			if got := report.DetectCycles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectCycles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByDAGLarge(t *testing.T) {
+	// A chain of 1000 teams, listed last first, with a fan-in at the end.
+	const n = 1000
+	report := &TeamReport{}
+	for i := n - 1; i >= 0; i-- {
+		team := TeamSection{ID: fmt.Sprintf("team-%04d", i)}
+		if i > 0 {
+			team.DependsOn = []string{fmt.Sprintf("team-%04d", i-1)}
+		}
+		report.Teams = append(report.Teams, team)
+	}
+	report.Teams = append(report.Teams, TeamSection{ID: "duplicate", DependsOn: []string{"team-0000"}}, TeamSection{ID: "duplicate"})
+	if err := report.SortByDAG(); err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Teams) != n+2 {
+		t.Fatalf("got %d teams, want %d", len(report.Teams), n+2)
+	}
+	if report.Teams[0].ID != "duplicate" || report.Teams[1].ID != "team-0000" || report.Teams[n+1].ID != "team-0999" {
+		t.Errorf("order starts %s, %s and ends %s", report.Teams[0].ID, report.Teams[1].ID, report.Teams[n+1].ID)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	report := &TeamReport{Teams: []TeamSection{
+		{ID: "release", DependsOn: []string{"review", "test"}},
+		{ID: "build"},
+		{ID: "test", DependsOn: []string{"build", "missing"}},
+		{ID: "review", DependsOn: []string{"lint"}},
+		{ID: "lint", DependsOn: []string{"build"}},
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"a"}},
+	}}
+	var got [][]string
+	for _, level := range report.Levels() {
+		var ids []string
+		for _, team := range level {
+			ids = append(ids, team.ID)
+		}
+		got = append(got, ids)
+	}
+	want := [][]string{{"build", "b"}, {"test", "lint", "a"}, {"review"}, {"release"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Levels() = %v, want %v", got, want)
+	}
+}
+
 func TestComputeStatus(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -197,6 +310,17 @@ func TestStatusIcon(t *testing.T) {
 	}
 }
 
+func TestParseStatus(t *testing.T) {
+	for in, want := range map[string]Status{"GO": StatusGo, "warn": StatusWarn, "No-Go": StatusNoGo, "skip": StatusSkip} {
+		if got, err := ParseStatus(in); err != nil || got != want {
+			t.Errorf("ParseStatus(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseStatus("NOGO"); err == nil || err.Error() != `unknown status "NOGO" (want GO, NO-GO, WARN, or SKIP)` {
+		t.Errorf("ParseStatus(NOGO) error = %v", err)
+	}
+}
+
 func TestEffectiveTitle(t *testing.T) {
 	t.Run("returns custom title when set", func(t *testing.T) {
 		report := &TeamReport{Title: "CUSTOM REPORT"}
@@ -212,3 +336,264 @@ func TestEffectiveTitle(t *testing.T) {
 		}
 	})
 }
+
+func TestAllowFailure(t *testing.T) {
+	report := &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Teams: []TeamSection{
+			{ID: "qa", Name: "qa", Status: StatusGo},
+			{ID: "perf", Name: "perf", Status: StatusNoGo, AllowFailure: true},
+		},
+	}
+	if got := report.ComputeOverallStatus(); got != StatusWarn {
+		t.Errorf("ComputeOverallStatus() = %s, want WARN", got)
+	}
+	if !report.IsGo() {
+		t.Error("IsGo() = false with only a non-blocking team failing")
+	}
+	if got := report.Teams[1].BlockingStatus(); got != StatusWarn {
+		t.Errorf("BlockingStatus() = %s, want WARN", got)
+	}
+	if got := report.Teams[1].StatusLabel(); got != "NO-GO (non-blocking)" {
+		t.Errorf("StatusLabel() = %q", got)
+	}
+	report.Status = report.ComputeOverallStatus()
+
+	renders := map[string]func(*bytes.Buffer, *TeamReport) error{
+		"box":             func(b *bytes.Buffer, r *TeamReport) error { return NewRenderer(b).Render(r) },
+		"quick box":       func(b *bytes.Buffer, r *TeamReport) error { return NewQuickRenderer(b).Render(r) },
+		"narrative":       func(b *bytes.Buffer, r *TeamReport) error { return NewNarrativeRenderer(b).Render(r) },
+		"quick narrative": func(b *bytes.Buffer, r *TeamReport) error { return NewQuickNarrativeRenderer(b).Render(r) },
+		"gh-summary":      func(b *bytes.Buffer, r *TeamReport) error { return WriteGitHubSummary(b, r) },
+	}
+	for name, render := range renders {
+		var buf bytes.Buffer
+		if err := render(&buf, report); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out := buf.String()
+		if strings.Count(out, "(non-blocking)") != 1 {
+			t.Errorf("%s output does not mark the non-blocking team once:\n%s", name, out)
+		}
+		if strings.Contains(out, "TEAM: NO-GO") {
+			t.Errorf("%s output reports NO-GO for a non-blocking failure:\n%s", name, out)
+		}
+	}
+
+	for i := range report.Teams { // renderers sort teams
+		report.Teams[i].AllowFailure = false
+	}
+	if report.ComputeOverallStatus() != StatusNoGo || report.IsGo() {
+		t.Error("blocking NO-GO team does not fail the report")
+	}
+}
+
+func TestSkippedTeams(t *testing.T) {
+	report := &TeamReport{
+		Version: "v1.0.0",
+		Teams: []TeamSection{
+			{ID: "qa", Status: StatusSkip},
+			{ID: "docs", Status: StatusSkip},
+		},
+	}
+	if got := report.ComputeOverallStatus(); got != StatusSkip {
+		t.Errorf("ComputeOverallStatus() = %s, want SKIP", got)
+	}
+	if got := report.SkippedTeams(); got != 2 {
+		t.Errorf("SkippedTeams() = %d, want 2", got)
+	}
+	if got := report.FinalMessage(); got != "⚪ TEAM: SKIP for v1.0.0 ⚪" {
+		t.Errorf("FinalMessage() = %q", got)
+	}
+
+	report.Teams = append(report.Teams, TeamSection{ID: "security", Status: StatusGo})
+	if got := report.ComputeOverallStatus(); got != StatusGo {
+		t.Errorf("ComputeOverallStatus() = %s, want GO", got)
+	}
+	counts := report.TeamStatusCounts()
+	if counts[StatusSkip] != 2 || counts[StatusGo] != 1 {
+		t.Errorf("TeamStatusCounts() = %v", counts)
+	}
+	if got := report.FinalMessage(); got != "🚀 TEAM: GO for v1.0.0 (2 teams skipped) 🚀" {
+		t.Errorf("FinalMessage() = %q", got)
+	}
+
+	if got := (&TeamReport{}).ComputeOverallStatus(); got != StatusGo {
+		t.Errorf("ComputeOverallStatus() without teams = %s, want GO", got)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	report := []byte(`{"project":"app","version":"v1.0.0","phase":"REVIEW","status":"GO",
+		"generated_at":"2026-01-02T03:04:05Z","teams":[{"id":"qa","name":"qa","status":"GO"}]}`)
+	if _, err := ParseTeamReportStrict(report); err != nil {
+		t.Fatalf("ParseTeamReportStrict() = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		parse func([]byte) error
+		data  string
+		want  string
+	}{
+		{"unknown report field", parseReportStrict, `{"project":"app","owner":"me"}`, `unknown field "owner"`},
+		{"unknown team field", parseReportStrict, `{"teams":[{"id":"qa","score":9}]}`, `unknown field "score"`},
+		{"trailing data", parseReportStrict, `{"project":"app"} {}`, "unexpected data"},
+		{"unknown task field", parseResultStrict, `{"agent_id":"qa","tasks":[{"id":"unit","ok":true}]}`, `unknown field "ok"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.parse([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseAgentResult([]byte(`{"agent_id":"qa","extra":1}`)); err != nil {
+		t.Errorf("ParseAgentResult() rejects unknown fields: %v", err)
+	}
+}
+
+func parseReportStrict(data []byte) error {
+	_, err := ParseTeamReportStrict(data)
+	return err
+}
+
+func parseResultStrict(data []byte) error {
+	_, err := ParseAgentResultStrict(data)
+	return err
+}
+
+func TestParseYAML(t *testing.T) {
+	report, err := ParseTeamReportYAML([]byte(`
+project: app
+version: v1.0.0
+phase: REVIEW
+status: WARN
+generated_at: 2026-01-02T03:04:05Z
+teams:
+  - id: security
+    name: security
+    status: WARN
+    depends_on: [qa]
+    tasks:
+      - id: scan
+        status: WARN
+        severity: medium
+        duration_ms: 1500
+`))
+	if err != nil {
+		t.Fatalf("ParseTeamReportYAML() = %v", err)
+	}
+	if report.Project != "app" || len(report.Teams) != 1 || report.Teams[0].DependsOn[0] != "qa" {
+		t.Errorf("report = %+v", report)
+	}
+	if task := report.Teams[0].Tasks[0]; task.Status != StatusWarn || task.DurationMs != 1500 {
+		t.Errorf("task = %+v", task)
+	}
+	if want := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC); !report.GeneratedAt.Equal(want) {
+		t.Errorf("GeneratedAt = %v, want %v", report.GeneratedAt, want)
+	}
+
+	result, err := ParseAgentResultYAML([]byte("agent_id: qa\nstatus: GO\ntasks:\n  - {id: unit, status: GO}\n"))
+	if err != nil {
+		t.Fatalf("ParseAgentResultYAML() = %v", err)
+	}
+	if result.AgentID != "qa" || len(result.Tasks) != 1 {
+		t.Errorf("result = %+v", result)
+	}
+
+	if _, err := ParseTeamReportYAML([]byte("project: [unclosed")); err == nil {
+		t.Error("ParseTeamReportYAML() accepts invalid YAML")
+	}
+}
+
+func TestFilterTeams(t *testing.T) {
+	report := &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Status:  StatusNoGo,
+		Teams: []TeamSection{
+			{ID: "qa-validation", Name: "qa", Status: StatusGo},
+			{ID: "security-audit", Name: "security", Status: StatusWarn, DependsOn: []string{"qa-validation"}},
+			{ID: "release-check", Name: "release", Status: StatusNoGo},
+		},
+	}
+	filtered, err := report.FilterTeams([]string{"security", "qa-validation"})
+	if err != nil {
+		t.Fatalf("FilterTeams() = %v", err)
+	}
+	if len(filtered.Teams) != 2 || filtered.Teams[0].ID != "qa-validation" || filtered.Teams[1].ID != "security-audit" {
+		t.Errorf("teams = %+v", filtered.Teams)
+	}
+	if filtered.Status != StatusWarn {
+		t.Errorf("Status = %s, want WARN for the shown teams", filtered.Status)
+	}
+	if got := filtered.FilterSummary(); got != "2 of 3 teams" {
+		t.Errorf("FilterSummary() = %q", got)
+	}
+	if got := filtered.FinalMessage(); got != "🚀 TEAM: GO for v1.0.0 (2 of 3 teams) 🚀" {
+		t.Errorf("FinalMessage() = %q", got)
+	}
+	if len(report.Teams) != 3 || report.FilterSummary() != "" {
+		t.Error("FilterTeams() modified the original report")
+	}
+
+	again, err := filtered.FilterTeams([]string{"qa"})
+	if err != nil || again.FilterSummary() != "1 of 3 teams" {
+		t.Errorf("refiltered FilterSummary() = %q, %v", again.FilterSummary(), err)
+	}
+
+	if _, err := report.FilterTeams([]string{"qa", "perf"}); err == nil || err.Error() != "unknown team perf" {
+		t.Errorf("FilterTeams() with an unknown team = %v", err)
+	}
+
+	renders := map[string]func(*bytes.Buffer, *TeamReport) error{
+		"narrative":       func(b *bytes.Buffer, r *TeamReport) error { return NewNarrativeRenderer(b).Render(r) },
+		"quick narrative": func(b *bytes.Buffer, r *TeamReport) error { return NewQuickNarrativeRenderer(b).Render(r) },
+		"gh-summary":      func(b *bytes.Buffer, r *TeamReport) error { return WriteGitHubSummary(b, r) },
+		"box":             func(b *bytes.Buffer, r *TeamReport) error { return NewRenderer(b).Render(r) },
+	}
+	for name, render := range renders {
+		var buf bytes.Buffer
+		if err := render(&buf, filtered); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !strings.Contains(buf.String(), "(2 of 3 teams)") {
+			t.Errorf("%s output does not note the filter:\n%s", name, buf.String())
+		}
+	}
+}
+
+func TestAgentResultToJSON(t *testing.T) {
+	result := &AgentResult{
+		Schema:  "https://example.com/old.json",
+		AgentID: "qa",
+		StepID:  "qa-validation",
+		Tasks:   []TaskResult{{ID: "unit", Status: StatusNoGo}},
+	}
+	data, err := result.ToJSON()
+	if err != nil {
+		t.Fatalf("ToJSON() = %v", err)
+	}
+	if result.Status != "" || !result.ExecutedAt.IsZero() {
+		t.Error("ToJSON() modified the result")
+	}
+	parsed, err := ParseAgentResult(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Schema != SchemaURL(SchemaAgentResult) || parsed.Status != StatusNoGo || parsed.ExecutedAt.IsZero() {
+		t.Errorf("ToJSON() stamped $schema %q, status %s, executed_at %v", parsed.Schema, parsed.Status, parsed.ExecutedAt)
+	}
+
+	empty := &AgentResult{AgentID: "qa"}
+	if _, err := empty.ToJSON(); err == nil || !strings.Contains(err.Error(), "step_id is required") {
+		t.Errorf("ToJSON() of result without step_id = %v, want error", err)
+	}
+	if data, err := (&AgentResult{AgentID: "qa", StepID: "qa"}).ToJSON(); err != nil || !strings.Contains(string(data), `"tasks": []`) {
+		t.Errorf("ToJSON() without tasks = %s, %v; want empty tasks list", data, err)
+	}
+}
@@ -0,0 +1,207 @@
+package multiagentspec
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Validate checks the report for structural consistency: the fields the
+// schema requires are set, statuses are known, team IDs are unique,
+// DependsOn names teams in the report, and content blocks are valid (see
+// ContentBlock.Validate). It returns every problem found, joined.
+// Statuses that differ from the computed ones are not errors; see
+// StatusOverrides.
+func (r *TeamReport) Validate() error {
+	var errs []error
+	for _, f := range []struct{ name, value string }{
+		{"project", r.Project},
+		{"version", r.Version},
+		{"phase", r.Phase},
+		{"status", string(r.Status)},
+	} {
+		if f.value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", f.name))
+		}
+	}
+	if r.GeneratedAt.IsZero() {
+		errs = append(errs, fmt.Errorf("generated_at is required"))
+	}
+	if !r.Status.valid() {
+		errs = append(errs, fmt.Errorf("unknown status %q", r.Status))
+	}
+	errs = appendBlockErrors(errs, "summary block", r.SummaryBlocks)
+	errs = appendBlockErrors(errs, "footer block", r.FooterBlocks)
+
+	ids := make(map[string]bool, len(r.Teams))
+	for i := range r.Teams {
+		t := &r.Teams[i]
+		if t.ID == "" {
+			errs = append(errs, fmt.Errorf("team %d: id is required", i))
+		} else if ids[t.ID] {
+			errs = append(errs, fmt.Errorf("team %s appears more than once", t.ID))
+		}
+		ids[t.ID] = true
+	}
+	for i := range r.Teams {
+		t := &r.Teams[i]
+		name := t.ID
+		if name == "" {
+			name = fmt.Sprint(i)
+		}
+		var teamErrs []error
+		if t.Name == "" {
+			teamErrs = append(teamErrs, fmt.Errorf("name is required"))
+		}
+		if t.Status == "" {
+			teamErrs = append(teamErrs, fmt.Errorf("status is required"))
+		} else if !t.Status.valid() {
+			teamErrs = append(teamErrs, fmt.Errorf("unknown status %q", t.Status))
+		}
+		for _, dep := range t.DependsOn {
+			if !ids[dep] {
+				teamErrs = append(teamErrs, fmt.Errorf("depends on unknown team %s", dep))
+			}
+		}
+		teamErrs = appendTaskErrors(teamErrs, t.Tasks)
+		for j, issue := range t.Issues {
+			if issue.ID == "" || issue.Category == "" || issue.Severity == "" || issue.Problem == "" {
+				teamErrs = append(teamErrs, fmt.Errorf("issue %d: id, category, severity, and problem are required", j))
+			}
+		}
+		teamErrs = appendArtifactErrors(teamErrs, t.Artifacts)
+		teamErrs = appendBlockErrors(teamErrs, "content block", t.ContentBlocks)
+		for _, err := range teamErrs {
+			errs = append(errs, fmt.Errorf("team %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// maxClockSkew is how far in the future an agent result's executed_at may
+// be, allowing for clocks that differ between machines.
+const maxClockSkew = 5 * time.Minute
+
+// Validate checks the agent result for structural consistency: agent_id,
+// step_id, and executed_at are set, $schema (if set) is an agent result
+// schema URL, statuses are known, Status matches the one computed from the
+// tasks, executed_at is not in the future, duration is a non-negative Go
+// duration, and content blocks and artifacts are valid. It returns every
+// problem found, joined.
+func (a *AgentResult) Validate() error {
+	var errs []error
+	if a.AgentID == "" {
+		errs = append(errs, fmt.Errorf("agent_id is required"))
+	}
+	if a.StepID == "" {
+		errs = append(errs, fmt.Errorf("step_id is required"))
+	}
+	if a.Schema != "" {
+		if kind, _, ok := ParseSchemaURL(a.Schema); !ok || kind != SchemaAgentResult {
+			errs = append(errs, fmt.Errorf("$schema %q is not an agent result schema URL", a.Schema))
+		}
+	}
+	switch {
+	case a.Status == "":
+		errs = append(errs, fmt.Errorf("status is required"))
+	case !a.Status.valid():
+		errs = append(errs, fmt.Errorf("unknown status %q", a.Status))
+	case len(a.Tasks) > 0:
+		if computed := a.ComputeStatus(); computed != a.Status {
+			errs = append(errs, fmt.Errorf("status %s does not match %s computed from its tasks", a.Status, computed))
+		}
+	}
+	if a.ExecutedAt.IsZero() {
+		errs = append(errs, fmt.Errorf("executed_at is required"))
+	} else if a.ExecutedAt.After(time.Now().Add(maxClockSkew)) {
+		errs = append(errs, fmt.Errorf("executed_at %s is in the future", a.ExecutedAt.Format(time.RFC3339)))
+	}
+	if a.Duration != "" {
+		if d, err := time.ParseDuration(a.Duration); err != nil {
+			errs = append(errs, fmt.Errorf("duration %q is not a duration such as 1m30s", a.Duration))
+		} else if d < 0 {
+			errs = append(errs, fmt.Errorf("duration %s is negative", a.Duration))
+		}
+	}
+	errs = appendTaskErrors(errs, a.Tasks)
+	errs = appendArtifactErrors(errs, a.Artifacts)
+	errs = appendBlockErrors(errs, "content block", a.ContentBlocks)
+	return errors.Join(errs...)
+}
+
+func appendTaskErrors(errs []error, tasks []TaskResult) []error {
+	for i, task := range tasks {
+		switch {
+		case task.ID == "":
+			errs = append(errs, fmt.Errorf("task %d: id is required", i))
+		case task.Status == "":
+			errs = append(errs, fmt.Errorf("task %s: status is required", task.ID))
+		case !task.Status.valid():
+			errs = append(errs, fmt.Errorf("task %s: unknown status %q", task.ID, task.Status))
+		}
+	}
+	return errs
+}
+
+func appendArtifactErrors(errs []error, artifacts []Artifact) []error {
+	for i, a := range artifacts {
+		if a.Name == "" || a.Link() == "" {
+			errs = append(errs, fmt.Errorf("artifact %d: name and path or url are required", i))
+		}
+	}
+	return errs
+}
+
+func appendBlockErrors(errs []error, kind string, blocks []ContentBlock) []error {
+	for i, b := range blocks {
+		if err := b.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s %d: %w", kind, i, err))
+		}
+	}
+	return errs
+}
+
+// StatusOverride is a status set on a report or team that differs from the
+// one computed from its teams or tasks.
+type StatusOverride struct {
+	// Team is the team's ID, or empty for the report's overall status.
+	Team string
+
+	// Status is the status the report records.
+	Status Status
+
+	// Computed is the status its teams or tasks imply.
+	Computed Status
+}
+
+// String describes the override, e.g., "team security: status WARN
+// overrides NO-GO computed from its tasks".
+func (o StatusOverride) String() string {
+	if o.Team == "" {
+		return fmt.Sprintf("status %s overrides %s computed from its teams", o.Status, o.Computed)
+	}
+	return fmt.Sprintf("team %s: status %s overrides %s computed from its tasks", o.Team, o.Status, o.Computed)
+}
+
+// StatusOverrides returns the statuses that differ from the computed ones:
+// each team with tasks whose Status is not OverallStatus, then the report
+// if its Status is not ComputeOverallStatus. An override may be
+// deliberate, such as a domain verdict, or a sign of a stale report.
+func (r *TeamReport) StatusOverrides() []StatusOverride {
+	var out []StatusOverride
+	for i := range r.Teams {
+		t := &r.Teams[i]
+		if len(t.Tasks) == 0 || t.Status == "" {
+			continue
+		}
+		if computed := t.OverallStatus(); computed != t.Status {
+			out = append(out, StatusOverride{Team: t.ID, Status: t.Status, Computed: computed})
+		}
+	}
+	if r.Status != "" {
+		if computed := r.ComputeOverallStatus(); computed != r.Status {
+			out = append(out, StatusOverride{Status: r.Status, Computed: computed})
+		}
+	}
+	return out
+}
@@ -0,0 +1,138 @@
+package multiagentspec
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func validReport() *TeamReport {
+	return &TeamReport{
+		Project:     "app",
+		Version:     "v1.0.0",
+		Phase:       "REVIEW",
+		Status:      StatusWarn,
+		GeneratedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Teams: []TeamSection{
+			{ID: "qa", Name: "qa", Status: StatusGo, Tasks: []TaskResult{{ID: "unit", Status: StatusGo}}},
+			{ID: "security", Name: "security", Status: StatusWarn, DependsOn: []string{"qa"},
+				Tasks:         []TaskResult{{ID: "scan", Status: StatusWarn}},
+				ContentBlocks: []ContentBlock{NewTextBlock("NOTES", "One finding")}},
+		},
+	}
+}
+
+func TestTeamReportValidate(t *testing.T) {
+	if err := validReport().Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	report := validReport()
+	report.Phase = ""
+	report.GeneratedAt = time.Time{}
+	report.Teams[1].ID = "qa"
+	report.Teams[1].DependsOn = []string{"docs"}
+	report.Teams[1].Tasks = append(report.Teams[1].Tasks, TaskResult{ID: "lint", Status: "FAIL"})
+	report.Teams[1].ContentBlocks = append(report.Teams[1].ContentBlocks, ContentBlock{Type: "chart"})
+	report.SummaryBlocks = []ContentBlock{NewTextBlock("", "")}
+	report.Teams[1].Artifacts = []Artifact{{Name: "log"}}
+
+	err := report.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want errors")
+	}
+	for _, want := range []string{
+		"phase is required",
+		"generated_at is required",
+		"summary block 0: text block: content is required",
+		"team qa appears more than once",
+		"team qa: depends on unknown team docs",
+		`team qa: task lint: unknown status "FAIL"`,
+		`team qa: content block 1: unknown content block type "chart"`,
+		"team qa: artifact 0: name and path or url are required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() errors missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestStatusOverrides(t *testing.T) {
+	report := validReport()
+	if got := report.StatusOverrides(); len(got) != 0 {
+		t.Errorf("StatusOverrides() = %v, want none", got)
+	}
+
+	report.Teams[1].Tasks[0].Status = StatusNoGo // team still says WARN
+	got := report.StatusOverrides()
+	want := []string{
+		"team security: status WARN overrides NO-GO computed from its tasks",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("StatusOverrides() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].String() != want[i] {
+			t.Errorf("override %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	report.Status = StatusGo
+	got = report.StatusOverrides()
+	if len(got) != 2 || got[1].String() != "status GO overrides WARN computed from its teams" {
+		t.Errorf("StatusOverrides() = %v", got)
+	}
+}
+
+func TestAgentResultValidate(t *testing.T) {
+	valid := AgentResult{
+		Schema:     SchemaURL(SchemaAgentResult),
+		AgentID:    "qa",
+		StepID:     "qa-validation",
+		Tasks:      []TaskResult{{ID: "unit", Status: StatusGo}, {ID: "lint", Status: StatusWarn}},
+		Status:     StatusWarn,
+		ExecutedAt: time.Now().Add(-time.Minute),
+		Duration:   "1m2s",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	result := valid
+	result.Schema = SchemaURL(SchemaTeamReport)
+	result.AgentID = ""
+	result.Status = StatusGo
+	result.ExecutedAt = time.Now().Add(time.Hour)
+	result.Duration = "-5s"
+	result.Tasks = append(result.Tasks, TaskResult{Status: StatusGo})
+	result.Artifacts = []Artifact{{Name: "log"}}
+
+	err := result.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want errors")
+	}
+	for _, want := range []string{
+		"agent_id is required",
+		"is not an agent result schema URL",
+		"status GO does not match WARN computed from its tasks",
+		"is in the future",
+		"duration -5s is negative",
+		"task 2: id is required",
+		"artifact 0: name and path or url are required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() errors missing %q:\n%v", want, err)
+		}
+	}
+
+	for _, want := range []string{"step_id is required", "status is required", "executed_at is required"} {
+		if err := (&AgentResult{AgentID: "qa"}).Validate(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() of empty result = %v, want %q", err, want)
+		}
+	}
+	result = valid
+	result.Duration = "soon"
+	if err := result.Validate(); err == nil || !strings.Contains(err.Error(), `duration "soon"`) {
+		t.Errorf("Validate() = %v, want a duration error", err)
+	}
+}
@@ -0,0 +1,335 @@
+// Package reportdb keeps team reports in a SQLite database, normalized
+// into reports, teams, tasks, and tags tables, so their history can be
+// queried with SQL, such as each team's pass rate over time.
+//
+// The tables are:
+//
+//	reports(id, project, version, phase, status, generated_at, ingested_at, report)
+//	teams(report_id, position, team_id, name, status, allow_failure)
+//	tasks(report_id, team_position, position, task_id, status, severity,
+//	      duration_ms, tokens_in, tokens_out, cost_usd)
+//	tags(report_id, key, value)
+//
+// A report's id is the hex SHA-256 of its JSON encoding, as in package
+// reportstore, and report holds that JSON. Times are UTC text that sorts in
+// time order and that SQLite's date functions accept.
+//
+// Example:
+//
+//	db, err := reportdb.Open("reports.db")
+//	if err != nil {
+//	    return err
+//	}
+//	defer db.Close()
+//	id, err := db.Ingest(ctx, report)
+//	...
+//	points, err := db.Trend(ctx, reportdb.TrendQuery{Project: "my-app", Interval: reportdb.Week})
+package reportdb
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// timeFormat is fixed width so stored times sort as text.
+const timeFormat = "2006-01-02T15:04:05.000000000Z"
+
+const schema = `
+CREATE TABLE IF NOT EXISTS reports (
+	id           TEXT PRIMARY KEY,
+	project      TEXT NOT NULL,
+	version      TEXT NOT NULL,
+	phase        TEXT NOT NULL,
+	status       TEXT NOT NULL,
+	generated_at TEXT NOT NULL,
+	ingested_at  TEXT NOT NULL,
+	report       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS reports_project ON reports (project, generated_at);
+
+CREATE TABLE IF NOT EXISTS teams (
+	report_id     TEXT NOT NULL REFERENCES reports (id) ON DELETE CASCADE,
+	position      INTEGER NOT NULL,
+	team_id       TEXT NOT NULL,
+	name          TEXT NOT NULL,
+	status        TEXT NOT NULL,
+	allow_failure INTEGER NOT NULL,
+	PRIMARY KEY (report_id, position)
+);
+CREATE INDEX IF NOT EXISTS teams_team ON teams (team_id);
+
+CREATE TABLE IF NOT EXISTS tasks (
+	report_id     TEXT NOT NULL,
+	team_position INTEGER NOT NULL,
+	position      INTEGER NOT NULL,
+	task_id       TEXT NOT NULL,
+	status        TEXT NOT NULL,
+	severity      TEXT NOT NULL,
+	duration_ms   INTEGER NOT NULL,
+	tokens_in     INTEGER NOT NULL,
+	tokens_out    INTEGER NOT NULL,
+	cost_usd      REAL NOT NULL,
+	PRIMARY KEY (report_id, team_position, position),
+	FOREIGN KEY (report_id, team_position) REFERENCES teams (report_id, position) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+	report_id TEXT NOT NULL REFERENCES reports (id) ON DELETE CASCADE,
+	key       TEXT NOT NULL,
+	value     TEXT NOT NULL,
+	PRIMARY KEY (report_id, key)
+);
+CREATE INDEX IF NOT EXISTS tags_key ON tags (key, value);
+`
+
+// DB is a report database. It is safe for concurrent use.
+type DB struct {
+	db *sql.DB
+}
+
+// Open opens the SQLite database at path, creating it and its tables if
+// needed.
+func Open(path string) (*DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create tables in %s: %w", path, err)
+	}
+	return &DB{db: db}, nil
+}
+
+// Close closes the database.
+func (d *DB) Close() error {
+	return d.db.Close()
+}
+
+// SQL returns the underlying database for queries beyond Trend.
+func (d *DB) SQL() *sql.DB {
+	return d.db
+}
+
+// Ingest stores report and returns its ID. Ingesting a report already in
+// the database does nothing.
+func (d *DB) Ingest(ctx context.Context, report *multiagentspec.TeamReport) (string, error) {
+	data, err := report.ToJSON()
+	if err != nil {
+		return "", fmt.Errorf("encode report: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	id := hex.EncodeToString(sum[:])
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+	status := report.Status
+	if status == "" {
+		status = report.ComputeOverallStatus()
+	}
+	res, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO reports VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		id, report.Project, report.Version, report.Phase, status,
+		report.GeneratedAt.UTC().Format(timeFormat), time.Now().UTC().Format(timeFormat), string(data))
+	if err != nil {
+		return "", fmt.Errorf("insert report: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return id, nil
+	}
+	for i, t := range report.Teams {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO teams VALUES (?, ?, ?, ?, ?, ?)`,
+			id, i, t.ID, t.Name, t.Status, t.AllowFailure); err != nil {
+			return "", fmt.Errorf("insert team %s: %w", t.ID, err)
+		}
+		for j, task := range t.Tasks {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO tasks VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				id, i, j, task.ID, task.Status, task.Severity, task.DurationMs, task.TokensIn, task.TokensOut, task.CostUSD); err != nil {
+				return "", fmt.Errorf("insert team %s task %s: %w", t.ID, task.ID, err)
+			}
+		}
+	}
+	for k, v := range report.Tags {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tags VALUES (?, ?, ?)`, id, k, v); err != nil {
+			return "", fmt.Errorf("insert tag %s: %w", k, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Interval is the period a trend groups runs by.
+type Interval string
+
+const (
+	// Run gives every report its own point, labeled with its generation time.
+	Run Interval = ""
+
+	// Day, Week, and Month group reports by UTC calendar period. Weeks
+	// start on Monday and are labeled with it.
+	Day   Interval = "day"
+	Week  Interval = "week"
+	Month Interval = "month"
+)
+
+// periodExpr returns the SQL expression labeling a report's period.
+func (i Interval) periodExpr() (string, error) {
+	switch i {
+	case Run:
+		return "strftime('%Y-%m-%dT%H:%M:%SZ', r.generated_at)", nil
+	case Day:
+		return "strftime('%Y-%m-%d', r.generated_at)", nil
+	case Week:
+		return "date(r.generated_at, 'weekday 0', '-6 days')", nil
+	case Month:
+		return "strftime('%Y-%m', r.generated_at)", nil
+	}
+	return "", fmt.Errorf("unknown interval %q (want day, week, or month)", string(i))
+}
+
+// TrendQuery selects the team runs a trend covers. Zero fields match
+// every run.
+type TrendQuery struct {
+	Project string
+
+	// Team is a team ID.
+	Team string
+
+	// Tags must all be set on the report to these values.
+	Tags map[string]string
+
+	// Since and Until bound the report's generation time: Since inclusive,
+	// Until exclusive.
+	Since, Until time.Time
+
+	Interval Interval
+}
+
+// TrendPoint summarizes one team's runs in one period.
+type TrendPoint struct {
+	// Team is the team ID.
+	Team string `json:"team"`
+
+	// Period labels the interval, e.g., "2026-01-05" for a day or week, or
+	// "2026-01" for a month.
+	Period string `json:"period"`
+
+	// Statuses counts the team's runs by status.
+	Statuses map[multiagentspec.Status]int `json:"statuses"`
+}
+
+// Runs is the number of runs that were not skipped.
+func (p TrendPoint) Runs() int {
+	n := 0
+	for s, c := range p.Statuses {
+		if s != multiagentspec.StatusSkip {
+			n += c
+		}
+	}
+	return n
+}
+
+// Passed is the number of GO and WARN runs.
+func (p TrendPoint) Passed() int {
+	return p.Statuses[multiagentspec.StatusGo] + p.Statuses[multiagentspec.StatusWarn]
+}
+
+// PassRate is Passed over Runs, or zero without runs.
+func (p TrendPoint) PassRate() float64 {
+	if p.Runs() == 0 {
+		return 0
+	}
+	return float64(p.Passed()) / float64(p.Runs())
+}
+
+// Trend returns each team's runs per period, ordered by period and then
+// team.
+func (d *DB) Trend(ctx context.Context, q TrendQuery) ([]TrendPoint, error) {
+	period, err := q.Interval.periodExpr()
+	if err != nil {
+		return nil, err
+	}
+	var where []string
+	var args []any
+	if q.Project != "" {
+		where = append(where, "r.project = ?")
+		args = append(args, q.Project)
+	}
+	if q.Team != "" {
+		where = append(where, "t.team_id = ?")
+		args = append(args, q.Team)
+	}
+	if !q.Since.IsZero() {
+		where = append(where, "r.generated_at >= ?")
+		args = append(args, q.Since.UTC().Format(timeFormat))
+	}
+	if !q.Until.IsZero() {
+		where = append(where, "r.generated_at < ?")
+		args = append(args, q.Until.UTC().Format(timeFormat))
+	}
+	keys := make([]string, 0, len(q.Tags))
+	for k := range q.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		where = append(where, "EXISTS (SELECT 1 FROM tags g WHERE g.report_id = r.id AND g.key = ? AND g.value = ?)")
+		args = append(args, k, q.Tags[k])
+	}
+	stmt := "SELECT t.team_id, " + period + " AS period, t.status, COUNT(*) FROM teams t JOIN reports r ON r.id = t.report_id"
+	if len(where) > 0 {
+		stmt += " WHERE " + strings.Join(where, " AND ")
+	}
+	stmt += " GROUP BY t.team_id, period, t.status ORDER BY period, t.team_id"
+
+	rows, err := d.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query trend: %w", err)
+	}
+	defer rows.Close()
+	var out []TrendPoint
+	for rows.Next() {
+		var team, label string
+		var status multiagentspec.Status
+		var n int
+		if err := rows.Scan(&team, &label, &status, &n); err != nil {
+			return nil, fmt.Errorf("query trend: %w", err)
+		}
+		if last := len(out) - 1; last < 0 || out[last].Team != team || out[last].Period != label {
+			out = append(out, TrendPoint{Team: team, Period: label, Statuses: map[multiagentspec.Status]int{}})
+		}
+		out[len(out)-1].Statuses[status] += n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query trend: %w", err)
+	}
+	return out, nil
+}
+
+// TrendBlock returns points as a table content block with a row per
+// point, for a report's summary or footer blocks.
+func TrendBlock(title string, points []TrendPoint) multiagentspec.ContentBlock {
+	rows := make([][]string, 0, len(points))
+	for _, p := range points {
+		rate := "-"
+		if p.Runs() > 0 {
+			rate = fmt.Sprintf("%.0f%%", 100*p.PassRate())
+		}
+		rows = append(rows, []string{p.Team, p.Period, fmt.Sprint(p.Runs()), rate})
+	}
+	return multiagentspec.NewTableBlock(title, []string{"Team", "Period", "Runs", "Pass rate"}, rows)
+}
@@ -0,0 +1,118 @@
+package reportdb
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func testReport(day int, env string, qa, security multiagentspec.Status) *multiagentspec.TeamReport {
+	return &multiagentspec.TeamReport{
+		Project:     "app",
+		Version:     "v1.0.0",
+		Phase:       "REVIEW",
+		Tags:        map[string]string{"environment": env},
+		GeneratedAt: time.Date(2026, 1, day, 12, 0, 0, 0, time.UTC),
+		Teams: []multiagentspec.TeamSection{
+			{ID: "qa", Name: "QA", Status: qa, Tasks: []multiagentspec.TaskResult{{ID: "unit", Status: qa, DurationMs: 1200}}},
+			{ID: "security", Name: "Security", Status: security},
+		},
+	}
+}
+
+func openTest(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(filepath.Join(t.TempDir(), "reports.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestIngest(t *testing.T) {
+	ctx := context.Background()
+	db := openTest(t)
+	report := testReport(5, "prod", multiagentspec.StatusGo, multiagentspec.StatusWarn)
+	id, err := db.Ingest(ctx, report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := db.Ingest(ctx, testReport(5, "prod", multiagentspec.StatusGo, multiagentspec.StatusWarn))
+	if err != nil || again != id {
+		t.Errorf("Ingest() again = %s, %v; want %s", again, err, id)
+	}
+
+	var reports, teams, tasks int
+	var status, env string
+	row := db.SQL().QueryRowContext(ctx, `SELECT
+		(SELECT COUNT(*) FROM reports), (SELECT COUNT(*) FROM teams), (SELECT COUNT(*) FROM tasks),
+		(SELECT status FROM reports), (SELECT value FROM tags WHERE key = 'environment')`)
+	if err := row.Scan(&reports, &teams, &tasks, &status, &env); err != nil {
+		t.Fatal(err)
+	}
+	if reports != 1 || teams != 2 || tasks != 1 || status != "WARN" || env != "prod" {
+		t.Errorf("rows = %d reports, %d teams, %d tasks, status %s, environment %s", reports, teams, tasks, status, env)
+	}
+}
+
+func TestTrend(t *testing.T) {
+	ctx := context.Background()
+	db := openTest(t)
+	pass, warn, fail, skip := multiagentspec.StatusGo, multiagentspec.StatusWarn, multiagentspec.StatusNoGo, multiagentspec.StatusSkip
+	for _, r := range []*multiagentspec.TeamReport{
+		testReport(4, "prod", pass, skip), // Sunday, in the week of Dec 29
+		testReport(5, "prod", fail, pass),
+		testReport(6, "staging", pass, pass),
+		testReport(7, "prod", warn, pass),
+		testReport(14, "prod", pass, fail),
+	} {
+		if _, err := db.Ingest(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	points, err := db.Trend(ctx, TrendQuery{Team: "qa", Tags: map[string]string{"environment": "prod"}, Interval: Week})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TrendPoint{
+		{Team: "qa", Period: "2025-12-29", Statuses: map[multiagentspec.Status]int{pass: 1}},
+		{Team: "qa", Period: "2026-01-05", Statuses: map[multiagentspec.Status]int{fail: 1, warn: 1}},
+		{Team: "qa", Period: "2026-01-12", Statuses: map[multiagentspec.Status]int{pass: 1}},
+	}
+	if !reflect.DeepEqual(points, want) {
+		t.Errorf("Trend() = %+v\nwant %+v", points, want)
+	}
+	if rate := points[1].PassRate(); rate != 0.5 {
+		t.Errorf("PassRate() = %v, want 0.5", rate)
+	}
+
+	points, err = db.Trend(ctx, TrendQuery{Interval: Month, Since: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 || points[0].Team != "qa" || points[0].Runs() != 4 || points[1].Team != "security" || points[1].Passed() != 3 {
+		t.Errorf("Trend(month) = %+v", points)
+	}
+
+	points, err = db.Trend(ctx, TrendQuery{Team: "security", Until: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 1 || points[0].Period != "2026-01-04T12:00:00Z" || points[0].Runs() != 0 {
+		t.Errorf("Trend(run) = %+v", points)
+	}
+	block := TrendBlock("Security", points)
+	if err := block.Validate(); err != nil || block.Rows[0][3] != "-" {
+		t.Errorf("TrendBlock() = %+v, %v", block, err)
+	}
+
+	if _, err := db.Trend(ctx, TrendQuery{Interval: "year"}); err == nil {
+		t.Error("Trend() with an unknown interval succeeded")
+	}
+}
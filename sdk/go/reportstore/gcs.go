@@ -0,0 +1,200 @@
+package reportstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// metadataTokenURL serves access tokens for the default service account
+// on Google Cloud compute.
+const metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// GCSConfig configures Cloud Storage.
+type GCSConfig struct {
+	Bucket string
+
+	// Prefix is prepended to every key, like a directory.
+	Prefix string
+
+	// Endpoint defaults to STORAGE_EMULATOR_HOST, or else to
+	// https://storage.googleapis.com.
+	Endpoint string
+
+	// Token returns an OAuth 2.0 access token. It defaults to
+	// GOOGLE_OAUTH_ACCESS_TOKEN, as printed by gcloud auth
+	// print-access-token, or else to the compute metadata server's token
+	// for the default service account.
+	Token func(ctx context.Context) (string, error)
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// GCS is Storage in a Cloud Storage bucket, using the JSON API.
+type GCS struct {
+	cfg GCSConfig
+}
+
+// NewGCS returns Cloud Storage for cfg.
+func NewGCS(cfg GCSConfig) *GCS {
+	cfg.Endpoint = firstNonEmpty(cfg.Endpoint, os.Getenv("STORAGE_EMULATOR_HOST"), "https://storage.googleapis.com")
+	if !strings.Contains(cfg.Endpoint, "://") {
+		cfg.Endpoint = "http://" + cfg.Endpoint
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Token == nil {
+		if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+			cfg.Token = func(context.Context) (string, error) { return token, nil }
+		} else {
+			cfg.Token = (&metadataToken{client: cfg.HTTPClient}).get
+		}
+	}
+	return &GCS{cfg: cfg}
+}
+
+// Put uploads data to key.
+func (g *GCS) Put(ctx context.Context, key string, data []byte) error {
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		g.cfg.Endpoint, url.PathEscape(g.cfg.Bucket), url.QueryEscape(joinKey(g.cfg.Prefix, key)))
+	if _, err := g.do(ctx, http.MethodPost, u, data); err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get downloads key.
+func (g *GCS) Get(ctx context.Context, key string) ([]byte, error) {
+	u := fmt.Sprintf("%s/storage/v1/b/%s/o/%s?alt=media",
+		g.cfg.Endpoint, url.PathEscape(g.cfg.Bucket), url.PathEscape(joinKey(g.cfg.Prefix, key)))
+	data, err := g.do(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// List returns the objects whose keys start with prefix, sorted by key.
+func (g *GCS) List(ctx context.Context, prefix string) ([]Object, error) {
+	q := url.Values{"prefix": {joinKey(g.cfg.Prefix, prefix)}, "fields": {"items(name,updated),nextPageToken"}}
+	var out []Object
+	for {
+		u := fmt.Sprintf("%s/storage/v1/b/%s/o?%s", g.cfg.Endpoint, url.PathEscape(g.cfg.Bucket), q.Encode())
+		data, err := g.do(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+		var page struct {
+			Items []struct {
+				Name    string    `json:"name"`
+				Updated time.Time `json:"updated"`
+			} `json:"items"`
+			NextPageToken string `json:"nextPageToken"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("list %s: parse response: %w", prefix, err)
+		}
+		for _, item := range page.Items {
+			out = append(out, Object{Key: strings.TrimPrefix(item.Name, joinKey(g.cfg.Prefix, "")), ModTime: item.Updated.UTC()})
+		}
+		if page.NextPageToken == "" {
+			return out, nil
+		}
+		q.Set("pageToken", page.NextPageToken)
+	}
+}
+
+// do sends an authorized request and returns the response body.
+func (g *GCS) do(ctx context.Context, method, u string, body []byte) ([]byte, error) {
+	token, err := g.cfg.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get access token: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := g.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return data, nil
+	}
+	var e struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(data, &e)
+	err = fmt.Errorf("gcs: %s", resp.Status)
+	if e.Error.Message != "" {
+		err = fmt.Errorf("gcs: %s: %s", resp.Status, e.Error.Message)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		// A missing bucket is also not found, so the message is kept
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	return nil, err
+}
+
+// metadataToken fetches and caches the metadata server's access token.
+type metadataToken struct {
+	client *http.Client
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+func (m *metadataToken) get(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.token != "" && time.Now().Before(m.expires) {
+		return m.token, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("no Google credentials: set GOOGLE_OAUTH_ACCESS_TOKEN or run on Google Cloud: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata server: %s", resp.Status)
+	}
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("metadata server: %w", err)
+	}
+	m.token = tok.AccessToken
+	// Refresh a minute early so a token never expires in flight.
+	m.expires = time.Now().Add(time.Duration(tok.ExpiresIn)*time.Second - time.Minute)
+	return m.token, nil
+}
@@ -0,0 +1,126 @@
+package reportstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// fakeGCS serves one bucket of the JSON API from memory, listing one
+// object per page to exercise page tokens.
+type fakeGCS struct {
+	t      *testing.T
+	bucket string
+
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+		f.t.Errorf("%s %s: Authorization = %q", r.Method, r.URL, got)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	q := r.URL.Query()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload/storage/v1/b/"+f.bucket+"/o":
+		body, _ := io.ReadAll(r.Body)
+		f.objects[q.Get("name")] = body
+		io.WriteString(w, "{}")
+	case r.Method == http.MethodGet && r.URL.Path == "/storage/v1/b/"+f.bucket+"/o":
+		var names []string
+		for name := range f.objects {
+			if strings.HasPrefix(name, q.Get("prefix")) && name > q.Get("pageToken") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		page := map[string]any{}
+		if len(names) > 0 {
+			page["items"] = []map[string]any{{"name": names[0], "updated": time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}}
+			if len(names) > 1 {
+				page["nextPageToken"] = names[0]
+			}
+		}
+		json.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/storage/v1/b/"+f.bucket+"/o/") && q.Get("alt") == "media":
+		data, ok := f.objects[strings.TrimPrefix(r.URL.Path, "/storage/v1/b/"+f.bucket+"/o/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error": {"code": 404, "message": "No such object"}}`)
+			return
+		}
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error": {"code": 404, "message": "The specified bucket does not exist."}}`)
+	}
+}
+
+func TestGCSStore(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeGCS{t: t, bucket: "ci", objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	storage := NewGCS(GCSConfig{
+		Bucket:   "ci",
+		Prefix:   "mas",
+		Endpoint: srv.URL,
+		Token:    func(context.Context) (string, error) { return "tok", nil },
+	})
+
+	store, err := New(ctx, storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for day := 1; day <= 3; day++ {
+		e, err := store.Save(ctx, testReport("v1.0.0", "prod", day, multiagentspec.StatusGo))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, e.ID)
+	}
+	if _, ok := fake.objects["mas/reports/"+ids[0]+".json"]; !ok {
+		t.Errorf("report %s not stored under mas/reports/", ids[0])
+	}
+
+	delete(fake.objects, "mas/index.json")
+	rebuilt, err := New(ctx, storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := rebuilt.List()
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("rebuilt index = %+v, %v", entries, err)
+	}
+	if !entries[0].SavedAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Errorf("SavedAt = %v, want the object's update time", entries[0].SavedAt)
+	}
+	if got, err := rebuilt.Get(ctx, ids[2]); err != nil || got.GeneratedAt.Day() != 3 {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+
+	if _, err := storage.Get(ctx, "missing.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+	failing := NewGCS(GCSConfig{
+		Bucket:   "ci",
+		Endpoint: srv.URL,
+		Token:    func(context.Context) (string, error) { return "", errors.New("no credentials") },
+	})
+	if _, err := failing.Get(ctx, "index.json"); err == nil || !strings.Contains(err.Error(), "no credentials") {
+		t.Errorf("Get() without a token error = %v", err)
+	}
+}
@@ -0,0 +1,328 @@
+// Package reportstore keeps team reports with an index by project,
+// version, tags, and date, so past runs can be listed, fetched, and
+// queried without parsing every report. A store lives in a Storage: a
+// local directory, an S3 bucket, or a Cloud Storage bucket.
+//
+// Reports are stored by content: a report's ID is the SHA-256 of its JSON
+// encoding, and it is written to reports/<id>.json, so saving the same
+// report twice stores it once. The index, index.json, holds one Entry per
+// report and is rebuilt from reports/ if it is missing.
+//
+// Example:
+//
+//	store, err := reportstore.OpenURL(ctx, "s3://ci-artifacts/mas")
+//	if err != nil {
+//	    return err
+//	}
+//	entry, err := store.Save(ctx, report)
+//	...
+//	q, _ := multiagentspec.ParseTagQuery("environment=prod")
+//	entries, err := store.Query(reportstore.Query{Project: "my-app", Tags: q})
+package reportstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// ErrNotFound is returned by Get for an ID that matches no stored report.
+var ErrNotFound = errors.New("report not found")
+
+const (
+	indexFile  = "index.json"
+	reportsDir = "reports"
+)
+
+// Entry is the index record of a stored report.
+type Entry struct {
+	// ID is the hex SHA-256 of the report's JSON encoding.
+	ID string `json:"id"`
+
+	Project string                `json:"project"`
+	Version string                `json:"version"`
+	Phase   string                `json:"phase,omitempty"`
+	Status  multiagentspec.Status `json:"status"`
+
+	// Tags are the report's tags.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Teams counts the report's teams by status.
+	Teams map[multiagentspec.Status]int `json:"teams,omitempty"`
+
+	// GeneratedAt is when the report was generated.
+	GeneratedAt time.Time `json:"generated_at"`
+
+	// SavedAt is when the report was first saved to the store.
+	SavedAt time.Time `json:"saved_at"`
+}
+
+// Query selects index entries. Zero fields match every entry.
+type Query struct {
+	Project string
+	Version string
+
+	// Tags, if set, must match the report's tags.
+	Tags *multiagentspec.TagQuery
+
+	// Since and Until bound GeneratedAt: Since inclusive, Until exclusive.
+	Since, Until time.Time
+
+	// Limit keeps only the newest Limit entries when positive.
+	Limit int
+}
+
+// Matches reports whether e satisfies the query, ignoring Limit.
+func (q Query) Matches(e Entry) bool {
+	switch {
+	case q.Project != "" && e.Project != q.Project,
+		q.Version != "" && e.Version != q.Version,
+		q.Tags != nil && !q.Tags.Matches(e.Tags),
+		!q.Since.IsZero() && e.GeneratedAt.Before(q.Since),
+		!q.Until.IsZero() && !e.GeneratedAt.Before(q.Until):
+		return false
+	}
+	return true
+}
+
+// Store is a set of reports and their index in a Storage. It is safe for
+// concurrent use within a process. Save rereads the index before updating
+// it, but separate processes saving at once may still lose index updates,
+// which Reindex repairs.
+type Store struct {
+	storage  Storage
+	location string
+
+	mu    sync.Mutex
+	index []Entry
+}
+
+// Open opens the store in directory dir, creating it if needed.
+func Open(dir string) (*Store, error) {
+	s, err := New(context.Background(), Dir(dir))
+	if err != nil {
+		return nil, fmt.Errorf("open store %s: %w", dir, err)
+	}
+	s.location = dir
+	return s, nil
+}
+
+// New opens the store in storage and loads its index, rebuilding it if it
+// is missing.
+func New(ctx context.Context, storage Storage) (*Store, error) {
+	s := &Store{storage: storage}
+	index, err := s.readIndex(ctx)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if err := s.Reindex(ctx); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	default:
+		s.index = index
+	}
+	return s, nil
+}
+
+// Location returns the directory or URL the store was opened from, or
+// empty if it was opened with New.
+func (s *Store) Location() string {
+	return s.location
+}
+
+// Save stores report and returns its index entry. Saving a report already
+// in the store returns the existing entry.
+func (s *Store) Save(ctx context.Context, report *multiagentspec.TeamReport) (Entry, error) {
+	data, err := report.ToJSON()
+	if err != nil {
+		return Entry{}, fmt.Errorf("encode report: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	id := hex.EncodeToString(sum[:])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Pick up reports saved by other processes since the store was opened
+	if index, err := s.readIndex(ctx); err == nil {
+		s.index = index
+	} else if !errors.Is(err, ErrNotFound) {
+		return Entry{}, err
+	}
+	for _, e := range s.index {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	if err := s.storage.Put(ctx, reportKey(id), data); err != nil {
+		return Entry{}, fmt.Errorf("write report: %w", err)
+	}
+	e := newEntry(id, report, time.Now().UTC())
+	s.index = append(s.index, e)
+	if err := s.writeIndex(ctx); err != nil {
+		return Entry{}, err
+	}
+	return e, nil
+}
+
+// Get returns the report with id, which may be a unique prefix of at least
+// four characters.
+func (s *Store) Get(ctx context.Context, id string) (*multiagentspec.TeamReport, error) {
+	e, err := s.Lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.storage.Get(ctx, reportKey(e.ID))
+	if err != nil {
+		return nil, fmt.Errorf("read report %s: %w", e.ID, err)
+	}
+	return multiagentspec.ParseTeamReport(data)
+}
+
+// Lookup returns the index entry for id, which may be a unique prefix of
+// at least four characters.
+func (s *Store) Lookup(id string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(id) < 4 {
+		return Entry{}, fmt.Errorf("report id %q: want at least 4 characters", id)
+	}
+	var found []Entry
+	for _, e := range s.index {
+		if strings.HasPrefix(e.ID, id) {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Entry{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	case 1:
+		return found[0], nil
+	}
+	return Entry{}, fmt.Errorf("report id %s is ambiguous: %d reports match", id, len(found))
+}
+
+// List returns every entry, oldest generated first.
+func (s *Store) List() ([]Entry, error) {
+	return s.Query(Query{})
+}
+
+// Query returns the entries matching q, oldest generated first, ties
+// broken by ID.
+func (s *Store) Query(q Query) ([]Entry, error) {
+	s.mu.Lock()
+	var out []Entry
+	for _, e := range s.index {
+		if q.Matches(e) {
+			out = append(out, e)
+		}
+	}
+	s.mu.Unlock()
+	sortEntries(out)
+	if q.Limit > 0 && len(out) > q.Limit {
+		out = out[len(out)-q.Limit:]
+	}
+	return out, nil
+}
+
+// Reindex rebuilds the index from the stored reports, keeping the SavedAt
+// of entries already indexed.
+func (s *Store) Reindex(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved := make(map[string]time.Time, len(s.index))
+	for _, e := range s.index {
+		saved[e.ID] = e.SavedAt
+	}
+	objects, err := s.storage.List(ctx, reportsDir+"/")
+	if err != nil {
+		return fmt.Errorf("list reports: %w", err)
+	}
+	index := []Entry{}
+	for _, obj := range objects {
+		id, ok := strings.CutSuffix(strings.TrimPrefix(obj.Key, reportsDir+"/"), ".json")
+		if !ok || strings.Contains(id, "/") {
+			continue
+		}
+		data, err := s.storage.Get(ctx, obj.Key)
+		if err != nil {
+			return fmt.Errorf("read report %s: %w", id, err)
+		}
+		report, err := multiagentspec.ParseTeamReport(data)
+		if err != nil {
+			return fmt.Errorf("parse report %s: %w", id, err)
+		}
+		savedAt, ok := saved[id]
+		if !ok {
+			savedAt = obj.ModTime
+		}
+		index = append(index, newEntry(id, report, savedAt))
+	}
+	sortEntries(index)
+	s.index = index
+	return s.writeIndex(ctx)
+}
+
+func newEntry(id string, report *multiagentspec.TeamReport, savedAt time.Time) Entry {
+	status := report.Status
+	if status == "" {
+		status = report.ComputeOverallStatus()
+	}
+	return Entry{
+		ID:          id,
+		Project:     report.Project,
+		Version:     report.Version,
+		Phase:       report.Phase,
+		Status:      status,
+		Tags:        report.Tags,
+		Teams:       report.TeamStatusCounts(),
+		GeneratedAt: report.GeneratedAt,
+		SavedAt:     savedAt,
+	}
+}
+
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].GeneratedAt.Equal(entries[j].GeneratedAt) {
+			return entries[i].GeneratedAt.Before(entries[j].GeneratedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+}
+
+func reportKey(id string) string {
+	return reportsDir + "/" + id + ".json"
+}
+
+func (s *Store) readIndex(ctx context.Context) ([]Entry, error) {
+	data, err := s.storage.Get(ctx, indexFile)
+	if err != nil {
+		return nil, fmt.Errorf("read index: %w", err)
+	}
+	var index []Entry
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("parse index: %w", err)
+	}
+	return index, nil
+}
+
+// writeIndex writes the index; the caller holds s.mu.
+func (s *Store) writeIndex(ctx context.Context) error {
+	data, err := json.MarshalIndent(s.index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode index: %w", err)
+	}
+	if err := s.storage.Put(ctx, indexFile, append(data, '\n')); err != nil {
+		return fmt.Errorf("write index: %w", err)
+	}
+	return nil
+}
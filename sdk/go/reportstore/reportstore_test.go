@@ -0,0 +1,149 @@
+package reportstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func testReport(version, env string, day int, status multiagentspec.Status) *multiagentspec.TeamReport {
+	return &multiagentspec.TeamReport{
+		Project:     "app",
+		Version:     version,
+		Phase:       "REVIEW",
+		Status:      status,
+		Tags:        map[string]string{"environment": env},
+		GeneratedAt: time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC),
+		Teams:       []multiagentspec.TeamSection{{ID: "qa", Name: "qa", Status: status}},
+	}
+}
+
+func TestSaveAndGet(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := testReport("v1.0.0", "prod", 2, multiagentspec.StatusGo)
+	e, err := store.Save(ctx, report)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if len(e.ID) != 64 || e.Project != "app" || e.Status != multiagentspec.StatusGo || e.Teams[multiagentspec.StatusGo] != 1 || e.SavedAt.IsZero() {
+		t.Errorf("entry = %+v", e)
+	}
+	again, err := store.Save(ctx, testReport("v1.0.0", "prod", 2, multiagentspec.StatusGo))
+	if err != nil || again.ID != e.ID || !again.SavedAt.Equal(e.SavedAt) {
+		t.Errorf("saving the same report again = %+v, %v; want the existing entry", again, err)
+	}
+	if entries, _ := store.List(); len(entries) != 1 {
+		t.Errorf("List() has %d entries, want 1", len(entries))
+	}
+
+	got, err := store.Get(ctx, e.ID[:8])
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Version != "v1.0.0" || len(got.Teams) != 1 || got.Tags["environment"] != "prod" {
+		t.Errorf("Get() = %+v", got)
+	}
+	if _, err := store.Get(ctx, "ffffffff"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(unknown) = %v, want ErrNotFound", err)
+	}
+	if _, err := store.Get(ctx, "ab"); err == nil {
+		t.Error("Get() accepted a 2-character ID")
+	}
+}
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*multiagentspec.TeamReport{
+		testReport("v1.1.0", "prod", 3, multiagentspec.StatusNoGo),
+		testReport("v1.0.0", "prod", 1, multiagentspec.StatusGo),
+		testReport("v1.0.0", "staging", 2, multiagentspec.StatusWarn),
+	} {
+		if _, err := store.Save(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prod, err := multiagentspec.ParseTagQuery("environment=prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		q    Query
+		want []string // versions, oldest first
+	}{
+		{"all", Query{}, []string{"v1.0.0", "v1.0.0", "v1.1.0"}},
+		{"tags", Query{Tags: prod}, []string{"v1.0.0", "v1.1.0"}},
+		{"version", Query{Version: "v1.0.0"}, []string{"v1.0.0", "v1.0.0"}},
+		{"since", Query{Since: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}, []string{"v1.0.0", "v1.1.0"}},
+		{"until", Query{Until: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}, []string{"v1.0.0"}},
+		{"limit", Query{Limit: 1}, []string{"v1.1.0"}},
+		{"project", Query{Project: "other"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := store.Query(tt.q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Version)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("versions = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("versions = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestReopenAndReindex(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := store.Save(ctx, testReport("v1.0.0", "prod", 1, multiagentspec.StatusGo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := reopened.Lookup(e.ID); err != nil || !got.SavedAt.Equal(e.SavedAt) {
+		t.Errorf("Lookup() after reopening = %+v, %v", got, err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, indexFile)); err != nil {
+		t.Fatal(err)
+	}
+	rebuilt, err := Open(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := rebuilt.List()
+	if err != nil || len(entries) != 1 || entries[0].ID != e.ID || entries[0].Version != "v1.0.0" {
+		t.Errorf("rebuilt index = %+v, %v", entries, err)
+	}
+}
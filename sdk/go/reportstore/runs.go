@@ -0,0 +1,136 @@
+package reportstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// runsDir holds each workflow run's approvals and step results, as
+// runs/<run>/approvals/<gate>.json and runs/<run>/results.json, apart from
+// the content-addressed reports.
+const runsDir = "runs"
+
+// GetApproval returns the approval for gate in run. It implements
+// multiagentspec.ApprovalStore; the error wraps
+// multiagentspec.ErrApprovalNotFound for a gate not yet reached.
+func (s *Store) GetApproval(ctx context.Context, run, gate string) (*multiagentspec.Approval, error) {
+	key, err := approvalKey(run, gate)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.storage.Get(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: run %s gate %s", multiagentspec.ErrApprovalNotFound, run, gate)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read approval: %w", err)
+	}
+	var a multiagentspec.Approval
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("parse approval %s/%s: %w", run, gate, err)
+	}
+	return &a, nil
+}
+
+// PutApproval saves a, replacing the approval for its run and gate. It
+// implements multiagentspec.ApprovalStore.
+func (s *Store) PutApproval(ctx context.Context, a *multiagentspec.Approval) error {
+	key, err := approvalKey(a.Run, a.Gate)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode approval: %w", err)
+	}
+	if err := s.storage.Put(ctx, key, append(data, '\n')); err != nil {
+		return fmt.Errorf("write approval: %w", err)
+	}
+	return nil
+}
+
+// Approvals returns the approvals of run, sorted by gate.
+func (s *Store) Approvals(ctx context.Context, run string) ([]multiagentspec.Approval, error) {
+	if err := checkRunName("run", run); err != nil {
+		return nil, err
+	}
+	prefix := runsDir + "/" + run + "/approvals/"
+	objects, err := s.storage.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("list approvals: %w", err)
+	}
+	var out []multiagentspec.Approval
+	for _, obj := range objects {
+		gate, ok := strings.CutSuffix(strings.TrimPrefix(obj.Key, prefix), ".json")
+		if !ok || strings.Contains(gate, "/") {
+			continue
+		}
+		a, err := s.GetApproval(ctx, run, gate)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *a)
+	}
+	return out, nil
+}
+
+// SaveRunResults saves the step results of run, replacing any saved
+// before, so the run can be resumed with multiagentspec.WithPriorResults.
+func (s *Store) SaveRunResults(ctx context.Context, run string, results []multiagentspec.AgentResult) error {
+	if err := checkRunName("run", run); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode results: %w", err)
+	}
+	if err := s.storage.Put(ctx, resultsKey(run), append(data, '\n')); err != nil {
+		return fmt.Errorf("write results: %w", err)
+	}
+	return nil
+}
+
+// RunResults returns the step results saved for run, or an error wrapping
+// ErrNotFound if none were.
+func (s *Store) RunResults(ctx context.Context, run string) ([]multiagentspec.AgentResult, error) {
+	if err := checkRunName("run", run); err != nil {
+		return nil, err
+	}
+	data, err := s.storage.Get(ctx, resultsKey(run))
+	if err != nil {
+		return nil, fmt.Errorf("read results of run %s: %w", run, err)
+	}
+	var results []multiagentspec.AgentResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("parse results of run %s: %w", run, err)
+	}
+	return results, nil
+}
+
+func approvalKey(run, gate string) (string, error) {
+	if err := checkRunName("run", run); err != nil {
+		return "", err
+	}
+	if err := checkRunName("gate", gate); err != nil {
+		return "", err
+	}
+	return runsDir + "/" + run + "/approvals/" + gate + ".json", nil
+}
+
+func resultsKey(run string) string {
+	return runsDir + "/" + run + "/results.json"
+}
+
+// checkRunName rejects run and gate names that are not a single key
+// segment.
+func checkRunName(kind, name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid %s name %q", kind, name)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package reportstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+func TestApprovals(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var approvals multiagentspec.ApprovalStore = store
+	if _, err := approvals.GetApproval(ctx, "run-1", "deploy.sign-off"); !errors.Is(err, multiagentspec.ErrApprovalNotFound) {
+		t.Errorf("GetApproval(new gate) = %v, want ErrApprovalNotFound", err)
+	}
+	for _, gate := range []string{"deploy.sign-off", "audit.review"} {
+		a := &multiagentspec.Approval{Run: "run-1", Gate: gate, Request: "Check it", Decision: multiagentspec.ApprovalPending, RequestedAt: time.Now().UTC()}
+		if err := approvals.PutApproval(ctx, a); err != nil {
+			t.Fatalf("PutApproval: %v", err)
+		}
+	}
+	a, err := approvals.GetApproval(ctx, "run-1", "deploy.sign-off")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Decide("alice", multiagentspec.ApprovalApproved, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if err := approvals.PutApproval(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := store.Approvals(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("Approvals: %v", err)
+	}
+	if len(list) != 2 || list[0].Gate != "audit.review" || list[1].Decision != multiagentspec.ApprovalApproved || list[1].DecidedBy != "alice" {
+		t.Errorf("Approvals() = %+v", list)
+	}
+	if list, err := store.Approvals(ctx, "run-2"); err != nil || len(list) != 0 {
+		t.Errorf("Approvals(unknown run) = %v, %v; want none", list, err)
+	}
+	if err := store.PutApproval(ctx, &multiagentspec.Approval{Run: "../x", Gate: "g"}); err == nil {
+		t.Error("PutApproval() accepted a run name with a slash")
+	}
+	if entries, _ := store.List(); len(entries) != 0 {
+		t.Errorf("approvals show up as %d reports", len(entries))
+	}
+}
+
+func TestRunResults(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.RunResults(ctx, "run-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("RunResults(unsaved) = %v, want ErrNotFound", err)
+	}
+	results := []multiagentspec.AgentResult{{AgentID: "builder", StepID: "build", Status: multiagentspec.StatusGo, Outputs: map[string]interface{}{"artifact": "app.tar"}}}
+	if err := store.SaveRunResults(ctx, "run-1", results); err != nil {
+		t.Fatalf("SaveRunResults: %v", err)
+	}
+	got, err := store.RunResults(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("RunResults: %v", err)
+	}
+	if len(got) != 1 || got[0].StepID != "build" || got[0].Outputs["artifact"] != "app.tar" {
+		t.Errorf("RunResults() = %+v", got)
+	}
+}
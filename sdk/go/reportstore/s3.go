@@ -0,0 +1,171 @@
+package reportstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/plexusone/multi-agent-spec/sdk/go/internal/sigv4"
+)
+
+// S3Config configures S3 storage. Unset fields are read from the
+// environment as the AWS CLI does.
+type S3Config struct {
+	Bucket string
+
+	// Prefix is prepended to every key, like a directory.
+	Prefix string
+
+	// Region defaults to AWS_REGION, AWS_DEFAULT_REGION, or us-east-1.
+	Region string
+
+	// Endpoint is the URL of an S3-compatible service, such as MinIO or
+	// Cloud Storage's XML API, addressed path-style. It defaults to
+	// AWS_ENDPOINT_URL_S3 or AWS_ENDPOINT_URL, or else to AWS's
+	// virtual-hosted endpoint for Bucket and Region.
+	Endpoint string
+
+	// AccessKeyID, SecretAccessKey, and SessionToken default to
+	// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, and AWS_SESSION_TOKEN.
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// S3 is Storage in an S3 bucket, using the REST API signed with Signature
+// Version 4.
+type S3 struct {
+	cfg   S3Config
+	base  string // URL that keys are appended to
+	creds sigv4.Credentials
+}
+
+// NewS3 returns S3 storage for cfg.
+func NewS3(cfg S3Config) *S3 {
+	cfg.Region = firstNonEmpty(cfg.Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), "us-east-1")
+	cfg.Endpoint = firstNonEmpty(cfg.Endpoint, os.Getenv("AWS_ENDPOINT_URL_S3"), os.Getenv("AWS_ENDPOINT_URL"))
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	creds := sigv4.Credentials{
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+		SessionToken:    cfg.SessionToken,
+	}
+	if creds.AccessKeyID == "" {
+		creds, _ = sigv4.CredentialsFromEnv()
+	}
+	base := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", cfg.Bucket, cfg.Region)
+	if cfg.Endpoint != "" {
+		base = strings.TrimSuffix(cfg.Endpoint, "/") + "/" + sigv4.URIEncode(cfg.Bucket, true) + "/"
+	}
+	return &S3{cfg: cfg, base: base, creds: creds}
+}
+
+// Put uploads data to key.
+func (s *S3) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.do(ctx, http.MethodPut, sigv4.URIEncode(joinKey(s.cfg.Prefix, key), false), data)
+	if err != nil {
+		return fmt.Errorf("put %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get downloads key.
+func (s *S3) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := s.do(ctx, http.MethodGet, sigv4.URIEncode(joinKey(s.cfg.Prefix, key), false), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", key, err)
+	}
+	return data, nil
+}
+
+// List returns the objects whose keys start with prefix, sorted by key.
+func (s *S3) List(ctx context.Context, prefix string) ([]Object, error) {
+	full := joinKey(s.cfg.Prefix, prefix)
+	q := url.Values{"list-type": {"2"}, "prefix": {full}}
+	var out []Object
+	for {
+		data, err := s.do(ctx, http.MethodGet, "?"+q.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %w", prefix, err)
+		}
+		var page struct {
+			Contents []struct {
+				Key          string
+				LastModified time.Time
+			}
+			IsTruncated           bool
+			NextContinuationToken string
+		}
+		if err := xml.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("list %s: parse response: %w", prefix, err)
+		}
+		for _, c := range page.Contents {
+			out = append(out, Object{Key: strings.TrimPrefix(c.Key, joinKey(s.cfg.Prefix, "")), ModTime: c.LastModified.UTC()})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return out, nil
+		}
+		q.Set("continuation-token", page.NextContinuationToken)
+	}
+}
+
+// do sends a signed request for path, relative to the bucket, and returns
+// the response body.
+func (s *S3) do(ctx context.Context, method, path string, body []byte) ([]byte, error) {
+	if s.creds.AccessKeyID == "" || s.creds.SecretAccessKey == "" {
+		return nil, fmt.Errorf("no AWS credentials: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.base+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	sigv4.Sign(req, body, s.creds, s.cfg.Region, "s3", time.Now())
+	resp, err := s.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 == 2 {
+		return data, nil
+	}
+	var e struct {
+		Code    string
+		Message string
+	}
+	_ = xml.Unmarshal(data, &e)
+	if resp.StatusCode == http.StatusNotFound && e.Code != "NoSuchBucket" {
+		return nil, ErrNotFound
+	}
+	if e.Code == "" {
+		return nil, fmt.Errorf("s3: %s", resp.Status)
+	}
+	return nil, fmt.Errorf("s3: %s: %s", e.Code, e.Message)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
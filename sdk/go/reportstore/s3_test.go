@@ -0,0 +1,138 @@
+package reportstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	multiagentspec "github.com/plexusone/multi-agent-spec/sdk/go"
+)
+
+// fakeS3 serves one path-style bucket from memory, listing one object per
+// page to exercise continuation.
+type fakeS3 struct {
+	t      *testing.T
+	bucket string
+
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if got := r.Header.Get("X-Amz-Content-Sha256"); got != hex.EncodeToString(sum[:]) {
+		f.t.Errorf("%s %s: X-Amz-Content-Sha256 = %q", r.Method, r.URL, got)
+	}
+	if auth := r.Header.Get("Authorization"); !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/eu-west-1/s3/aws4_request") {
+		f.t.Errorf("%s %s: Authorization = %q", r.Method, r.URL, auth)
+	}
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, "<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>")
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+	case key == "" && r.URL.Query().Get("list-type") == "2":
+		var keys []string
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) && k > r.URL.Query().Get("continuation-token") {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		type content struct {
+			Key          string
+			LastModified time.Time
+		}
+		page := struct {
+			XMLName               xml.Name `xml:"ListBucketResult"`
+			Contents              []content
+			IsTruncated           bool
+			NextContinuationToken string `xml:",omitempty"`
+		}{}
+		if len(keys) > 0 {
+			page.Contents = []content{{Key: keys[0], LastModified: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}}
+			page.IsTruncated = len(keys) > 1
+			page.NextContinuationToken = keys[0]
+		}
+		xml.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodGet:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>")
+			return
+		}
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{t: t, bucket: "ci", objects: map[string][]byte{}}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+	storage := NewS3(S3Config{
+		Bucket:          "ci",
+		Prefix:          "mas/",
+		Region:          "eu-west-1",
+		Endpoint:        srv.URL,
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+	})
+
+	store, err := New(ctx, storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for day := 1; day <= 3; day++ {
+		e, err := store.Save(ctx, testReport("v1.0.0", "prod", day, multiagentspec.StatusGo))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, e.ID)
+	}
+	if _, ok := fake.objects["mas/index.json"]; !ok {
+		t.Errorf("objects = %v, want mas/index.json", fake.objects)
+	}
+
+	delete(fake.objects, "mas/index.json")
+	rebuilt, err := New(ctx, storage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := rebuilt.List()
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("rebuilt index = %+v, %v", entries, err)
+	}
+	if got, err := rebuilt.Get(ctx, ids[1]); err != nil || got.GeneratedAt.Day() != 2 {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+
+	if _, err := storage.Get(ctx, "missing.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+	other := NewS3(S3Config{Bucket: "nope", Region: "eu-west-1", Endpoint: srv.URL, AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	if _, err := other.Get(ctx, "index.json"); err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "NoSuchBucket") {
+		t.Errorf("Get() from missing bucket error = %v", err)
+	}
+}
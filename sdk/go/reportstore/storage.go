@@ -0,0 +1,141 @@
+package reportstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Storage holds a store's objects: its reports and index. Keys are
+// slash-separated paths such as "reports/<id>.json". Get returns an error
+// wrapping ErrNotFound for a missing key.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object describes a stored object.
+type Object struct {
+	Key     string
+	ModTime time.Time
+}
+
+// OpenURL opens the store at location: s3://bucket/prefix (see S3Config),
+// gs://bucket/prefix (see GCSConfig), or a directory path.
+func OpenURL(ctx context.Context, location string) (*Store, error) {
+	scheme, rest, ok := strings.Cut(location, "://")
+	if !ok {
+		scheme, rest = "file", location
+	}
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	var storage Storage
+	switch scheme {
+	case "file":
+		storage = Dir(rest)
+	case "s3":
+		storage = NewS3(S3Config{Bucket: bucket, Prefix: prefix})
+	case "gs":
+		storage = NewGCS(GCSConfig{Bucket: bucket, Prefix: prefix})
+	default:
+		return nil, fmt.Errorf("store %s: unknown scheme %q (want s3, gs, or file)", location, scheme)
+	}
+	if scheme != "file" && bucket == "" {
+		return nil, fmt.Errorf("store %s: missing bucket", location)
+	}
+	s, err := New(ctx, storage)
+	if err != nil {
+		return nil, fmt.Errorf("open store %s: %w", location, err)
+	}
+	s.location = location
+	return s, nil
+}
+
+// Dir is Storage in a local directory.
+type Dir string
+
+// Put writes data to key through a temporary file and rename, so readers
+// never see a partial object.
+func (d Dir) Put(_ context.Context, key string, data []byte) error {
+	path := d.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// Get reads key.
+func (d Dir) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(d.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return data, err
+}
+
+// List returns the objects whose keys start with prefix, sorted by key.
+func (d Dir) List(_ context.Context, prefix string) ([]Object, error) {
+	var out []Object
+	err := filepath.WalkDir(string(d), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == string(d) && errors.Is(err, fs.ErrNotExist) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(string(d), path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		out = append(out, Object{Key: key, ModTime: info.ModTime().UTC()})
+		return nil
+	})
+	return out, err
+}
+
+func (d Dir) path(key string) string {
+	return filepath.Join(string(d), filepath.FromSlash(key))
+}
+
+// writeFileAtomic writes data to path through a temporary file and rename,
+// so readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// joinKey prefixes key with prefix, treating prefix as a directory.
+func joinKey(prefix, key string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
@@ -0,0 +1,54 @@
+package reportstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	ctx := context.Background()
+	d := Dir(filepath.Join(t.TempDir(), "store"))
+	if objects, err := d.List(ctx, ""); err != nil || len(objects) != 0 {
+		t.Errorf("List() of missing directory = %v, %v", objects, err)
+	}
+	for _, key := range []string{"index.json", "reports/a.json", "reports/b.json"} {
+		if err := d.Put(ctx, key, []byte(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(string(d), "reports", ".tmp-123"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	objects, err := d.List(ctx, "reports/")
+	if err != nil || len(objects) != 2 || objects[0].Key != "reports/a.json" || objects[1].Key != "reports/b.json" {
+		t.Errorf("List(reports/) = %+v, %v", objects, err)
+	}
+	if data, err := d.Get(ctx, "reports/b.json"); err != nil || string(data) != "reports/b.json" {
+		t.Errorf("Get() = %q, %v", data, err)
+	}
+	if _, err := d.Get(ctx, "reports/c.json"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOpenURL(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, location := range []string{dir, "file://" + dir} {
+		s, err := OpenURL(ctx, location)
+		if err != nil {
+			t.Fatalf("OpenURL(%q) error = %v", location, err)
+		}
+		if s.Location() != location {
+			t.Errorf("Location() = %q", s.Location())
+		}
+	}
+	for _, location := range []string{"s3://", "ftp://host/reports"} {
+		if _, err := OpenURL(ctx, location); err == nil {
+			t.Errorf("OpenURL(%q) succeeded", location)
+		}
+	}
+}
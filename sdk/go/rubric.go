@@ -0,0 +1,191 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Rubric defines versioned evaluation criteria that agents and evaluation
+// tasks reference by name, instead of embedding them in prompts.
+type Rubric struct {
+	// Schema is the JSON Schema reference.
+	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+
+	// Name is the rubric identifier agents and tasks reference.
+	Name string `json:"name" yaml:"name" jsonschema_description:"Rubric identifier agents and tasks reference"`
+
+	// Version is the semantic version of the rubric (e.g., 1.2.0).
+	Version string `json:"version,omitempty" yaml:"version,omitempty" jsonschema_description:"Semantic version of the rubric, e.g., 1.2.0"`
+
+	// Description explains what the rubric evaluates.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" jsonschema_description:"What the rubric evaluates"`
+
+	// Categories are the dimensions content is scored on.
+	Categories []RubricCategory `json:"categories" yaml:"categories" jsonschema:"minItems=1" jsonschema_description:"Dimensions content is scored on"`
+}
+
+// RubricCategory is one scored dimension of a rubric.
+type RubricCategory struct {
+	// ID is the category identifier (lowercase, hyphenated), matching
+	// EvaluationCategory IDs in reports.
+	ID string `json:"id" yaml:"id" jsonschema_description:"Category identifier (lowercase, hyphenated), matching evaluation category IDs in reports"`
+
+	// Name is the human-readable category name.
+	Name string `json:"name,omitempty" yaml:"name,omitempty" jsonschema_description:"Human-readable category name"`
+
+	// Description explains what the category measures.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" jsonschema_description:"What the category measures"`
+
+	// Weight is the category's share of the overall score (0-1). Weights
+	// are normalized; when all are zero, categories weigh equally.
+	Weight float64 `json:"weight,omitempty" yaml:"weight,omitempty" jsonschema:"minimum=0,maximum=1" jsonschema_description:"Share of the overall score; weights are normalized, and categories weigh equally when none is set"`
+
+	// Criteria are the questions or checks the category is judged by.
+	Criteria []string `json:"criteria,omitempty" yaml:"criteria,omitempty" jsonschema_description:"Questions or checks the category is judged by"`
+
+	// Anchors describe what content at particular scores looks like.
+	Anchors []ScoreAnchor `json:"anchors,omitempty" yaml:"anchors,omitempty" jsonschema_description:"What content at particular scores looks like"`
+}
+
+// ScoreAnchor describes content deserving a particular score.
+type ScoreAnchor struct {
+	// Score is the anchored score (0-10).
+	Score float64 `json:"score" yaml:"score" jsonschema:"minimum=0,maximum=10" jsonschema_description:"Anchored score (0-10)"`
+
+	// Description is what content at this score looks like.
+	Description string `json:"description" yaml:"description" jsonschema_description:"What content at this score looks like"`
+}
+
+// Validate checks that the rubric has a name, a valid version if set, and
+// categories with unique IDs, non-negative weights, and anchors within
+// 0-10.
+func (r *Rubric) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if r.Version != "" {
+		if _, err := ParseVersion(r.Version); err != nil {
+			return err
+		}
+	}
+	if len(r.Categories) == 0 {
+		return fmt.Errorf("rubric %s: at least one category is required", r.Name)
+	}
+	seen := make(map[string]bool, len(r.Categories))
+	for _, c := range r.Categories {
+		if c.ID == "" {
+			return fmt.Errorf("rubric %s: category id is required", r.Name)
+		}
+		if seen[c.ID] {
+			return fmt.Errorf("rubric %s: category %s is defined more than once", r.Name, c.ID)
+		}
+		seen[c.ID] = true
+		if c.Weight < 0 || c.Weight > 1 {
+			return fmt.Errorf("rubric %s: category %s: weight %g is outside 0-1", r.Name, c.ID, c.Weight)
+		}
+		scores := make(map[float64]bool, len(c.Anchors))
+		for _, a := range c.Anchors {
+			if a.Score < 0 || a.Score > DefaultLLMMaxScore {
+				return fmt.Errorf("rubric %s: category %s: anchor score %g is outside 0-%d", r.Name, c.ID, a.Score, DefaultLLMMaxScore)
+			}
+			if scores[a.Score] {
+				return fmt.Errorf("rubric %s: category %s: score %g is anchored more than once", r.Name, c.ID, a.Score)
+			}
+			scores[a.Score] = true
+		}
+	}
+	return nil
+}
+
+// Weights returns each category's weight normalized so they sum to 1,
+// keyed by category ID. When no category has a weight, all weigh equally.
+func (r *Rubric) Weights() map[string]float64 {
+	var total float64
+	for _, c := range r.Categories {
+		total += c.Weight
+	}
+	weights := make(map[string]float64, len(r.Categories))
+	for _, c := range r.Categories {
+		if total == 0 {
+			weights[c.ID] = 1 / float64(len(r.Categories))
+		} else {
+			weights[c.ID] = c.Weight / total
+		}
+	}
+	return weights
+}
+
+// Prompt renders the rubric as markdown for an Evaluator, with categories
+// in order and their anchors from the highest score down.
+func (r *Rubric) Prompt() string {
+	var b strings.Builder
+	b.WriteString("# Rubric: " + r.Name)
+	if r.Version != "" {
+		b.WriteString(" (v" + strings.TrimPrefix(r.Version, "v") + ")")
+	}
+	b.WriteString("\n")
+	if r.Description != "" {
+		b.WriteString("\n" + r.Description + "\n")
+	}
+	weights := r.Weights()
+	for _, c := range r.Categories {
+		name := c.Name
+		if name == "" {
+			name = c.ID
+		}
+		fmt.Fprintf(&b, "\n## %s (%s, weight %.0f%%)\n", name, c.ID, weights[c.ID]*100)
+		if c.Description != "" {
+			b.WriteString("\n" + c.Description + "\n")
+		}
+		if len(c.Criteria) > 0 {
+			b.WriteString("\nCriteria:\n")
+			for _, criterion := range c.Criteria {
+				b.WriteString("- " + criterion + "\n")
+			}
+		}
+		if len(c.Anchors) > 0 {
+			anchors := append([]ScoreAnchor(nil), c.Anchors...)
+			sort.SliceStable(anchors, func(i, j int) bool { return anchors[i].Score > anchors[j].Score })
+			b.WriteString("\nScore anchors:\n")
+			for _, a := range anchors {
+				fmt.Fprintf(&b, "- %g: %s\n", a.Score, a.Description)
+			}
+		}
+	}
+	return b.String()
+}
+
+// RubricFor returns the name of the rubric the agent's task taskID is
+// graded against: the task's own rubric, else the agent's.
+func (a *Agent) RubricFor(taskID string) string {
+	for _, t := range a.Tasks {
+		if t.ID == taskID && t.Rubric != "" {
+			return t.Rubric
+		}
+	}
+	return a.Rubric
+}
+
+// CheckRubricReferences verifies that rubric names are unique and that the
+// rubric every agent and task names is one of rubrics.
+func CheckRubricReferences(agents []*Agent, rubrics []*Rubric) error {
+	defined := make(map[string]bool, len(rubrics))
+	for _, r := range rubrics {
+		if defined[r.Name] {
+			return fmt.Errorf("rubric %s is defined more than once", r.Name)
+		}
+		defined[r.Name] = true
+	}
+	for _, a := range agents {
+		if a.Rubric != "" && !defined[a.Rubric] {
+			return fmt.Errorf("agent %s: unknown rubric %q", a.QualifiedName(), a.Rubric)
+		}
+		for _, t := range a.Tasks {
+			if t.Rubric != "" && !defined[t.Rubric] {
+				return fmt.Errorf("agent %s: task %s: unknown rubric %q", a.QualifiedName(), t.ID, t.Rubric)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+func testRubric() *Rubric {
+	return &Rubric{
+		Name:    "prd-quality",
+		Version: "1.2.0",
+		Categories: []RubricCategory{
+			{
+				ID:       "problem-clarity",
+				Name:     "Problem Clarity",
+				Weight:   0.6,
+				Criteria: []string{"Is the user problem stated?"},
+				Anchors: []ScoreAnchor{
+					{Score: 5, Description: "Problem implied but not stated"},
+					{Score: 10, Description: "Problem stated with evidence"},
+				},
+			},
+			{ID: "scope", Weight: 0.2},
+		},
+	}
+}
+
+func TestRubricValidate(t *testing.T) {
+	if err := testRubric().Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*Rubric)
+		wantErr string
+	}{
+		{"no name", func(r *Rubric) { r.Name = "" }, "name is required"},
+		{"bad version", func(r *Rubric) { r.Version = "v2" }, "invalid version"},
+		{"no categories", func(r *Rubric) { r.Categories = nil }, "at least one category is required"},
+		{"no category id", func(r *Rubric) { r.Categories[1].ID = "" }, "category id is required"},
+		{"duplicate category", func(r *Rubric) { r.Categories[1].ID = "problem-clarity" }, "category problem-clarity is defined more than once"},
+		{"weight", func(r *Rubric) { r.Categories[1].Weight = 1.5 }, "weight 1.5 is outside 0-1"},
+		{"anchor score", func(r *Rubric) { r.Categories[0].Anchors[0].Score = 11 }, "anchor score 11 is outside 0-10"},
+		{"duplicate anchor", func(r *Rubric) { r.Categories[0].Anchors[0].Score = 10 }, "score 10 is anchored more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testRubric()
+			tt.mutate(r)
+			err := r.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRubricWeights(t *testing.T) {
+	w := testRubric().Weights()
+	if math.Abs(w["problem-clarity"]-0.75) > 1e-9 || math.Abs(w["scope"]-0.25) > 1e-9 {
+		t.Errorf("Weights() = %v", w)
+	}
+
+	equal := &Rubric{Name: "r", Categories: []RubricCategory{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}}
+	for id, weight := range equal.Weights() {
+		if weight != 0.25 {
+			t.Errorf("Weights()[%s] = %g, want 0.25", id, weight)
+		}
+	}
+}
+
+func TestRubricPrompt(t *testing.T) {
+	got := testRubric().Prompt()
+	want := `# Rubric: prd-quality (v1.2.0)
+
+## Problem Clarity (problem-clarity, weight 75%)
+
+Criteria:
+- Is the user problem stated?
+
+Score anchors:
+- 10: Problem stated with evidence
+- 5: Problem implied but not stated
+
+## scope (scope, weight 25%)
+`
+	if got != want {
+		t.Errorf("Prompt() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAgentRubricFor(t *testing.T) {
+	a := &Agent{Name: "pm", Rubric: "prd-quality", Tasks: []Task{{ID: "scope-check", Rubric: "scope"}, {ID: "lint"}}}
+	if got := a.RubricFor("scope-check"); got != "scope" {
+		t.Errorf("RubricFor(scope-check) = %q", got)
+	}
+	if got := a.RubricFor("lint"); got != "prd-quality" {
+		t.Errorf("RubricFor(lint) = %q", got)
+	}
+}
+
+func TestCheckRubricReferences(t *testing.T) {
+	rubrics := []*Rubric{testRubric()}
+	agents := []*Agent{
+		{Name: "pm", Rubric: "prd-quality"},
+		{Name: "qa", Namespace: "shared", Tasks: []Task{{ID: "review", Rubric: "prd-quality"}}},
+	}
+	if err := CheckRubricReferences(agents, rubrics); err != nil {
+		t.Errorf("CheckRubricReferences() = %v", err)
+	}
+
+	agents[1].Tasks = append(agents[1].Tasks, Task{ID: "security", Rubric: "threat-model"})
+	err := CheckRubricReferences(agents, rubrics)
+	if err == nil || err.Error() != `agent shared/qa: task security: unknown rubric "threat-model"` {
+		t.Errorf("CheckRubricReferences() = %v, want unknown task rubric", err)
+	}
+
+	agents[0].Rubric = "missing"
+	err = CheckRubricReferences(agents, rubrics)
+	if err == nil || err.Error() != `agent pm: unknown rubric "missing"` {
+		t.Errorf("CheckRubricReferences() = %v, want unknown agent rubric", err)
+	}
+
+	err = CheckRubricReferences(nil, append(rubrics, testRubric()))
+	if err == nil || err.Error() != "rubric prd-quality is defined more than once" {
+		t.Errorf("CheckRubricReferences() = %v, want duplicate rubric", err)
+	}
+}
+
+func TestValidateRubricJSON(t *testing.T) {
+	data, err := json.Marshal(testRubric())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateRubricJSON(data); err != nil {
+		t.Errorf("ValidateRubricJSON() = %v", err)
+	}
+	if err := ValidateRubricJSON([]byte(`{"name": "r", "categories": []}`)); err == nil {
+		t.Error("expected error for rubric without categories")
+	}
+}
@@ -0,0 +1,150 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// embeddedSchemas holds a copy of the published schemas at SpecVersion.
+// The files under schema/ mirror the repository's top-level schema
+// directory and are kept in sync by tools/generate.
+//
+//go:embed schema
+var embeddedSchemas embed.FS
+
+// SchemaFS returns the embedded schema files. Paths match SchemaPath.
+func SchemaFS() fs.FS {
+	return embeddedSchemas
+}
+
+// SchemaJSON returns the embedded JSON Schema document for kind.
+func SchemaJSON(kind SchemaKind) ([]byte, error) {
+	path := SchemaPath(kind)
+	if path == "" {
+		return nil, fmt.Errorf("unknown schema kind %q", kind)
+	}
+	return embeddedSchemas.ReadFile(path)
+}
+
+var (
+	schemaCompileOnce sync.Once
+	compiledSchemas   map[SchemaKind]*jsonschema.Schema
+	schemaCompileErr  error
+)
+
+// compiledSchema returns the compiled embedded schema for kind.
+// All schemas are compiled once on first use; no network access is performed.
+func compiledSchema(kind SchemaKind) (*jsonschema.Schema, error) {
+	schemaCompileOnce.Do(func() {
+		compiledSchemas, schemaCompileErr = compileEmbeddedSchemas()
+	})
+	if schemaCompileErr != nil {
+		return nil, schemaCompileErr
+	}
+	s, ok := compiledSchemas[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown schema kind %q", kind)
+	}
+	return s, nil
+}
+
+func compileEmbeddedSchemas() (map[SchemaKind]*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	for _, kind := range SchemaKinds() {
+		data, err := SchemaJSON(kind)
+		if err != nil {
+			return nil, fmt.Errorf("read embedded %s schema: %w", kind, err)
+		}
+		doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("parse embedded %s schema: %w", kind, err)
+		}
+		if err := compiler.AddResource(SchemaURL(kind), doc); err != nil {
+			return nil, fmt.Errorf("add %s schema: %w", kind, err)
+		}
+	}
+
+	schemas := make(map[SchemaKind]*jsonschema.Schema)
+	for _, kind := range SchemaKinds() {
+		s, err := compiler.Compile(SchemaURL(kind))
+		if err != nil {
+			return nil, fmt.Errorf("compile %s schema: %w", kind, err)
+		}
+		schemas[kind] = s
+	}
+	return schemas, nil
+}
+
+// ValidateJSON validates a JSON document against the embedded schema for kind.
+// Validation is performed entirely offline.
+func ValidateJSON(kind SchemaKind, data []byte) error {
+	s, err := compiledSchema(kind)
+	if err != nil {
+		return err
+	}
+	v, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("parse json: %w", err)
+	}
+	if err := s.Validate(v); err != nil {
+		return fmt.Errorf("validate %s: %w", kind, err)
+	}
+	return nil
+}
+
+// ValidateAgentJSON validates an Agent JSON document against the agent schema.
+func ValidateAgentJSON(data []byte) error {
+	return ValidateJSON(SchemaAgent, data)
+}
+
+// ValidateTeamJSON validates a Team JSON document against the team schema.
+func ValidateTeamJSON(data []byte) error {
+	return ValidateJSON(SchemaTeam, data)
+}
+
+// ValidateDeploymentJSON validates a Deployment JSON document against the deployment schema.
+func ValidateDeploymentJSON(data []byte) error {
+	return ValidateJSON(SchemaDeployment, data)
+}
+
+// ValidateTeamReportJSON validates a TeamReport JSON document against the team report schema.
+func ValidateTeamReportJSON(data []byte) error {
+	return ValidateJSON(SchemaTeamReport, data)
+}
+
+// ValidateAgentResultJSON validates an AgentResult JSON document against the agent result schema.
+func ValidateAgentResultJSON(data []byte) error {
+	return ValidateJSON(SchemaAgentResult, data)
+}
+
+// ValidateMessageJSON validates a Message JSON document against the message schema.
+func ValidateMessageJSON(data []byte) error {
+	return ValidateJSON(SchemaMessage, data)
+}
+
+// ValidateSkillJSON validates a Skill JSON document against the skill schema.
+func ValidateSkillJSON(data []byte) error {
+	return ValidateJSON(SchemaSkill, data)
+}
+
+// ValidateToolJSON validates a ToolSpec JSON document against the tool schema.
+func ValidateToolJSON(data []byte) error {
+	return ValidateJSON(SchemaTool, data)
+}
+
+// ValidateRubricJSON validates a Rubric JSON document against the rubric
+// schema.
+func ValidateRubricJSON(data []byte) error {
+	return ValidateJSON(SchemaRubric, data)
+}
+
+// ValidatePromptJSON validates a PromptTemplate JSON document against the
+// prompt schema.
+func ValidatePromptJSON(data []byte) error {
+	return ValidateJSON(SchemaPrompt, data)
+}
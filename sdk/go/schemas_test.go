@@ -0,0 +1,92 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestEmbeddedSchemasMatchPublished ensures the embedded copies stay in sync
+// with the repository's top-level schema directory.
+func TestEmbeddedSchemasMatchPublished(t *testing.T) {
+	for _, kind := range SchemaKinds() {
+		embedded, err := SchemaJSON(kind)
+		if err != nil {
+			t.Fatalf("SchemaJSON(%s): %v", kind, err)
+		}
+		path := filepath.Join("..", "..", filepath.FromSlash(SchemaPath(kind)))
+		published, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("read %s: %v", path, err)
+		}
+		if !bytes.Equal(embedded, published) {
+			t.Errorf("embedded %s schema differs from %s; run tools/generate", kind, path)
+		}
+	}
+}
+
+func TestSchemaJSONUnknownKind(t *testing.T) {
+	if _, err := SchemaJSON("bogus"); err == nil {
+		t.Error("SchemaJSON(unknown) should return an error")
+	}
+	if err := ValidateJSON("bogus", []byte(`{}`)); err == nil {
+		t.Error("ValidateJSON(unknown) should return an error")
+	}
+}
+
+func TestValidateTeamReportJSON(t *testing.T) {
+	report := AggregateResults([]AgentResult{
+		{
+			AgentID: "qa",
+			Status:  StatusGo,
+			Tasks:   []TaskResult{{ID: "unit-tests", Status: StatusGo}},
+		},
+	}, "my-project", "v1.0.0", "RELEASE VALIDATION")
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := ValidateTeamReportJSON(data); err != nil {
+		t.Errorf("valid report rejected: %v", err)
+	}
+
+	if err := ValidateTeamReportJSON([]byte(`{"project": "p"}`)); err == nil {
+		t.Error("report missing required fields should be rejected")
+	}
+	if err := ValidateTeamReportJSON([]byte(`{not json`)); err == nil {
+		t.Error("malformed JSON should be rejected")
+	}
+}
+
+func TestValidateTeamJSON(t *testing.T) {
+	valid := `{"name": "release-team", "version": "1.0.0", "agents": ["pm", "qa"], "workflow": {"type": "graph"}}`
+	if err := ValidateTeamJSON([]byte(valid)); err != nil {
+		t.Errorf("valid team rejected: %v", err)
+	}
+
+	invalid := `{"name": "release-team", "version": "1.0.0", "agents": ["pm"], "workflow": {"type": "dag"}}`
+	if err := ValidateTeamJSON([]byte(invalid)); err == nil {
+		t.Error("team with unknown workflow type should be rejected")
+	}
+}
+
+func TestValidateAgentJSON(t *testing.T) {
+	if err := ValidateAgentJSON([]byte(`{"name": "qa", "model": "sonnet"}`)); err != nil {
+		t.Errorf("valid agent rejected: %v", err)
+	}
+	if err := ValidateAgentJSON([]byte(`{"name": "qa", "model": "gpt-4"}`)); err == nil {
+		t.Error("agent with unknown model should be rejected")
+	}
+}
+
+func TestValidateAgentResultJSON(t *testing.T) {
+	valid := `{"agent_id": "qa", "step_id": "qa", "inputs": {}, "outputs": {}, "checks": [], "status": "GO", "executed_at": "2026-01-01T00:00:00Z"}`
+	if err := ValidateAgentResultJSON([]byte(valid)); err != nil {
+		t.Errorf("valid agent result rejected: %v", err)
+	}
+	if err := ValidateAgentResultJSON([]byte(`{"agent_id": "qa", "status": "MAYBE"}`)); err == nil {
+		t.Error("agent result with unknown status should be rejected")
+	}
+}
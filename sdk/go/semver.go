@@ -0,0 +1,247 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version (major.minor.patch with an optional
+// pre-release). Build metadata is ignored.
+type Version struct {
+	Major, Minor, Patch int
+
+	// Prerelease is the dot-separated pre-release identifier, e.g., rc.1.
+	Prerelease string
+}
+
+// ParseVersion parses a semantic version such as 2.1.0 or v2.1.0-rc.1.
+func ParseVersion(s string) (Version, error) {
+	v, parts, err := parseVersion(s)
+	if err != nil {
+		return Version{}, err
+	}
+	if parts != 3 {
+		return Version{}, fmt.Errorf("invalid version %q: want major.minor.patch", s)
+	}
+	return v, nil
+}
+
+// parseVersion parses a full or partial version (2, 2.1, 2.1.0) and returns
+// how many numeric parts it had.
+func parseVersion(s string) (Version, int, error) {
+	raw := s
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s = s[:i]
+	}
+	var v Version
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.Prerelease = s[i+1:]
+		s = s[:i]
+		if v.Prerelease == "" {
+			return Version{}, 0, fmt.Errorf("invalid version %q", raw)
+		}
+	}
+	fields := strings.Split(s, ".")
+	if len(fields) > 3 {
+		return Version{}, 0, fmt.Errorf("invalid version %q", raw)
+	}
+	nums := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 || (len(f) > 1 && f[0] == '0') {
+			return Version{}, 0, fmt.Errorf("invalid version %q", raw)
+		}
+		*nums[i] = n
+	}
+	if v.Prerelease != "" && len(fields) != 3 {
+		return Version{}, 0, fmt.Errorf("invalid version %q", raw)
+	}
+	return v, len(fields), nil
+}
+
+// String returns the version as major.minor.patch[-prerelease].
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0, or 1 as v is lower than, equal to, or higher than
+// w in semantic version precedence.
+func (v Version) Compare(w Version) int {
+	for _, d := range [][2]int{{v.Major, w.Major}, {v.Minor, w.Minor}, {v.Patch, w.Patch}} {
+		if d[0] != d[1] {
+			return cmpInt(d[0], d[1])
+		}
+	}
+	return comparePrerelease(v.Prerelease, w.Prerelease)
+}
+
+// comparePrerelease orders pre-releases per semver: a release is higher
+// than any pre-release, numeric identifiers compare numerically and below
+// alphanumeric ones, and a longer identifier list wins a tie.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return cmpInt(an, bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		case as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return cmpInt(len(as), len(bs))
+}
+
+func cmpInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// VersionConstraint is a set of version comparators that must all hold,
+// written npm-style: ^2, ~1.4, >=1.2.0 <2, 2.1.x, or an exact version.
+type VersionConstraint struct {
+	raw         string
+	comparators []comparator
+}
+
+type comparator struct {
+	op      string // one of =, >, >=, <, <=
+	version Version
+}
+
+// ParseVersionConstraint parses a constraint. Comparators are separated by
+// spaces or commas and all must hold. Supported forms:
+//
+//	*, x             any version
+//	1.2.3, =1.2.3    exactly 1.2.3
+//	2, 2.x, 2.1.x    any 2.x.x, any 2.1.x
+//	^2.1.0           >=2.1.0 <3.0.0 (^0.2.1 is >=0.2.1 <0.3.0)
+//	~2.1.0, ~2.1     >=2.1.0 <2.2.0
+//	>=1.2, <2        ranges
+func ParseVersionConstraint(s string) (*VersionConstraint, error) {
+	c := &VersionConstraint{raw: strings.TrimSpace(s)}
+	for _, term := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		cs, err := parseComparator(term)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", s, err)
+		}
+		c.comparators = append(c.comparators, cs...)
+	}
+	return c, nil
+}
+
+func parseComparator(term string) ([]comparator, error) {
+	if term == "*" || term == "x" || term == "X" {
+		return nil, nil
+	}
+	op := ""
+	for _, p := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(term, p) {
+			op, term = p, term[len(p):]
+			break
+		}
+	}
+	term = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(term, ".x"), ".X"), ".*")
+	v, parts, err := parseVersion(term)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case ">", ">=", "<", "<=":
+		return []comparator{{op, v}}, nil
+	case "^":
+		upper := Version{Major: v.Major + 1}
+		switch {
+		case v.Major == 0 && parts == 1:
+			upper = Version{Major: 1}
+		case v.Major == 0 && (v.Minor > 0 || parts == 2):
+			upper = Version{Minor: v.Minor + 1}
+		case v.Major == 0:
+			upper = Version{Patch: v.Patch + 1}
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	case "~":
+		upper := Version{Major: v.Major, Minor: v.Minor + 1}
+		if parts == 1 {
+			upper = Version{Major: v.Major + 1}
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	}
+	// Bare or = versions: exact when complete, else any version with the
+	// given prefix.
+	switch parts {
+	case 1:
+		return []comparator{{">=", v}, {"<", Version{Major: v.Major + 1}}}, nil
+	case 2:
+		return []comparator{{">=", v}, {"<", Version{Major: v.Major, Minor: v.Minor + 1}}}, nil
+	}
+	return []comparator{{"=", v}}, nil
+}
+
+// String returns the constraint as written.
+func (c *VersionConstraint) String() string {
+	return c.raw
+}
+
+// Allows reports whether v satisfies every comparator. Pre-releases satisfy
+// a constraint only if one of its comparators names a pre-release of the
+// same major.minor.patch, so ^2 does not pick up 3.0.0-rc.1 or 2.1.0-rc.1.
+func (c *VersionConstraint) Allows(v Version) bool {
+	if v.Prerelease != "" {
+		ok := false
+		for _, cmp := range c.comparators {
+			w := cmp.version
+			ok = ok || (w.Prerelease != "" && w.Major == v.Major && w.Minor == v.Minor && w.Patch == v.Patch)
+		}
+		if !ok {
+			return false
+		}
+	}
+	for _, cmp := range c.comparators {
+		d := v.Compare(cmp.version)
+		var ok bool
+		switch cmp.op {
+		case "=":
+			ok = d == 0
+		case ">":
+			ok = d > 0
+		case ">=":
+			ok = d >= 0
+		case "<":
+			ok = d < 0
+		case "<=":
+			ok = d <= 0
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
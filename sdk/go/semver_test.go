@@ -0,0 +1,91 @@
+package multiagentspec
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"2.1.0", "2.1.0"},
+		{"v2.1.0", "2.1.0"},
+		{"1.0.0-rc.1", "1.0.0-rc.1"},
+		{"1.0.0+build.5", "1.0.0"},
+	}
+	for _, tt := range tests {
+		v, err := ParseVersion(tt.in)
+		if err != nil {
+			t.Errorf("ParseVersion(%q): %v", tt.in, err)
+			continue
+		}
+		if v.String() != tt.want {
+			t.Errorf("ParseVersion(%q) = %s, want %s", tt.in, v, tt.want)
+		}
+	}
+	for _, in := range []string{"", "2", "2.1", "2.1.0.4", "2.01.0", "2.x.0", "1.0.0-", "-1.0.0"} {
+		if _, err := ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.2.0", "2.0.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, _ := ParseVersion(ordered[i])
+		b, _ := ParseVersion(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+	a, _ := ParseVersion("v1.2.3")
+	b, _ := ParseVersion("1.2.3")
+	if a.Compare(b) != 0 {
+		t.Errorf("expected %s == %s", a, b)
+	}
+}
+
+func TestVersionConstraintAllows(t *testing.T) {
+	tests := []struct {
+		constraint string
+		allowed    []string
+		denied     []string
+	}{
+		{"^2", []string{"2.0.0", "2.9.1"}, []string{"1.9.9", "3.0.0", "2.1.0-rc.1"}},
+		{"^2.1.3", []string{"2.1.3", "2.5.0"}, []string{"2.1.2", "3.0.0"}},
+		{"^0.2.1", []string{"0.2.1", "0.2.9"}, []string{"0.3.0", "0.2.0"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.4", []string{"1.4.0", "1.4.7"}, []string{"1.5.0", "1.3.9"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"2.1.x", []string{"2.1.0", "2.1.9"}, []string{"2.2.0"}},
+		{"2", []string{"2.0.0", "2.3.0"}, []string{"3.0.0"}},
+		{"1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"=1.2.3", []string{"1.2.3"}, []string{"1.2.2"}},
+		{">=1.2, <2", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"}},
+		{">1.0.0 <=1.1.0", []string{"1.0.1", "1.1.0"}, []string{"1.0.0", "1.1.1"}},
+		{"*", []string{"0.0.1", "7.0.0"}, []string{"7.0.0-beta"}},
+		{"^2.0.0-rc.1", []string{"2.0.0-rc.2", "2.0.0", "2.4.0"}, []string{"2.0.0-beta", "2.1.0-rc.1"}},
+	}
+	for _, tt := range tests {
+		c, err := ParseVersionConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseVersionConstraint(%q): %v", tt.constraint, err)
+			continue
+		}
+		for _, s := range tt.allowed {
+			if v, _ := ParseVersion(s); !c.Allows(v) {
+				t.Errorf("%s should allow %s", tt.constraint, s)
+			}
+		}
+		for _, s := range tt.denied {
+			if v, _ := ParseVersion(s); c.Allows(v) {
+				t.Errorf("%s should not allow %s", tt.constraint, s)
+			}
+		}
+	}
+	for _, bad := range []string{"^two", ">=", "~1.2.3.4", "1.2.3-"} {
+		if _, err := ParseVersionConstraint(bad); err == nil {
+			t.Errorf("ParseVersionConstraint(%q) succeeded, want error", bad)
+		}
+	}
+}
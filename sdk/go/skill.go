@@ -0,0 +1,93 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// Skill is a reusable capability that agents reference by name in
+// Agent.Skills.
+type Skill struct {
+	// Schema is the JSON Schema reference.
+	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+
+	// Name is the skill identifier agents reference (lowercase, hyphenated).
+	Name string `json:"name" yaml:"name" jsonschema_description:"Skill identifier agents reference (lowercase, hyphenated)"`
+
+	// Description tells agents what the skill does and when to use it.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" jsonschema_description:"What the skill does and when to use it"`
+
+	// Inputs is a JSON Schema for the skill's input.
+	Inputs map[string]any `json:"inputs,omitempty" yaml:"inputs,omitempty" jsonschema_description:"JSON Schema for the skill's input"`
+
+	// Outputs is a JSON Schema for the skill's output.
+	Outputs map[string]any `json:"outputs,omitempty" yaml:"outputs,omitempty" jsonschema_description:"JSON Schema for the skill's output"`
+
+	// Implementation hints how platforms realize the skill, e.g., a script
+	// to run or an MCP tool to call.
+	Implementation string `json:"implementation,omitempty" yaml:"implementation,omitempty" jsonschema_description:"How platforms realize the skill, e.g., a script to run or an MCP tool to call"`
+
+	// Instructions explain how to perform the skill. In markdown skill
+	// files they are the body after the frontmatter.
+	Instructions string `json:"instructions,omitempty" yaml:"instructions,omitempty" jsonschema_description:"How to perform the skill; the body of a markdown skill file"`
+}
+
+// Validate checks that the skill has a name and that its inputs and
+// outputs are valid JSON Schemas.
+func (s *Skill) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := checkJSONSchema("inputs", s.Inputs); err != nil {
+		return err
+	}
+	return checkJSONSchema("outputs", s.Outputs)
+}
+
+// checkJSONSchema compiles doc to check that it is a valid JSON Schema.
+func checkJSONSchema(field string, doc map[string]any) error {
+	if doc == nil {
+		return nil
+	}
+	// Round-trip through JSON so values decoded from YAML have JSON types.
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	v, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	url := "urn:multi-agent-spec:skill:" + field
+	c := jsonschema.NewCompiler()
+	if err := c.AddResource(url, v); err != nil {
+		return fmt.Errorf("%s: %w", field, err)
+	}
+	if _, err := c.Compile(url); err != nil {
+		return fmt.Errorf("%s is not a valid JSON Schema: %w", field, err)
+	}
+	return nil
+}
+
+// CheckSkillReferences verifies that skill names are unique and that every
+// entry in the agents' Skills names one of skills.
+func CheckSkillReferences(agents []*Agent, skills []*Skill) error {
+	defined := make(map[string]bool, len(skills))
+	for _, s := range skills {
+		if defined[s.Name] {
+			return fmt.Errorf("skill %s is defined more than once", s.Name)
+		}
+		defined[s.Name] = true
+	}
+	for _, a := range agents {
+		for _, name := range a.Skills {
+			if !defined[name] {
+				return fmt.Errorf("agent %s: unknown skill %q", a.QualifiedName(), name)
+			}
+		}
+	}
+	return nil
+}
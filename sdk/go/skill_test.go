@@ -0,0 +1,80 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSkillValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		skill   Skill
+		wantErr string
+	}{
+		{"minimal", Skill{Name: "review"}, ""},
+		{"schemas", Skill{
+			Name:    "review",
+			Inputs:  map[string]any{"type": "object", "properties": map[string]any{"diff": map[string]any{"type": "string"}}},
+			Outputs: map[string]any{"type": "array", "minItems": 1},
+		}, ""},
+		{"no name", Skill{}, "name is required"},
+		{"bad inputs", Skill{Name: "review", Inputs: map[string]any{"type": "text"}}, "inputs is not a valid JSON Schema"},
+		{"bad outputs", Skill{Name: "review", Outputs: map[string]any{"minItems": -1}}, "outputs is not a valid JSON Schema"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.skill.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckSkillReferences(t *testing.T) {
+	skills := []*Skill{{Name: "review"}, {Name: "planning"}}
+	agents := []*Agent{
+		{Name: "pm", Skills: []string{"planning"}},
+		{Name: "qa", Namespace: "shared", Skills: []string{"review", "planning"}},
+	}
+	if err := CheckSkillReferences(agents, skills); err != nil {
+		t.Errorf("CheckSkillReferences() = %v", err)
+	}
+
+	agents[1].Skills = append(agents[1].Skills, "fuzzing")
+	err := CheckSkillReferences(agents, skills)
+	if err == nil || err.Error() != `agent shared/qa: unknown skill "fuzzing"` {
+		t.Errorf("CheckSkillReferences() = %v, want unknown skill", err)
+	}
+
+	err = CheckSkillReferences(nil, append(skills, &Skill{Name: "review"}))
+	if err == nil || err.Error() != "skill review is defined more than once" {
+		t.Errorf("CheckSkillReferences() = %v, want duplicate skill", err)
+	}
+}
+
+func TestValidateSkillJSON(t *testing.T) {
+	skill := Skill{
+		Name:           "review",
+		Description:    "Review a diff",
+		Inputs:         map[string]any{"type": "object"},
+		Implementation: "mcp:github/review",
+	}
+	data, err := json.Marshal(skill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateSkillJSON(data); err != nil {
+		t.Errorf("ValidateSkillJSON() = %v", err)
+	}
+	if err := ValidateSkillJSON([]byte(`{"description": "no name"}`)); err == nil {
+		t.Error("expected error for skill without name")
+	}
+}
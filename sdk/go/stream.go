@@ -0,0 +1,101 @@
+package multiagentspec
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+)
+
+// maxResultLine is the longest NDJSON line ReadNDJSON accepts.
+const maxResultLine = 16 << 20
+
+// StreamAggregator builds a TeamReport incrementally from AgentResults as
+// they arrive, as AggregateResults does for a complete set. A result for a
+// step already seen replaces that step's team, so a retried step reports
+// its latest result. It is not safe for concurrent use.
+type StreamAggregator struct {
+	report   *TeamReport
+	index    map[string]int // team ID to position in report.Teams
+	workflow *Workflow
+	outputs  map[string]map[string]interface{} // outputs of the steps that ran
+}
+
+// NewStreamAggregator returns an aggregator with an empty report.
+func NewStreamAggregator(project, version, phase string) *StreamAggregator {
+	report := newAggregateReport(project, version, phase)
+	report.Teams = []TeamSection{}
+	report.Status = report.ComputeOverallStatus()
+	return &StreamAggregator{report: report, index: make(map[string]int)}
+}
+
+// CheckContracts makes Add check each result against its step's ports in
+// w (see Workflow.CheckContract), comparing inputs with the outputs of
+// results added before it. A result that violates its contract gets a
+// contract task listing the violations, which its team's status counts.
+func (a *StreamAggregator) CheckContracts(w *Workflow) {
+	a.workflow = w
+	a.outputs = make(map[string]map[string]interface{})
+}
+
+// Add adds or replaces the team for result's step, falling back to the
+// agent ID when the result has no step ID, and recomputes the overall
+// status.
+func (a *StreamAggregator) Add(result AgentResult) {
+	if a.workflow != nil {
+		if violations := a.workflow.CheckContract(result, a.outputs); len(violations) > 0 {
+			task := contractTask(violations)
+			result.Tasks = append(append([]TaskResult(nil), result.Tasks...), task)
+		}
+		if result.Status != StatusSkip {
+			a.outputs[result.StepID] = result.Outputs
+		}
+	}
+	team := result.ToTeamSection()
+	if team.ID == "" {
+		team.ID = result.AgentID
+	}
+	if i, ok := a.index[team.ID]; ok {
+		a.report.Teams[i] = team
+	} else {
+		a.index[team.ID] = len(a.report.Teams)
+		a.report.Teams = append(a.report.Teams, team)
+	}
+	a.report.GeneratedAt = time.Now().UTC()
+	a.report.Status = a.report.ComputeOverallStatus()
+}
+
+// Report returns a snapshot of the report so far. Callers may reorder or
+// modify its teams, as the renderers do, without affecting the aggregator.
+func (a *StreamAggregator) Report() *TeamReport {
+	report := *a.report
+	report.Teams = append([]TeamSection(nil), a.report.Teams...)
+	return &report
+}
+
+// ReadNDJSON reads newline-delimited AgentResult JSON from r until EOF,
+// adding each result and calling onUpdate, if set, with a snapshot of the
+// report after each. Blank lines are skipped. It stops at the first line
+// that does not parse or the first error from onUpdate.
+func (a *StreamAggregator) ReadNDJSON(r io.Reader, onUpdate func(*TeamReport) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64<<10), maxResultLine)
+	for line := 1; sc.Scan(); line++ {
+		data := bytes.TrimSpace(sc.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		result, err := ParseAgentResult(data)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		a.Add(*result)
+		if onUpdate != nil {
+			if err := onUpdate(a.Report()); err != nil {
+				return err
+			}
+		}
+	}
+	return sc.Err()
+}
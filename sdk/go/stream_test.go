@@ -0,0 +1,73 @@
+package multiagentspec
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStreamAggregator(t *testing.T) {
+	input := `{"agent_id":"qa","step_id":"qa-validation","tasks":[{"id":"unit","status":"GO"}]}
+
+{"agent_id":"security","step_id":"security-audit","tasks":[{"id":"scan","status":"NO-GO"}]}
+{"agent_id":"security","step_id":"security-audit","tasks":[{"id":"scan","status":"WARN"}]}
+{"agent_id":"docs","tasks":[{"id":"links","status":"GO"}]}
+`
+	agg := NewStreamAggregator("app", "v1.0.0", "REVIEW")
+	var statuses []Status
+	err := agg.ReadNDJSON(strings.NewReader(input), func(r *TeamReport) error {
+		statuses = append(statuses, r.Status)
+		r.Teams = nil // snapshots are the caller's to modify
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadNDJSON() = %v", err)
+	}
+	if want := []Status{StatusGo, StatusNoGo, StatusWarn, StatusWarn}; !equalStatuses(statuses, want) {
+		t.Errorf("statuses = %v, want %v", statuses, want)
+	}
+
+	report := agg.Report()
+	var ids []string
+	for _, team := range report.Teams {
+		ids = append(ids, team.ID)
+	}
+	if got := strings.Join(ids, ","); got != "qa-validation,security-audit,docs" {
+		t.Errorf("teams = %s", got)
+	}
+	if report.Teams[1].Status != StatusWarn {
+		t.Errorf("retried team status = %s, want its latest, WARN", report.Teams[1].Status)
+	}
+	if report.Project != "app" || report.Target != "v1.0.0" || report.Schema == "" || report.GeneratedAt.IsZero() {
+		t.Errorf("report = %+v", report)
+	}
+}
+
+func TestStreamAggregatorErrors(t *testing.T) {
+	agg := NewStreamAggregator("app", "v1.0.0", "REVIEW")
+	err := agg.ReadNDJSON(strings.NewReader("{\"agent_id\":\"qa\"}\nnot json\n"), nil)
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("ReadNDJSON() = %v, want a line 2 error", err)
+	}
+	if len(agg.Report().Teams) != 1 {
+		t.Errorf("teams before the bad line = %d, want 1", len(agg.Report().Teams))
+	}
+
+	stop := errors.New("stop")
+	err = agg.ReadNDJSON(strings.NewReader("{\"agent_id\":\"docs\"}\n{\"agent_id\":\"ops\"}\n"), func(*TeamReport) error { return stop })
+	if !errors.Is(err, stop) || len(agg.Report().Teams) != 2 {
+		t.Errorf("ReadNDJSON() = %v with %d teams, want stop after the first result", err, len(agg.Report().Teams))
+	}
+}
+
+func equalStatuses(a, b []Status) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
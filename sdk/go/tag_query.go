@@ -0,0 +1,166 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TagQuery is a boolean expression over report tags, such as
+// "customer=acme AND environment=prod". Terms are
+//
+//	key=value    the tag is set to value
+//	key!=value   the tag is unset or set to another value
+//	key          the tag is set
+//	!key         the tag is unset
+//
+// joined with AND and OR, AND binding tighter. Keywords are
+// case-insensitive; keys and values are not and may not contain spaces.
+// An empty query matches every report.
+type TagQuery struct {
+	raw string
+	any [][]tagTerm // OR of ANDs
+}
+
+type tagTerm struct {
+	key, value string
+	op         string // one of =, !=, exists, absent
+}
+
+// ParseTagQuery parses a tag query.
+func ParseTagQuery(s string) (*TagQuery, error) {
+	q := &TagQuery{raw: strings.TrimSpace(s)}
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return q, nil
+	}
+	var all []tagTerm
+	expectTerm := true
+	for _, f := range fields {
+		kw := strings.ToUpper(f)
+		if kw == "AND" || kw == "OR" {
+			if expectTerm {
+				return nil, fmt.Errorf("invalid tag query %q: %s without a term before it", s, kw)
+			}
+			if kw == "OR" {
+				q.any = append(q.any, all)
+				all = nil
+			}
+			expectTerm = true
+			continue
+		}
+		if !expectTerm {
+			return nil, fmt.Errorf("invalid tag query %q: missing AND or OR before %q", s, f)
+		}
+		term, err := parseTagTerm(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag query %q: %w", s, err)
+		}
+		all = append(all, term)
+		expectTerm = false
+	}
+	if expectTerm {
+		return nil, fmt.Errorf("invalid tag query %q: ends without a term", s)
+	}
+	q.any = append(q.any, all)
+	return q, nil
+}
+
+func parseTagTerm(s string) (tagTerm, error) {
+	if i := strings.Index(s, "!="); i >= 0 {
+		return tagTerm{key: s[:i], value: s[i+2:], op: "!="}, checkTagKey(s[:i], s)
+	}
+	if i := strings.IndexByte(s, '='); i >= 0 {
+		return tagTerm{key: s[:i], value: s[i+1:], op: "="}, checkTagKey(s[:i], s)
+	}
+	if key, ok := strings.CutPrefix(s, "!"); ok {
+		return tagTerm{key: key, op: "absent"}, checkTagKey(key, s)
+	}
+	return tagTerm{key: s, op: "exists"}, nil
+}
+
+func checkTagKey(key, term string) error {
+	if key == "" {
+		return fmt.Errorf("term %q has no tag key", term)
+	}
+	return nil
+}
+
+// String returns the query as written.
+func (q *TagQuery) String() string {
+	return q.raw
+}
+
+// Matches reports whether tags satisfy the query.
+func (q *TagQuery) Matches(tags map[string]string) bool {
+	if len(q.any) == 0 {
+		return true
+	}
+	for _, all := range q.any {
+		ok := true
+		for _, t := range all {
+			ok = ok && t.matches(tags)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (t tagTerm) matches(tags map[string]string) bool {
+	v, set := tags[t.key]
+	switch t.op {
+	case "=":
+		return set && v == t.value
+	case "!=":
+		return !set || v != t.value
+	case "exists":
+		return set
+	default:
+		return !set
+	}
+}
+
+// TagGroup counts reports sharing a tag value by overall status.
+type TagGroup struct {
+	// Value is the tag value, or empty for reports without the tag.
+	Value string `json:"value"`
+
+	// Reports is the number of reports in the group.
+	Reports int `json:"reports"`
+
+	// Statuses counts the reports by overall status.
+	Statuses map[Status]int `json:"statuses"`
+}
+
+// GroupByTag groups reports by the value of tag key, sorted by value with
+// reports lacking the tag first. A report's status is its Status, or the
+// computed one when unset.
+func GroupByTag(reports []*TeamReport, key string) []TagGroup {
+	groups := make(map[string]*TagGroup)
+	for _, r := range reports {
+		v := r.Tags[key]
+		g, ok := groups[v]
+		if !ok {
+			g = &TagGroup{Value: v, Statuses: make(map[Status]int)}
+			groups[v] = g
+		}
+		g.Reports++
+		g.Statuses[r.effectiveStatus()]++
+	}
+	out := make([]TagGroup, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Value < out[j].Value })
+	return out
+}
+
+// effectiveStatus returns Status, or ComputeOverallStatus when it is unset.
+func (r *TeamReport) effectiveStatus() Status {
+	if r.Status != "" {
+		return r.Status
+	}
+	return r.ComputeOverallStatus()
+}
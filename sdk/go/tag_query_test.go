@@ -0,0 +1,62 @@
+package multiagentspec
+
+import "testing"
+
+func TestTagQuery(t *testing.T) {
+	acmeProd := map[string]string{"customer": "acme", "environment": "prod"}
+	acmeStaging := map[string]string{"customer": "acme", "environment": "staging"}
+	globex := map[string]string{"customer": "globex"}
+
+	tests := []struct {
+		query string
+		want  []bool // acmeProd, acmeStaging, globex
+	}{
+		{"", []bool{true, true, true}},
+		{"customer=acme AND environment=prod", []bool{true, false, false}},
+		{"customer=acme and environment!=prod", []bool{false, true, false}},
+		{"environment=prod OR customer=globex", []bool{true, false, true}},
+		{"customer=globex OR customer=acme AND environment=staging", []bool{false, true, true}},
+		{"environment", []bool{true, true, false}},
+		{"!environment", []bool{false, false, true}},
+		{"region=eu", []bool{false, false, false}},
+	}
+	for _, tt := range tests {
+		q, err := ParseTagQuery(tt.query)
+		if err != nil {
+			t.Fatalf("ParseTagQuery(%q): %v", tt.query, err)
+		}
+		for i, tags := range []map[string]string{acmeProd, acmeStaging, globex} {
+			if got := q.Matches(tags); got != tt.want[i] {
+				t.Errorf("%q.Matches(%v) = %v, want %v", tt.query, tags, got, tt.want[i])
+			}
+		}
+	}
+
+	for _, bad := range []string{"AND customer=acme", "customer=acme OR", "customer=acme environment=prod", "=acme", "!"} {
+		if _, err := ParseTagQuery(bad); err == nil {
+			t.Errorf("ParseTagQuery(%q) accepted an invalid query", bad)
+		}
+	}
+}
+
+func TestGroupByTag(t *testing.T) {
+	reports := []*TeamReport{
+		{Tags: map[string]string{"customer": "acme"}, Status: StatusGo},
+		{Tags: map[string]string{"customer": "globex"}, Status: StatusNoGo},
+		{Tags: map[string]string{"customer": "acme"}, Teams: []TeamSection{{Status: StatusWarn}}},
+		{},
+	}
+	groups := GroupByTag(reports, "customer")
+	if len(groups) != 3 {
+		t.Fatalf("groups = %+v", groups)
+	}
+	if g := groups[0]; g.Value != "" || g.Reports != 1 || g.Statuses[StatusGo] != 1 {
+		t.Errorf("untagged group = %+v", g)
+	}
+	if g := groups[1]; g.Value != "acme" || g.Reports != 2 || g.Statuses[StatusGo] != 1 || g.Statuses[StatusWarn] != 1 {
+		t.Errorf("acme group = %+v", g)
+	}
+	if g := groups[2]; g.Value != "globex" || g.Statuses[StatusNoGo] != 1 {
+		t.Errorf("globex group = %+v", g)
+	}
+}
@@ -0,0 +1,71 @@
+package multiagentspec
+
+import (
+	"strconv"
+	"time"
+)
+
+// PassTask returns a GO task result.
+func PassTask(id, detail string) TaskResult {
+	return TaskResult{ID: id, Status: StatusGo, Detail: detail}
+}
+
+// FailTask returns a NO-GO task result with err as its detail. severity
+// is the impact level (critical, high, medium, low, info), or empty.
+func FailTask(id string, err error, severity string) TaskResult {
+	task := TaskResult{ID: id, Status: StatusNoGo, Severity: severity}
+	if err != nil {
+		task.Detail = err.Error()
+	}
+	return task
+}
+
+// WarnTask returns a WARN task result. severity is the impact level, or
+// empty.
+func WarnTask(id, detail, severity string) TaskResult {
+	return TaskResult{ID: id, Status: StatusWarn, Severity: severity, Detail: detail}
+}
+
+// SkipTask returns a SKIP task result with the reason it was skipped.
+func SkipTask(id, reason string) TaskResult {
+	return TaskResult{ID: id, Status: StatusSkip, Detail: reason}
+}
+
+// TimeTask runs fn and returns its task result with DurationMs set: GO if
+// fn returns nil, otherwise NO-GO with the error as its detail.
+func TimeTask(id string, fn func() error) TaskResult {
+	start := time.Now()
+	err := fn()
+	var task TaskResult
+	if err != nil {
+		task = FailTask(id, err, "")
+	} else {
+		task = PassTask(id, "")
+	}
+	task.DurationMs = time.Since(start).Milliseconds()
+	return task
+}
+
+// durationText formats a task duration given in milliseconds, e.g.,
+// "340ms", "1.2s", or "2m05s".
+func durationText(ms int64) string {
+	var buf [24]byte
+	return string(appendDuration(buf[:0], ms))
+}
+
+// appendDuration appends durationText(ms) to dst.
+func appendDuration(dst []byte, ms int64) []byte {
+	switch {
+	case ms < 1000:
+		return append(strconv.AppendInt(dst, ms, 10), "ms"...)
+	case ms < 60000:
+		return append(strconv.AppendFloat(dst, float64(ms)/1000, 'f', 1, 64), 's')
+	}
+	d := time.Duration(ms) * time.Millisecond
+	dst = append(strconv.AppendInt(dst, int64(d.Minutes()), 10), 'm')
+	sec := int64(d.Seconds()) % 60
+	if sec < 10 {
+		dst = append(dst, '0')
+	}
+	return append(strconv.AppendInt(dst, sec, 10), 's')
+}
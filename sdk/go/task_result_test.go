@@ -0,0 +1,60 @@
+package multiagentspec
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskConstructors(t *testing.T) {
+	tests := []struct {
+		name string
+		got  TaskResult
+		want TaskResult
+	}{
+		{"pass", PassTask("unit", "42 passed"), TaskResult{ID: "unit", Status: StatusGo, Detail: "42 passed"}},
+		{"fail", FailTask("deps", errors.New("CVE-2024-1234"), "high"), TaskResult{ID: "deps", Status: StatusNoGo, Severity: "high", Detail: "CVE-2024-1234"}},
+		{"fail nil error", FailTask("deps", nil, ""), TaskResult{ID: "deps", Status: StatusNoGo}},
+		{"warn", WarnTask("lint", "3 warnings", "low"), TaskResult{ID: "lint", Status: StatusWarn, Severity: "low", Detail: "3 warnings"}},
+		{"skip", SkipTask("e2e", "no browser"), TaskResult{ID: "e2e", Status: StatusSkip, Detail: "no browser"}},
+	}
+	for _, tt := range tests {
+		if tt.got.ID != tt.want.ID || tt.got.Status != tt.want.Status || tt.got.Severity != tt.want.Severity || tt.got.Detail != tt.want.Detail {
+			t.Errorf("%s = %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestTimeTask(t *testing.T) {
+	task := TimeTask("build", func() error {
+		time.Sleep(5 * time.Millisecond)
+		return nil
+	})
+	if task.ID != "build" || task.Status != StatusGo || task.DurationMs < 5 {
+		t.Errorf("TimeTask = %+v", task)
+	}
+
+	task = TimeTask("build", func() error { return errors.New("compile error") })
+	if task.Status != StatusNoGo || task.Detail != "compile error" {
+		t.Errorf("TimeTask = %+v", task)
+	}
+}
+
+func TestDurationText(t *testing.T) {
+	tests := []struct {
+		ms   int64
+		want string
+	}{
+		{0, "0ms"},
+		{340, "340ms"},
+		{1000, "1.0s"},
+		{1234, "1.2s"},
+		{59900, "59.9s"},
+		{125000, "2m05s"},
+	}
+	for _, tt := range tests {
+		if got := durationText(tt.ms); got != tt.want {
+			t.Errorf("durationText(%d) = %q, want %q", tt.ms, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package multiagentspec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// maxTaskOutput is how much of a command's output, from the end, a task
+// result keeps in its "output" metadata.
+const maxTaskOutput = 4096
+
+// commandWaitDelay is how long a canceled command's children may hold its
+// output open before the runner stops waiting for them.
+const commandWaitDelay = time.Second
+
+// TaskRunner runs an agent's command, pattern, and file tasks locally, for
+// developing and debugging task definitions. A task that fails is NO-GO
+// if it is required and WARN otherwise; manual tasks are skipped.
+type TaskRunner struct {
+	// Dir is the directory commands run in and pattern and file paths are
+	// relative to, the current directory if empty.
+	Dir string
+
+	// Env is the commands' environment in os.Environ form, the current
+	// process's if nil.
+	Env []string
+
+	// Timeout bounds each task, unlimited if zero.
+	Timeout time.Duration
+}
+
+// Run runs task and returns its result with DurationMs set:
+//
+//   - command tasks run Command in a shell and pass if it exits with
+//     status 0; their exit code and the tail of their output are in
+//     Metadata "exit_code" and "output"
+//   - pattern tasks search Files, a glob such as "**/*.go" (every file if
+//     empty), for Pattern and pass if nothing matches, like a search for
+//     hardcoded secrets; the first match is in Metadata "file" and "line"
+//   - file tasks pass if File exists
+//
+// A task without a type runs as the type its fields imply.
+func (r *TaskRunner) Run(ctx context.Context, task Task) TaskResult {
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	var result TaskResult
+	switch taskType(task) {
+	case TaskTypeCommand:
+		result = r.runCommand(ctx, task)
+	case TaskTypePattern:
+		result = r.runPattern(ctx, task)
+	case TaskTypeFile:
+		result = r.runFile(task)
+	case TaskTypeManual:
+		result = SkipTask(task.ID, "manual task")
+		if task.HumanInLoop != "" {
+			result.Detail += ": " + task.HumanInLoop
+		}
+		return result
+	default:
+		return SkipTask(task.ID, fmt.Sprintf("unknown task type %q", task.Type))
+	}
+	result.ID = task.ID
+	if result.Status == StatusNoGo && !task.IsRequired() {
+		result.Status = StatusWarn
+	}
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// RunAgent runs the agent's tasks with the given IDs, or all of them, and
+// returns the results as an AgentResult. It fails if an ID names no task.
+func (r *TaskRunner) RunAgent(ctx context.Context, a *Agent, ids ...string) (*AgentResult, error) {
+	tasks := a.Tasks
+	if len(ids) > 0 {
+		tasks = make([]Task, 0, len(ids))
+		for _, id := range ids {
+			task, ok := findTask(a, id)
+			if !ok {
+				return nil, fmt.Errorf("agent %s has no task %q", a.QualifiedName(), id)
+			}
+			tasks = append(tasks, task)
+		}
+	}
+	result := &AgentResult{
+		Schema:     SchemaURL(SchemaAgentResult),
+		AgentID:    a.QualifiedName(),
+		StepID:     a.Name,
+		Tasks:      []TaskResult{},
+		ExecutedAt: time.Now().UTC(),
+		AgentModel: string(a.Model),
+	}
+	start := time.Now()
+	for _, task := range tasks {
+		result.Tasks = append(result.Tasks, r.Run(ctx, task))
+	}
+	result.Duration = time.Since(start).Round(time.Millisecond).String()
+	result.Status = result.ComputeStatus()
+	return result, nil
+}
+
+// IsRequired returns true unless Required is set to false.
+func (t Task) IsRequired() bool {
+	return t.Required == nil || *t.Required
+}
+
+func findTask(a *Agent, id string) (Task, bool) {
+	for _, t := range a.Tasks {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Task{}, false
+}
+
+// taskType returns the task's type, or the one its fields imply.
+func taskType(task Task) TaskType {
+	switch {
+	case task.Type != "":
+		return task.Type
+	case task.Command != "":
+		return TaskTypeCommand
+	case task.Pattern != "":
+		return TaskTypePattern
+	case task.File != "":
+		return TaskTypeFile
+	case task.HumanInLoop != "":
+		return TaskTypeManual
+	}
+	return ""
+}
+
+func (r *TaskRunner) runCommand(ctx context.Context, task Task) TaskResult {
+	if strings.TrimSpace(task.Command) == "" {
+		return FailTask(task.ID, errors.New("command task has no command"), "")
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", task.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", task.Command)
+	}
+	cmd.Dir = r.Dir
+	cmd.Env = r.Env
+	cmd.WaitDelay = commandWaitDelay
+	out, err := cmd.CombinedOutput()
+
+	result := PassTask(task.ID, lastLine(out))
+	exitCode := 0
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		result = FailTask(task.ID, fmt.Errorf("command did not finish: %w", ctx.Err()), "")
+		exitCode = -1
+	case errors.As(err, &exitErr):
+		exitCode = exitErr.ExitCode()
+		result = FailTask(task.ID, fmt.Errorf("exit status %d", exitCode), "")
+		if line := lastLine(out); line != "" {
+			result.Detail += ": " + line
+		}
+	case err != nil:
+		return FailTask(task.ID, err, "")
+	}
+	if len(out) > maxTaskOutput {
+		out = out[len(out)-maxTaskOutput:]
+	}
+	result.Metadata = map[string]interface{}{"exit_code": exitCode, "output": string(out)}
+	return result
+}
+
+// lastLine returns the last non-blank line of output.
+func lastLine(output []byte) string {
+	output = bytes.TrimRight(output, " \t\r\n")
+	if i := bytes.LastIndexByte(output, '\n'); i >= 0 {
+		output = output[i+1:]
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func (r *TaskRunner) runPattern(ctx context.Context, task Task) TaskResult {
+	re, err := regexp.Compile(task.Pattern)
+	if err != nil {
+		return FailTask(task.ID, fmt.Errorf("invalid pattern: %w", err), "")
+	}
+	dir := r.Dir
+	if dir == "" {
+		dir = "."
+	}
+	var matches int
+	var first string
+	var firstLine int
+	var files int
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if rel != "." && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if task.Files != "" && !matchGlob(task.Files, rel) {
+			return nil
+		}
+		files++
+		n, line, err := countMatches(p, re)
+		if err != nil {
+			return err
+		}
+		if n > 0 && first == "" {
+			first, firstLine = rel, line
+		}
+		matches += n
+		return nil
+	})
+	if err != nil {
+		return FailTask(task.ID, err, "")
+	}
+	if matches == 0 {
+		return PassTask(task.ID, fmt.Sprintf("no matches in %d files", files))
+	}
+	result := FailTask(task.ID, fmt.Errorf("%d matches, first at %s:%d", matches, first, firstLine), "")
+	result.Metadata = map[string]interface{}{"file": first, "line": firstLine, "matches": matches}
+	return result
+}
+
+// countMatches returns the number of lines of the file at p that match
+// re and the number of the first. Binary files have no matches.
+func countMatches(p string, re *regexp.Regexp) (n, first int, err error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	br := bufio.NewReader(f)
+	if head, _ := br.Peek(8000); bytes.IndexByte(head, 0) >= 0 {
+		return 0, 0, nil
+	}
+	sc := bufio.NewScanner(br)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if re.Match(sc.Bytes()) {
+			if n == 0 {
+				first = line
+			}
+			n++
+		}
+	}
+	return n, first, sc.Err()
+}
+
+// matchGlob reports whether the slash-separated name matches pattern, in
+// which "**" matches any number of directories and a pattern without a
+// "/" matches base names, e.g., "*.go" matches "cmd/main.go".
+func matchGlob(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") && !strings.Contains(pattern, "**") {
+		ok, _ := path.Match(pattern, path.Base(name))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func (r *TaskRunner) runFile(task Task) TaskResult {
+	if task.File == "" {
+		return FailTask(task.ID, errors.New("file task has no file"), "")
+	}
+	p := task.File
+	if !filepath.IsAbs(p) && r.Dir != "" {
+		p = filepath.Join(r.Dir, p)
+	}
+	var result TaskResult
+	if _, err := os.Stat(p); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return FailTask(task.ID, err, "")
+		}
+		result = FailTask(task.ID, fmt.Errorf("%s does not exist", task.File), "")
+	} else {
+		result = PassTask(task.ID, task.File+" exists")
+	}
+	result.Metadata = map[string]interface{}{"file": task.File}
+	return result
+}
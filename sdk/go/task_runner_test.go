@@ -0,0 +1,140 @@
+package multiagentspec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskRunnerRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands use sh")
+	}
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "main.go"), "package main\n\nconst apiKey = \"sk-123\"\n")
+	writeFile(t, filepath.Join(dir, "pkg", "util.go"), "package pkg\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "api_key: see docs\n")
+	writeFile(t, filepath.Join(dir, ".git", "config"), "apiKey = \"x\"\n")
+	optional := false
+	r := &TaskRunner{Dir: dir}
+
+	tests := []struct {
+		name       string
+		task       Task
+		wantStatus Status
+		wantDetail string
+	}{
+		{"command passes", Task{ID: "t", Type: TaskTypeCommand, Command: "echo building; echo ok"}, StatusGo, "ok"},
+		{"command fails", Task{ID: "t", Type: TaskTypeCommand, Command: "echo 2 failures; exit 3"}, StatusNoGo, "exit status 3: 2 failures"},
+		{"command runs in dir", Task{ID: "t", Command: "test -f main.go"}, StatusGo, ""},
+		{"optional command fails", Task{ID: "t", Type: TaskTypeCommand, Command: "false", Required: &optional}, StatusWarn, "exit status 1"},
+		{"pattern matches", Task{ID: "t", Type: TaskTypePattern, Pattern: `apiKey = "`, Files: "**/*.go"}, StatusNoGo, "1 matches, first at main.go:3"},
+		{"pattern misses", Task{ID: "t", Type: TaskTypePattern, Pattern: `password`, Files: "**/*.go"}, StatusGo, "no matches in 2 files"},
+		{"pattern base-name glob", Task{ID: "t", Type: TaskTypePattern, Pattern: `api_?[kK]ey`, Files: "*.md"}, StatusNoGo, "1 matches, first at README.md:1"},
+		{"pattern all files", Task{ID: "t", Type: TaskTypePattern, Pattern: `api_?[kK]ey`}, StatusNoGo, "2 matches, first at README.md:1"},
+		{"invalid pattern", Task{ID: "t", Type: TaskTypePattern, Pattern: `(`}, StatusNoGo, "invalid pattern: error parsing regexp: missing closing ): `(`"},
+		{"file exists", Task{ID: "t", Type: TaskTypeFile, File: "pkg/util.go"}, StatusGo, "pkg/util.go exists"},
+		{"file missing", Task{ID: "t", Type: TaskTypeFile, File: "LICENSE"}, StatusNoGo, "LICENSE does not exist"},
+		{"manual", Task{ID: "t", Type: TaskTypeManual, HumanInLoop: "review the diff"}, StatusSkip, "manual task: review the diff"},
+		{"unknown type", Task{ID: "t", Type: "remote"}, StatusSkip, `unknown task type "remote"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Run(context.Background(), tt.task)
+			if got.ID != "t" || got.Status != tt.wantStatus || got.Detail != tt.wantDetail {
+				t.Errorf("Run() = %s %s %q, want %s %q", got.ID, got.Status, got.Detail, tt.wantStatus, tt.wantDetail)
+			}
+		})
+	}
+
+	got := r.Run(context.Background(), Task{ID: "secrets", Pattern: `sk-\d+`, Files: "**/*.go"})
+	if loc := got.Location(); loc != "main.go:3" {
+		t.Errorf("Location() = %q, want main.go:3", loc)
+	}
+	got = r.Run(context.Background(), Task{ID: "build", Command: "echo out; exit 2"})
+	if got.Metadata["exit_code"] != 2 || got.Metadata["output"] != "out\n" {
+		t.Errorf("Metadata = %v", got.Metadata)
+	}
+}
+
+func TestTaskRunnerTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands use sh")
+	}
+	r := &TaskRunner{Timeout: 50 * time.Millisecond}
+	got := r.Run(context.Background(), Task{ID: "slow", Command: "sleep 5"})
+	if got.Status != StatusNoGo || !strings.HasPrefix(got.Detail, "command did not finish") {
+		t.Errorf("Run() = %s %q, want NO-GO after timeout", got.Status, got.Detail)
+	}
+}
+
+func TestTaskRunnerRunAgent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("commands use sh")
+	}
+	a := &Agent{
+		Name:      "qa",
+		Namespace: "shared",
+		Model:     ModelSonnet,
+		Tasks: []Task{
+			{ID: "lint", Command: "true"},
+			{ID: "test", Command: "false"},
+		},
+	}
+	r := &TaskRunner{}
+	result, err := r.RunAgent(context.Background(), a, "lint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.AgentID != "shared/qa" || result.StepID != "qa" || result.AgentModel != "sonnet" ||
+		result.Schema != SchemaURL(SchemaAgentResult) || len(result.Tasks) != 1 || result.Status != StatusGo {
+		t.Errorf("RunAgent(lint) = %+v", result)
+	}
+
+	result, err = r.RunAgent(context.Background(), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Tasks) != 2 || result.Status != StatusNoGo {
+		t.Errorf("RunAgent() = %d tasks, %s; want 2 tasks, NO-GO", len(result.Tasks), result.Status)
+	}
+
+	if _, err := r.RunAgent(context.Background(), a, "deploy"); err == nil || err.Error() != `agent shared/qa has no task "deploy"` {
+		t.Errorf("RunAgent(deploy) error = %v", err)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/mas/main.go", true},
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "cmd/mas/main.go", true},
+		{"cmd/**", "cmd/mas/main.go", true},
+		{"cmd/*.go", "cmd/mas/main.go", false},
+		{"cmd/**/main.go", "cmd/main.go", true},
+		{"**/*.go", "main.md", false},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -49,6 +49,14 @@ const (
 	WorkflowCouncil WorkflowType = "council"
 )
 
+// WorkflowTypes returns all workflow types, deterministic types first.
+func WorkflowTypes() []WorkflowType {
+	return []WorkflowType{
+		WorkflowChain, WorkflowScatter, WorkflowGraph,
+		WorkflowCrew, WorkflowSwarm, WorkflowCouncil,
+	}
+}
+
 // Category returns the workflow category for this type.
 func (w WorkflowType) Category() WorkflowCategory {
 	switch w {
@@ -83,6 +91,14 @@ const (
 	PortTypeFile    PortType = "file"
 )
 
+// PortTypes returns all port data types in schema order.
+func PortTypes() []PortType {
+	return []PortType{
+		PortTypeString, PortTypeNumber, PortTypeBoolean,
+		PortTypeObject, PortTypeArray, PortTypeFile,
+	}
+}
+
 // Port represents a typed input or output for a workflow step.
 type Port struct {
 	// Name is the port identifier (e.g., version_recommendation, test_results).
@@ -123,6 +139,12 @@ type Step struct {
 
 	// Outputs are typed data outputs produced by this step.
 	Outputs []Port `json:"outputs,omitempty"`
+
+	// When is a condition on upstream outputs, referenced as
+	// 'step_name.output_name' (e.g., "review.approved == true"). The step runs
+	// only when the condition holds. Supports ==, !=, <, <=, >, >=, &&, ||, !,
+	// and string, number, boolean, and null literals.
+	When string `json:"when,omitempty" jsonschema_description:"Condition on upstream outputs (step_name.output_name); the step runs only when it holds"`
 }
 
 // Workflow represents a workflow definition.
@@ -134,6 +156,54 @@ type Workflow struct {
 	Steps []Step `json:"steps,omitempty"`
 }
 
+// Levels groups the steps by depth in their dependency DAG, e.g., to show
+// them in waves or run each level's steps in parallel: level 0 holds the
+// steps that depend on no other, and each other step is one level after
+// its deepest dependency. In a chain, a step without depends_on follows
+// the step before it, as the executor runs it. Steps keep their order
+// within a level. Unknown steps, and a dependency that would close a
+// cycle, are ignored.
+func (w *Workflow) Levels() [][]Step {
+	var levels [][]Step
+	for _, level := range dagLevels(w.dependencyGraph()) {
+		steps := make([]Step, len(level))
+		for i, s := range level {
+			steps[i] = w.Steps[s]
+		}
+		levels = append(levels, steps)
+	}
+	return levels
+}
+
+// dependencyGraph returns, for each step by index, the indexes of the
+// steps it runs after, ignoring unknown steps.
+func (w *Workflow) dependencyGraph() [][]int {
+	index := make(map[string]int, len(w.Steps))
+	for i, s := range w.Steps {
+		if _, ok := index[s.Name]; !ok {
+			index[s.Name] = i
+		}
+	}
+	deps := make([][]int, len(w.Steps))
+	for i := range w.Steps {
+		for _, dep := range stepDependencies(w.Steps, i, w.Type == WorkflowChain) {
+			if d, ok := index[dep]; ok {
+				deps[i] = append(deps[i], d)
+			}
+		}
+	}
+	return deps
+}
+
+// stepDependencies returns the steps that steps[i] runs after: its
+// depends_on, or in a chain, the step before it when it has none.
+func stepDependencies(steps []Step, i int, chain bool) []string {
+	if deps := steps[i].DependsOn; len(deps) > 0 || !chain || i == 0 {
+		return deps
+	}
+	return []string{steps[i-1].Name}
+}
+
 // Team represents a team definition.
 type Team struct {
 	// Name is the team identifier (e.g., stats-agent-team).
@@ -145,8 +215,9 @@ type Team struct {
 	// Description is a brief summary of the team's purpose.
 	Description string `json:"description,omitempty"`
 
-	// Agents is the list of agent names in the team.
-	Agents []string `json:"agents"`
+	// Agents is the list of agent names in the team. An entry may pin
+	// compatible versions with name@constraint (e.g., security-analyst@^2).
+	Agents []string `json:"agents" jsonschema_description:"Agent names, optionally pinned to compatible versions as name@constraint (e.g., security-analyst@^2)"`
 
 	// Orchestrator is the name of the orchestrator agent.
 	Orchestrator string `json:"orchestrator,omitempty"`
@@ -157,16 +228,20 @@ type Team struct {
 	// Context is shared background information for all agents.
 	Context string `json:"context,omitempty"`
 
+	// Variables are values for {{ .vars.name }} placeholders in agent
+	// instructions. Deployment variables override them.
+	Variables map[string]string `json:"variables,omitempty" jsonschema_description:"Values for {{ .vars.name }} placeholders in agent instructions"`
+
 	// Self-directed workflow fields
 
 	// Collaboration defines how agents interact in self-directed workflows.
-	Collaboration *CollaborationConfig `json:"collaboration,omitempty"`
+	Collaboration *CollaborationConfig `json:"collaboration,omitempty" jsonschema_description:"Collaboration configuration for self-directed workflows"`
 
 	// SelfClaim allows agents to self-claim tasks from a shared queue (swarm).
-	SelfClaim bool `json:"self_claim,omitempty"`
+	SelfClaim bool `json:"self_claim,omitempty" jsonschema:"default=false" jsonschema_description:"Allow agents to self-claim tasks from shared queue (swarm workflow)"`
 
 	// PlanApproval requires plan approval before implementation (crew).
-	PlanApproval bool `json:"plan_approval,omitempty"`
+	PlanApproval bool `json:"plan_approval,omitempty" jsonschema:"default=false" jsonschema_description:"Require plan approval before implementation (crew workflow)"`
 }
 
 // NewTeam creates a new Team with the given name and version.
@@ -234,8 +309,14 @@ func (t *Team) WithPlanApproval(planApproval bool) *Team {
 }
 
 // Validate checks team configuration consistency.
-// Returns an error if the configuration is invalid for the workflow type.
+// Returns an error if an agent entry is malformed or the configuration is
+// invalid for the workflow type.
 func (t *Team) Validate() error {
+	for _, entry := range t.Agents {
+		if _, err := ParseAgentRef(entry); err != nil {
+			return err
+		}
+	}
 	if t.Workflow == nil {
 		return nil
 	}
@@ -2,6 +2,7 @@ package multiagentspec
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -245,6 +246,49 @@ func TestWorkflowSerialization(t *testing.T) {
 	}
 }
 
+func TestWorkflowLevels(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow Workflow
+		want     [][]string
+	}{
+		{"graph", Workflow{Type: WorkflowGraph, Steps: []Step{
+			{Name: "release", DependsOn: []string{"review", "test"}},
+			{Name: "build"},
+			{Name: "lint", DependsOn: []string{"build"}},
+			{Name: "test", DependsOn: []string{"build", "unknown"}},
+			{Name: "review", DependsOn: []string{"lint"}},
+		}}, [][]string{{"build"}, {"lint", "test"}, {"review"}, {"release"}}},
+		{"chain", Workflow{Type: WorkflowChain, Steps: []Step{
+			{Name: "plan"},
+			{Name: "docs", DependsOn: []string{"plan"}},
+			{Name: "code", DependsOn: []string{"plan"}},
+			{Name: "check"},
+		}}, [][]string{{"plan"}, {"docs", "code"}, {"check"}}},
+		{"scatter", Workflow{Type: WorkflowScatter, Steps: []Step{{Name: "a"}, {Name: "b"}}},
+			[][]string{{"a", "b"}}},
+		{"cycle", Workflow{Type: WorkflowGraph, Steps: []Step{
+			{Name: "a", DependsOn: []string{"b"}},
+			{Name: "b", DependsOn: []string{"a"}},
+		}}, [][]string{{"b"}, {"a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, level := range tt.workflow.Levels() {
+				var names []string
+				for _, s := range level {
+					names = append(names, s.Name)
+				}
+				got = append(got, names)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Levels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewTeam(t *testing.T) {
 	team := NewTeam("test-team", "1.0.0")
 
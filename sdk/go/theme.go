@@ -0,0 +1,96 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Theme is the set of characters that draw the box format, so output can
+// suit the terminal, document, or comment it appears in. Each character
+// must be one column wide.
+type Theme struct {
+	// Name identifies the theme, as accepted by ThemeByName.
+	Name string
+
+	// TopLeft, TopRight, BottomLeft, and BottomRight are the box's corners.
+	TopLeft, TopRight, BottomLeft, BottomRight string
+
+	// Horizontal and Vertical draw the box's borders. SeparatorLeft and
+	// SeparatorRight end the lines between sections.
+	Horizontal, Vertical, SeparatorLeft, SeparatorRight string
+
+	// TableColumn separates the columns of table content blocks, and
+	// TableRule and TableCross draw the rule under their headers.
+	TableColumn, TableRule, TableCross string
+
+	// Arrow ends the TableRule arrows between the levels of the workflow
+	// diagram drawn by WithDAG. Themes without one draw ">".
+	Arrow string
+
+	// Fence wraps the output in a Markdown code fence, so it keeps its
+	// alignment in GitHub comments and other Markdown.
+	Fence bool
+}
+
+var (
+	// ThemeDouble draws double lines. It is the default.
+	ThemeDouble = Theme{
+		Name:    "double",
+		TopLeft: "╔", TopRight: "╗", BottomLeft: "╚", BottomRight: "╝",
+		Horizontal: "═", Vertical: "║", SeparatorLeft: "╠", SeparatorRight: "╣",
+		TableColumn: "│", TableRule: "─", TableCross: "┼", Arrow: "▶",
+	}
+
+	// ThemeSingle draws single lines.
+	ThemeSingle = Theme{
+		Name:    "single",
+		TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘",
+		Horizontal: "─", Vertical: "│", SeparatorLeft: "├", SeparatorRight: "┤",
+		TableColumn: "│", TableRule: "─", TableCross: "┼", Arrow: "▶",
+	}
+
+	// ThemeRounded draws single lines with rounded corners, e.g., for
+	// documentation.
+	ThemeRounded = Theme{
+		Name:    "rounded",
+		TopLeft: "╭", TopRight: "╮", BottomLeft: "╰", BottomRight: "╯",
+		Horizontal: "─", Vertical: "│", SeparatorLeft: "├", SeparatorRight: "┤",
+		TableColumn: "│", TableRule: "─", TableCross: "┼", Arrow: "▶",
+	}
+
+	// ThemeASCII draws with ASCII characters only, for terminals and logs
+	// without box-drawing glyphs.
+	ThemeASCII = Theme{
+		Name:    "ascii",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+		Horizontal: "-", Vertical: "|", SeparatorLeft: "+", SeparatorRight: "+",
+		TableColumn: "|", TableRule: "-", TableCross: "+", Arrow: ">",
+	}
+
+	// ThemeMarkdown draws like ThemeASCII inside a fenced code block, for
+	// GitHub comments and other Markdown.
+	ThemeMarkdown = Theme{
+		Name:    "markdown",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+		Horizontal: "-", Vertical: "|", SeparatorLeft: "+", SeparatorRight: "+",
+		TableColumn: "|", TableRule: "-", TableCross: "+", Arrow: ">",
+		Fence: true,
+	}
+)
+
+// Themes returns the built-in themes, the default first.
+func Themes() []Theme {
+	return []Theme{ThemeDouble, ThemeSingle, ThemeRounded, ThemeASCII, ThemeMarkdown}
+}
+
+// ThemeByName returns the built-in theme with the given name.
+func ThemeByName(name string) (Theme, error) {
+	var names []string
+	for _, t := range Themes() {
+		if t.Name == name {
+			return t, nil
+		}
+		names = append(names, t.Name)
+	}
+	return Theme{}, fmt.Errorf("unknown theme %q (want %s)", name, strings.Join(names, ", "))
+}
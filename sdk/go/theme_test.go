@@ -0,0 +1,82 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func themeTestReport() *TeamReport {
+	return &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Teams: []TeamSection{
+			{ID: "qa", Name: "QA", Status: StatusGo,
+				Tasks:         []TaskResult{{ID: "unit", Status: StatusGo}},
+				ContentBlocks: []ContentBlock{NewTableBlock("Coverage", []string{"Package", "Percent"}, [][]string{{"core", "91%"}})}},
+		},
+	}
+}
+
+func TestRendererThemes(t *testing.T) {
+	for _, theme := range Themes() {
+		t.Run(theme.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := NewRenderer(&buf, WithTheme(theme)).Render(themeTestReport()); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			if theme.Fence {
+				if lines[0] != "```" || lines[len(lines)-1] != "```" {
+					t.Fatalf("output is not fenced:\n%s", buf.String())
+				}
+				lines = lines[1 : len(lines)-1]
+			}
+			if want := theme.TopLeft + strings.Repeat(theme.Horizontal, boxWidth) + theme.TopRight; lines[0] != want {
+				t.Errorf("top = %q, want %q", lines[0], want)
+			}
+			if want := theme.BottomLeft + strings.Repeat(theme.Horizontal, boxWidth) + theme.BottomRight; lines[len(lines)-1] != want {
+				t.Errorf("bottom = %q, want %q", lines[len(lines)-1], want)
+			}
+			for _, line := range lines {
+				if visualLength(line) != boxWidth+2 {
+					t.Errorf("line %q is %d columns, want %d", line, visualLength(line), boxWidth+2)
+				}
+			}
+			out := buf.String()
+			if !strings.Contains(out, "Package "+theme.TableColumn+" Percent") ||
+				!strings.Contains(out, theme.TableRule+theme.TableCross+theme.TableRule) {
+				t.Errorf("table not drawn with the theme:\n%s", out)
+			}
+			if theme.Name != ThemeDouble.Name && strings.ContainsAny(out, "╔║═╠") {
+				t.Errorf("output has double-line characters:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestRendererDefaultTheme(t *testing.T) {
+	var def, double bytes.Buffer
+	if err := NewRenderer(&def).Render(themeTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewRenderer(&double, WithTheme(ThemeDouble)).Render(themeTestReport()); err != nil {
+		t.Fatal(err)
+	}
+	if def.String() != double.String() {
+		t.Errorf("default output differs from ThemeDouble:\n%s\n%s", def.String(), double.String())
+	}
+}
+
+func TestThemeByName(t *testing.T) {
+	for _, theme := range Themes() {
+		got, err := ThemeByName(theme.Name)
+		if err != nil || got != theme {
+			t.Errorf("ThemeByName(%q) = %+v, %v", theme.Name, got, err)
+		}
+	}
+	if _, err := ThemeByName("fancy"); err == nil || !strings.Contains(err.Error(), "double, single, rounded, ascii, markdown") {
+		t.Errorf("ThemeByName(fancy) error = %v", err)
+	}
+}
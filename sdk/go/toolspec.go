@@ -0,0 +1,128 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToolBindingType is how a custom tool is executed.
+type ToolBindingType string
+
+const (
+	ToolBindingCommand ToolBindingType = "command"
+	ToolBindingHTTP    ToolBindingType = "http"
+	ToolBindingMCP     ToolBindingType = "mcp"
+)
+
+// ToolBindingTypes returns all tool binding types in schema order.
+func ToolBindingTypes() []ToolBindingType {
+	return []ToolBindingType{ToolBindingCommand, ToolBindingHTTP, ToolBindingMCP}
+}
+
+// ToolSpec defines a non-canonical tool agents can list in Agent.Tools,
+// e.g., a function-calling tool or a tool served over MCP.
+type ToolSpec struct {
+	// Schema is the JSON Schema reference.
+	Schema string `json:"$schema,omitempty" yaml:"$schema,omitempty"`
+
+	// Name is the tool identifier agents reference.
+	Name string `json:"name" yaml:"name" jsonschema_description:"Tool identifier agents reference; must not be a canonical tool name"`
+
+	// Description tells the model what the tool does and when to call it.
+	Description string `json:"description,omitempty" yaml:"description,omitempty" jsonschema_description:"What the tool does and when to call it"`
+
+	// Parameters is a JSON Schema for the tool's arguments.
+	Parameters map[string]any `json:"parameters,omitempty" yaml:"parameters,omitempty" jsonschema_description:"JSON Schema for the tool's arguments"`
+
+	// Binding is how the tool is executed.
+	Binding *ToolBinding `json:"binding,omitempty" yaml:"binding,omitempty"`
+}
+
+// ToolBinding connects a tool definition to its implementation.
+type ToolBinding struct {
+	// Type is how the tool is executed.
+	Type ToolBindingType `json:"type" yaml:"type"`
+
+	// Command is the executable to run (for command), receiving the
+	// arguments as JSON on stdin.
+	Command string `json:"command,omitempty" yaml:"command,omitempty" jsonschema_description:"Executable to run, receiving the arguments as JSON on stdin (command binding)"`
+
+	// Args are the command's arguments (for command).
+	Args []string `json:"args,omitempty" yaml:"args,omitempty" jsonschema_description:"Command arguments (command binding)"`
+
+	// URL is the endpoint the arguments are POSTed to (for http).
+	URL string `json:"url,omitempty" yaml:"url,omitempty" jsonschema_description:"Endpoint the arguments are POSTed to (http binding)"`
+
+	// Server is the MCP server providing the tool (for mcp).
+	Server string `json:"server,omitempty" yaml:"server,omitempty" jsonschema_description:"MCP server providing the tool (mcp binding)"`
+
+	// Tool is the tool's name on the MCP server (default: the spec name).
+	Tool string `json:"tool,omitempty" yaml:"tool,omitempty" jsonschema_description:"Tool name on the MCP server (default: the tool's name)"`
+}
+
+// Validate checks that the tool has a name that does not shadow a canonical
+// tool, that its parameters are a valid JSON Schema, and that its binding
+// has what its type needs.
+func (t *ToolSpec) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if isCanonicalTool(Tool(t.Name)) {
+		return fmt.Errorf("tool %s is canonical and cannot be redefined", t.Name)
+	}
+	if err := checkJSONSchema("parameters", t.Parameters); err != nil {
+		return err
+	}
+	if t.Binding != nil {
+		if err := t.Binding.Validate(); err != nil {
+			return fmt.Errorf("binding: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the binding has a known type and the fields it
+// needs.
+func (b *ToolBinding) Validate() error {
+	switch b.Type {
+	case ToolBindingCommand:
+		if b.Command == "" {
+			return fmt.Errorf("command binding requires command")
+		}
+	case ToolBindingHTTP:
+		if b.URL == "" {
+			return fmt.Errorf("http binding requires url")
+		}
+	case ToolBindingMCP:
+		if b.Server == "" {
+			return fmt.Errorf("mcp binding requires server")
+		}
+	case "":
+		return fmt.Errorf("type is required")
+	default:
+		return fmt.Errorf("unknown type %q", b.Type)
+	}
+	return nil
+}
+
+// CheckToolReferences verifies that tool names are unique and that every
+// entry in the agents' Tools is a canonical tool, a permission pattern on
+// one such as Bash(git:*), or one of tools.
+func CheckToolReferences(agents []*Agent, tools []*ToolSpec) error {
+	defined := make(map[string]bool, len(tools))
+	for _, t := range tools {
+		if defined[t.Name] {
+			return fmt.Errorf("tool %s is defined more than once", t.Name)
+		}
+		defined[t.Name] = true
+	}
+	for _, a := range agents {
+		for _, name := range a.Tools {
+			base, _, _ := strings.Cut(name, "(")
+			if !isCanonicalTool(Tool(base)) && !defined[name] {
+				return fmt.Errorf("agent %s: unknown tool %q", a.QualifiedName(), name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package multiagentspec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestToolSpecValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    ToolSpec
+		wantErr string
+	}{
+		{"minimal", ToolSpec{Name: "jira_search"}, ""},
+		{"full", ToolSpec{
+			Name:       "jira_search",
+			Parameters: map[string]any{"type": "object", "properties": map[string]any{"query": map[string]any{"type": "string"}}},
+			Binding:    &ToolBinding{Type: ToolBindingMCP, Server: "jira"},
+		}, ""},
+		{"http", ToolSpec{Name: "lookup", Binding: &ToolBinding{Type: ToolBindingHTTP, URL: "https://tools.internal/lookup"}}, ""},
+		{"no name", ToolSpec{}, "name is required"},
+		{"canonical", ToolSpec{Name: "Bash"}, "tool Bash is canonical and cannot be redefined"},
+		{"bad parameters", ToolSpec{Name: "lookup", Parameters: map[string]any{"required": "query"}}, "parameters is not a valid JSON Schema"},
+		{"no binding type", ToolSpec{Name: "lookup", Binding: &ToolBinding{}}, "binding: type is required"},
+		{"unknown binding", ToolSpec{Name: "lookup", Binding: &ToolBinding{Type: "grpc"}}, `binding: unknown type "grpc"`},
+		{"http without url", ToolSpec{Name: "lookup", Binding: &ToolBinding{Type: ToolBindingHTTP}}, "http binding requires url"},
+		{"mcp without server", ToolSpec{Name: "lookup", Binding: &ToolBinding{Type: ToolBindingMCP}}, "mcp binding requires server"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tool.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckToolReferences(t *testing.T) {
+	tools := []*ToolSpec{{Name: "jira_search"}}
+	agents := []*Agent{
+		{Name: "pm", Tools: []string{"Read", "jira_search"}},
+		{Name: "qa", Namespace: "shared", Tools: []string{"Bash(go test:*)", "Grep"}},
+	}
+	if err := CheckToolReferences(agents, tools); err != nil {
+		t.Errorf("CheckToolReferences() = %v", err)
+	}
+
+	agents[1].Tools = append(agents[1].Tools, "jira_create")
+	err := CheckToolReferences(agents, tools)
+	if err == nil || err.Error() != `agent shared/qa: unknown tool "jira_create"` {
+		t.Errorf("CheckToolReferences() = %v, want unknown tool", err)
+	}
+
+	err = CheckToolReferences(nil, append(tools, &ToolSpec{Name: "jira_search"}))
+	if err == nil || err.Error() != "tool jira_search is defined more than once" {
+		t.Errorf("CheckToolReferences() = %v, want duplicate tool", err)
+	}
+}
+
+func TestValidateToolJSON(t *testing.T) {
+	tool := ToolSpec{
+		Name:       "jira_search",
+		Parameters: map[string]any{"type": "object"},
+		Binding:    &ToolBinding{Type: ToolBindingMCP, Server: "jira"},
+	}
+	data, err := json.Marshal(tool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateToolJSON(data); err != nil {
+		t.Errorf("ValidateToolJSON() = %v", err)
+	}
+	if err := ValidateToolJSON([]byte(`{"name": "x", "binding": {"type": "grpc"}}`)); err == nil {
+		t.Error("expected error for unknown binding type")
+	}
+}
@@ -0,0 +1,67 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// TraceparentEnv is the environment variable through which a traced runner
+// passes the W3C trace context of an agent's span to the agent process.
+const TraceparentEnv = "TRACEPARENT"
+
+// ParseTraceparent parses a W3C traceparent header
+// (version-traceid-spanid-flags, e.g.,
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01) and returns the
+// trace and span IDs.
+func ParseTraceparent(traceparent string) (traceID, spanID string, err error) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 {
+		return "", "", fmt.Errorf("invalid traceparent %q: want version-traceid-spanid-flags", traceparent)
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	switch {
+	case !isLowerHex(version, 2) || version == "ff":
+		return "", "", fmt.Errorf("invalid traceparent %q: bad version", traceparent)
+	case version == "00" && len(parts) != 4:
+		return "", "", fmt.Errorf("invalid traceparent %q: want 4 fields for version 00", traceparent)
+	case !isLowerHex(traceID, 32) || traceID == strings.Repeat("0", 32):
+		return "", "", fmt.Errorf("invalid traceparent %q: bad trace ID", traceparent)
+	case !isLowerHex(spanID, 16) || spanID == strings.Repeat("0", 16):
+		return "", "", fmt.Errorf("invalid traceparent %q: bad span ID", traceparent)
+	case !isLowerHex(flags, 2):
+		return "", "", fmt.Errorf("invalid traceparent %q: bad flags", traceparent)
+	}
+	return traceID, spanID, nil
+}
+
+// SetTraceContext sets the result's trace and span IDs from a W3C
+// traceparent header. An empty traceparent falls back to the
+// TraceparentEnv environment variable and, when that is unset too, leaves
+// the result unchanged.
+func (a *AgentResult) SetTraceContext(traceparent string) error {
+	if traceparent == "" {
+		traceparent = os.Getenv(TraceparentEnv)
+	}
+	if traceparent == "" {
+		return nil
+	}
+	traceID, spanID, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return err
+	}
+	a.TraceID, a.SpanID = traceID, spanID
+	return nil
+}
+
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package multiagentspec
+
+import "testing"
+
+func TestParseTraceparent(t *testing.T) {
+	traceID, spanID, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil || traceID != "4bf92f3577b34da6a3ce929d0e0e4736" || spanID != "00f067aa0ba902b7" {
+		t.Errorf("ParseTraceparent = %q, %q, %v", traceID, spanID, err)
+	}
+	// Future versions may append fields.
+	if _, _, err := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"); err != nil {
+		t.Errorf("future version: %v", err)
+	}
+
+	for _, tp := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1",
+	} {
+		if _, _, err := ParseTraceparent(tp); err == nil {
+			t.Errorf("ParseTraceparent(%q) should fail", tp)
+		}
+	}
+}
+
+func TestAgentResultSetTraceContext(t *testing.T) {
+	t.Setenv(TraceparentEnv, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	var r AgentResult
+	if err := r.SetTraceContext(""); err != nil {
+		t.Fatalf("SetTraceContext from env: %v", err)
+	}
+	if r.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || r.SpanID != "00f067aa0ba902b7" {
+		t.Errorf("result = %+v", r)
+	}
+	if err := r.SetTraceContext("bogus"); err == nil {
+		t.Error("SetTraceContext should reject an invalid traceparent")
+	}
+
+	section := r.ToTeamSection()
+	if section.TraceID != r.TraceID || section.SpanID != r.SpanID {
+		t.Errorf("section trace = %q/%q", section.TraceID, section.SpanID)
+	}
+	r.AgentID, r.StepID = "qa", "qa-validation"
+	data, err := AggregateResults([]AgentResult{r}, "p", "v1.0.0", "REVIEW").ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateTeamReportJSON(data); err != nil {
+		t.Errorf("report with trace IDs fails schema: %v", err)
+	}
+
+	t.Setenv(TraceparentEnv, "")
+	var empty AgentResult
+	if err := empty.SetTraceContext(""); err != nil || empty.TraceID != "" {
+		t.Errorf("SetTraceContext without context = %v, %+v", err, empty)
+	}
+}
@@ -0,0 +1,351 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Trace exporters accepted in TracingConfig.Exporter.
+const (
+	// TraceExporterOTLP posts spans to an OpenTelemetry collector as
+	// OTLP/HTTP JSON.
+	TraceExporterOTLP = "otlp"
+
+	// TraceExporterConsole writes spans as JSON lines, for debugging.
+	TraceExporterConsole = "console"
+)
+
+// DefaultOTLPEndpoint is the collector spans are posted to when
+// TracingConfig.Endpoint is empty.
+const DefaultOTLPEndpoint = "http://localhost:4318/v1/traces"
+
+// Span is a finished span of a workflow run's trace: the run, one of its
+// steps, or an attempt at a step.
+type Span struct {
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	Name         string            `json:"name"`
+	Start        time.Time         `json:"start"`
+	End          time.Time         `json:"end"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+
+	// Error is why the span's operation failed, or empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// SpanExporter sends finished spans to a tracing backend.
+type SpanExporter interface {
+	ExportSpans(ctx context.Context, spans []Span) error
+}
+
+// SpanExporterFunc adapts a function to SpanExporter.
+type SpanExporterFunc func(ctx context.Context, spans []Span) error
+
+// ExportSpans calls f.
+func (f SpanExporterFunc) ExportSpans(ctx context.Context, spans []Span) error {
+	return f(ctx, spans)
+}
+
+// Tracer records the spans of workflow runs, as WithTracer enables them,
+// and exports those of sampled runs once each run finishes.
+type Tracer struct {
+	exporter   SpanExporter
+	sampleRate float64
+
+	mu    sync.Mutex
+	spans []Span
+}
+
+// NewTracer returns a tracer exporting to exporter and sampling the given
+// fraction of new traces, from 0 to 1. Runs that continue a trace follow
+// its sampled flag.
+func NewTracer(exporter SpanExporter, sampleRate float64) *Tracer {
+	return &Tracer{exporter: exporter, sampleRate: sampleRate}
+}
+
+// Tracer returns a tracer for the config, with a console exporter writing
+// to w, or nil if c is nil or not enabled. An empty exporter is otlp, and
+// a zero sample rate samples every trace.
+func (c *TracingConfig) Tracer(w io.Writer) (*Tracer, error) {
+	if c == nil || !c.Enabled {
+		return nil, nil
+	}
+	rate := c.SampleRate
+	if rate < 0 || rate > 1 {
+		return nil, fmt.Errorf("invalid sample_rate %v (want 0 to 1)", rate)
+	}
+	if rate == 0 {
+		rate = 1
+	}
+	switch strings.ToLower(c.Exporter) {
+	case "", TraceExporterOTLP:
+		endpoint, err := otlpEndpoint(c.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return NewTracer(&otlpExporter{url: endpoint, client: &http.Client{Timeout: 10 * time.Second}}, rate), nil
+	case TraceExporterConsole:
+		return NewTracer(&consoleExporter{w: w}, rate), nil
+	}
+	return nil, fmt.Errorf("invalid trace exporter %q (want otlp or console)", c.Exporter)
+}
+
+// Validate checks the exporter, endpoint, and sample rate.
+func (c *TracingConfig) Validate() error {
+	_, err := c.Tracer(io.Discard)
+	return err
+}
+
+// sample decides whether a new trace is sampled. A nil tracer samples
+// every trace, as traces without one were before tracers existed.
+func (t *Tracer) sample() bool {
+	return t == nil || t.sampleRate >= 1 || rand.Float64() < t.sampleRate
+}
+
+// span is an open span; ending it records it with its tracer if its trace
+// is sampled.
+type span struct {
+	Span
+	tracer *Tracer
+	flags  string
+}
+
+// start opens a span named name in trace tc, a child of tc's parent, with
+// attributes given as key, value pairs. With a nil tracer, the span only
+// carries its trace context.
+func (t *Tracer) start(tc traceContext, name string, attrs ...string) *span {
+	s := &span{
+		Span: Span{
+			TraceID:      tc.traceID,
+			SpanID:       randomHex(8),
+			ParentSpanID: tc.parentID,
+			Name:         name,
+			Start:        time.Now(),
+		},
+		tracer: t,
+		flags:  tc.flags,
+	}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		s.set(attrs[i], attrs[i+1])
+	}
+	return s
+}
+
+// set sets an attribute of the span.
+func (s *span) set(key, value string) {
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]string)
+	}
+	s.Attributes[key] = value
+}
+
+// traceparent returns the span's W3C trace context.
+func (s *span) traceparent() string {
+	return "00-" + s.TraceID + "-" + s.SpanID + "-" + s.flags
+}
+
+// context returns the trace context of the span's children.
+func (s *span) context() traceContext {
+	return traceContext{traceID: s.TraceID, parentID: s.SpanID, flags: s.flags}
+}
+
+// end closes the span, failed with errMsg if it is not empty.
+func (s *span) end(errMsg string) {
+	if s.tracer == nil || !traceSampled(s.flags) {
+		return
+	}
+	s.End = time.Now()
+	s.Error = errMsg
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s.Span)
+}
+
+// flush exports the spans recorded since the last flush.
+func (t *Tracer) flush(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+	if len(spans) == 0 {
+		return nil
+	}
+	return t.exporter.ExportSpans(ctx, spans)
+}
+
+// traceSampled reports whether trace flags have the sampled bit set.
+func traceSampled(flags string) bool {
+	f, err := strconv.ParseUint(flags, 16, 8)
+	return err == nil && f&1 == 1
+}
+
+// otlpEndpoint returns the URL spans are posted to for endpoint: a URL,
+// or host:port, with /v1/traces as its path if it has none.
+func otlpEndpoint(endpoint string) (string, error) {
+	if endpoint == "" {
+		return DefaultOTLPEndpoint, nil
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("invalid trace endpoint %q (want an http URL or host:port)", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/v1/traces"
+	}
+	return u.String(), nil
+}
+
+// consoleExporter writes spans to w as JSON lines.
+type consoleExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (x *consoleExporter) ExportSpans(_ context.Context, spans []Span) error {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	enc := json.NewEncoder(x.w)
+	for _, s := range spans {
+		if err := enc.Encode(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// otlpExporter posts spans to an OpenTelemetry collector in the OTLP/HTTP
+// JSON encoding.
+type otlpExporter struct {
+	url    string
+	client *http.Client
+}
+
+func (x *otlpExporter) ExportSpans(ctx context.Context, spans []Span) error {
+	data, err := json.Marshal(otlpTraces(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, x.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := x.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("exporting spans to %s: %s: %s", x.url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// OTLP/HTTP JSON request types, as the collector's
+// ExportTraceServiceRequest expects them.
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              int             `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+		Status            *otlpStatus     `json:"status,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue string `json:"stringValue"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+)
+
+// OTLP span kind and status codes.
+const (
+	otlpKindInternal = 1
+	otlpStatusError  = 2
+)
+
+// otlpTraces converts spans to an OTLP export request.
+func otlpTraces(spans []Span) otlpRequest {
+	scope := otlpScopeSpans{Scope: otlpScope{Name: "github.com/plexusone/multi-agent-spec/sdk/go", Version: SpecVersion}}
+	for _, s := range spans {
+		out := otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			ParentSpanID:      s.ParentSpanID,
+			Name:              s.Name,
+			Kind:              otlpKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.Start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.End.UnixNano(), 10),
+			Attributes:        otlpAttributes(s.Attributes),
+		}
+		if s.Error != "" {
+			out.Status = &otlpStatus{Code: otlpStatusError, Message: s.Error}
+		}
+		scope.Spans = append(scope.Spans, out)
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: otlpAttributes(map[string]string{"service.name": "multi-agent-spec"})},
+		ScopeSpans: []otlpScopeSpans{scope},
+	}}}
+}
+
+// otlpAttributes returns attrs as OTLP string attributes, sorted by key.
+func otlpAttributes(attrs map[string]string) []otlpAttribute {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]otlpAttribute, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, otlpAttribute{Key: k, Value: otlpValue{StringValue: attrs[k]}})
+	}
+	return out
+}
@@ -0,0 +1,265 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// spanRecorder is a SpanExporter keeping the spans it exports.
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+func (r *spanRecorder) ExportSpans(_ context.Context, spans []Span) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+// byName returns the recorded spans by name.
+func (r *spanRecorder) byName() map[string]Span {
+	spans := make(map[string]Span)
+	for _, s := range r.spans {
+		spans[s.Name] = s
+	}
+	return spans
+}
+
+func TestExecutorTracer(t *testing.T) {
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	var mu sync.Mutex
+	traceparents := map[string]string{}
+	failed := false
+	runner := AgentRunnerFunc(func(ctx context.Context, run StepRun) (*AgentResult, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		traceparents[run.Step.Name] = run.Traceparent
+		if run.Step.Name == "build" && !failed {
+			failed = true
+			return nil, errors.New("connection reset")
+		}
+		return &AgentResult{Tasks: []TaskResult{PassTask("x", "")}}, nil
+	})
+	team := &Team{Name: "t", Agents: []string{"a", "b"}, Workflow: &Workflow{Type: WorkflowChain, Steps: []Step{
+		{Name: "build", Agent: "a"}, {Name: "test", Agent: "b"},
+	}}}
+	rt := &RuntimeConfig{Steps: map[string]*StepRuntime{"build": {Retry: &RetryPolicy{MaxAttempts: 1, InitialDelay: "1ms"}}}}
+	rec := &spanRecorder{}
+	e, err := NewExecutor(team, []*Agent{commandAgent("a", "x"), commandAgent("b", "x")},
+		WithTaskRunner(runner), WithRuntime(rt), WithTraceContext(parent), WithTracer(NewTracer(rec, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := rec.byName()
+	if len(rec.spans) != 6 {
+		t.Fatalf("exported %d spans, want run, 2 steps, and 3 attempts: %+v", len(rec.spans), rec.spans)
+	}
+	run := spans["run t"]
+	if run.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || run.ParentSpanID != "00f067aa0ba902b7" || run.Attributes["mas.status"] != "GO" {
+		t.Errorf("run span = %+v, want a GO child of %s", run, parent)
+	}
+	for _, team := range report.Teams {
+		step := spans["step "+team.ID]
+		if step.ParentSpanID != run.SpanID || step.TraceID != run.TraceID || step.Attributes["mas.status"] != "GO" {
+			t.Errorf("step %s span = %+v, want a GO child of the run span", team.ID, step)
+		}
+		if want := "00-" + step.TraceID + "-" + step.SpanID + "-01"; traceparents[team.ID] != want || team.SpanID != step.SpanID {
+			t.Errorf("step %s traceparent = %s, span = %s; want %s", team.ID, traceparents[team.ID], team.SpanID, want)
+		}
+		if step.End.Before(step.Start) || run.End.Before(step.End) {
+			t.Errorf("step %s span runs %s to %s, outside the run", team.ID, step.Start, step.End)
+		}
+	}
+	var attempts []string
+	for _, s := range rec.spans {
+		if strings.HasPrefix(s.Name, "attempt") {
+			attempts = append(attempts, s.Attributes["mas.step"]+" "+s.Name+" "+s.Error)
+			if s.ParentSpanID != spans["step "+s.Attributes["mas.step"]].SpanID {
+				t.Errorf("%s span of %s is not a child of its step", s.Name, s.Attributes["mas.step"])
+			}
+		}
+	}
+	want := []string{"build attempt 1 connection reset", "build attempt 2 ", "test attempt 1 "}
+	if strings.Join(attempts, "|") != strings.Join(want, "|") {
+		t.Errorf("attempt spans = %q, want %q", attempts, want)
+	}
+}
+
+func TestExecutorTracerFailedStep(t *testing.T) {
+	runner := AgentRunnerFunc(func(ctx context.Context, run StepRun) (*AgentResult, error) {
+		return nil, errors.New("boom")
+	})
+	rec := &spanRecorder{}
+	e, err := NewExecutor(&Team{Name: "t", Agents: []string{"a"}}, []*Agent{commandAgent("a", "x")},
+		WithTaskRunner(runner), WithTracer(NewTracer(rec, 1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	spans := rec.byName()
+	if run := spans["run t"]; run.Error != "run is NO-GO" || run.ParentSpanID != "" {
+		t.Errorf("run span = %+v, want a NO-GO root span", run)
+	}
+	if step := spans["step a"]; step.Error != "boom" || step.Attributes["mas.status"] != "NO-GO" {
+		t.Errorf("step span = %+v, want failed with boom", step)
+	}
+	if attempt := spans["attempt 1"]; attempt.Error != "boom" {
+		t.Errorf("attempt span = %+v, want failed with boom", attempt)
+	}
+}
+
+func TestExecutorTracerSampling(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceparent string
+		rate        float64
+		sampled     bool
+	}{
+		{"new trace, never sampled", "", 0, false},
+		{"new trace, always sampled", "", 1, true},
+		{"unsampled parent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", 1, false},
+		{"sampled parent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(TraceparentEnv, "")
+			var traceparent string
+			runner := AgentRunnerFunc(func(ctx context.Context, run StepRun) (*AgentResult, error) {
+				traceparent = run.Traceparent
+				return &AgentResult{Tasks: []TaskResult{PassTask("x", "")}}, nil
+			})
+			rec := &spanRecorder{}
+			opts := []ExecutorOption{WithTaskRunner(runner), WithTracer(NewTracer(rec, tt.rate))}
+			if tt.traceparent != "" {
+				opts = append(opts, WithTraceContext(tt.traceparent))
+			}
+			e, err := NewExecutor(&Team{Name: "t", Agents: []string{"a"}}, []*Agent{commandAgent("a", "x")}, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := e.Run(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			wantFlags, wantSpans := "-00", 0
+			if tt.sampled {
+				wantFlags, wantSpans = "-01", 3
+			}
+			if !strings.HasSuffix(traceparent, wantFlags) || len(rec.spans) != wantSpans {
+				t.Errorf("traceparent = %s with %d spans exported, want flags %s and %d spans", traceparent, len(rec.spans), wantFlags, wantSpans)
+			}
+		})
+	}
+}
+
+func TestTracingConfigTracer(t *testing.T) {
+	for _, cfg := range []*TracingConfig{nil, {Exporter: "jaeger"}} {
+		if tracer, err := cfg.Tracer(nil); tracer != nil || err != nil {
+			t.Errorf("Tracer(%+v) = %v, %v; want nil for tracing off", cfg, tracer, err)
+		}
+	}
+	for _, tt := range []struct {
+		cfg  TracingConfig
+		want string
+	}{
+		{TracingConfig{Enabled: true, Exporter: "jaeger"}, `invalid trace exporter "jaeger"`},
+		{TracingConfig{Enabled: true, SampleRate: 1.5}, "invalid sample_rate 1.5"},
+		{TracingConfig{Enabled: true, Endpoint: "ftp://collector"}, `invalid trace endpoint "ftp://collector"`},
+	} {
+		if err := tt.cfg.Validate(); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Validate(%+v) = %v, want %s", tt.cfg, err, tt.want)
+		}
+	}
+	for endpoint, want := range map[string]string{
+		"":                            DefaultOTLPEndpoint,
+		"collector:4318":              "http://collector:4318/v1/traces",
+		"https://collector/":          "https://collector/v1/traces",
+		"http://collector/otlp/spans": "http://collector/otlp/spans",
+	} {
+		if got, err := otlpEndpoint(endpoint); err != nil || got != want {
+			t.Errorf("otlpEndpoint(%q) = %q, %v; want %q", endpoint, got, err, want)
+		}
+	}
+
+	var buf bytes.Buffer
+	tracer, err := (&TracingConfig{Enabled: true, Exporter: TraceExporterConsole}).Tracer(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := NewExecutor(&Team{Name: "t", Agents: []string{"a"}}, []*Agent{commandAgent("a", "x")},
+		WithTaskRunner(&fakeRunner{}), WithTracer(tracer))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := e.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	var first Span
+	if len(lines) != 3 || json.Unmarshal([]byte(lines[0]), &first) != nil || first.TraceID == "" {
+		t.Errorf("console spans = %s, want 3 JSON lines", buf.String())
+	}
+}
+
+func TestExecutorRuntimeTracingOTLP(t *testing.T) {
+	var mu sync.Mutex
+	var got otlpRequest
+	var path, contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path, contentType = r.URL.Path, r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding export request: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	rt := &RuntimeConfig{Observability: &ObservabilityConfig{Tracing: &TracingConfig{Enabled: true, Endpoint: srv.URL}}}
+	e, err := NewExecutor(&Team{Name: "t", Agents: []string{"a"}}, []*Agent{commandAgent("a", "x")},
+		WithTaskRunner(&fakeRunner{}), WithRuntime(rt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := e.Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if path != "/v1/traces" || contentType != "application/json" {
+		t.Errorf("exported to %s as %s, want /v1/traces as JSON", path, contentType)
+	}
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("export request = %+v, want one resource and scope", got)
+	}
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	names := map[string]otlpSpan{}
+	for _, s := range spans {
+		names[s.Name] = s
+	}
+	step := names["step a"]
+	if len(spans) != 3 || step.SpanID != report.Teams[0].SpanID || step.ParentSpanID != names["run t"].SpanID || step.StartTimeUnixNano == "" {
+		t.Errorf("exported spans = %+v, want run, step a, and its attempt", spans)
+	}
+
+	rt.Observability.Tracing.Exporter = "zipkin"
+	if _, err := NewExecutor(&Team{Name: "t", Agents: []string{"a"}}, []*Agent{commandAgent("a", "x")}, WithRuntime(rt)); err == nil || !strings.HasPrefix(err.Error(), "team t: tracing: invalid trace exporter") {
+		t.Errorf("NewExecutor() error = %v, want invalid trace exporter", err)
+	}
+}
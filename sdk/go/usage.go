@@ -0,0 +1,96 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Usage is LLM token usage and cost.
+type Usage struct {
+	TokensIn  int64   `json:"tokens_in,omitempty"`
+	TokensOut int64   `json:"tokens_out,omitempty"`
+	CostUSD   float64 `json:"cost_usd,omitempty"`
+}
+
+// Add returns the sum of u and o.
+func (u Usage) Add(o Usage) Usage {
+	return Usage{
+		TokensIn:  u.TokensIn + o.TokensIn,
+		TokensOut: u.TokensOut + o.TokensOut,
+		CostUSD:   u.CostUSD + o.CostUSD,
+	}
+}
+
+// Tokens returns the total of input and output tokens.
+func (u Usage) Tokens() int64 {
+	return u.TokensIn + u.TokensOut
+}
+
+// IsZero reports whether no usage is recorded.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// Usage returns the task's token usage and cost.
+func (t *TaskResult) Usage() Usage {
+	return Usage{TokensIn: t.TokensIn, TokensOut: t.TokensOut, CostUSD: t.CostUSD}
+}
+
+// TotalUsage returns the agent's usage fields if any is set, otherwise the
+// sum over its tasks.
+func (a *AgentResult) TotalUsage() Usage {
+	return totalUsage(Usage{TokensIn: a.TokensIn, TokensOut: a.TokensOut, CostUSD: a.CostUSD}, a.Tasks)
+}
+
+// TotalUsage returns the team's usage fields if any is set, otherwise the
+// sum over its tasks.
+func (t *TeamSection) TotalUsage() Usage {
+	return totalUsage(Usage{TokensIn: t.TokensIn, TokensOut: t.TokensOut, CostUSD: t.CostUSD}, t.Tasks)
+}
+
+// TotalUsage returns the sum of TotalUsage over all teams.
+func (r *TeamReport) TotalUsage() Usage {
+	var total Usage
+	for i := range r.Teams {
+		total = total.Add(r.Teams[i].TotalUsage())
+	}
+	return total
+}
+
+func totalUsage(own Usage, tasks []TaskResult) Usage {
+	if !own.IsZero() {
+		return own
+	}
+	var total Usage
+	for i := range tasks {
+		total = total.Add(tasks[i].Usage())
+	}
+	return total
+}
+
+// CostSummaryBlock returns a table of token usage and cost per team, with a
+// total row, for teams that recorded usage. It returns false if no team did.
+func (r *TeamReport) CostSummaryBlock() (ContentBlock, bool) {
+	var rows [][]string
+	for i := range r.Teams {
+		u := r.Teams[i].TotalUsage()
+		if u.IsZero() {
+			continue
+		}
+		rows = append(rows, usageRow(r.Teams[i].Name, u))
+	}
+	if len(rows) == 0 {
+		return ContentBlock{}, false
+	}
+	rows = append(rows, usageRow("Total", r.TotalUsage()))
+	return NewTableBlock("Cost Summary", []string{"Team", "Tokens In", "Tokens Out", "Cost (USD)"}, rows), true
+}
+
+func usageRow(name string, u Usage) []string {
+	return []string{
+		name,
+		strconv.FormatInt(u.TokensIn, 10),
+		strconv.FormatInt(u.TokensOut, 10),
+		fmt.Sprintf("$%.4f", u.CostUSD),
+	}
+}
@@ -0,0 +1,118 @@
+package multiagentspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func usageReport() *TeamReport {
+	return &TeamReport{
+		Project: "app",
+		Version: "v1.0.0",
+		Phase:   "REVIEW",
+		Status:  StatusGo,
+		Teams: []TeamSection{
+			{
+				ID: "qa", Name: "qa", Status: StatusGo,
+				Tasks: []TaskResult{
+					{ID: "unit", Status: StatusGo, TokensIn: 1000, TokensOut: 200, CostUSD: 0.01},
+					{ID: "e2e", Status: StatusGo, TokensIn: 500, TokensOut: 100, CostUSD: 0.005},
+				},
+			},
+			{
+				ID: "security", Name: "security", Status: StatusGo,
+				TokensIn: 3000, TokensOut: 700, CostUSD: 0.05,
+				Tasks: []TaskResult{{ID: "deps", Status: StatusGo, TokensIn: 1}},
+			},
+			{ID: "docs", Name: "docs", Status: StatusGo, Tasks: []TaskResult{{ID: "readme", Status: StatusGo}}},
+		},
+	}
+}
+
+func TestTotalUsage(t *testing.T) {
+	report := usageReport()
+	if got, want := report.Teams[0].TotalUsage(), (Usage{TokensIn: 1500, TokensOut: 300, CostUSD: 0.015}); got != want {
+		t.Errorf("qa usage = %+v, want task sum %+v", got, want)
+	}
+	if got, want := report.Teams[1].TotalUsage(), (Usage{TokensIn: 3000, TokensOut: 700, CostUSD: 0.05}); got != want {
+		t.Errorf("security usage = %+v, want team fields %+v", got, want)
+	}
+	total := report.TotalUsage()
+	if total.TokensIn != 4500 || total.TokensOut != 1000 || total.Tokens() != 5500 {
+		t.Errorf("report usage = %+v", total)
+	}
+
+	result := AgentResult{AgentID: "qa", StepID: "qa", Tasks: report.Teams[0].Tasks}
+	if got := result.TotalUsage(); got != report.Teams[0].TotalUsage() {
+		t.Errorf("agent usage = %+v", got)
+	}
+	result.CostUSD = 1
+	if section := result.ToTeamSection(); section.CostUSD != 1 || section.TotalUsage().CostUSD != 1 {
+		t.Errorf("ToTeamSection usage = %+v", section)
+	}
+}
+
+func TestCostSummaryBlock(t *testing.T) {
+	block, ok := usageReport().CostSummaryBlock()
+	if !ok {
+		t.Fatal("CostSummaryBlock reported no usage")
+	}
+	want := [][]string{
+		{"qa", "1500", "300", "$0.0150"},
+		{"security", "3000", "700", "$0.0500"},
+		{"Total", "4500", "1000", "$0.0650"},
+	}
+	if len(block.Rows) != len(want) {
+		t.Fatalf("rows = %v, want %v", block.Rows, want)
+	}
+	for i := range want {
+		if strings.Join(block.Rows[i], ",") != strings.Join(want[i], ",") {
+			t.Errorf("row %d = %v, want %v", i, block.Rows[i], want[i])
+		}
+	}
+
+	if _, ok := (&TeamReport{Teams: []TeamSection{{ID: "qa"}}}).CostSummaryBlock(); ok {
+		t.Error("CostSummaryBlock reported usage for a report without any")
+	}
+}
+
+func TestRenderersCostSummary(t *testing.T) {
+	renders := map[string]func(*bytes.Buffer, *TeamReport) error{
+		"box":             func(b *bytes.Buffer, r *TeamReport) error { return NewRenderer(b).Render(r) },
+		"quick box":       func(b *bytes.Buffer, r *TeamReport) error { return NewQuickRenderer(b).Render(r) },
+		"narrative":       func(b *bytes.Buffer, r *TeamReport) error { return NewNarrativeRenderer(b).Render(r) },
+		"quick narrative": func(b *bytes.Buffer, r *TeamReport) error { return NewQuickNarrativeRenderer(b).Render(r) },
+		"gh-summary":      func(b *bytes.Buffer, r *TeamReport) error { return WriteGitHubSummary(b, r) },
+	}
+	for name, render := range renders {
+		var buf bytes.Buffer
+		if err := render(&buf, usageReport()); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if out := buf.String(); !strings.Contains(out, "Cost Summary") || !strings.Contains(out, "$0.0650") {
+			t.Errorf("%s output missing cost summary:\n%s", name, out)
+		}
+
+		buf.Reset()
+		plain := &TeamReport{Project: "app", Version: "v1", Teams: []TeamSection{{ID: "qa", Name: "qa", Status: StatusGo}}}
+		if err := render(&buf, plain); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if strings.Contains(buf.String(), "Cost Summary") {
+			t.Errorf("%s renders a cost summary without usage", name)
+		}
+	}
+}
+
+func TestUsageSchema(t *testing.T) {
+	report := usageReport()
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateTeamReportJSON(data); err != nil {
+		t.Errorf("schema rejects usage fields: %v", err)
+	}
+}
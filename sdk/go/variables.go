@@ -0,0 +1,151 @@
+package multiagentspec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// MergeVariables merges variable sets, later sets overriding earlier ones,
+// e.g., MergeVariables(team.Variables, deployment.Variables). It returns
+// nil when no set has variables.
+func MergeVariables(sets ...map[string]string) map[string]string {
+	var out map[string]string
+	for _, set := range sets {
+		for k, v := range set {
+			if out == nil {
+				out = make(map[string]string)
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// InstructionVariables returns the names of the variables the agent's
+// instructions reference as {{ .vars.name }}, sorted and without
+// duplicates.
+func (a *Agent) InstructionVariables() ([]string, error) {
+	t, err := parseInstructions(a.Instructions)
+	if err != nil || t == nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			collectVars(tmpl.Tree.Root, seen)
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// UnresolvedVariables returns the variables the agent's instructions
+// reference that vars does not define, sorted.
+func (a *Agent) UnresolvedVariables(vars map[string]string) ([]string, error) {
+	names, err := a.InstructionVariables()
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, name := range names {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// ResolveInstructions returns the agent's instructions with {{ .vars.name }}
+// placeholders replaced from vars. Instructions are Go text/template
+// source; referencing an undefined variable is an error. Instructions
+// without placeholders are returned unchanged.
+func (a *Agent) ResolveInstructions(vars map[string]string) (string, error) {
+	return resolveVariables("instructions", a.Instructions, vars)
+}
+
+// resolveVariables executes text as a named template with vars bound to
+// .vars, returning text unchanged when it has no actions.
+func resolveVariables(name, text string, vars map[string]string) (string, error) {
+	t, err := parseTemplate(name, text)
+	if err != nil || t == nil {
+		return text, err
+	}
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, map[string]any{"vars": vars}); err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// parseInstructions parses instructions as a template, returning nil when
+// they contain no actions.
+func parseInstructions(instructions string) (*template.Template, error) {
+	return parseTemplate("instructions", instructions)
+}
+
+// parseTemplate parses text as a template called name, returning nil when
+// it contains no actions.
+func parseTemplate(name, text string) (*template.Template, error) {
+	if !strings.Contains(text, "{{") {
+		return nil, nil
+	}
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return t, nil
+}
+
+// collectVars records the variable names referenced as .vars.name under n.
+func collectVars(n parse.Node, seen map[string]bool) {
+	switch n := n.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			collectVars(c, seen)
+		}
+	case *parse.ActionNode:
+		collectVars(n.Pipe, seen)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Cmds {
+			collectVars(c, seen)
+		}
+	case *parse.CommandNode:
+		for _, c := range n.Args {
+			collectVars(c, seen)
+		}
+	case *parse.FieldNode:
+		if len(n.Ident) >= 2 && n.Ident[0] == "vars" {
+			seen[n.Ident[1]] = true
+		}
+	case *parse.IfNode:
+		collectBranch(&n.BranchNode, seen)
+	case *parse.RangeNode:
+		collectBranch(&n.BranchNode, seen)
+	case *parse.WithNode:
+		collectBranch(&n.BranchNode, seen)
+	case *parse.TemplateNode:
+		collectVars(n.Pipe, seen)
+	}
+}
+
+func collectBranch(n *parse.BranchNode, seen map[string]bool) {
+	collectVars(n.Pipe, seen)
+	collectVars(n.List, seen)
+	collectVars(n.ElseList, seen)
+}
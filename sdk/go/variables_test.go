@@ -0,0 +1,84 @@
+package multiagentspec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeVariables(t *testing.T) {
+	got := MergeVariables(
+		map[string]string{"project_name": "atlas", "language": "Go"},
+		nil,
+		map[string]string{"project_name": "atlas-prod"},
+	)
+	want := map[string]string{"project_name": "atlas-prod", "language": "Go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MergeVariables = %v, want %v", got, want)
+	}
+	if got := MergeVariables(nil, map[string]string{}); got != nil {
+		t.Errorf("MergeVariables of empty sets = %v, want nil", got)
+	}
+}
+
+func TestAgentInstructionVariables(t *testing.T) {
+	a := &Agent{Name: "reviewer", Instructions: `Review {{ .vars.project_name }} changes.
+{{ if .vars.style_guide }}Follow {{ .vars.style_guide }}.{{ else }}Follow {{ .vars.language | printf "%s" }} conventions.{{ end }}
+{{ range .vars.repos }}{{ . }}{{ end }}`}
+	names, err := a.InstructionVariables()
+	if err != nil {
+		t.Fatalf("InstructionVariables: %v", err)
+	}
+	if want := []string{"language", "project_name", "repos", "style_guide"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("InstructionVariables = %v, want %v", names, want)
+	}
+
+	missing, err := a.UnresolvedVariables(map[string]string{"project_name": "atlas", "language": "Go"})
+	if err != nil {
+		t.Fatalf("UnresolvedVariables: %v", err)
+	}
+	if want := []string{"repos", "style_guide"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("UnresolvedVariables = %v, want %v", missing, want)
+	}
+
+	plain := &Agent{Name: "plain", Instructions: "No placeholders here."}
+	if names, err := plain.InstructionVariables(); err != nil || len(names) != 0 {
+		t.Errorf("InstructionVariables without placeholders = %v, %v", names, err)
+	}
+
+	broken := &Agent{Name: "broken", Instructions: "Review {{ .vars.project_name"}
+	if _, err := broken.UnresolvedVariables(nil); err == nil || !strings.HasPrefix(err.Error(), "instructions: ") {
+		t.Errorf("UnresolvedVariables error = %v, want parse error", err)
+	}
+}
+
+func TestAgentResolveInstructions(t *testing.T) {
+	a := &Agent{Name: "reviewer", Instructions: "Review {{ .vars.project_name }} changes in {{.vars.language}}."}
+	got, err := a.ResolveInstructions(map[string]string{"project_name": "atlas", "language": "Go"})
+	if err != nil {
+		t.Fatalf("ResolveInstructions: %v", err)
+	}
+	if want := "Review atlas changes in Go."; got != want {
+		t.Errorf("ResolveInstructions = %q, want %q", got, want)
+	}
+
+	if _, err := a.ResolveInstructions(map[string]string{"project_name": "atlas"}); err == nil || !strings.Contains(err.Error(), "language") {
+		t.Errorf("ResolveInstructions error = %v, want undefined language", err)
+	}
+
+	plain := &Agent{Name: "plain", Instructions: "Use 100% of the budget."}
+	if got, err := plain.ResolveInstructions(nil); err != nil || got != plain.Instructions {
+		t.Errorf("ResolveInstructions without placeholders = %q, %v", got, err)
+	}
+}
+
+func TestTeamVariablesJSON(t *testing.T) {
+	data := []byte(`{"name": "review-team", "version": "1.0.0", "agents": ["reviewer"], "variables": {"project_name": "atlas"}}`)
+	if err := ValidateTeamJSON(data); err != nil {
+		t.Fatalf("ValidateTeamJSON: %v", err)
+	}
+	bad := []byte(`{"name": "review-team", "version": "1.0.0", "agents": ["reviewer"], "variables": {"retries": 3}}`)
+	if err := ValidateTeamJSON(bad); err == nil {
+		t.Error("expected schema error for non-string variable")
+	}
+}
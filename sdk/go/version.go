@@ -0,0 +1,106 @@
+package multiagentspec
+
+import "strings"
+
+// SpecVersion is the multi-agent-spec version implemented by this package.
+// Schema $id URLs and $schema stamps are pinned to this version.
+const SpecVersion = "0.8.0"
+
+// schemaBaseURL is the raw content root for published schemas.
+const schemaBaseURL = "https://raw.githubusercontent.com/plexusone/multi-agent-spec"
+
+// SchemaKind identifies a spec document type with a published JSON Schema.
+type SchemaKind string
+
+const (
+	SchemaAgent         SchemaKind = "agent"
+	SchemaTeam          SchemaKind = "team"
+	SchemaDeployment    SchemaKind = "deployment"
+	SchemaTeamReport    SchemaKind = "team-report"
+	SchemaAgentResult   SchemaKind = "agent-result"
+	SchemaMessage       SchemaKind = "message"
+	SchemaLLMEvaluation SchemaKind = "llm-evaluation"
+	SchemaSkill         SchemaKind = "skill"
+	SchemaTool          SchemaKind = "tool"
+	SchemaRubric        SchemaKind = "rubric"
+	SchemaPrompt        SchemaKind = "prompt"
+)
+
+// schemaPaths maps schema kinds to their path below the repository root.
+var schemaPaths = map[SchemaKind]string{
+	SchemaAgent:         "schema/agent/agent.schema.json",
+	SchemaTeam:          "schema/orchestration/team.schema.json",
+	SchemaDeployment:    "schema/deployment/deployment.schema.json",
+	SchemaTeamReport:    "schema/report/team-report.schema.json",
+	SchemaAgentResult:   "schema/report/agent-result.schema.json",
+	SchemaMessage:       "schema/message/message.schema.json",
+	SchemaLLMEvaluation: "schema/report/llm-evaluation.schema.json",
+	SchemaSkill:         "schema/skill/skill.schema.json",
+	SchemaTool:          "schema/tool/tool.schema.json",
+	SchemaRubric:        "schema/rubric/rubric.schema.json",
+	SchemaPrompt:        "schema/prompt/prompt.schema.json",
+}
+
+// SchemaKinds returns all known schema kinds in a stable order.
+func SchemaKinds() []SchemaKind {
+	return []SchemaKind{
+		SchemaAgent, SchemaTeam, SchemaDeployment, SchemaTeamReport,
+		SchemaAgentResult, SchemaMessage, SchemaLLMEvaluation, SchemaSkill,
+		SchemaTool, SchemaRubric, SchemaPrompt,
+	}
+}
+
+// SchemaPath returns the repository-relative path of the schema file for kind,
+// or an empty string if the kind is unknown.
+func SchemaPath(kind SchemaKind) string {
+	return schemaPaths[kind]
+}
+
+// SchemaURL returns the versioned schema URL for kind at SpecVersion.
+func SchemaURL(kind SchemaKind) string {
+	return SchemaURLForVersion(kind, SpecVersion)
+}
+
+// SchemaURLForVersion returns the schema URL for kind at the given spec version.
+// An empty version refers to the unversioned main branch.
+func SchemaURLForVersion(kind SchemaKind, version string) string {
+	path, ok := schemaPaths[kind]
+	if !ok {
+		return ""
+	}
+	ref := "main"
+	if version != "" {
+		ref = "v" + strings.TrimPrefix(version, "v")
+	}
+	return schemaBaseURL + "/" + ref + "/" + path
+}
+
+// ParseSchemaURL extracts the schema kind and spec version from a schema URL.
+// Legacy organization URLs (agentplexus) and unversioned main-branch URLs are
+// recognized; the version is empty for main-branch URLs.
+// Returns ok=false if the URL does not reference a known schema.
+func ParseSchemaURL(url string) (kind SchemaKind, version string, ok bool) {
+	for _, prefix := range []string{
+		schemaBaseURL + "/",
+		"https://raw.githubusercontent.com/agentplexus/multi-agent-spec/",
+	} {
+		if !strings.HasPrefix(url, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(url, prefix)
+		ref, path, found := strings.Cut(rest, "/")
+		if !found {
+			return "", "", false
+		}
+		for k, p := range schemaPaths {
+			if p == path {
+				if ref == "main" {
+					return k, "", true
+				}
+				return k, strings.TrimPrefix(ref, "v"), true
+			}
+		}
+		return "", "", false
+	}
+	return "", "", false
+}
@@ -0,0 +1,49 @@
+package multiagentspec
+
+import "testing"
+
+func TestSchemaURL(t *testing.T) {
+	want := "https://raw.githubusercontent.com/plexusone/multi-agent-spec/v" + SpecVersion + "/schema/report/team-report.schema.json"
+	if got := SchemaURL(SchemaTeamReport); got != want {
+		t.Errorf("SchemaURL = %q, want %q", got, want)
+	}
+	if got := SchemaURLForVersion(SchemaTeam, ""); got != "https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/orchestration/team.schema.json" {
+		t.Errorf("unversioned SchemaURLForVersion = %q", got)
+	}
+	if got := SchemaURL("bogus"); got != "" {
+		t.Errorf("SchemaURL(unknown) = %q, want empty", got)
+	}
+}
+
+func TestParseSchemaURL(t *testing.T) {
+	tests := []struct {
+		url         string
+		wantKind    SchemaKind
+		wantVersion string
+		wantOK      bool
+	}{
+		{SchemaURL(SchemaAgent), SchemaAgent, SpecVersion, true},
+		{SchemaURLForVersion(SchemaDeployment, "v0.5.0"), SchemaDeployment, "0.5.0", true},
+		{"https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/report/agent-result.schema.json", SchemaAgentResult, "", true},
+		{"https://raw.githubusercontent.com/agentplexus/multi-agent-spec/main/schema/report/team-report.schema.json", SchemaTeamReport, "", true},
+		{"https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/unknown.json", "", "", false},
+		{"../schema/deployment.schema.json", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			kind, version, ok := ParseSchemaURL(tt.url)
+			if kind != tt.wantKind || version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("ParseSchemaURL = (%q, %q, %v), want (%q, %q, %v)",
+					kind, version, ok, tt.wantKind, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestAggregateResultsStampsVersionedSchema(t *testing.T) {
+	report := AggregateResults(nil, "p", "v1", "REVIEW")
+	if report.Schema != SchemaURL(SchemaTeamReport) {
+		t.Errorf("Schema = %q, want %q", report.Schema, SchemaURL(SchemaTeamReport))
+	}
+}
@@ -0,0 +1,202 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+"""
+
+from .agent import (
+    Agent,
+    Budget,
+    BudgetAction,
+    DelegationConfig,
+    Example,
+    Guardrails,
+    KnowledgeSource,
+    MCPServer,
+    MCPTransport,
+    Memory,
+    MemoryBackend,
+    MemoryType,
+    Model,
+    PIIType,
+    RateLimit,
+    RefreshPolicy,
+    Task,
+    TaskType,
+)
+from .team import (
+    Channel,
+    ChannelType,
+    CollaborationConfig,
+    ConsensusRules,
+    Port,
+    PortType,
+    Step,
+    Team,
+    Workflow,
+    WorkflowType,
+)
+from .deployment import (
+    ADKGoConfig,
+    AWSAgentCoreConfig,
+    AWSBedrockAgentsConfig,
+    AgentKitLocalConfig,
+    AutoGenConfig,
+    BedrockKnowledgeBase,
+    ClaudeCodeConfig,
+    CodeExecutionConfig,
+    CrewAIConfig,
+    Deployment,
+    DeploymentMode,
+    DockerComposeConfig,
+    Environment,
+    GeminiCLIConfig,
+    KiroCLIConfig,
+    KubernetesConfig,
+    LangGraphConfig,
+    LoggingConfig,
+    MetricsConfig,
+    N8NConfig,
+    ObservabilityConfig,
+    OpenAIAgentsConfig,
+    Platform,
+    Priority,
+    RateLimit,
+    ResourceLimits,
+    RetryPolicy,
+    RuntimeConfig,
+    SecretRef,
+    SecretSource,
+    SemanticKernelConfig,
+    StepRuntime,
+    Target,
+    TemporalConfig,
+    TracingConfig,
+    VertexAIConfig,
+)
+from .report import (
+    Artifact,
+    ContentBlock,
+    ContentBlockType,
+    Issue,
+    KVPair,
+    ListItem,
+    NarrativeSection,
+    Severity,
+    Status,
+    TaskResult,
+    TeamReport,
+    TeamSection,
+)
+from .message import (
+    Message,
+    MessageType,
+    Attachment,
+    AttachmentType,
+)
+from .skill import (
+    Skill,
+)
+from .tool import (
+    ToolBinding,
+    ToolBindingType,
+    ToolSpec,
+)
+from .rubric import (
+    Rubric,
+    RubricCategory,
+    ScoreAnchor,
+)
+from .prompt import (
+    PromptTemplate,
+)
+
+__all__ = [
+    "Agent",
+    "Budget",
+    "BudgetAction",
+    "DelegationConfig",
+    "Example",
+    "Guardrails",
+    "KnowledgeSource",
+    "MCPServer",
+    "MCPTransport",
+    "Memory",
+    "MemoryBackend",
+    "MemoryType",
+    "Model",
+    "PIIType",
+    "RateLimit",
+    "RefreshPolicy",
+    "Task",
+    "TaskType",
+    "Channel",
+    "ChannelType",
+    "CollaborationConfig",
+    "ConsensusRules",
+    "Port",
+    "PortType",
+    "Step",
+    "Team",
+    "Workflow",
+    "WorkflowType",
+    "ADKGoConfig",
+    "AWSAgentCoreConfig",
+    "AWSBedrockAgentsConfig",
+    "AgentKitLocalConfig",
+    "AutoGenConfig",
+    "BedrockKnowledgeBase",
+    "ClaudeCodeConfig",
+    "CodeExecutionConfig",
+    "CrewAIConfig",
+    "Deployment",
+    "DeploymentMode",
+    "DockerComposeConfig",
+    "Environment",
+    "GeminiCLIConfig",
+    "KiroCLIConfig",
+    "KubernetesConfig",
+    "LangGraphConfig",
+    "LoggingConfig",
+    "MetricsConfig",
+    "N8NConfig",
+    "ObservabilityConfig",
+    "OpenAIAgentsConfig",
+    "Platform",
+    "Priority",
+    "RateLimit",
+    "ResourceLimits",
+    "RetryPolicy",
+    "RuntimeConfig",
+    "SecretRef",
+    "SecretSource",
+    "SemanticKernelConfig",
+    "StepRuntime",
+    "Target",
+    "TemporalConfig",
+    "TracingConfig",
+    "VertexAIConfig",
+    "Artifact",
+    "ContentBlock",
+    "ContentBlockType",
+    "Issue",
+    "KVPair",
+    "ListItem",
+    "NarrativeSection",
+    "Severity",
+    "Status",
+    "TaskResult",
+    "TeamReport",
+    "TeamSection",
+    "Message",
+    "MessageType",
+    "Attachment",
+    "AttachmentType",
+    "Skill",
+    "ToolBinding",
+    "ToolBindingType",
+    "ToolSpec",
+    "Rubric",
+    "RubricCategory",
+    "ScoreAnchor",
+    "PromptTemplate",
+]
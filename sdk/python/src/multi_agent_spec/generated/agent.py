@@ -0,0 +1,222 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: agent/agent.schema.json
+"""
+
+from __future__ import annotations
+
+from enum import Enum
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class Model(str, Enum):
+    """Model capability tier (mapped to platform-specific models)"""
+
+    HAIKU = "haiku"
+    SONNET = "sonnet"
+    OPUS = "opus"
+
+
+class TaskType(str, Enum):
+    """How the task is executed"""
+
+    COMMAND = "command"
+    PATTERN = "pattern"
+    FILE = "file"
+    MANUAL = "manual"
+
+
+class Task(BaseModel):
+    """Task model."""
+
+    id: str
+    description: str | None = None
+    type: TaskType | None = None
+    command: str | None = None
+    pattern: str | None = None
+    file: str | None = None
+    files: str | None = None
+    required: bool | None = None
+    expected_output: str | None = None
+    human_in_loop: str | None = None
+    rubric: str | None = Field(None, description="Name of the rubric this evaluation task is graded against, overriding the agent's")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class MCPTransport(str, Enum):
+    """How the agent connects to the MCP server"""
+
+    STDIO = "stdio"
+    HTTP = "http"
+    SSE = "sse"
+
+
+class MCPServer(BaseModel):
+    """MCPServer model."""
+
+    name: str
+    transport: MCPTransport | None = None
+    command: str | None = Field(None, description="Executable that starts the server (stdio transport)")
+    args: list[str] | None = None
+    url: str | None = Field(None, description="Server endpoint (http and sse transports)")
+    allowed_tools: list[str] | None = Field(None, description="Server tools the agent may use (empty means all)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class DelegationConfig(BaseModel):
+    """Delegation permissions for self-directed workflows"""
+
+    allow_delegation: bool | None = Field(False, description="Whether this agent can delegate work to others")
+    can_delegate_to: list[str] | None = Field(None, description="Agent names this agent can delegate to (empty means no restrictions)")
+    can_receive_from: list[str] | None = Field(None, description="Agent names this agent can receive delegations from (empty means no restrictions)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class BudgetAction(str, Enum):
+    """What happens when the agent exceeds its budget"""
+
+    ABORT = "abort"
+    DOWNGRADE = "downgrade"
+    WARN = "warn"
+
+
+class Budget(BaseModel):
+    """Per-run cap on the agent's LLM usage; zero limits are unlimited"""
+
+    max_tokens: int | None = Field(None, description="Maximum input plus output tokens per run")
+    max_cost_usd: float | None = Field(None, description="Maximum LLM cost per run in US dollars")
+    on_exceed: BudgetAction | None = None
+    downgrade_to: Model | None = Field(None, description="Model tier to switch to with the downgrade action (default: the next cheaper tier)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class RateLimit(BaseModel):
+    """Cap on LLM provider calls; zero limits are unlimited"""
+
+    requests_per_minute: int | None = Field(None, description="Sustained request rate")
+    max_concurrent: int | None = Field(None, description="Maximum calls in flight at once")
+    burst: int | None = Field(None, description="Requests allowed at once above the sustained rate (default: 1)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class PIIType(str, Enum):
+    """Category of personally identifiable information to redact"""
+
+    EMAIL = "email"
+    PHONE = "phone"
+    SSN = "ssn"
+    CREDIT_CARD = "credit_card"
+    IP_ADDRESS = "ip_address"
+
+
+class Guardrails(BaseModel):
+    """Restrictions on the agent's input, output, and fetched URLs"""
+
+    input_deny_patterns: list[str] | None = Field(None, description="Regular expressions; matching input is rejected")
+    output_deny_patterns: list[str] | None = Field(None, description="Regular expressions; matching output is rejected")
+    max_output_length: int | None = Field(None, description="Maximum output length in characters; 0 is unlimited")
+    pii_filters: list[PIIType] | None = Field(None, description="PII types redacted from input and output")
+    allowed_domains: list[str] | None = Field(None, description="Domains, with their subdomains, WebFetch may fetch from; empty allows any")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class MemoryType(str, Enum):
+    """How long the agent remembers past interactions"""
+
+    NONE = "none"
+    SHORT_TERM = "short-term"
+    LONG_TERM = "long-term"
+
+
+class MemoryBackend(str, Enum):
+    """Where agent memory is stored"""
+
+    FILE = "file"
+    SQLITE = "sqlite"
+    VECTOR = "vector"
+
+
+class Memory(BaseModel):
+    """What the agent remembers between calls and where"""
+
+    type: MemoryType
+    backend: MemoryBackend | None = None
+    path: str | None = Field(None, description="Directory for the file backend or database file for the sqlite backend")
+    vector_store: str | None = Field(None, description="Vector store reference for the vector backend, e.g., a collection name or URI")
+    retention: str | None = Field(None, description="How long memories are kept (e.g., 24h, 720h); empty keeps them until removed")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class RefreshPolicy(str, Enum):
+    """When a knowledge source is re-ingested"""
+
+    NEVER = "never"
+    ON_RUN = "on-run"
+    INTERVAL = "interval"
+
+
+class KnowledgeSource(BaseModel):
+    """Corpus the agent grounds its answers on; exactly one of path, url, and vector_index is set"""
+
+    name: str = Field(..., description="Identifies the source within the agent")
+    description: str | None = Field(None, description="What the source holds and when to use it")
+    path: str | None = Field(None, description="Local file or directory, relative to the agent file")
+    url: str | None = Field(None, description="http or https document or site")
+    vector_index: str | None = Field(None, description="ID of an existing vector index or knowledge base on the deployment platform")
+    refresh: RefreshPolicy | None = None
+    refresh_interval: str | None = Field(None, description="Time between refreshes with the interval policy (e.g., 24h)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Example(BaseModel):
+    """Few-shot example of an input and the expected output"""
+
+    input: str = Field(..., description="Example request")
+    output: str = Field(..., description="Expected response")
+    commentary: str | None = Field(None, description="Why the output is right")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Agent(BaseModel):
+    """Agent model."""
+
+    name: str
+    namespace: str | None = None
+    description: str | None = None
+    version: str | None = Field(None, description="Semantic version of the agent definition, e.g., 2.1.0")
+    deprecated: bool | None = Field(None, description="Marks the agent for removal")
+    replaced_by: str | None = Field(None, description="Agent that supersedes this deprecated one")
+    icon: str | None = None
+    model: Model | None = None
+    tools: list[str] | None = None
+    allowed_tools: list[str] | None = Field(None, alias="allowedTools")
+    skills: list[str] | None = None
+    dependencies: list[str] | None = None
+    requires: list[str] | None = None
+    instructions: str | None = None
+    tasks: list[Task] | None = None
+    mcp_servers: list[MCPServer] | None = Field(None, description="Model Context Protocol servers the agent uses")
+    role: str | None = Field(None, description="Agent's role title for self-directed workflows (e.g., 'Security Analyst')")
+    goal: str | None = Field(None, description="What the agent aims to achieve in this role")
+    backstory: str | None = Field(None, description="Context and background for the agent's role")
+    delegation: DelegationConfig | None = None
+    budget: Budget | None = None
+    rate_limit: RateLimit | None = None
+    guardrails: Guardrails | None = None
+    memory: Memory | None = None
+    knowledge_sources: list[KnowledgeSource] | None = Field(None, description="Corpora the agent grounds its answers on")
+    examples: list[Example] | None = Field(None, description="Few-shot examples added to the agent's prompt")
+    rubric: str | None = Field(None, description="Name of the rubric the agent's evaluations are graded against")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
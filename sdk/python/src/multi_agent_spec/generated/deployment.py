@@ -0,0 +1,417 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: deployment/deployment.schema.json
+"""
+
+from __future__ import annotations
+
+from enum import Enum
+from typing import Any, Literal
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class ADKGoConfig(BaseModel):
+    """ADKGoConfig model."""
+
+    model: str | None = None
+    server_port: int | None = Field(None, alias="serverPort")
+    session_store: str | None = Field(None, alias="sessionStore")
+    tool_registry: str | None = Field(None, alias="toolRegistry")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class AWSAgentCoreConfig(BaseModel):
+    """AWSAgentCoreConfig model."""
+
+    region: str
+    foundation_model: str = Field(..., alias="foundationModel")
+    iac: str
+    lambda_runtime: str = Field(..., alias="lambdaRuntime")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class BedrockKnowledgeBase(BaseModel):
+    """BedrockKnowledgeBase model."""
+
+    id: str = Field(..., description="Knowledge base ID")
+    description: str = Field(..., description="When the agent should use the knowledge base")
+    agents: list[str] | None = Field(None, description="Agents to associate the knowledge base with; empty means every team agent")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class AWSBedrockAgentsConfig(BaseModel):
+    """AWSBedrockAgentsConfig model."""
+
+    region: str | None = Field(None, description="AWS region")
+    foundation_model: str | None = Field(None, alias="foundationModel", description="Default Bedrock model ID for agents without a model")
+    collaboration_mode: Literal["SUPERVISOR", "SUPERVISOR_ROUTER"] | None = Field("SUPERVISOR", alias="collaborationMode", description="How supervisors use their collaborators")
+    relay_conversation_history: bool | None = Field(None, alias="relayConversationHistory", description="Share the supervisor's conversation history with collaborators")
+    knowledge_bases: list[BedrockKnowledgeBase] | None = Field(None, alias="knowledgeBases", description="Existing knowledge bases to associate with agents")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class AgentKitLocalConfig(BaseModel):
+    """AgentKitLocalConfig model."""
+
+    transport: str
+    port: int | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class CodeExecutionConfig(BaseModel):
+    """CodeExecutionConfig model."""
+
+    work_dir: str | None = Field(None, alias="workDir")
+    use_docker: bool | None = Field(None, alias="useDocker")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class AutoGenConfig(BaseModel):
+    """AutoGenConfig model."""
+
+    model: str | None = None
+    human_input_mode: str | None = Field(None, alias="humanInputMode")
+    max_consecutive_auto_reply: int | None = Field(None, alias="maxConsecutiveAutoReply")
+    code_execution_config: CodeExecutionConfig | None = Field(None, alias="codeExecutionConfig")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class ClaudeCodeConfig(BaseModel):
+    """ClaudeCodeConfig model."""
+
+    agent_dir: str = Field(..., alias="agentDir")
+    format: str
+    team_mode: Literal["subagent", "team"] | None = Field("subagent", description="Whether to use subagents or agent teams")
+    teammate_mode: Literal["in-process", "tmux", "auto"] | None = Field("auto", description="Display mode for agent teams")
+    enable_teams: bool | None = Field(False, description="Set CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS=1")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class CrewAIConfig(BaseModel):
+    """CrewAIConfig model."""
+
+    model: str | None = None
+    verbose: bool | None = None
+    memory: bool | None = None
+    process_type: str | None = Field(None, alias="processType")
+    max_iterations: int | None = Field(None, alias="maxIterations")
+    allow_delegation: bool | None = Field(True, alias="allowDelegation", description="Enable agent delegation in CrewAI")
+    manager_llm: str | None = Field(None, alias="managerLlm", description="Model for manager agent in hierarchical process")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class Platform(str, Enum):
+    """Supported deployment platform"""
+
+    CLAUDE_CODE = "claude-code"
+    GEMINI_CLI = "gemini-cli"
+    KIRO_CLI = "kiro-cli"
+    ADK_GO = "adk-go"
+    CREWAI = "crewai"
+    AUTOGEN = "autogen"
+    AWS_AGENTCORE = "aws-agentcore"
+    AWS_EKS = "aws-eks"
+    AZURE_AKS = "azure-aks"
+    GCP_GKE = "gcp-gke"
+    KUBERNETES = "kubernetes"
+    DOCKER_COMPOSE = "docker-compose"
+    AGENTKIT_LOCAL = "agentkit-local"
+    LANGGRAPH = "langgraph"
+    OPENAI_AGENTS = "openai-agents"
+    SEMANTIC_KERNEL = "semantic-kernel"
+    AWS_BEDROCK_AGENTS = "aws-bedrock-agents"
+    VERTEX_AI = "vertex-ai"
+    TEMPORAL = "temporal"
+    N8N = "n8n"
+
+
+class DeploymentMode(str, Enum):
+    """Deployment execution mode"""
+
+    SINGLE_PROCESS = "single-process"
+    MULTI_PROCESS = "multi-process"
+    DISTRIBUTED = "distributed"
+    SERVERLESS = "serverless"
+
+
+class Priority(str, Enum):
+    """Deployment priority level"""
+
+    P1 = "p1"
+    P2 = "p2"
+    P3 = "p3"
+
+
+class RetryPolicy(BaseModel):
+    """RetryPolicy model."""
+
+    max_attempts: int | None = None
+    backoff: str | None = None
+    initial_delay: str | None = None
+    max_delay: str | None = None
+    retryable_errors: list[str] | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class ResourceLimits(BaseModel):
+    """ResourceLimits model."""
+
+    cpu: str | None = None
+    memory: str | None = None
+    gpu: int | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class RateLimit(BaseModel):
+    """Cap on LLM provider calls; zero limits are unlimited"""
+
+    requests_per_minute: int | None = Field(None, description="Sustained request rate")
+    max_concurrent: int | None = Field(None, description="Maximum calls in flight at once")
+    burst: int | None = Field(None, description="Requests allowed at once above the sustained rate (default: 1)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class StepRuntime(BaseModel):
+    """StepRuntime model."""
+
+    timeout: str | None = None
+    retry: RetryPolicy | None = None
+    condition: str | None = None
+    concurrency: int | None = None
+    resources: ResourceLimits | None = None
+    rate_limit: RateLimit | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class TracingConfig(BaseModel):
+    """TracingConfig model."""
+
+    enabled: bool | None = None
+    exporter: str | None = None
+    endpoint: str | None = None
+    sample_rate: float | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class MetricsConfig(BaseModel):
+    """MetricsConfig model."""
+
+    enabled: bool | None = None
+    exporter: str | None = None
+    endpoint: str | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class LoggingConfig(BaseModel):
+    """LoggingConfig model."""
+
+    level: str | None = None
+    format: str | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class ObservabilityConfig(BaseModel):
+    """ObservabilityConfig model."""
+
+    tracing: TracingConfig | None = None
+    metrics: MetricsConfig | None = None
+    logging: LoggingConfig | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class RuntimeConfig(BaseModel):
+    """RuntimeConfig model."""
+
+    defaults: StepRuntime | None = None
+    steps: dict[str, StepRuntime] | None = None
+    observability: ObservabilityConfig | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class SecretSource(str, Enum):
+    """Where a secret value is stored"""
+
+    ENV = "env"
+    FILE = "file"
+    AWS_SM = "aws-sm"
+    VAULT = "vault"
+
+
+class SecretRef(BaseModel):
+    """Reference to a secret stored outside the spec"""
+
+    source: SecretSource
+    key: str = Field(..., description="Variable name, file path, or secret name/path, optionally followed by #property")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class GeminiCLIConfig(BaseModel):
+    """GeminiCLIConfig model."""
+
+    model: str | None = None
+    config_dir: str | None = Field(None, alias="configDir")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class KiroCLIConfig(BaseModel):
+    """KiroCLIConfig model."""
+
+    plugin_dir: str | None = Field(None, alias="pluginDir")
+    format: str | None = None
+    prefix: str | None = Field(None, description="Prefix applied to agent names, filenames, and steering files for namespace isolation")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class KubernetesConfig(BaseModel):
+    """KubernetesConfig model."""
+
+    namespace: str
+    helm_chart: bool = Field(..., alias="helmChart")
+    image_registry: str | None = Field(None, alias="imageRegistry")
+    resource_limits: ResourceLimits | None = Field(None, alias="resourceLimits")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class DockerComposeConfig(BaseModel):
+    """DockerComposeConfig model."""
+
+    network_mode: str | None = Field(None, alias="networkMode")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class LangGraphConfig(BaseModel):
+    """LangGraphConfig model."""
+
+    model: str | None = Field(None, description="Default chat model as a LangChain provider:model string")
+    checkpointer: Literal["memory", "sqlite", "postgres", "none"] | None = Field("memory", description="Graph state persistence")
+    checkpointer_uri: str | None = Field(None, alias="checkpointerUri", description="Sqlite path or postgres connection string")
+    state_schema: Literal["typeddict", "pydantic"] | None = Field("typeddict", alias="stateSchema", description="How the graph state class is declared")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class OpenAIAgentsConfig(BaseModel):
+    """OpenAIAgentsConfig model."""
+
+    model: str | None = Field(None, description="Default OpenAI model for agents without a model")
+    assistant_ids: dict[str, str] | None = Field(None, alias="assistantIds", description="Existing assistant IDs keyed by agent name")
+    tool_mappings: dict[str, str] | None = Field(None, alias="toolMappings", description="Spec tool name to OpenAI hosted tool type (web_search, file_search, code_interpreter)")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class SemanticKernelConfig(BaseModel):
+    """SemanticKernelConfig model."""
+
+    language: Literal["python", "dotnet"] | None = Field("python", description="Semantic Kernel SDK flavor")
+    model: str | None = Field(None, description="Default chat model or Azure OpenAI deployment name")
+    service: Literal["openai", "azure-openai"] | None = Field("openai", description="Chat completion connector")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class VertexAIConfig(BaseModel):
+    """VertexAIConfig model."""
+
+    project: str | None = Field(None, description="Google Cloud project ID")
+    location: str | None = Field("us-central1", description="Vertex AI region")
+    model: str | None = Field(None, description="Default Gemini model for agents without a model")
+    data_stores: list[str] | None = Field(None, alias="dataStores", description="Vertex AI Search data store IDs or full resource names the root agent is grounded on")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class TemporalConfig(BaseModel):
+    """TemporalConfig model."""
+
+    host_port: str | None = Field("localhost:7233", alias="hostPort", description="Temporal frontend address")
+    namespace: str | None = Field("default", description="Temporal namespace")
+    task_queue: str | None = Field(None, alias="taskQueue", description="Task queue the worker polls; defaults to the team name")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class N8NConfig(BaseModel):
+    """N8NConfig model."""
+
+    webhook_path: str | None = Field(None, alias="webhookPath", description="Path of the webhook that starts the workflow; defaults to the team name")
+    agent_url: str | None = Field("http://{agent}:8080/invoke", alias="agentUrl", description="Endpoint each step POSTs to; {agent} is replaced with the agent name")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class Target(BaseModel):
+    """Target model."""
+
+    name: str
+    platform: Platform
+    mode: DeploymentMode | None = None
+    priority: Priority | None = None
+    output: str | None = None
+    runtime: RuntimeConfig | None = None
+    secrets: dict[str, SecretRef] | None = Field(None, description="Secrets exposed to the agents, keyed by environment variable name")
+    models: dict[str, str] | None = Field(None, description="Model identifier overrides keyed by canonical model tier or name")
+    claude_code: ClaudeCodeConfig | None = Field(None, alias="claudeCode")
+    gemini_cli: GeminiCLIConfig | None = Field(None, alias="geminiCli")
+    kiro_cli: KiroCLIConfig | None = Field(None, alias="kiroCli")
+    adk_go: ADKGoConfig | None = Field(None, alias="adkGo")
+    crewai: CrewAIConfig | None = None
+    autogen: AutoGenConfig | None = None
+    aws_agent_core: AWSAgentCoreConfig | None = Field(None, alias="awsAgentCore")
+    kubernetes: KubernetesConfig | None = None
+    docker_compose: DockerComposeConfig | None = Field(None, alias="dockerCompose")
+    agent_kit_local: AgentKitLocalConfig | None = Field(None, alias="agentKitLocal")
+    langgraph: LangGraphConfig | None = None
+    openai_agents: OpenAIAgentsConfig | None = Field(None, alias="openaiAgents")
+    semantic_kernel: SemanticKernelConfig | None = Field(None, alias="semanticKernel")
+    aws_bedrock_agents: AWSBedrockAgentsConfig | None = Field(None, alias="awsBedrockAgents")
+    vertex_ai: VertexAIConfig | None = Field(None, alias="vertexAi")
+    temporal: TemporalConfig | None = None
+    n8n: N8NConfig | None = None
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class Environment(BaseModel):
+    """Environment model."""
+
+    targets: dict[str, dict[str, Any]] = Field(..., description="Partial target definitions keyed by base target name, merged onto the base target as a JSON merge patch")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Deployment(BaseModel):
+    """Deployment model."""
+
+    schema_: str | None = Field(None, alias="$schema")
+    team: str
+    targets: list[Target]
+    environments: dict[str, Environment] | None = Field(None, description="Per-environment target overrides keyed by environment name")
+    variables: dict[str, str] | None = Field(None, description="Values for {{ .vars.name }} placeholders in agent instructions, overriding team variables")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
@@ -0,0 +1,63 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: message/message.schema.json
+"""
+
+from __future__ import annotations
+
+from enum import Enum
+from typing import Any
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class MessageType(str, Enum):
+    """Type of inter-agent message"""
+
+    DELEGATE_WORK = "delegate_work"
+    ASK_QUESTION = "ask_question"
+    SHARE_FINDING = "share_finding"
+    REQUEST_APPROVAL = "request_approval"
+    APPROVAL = "approval"
+    REJECTION = "rejection"
+    CHALLENGE = "challenge"
+    VOTE = "vote"
+    TASK_CLAIMED = "task_claimed"
+    TASK_COMPLETED = "task_completed"
+    SHUTDOWN_REQUEST = "shutdown_request"
+    SHUTDOWN_APPROVED = "shutdown_approved"
+
+
+class AttachmentType(str, Enum):
+    """Type of attachment: file (path), data (inline), reference (external)"""
+
+    FILE = "file"
+    DATA = "data"
+    REFERENCE = "reference"
+
+
+class Attachment(BaseModel):
+    """Message attachment"""
+
+    name: str = Field(..., description="Attachment name or identifier")
+    type: AttachmentType
+    data: Any | None = Field(None, description="Attachment data (type depends on attachment type)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Message(BaseModel):
+    """Inter-agent message for self-directed workflows"""
+
+    id: str = Field(..., description="Unique message identifier")
+    type: MessageType
+    from_: str = Field(..., alias="from", description="Sender agent name")
+    to: str | None = Field(None, description="Recipient agent name or '*' for broadcast")
+    subject: str | None = Field(None, description="Message subject line")
+    content: str = Field(..., description="Message body")
+    attachments: list[Attachment] | None = Field(None, description="Message attachments")
+    metadata: dict[str, Any] | None = Field(None, description="Additional message metadata")
+    timestamp: str = Field(..., description="Message timestamp in ISO 8601 format")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
@@ -0,0 +1,21 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: prompt/prompt.schema.json
+"""
+
+from __future__ import annotations
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class PromptTemplate(BaseModel):
+    """Named, versioned prompt template, such as an evaluator's system prompt, that evaluations reference as name@version"""
+
+    schema_: str | None = Field(None, alias="$schema")
+    name: str = Field(..., description="Prompt identifier")
+    version: str = Field(..., description="Semantic version of the prompt, e.g., 1.2.0")
+    description: str | None = Field(None, description="What the prompt is for")
+    template: str = Field(..., description="Prompt text; may reference {{ .vars.name }} placeholders")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
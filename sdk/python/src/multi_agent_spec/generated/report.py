@@ -0,0 +1,180 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: report/team-report.schema.json
+"""
+
+from __future__ import annotations
+
+from enum import Enum
+from typing import Any, Literal
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class Artifact(BaseModel):
+    """Artifact model."""
+
+    name: str = Field(..., description="Display name of the artifact (e.g., Unit test log)")
+    path: str | None = Field(None, description="File path of the artifact, relative to the report's directory unless absolute")
+    url: str | None = Field(None, description="URL of an artifact kept elsewhere, used when path is unset")
+    media_type: str | None = Field(None, description="Media type of the artifact (e.g., text/plain, application/spdx+json)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class ContentBlockType(str, Enum):
+    """Content block type discriminator"""
+
+    KV_PAIRS = "kv_pairs"
+    LIST = "list"
+    TABLE = "table"
+    TEXT = "text"
+    METRIC = "metric"
+
+
+class KVPair(BaseModel):
+    """KVPair model."""
+
+    key: str
+    value: str
+    icon: str | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Status(str, Enum):
+    """Validation status"""
+
+    GO = "GO"
+    NO_GO = "NO-GO"
+    WARN = "WARN"
+    SKIP = "SKIP"
+
+
+class ListItem(BaseModel):
+    """ListItem model."""
+
+    text: str
+    icon: str | None = None
+    status: Status | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class ContentBlock(BaseModel):
+    """ContentBlock model."""
+
+    type: ContentBlockType
+    title: str | None = None
+    pairs: list[KVPair] | None = None
+    items: list[ListItem] | None = None
+    headers: list[str] | None = None
+    rows: list[list[str]] | None = None
+    content: str | None = None
+    label: str | None = None
+    value: str | None = None
+    status: Status | None = None
+    target: str | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Issue(BaseModel):
+    """Issue model."""
+
+    id: str = Field(..., description="Issue identifier (e.g., ISS-001)")
+    category: str = Field(..., description="Evaluation category the issue belongs to")
+    severity: Literal["critical", "major", "minor", "suggestion"] = Field(..., description="How serious the issue is")
+    problem: str = Field(..., description="What the issue is")
+    location: str | None = Field(None, description="Where in the document the issue occurs (e.g., requirements.functional[2])")
+    analysis: str | None = Field(None, description="Why this is a problem")
+    recommendation: str | None = Field(None, description="How to fix the issue")
+    example: str | None = Field(None, description="Sample improved text or structure")
+    effort: Literal["trivial", "low", "medium", "high"] | None = Field(None, description="Estimated work to fix the issue")
+    related_issues: list[str] | None = Field(None, alias="relatedIssues", description="IDs of related issues")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class NarrativeSection(BaseModel):
+    """NarrativeSection model."""
+
+    problem: str | None = None
+    analysis: str | None = None
+    recommendation: str | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Severity(str, Enum):
+    """Finding severity/impact level. Orthogonal to status (status = pass/fail, severity = impact)."""
+
+    CRITICAL = "critical"
+    HIGH = "high"
+    MEDIUM = "medium"
+    LOW = "low"
+    INFO = "info"
+
+
+class TaskResult(BaseModel):
+    """TaskResult model."""
+
+    id: str
+    status: Status
+    severity: Severity | None = None
+    detail: str | None = None
+    duration_ms: int | None = None
+    tokens_in: int | None = Field(None, description="Input (prompt) tokens consumed by the task")
+    tokens_out: int | None = Field(None, description="Output (completion) tokens produced by the task")
+    cost_usd: float | None = Field(None, description="LLM cost of the task in US dollars")
+    metadata: dict[str, Any] | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class TeamSection(BaseModel):
+    """TeamSection model."""
+
+    id: str
+    name: str
+    agent_id: str | None = None
+    model: str | None = None
+    depends_on: list[str] | None = None
+    tasks: list[TaskResult] | None = None
+    status: Status
+    verdict: str | None = Field(None, description="Domain-specific verdict label. Richer than status. Status is machine-readable GO/NO-GO; verdict is the human-readable domain assessment.")
+    allow_failure: bool | None = Field(None, description="Experimental or advisory team whose NO-GO does not block the report; it counts as WARN in the overall status")
+    content_blocks: list[ContentBlock] | None = None
+    narrative: NarrativeSection | None = None
+    issues: list[Issue] | None = Field(None, description="Specific problems the team identified; reports aggregate them across teams")
+    artifacts: list[Artifact] | None = Field(None, description="Raw outputs supporting the team's results, such as logs, SBOMs, and screenshots")
+    trace_id: str | None = Field(None, description="W3C trace ID of the trace the agent ran in, for correlating report tasks with traces")
+    span_id: str | None = Field(None, description="W3C span ID of the agent's span")
+    tokens_in: int | None = Field(None, description="Total input (prompt) tokens the team consumed; when unset, the sum over its tasks")
+    tokens_out: int | None = Field(None, description="Total output (completion) tokens the team produced; when unset, the sum over its tasks")
+    cost_usd: float | None = Field(None, description="Total LLM cost of the team in US dollars; when unset, the sum over its tasks")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class TeamReport(BaseModel):
+    """TeamReport model."""
+
+    schema_: str | None = Field(None, alias="$schema")
+    title: str | None = None
+    project: str
+    version: str
+    target: str | None = None
+    phase: str
+    tags: dict[str, str] | None = Field(None, description="Key-value tags for filtering and aggregation across reports.")
+    summary_blocks: list[ContentBlock] | None = None
+    teams: list[TeamSection]
+    footer_blocks: list[ContentBlock] | None = None
+    summary: str | None = None
+    conclusion: str | None = None
+    status: Status
+    generated_at: str
+    generated_by: str | None = None
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
@@ -0,0 +1,43 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: rubric/rubric.schema.json
+"""
+
+from __future__ import annotations
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class ScoreAnchor(BaseModel):
+    """Description of content deserving a particular score"""
+
+    score: float = Field(..., description="Anchored score (0-10)")
+    description: str = Field(..., description="What content at this score looks like")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class RubricCategory(BaseModel):
+    """One scored dimension of a rubric"""
+
+    id: str = Field(..., description="Category identifier (lowercase, hyphenated), matching evaluation category IDs in reports")
+    name: str | None = Field(None, description="Human-readable category name")
+    description: str | None = Field(None, description="What the category measures")
+    weight: float | None = Field(None, description="Share of the overall score; weights are normalized, and categories weigh equally when none is set")
+    criteria: list[str] | None = Field(None, description="Questions or checks the category is judged by")
+    anchors: list[ScoreAnchor] | None = Field(None, description="What content at particular scores looks like")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Rubric(BaseModel):
+    """Versioned evaluation criteria that agents and evaluation tasks reference by name"""
+
+    schema_: str | None = Field(None, alias="$schema")
+    name: str = Field(..., description="Rubric identifier agents and tasks reference")
+    version: str | None = Field(None, description="Semantic version of the rubric, e.g., 1.2.0")
+    description: str | None = Field(None, description="What the rubric evaluates")
+    categories: list[RubricCategory] = Field(..., description="Dimensions content is scored on")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
@@ -0,0 +1,25 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: skill/skill.schema.json
+"""
+
+from __future__ import annotations
+
+from typing import Any
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class Skill(BaseModel):
+    """Reusable capability that agents reference by name in their skills list"""
+
+    schema_: str | None = Field(None, alias="$schema")
+    name: str = Field(..., description="Skill identifier agents reference (lowercase, hyphenated)")
+    description: str | None = Field(None, description="What the skill does and when to use it")
+    inputs: dict[str, Any] | None = Field(None, description="JSON Schema for the skill's input")
+    outputs: dict[str, Any] | None = Field(None, description="JSON Schema for the skill's output")
+    implementation: str | None = Field(None, description="How platforms realize the skill, e.g., a script to run or an MCP tool to call")
+    instructions: str | None = Field(None, description="How to perform the skill; the body of a markdown skill file")
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
@@ -0,0 +1,128 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: orchestration/team.schema.json
+"""
+
+from __future__ import annotations
+
+from enum import Enum
+from typing import Any
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class ChannelType(str, Enum):
+    """Communication channel type"""
+
+    DIRECT = "direct"
+    BROADCAST = "broadcast"
+    PUB_SUB = "pub-sub"
+
+
+class Channel(BaseModel):
+    """Communication channel between agents"""
+
+    name: str = Field(..., description="Channel identifier")
+    type: ChannelType
+    participants: list[str] | None = Field(None, description="Agent names or '*' for all agents")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class ConsensusRules(BaseModel):
+    """Rules for how agents reach agreement (council workflow)"""
+
+    required_agreement: float | None = Field(0.5, description="Fraction of agents that must agree (e.g., 0.66 for 2/3 majority)")
+    max_rounds: int | None = Field(3, description="Maximum debate rounds before forcing decision")
+    tie_breaker: str | None = Field(None, description="Agent name to break ties, or 'lead' for lead agent")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class CollaborationConfig(BaseModel):
+    """Configuration for how agents interact in self-directed workflows"""
+
+    lead: str | None = Field(None, description="Lead agent name (required for 'crew' workflow)")
+    specialists: list[str] | None = Field(None, description="Non-delegating specialist agent names")
+    task_queue: bool | None = Field(False, description="Enable shared task queue for self-claiming (for 'swarm' workflow)")
+    consensus: ConsensusRules | None = None
+    channels: list[Channel] | None = Field(None, description="Communication channels between agents")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class PortType(str, Enum):
+    """Data type of a port"""
+
+    STRING = "string"
+    NUMBER = "number"
+    BOOLEAN = "boolean"
+    OBJECT = "object"
+    ARRAY = "array"
+    FILE = "file"
+
+
+class Port(BaseModel):
+    """Port model."""
+
+    name: str
+    type: PortType | None = None
+    description: str | None = None
+    required: bool | None = None
+    from_: str | None = Field(None, alias="from")
+    schema_: Any | None = Field(None, alias="schema")
+    default: Any | None = None
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
+
+
+class Step(BaseModel):
+    """Step model."""
+
+    name: str
+    agent: str
+    depends_on: list[str] | None = None
+    inputs: list[Port] | None = None
+    outputs: list[Port] | None = None
+    when: str | None = Field(None, description="Condition on upstream outputs (step_name.output_name); the step runs only when it holds")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class WorkflowType(str, Enum):
+    """Workflow execution pattern. Deterministic (schema controls): chain, scatter, graph. Self-directed (agents control): crew, swarm, council."""
+
+    CHAIN = "chain"
+    SCATTER = "scatter"
+    GRAPH = "graph"
+    CREW = "crew"
+    SWARM = "swarm"
+    COUNCIL = "council"
+
+
+class Workflow(BaseModel):
+    """Workflow model."""
+
+    type: WorkflowType | None = None
+    steps: list[Step] | None = None
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class Team(BaseModel):
+    """Team model."""
+
+    name: str
+    version: str
+    description: str | None = None
+    agents: list[str] = Field(..., description="Agent names, optionally pinned to compatible versions as name@constraint (e.g., security-analyst@^2)")
+    orchestrator: str | None = None
+    workflow: Workflow | None = None
+    context: str | None = None
+    variables: dict[str, str] | None = Field(None, description="Values for {{ .vars.name }} placeholders in agent instructions")
+    collaboration: CollaborationConfig | None = Field(None, description="Collaboration configuration for self-directed workflows")
+    self_claim: bool | None = Field(False, description="Allow agents to self-claim tasks from shared queue (swarm workflow)")
+    plan_approval: bool | None = Field(False, description="Require plan approval before implementation (crew workflow)")
+
+    model_config = ConfigDict(extra="forbid")
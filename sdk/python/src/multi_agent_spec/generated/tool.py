@@ -0,0 +1,45 @@
+"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+Source: tool/tool.schema.json
+"""
+
+from __future__ import annotations
+
+from enum import Enum
+from typing import Any
+
+from pydantic import BaseModel, ConfigDict, Field
+
+
+class ToolBindingType(str, Enum):
+    """How a custom tool is executed"""
+
+    COMMAND = "command"
+    HTTP = "http"
+    MCP = "mcp"
+
+
+class ToolBinding(BaseModel):
+    """How the tool is executed"""
+
+    type: ToolBindingType
+    command: str | None = Field(None, description="Executable to run, receiving the arguments as JSON on stdin (command binding)")
+    args: list[str] | None = Field(None, description="Command arguments (command binding)")
+    url: str | None = Field(None, description="Endpoint the arguments are POSTed to (http binding)")
+    server: str | None = Field(None, description="MCP server providing the tool (mcp binding)")
+    tool: str | None = Field(None, description="Tool name on the MCP server (default: the tool's name)")
+
+    model_config = ConfigDict(extra="forbid")
+
+
+class ToolSpec(BaseModel):
+    """Non-canonical tool agents can list in their tools, e.g., a function-calling or MCP tool"""
+
+    schema_: str | None = Field(None, alias="$schema")
+    name: str = Field(..., description="Tool identifier agents reference; must not be a canonical tool name")
+    description: str | None = Field(None, description="What the tool does and when to call it")
+    parameters: dict[str, Any] | None = Field(None, description="JSON Schema for the tool's arguments")
+    binding: ToolBinding | None = None
+
+    model_config = ConfigDict(extra="forbid", populate_by_name=True)
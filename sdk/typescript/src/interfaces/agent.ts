@@ -0,0 +1,180 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: agent/agent.schema.json
+ */
+
+export interface Agent {
+  name: string;
+  namespace?: string;
+  description?: string;
+  /** Semantic version of the agent definition, e.g., 2.1.0 */
+  version?: string;
+  /** Marks the agent for removal */
+  deprecated?: boolean;
+  /** Agent that supersedes this deprecated one */
+  replaced_by?: string;
+  icon?: string;
+  model?: Model;
+  tools?: string[];
+  allowedTools?: string[];
+  skills?: string[];
+  dependencies?: string[];
+  requires?: string[];
+  instructions?: string;
+  tasks?: Task[];
+  /** Model Context Protocol servers the agent uses */
+  mcp_servers?: MCPServer[];
+  /** Agent's role title for self-directed workflows (e.g., 'Security Analyst') */
+  role?: string;
+  /** What the agent aims to achieve in this role */
+  goal?: string;
+  /** Context and background for the agent's role */
+  backstory?: string;
+  delegation?: DelegationConfig;
+  budget?: Budget;
+  rate_limit?: RateLimit;
+  guardrails?: Guardrails;
+  memory?: Memory;
+  /** Corpora the agent grounds its answers on */
+  knowledge_sources?: KnowledgeSource[];
+  /** Few-shot examples added to the agent's prompt */
+  examples?: Example[];
+  /** Name of the rubric the agent's evaluations are graded against */
+  rubric?: string;
+}
+
+/** Per-run cap on the agent's LLM usage; zero limits are unlimited */
+export interface Budget {
+  /** Maximum input plus output tokens per run */
+  max_tokens?: number;
+  /** Maximum LLM cost per run in US dollars */
+  max_cost_usd?: number;
+  on_exceed?: BudgetAction;
+  /** Model tier to switch to with the downgrade action (default: the next cheaper tier) */
+  downgrade_to?: Model;
+}
+
+/** What happens when the agent exceeds its budget */
+export type BudgetAction = "abort" | "downgrade" | "warn";
+
+/** Delegation permissions for self-directed workflows */
+export interface DelegationConfig {
+  /** Whether this agent can delegate work to others */
+  allow_delegation?: boolean;
+  /** Agent names this agent can delegate to (empty means no restrictions) */
+  can_delegate_to?: string[];
+  /** Agent names this agent can receive delegations from (empty means no restrictions) */
+  can_receive_from?: string[];
+}
+
+/** Few-shot example of an input and the expected output */
+export interface Example {
+  /** Example request */
+  input: string;
+  /** Expected response */
+  output: string;
+  /** Why the output is right */
+  commentary?: string;
+}
+
+/** Restrictions on the agent's input, output, and fetched URLs */
+export interface Guardrails {
+  /** Regular expressions; matching input is rejected */
+  input_deny_patterns?: string[];
+  /** Regular expressions; matching output is rejected */
+  output_deny_patterns?: string[];
+  /** Maximum output length in characters; 0 is unlimited */
+  max_output_length?: number;
+  /** PII types redacted from input and output */
+  pii_filters?: PIIType[];
+  /** Domains, with their subdomains, WebFetch may fetch from; empty allows any */
+  allowed_domains?: string[];
+}
+
+/** Corpus the agent grounds its answers on; exactly one of path, url, and vector_index is set */
+export interface KnowledgeSource {
+  /** Identifies the source within the agent */
+  name: string;
+  /** What the source holds and when to use it */
+  description?: string;
+  /** Local file or directory, relative to the agent file */
+  path?: string;
+  /** http or https document or site */
+  url?: string;
+  /** ID of an existing vector index or knowledge base on the deployment platform */
+  vector_index?: string;
+  refresh?: RefreshPolicy;
+  /** Time between refreshes with the interval policy (e.g., 24h) */
+  refresh_interval?: string;
+}
+
+export interface MCPServer {
+  name: string;
+  transport?: MCPTransport;
+  /** Executable that starts the server (stdio transport) */
+  command?: string;
+  args?: string[];
+  /** Server endpoint (http and sse transports) */
+  url?: string;
+  /** Server tools the agent may use (empty means all) */
+  allowed_tools?: string[];
+}
+
+/** How the agent connects to the MCP server */
+export type MCPTransport = "stdio" | "http" | "sse";
+
+/** What the agent remembers between calls and where */
+export interface Memory {
+  type: MemoryType;
+  backend?: MemoryBackend;
+  /** Directory for the file backend or database file for the sqlite backend */
+  path?: string;
+  /** Vector store reference for the vector backend, e.g., a collection name or URI */
+  vector_store?: string;
+  /** How long memories are kept (e.g., 24h, 720h); empty keeps them until removed */
+  retention?: string;
+}
+
+/** Where agent memory is stored */
+export type MemoryBackend = "file" | "sqlite" | "vector";
+
+/** How long the agent remembers past interactions */
+export type MemoryType = "none" | "short-term" | "long-term";
+
+/** Model capability tier (mapped to platform-specific models) */
+export type Model = "haiku" | "sonnet" | "opus";
+
+/** Category of personally identifiable information to redact */
+export type PIIType = "email" | "phone" | "ssn" | "credit_card" | "ip_address";
+
+/** Cap on LLM provider calls; zero limits are unlimited */
+export interface RateLimit {
+  /** Sustained request rate */
+  requests_per_minute?: number;
+  /** Maximum calls in flight at once */
+  max_concurrent?: number;
+  /** Requests allowed at once above the sustained rate (default: 1) */
+  burst?: number;
+}
+
+/** When a knowledge source is re-ingested */
+export type RefreshPolicy = "never" | "on-run" | "interval";
+
+export interface Task {
+  id: string;
+  description?: string;
+  type?: TaskType;
+  command?: string;
+  pattern?: string;
+  file?: string;
+  files?: string;
+  required?: boolean;
+  expected_output?: string;
+  human_in_loop?: string;
+  /** Name of the rubric this evaluation task is graded against, overriding the agent's */
+  rubric?: string;
+}
+
+/** How the task is executed */
+export type TaskType = "command" | "pattern" | "file" | "manual";
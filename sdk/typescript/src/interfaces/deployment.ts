@@ -0,0 +1,287 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: deployment/deployment.schema.json
+ */
+
+export interface ADKGoConfig {
+  model?: string;
+  serverPort?: number;
+  sessionStore?: string;
+  toolRegistry?: string;
+}
+
+export interface AWSAgentCoreConfig {
+  region: string;
+  foundationModel: string;
+  iac: string;
+  lambdaRuntime: string;
+}
+
+export interface AWSBedrockAgentsConfig {
+  /** AWS region */
+  region?: string;
+  /** Default Bedrock model ID for agents without a model */
+  foundationModel?: string;
+  /** How supervisors use their collaborators */
+  collaborationMode?: "SUPERVISOR" | "SUPERVISOR_ROUTER";
+  /** Share the supervisor's conversation history with collaborators */
+  relayConversationHistory?: boolean;
+  /** Existing knowledge bases to associate with agents */
+  knowledgeBases?: BedrockKnowledgeBase[];
+}
+
+export interface AgentKitLocalConfig {
+  transport: string;
+  port?: number;
+}
+
+export interface AutoGenConfig {
+  model?: string;
+  humanInputMode?: string;
+  maxConsecutiveAutoReply?: number;
+  codeExecutionConfig?: CodeExecutionConfig;
+}
+
+export interface BedrockKnowledgeBase {
+  /** Knowledge base ID */
+  id: string;
+  /** When the agent should use the knowledge base */
+  description: string;
+  /** Agents to associate the knowledge base with; empty means every team agent */
+  agents?: string[];
+}
+
+export interface ClaudeCodeConfig {
+  agentDir: string;
+  format: string;
+  /** Whether to use subagents or agent teams */
+  team_mode?: "subagent" | "team";
+  /** Display mode for agent teams */
+  teammate_mode?: "in-process" | "tmux" | "auto";
+  /** Set CLAUDE_CODE_EXPERIMENTAL_AGENT_TEAMS=1 */
+  enable_teams?: boolean;
+}
+
+export interface CodeExecutionConfig {
+  workDir?: string;
+  useDocker?: boolean;
+}
+
+export interface CrewAIConfig {
+  model?: string;
+  verbose?: boolean;
+  memory?: boolean;
+  processType?: string;
+  maxIterations?: number;
+  /** Enable agent delegation in CrewAI */
+  allowDelegation?: boolean;
+  /** Model for manager agent in hierarchical process */
+  managerLlm?: string;
+}
+
+export interface Deployment {
+  $schema?: string;
+  team: string;
+  targets: Target[];
+  /** Per-environment target overrides keyed by environment name */
+  environments?: Record<string, Environment>;
+  /** Values for {{ .vars.name }} placeholders in agent instructions, overriding team variables */
+  variables?: Record<string, string>;
+}
+
+/** Deployment execution mode */
+export type DeploymentMode = "single-process" | "multi-process" | "distributed" | "serverless";
+
+export interface DockerComposeConfig {
+  networkMode?: string;
+}
+
+export interface Environment {
+  /** Partial target definitions keyed by base target name, merged onto the base target as a JSON merge patch */
+  targets: Record<string, Record<string, unknown>>;
+}
+
+export interface GeminiCLIConfig {
+  model?: string;
+  configDir?: string;
+}
+
+export interface KiroCLIConfig {
+  pluginDir?: string;
+  format?: string;
+  /** Prefix applied to agent names, filenames, and steering files for namespace isolation */
+  prefix?: string;
+}
+
+export interface KubernetesConfig {
+  namespace: string;
+  helmChart: boolean;
+  imageRegistry?: string;
+  resourceLimits?: ResourceLimits;
+}
+
+export interface LangGraphConfig {
+  /** Default chat model as a LangChain provider:model string */
+  model?: string;
+  /** Graph state persistence */
+  checkpointer?: "memory" | "sqlite" | "postgres" | "none";
+  /** Sqlite path or postgres connection string */
+  checkpointerUri?: string;
+  /** How the graph state class is declared */
+  stateSchema?: "typeddict" | "pydantic";
+}
+
+export interface LoggingConfig {
+  level?: string;
+  format?: string;
+}
+
+export interface MetricsConfig {
+  enabled?: boolean;
+  exporter?: string;
+  endpoint?: string;
+}
+
+export interface N8NConfig {
+  /** Path of the webhook that starts the workflow; defaults to the team name */
+  webhookPath?: string;
+  /** Endpoint each step POSTs to; {agent} is replaced with the agent name */
+  agentUrl?: string;
+}
+
+export interface ObservabilityConfig {
+  tracing?: TracingConfig;
+  metrics?: MetricsConfig;
+  logging?: LoggingConfig;
+}
+
+export interface OpenAIAgentsConfig {
+  /** Default OpenAI model for agents without a model */
+  model?: string;
+  /** Existing assistant IDs keyed by agent name */
+  assistantIds?: Record<string, string>;
+  /** Spec tool name to OpenAI hosted tool type (web_search, file_search, code_interpreter) */
+  toolMappings?: Record<string, string>;
+}
+
+/** Supported deployment platform */
+export type Platform = "claude-code" | "gemini-cli" | "kiro-cli" | "adk-go" | "crewai" | "autogen" | "aws-agentcore" | "aws-eks" | "azure-aks" | "gcp-gke" | "kubernetes" | "docker-compose" | "agentkit-local" | "langgraph" | "openai-agents" | "semantic-kernel" | "aws-bedrock-agents" | "vertex-ai" | "temporal" | "n8n";
+
+/** Deployment priority level */
+export type Priority = "p1" | "p2" | "p3";
+
+/** Cap on LLM provider calls; zero limits are unlimited */
+export interface RateLimit {
+  /** Sustained request rate */
+  requests_per_minute?: number;
+  /** Maximum calls in flight at once */
+  max_concurrent?: number;
+  /** Requests allowed at once above the sustained rate (default: 1) */
+  burst?: number;
+}
+
+export interface ResourceLimits {
+  cpu?: string;
+  memory?: string;
+  gpu?: number;
+}
+
+export interface RetryPolicy {
+  max_attempts?: number;
+  backoff?: string;
+  initial_delay?: string;
+  max_delay?: string;
+  retryable_errors?: string[];
+}
+
+export interface RuntimeConfig {
+  defaults?: StepRuntime;
+  steps?: Record<string, StepRuntime>;
+  observability?: ObservabilityConfig;
+}
+
+/** Reference to a secret stored outside the spec */
+export interface SecretRef {
+  source: SecretSource;
+  /** Variable name, file path, or secret name/path, optionally followed by #property */
+  key: string;
+}
+
+/** Where a secret value is stored */
+export type SecretSource = "env" | "file" | "aws-sm" | "vault";
+
+export interface SemanticKernelConfig {
+  /** Semantic Kernel SDK flavor */
+  language?: "python" | "dotnet";
+  /** Default chat model or Azure OpenAI deployment name */
+  model?: string;
+  /** Chat completion connector */
+  service?: "openai" | "azure-openai";
+}
+
+export interface StepRuntime {
+  timeout?: string;
+  retry?: RetryPolicy;
+  condition?: string;
+  concurrency?: number;
+  resources?: ResourceLimits;
+  rate_limit?: RateLimit;
+}
+
+export interface Target {
+  name: string;
+  platform: Platform;
+  mode?: DeploymentMode;
+  priority?: Priority;
+  output?: string;
+  runtime?: RuntimeConfig;
+  /** Secrets exposed to the agents, keyed by environment variable name */
+  secrets?: Record<string, SecretRef>;
+  /** Model identifier overrides keyed by canonical model tier or name */
+  models?: Record<string, string>;
+  claudeCode?: ClaudeCodeConfig;
+  geminiCli?: GeminiCLIConfig;
+  kiroCli?: KiroCLIConfig;
+  adkGo?: ADKGoConfig;
+  crewai?: CrewAIConfig;
+  autogen?: AutoGenConfig;
+  awsAgentCore?: AWSAgentCoreConfig;
+  kubernetes?: KubernetesConfig;
+  dockerCompose?: DockerComposeConfig;
+  agentKitLocal?: AgentKitLocalConfig;
+  langgraph?: LangGraphConfig;
+  openaiAgents?: OpenAIAgentsConfig;
+  semanticKernel?: SemanticKernelConfig;
+  awsBedrockAgents?: AWSBedrockAgentsConfig;
+  vertexAi?: VertexAIConfig;
+  temporal?: TemporalConfig;
+  n8n?: N8NConfig;
+}
+
+export interface TemporalConfig {
+  /** Temporal frontend address */
+  hostPort?: string;
+  /** Temporal namespace */
+  namespace?: string;
+  /** Task queue the worker polls; defaults to the team name */
+  taskQueue?: string;
+}
+
+export interface TracingConfig {
+  enabled?: boolean;
+  exporter?: string;
+  endpoint?: string;
+  sample_rate?: number;
+}
+
+export interface VertexAIConfig {
+  /** Google Cloud project ID */
+  project?: string;
+  /** Vertex AI region */
+  location?: string;
+  /** Default Gemini model for agents without a model */
+  model?: string;
+  /** Vertex AI Search data store IDs or full resource names the root agent is grounded on */
+  dataStores?: string[];
+}
@@ -0,0 +1,14 @@
+/**
+ * Auto-generated TypeScript interfaces index.
+ * DO NOT EDIT - regenerate with: make generate-types
+ */
+
+export * from './agent.js';
+export * from './team.js';
+export * from './deployment.js';
+export * from './report.js';
+export * from './message.js';
+export * from './skill.js';
+export * from './tool.js';
+export * from './rubric.js';
+export * from './prompt.js';
@@ -0,0 +1,41 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: message/message.schema.json
+ */
+
+/** Inter-agent message for self-directed workflows */
+export interface Message {
+  /** Unique message identifier */
+  id: string;
+  type: MessageType;
+  /** Sender agent name */
+  from: string;
+  /** Recipient agent name or '*' for broadcast */
+  to?: string;
+  /** Message subject line */
+  subject?: string;
+  /** Message body */
+  content: string;
+  /** Message attachments */
+  attachments?: Attachment[];
+  /** Additional message metadata */
+  metadata?: Record<string, unknown>;
+  /** Message timestamp in ISO 8601 format */
+  timestamp: string;
+}
+
+/** Type of inter-agent message */
+export type MessageType = "delegate_work" | "ask_question" | "share_finding" | "request_approval" | "approval" | "rejection" | "challenge" | "vote" | "task_claimed" | "task_completed" | "shutdown_request" | "shutdown_approved";
+
+/** Message attachment */
+export interface Attachment {
+  /** Attachment name or identifier */
+  name: string;
+  type: AttachmentType;
+  /** Attachment data (type depends on attachment type) */
+  data?: unknown;
+}
+
+/** Type of attachment: file (path), data (inline), reference (external) */
+export type AttachmentType = "file" | "data" | "reference";
@@ -0,0 +1,18 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: prompt/prompt.schema.json
+ */
+
+/** Named, versioned prompt template, such as an evaluator's system prompt, that evaluations reference as name@version */
+export interface PromptTemplate {
+  $schema?: string;
+  /** Prompt identifier */
+  name: string;
+  /** Semantic version of the prompt, e.g., 1.2.0 */
+  version: string;
+  /** What the prompt is for */
+  description?: string;
+  /** Prompt text; may reference {{ .vars.name }} placeholders */
+  template: string;
+}
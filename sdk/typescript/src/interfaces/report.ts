@@ -0,0 +1,144 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: report/team-report.schema.json
+ */
+
+export interface Artifact {
+  /** Display name of the artifact (e.g., Unit test log) */
+  name: string;
+  /** File path of the artifact, relative to the report's directory unless absolute */
+  path?: string;
+  /** URL of an artifact kept elsewhere, used when path is unset */
+  url?: string;
+  /** Media type of the artifact (e.g., text/plain, application/spdx+json) */
+  media_type?: string;
+}
+
+export interface ContentBlock {
+  type: ContentBlockType;
+  title?: string;
+  pairs?: KVPair[];
+  items?: ListItem[];
+  headers?: string[];
+  rows?: string[][];
+  content?: string;
+  label?: string;
+  value?: string;
+  status?: Status;
+  target?: string;
+}
+
+/** Content block type discriminator */
+export type ContentBlockType = "kv_pairs" | "list" | "table" | "text" | "metric";
+
+export interface Issue {
+  /** Issue identifier (e.g., ISS-001) */
+  id: string;
+  /** Evaluation category the issue belongs to */
+  category: string;
+  /** How serious the issue is */
+  severity: "critical" | "major" | "minor" | "suggestion";
+  /** What the issue is */
+  problem: string;
+  /** Where in the document the issue occurs (e.g., requirements.functional[2]) */
+  location?: string;
+  /** Why this is a problem */
+  analysis?: string;
+  /** How to fix the issue */
+  recommendation?: string;
+  /** Sample improved text or structure */
+  example?: string;
+  /** Estimated work to fix the issue */
+  effort?: "trivial" | "low" | "medium" | "high";
+  /** IDs of related issues */
+  relatedIssues?: string[];
+}
+
+export interface KVPair {
+  key: string;
+  value: string;
+  icon?: string;
+}
+
+export interface ListItem {
+  text: string;
+  icon?: string;
+  status?: Status;
+}
+
+export interface NarrativeSection {
+  problem?: string;
+  analysis?: string;
+  recommendation?: string;
+}
+
+/** Finding severity/impact level. Orthogonal to status (status = pass/fail, severity = impact). */
+export type Severity = "critical" | "high" | "medium" | "low" | "info";
+
+/** Validation status */
+export type Status = "GO" | "NO-GO" | "WARN" | "SKIP";
+
+export interface TaskResult {
+  id: string;
+  status: Status;
+  severity?: Severity;
+  detail?: string;
+  duration_ms?: number;
+  /** Input (prompt) tokens consumed by the task */
+  tokens_in?: number;
+  /** Output (completion) tokens produced by the task */
+  tokens_out?: number;
+  /** LLM cost of the task in US dollars */
+  cost_usd?: number;
+  metadata?: Record<string, unknown>;
+}
+
+export interface TeamReport {
+  $schema?: string;
+  title?: string;
+  project: string;
+  version: string;
+  target?: string;
+  phase: string;
+  /** Key-value tags for filtering and aggregation across reports. */
+  tags?: Record<string, string>;
+  summary_blocks?: ContentBlock[];
+  teams: TeamSection[];
+  footer_blocks?: ContentBlock[];
+  summary?: string;
+  conclusion?: string;
+  status: Status;
+  generated_at: string;
+  generated_by?: string;
+}
+
+export interface TeamSection {
+  id: string;
+  name: string;
+  agent_id?: string;
+  model?: string;
+  depends_on?: string[];
+  tasks?: TaskResult[];
+  status: Status;
+  /** Domain-specific verdict label. Richer than status. Status is machine-readable GO/NO-GO; verdict is the human-readable domain assessment. */
+  verdict?: string;
+  /** Experimental or advisory team whose NO-GO does not block the report; it counts as WARN in the overall status */
+  allow_failure?: boolean;
+  content_blocks?: ContentBlock[];
+  narrative?: NarrativeSection;
+  /** Specific problems the team identified; reports aggregate them across teams */
+  issues?: Issue[];
+  /** Raw outputs supporting the team's results, such as logs, SBOMs, and screenshots */
+  artifacts?: Artifact[];
+  /** W3C trace ID of the trace the agent ran in, for correlating report tasks with traces */
+  trace_id?: string;
+  /** W3C span ID of the agent's span */
+  span_id?: string;
+  /** Total input (prompt) tokens the team consumed; when unset, the sum over its tasks */
+  tokens_in?: number;
+  /** Total output (completion) tokens the team produced; when unset, the sum over its tasks */
+  tokens_out?: number;
+  /** Total LLM cost of the team in US dollars; when unset, the sum over its tasks */
+  cost_usd?: number;
+}
@@ -0,0 +1,42 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: rubric/rubric.schema.json
+ */
+
+/** Versioned evaluation criteria that agents and evaluation tasks reference by name */
+export interface Rubric {
+  $schema?: string;
+  /** Rubric identifier agents and tasks reference */
+  name: string;
+  /** Semantic version of the rubric, e.g., 1.2.0 */
+  version?: string;
+  /** What the rubric evaluates */
+  description?: string;
+  /** Dimensions content is scored on */
+  categories: RubricCategory[];
+}
+
+/** One scored dimension of a rubric */
+export interface RubricCategory {
+  /** Category identifier (lowercase, hyphenated), matching evaluation category IDs in reports */
+  id: string;
+  /** Human-readable category name */
+  name?: string;
+  /** What the category measures */
+  description?: string;
+  /** Share of the overall score; weights are normalized, and categories weigh equally when none is set */
+  weight?: number;
+  /** Questions or checks the category is judged by */
+  criteria?: string[];
+  /** What content at particular scores looks like */
+  anchors?: ScoreAnchor[];
+}
+
+/** Description of content deserving a particular score */
+export interface ScoreAnchor {
+  /** Anchored score (0-10) */
+  score: number;
+  /** What content at this score looks like */
+  description: string;
+}
@@ -0,0 +1,22 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: skill/skill.schema.json
+ */
+
+/** Reusable capability that agents reference by name in their skills list */
+export interface Skill {
+  $schema?: string;
+  /** Skill identifier agents reference (lowercase, hyphenated) */
+  name: string;
+  /** What the skill does and when to use it */
+  description?: string;
+  /** JSON Schema for the skill's input */
+  inputs?: Record<string, unknown>;
+  /** JSON Schema for the skill's output */
+  outputs?: Record<string, unknown>;
+  /** How platforms realize the skill, e.g., a script to run or an MCP tool to call */
+  implementation?: string;
+  /** How to perform the skill; the body of a markdown skill file */
+  instructions?: string;
+}
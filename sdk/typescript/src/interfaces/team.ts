@@ -0,0 +1,90 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: orchestration/team.schema.json
+ */
+
+/** Communication channel between agents */
+export interface Channel {
+  /** Channel identifier */
+  name: string;
+  type: ChannelType;
+  /** Agent names or '*' for all agents */
+  participants?: string[];
+}
+
+/** Communication channel type */
+export type ChannelType = "direct" | "broadcast" | "pub-sub";
+
+/** Configuration for how agents interact in self-directed workflows */
+export interface CollaborationConfig {
+  /** Lead agent name (required for 'crew' workflow) */
+  lead?: string;
+  /** Non-delegating specialist agent names */
+  specialists?: string[];
+  /** Enable shared task queue for self-claiming (for 'swarm' workflow) */
+  task_queue?: boolean;
+  consensus?: ConsensusRules;
+  /** Communication channels between agents */
+  channels?: Channel[];
+}
+
+/** Rules for how agents reach agreement (council workflow) */
+export interface ConsensusRules {
+  /** Fraction of agents that must agree (e.g., 0.66 for 2/3 majority) */
+  required_agreement?: number;
+  /** Maximum debate rounds before forcing decision */
+  max_rounds?: number;
+  /** Agent name to break ties, or 'lead' for lead agent */
+  tie_breaker?: string;
+}
+
+export interface Port {
+  name: string;
+  type?: PortType;
+  description?: string;
+  required?: boolean;
+  from?: string;
+  schema?: unknown;
+  default?: unknown;
+}
+
+/** Data type of a port */
+export type PortType = "string" | "number" | "boolean" | "object" | "array" | "file";
+
+export interface Step {
+  name: string;
+  agent: string;
+  depends_on?: string[];
+  inputs?: Port[];
+  outputs?: Port[];
+  /** Condition on upstream outputs (step_name.output_name); the step runs only when it holds */
+  when?: string;
+}
+
+export interface Team {
+  name: string;
+  version: string;
+  description?: string;
+  /** Agent names, optionally pinned to compatible versions as name@constraint (e.g., security-analyst@^2) */
+  agents: string[];
+  orchestrator?: string;
+  workflow?: Workflow;
+  context?: string;
+  /** Values for {{ .vars.name }} placeholders in agent instructions */
+  variables?: Record<string, string>;
+  /** Collaboration configuration for self-directed workflows */
+  collaboration?: CollaborationConfig;
+  /** Allow agents to self-claim tasks from shared queue (swarm workflow) */
+  self_claim?: boolean;
+  /** Require plan approval before implementation (crew workflow) */
+  plan_approval?: boolean;
+}
+
+export interface Workflow {
+  type?: WorkflowType;
+  steps?: Step[];
+}
+
+/** Workflow execution pattern. Deterministic (schema controls): chain, scatter, graph. Self-directed (agents control): crew, swarm, council. */
+export type WorkflowType = "chain" | "scatter" | "graph" | "crew" | "swarm" | "council";
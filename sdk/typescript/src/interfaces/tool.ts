@@ -0,0 +1,35 @@
+/**
+ * Auto-generated TypeScript interfaces from JSON Schema.
+ * DO NOT EDIT - regenerate with: make generate-types
+ * Source: tool/tool.schema.json
+ */
+
+/** How the tool is executed */
+export interface ToolBinding {
+  type: ToolBindingType;
+  /** Executable to run, receiving the arguments as JSON on stdin (command binding) */
+  command?: string;
+  /** Command arguments (command binding) */
+  args?: string[];
+  /** Endpoint the arguments are POSTed to (http binding) */
+  url?: string;
+  /** MCP server providing the tool (mcp binding) */
+  server?: string;
+  /** Tool name on the MCP server (default: the tool's name) */
+  tool?: string;
+}
+
+/** How a custom tool is executed */
+export type ToolBindingType = "command" | "http" | "mcp";
+
+/** Non-canonical tool agents can list in their tools, e.g., a function-calling or MCP tool */
+export interface ToolSpec {
+  $schema?: string;
+  /** Tool identifier agents reference; must not be a canonical tool name */
+  name: string;
+  /** What the tool does and when to call it */
+  description?: string;
+  /** JSON Schema for the tool's arguments */
+  parameters?: Record<string, unknown>;
+  binding?: ToolBinding;
+}
@@ -1,15 +1,22 @@
-// Package main generates JSON Schema files from Go types.
+// Package main generates JSON Schema files from Go types and language
+// bindings from the published JSON Schemas.
 //
 // Usage:
 //
-//	go run ./tools/generate
+//	go run ./tools/generate             # JSON Schemas from Go types
+//	go run ./tools/generate --lang=ts   # TypeScript interfaces from JSON Schemas
+//	go run ./tools/generate --lang=py   # pydantic models from JSON Schemas
 package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 
 	"github.com/invopop/jsonschema"
 
@@ -24,8 +31,34 @@ func main() {
 }
 
 func run() error {
-	// Output directory for generated schemas (relative to repo root)
-	outputDir := "../../schema"
+	lang := flag.String("lang", "schema", "Output to generate: schema, ts, or py")
+	flag.Parse()
+
+	// Paths are relative to tools/generate
+	schemaDir := filepath.Join("..", "..", "schema")
+
+	switch *lang {
+	case "schema":
+		return generateSchemas(schemaDir)
+	case "ts":
+		if err := generateTypeScript(schemaDir, filepath.Join("..", "..", "sdk", "typescript", "src", "interfaces")); err != nil {
+			return fmt.Errorf("generating typescript: %w", err)
+		}
+		fmt.Println("TypeScript generation complete!")
+		return nil
+	case "py":
+		if err := generatePython(schemaDir, filepath.Join("..", "..", "sdk", "python", "src", "multi_agent_spec", "generated")); err != nil {
+			return fmt.Errorf("generating python: %w", err)
+		}
+		fmt.Println("Python generation complete!")
+		return nil
+	default:
+		return fmt.Errorf("unknown --lang %q (want schema, ts, or py)", *lang)
+	}
+}
+
+// generateSchemas reflects the Go types into JSON Schema files under outputDir.
+func generateSchemas(outputDir string) error {
 
 	// Generate Agent schema
 	if err := generateSchema(
@@ -33,7 +66,7 @@ func run() error {
 		filepath.Join(outputDir, "agent", "agent.schema.json"),
 		"Multi-Agent Spec - Agent Definition",
 		"Schema for defining an AI agent in a multi-agent system",
-		"https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/agent/agent.schema.json",
+		multiagentspec.SchemaURL(multiagentspec.SchemaAgent),
 	); err != nil {
 		return fmt.Errorf("generating agent schema: %w", err)
 	}
@@ -44,7 +77,7 @@ func run() error {
 		filepath.Join(outputDir, "orchestration", "team.schema.json"),
 		"Multi-Agent Spec - Team Definition",
 		"Schema for defining a team of AI agents with orchestration",
-		"https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/orchestration/team.schema.json",
+		multiagentspec.SchemaURL(multiagentspec.SchemaTeam),
 	); err != nil {
 		return fmt.Errorf("generating team schema: %w", err)
 	}
@@ -55,7 +88,7 @@ func run() error {
 		filepath.Join(outputDir, "deployment", "deployment.schema.json"),
 		"Multi-Agent Spec - Deployment Definition",
 		"Schema for defining deployment targets for multi-agent systems",
-		"https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/deployment/deployment.schema.json",
+		multiagentspec.SchemaURL(multiagentspec.SchemaDeployment),
 	); err != nil {
 		return fmt.Errorf("generating deployment schema: %w", err)
 	}
@@ -66,21 +99,108 @@ func run() error {
 		filepath.Join(outputDir, "report", "team-report.schema.json"),
 		"Multi-Agent Spec - Team Report",
 		"Schema for team validation reports",
-		"https://raw.githubusercontent.com/plexusone/multi-agent-spec/main/schema/report/team-report.schema.json",
+		multiagentspec.SchemaURL(multiagentspec.SchemaTeamReport),
 	); err != nil {
 		return fmt.Errorf("generating team-report schema: %w", err)
 	}
 
+	// Generate Skill schema
+	if err := generateSchema(
+		&multiagentspec.Skill{},
+		filepath.Join(outputDir, "skill", "skill.schema.json"),
+		"Multi-Agent Spec - Skill Definition",
+		"Schema for defining a reusable skill shared by agents",
+		multiagentspec.SchemaURL(multiagentspec.SchemaSkill),
+	); err != nil {
+		return fmt.Errorf("generating skill schema: %w", err)
+	}
+
+	// Generate Tool schema
+	if err := generateSchema(
+		&multiagentspec.ToolSpec{},
+		filepath.Join(outputDir, "tool", "tool.schema.json"),
+		"Multi-Agent Spec - Tool Definition",
+		"Schema for defining a custom tool with a parameters schema and execution binding",
+		multiagentspec.SchemaURL(multiagentspec.SchemaTool),
+	); err != nil {
+		return fmt.Errorf("generating tool schema: %w", err)
+	}
+
+	// Generate Rubric schema
+	if err := generateSchema(
+		&multiagentspec.Rubric{},
+		filepath.Join(outputDir, "rubric", "rubric.schema.json"),
+		"Multi-Agent Spec - Rubric Definition",
+		"Schema for defining evaluation rubrics with weighted categories and score anchors",
+		multiagentspec.SchemaURL(multiagentspec.SchemaRubric),
+	); err != nil {
+		return fmt.Errorf("generating rubric schema: %w", err)
+	}
+
+	// Generate Prompt schema
+	if err := generateSchema(
+		&multiagentspec.PromptTemplate{},
+		filepath.Join(outputDir, "prompt", "prompt.schema.json"),
+		"Multi-Agent Spec - Prompt Template",
+		"Schema for versioned prompt templates",
+		multiagentspec.SchemaURL(multiagentspec.SchemaPrompt),
+	); err != nil {
+		return fmt.Errorf("generating prompt schema: %w", err)
+	}
+
+	// Mirror all published schemas into the Go SDK for go:embed
+	if err := syncEmbeddedSchemas(outputDir, filepath.Join("..", "..", "sdk", "go", "schema")); err != nil {
+		return fmt.Errorf("syncing embedded schemas: %w", err)
+	}
+
 	fmt.Println("Schema generation complete!")
 	return nil
 }
 
+// syncEmbeddedSchemas copies every *.schema.json file under srcDir to the
+// same relative path under dstDir. Example documents are not copied.
+func syncEmbeddedSchemas(srcDir, dstDir string) error {
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "examples" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".schema.json") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		dst := filepath.Join(dstDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return fmt.Errorf("creating directory for %s: %w", dst, err)
+		}
+		if err := os.WriteFile(dst, data, 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", dst, err)
+		}
+		fmt.Printf("Synced: %s\n", dst)
+		return nil
+	})
+}
+
 func generateSchema(v interface{}, outputPath, title, description, id string) error {
 	// Create reflector with options
 	r := &jsonschema.Reflector{
 		DoNotReference:            false, // Use $ref for named types
 		ExpandedStruct:            false,
 		AllowAdditionalProperties: false,
+		Namer:                     definitionName,
 	}
 
 	// Generate schema
@@ -109,3 +229,12 @@ func generateSchema(v interface{}, outputPath, title, description, id string) er
 	fmt.Printf("Generated: %s\n", outputPath)
 	return nil
 }
+
+// definitionName returns the $defs name a type chooses with a
+// JSONSchemaName method, or "" for the Go type name.
+func definitionName(t reflect.Type) string {
+	if n, ok := reflect.New(t).Elem().Interface().(interface{ JSONSchemaName() string }); ok {
+		return n.JSONSchemaName()
+	}
+	return ""
+}
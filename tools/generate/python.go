@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var pyNonIdent = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// pyReserved are names that cannot be used as pydantic field names as-is:
+// Python keywords and attributes of pydantic.BaseModel.
+var pyReserved = map[string]bool{
+	"and": true, "as": true, "class": true, "def": true, "from": true,
+	"global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "not": true, "or": true, "pass": true, "return": true,
+	"with": true, "yield": true,
+	"construct": true, "copy": true, "dict": true, "json": true,
+	"schema": true, "validate": true,
+}
+
+// generatePython writes one module of pydantic v2 models per binding schema
+// into outputDir, plus a package __init__ re-exporting them.
+func generatePython(schemaDir, outputDir string) error {
+	var imports, exports []string
+	for _, f := range bindingSchemas {
+		s, err := loadSchema(schemaDir, f)
+		if err != nil {
+			return err
+		}
+		if err := writeGenerated(filepath.Join(outputDir, f.Module+".py"), []byte(renderPython(f, s))); err != nil {
+			return err
+		}
+
+		var names []string
+		for _, def := range s.Defs {
+			names = append(names, def.Name)
+		}
+		imports = append(imports, fmt.Sprintf("from .%s import (\n    %s,\n)", f.Module, strings.Join(names, ",\n    ")))
+		exports = append(exports, names...)
+	}
+
+	var b strings.Builder
+	b.WriteString(`"""Auto-generated pydantic models from JSON Schema.
+
+DO NOT EDIT - regenerate with: make generate-python
+"""
+
+`)
+	b.WriteString(strings.Join(imports, "\n"))
+	b.WriteString("\n\n__all__ = [\n")
+	for _, name := range exports {
+		fmt.Fprintf(&b, "    %q,\n", name)
+	}
+	b.WriteString("]\n")
+
+	return writeGenerated(filepath.Join(outputDir, "__init__.py"), []byte(b.String()))
+}
+
+func renderPython(f schemaFile, s *schemaNode) string {
+	var body strings.Builder
+	usesEnum, usesField, usesAny, usesLiteral := false, false, false, false
+
+	for _, def := range pySortDefs(s.Defs) {
+		body.WriteString("\n\n")
+		switch {
+		case len(def.Schema.Enum) > 0:
+			usesEnum = true
+			fmt.Fprintf(&body, "class %s(str, Enum):\n", def.Name)
+			fmt.Fprintf(&body, "    %s\n\n", pyDocstring(def.Schema.Description, def.Name+" values."))
+			for _, v := range def.Schema.Enum {
+				fmt.Fprintf(&body, "    %s = %q\n", pyEnumMember(v), v)
+			}
+
+		case def.Schema.Type == "object" && len(def.Schema.Properties) > 0:
+			fmt.Fprintf(&body, "class %s(BaseModel):\n", def.Name)
+			fmt.Fprintf(&body, "    %s\n\n", pyDocstring(def.Schema.Description, def.Name+" model."))
+			aliased := false
+			for _, p := range def.Schema.Properties {
+				field := pyFieldName(p.Name)
+				typ := pyType(p.Schema)
+				usesAny = usesAny || strings.Contains(typ, "Any")
+				usesLiteral = usesLiteral || strings.Contains(typ, "Literal[")
+
+				required := def.Schema.isRequired(p.Name)
+				dflt := "..."
+				if !required {
+					typ += " | None"
+					dflt = "None"
+					if v, ok := pyDefault(s, p.Schema); ok {
+						dflt = v
+					}
+				}
+
+				var args []string
+				if field != p.Name {
+					aliased = true
+					args = append(args, "alias="+strconv.Quote(p.Name))
+				}
+				if p.Schema.Description != "" {
+					args = append(args, "description="+strconv.Quote(p.Schema.Description))
+				}
+				switch {
+				case len(args) > 0:
+					usesField = true
+					fmt.Fprintf(&body, "    %s: %s = Field(%s)\n", field, typ, strings.Join(append([]string{dflt}, args...), ", "))
+				case required:
+					fmt.Fprintf(&body, "    %s: %s\n", field, typ)
+				default:
+					fmt.Fprintf(&body, "    %s: %s = %s\n", field, typ, dflt)
+				}
+			}
+
+			cfg := []string{}
+			if _, allowed := def.Schema.additional(); !allowed {
+				cfg = append(cfg, `extra="forbid"`)
+			}
+			if aliased {
+				cfg = append(cfg, "populate_by_name=True")
+			}
+			if len(cfg) > 0 {
+				fmt.Fprintf(&body, "\n    model_config = ConfigDict(%s)\n", strings.Join(cfg, ", "))
+			}
+
+		default:
+			typ := pyType(def.Schema)
+			usesAny = usesAny || strings.Contains(typ, "Any")
+			usesLiteral = usesLiteral || strings.Contains(typ, "Literal[")
+			fmt.Fprintf(&body, "%s = %s\n", def.Name, typ)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\"\"\"Auto-generated pydantic models from JSON Schema.\n\nDO NOT EDIT - regenerate with: make generate-python\nSource: %s\n\"\"\"\n\n", f.Path)
+	b.WriteString("from __future__ import annotations\n\n")
+	if usesEnum {
+		b.WriteString("from enum import Enum\n")
+	}
+	switch {
+	case usesAny && usesLiteral:
+		b.WriteString("from typing import Any, Literal\n")
+	case usesAny:
+		b.WriteString("from typing import Any\n")
+	case usesLiteral:
+		b.WriteString("from typing import Literal\n")
+	}
+	if usesEnum || usesAny || usesLiteral {
+		b.WriteString("\n")
+	}
+	if usesField {
+		b.WriteString("from pydantic import BaseModel, ConfigDict, Field\n")
+	} else {
+		b.WriteString("from pydantic import BaseModel, ConfigDict\n")
+	}
+	b.WriteString(body.String())
+	return b.String()
+}
+
+// pyType returns the Python type annotation for a schema.
+func pyType(s *schemaNode) string {
+	if s.Ref != "" {
+		return s.refName()
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = strconv.Quote(v)
+		}
+		return "Literal[" + strings.Join(values, ", ") + "]"
+	}
+
+	switch s.Type {
+	case "string":
+		return "str"
+	case "integer":
+		return "int"
+	case "number":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items == nil {
+			return "list[Any]"
+		}
+		return "list[" + pyType(s.Items) + "]"
+	case "object":
+		if ap, _ := s.additional(); ap != nil && len(s.Properties) == 0 {
+			return "dict[str, " + pyType(ap) + "]"
+		}
+		return "dict[str, Any]"
+	}
+	return "Any"
+}
+
+// pyDefault renders a property's schema default as a Python expression.
+func pyDefault(root, s *schemaNode) (string, bool) {
+	if len(s.Default) == 0 {
+		return "", false
+	}
+	var v interface{}
+	if err := json.Unmarshal(s.Default, &v); err != nil {
+		return "", false
+	}
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return "True", true
+		}
+		return "False", true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case string:
+		if s.Ref != "" {
+			for _, def := range root.Defs {
+				if def.Name == s.refName() && len(def.Schema.Enum) > 0 {
+					return s.refName() + "." + pyEnumMember(v), true
+				}
+			}
+		}
+		return strconv.Quote(v), true
+	}
+	return "", false
+}
+
+// pySortDefs orders definitions so that each model follows the models it
+// references, keeping file order otherwise.
+func pySortDefs(defs orderedSchemas) orderedSchemas {
+	byName := make(map[string]namedSchema, len(defs))
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+
+	var out orderedSchemas
+	visited := make(map[string]bool)
+	var visit func(d namedSchema)
+	visit = func(d namedSchema) {
+		if visited[d.Name] {
+			return
+		}
+		visited[d.Name] = true
+		for _, dep := range pyRefs(d.Schema) {
+			if target, ok := byName[dep]; ok {
+				visit(target)
+			}
+		}
+		out = append(out, d)
+	}
+	for _, d := range defs {
+		visit(d)
+	}
+	return out
+}
+
+func pyRefs(s *schemaNode) []string {
+	if s == nil {
+		return nil
+	}
+	var refs []string
+	if s.Ref != "" {
+		refs = append(refs, s.refName())
+	}
+	refs = append(refs, pyRefs(s.Items)...)
+	for _, p := range s.Properties {
+		refs = append(refs, pyRefs(p.Schema)...)
+	}
+	if ap, _ := s.additional(); ap != nil {
+		refs = append(refs, pyRefs(ap)...)
+	}
+	return refs
+}
+
+// pyFieldName converts a JSON property name to a snake_case field name.
+func pyFieldName(name string) string {
+	var b strings.Builder
+	runes := []rune(pyNonIdent.ReplaceAllString(name, "_"))
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteRune('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	field := strings.Trim(b.String(), "_")
+	if pyReserved[field] {
+		field += "_"
+	}
+	return field
+}
+
+// pyEnumMember converts an enum value to an UPPER_SNAKE member name.
+func pyEnumMember(v string) string {
+	name := strings.ToUpper(pyFieldName(v))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "V_" + name
+	}
+	return name
+}
+
+func pyDocstring(description, fallback string) string {
+	if description == "" {
+		description = fallback
+	}
+	return `"""` + strings.ReplaceAll(description, `"""`, `\"\"\"`) + `"""`
+}
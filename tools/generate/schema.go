@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// schemaFile describes a published JSON Schema that is used as the source
+// for language bindings.
+type schemaFile struct {
+	// Path is relative to the schema directory.
+	Path string
+	// Module is the base name of the generated source file.
+	Module string
+}
+
+// bindingSchemas are the schemas exported to non-Go SDKs.
+var bindingSchemas = []schemaFile{
+	{Path: "agent/agent.schema.json", Module: "agent"},
+	{Path: "orchestration/team.schema.json", Module: "team"},
+	{Path: "deployment/deployment.schema.json", Module: "deployment"},
+	{Path: "report/team-report.schema.json", Module: "report"},
+	{Path: "message/message.schema.json", Module: "message"},
+	{Path: "skill/skill.schema.json", Module: "skill"},
+	{Path: "tool/tool.schema.json", Module: "tool"},
+	{Path: "rubric/rubric.schema.json", Module: "rubric"},
+	{Path: "prompt/prompt.schema.json", Module: "prompt"},
+}
+
+// schemaNode is the subset of JSON Schema used by the published schemas.
+type schemaNode struct {
+	Ref                  string          `json:"$ref"`
+	Type                 string          `json:"type"`
+	Description          string          `json:"description"`
+	Enum                 []string        `json:"enum"`
+	Default              json.RawMessage `json:"default"`
+	Items                *schemaNode     `json:"items"`
+	Properties           orderedSchemas  `json:"properties"`
+	AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	Required             []string        `json:"required"`
+	Defs                 orderedSchemas  `json:"$defs"`
+}
+
+// namedSchema is a schema paired with its property or definition name.
+type namedSchema struct {
+	Name   string
+	Schema *schemaNode
+}
+
+// orderedSchemas preserves the declaration order of a JSON object of schemas
+// so generated code follows the order of the schema file.
+type orderedSchemas []namedSchema
+
+// UnmarshalJSON decodes a JSON object while keeping key order.
+func (o *orderedSchemas) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("expected object key, got %v", tok)
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("decoding %s: %w", name, err)
+		}
+		// Boolean schemas ("schema": true) accept any value
+		var s schemaNode
+		if t := string(bytes.TrimSpace(raw)); t != "true" && t != "false" {
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return fmt.Errorf("decoding %s: %w", name, err)
+			}
+		}
+		*o = append(*o, namedSchema{Name: name, Schema: &s})
+	}
+	return nil
+}
+
+// refName returns the definition name of a local "#/$defs/..." reference.
+func (s *schemaNode) refName() string {
+	return strings.TrimPrefix(s.Ref, "#/$defs/")
+}
+
+// isRequired reports whether the object schema requires property name.
+func (s *schemaNode) isRequired(name string) bool {
+	for _, r := range s.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// additional returns the schema for additionalProperties. The boolean
+// reports whether additional properties are allowed at all; a nil schema
+// with true means any value is allowed.
+func (s *schemaNode) additional() (*schemaNode, bool) {
+	raw := bytes.TrimSpace(s.AdditionalProperties)
+	switch string(raw) {
+	case "", "true":
+		return nil, true
+	case "false":
+		return nil, false
+	}
+	var ap schemaNode
+	if err := json.Unmarshal(raw, &ap); err != nil {
+		return nil, true
+	}
+	return &ap, true
+}
+
+// loadSchema reads and decodes a schema file.
+func loadSchema(schemaDir string, f schemaFile) (*schemaNode, error) {
+	path := filepath.Join(schemaDir, filepath.FromSlash(f.Path))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var s schemaNode
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// writeGenerated writes a generated source file, creating its directory.
+func writeGenerated(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing file %s: %w", path, err)
+	}
+	fmt.Printf("Generated: %s\n", path)
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// generateTypeScript writes one TypeScript module of interfaces per binding
+// schema into outputDir, plus an index re-exporting them.
+func generateTypeScript(schemaDir, outputDir string) error {
+	var index strings.Builder
+	index.WriteString("/**\n * Auto-generated TypeScript interfaces index.\n * DO NOT EDIT - regenerate with: make generate-types\n */\n\n")
+
+	for _, f := range bindingSchemas {
+		s, err := loadSchema(schemaDir, f)
+		if err != nil {
+			return err
+		}
+		src := renderTypeScript(f, s)
+		if err := writeGenerated(filepath.Join(outputDir, f.Module+".ts"), []byte(src)); err != nil {
+			return err
+		}
+		fmt.Fprintf(&index, "export * from './%s.js';\n", f.Module)
+	}
+
+	return writeGenerated(filepath.Join(outputDir, "index.ts"), []byte(index.String()))
+}
+
+func renderTypeScript(f schemaFile, s *schemaNode) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "/**\n * Auto-generated TypeScript interfaces from JSON Schema.\n * DO NOT EDIT - regenerate with: make generate-types\n * Source: %s\n */\n", f.Path)
+
+	for _, def := range s.Defs {
+		b.WriteString("\n")
+		writeTSDoc(&b, "", def.Schema.Description)
+		if def.Schema.Type == "object" && len(def.Schema.Properties) > 0 {
+			fmt.Fprintf(&b, "export interface %s {\n", def.Name)
+			for _, p := range def.Schema.Properties {
+				writeTSDoc(&b, "  ", p.Schema.Description)
+				opt := "?"
+				if def.Schema.isRequired(p.Name) {
+					opt = ""
+				}
+				fmt.Fprintf(&b, "  %s%s: %s;\n", tsPropertyName(p.Name), opt, tsType(p.Schema))
+			}
+			b.WriteString("}\n")
+			continue
+		}
+		fmt.Fprintf(&b, "export type %s = %s;\n", def.Name, tsType(def.Schema))
+	}
+	return b.String()
+}
+
+// tsType returns the TypeScript type expression for a schema.
+func tsType(s *schemaNode) string {
+	if s.Ref != "" {
+		return s.refName()
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = strconv.Quote(v)
+		}
+		return strings.Join(values, " | ")
+	}
+
+	switch s.Type {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		if s.Items == nil {
+			return "unknown[]"
+		}
+		item := tsType(s.Items)
+		if strings.Contains(item, " ") {
+			return "Array<" + item + ">"
+		}
+		return item + "[]"
+	case "object":
+		if len(s.Properties) > 0 {
+			var fields []string
+			for _, p := range s.Properties {
+				opt := "?"
+				if s.isRequired(p.Name) {
+					opt = ""
+				}
+				fields = append(fields, fmt.Sprintf("%s%s: %s", tsPropertyName(p.Name), opt, tsType(p.Schema)))
+			}
+			return "{ " + strings.Join(fields, "; ") + " }"
+		}
+		if ap, _ := s.additional(); ap != nil {
+			return "Record<string, " + tsType(ap) + ">"
+		}
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+func tsPropertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func writeTSDoc(b *strings.Builder, indent, description string) {
+	if description == "" {
+		return
+	}
+	description = strings.ReplaceAll(description, "*/", "*\\/")
+	lines := strings.Split(description, "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+		return
+	}
+	fmt.Fprintf(b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(b, "%s * %s\n", indent, line)
+	}
+	fmt.Fprintf(b, "%s */\n", indent)
+}